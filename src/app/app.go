@@ -3,6 +3,11 @@ package app
 import (
 	"fmt"
 	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/middlewares"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/tracing"
 	"github.com/development-raul/footy-predictor/src/zlog"
 
 	"github.com/gin-gonic/gin"
@@ -10,31 +15,58 @@ import (
 )
 
 type App struct {
-	FootyDB *sqlx.DB
-	Router  *gin.Engine
+	Config          *config.Config
+	FootyDB         *sqlx.DB
+	Router          *gin.Engine
+	CORS            middlewares.CORSConfig
+	SecurityHeaders middlewares.SecurityHeadersConfig
 }
 
-type Credentials struct {
-	DBUser  string
-	DBPass  string
-	DBHost  string
-	DBPort  string
-	DBName  string
-	AppPort string
+// Setup configures the logger, the tracing and the services, which get the authentication, API Sports and value bets
+// settings, and connects to the database. It is shared by the HTTP server and the CLI commands
+func Setup(cfg *config.Config) (*sqlx.DB, error) {
+	if err := zlog.Configure(cfg); err != nil {
+		return nil, err
+	}
+	if err := tracing.Configure(cfg.Tracing); err != nil {
+		return nil, err
+	}
+	services.Configure(cfg)
+
+	return footy_db.Connect(cfg.DB)
 }
 
-func StartApplication(c *Credentials) {
-	// Connect to DB
-	footyDB := footy_db.ConnectToDatabase(c.DBUser, c.DBPass, c.DBHost, c.DBPort, c.DBName)
+func StartApplication(cfg *config.Config) {
+	footyDB, err := Setup(cfg)
+	if err != nil {
+		zlog.Logger.Panicw("application setup failed", "error", err)
+	}
+	if err := metrics.RegisterDB(metrics.Registry, footyDB.DB, cfg.DB.Name); err != nil {
+		zlog.Logger.Panicw("failed to register the database metrics", "error", err)
+	}
 	application := &App{
-		FootyDB: footyDB,
-		Router:  gin.Default(),
+		Config:          cfg,
+		FootyDB:         footyDB,
+		Router:          gin.New(),
+		CORS:            corsConfig(cfg.CORS),
+		SecurityHeaders: middlewares.DefaultSecurityHeadersConfig(cfg.App.Env),
 	}
 
 	application.SetupRoutes()
 
-	if err := application.Router.Run(fmt.Sprintf(":%s", c.AppPort)); err != nil {
+	if err := application.Router.Run(fmt.Sprintf(":%s", cfg.App.Port)); err != nil {
 		zlog.Logger.Panicw("failed to start application", "error", err)
 	}
 	zlog.Logger.Infow("application started")
 }
+
+func corsConfig(cfg config.CORSConfig) middlewares.CORSConfig {
+	return middlewares.CORSConfig{
+		AllowedOrigins:   cfg.AllowedOrigins,
+		AllowedMethods:   cfg.AllowedMethods,
+		AllowedHeaders:   cfg.AllowedHeaders,
+		ExposedHeaders:   cfg.ExposedHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           cfg.MaxAge,
+	}
+}
@@ -2,6 +2,9 @@ package app
 
 import (
 	"github.com/development-raul/footy-predictor/src/controllers"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/middlewares"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,18 +14,20 @@ import (
 )
 
 func (app *App) SetupRoutes() {
-	// Adding CORS
-	app.Router.Use(func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "https://localhost:8080")
-		c.Header("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Max-Age", "86400")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, token, tableau_token")
-
-		c.Next()
-	})
+	// The request ID comes first so the access log and any panic are logged with it. CORS has to run before
+	// the routes are matched so preflight requests are answered even though no OPTIONS route is registered
+	app.Router.Use(
+		middlewares.RequestID(),
+		middlewares.Tracing(app.Config.Tracing.ServiceName),
+		middlewares.AccessLog(),
+		middlewares.Metrics(),
+		middlewares.Recovery(),
+		middlewares.SecurityHeaders(app.SecurityHeaders),
+		middlewares.CORS(app.CORS),
+	)
 
 	app.Router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	app.Router.GET("/metrics", gin.WrapH(metrics.Handler(metrics.Registry)))
 	app.Router.NoRoute(func(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Not found",
@@ -33,21 +38,132 @@ func (app *App) SetupRoutes() {
 	v1Routes := app.Router.Group("/v1")
 
 	v1Routes.GET("/", controllers.HealthController.Check)
-	countryGroup := v1Routes.Group("/countries")
-	{
-		countryGroup.POST("", controllers.CountryController.Create)
-		countryGroup.PUT("/:id", controllers.CountryController.Update)
-		countryGroup.GET("", controllers.CountryController.List)
-		countryGroup.GET("/:id", controllers.CountryController.Find)
-		countryGroup.DELETE("/:id", controllers.CountryController.Delete)
-		countryGroup.POST("/sync", controllers.CountryController.Sync)
-	}
-	seasonGroup := v1Routes.Group("/seasons")
-	{
-		seasonGroup.POST("", controllers.SeasonController.Create)
-		seasonGroup.GET("", controllers.SeasonController.List)
-		seasonGroup.GET("/:id", controllers.SeasonController.Find)
-		seasonGroup.DELETE("/:id", controllers.SeasonController.Delete)
-		seasonGroup.POST("/sync", controllers.SeasonController.Sync)
+	v1Routes.POST("/auth/token", controllers.AuthController.Token)
+	v1Routes.POST("/auth/login", controllers.AuthController.Login)
+	v1Routes.POST("/users/register", controllers.UserController.Register)
+
+	// Every route below requires authentication, reads need the reader role, mutations the editor role
+	// and syncs or key management the admin role
+	reader := middlewares.Authorize(auth.RoleReader)
+	editor := middlewares.Authorize(auth.RoleEditor)
+	admin := middlewares.Authorize(auth.RoleAdmin)
+
+	apiKeyGroup := v1Routes.Group("/api-keys", middlewares.Authenticate(), admin)
+	{
+		apiKeyGroup.POST("", controllers.ApiKeyController.Create)
+		apiKeyGroup.PUT("/:id", controllers.ApiKeyController.Update)
+		apiKeyGroup.GET("", controllers.ApiKeyController.List)
+		apiKeyGroup.GET("/:id", controllers.ApiKeyController.Find)
+		apiKeyGroup.DELETE("/:id", controllers.ApiKeyController.Delete)
+	}
+	countryGroup := v1Routes.Group("/countries", middlewares.Authenticate())
+	{
+		countryGroup.POST("", editor, controllers.CountryController.Create)
+		countryGroup.PUT("/:id", editor, controllers.CountryController.Update)
+		countryGroup.PATCH("/:id", editor, controllers.CountryController.Patch)
+		countryGroup.GET("", reader, controllers.CountryController.List)
+		countryGroup.GET("/export", reader, controllers.CountryController.Export)
+		countryGroup.POST("/import", editor, controllers.CountryController.Import)
+		countryGroup.GET("/:id", reader, controllers.CountryController.Find)
+		countryGroup.DELETE("/:id", editor, controllers.CountryController.Delete)
+		countryGroup.POST("/bulk", editor, controllers.CountryController.BulkCreate)
+		countryGroup.PUT("/bulk", editor, controllers.CountryController.BulkUpdate)
+		countryGroup.DELETE("/bulk", editor, controllers.CountryController.BulkDelete)
+		countryGroup.POST("/sync", admin, controllers.CountryController.Sync)
+	}
+	seasonGroup := v1Routes.Group("/seasons", middlewares.Authenticate())
+	{
+		seasonGroup.POST("", editor, controllers.SeasonController.Create)
+		seasonGroup.GET("", reader, controllers.SeasonController.List)
+		seasonGroup.GET("/export", reader, controllers.SeasonController.Export)
+		seasonGroup.POST("/import", editor, controllers.SeasonController.Import)
+		seasonGroup.GET("/current", reader, controllers.SeasonController.Current)
+		seasonGroup.GET("/:id", reader, controllers.SeasonController.Find)
+		seasonGroup.PATCH("/:id", editor, controllers.SeasonController.Patch)
+		seasonGroup.DELETE("/:id", editor, controllers.SeasonController.Delete)
+		seasonGroup.POST("/bulk", editor, controllers.SeasonController.BulkCreate)
+		seasonGroup.DELETE("/bulk", editor, controllers.SeasonController.BulkDelete)
+		seasonGroup.POST("/sync", admin, controllers.SeasonController.Sync)
+	}
+	leagueGroup := v1Routes.Group("/leagues", middlewares.Authenticate())
+	{
+		leagueGroup.GET("", reader, controllers.LeagueController.List)
+		leagueGroup.GET("/export", reader, controllers.LeagueController.Export)
+		leagueGroup.POST("/import", editor, controllers.LeagueController.Import)
+		leagueGroup.GET("/:id", reader, controllers.LeagueController.Find)
+		leagueGroup.GET("/:id/seasons/:season/simulation", reader, controllers.SimulationController.Simulate)
+		leagueGroup.GET("/:id/seasons/:season/bracket", reader, controllers.BracketController.Find)
+		leagueGroup.GET("/:id/seasons/:season/bracket/simulation", reader, controllers.BracketController.Simulate)
+		leagueGroup.POST("/sync", admin, controllers.LeagueController.Sync)
+	}
+	fixtureGroup := v1Routes.Group("/fixtures", middlewares.Authenticate())
+	{
+		fixtureGroup.GET("", reader, controllers.FixtureController.List)
+		fixtureGroup.GET("/export", reader, controllers.FixtureController.Export)
+		fixtureGroup.POST("/import", editor, controllers.FixtureController.Import)
+		fixtureGroup.GET("/:id", reader, controllers.FixtureController.Find)
+		fixtureGroup.GET("/:id/prediction", reader, controllers.PredictionController.Predict)
+		fixtureGroup.GET("/:id/odds", reader, controllers.OddController.Fixture)
+		fixtureGroup.GET("/:id/events", reader, controllers.FixtureDetailController.Events)
+		fixtureGroup.GET("/:id/lineups", reader, controllers.FixtureDetailController.Lineups)
+		fixtureGroup.GET("/:id/statistics", reader, controllers.FixtureDetailController.Statistics)
+		fixtureGroup.POST("/sync", admin, controllers.FixtureController.Sync)
+		fixtureGroup.POST("/details/sync", admin, controllers.FixtureDetailController.Sync)
+	}
+	oddGroup := v1Routes.Group("/odds", middlewares.Authenticate())
+	{
+		oddGroup.GET("", reader, controllers.OddController.List)
+		oddGroup.POST("/sync", admin, controllers.OddController.Sync)
+	}
+	playerGroup := v1Routes.Group("/players", middlewares.Authenticate())
+	{
+		playerGroup.GET("", reader, controllers.PlayerController.List)
+		playerGroup.GET("/top-scorers", reader, controllers.PlayerController.TopScorers)
+		playerGroup.GET("/top-assists", reader, controllers.PlayerController.TopAssists)
+		playerGroup.GET("/:id", reader, controllers.PlayerController.Find)
+		playerGroup.POST("/sync", admin, controllers.PlayerController.Sync)
+	}
+	teamGroup := v1Routes.Group("/teams", middlewares.Authenticate())
+	{
+		teamGroup.GET("/:id/squad", reader, controllers.PlayerController.Squad)
+		teamGroup.GET("/:id/unavailable", reader, controllers.InjuryController.Unavailable)
+	}
+	injuryGroup := v1Routes.Group("/injuries", middlewares.Authenticate())
+	{
+		injuryGroup.POST("/sync", admin, controllers.InjuryController.Sync)
+	}
+	v1Routes.GET("/value-bets", middlewares.Authenticate(), reader, controllers.ValueBetController.List)
+	backtestGroup := v1Routes.Group("/backtests", middlewares.Authenticate())
+	{
+		backtestGroup.POST("", admin, controllers.BacktestController.Create)
+		backtestGroup.GET("", reader, controllers.BacktestController.List)
+		backtestGroup.GET("/:id", reader, controllers.BacktestController.Find)
+		backtestGroup.GET("/:id/predictions", reader, controllers.BacktestController.Predictions)
+	}
+	modelGroup := v1Routes.Group("/models", middlewares.Authenticate())
+	{
+		modelGroup.POST("", admin, controllers.ModelController.Create)
+		modelGroup.GET("", reader, controllers.ModelController.List)
+		modelGroup.GET("/compare", reader, controllers.ModelController.Compare)
+		modelGroup.GET("/:id", reader, controllers.ModelController.Find)
+		modelGroup.PUT("/:id/champion", admin, controllers.ModelController.Champion)
+		modelGroup.GET("/:id/fit", reader, controllers.ModelController.Fit)
+	}
+
+	// Competitions belong to registered users, so besides the reader role the controllers
+	// require the caller to be authenticated as a user rather than with an API key
+	v1Routes.GET("/users/me", middlewares.Authenticate(), reader, controllers.UserController.Me)
+	competitionGroup := v1Routes.Group("/competitions", middlewares.Authenticate(), reader)
+	{
+		competitionGroup.POST("", controllers.CompetitionController.Create)
+		competitionGroup.PUT("/:id", controllers.CompetitionController.Update)
+		competitionGroup.GET("", controllers.CompetitionController.List)
+		competitionGroup.GET("/:id", controllers.CompetitionController.Find)
+		competitionGroup.DELETE("/:id", controllers.CompetitionController.Delete)
+		competitionGroup.POST("/:id/members", controllers.CompetitionController.Join)
+		competitionGroup.DELETE("/:id/members", controllers.CompetitionController.Leave)
+		competitionGroup.GET("/:id/leaderboard", controllers.CompetitionController.Leaderboard)
+		competitionGroup.PUT("/:id/predictions", controllers.UserPredictionController.Submit)
+		competitionGroup.GET("/:id/predictions", controllers.UserPredictionController.List)
 	}
 }
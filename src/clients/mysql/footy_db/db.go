@@ -2,9 +2,9 @@ package footy_db
 
 import (
 	"fmt"
+	"github.com/development-raul/footy-predictor/src/config"
 	"github.com/development-raul/footy-predictor/src/zlog"
 	"github.com/jmoiron/sqlx"
-	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
@@ -14,16 +14,25 @@ var (
 	Client *sqlx.DB
 )
 
-func ConnectToDatabase(dbUser, dbPass, dbHost, dbPort, dbName string) *sqlx.DB {
-	var err error
-	connectionString := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", dbUser, dbPass, dbHost, dbPort, dbName)
-	Client, err = sqlx.Connect("mysql", connectionString)
+// Connect opens the database connection, sets the pool sizes and assigns it to Client
+func Connect(cfg config.DBConfig) (*sqlx.DB, error) {
+	connectionString := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", cfg.User, cfg.Pass, cfg.Host, cfg.Port, cfg.Name)
+	db, err := sqlx.Connect("mysql", connectionString)
 	if err != nil {
-		zlog.Logger.Panicw("database connection failed", "error", err)
+		return nil, err
 	}
-	Client.SetMaxIdleConns(50)
-	Client.SetMaxOpenConns(100)
-	Client.SetConnMaxLifetime(time.Second * 10)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	Client = db
+	return Client, nil
+}
 
-	return Client
+func ConnectToDatabase(cfg config.DBConfig) *sqlx.DB {
+	db, err := Connect(cfg)
+	if err != nil {
+		zlog.Logger.Panicw("database connection failed", "error", err)
+	}
+	return db
 }
@@ -1,6 +1,11 @@
 package footy_db
 
 import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/jmoiron/sqlx"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,6 +13,76 @@ import (
 
 func TestConnectToDatabase(t *testing.T) {
 	assert.Panics(t, func() {
-		ConnectToDatabase("", "", "", "", "")
+		ConnectToDatabase(config.DBConfig{})
 	})
 }
+
+func TestConnect(t *testing.T) {
+	db, err := Connect(config.DBConfig{})
+	assert.Nil(t, db)
+	assert.NotNil(t, err)
+}
+
+func TestTransaction(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		fn          func(ctx context.Context) error
+		expectedErr error
+	}{
+		{
+			title: "error Begin",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin().WillReturnError(errors.New("error Begin"))
+			},
+			fn:          func(ctx context.Context) error { return nil },
+			expectedErr: errors.New("error Begin"),
+		},
+		{
+			title: "error fn rolls back",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM seasons").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectRollback()
+			},
+			fn: func(ctx context.Context) error {
+				if _, err := DB(ctx).ExecContext(ctx, "DELETE FROM seasons"); err != nil {
+					return err
+				}
+				return errors.New("error fn")
+			},
+			expectedErr: errors.New("error fn"),
+		},
+		{
+			title: "success commits",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM seasons").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			fn: func(ctx context.Context) error {
+				_, err := DB(ctx).ExecContext(ctx, "DELETE FROM seasons")
+				return err
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = Transaction(context.Background(), testCase.fn)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Nil(t, mock.ExpectationsWereMet())
+			assert.Equal(t, Client, DB(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package footy_db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+)
+
+// Conn is implemented by the connection pool and by transactions, the DAOs that can run inside a transaction
+// get it from DB
+type Conn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+type txKey struct{}
+
+// DB returns the transaction started by Transaction for ctx, or Client outside of a transaction
+func DB(ctx context.Context) Conn {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return Client
+}
+
+// Transaction runs fn in a transaction which is committed when fn returns nil and rolled back otherwise. The
+// queries made through DB with the context passed to fn use the transaction
+func Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := Client.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -2,10 +2,16 @@ package restclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/development-raul/footy-predictor/src/tracing"
 	"github.com/development-raul/footy-predictor/src/zlog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
 	"net/http"
 	"net/url"
 	"strings"
@@ -43,7 +49,7 @@ func AddMockup(mock Mock) {
 	mocks[getMockId(mock.HttpMethod, mock.Url)] = &mock
 }
 
-func Post(url string, body interface{}, headers http.Header) (*http.Response, error) {
+func Post(ctx context.Context, url string, body interface{}, headers http.Header) (*http.Response, error) {
 	if enabledMocks {
 		mock := mocks[getMockId(http.MethodPost, url)]
 		if mock == nil {
@@ -56,18 +62,17 @@ func Post(url string, body interface{}, headers http.Header) (*http.Response, er
 	if err != nil {
 		return nil, err
 	}
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(jsonBytes))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonBytes))
 	if err != nil {
-		zlog.Logger.Errorw("RestClient Post NewRequest", err)
+		zlog.FromContext(ctx).Errorw("RestClient Post NewRequest", "error", err)
 		return nil, err
 	}
 	request.Header = headers
 
-	client := http.Client{}
-	return client.Do(request)
+	return do(request)
 }
 
-func Get(url string, headers http.Header) (*http.Response, error) {
+func Get(ctx context.Context, url string, headers http.Header) (*http.Response, error) {
 	if enabledMocks {
 		mock := mocks[getMockId(http.MethodGet, url)]
 		if mock == nil {
@@ -76,18 +81,17 @@ func Get(url string, headers http.Header) (*http.Response, error) {
 		return mock.Response, mock.Err
 	}
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		zlog.Logger.Errorw("RestClient Get NewRequest", err)
+		zlog.FromContext(ctx).Errorw("RestClient Get NewRequest", "error", err)
 		return nil, err
 	}
 	request.Header = headers
 
-	client := http.Client{}
-	return client.Do(request)
+	return do(request)
 }
 
-func PostForm(url string, data url.Values, headers http.Header) (*http.Response, error) {
+func PostForm(ctx context.Context, url string, data url.Values, headers http.Header) (*http.Response, error) {
 	if enabledMocks {
 		mock := mocks[getMockId(http.MethodPost, url)]
 		if mock == nil {
@@ -96,13 +100,37 @@ func PostForm(url string, data url.Values, headers http.Header) (*http.Response,
 		return mock.Response, mock.Err
 	}
 
-	request, err := http.NewRequest(http.MethodPost, url, strings.NewReader(data.Encode()))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(data.Encode()))
 	if err != nil {
-		zlog.Logger.Errorw("RestClient PostForm NewRequest", err)
+		zlog.FromContext(ctx).Errorw("RestClient PostForm NewRequest", "error", err)
 		return nil, err
 	}
 	request.Header = headers
 
+	return do(request)
+}
+
+// do sends the request in a client span and propagates the trace context to the called service
+func do(request *http.Request) (*http.Response, error) {
+	ctx, span := tracing.Start(request.Context(), "HTTP "+request.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		tracing.Attributes(semconv.HTTPClientAttributesFromHTTPRequest(request)...),
+	)
+	defer span.End()
+
+	request = request.WithContext(ctx)
+	if request.Header == nil {
+		request.Header = http.Header{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.Header))
+
 	client := http.Client{}
-	return client.Do(request)
+	res, err := client.Do(request)
+	if err != nil {
+		tracing.Fail(span, err)
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(res.StatusCode))
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(res.StatusCode))
+	return res, nil
 }
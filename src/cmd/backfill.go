@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/urfave/cli/v2"
+	"strings"
+)
+
+type backfillResult struct {
+	LeagueID int64  `json:"league_id"`
+	Season   int64  `json:"season"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (r *runner) backfillCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "backfill",
+		Usage: "import the fixtures of a range of seasons",
+		Flags: []cli.Flag{
+			&cli.Int64Flag{Name: "from", Usage: "first season year", Required: true},
+			&cli.Int64Flag{Name: "to", Usage: "last season year", Required: true},
+			&cli.Int64SliceFlag{Name: "league", Usage: "league id, can be repeated", Required: true},
+		},
+		Action: r.backfill,
+	}
+}
+
+// backfill syncs every league season in the range, a failed season does not stop the others
+// but makes the command exit with ExitFailure
+func (r *runner) backfill(c *cli.Context) error {
+	from, to := c.Int64("from"), c.Int64("to")
+	if from > to {
+		return cli.Exit("--from must not be after --to", ExitUsage)
+	}
+	if err := r.setup(c); err != nil {
+		return err
+	}
+
+	var results []backfillResult
+	var lines []string
+	failed := 0
+	for _, leagueID := range c.Int64Slice("league") {
+		for season := from; season <= to; season++ {
+			res := backfillResult{LeagueID: leagueID, Season: season, Status: "ok"}
+			if apiErr := services.FixtureService.Sync(c.Context, &fixtures.SyncFixtureInput{LeagueID: leagueID, Season: season}); apiErr != nil {
+				res.Status = "failed"
+				res.Error = fmt.Sprint(apiErr.Error())
+				failed++
+			}
+			results = append(results, res)
+			line := fmt.Sprintf("league %d season %d: %s", leagueID, season, res.Status)
+			if res.Error != "" {
+				line += " (" + res.Error + ")"
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if err := r.print(strings.Join(lines, "\n"), results); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return cli.Exit(fmt.Sprintf("%d of %d league seasons failed", failed, len(results)), ExitFailure)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/domains/backtests"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/urfave/cli/v2"
+)
+
+func (r *runner) backtestCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "backtest",
+		Usage: "evaluate a model on the finished fixtures of a range of seasons",
+		Flags: []cli.Flag{
+			&cli.Int64Flag{Name: "league", Usage: "league id", Required: true},
+			&cli.Int64Flag{Name: "from", Usage: "first season year", Required: true},
+			&cli.Int64Flag{Name: "to", Usage: "last season year", Required: true},
+			&cli.StringFlag{Name: "model", Usage: "model version e.g. dixon-coles@3, or a name for its latest version, the champion by default"},
+			&cli.Float64Flag{Name: "min-edge", Usage: "minimum edge of the simulated bets, the value bets edge by default"},
+		},
+		Action: r.backtest,
+	}
+}
+
+// backtest runs the backtest before returning, unlike the API which runs it in the background. The run is
+// saved either way so it can be compared with the others through the API
+func (r *runner) backtest(c *cli.Context) error {
+	req := backtests.CreateBacktestInput{
+		LeagueID:   c.Int64("league"),
+		SeasonFrom: c.Int64("from"),
+		SeasonTo:   c.Int64("to"),
+		Model:      c.String("model"),
+	}
+	if req.SeasonFrom > req.SeasonTo {
+		return cli.Exit("--from must not be after --to", ExitUsage)
+	}
+	if c.IsSet("min-edge") {
+		minEdge := c.Float64("min-edge")
+		req.MinEdge = &minEdge
+	}
+	if err := r.setup(c); err != nil {
+		return err
+	}
+
+	res, apiErr := services.BacktestService.Run(c.Context, &req)
+	if apiErr != nil {
+		return apiError(apiErr)
+	}
+	return r.print(describeBacktest(res), res)
+}
+
+func describeBacktest(b *backtests.Backtest) string {
+	bets := "no bet"
+	if b.ROI != nil {
+		bets = fmt.Sprintf("%d, profit %.2f (ROI %.1f%%)", b.Bets, b.Profit, *b.ROI*100)
+	}
+	return fmt.Sprintf("backtest %d league %d seasons %d-%d (%s)\n"+
+		"fixtures          %d (%d skipped)\n"+
+		"log-loss          %.4f\n"+
+		"brier score       %.4f\n"+
+		"ranked prob score %.4f\n"+
+		"accuracy          %.1f%%\n"+
+		"bets              %s",
+		b.ID, b.LeagueID, b.SeasonFrom, b.SeasonTo, b.Model,
+		b.Fixtures, b.Skipped,
+		*b.LogLoss, *b.Brier, *b.RPS, *b.Accuracy*100,
+		bets)
+}
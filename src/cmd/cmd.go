@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/app"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/urfave/cli/v2"
+	"io"
+	"net/http"
+)
+
+// Exit codes returned by the CLI so cron jobs and scripts can react to failures
+const (
+	ExitOK = 0
+	// ExitFailure is returned when the command could not complete, e.g. the database or API Sports failed
+	ExitFailure = 1
+	// ExitUsage is returned for invalid arguments, including ids that do not exist
+	ExitUsage = 2
+	// ExitConfig is returned when the configuration could not be loaded or is invalid
+	ExitConfig = 3
+)
+
+const (
+	outputHuman = "human"
+	outputJSON  = "json"
+)
+
+// configFlags are forwarded to config.Load, which applies them on top of the file and environment values
+var configFlags = []string{"config", "env", "port", "db-host", "db-port", "db-name", "db-user", "log-file", "log-level", "log-format"}
+
+type runner struct {
+	stdout io.Writer
+	stderr io.Writer
+	output string
+	// setup loads the configuration and connects to the database, tests replace it
+	setup func(c *cli.Context) error
+}
+
+// Run executes the CLI with the given arguments and returns the process exit code
+func Run(args []string, stdout io.Writer, stderr io.Writer) int {
+	r := &runner{stdout: stdout, stderr: stderr, output: outputHuman}
+	r.setup = r.connect
+	return r.run(args)
+}
+
+func (r *runner) run(args []string) int {
+	err := r.newApp().Run(args)
+	// Batched spans are only exported in the background, flush them before the process exits
+	if shutdownErr := tracing.Shutdown(context.Background()); shutdownErr != nil {
+		fmt.Fprintln(r.stderr, "error: flushing traces:", shutdownErr)
+	}
+	if err == nil {
+		return ExitOK
+	}
+
+	code := ExitUsage
+	if exitErr, ok := err.(cli.ExitCoder); ok {
+		code = exitErr.ExitCode()
+	}
+	r.printError(err.Error(), code)
+	return code
+}
+
+func (r *runner) newApp() *cli.App {
+	flags := []cli.Flag{
+		&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Value: outputHuman, Usage: "output format: human or json"},
+		&cli.StringFlag{Name: "config", Usage: "path to the YAML config file"},
+		&cli.StringFlag{Name: "env", Usage: "application environment"},
+		&cli.StringFlag{Name: "port", Usage: "HTTP port"},
+		&cli.StringFlag{Name: "db-host", Usage: "database host"},
+		&cli.StringFlag{Name: "db-port", Usage: "database port"},
+		&cli.StringFlag{Name: "db-name", Usage: "database name"},
+		&cli.StringFlag{Name: "db-user", Usage: "database user"},
+		&cli.StringFlag{Name: "log-file", Usage: "log file path"},
+		&cli.StringFlag{Name: "log-level", Usage: "log level: debug, info, warn or error"},
+		&cli.StringFlag{Name: "log-format", Usage: "log format: json or console"},
+	}
+
+	return &cli.App{
+		Name:      "footy-predictor",
+		HelpName:  "footy-predictor",
+		Usage:     "Football predictor API and maintenance commands",
+		Writer:    r.stdout,
+		ErrWriter: r.stderr,
+		Flags:     flags,
+		Before: func(c *cli.Context) error {
+			r.output = c.String("output")
+			if r.output != outputHuman && r.output != outputJSON {
+				return cli.Exit("invalid --output, expected human or json", ExitUsage)
+			}
+			return nil
+		},
+		// Errors are printed and converted to exit codes by run, never exit from inside the app
+		ExitErrHandler: func(c *cli.Context, err error) {},
+		// Starting without a command keeps the previous behaviour of the binary
+		Action: r.serve,
+		Commands: []*cli.Command{
+			r.serveCommand(),
+			r.syncCommand(),
+			r.migrateCommand(),
+			r.predictCommand(),
+			r.backfillCommand(),
+			r.exportCommand(),
+			r.backtestCommand(),
+		},
+	}
+}
+
+// loadConfig builds the configuration from the config file, the environment and the global flags
+func loadConfig(c *cli.Context) (*config.Config, error) {
+	var args []string
+	for _, name := range configFlags {
+		if c.IsSet(name) {
+			args = append(args, "-"+name, c.String(name))
+		}
+	}
+	cfg, err := config.Load(args)
+	if err != nil {
+		return nil, cli.Exit(err.Error(), ExitConfig)
+	}
+	return cfg, nil
+}
+
+func (r *runner) connect(c *cli.Context) error {
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+	if _, err := app.Setup(cfg); err != nil {
+		return cli.Exit(fmt.Sprintf("setup failed: %v", err), ExitFailure)
+	}
+	return nil
+}
+
+// print writes the human message or the JSON encoded data depending on the --output flag
+func (r *runner) print(human string, data interface{}) error {
+	if r.output == outputJSON {
+		enc := json.NewEncoder(r.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	}
+	_, err := fmt.Fprintln(r.stdout, human)
+	return err
+}
+
+func (r *runner) printError(message string, code int) {
+	if r.output == outputJSON {
+		_ = json.NewEncoder(r.stderr).Encode(map[string]interface{}{
+			"error": message,
+			"code":  code,
+		})
+		return
+	}
+	fmt.Fprintln(r.stderr, "error:", message)
+}
+
+// apiError converts a service error into an exit error, client errors are reported as usage errors
+func apiError(err resterror.RestErrorI) error {
+	code := ExitFailure
+	if err.Code() >= http.StatusBadRequest && err.Code() < http.StatusInternalServerError {
+		code = ExitUsage
+	}
+	return cli.Exit(fmt.Sprint(err.Error()), code)
+}
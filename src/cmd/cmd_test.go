@@ -0,0 +1,480 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/backtests"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_events"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_lineups"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_statistics"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/injuries"
+	"github.com/development-raul/footy-predictor/src/domains/leagues"
+	"github.com/development-raul/footy-predictor/src/domains/odds"
+	"github.com/development-raul/footy-predictor/src/domains/players"
+	"github.com/development-raul/footy-predictor/src/domains/predictions"
+	"github.com/development-raul/footy-predictor/src/domains/squads"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockFixtureService struct {
+	FuncList func(req *fixtures.ListFixtureInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	FuncSync func(req *fixtures.SyncFixtureInput) resterror.RestErrorI
+}
+
+func (m MockFixtureService) Find(ctx context.Context, id int64) (*fixtures.Fixture, resterror.RestErrorI) {
+	return nil, resterror.NewNotFoundError("FIXTURE_NOT_FOUND")
+}
+func (m MockFixtureService) List(ctx context.Context, req *fixtures.ListFixtureInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncList(req)
+}
+func (m MockFixtureService) Export(ctx context.Context, req *fixtures.ListFixtureInput, w export.Writer) resterror.RestErrorI {
+	return nil
+}
+func (m MockFixtureService) Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI) {
+	return &importer.Report{}, nil
+}
+func (m MockFixtureService) Sync(ctx context.Context, req *fixtures.SyncFixtureInput) resterror.RestErrorI {
+	return m.FuncSync(req)
+}
+
+type MockLeagueService struct {
+	FuncSync func() resterror.RestErrorI
+}
+
+func (m MockLeagueService) Find(ctx context.Context, id int64) (*leagues.League, resterror.RestErrorI) {
+	return nil, resterror.NewNotFoundError("LEAGUE_NOT_FOUND")
+}
+func (m MockLeagueService) List(ctx context.Context, req *leagues.ListLeagueInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	res := pagination.GeneratePaginatedResponse([]leagues.League{}, req.Page, req.PerPage, 0)
+	return &res, nil
+}
+func (m MockLeagueService) Export(ctx context.Context, req *leagues.ListLeagueInput, w export.Writer) resterror.RestErrorI {
+	return nil
+}
+func (m MockLeagueService) Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI) {
+	return &importer.Report{}, nil
+}
+func (m MockLeagueService) Sync(ctx context.Context) resterror.RestErrorI {
+	return m.FuncSync()
+}
+
+type MockOddService struct {
+	FuncSync func(req *odds.SyncOddInput) resterror.RestErrorI
+}
+
+func (m MockOddService) List(ctx context.Context, req *odds.ListOddInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockOddService) Fixture(ctx context.Context, req *odds.LatestOddInput) ([]odds.MarketOdds, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockOddService) Sync(ctx context.Context, req *odds.SyncOddInput) resterror.RestErrorI {
+	return m.FuncSync(req)
+}
+
+type MockPlayerService struct {
+	FuncSync func(req *players.SyncPlayerInput) resterror.RestErrorI
+}
+
+func (m MockPlayerService) Find(ctx context.Context, id int64) (*players.PlayerOutput, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockPlayerService) List(ctx context.Context, req *players.ListPlayerInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockPlayerService) Leaders(ctx context.Context, req *players.ListLeaderInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockPlayerService) Squad(ctx context.Context, teamID int64) ([]squads.SquadPlayer, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockPlayerService) Sync(ctx context.Context, req *players.SyncPlayerInput) resterror.RestErrorI {
+	return m.FuncSync(req)
+}
+
+type MockInjuryService struct {
+	FuncSync func(req *injuries.SyncInjuryInput) resterror.RestErrorI
+}
+
+func (m MockInjuryService) Unavailable(ctx context.Context, teamID int64) ([]injuries.Unavailable, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockInjuryService) Sync(ctx context.Context, req *injuries.SyncInjuryInput) resterror.RestErrorI {
+	return m.FuncSync(req)
+}
+
+type MockFixtureDetailService struct {
+	FuncSyncMissing func(req *fixtures.SyncDetailsInput) resterror.RestErrorI
+}
+
+func (m MockFixtureDetailService) Events(ctx context.Context, fixtureID int64) ([]fixture_events.Event, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockFixtureDetailService) Lineups(ctx context.Context, fixtureID int64) ([]fixture_lineups.Lineup, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockFixtureDetailService) Statistics(ctx context.Context, fixtureID int64) ([]fixture_statistics.TeamStatistic, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockFixtureDetailService) Sync(ctx context.Context, fixtureID int64) resterror.RestErrorI {
+	return nil
+}
+func (m MockFixtureDetailService) SyncMissing(ctx context.Context, req *fixtures.SyncDetailsInput) resterror.RestErrorI {
+	return m.FuncSyncMissing(req)
+}
+
+type MockPredictionService struct {
+	FuncPredict func(fixtureID int64, model string) (*predictions.Prediction, resterror.RestErrorI)
+}
+
+func (m MockPredictionService) Predict(ctx context.Context, fixtureID int64, model string) (*predictions.Prediction, resterror.RestErrorI) {
+	return m.FuncPredict(fixtureID, model)
+}
+
+type MockBacktestService struct {
+	FuncRun func(req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI)
+}
+
+func (m MockBacktestService) Create(ctx context.Context, req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockBacktestService) Run(ctx context.Context, req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI) {
+	return m.FuncRun(req)
+}
+func (m MockBacktestService) Find(ctx context.Context, id int64) (*backtests.Backtest, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockBacktestService) List(ctx context.Context, req *backtests.ListBacktestInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockBacktestService) Predictions(ctx context.Context, req *backtests.ListPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return nil, nil
+}
+
+// testRun runs the CLI without loading the configuration or connecting to the database
+func testRun(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	r := &runner{stdout: &stdout, stderr: &stderr, output: outputHuman}
+	r.setup = func(c *cli.Context) error { return nil }
+	code := r.run(append([]string{"footy-predictor"}, args...))
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	goals := func(v int64) *int64 { return &v }
+	fixturePage := func(page int64, data []fixtures.Fixture) *pagination.PaginatedResponse {
+		res := pagination.GeneratePaginatedResponse(data, page, 1, 2)
+		return &res
+	}
+
+	services.LeagueService = &MockLeagueService{
+		FuncSync: func() resterror.RestErrorI {
+			return resterror.NewStandardInternalServerError()
+		},
+	}
+	services.OddService = &MockOddService{
+		FuncSync: func(req *odds.SyncOddInput) resterror.RestErrorI {
+			return nil
+		},
+	}
+	services.PlayerService = &MockPlayerService{
+		FuncSync: func(req *players.SyncPlayerInput) resterror.RestErrorI {
+			return nil
+		},
+	}
+	services.InjuryService = &MockInjuryService{
+		FuncSync: func(req *injuries.SyncInjuryInput) resterror.RestErrorI {
+			return nil
+		},
+	}
+	services.FixtureDetailService = &MockFixtureDetailService{
+		FuncSyncMissing: func(req *fixtures.SyncDetailsInput) resterror.RestErrorI {
+			return nil
+		},
+	}
+	services.PredictionService = &MockPredictionService{
+		FuncPredict: func(fixtureID int64, model string) (*predictions.Prediction, resterror.RestErrorI) {
+			if fixtureID != 10 {
+				return nil, resterror.NewNotFoundError("FIXTURE_NOT_FOUND")
+			}
+			if model == "" {
+				model = "poisson@1"
+			}
+			return &predictions.Prediction{
+				FixtureID:         10,
+				Model:             model,
+				HomeExpectedGoals: 1.5,
+				AwayExpectedGoals: 1,
+				HomeWin:           0.5,
+				Draw:              0.25,
+				AwayWin:           0.25,
+				MostLikelyScore:   predictions.Score{HomeGoals: 1, AwayGoals: 1, Probability: 0.12},
+				Markets: predictions.Markets{
+					OverUnder:      []predictions.GoalLine{{Line: 1.5, Over: 0.71, Under: 0.29}, {Line: 2.5, Over: 0.46, Under: 0.54}},
+					BothTeamsScore: predictions.BothTeamsScore{Yes: 0.48, No: 0.52},
+				},
+				MatchesUsed: 380,
+			}, nil
+		},
+	}
+	services.BacktestService = &MockBacktestService{
+		FuncRun: func(req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI) {
+			if req.LeagueID != 39 {
+				return nil, resterror.NewUnprocessableEntityError("NOT_ENOUGH_DATA")
+			}
+			if req.Model != "" {
+				return nil, resterror.NewNotFoundError("MODEL_NOT_FOUND")
+			}
+			logLoss, brier, rps, accuracy, roi := 0.98, 0.58, 0.2, 0.5, 0.036
+			res := &backtests.Backtest{
+				ID:         3,
+				LeagueID:   39,
+				SeasonFrom: req.SeasonFrom,
+				SeasonTo:   req.SeasonTo,
+				Model:      "poisson@1",
+				Status:     backtests.StatusFinished,
+				Fixtures:   760,
+				Skipped:    10,
+				LogLoss:    &logLoss,
+				Brier:      &brier,
+				RPS:        &rps,
+				Accuracy:   &accuracy,
+			}
+			if req.MinEdge != nil {
+				res.Bets, res.Staked, res.Profit, res.ROI = 120, 120, 4.32, &roi
+			}
+			return res, nil
+		},
+	}
+	services.FixtureService = &MockFixtureService{
+		FuncSync: func(req *fixtures.SyncFixtureInput) resterror.RestErrorI {
+			if req.Season == 2020 {
+				return resterror.NewStandardInternalServerError()
+			}
+			return nil
+		},
+		FuncList: func(req *fixtures.ListFixtureInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+			kickoff := time.Date(2022, 1, 15, 15, 0, 0, 0, time.UTC)
+			if req.Page == 1 {
+				return fixturePage(1, []fixtures.Fixture{{ID: 1, LeagueID: req.LeagueID, KickoffAt: kickoff, Status: "FT", HomeGoals: goals(2), AwayGoals: goals(0)}}), nil
+			}
+			return fixturePage(2, []fixtures.Fixture{{ID: 2, LeagueID: req.LeagueID, KickoffAt: kickoff, Status: "NS"}}), nil
+		},
+	}
+
+	testCases := []struct {
+		title          string
+		args           []string
+		expectedCode   int
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			title:          "error invalid output",
+			args:           []string{"-o", "xml", "predict", "--fixture", "10"},
+			expectedCode:   ExitUsage,
+			expectedStderr: "error: invalid --output, expected human or json\n",
+		},
+		{
+			title:        "error missing required flag",
+			args:         []string{"predict"},
+			expectedCode: ExitUsage,
+			expectedStdout: "NAME:\n   footy-predictor predict - forecast the result of a fixture\n\n" +
+				"USAGE:\n   footy-predictor predict [command options] [arguments...]\n\n" +
+				"OPTIONS:\n   --fixture value  fixture id (default: 0)\n   --model value    model version e.g. dixon-coles@3, or a name for its latest version, the champion by default\n   --help, -h       show help (default: false)\n   \n",
+			expectedStderr: "error: Required flag \"fixture\" not set\n",
+		},
+		{
+			title:          "error predict fixture not found",
+			args:           []string{"--output", "json", "predict", "--fixture", "11"},
+			expectedCode:   ExitUsage,
+			expectedStderr: `{"code":2,"error":"FIXTURE_NOT_FOUND"}` + "\n",
+		},
+		{
+			title:        "success predict",
+			args:         []string{"predict", "--fixture", "10"},
+			expectedCode: ExitOK,
+			expectedStdout: "fixture 10 (poisson@1, 380 matches)\n" +
+				"expected goals     1.50 - 1.00\n" +
+				"home / draw / away 50.0% / 25.0% / 25.0%\n" +
+				"most likely score  1-1 (12.0%)\n" +
+				"over 2.5 / btts    46.0% / 48.0%\n",
+		},
+		{
+			title:        "success predict with model",
+			args:         []string{"predict", "--fixture", "10", "--model", "dixon-coles@3"},
+			expectedCode: ExitOK,
+			expectedStdout: "fixture 10 (dixon-coles@3, 380 matches)\n" +
+				"expected goals     1.50 - 1.00\n" +
+				"home / draw / away 50.0% / 25.0% / 25.0%\n" +
+				"most likely score  1-1 (12.0%)\n" +
+				"over 2.5 / btts    46.0% / 48.0%\n",
+		},
+		{
+			title:          "error sync leagues",
+			args:           []string{"sync", "leagues"},
+			expectedCode:   ExitFailure,
+			expectedStderr: "error: Something went wrong. Please try again later.\n",
+		},
+		{
+			title:          "success sync fixtures",
+			args:           []string{"-o", "json", "sync", "fixtures", "--league", "39", "--season", "2021"},
+			expectedCode:   ExitOK,
+			expectedStdout: "{\n  \"resource\": \"fixtures\",\n  \"league_id\": 39,\n  \"season\": 2021,\n  \"status\": \"ok\"\n}\n",
+		},
+		{
+			title:          "error sync odds without fixture or league season",
+			args:           []string{"sync", "odds", "--league", "39"},
+			expectedCode:   ExitUsage,
+			expectedStderr: "error: --fixture or --league and --season are required\n",
+		},
+		{
+			title:          "success sync odds of a fixture",
+			args:           []string{"sync", "odds", "--fixture", "10", "--bookmaker", "8"},
+			expectedCode:   ExitOK,
+			expectedStdout: "odds synced for fixture 10\n",
+		},
+		{
+			title:          "success sync odds of a league season",
+			args:           []string{"-o", "json", "sync", "odds", "--league", "39", "--season", "2021"},
+			expectedCode:   ExitOK,
+			expectedStdout: "{\n  \"resource\": \"odds\",\n  \"league_id\": 39,\n  \"season\": 2021,\n  \"status\": \"ok\"\n}\n",
+		},
+		{
+			title:          "error sync players without team or league season",
+			args:           []string{"sync", "players", "--season", "2021"},
+			expectedCode:   ExitUsage,
+			expectedStderr: "error: --team or --league and --season are required\n",
+		},
+		{
+			title:          "success sync squad of a team",
+			args:           []string{"-o", "json", "sync", "players", "--team", "85"},
+			expectedCode:   ExitOK,
+			expectedStdout: "{\n  \"resource\": \"players\",\n  \"team_id\": 85,\n  \"status\": \"ok\"\n}\n",
+		},
+		{
+			title:          "success sync players of a league season",
+			args:           []string{"sync", "players", "--league", "61", "--season", "2021"},
+			expectedCode:   ExitOK,
+			expectedStdout: "players synced for league 61 season 2021\n",
+		},
+		{
+			title:          "error sync injuries without fixture or league season",
+			args:           []string{"sync", "injuries", "--league", "61"},
+			expectedCode:   ExitUsage,
+			expectedStderr: "error: --fixture or --league and --season are required\n",
+		},
+		{
+			title:          "success sync injuries of a fixture",
+			args:           []string{"-o", "json", "sync", "injuries", "--fixture", "10"},
+			expectedCode:   ExitOK,
+			expectedStdout: "{\n  \"resource\": \"injuries\",\n  \"fixture_id\": 10,\n  \"status\": \"ok\"\n}\n",
+		},
+		{
+			title:          "error sync details without fixture or league season",
+			args:           []string{"sync", "details", "--season", "2021"},
+			expectedCode:   ExitUsage,
+			expectedStderr: "error: --fixture or --league and --season are required\n",
+		},
+		{
+			title:          "success sync details of a league season",
+			args:           []string{"sync", "details", "--league", "39", "--season", "2021"},
+			expectedCode:   ExitOK,
+			expectedStdout: "details synced for league 39 season 2021\n",
+		},
+		{
+			title:          "error backfill invalid range",
+			args:           []string{"backfill", "--from", "2021", "--to", "2020", "--league", "39"},
+			expectedCode:   ExitUsage,
+			expectedStderr: "error: --from must not be after --to\n",
+		},
+		{
+			title:        "error backfill partial failure",
+			args:         []string{"backfill", "--from", "2020", "--to", "2021", "--league", "39"},
+			expectedCode: ExitFailure,
+			expectedStdout: "league 39 season 2020: failed (Something went wrong. Please try again later.)\n" +
+				"league 39 season 2021: ok\n",
+			expectedStderr: "error: 1 of 2 league seasons failed\n",
+		},
+		{
+			title:          "error backtest model not found",
+			args:           []string{"backtest", "--league", "39", "--from", "2020", "--to", "2021", "--model", "elo@9"},
+			expectedCode:   ExitUsage,
+			expectedStderr: "error: MODEL_NOT_FOUND\n",
+		},
+		{
+			title:          "error backtest not enough data",
+			args:           []string{"backtest", "--league", "40", "--from", "2020", "--to", "2021"},
+			expectedCode:   ExitUsage,
+			expectedStderr: "error: NOT_ENOUGH_DATA\n",
+		},
+		{
+			title:        "success backtest",
+			args:         []string{"backtest", "--league", "39", "--from", "2020", "--to", "2021", "--min-edge", "0.05"},
+			expectedCode: ExitOK,
+			expectedStdout: "backtest 3 league 39 seasons 2020-2021 (poisson@1)\n" +
+				"fixtures          760 (10 skipped)\n" +
+				"log-loss          0.9800\n" +
+				"brier score       0.5800\n" +
+				"ranked prob score 0.2000\n" +
+				"accuracy          50.0%\n" +
+				"bets              120, profit 4.32 (ROI 3.6%)\n",
+		},
+		{
+			title:          "success backtest without bets",
+			args:           []string{"-o", "json", "backtest", "--league", "39", "--from", "2021", "--to", "2021"},
+			expectedCode:   ExitOK,
+			expectedStdout: "{\n  \"id\": 3,\n  \"league_id\": 39,\n  \"season_from\": 2021,\n  \"season_to\": 2021,\n  \"model\": \"poisson@1\",\n  \"min_edge\": 0,\n  \"status\": \"finished\",\n  \"error\": \"\",\n  \"fixtures\": 760,\n  \"skipped\": 10,\n  \"log_loss\": 0.98,\n  \"brier\": 0.58,\n  \"rps\": 0.2,\n  \"accuracy\": 0.5,\n  \"bets\": 0,\n  \"staked\": 0,\n  \"profit\": 0,\n  \"roi\": null,\n  \"created_at\": \"0001-01-01T00:00:00Z\",\n  \"started_at\": null,\n  \"finished_at\": null\n}\n",
+		},
+		{
+			title:          "error export invalid format",
+			args:           []string{"export", "leagues", "--format", "xml"},
+			expectedCode:   ExitUsage,
+			expectedStderr: "error: invalid --format, expected json or csv\n",
+		},
+		{
+			title:        "success export fixtures csv",
+			args:         []string{"export", "fixtures", "--league", "39", "--format", "csv"},
+			expectedCode: ExitOK,
+			expectedStdout: "id,league_id,season,round,kickoff_at,status,home_team_id,home_team_name,away_team_id,away_team_name,home_goals,away_goals,winner_team_id,scored_at,details_synced_at\n" +
+				"1,39,0,,2022-01-15T15:00:00Z,FT,0,,0,,2,0,,,\n" +
+				"2,39,0,,2022-01-15T15:00:00Z,NS,0,,0,,,,,,\n",
+		},
+		{
+			title:          "success export empty leagues",
+			args:           []string{"export", "leagues"},
+			expectedCode:   ExitOK,
+			expectedStdout: "[]\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			code, stdout, stderr := testRun(testCase.args...)
+
+			assert.Equal(t, testCase.expectedCode, code)
+			assert.Equal(t, testCase.expectedStdout, stdout)
+			assert.Equal(t, testCase.expectedStderr, stderr)
+		})
+	}
+}
+
+func TestRun_Help(t *testing.T) {
+	code, stdout, _ := testRun("help")
+
+	assert.Equal(t, ExitOK, code)
+	for _, command := range []string{"serve", "sync", "migrate", "predict", "backfill", "export", "backtest"} {
+		assert.True(t, strings.Contains(stdout, command), command)
+	}
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/domains/countries"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/leagues"
+	"github.com/development-raul/footy-predictor/src/domains/seasons"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/urfave/cli/v2"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+)
+
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+
+	exportPerPage = 500
+)
+
+// pageFunc returns a single page of a paginated service List call
+type pageFunc func(page int64) (*pagination.PaginatedResponse, resterror.RestErrorI)
+
+func (r *runner) exportCommand() *cli.Command {
+	flags := func(extra ...cli.Flag) []cli.Flag {
+		return append([]cli.Flag{
+			&cli.StringFlag{Name: "format", Value: formatJSON, Usage: "json or csv"},
+			&cli.StringFlag{Name: "file", Usage: "write to a file instead of stdout"},
+		}, extra...)
+	}
+	return &cli.Command{
+		Name:  "export",
+		Usage: "export stored records as JSON or CSV",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "countries",
+				Usage: "export the countries",
+				Flags: flags(),
+				Action: func(c *cli.Context) error {
+					return r.export(c, func() (interface{}, resterror.RestErrorI) {
+						return collectPages(func(page int64) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+							return services.CountryService.List(c.Context, &countries.ListCountryInput{Page: page, PerPage: exportPerPage})
+						})
+					})
+				},
+			},
+			{
+				Name:  "seasons",
+				Usage: "export the seasons",
+				Flags: flags(),
+				Action: func(c *cli.Context) error {
+					return r.export(c, func() (interface{}, resterror.RestErrorI) {
+						return collectPages(func(page int64) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+							return services.SeasonService.List(c.Context, &seasons.ListSeasonInput{Page: page, PerPage: exportPerPage})
+						})
+					})
+				},
+			},
+			{
+				Name:  "leagues",
+				Usage: "export the leagues",
+				Flags: flags(),
+				Action: func(c *cli.Context) error {
+					return r.export(c, func() (interface{}, resterror.RestErrorI) {
+						return collectPages(func(page int64) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+							return services.LeagueService.List(c.Context, &leagues.ListLeagueInput{Page: page, PerPage: exportPerPage})
+						})
+					})
+				},
+			},
+			{
+				Name:  "fixtures",
+				Usage: "export the fixtures, optionally of a single league or season",
+				Flags: flags(
+					&cli.Int64Flag{Name: "league", Usage: "league id"},
+					&cli.Int64Flag{Name: "season", Usage: "season year"},
+				),
+				Action: func(c *cli.Context) error {
+					return r.export(c, func() (interface{}, resterror.RestErrorI) {
+						return collectPages(func(page int64) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+							return services.FixtureService.List(c.Context, &fixtures.ListFixtureInput{
+								LeagueID: c.Int64("league"),
+								Season:   c.Int64("season"),
+								Page:     page,
+								PerPage:  exportPerPage,
+							})
+						})
+					})
+				},
+			},
+		},
+	}
+}
+
+// export writes the records returned by list in the requested format, the --output flag only affects
+// the summary printed once the export is written to a file
+func (r *runner) export(c *cli.Context, list func() (interface{}, resterror.RestErrorI)) error {
+	format := c.String("format")
+	if format != formatJSON && format != formatCSV {
+		return cli.Exit("invalid --format, expected json or csv", ExitUsage)
+	}
+	if err := r.setup(c); err != nil {
+		return err
+	}
+
+	records, apiErr := list()
+	if apiErr != nil {
+		return apiError(apiErr)
+	}
+
+	path := c.String("file")
+	var w io.Writer = r.stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return cli.Exit(fmt.Sprintf("could not create %s: %v", path, err), ExitFailure)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	var err error
+	if format == formatCSV {
+		err = writeCSV(w, records)
+	} else {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(records)
+	}
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("export failed: %v", err), ExitFailure)
+	}
+
+	if path == "" {
+		return nil
+	}
+	count := reflect.ValueOf(records).Len()
+	return r.print(
+		fmt.Sprintf("%d %s exported to %s", count, c.Command.Name, path),
+		map[string]interface{}{"resource": c.Command.Name, "count": count, "file": path},
+	)
+}
+
+// collectPages calls the paginated list until the last page and concatenates the data slices
+func collectPages(list pageFunc) (interface{}, resterror.RestErrorI) {
+	var all reflect.Value
+	for page := int64(1); ; page++ {
+		res, apiErr := list(page)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		data := reflect.ValueOf(res.Data)
+		if data.Kind() != reflect.Slice {
+			return nil, resterror.NewStandardInternalServerError()
+		}
+		if !all.IsValid() {
+			all = reflect.MakeSlice(data.Type(), 0, data.Len())
+		}
+		all = reflect.AppendSlice(all, data)
+		if page >= res.LastPage || data.Len() == 0 {
+			return all.Interface(), nil
+		}
+	}
+}
+
+// writeCSV writes a slice of structs with a header row made of the json, or else db, field names
+func writeCSV(w io.Writer, records interface{}) error {
+	rows := reflect.ValueOf(records)
+	if rows.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot write %T as csv", records)
+	}
+	elem := rows.Type().Elem()
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot write %T as csv", records)
+	}
+
+	var header []string
+	var fields []int
+	for i := 0; i < elem.NumField(); i++ {
+		name := csvColumn(elem.Field(i))
+		if name == "" {
+			continue
+		}
+		header = append(header, name)
+		fields = append(fields, i)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i := 0; i < rows.Len(); i++ {
+		row := reflect.Indirect(rows.Index(i))
+		record := make([]string, len(fields))
+		for j, field := range fields {
+			record[j] = export.Value(row.Field(field).Interface())
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvColumn(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+	for _, key := range []string{"json", "db"} {
+		name := strings.Split(field.Tag.Get(key), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return strings.ToLower(field.Name)
+}
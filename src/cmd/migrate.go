@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/migrations"
+	"github.com/urfave/cli/v2"
+	"strings"
+)
+
+type migrateResult struct {
+	DryRun     bool                   `json:"dry_run"`
+	Migrations []migrations.Migration `json:"migrations"`
+}
+
+func (r *runner) migrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "apply the pending database migrations",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "dry-run", Usage: "only list the pending migrations"},
+		},
+		Action: r.migrate,
+	}
+}
+
+func (r *runner) migrate(c *cli.Context) error {
+	if err := r.setup(c); err != nil {
+		return err
+	}
+
+	dryRun := c.Bool("dry-run")
+	var list []migrations.Migration
+	var err error
+	if dryRun {
+		list, err = migrations.Pending()
+	} else {
+		list, err = migrations.Up()
+	}
+	if err != nil {
+		// Report what was applied before the failure, the next run continues from there
+		_ = r.print(describeMigrations("applied", list), migrateResult{Migrations: list})
+		return cli.Exit(fmt.Sprintf("migration failed: %v", err), ExitFailure)
+	}
+
+	action := "applied"
+	if dryRun {
+		action = "pending"
+	}
+	if list == nil {
+		list = []migrations.Migration{}
+	}
+	return r.print(describeMigrations(action, list), migrateResult{DryRun: dryRun, Migrations: list})
+}
+
+func describeMigrations(action string, list []migrations.Migration) string {
+	if len(list) == 0 {
+		return fmt.Sprintf("no migrations %s", action)
+	}
+	names := make([]string, len(list))
+	for i, m := range list {
+		names[i] = fmt.Sprintf("  %d_%s", m.Version, m.Name)
+	}
+	return fmt.Sprintf("%d migrations %s:\n%s", len(list), action, strings.Join(names, "\n"))
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/domains/predictions"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/urfave/cli/v2"
+)
+
+func (r *runner) predictCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "predict",
+		Usage: "forecast the result of a fixture",
+		Flags: []cli.Flag{
+			&cli.Int64Flag{Name: "fixture", Usage: "fixture id", Required: true},
+			&cli.StringFlag{Name: "model", Usage: "model version e.g. dixon-coles@3, or a name for its latest version, the champion by default"},
+		},
+		Action: r.predict,
+	}
+}
+
+func (r *runner) predict(c *cli.Context) error {
+	if err := r.setup(c); err != nil {
+		return err
+	}
+	res, apiErr := services.PredictionService.Predict(c.Context, c.Int64("fixture"), c.String("model"))
+	if apiErr != nil {
+		return apiError(apiErr)
+	}
+	return r.print(describePrediction(res), res)
+}
+
+func describePrediction(p *predictions.Prediction) string {
+	var over float64
+	for _, line := range p.Markets.OverUnder {
+		if line.Line == 2.5 {
+			over = line.Over
+		}
+	}
+	return fmt.Sprintf("fixture %d (%s, %d matches)\n"+
+		"expected goals     %.2f - %.2f\n"+
+		"home / draw / away %.1f%% / %.1f%% / %.1f%%\n"+
+		"most likely score  %d-%d (%.1f%%)\n"+
+		"over 2.5 / btts    %.1f%% / %.1f%%",
+		p.FixtureID, p.Model, p.MatchesUsed,
+		p.HomeExpectedGoals, p.AwayExpectedGoals,
+		p.HomeWin*100, p.Draw*100, p.AwayWin*100,
+		p.MostLikelyScore.HomeGoals, p.MostLikelyScore.AwayGoals, p.MostLikelyScore.Probability*100,
+		over*100, p.Markets.BothTeamsScore.Yes*100)
+}
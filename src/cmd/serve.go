@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"github.com/development-raul/footy-predictor/src/app"
+	"github.com/urfave/cli/v2"
+)
+
+func (r *runner) serveCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "serve",
+		Usage:  "start the HTTP API",
+		Action: r.serve,
+	}
+}
+
+func (r *runner) serve(c *cli.Context) error {
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+	// StartApplication only returns once the server stops
+	app.StartApplication(cfg)
+	return nil
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/injuries"
+	"github.com/development-raul/footy-predictor/src/domains/odds"
+	"github.com/development-raul/footy-predictor/src/domains/players"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/urfave/cli/v2"
+)
+
+type syncResult struct {
+	Resource  string `json:"resource"`
+	FixtureID int64  `json:"fixture_id,omitempty"`
+	TeamID    int64  `json:"team_id,omitempty"`
+	LeagueID  int64  `json:"league_id,omitempty"`
+	Season    int64  `json:"season,omitempty"`
+	Status    string `json:"status"`
+}
+
+func (r *runner) syncCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "sync",
+		Usage: "import data from API Sports",
+		Subcommands: []*cli.Command{
+			r.syncResourceCommand("countries", func(ctx context.Context) resterror.RestErrorI { return services.CountryService.Sync(ctx) }),
+			r.syncResourceCommand("seasons", func(ctx context.Context) resterror.RestErrorI { return services.SeasonService.Sync(ctx) }),
+			r.syncResourceCommand("leagues", func(ctx context.Context) resterror.RestErrorI { return services.LeagueService.Sync(ctx) }),
+			{
+				Name:  "fixtures",
+				Usage: "import the fixtures of a league season",
+				Flags: []cli.Flag{
+					&cli.Int64Flag{Name: "league", Usage: "league id", Required: true},
+					&cli.Int64Flag{Name: "season", Usage: "season year", Required: true},
+				},
+				Action: r.syncFixtures,
+			},
+			{
+				Name:  "odds",
+				Usage: "import the pre-match odds of a fixture or of a league season",
+				Flags: []cli.Flag{
+					&cli.Int64Flag{Name: "fixture", Usage: "fixture id"},
+					&cli.Int64Flag{Name: "league", Usage: "league id"},
+					&cli.Int64Flag{Name: "season", Usage: "season year"},
+					&cli.Int64Flag{Name: "bookmaker", Usage: "bookmaker id, every bookmaker by default"},
+				},
+				Action: r.syncOdds,
+			},
+			{
+				Name:  "players",
+				Usage: "import the squad of a team, or the players of a league season with their statistics",
+				Flags: []cli.Flag{
+					&cli.Int64Flag{Name: "team", Usage: "team id"},
+					&cli.Int64Flag{Name: "league", Usage: "league id"},
+					&cli.Int64Flag{Name: "season", Usage: "season year"},
+				},
+				Action: r.syncPlayers,
+			},
+			{
+				Name:  "injuries",
+				Usage: "import the injured and suspended players missing a fixture or the fixtures of a league season",
+				Flags: []cli.Flag{
+					&cli.Int64Flag{Name: "fixture", Usage: "fixture id"},
+					&cli.Int64Flag{Name: "league", Usage: "league id"},
+					&cli.Int64Flag{Name: "season", Usage: "season year"},
+				},
+				Action: r.syncInjuries,
+			},
+			{
+				Name:  "details",
+				Usage: "import the events, lineups and statistics of a fixture or of the finished fixtures of a league season missing them",
+				Flags: []cli.Flag{
+					&cli.Int64Flag{Name: "fixture", Usage: "fixture id"},
+					&cli.Int64Flag{Name: "league", Usage: "league id"},
+					&cli.Int64Flag{Name: "season", Usage: "season year"},
+				},
+				Action: r.syncDetails,
+			},
+		},
+	}
+}
+
+// syncResourceCommand builds the command of a resource that is synced without arguments
+func (r *runner) syncResourceCommand(resource string, sync func(ctx context.Context) resterror.RestErrorI) *cli.Command {
+	return &cli.Command{
+		Name:  resource,
+		Usage: fmt.Sprintf("import the %s", resource),
+		Action: func(c *cli.Context) error {
+			if err := r.setup(c); err != nil {
+				return err
+			}
+			if apiErr := sync(c.Context); apiErr != nil {
+				return apiError(apiErr)
+			}
+			return r.print(resource+" synced", syncResult{Resource: resource, Status: "ok"})
+		},
+	}
+}
+
+func (r *runner) syncFixtures(c *cli.Context) error {
+	if err := r.setup(c); err != nil {
+		return err
+	}
+	req := fixtures.SyncFixtureInput{
+		LeagueID: c.Int64("league"),
+		Season:   c.Int64("season"),
+	}
+	if apiErr := services.FixtureService.Sync(c.Context, &req); apiErr != nil {
+		return apiError(apiErr)
+	}
+	return r.print(
+		fmt.Sprintf("fixtures synced for league %d season %d", req.LeagueID, req.Season),
+		syncResult{Resource: "fixtures", LeagueID: req.LeagueID, Season: req.Season, Status: "ok"},
+	)
+}
+
+func (r *runner) syncOdds(c *cli.Context) error {
+	req := odds.SyncOddInput{
+		FixtureID:   c.Int64("fixture"),
+		LeagueID:    c.Int64("league"),
+		Season:      c.Int64("season"),
+		BookmakerID: c.Int64("bookmaker"),
+	}
+	if req.FixtureID == 0 && (req.LeagueID == 0 || req.Season == 0) {
+		return cli.Exit("--fixture or --league and --season are required", ExitUsage)
+	}
+	if err := r.setup(c); err != nil {
+		return err
+	}
+	if apiErr := services.OddService.Sync(c.Context, &req); apiErr != nil {
+		return apiError(apiErr)
+	}
+	msg := fmt.Sprintf("odds synced for fixture %d", req.FixtureID)
+	if req.FixtureID == 0 {
+		msg = fmt.Sprintf("odds synced for league %d season %d", req.LeagueID, req.Season)
+	}
+	return r.print(msg, syncResult{Resource: "odds", FixtureID: req.FixtureID, LeagueID: req.LeagueID, Season: req.Season, Status: "ok"})
+}
+
+func (r *runner) syncPlayers(c *cli.Context) error {
+	req := players.SyncPlayerInput{
+		TeamID:   c.Int64("team"),
+		LeagueID: c.Int64("league"),
+		Season:   c.Int64("season"),
+	}
+	if req.TeamID == 0 && (req.LeagueID == 0 || req.Season == 0) {
+		return cli.Exit("--team or --league and --season are required", ExitUsage)
+	}
+	if err := r.setup(c); err != nil {
+		return err
+	}
+	if apiErr := services.PlayerService.Sync(c.Context, &req); apiErr != nil {
+		return apiError(apiErr)
+	}
+	msg := fmt.Sprintf("squad synced for team %d", req.TeamID)
+	if req.TeamID == 0 {
+		msg = fmt.Sprintf("players synced for league %d season %d", req.LeagueID, req.Season)
+	}
+	return r.print(msg, syncResult{Resource: "players", TeamID: req.TeamID, LeagueID: req.LeagueID, Season: req.Season, Status: "ok"})
+}
+
+func (r *runner) syncInjuries(c *cli.Context) error {
+	req := injuries.SyncInjuryInput{
+		FixtureID: c.Int64("fixture"),
+		LeagueID:  c.Int64("league"),
+		Season:    c.Int64("season"),
+	}
+	if req.FixtureID == 0 && (req.LeagueID == 0 || req.Season == 0) {
+		return cli.Exit("--fixture or --league and --season are required", ExitUsage)
+	}
+	if err := r.setup(c); err != nil {
+		return err
+	}
+	if apiErr := services.InjuryService.Sync(c.Context, &req); apiErr != nil {
+		return apiError(apiErr)
+	}
+	msg := fmt.Sprintf("injuries synced for fixture %d", req.FixtureID)
+	if req.FixtureID == 0 {
+		msg = fmt.Sprintf("injuries synced for league %d season %d", req.LeagueID, req.Season)
+	}
+	return r.print(msg, syncResult{Resource: "injuries", FixtureID: req.FixtureID, LeagueID: req.LeagueID, Season: req.Season, Status: "ok"})
+}
+
+func (r *runner) syncDetails(c *cli.Context) error {
+	req := fixtures.SyncDetailsInput{
+		FixtureID: c.Int64("fixture"),
+		LeagueID:  c.Int64("league"),
+		Season:    c.Int64("season"),
+	}
+	if req.FixtureID == 0 && (req.LeagueID == 0 || req.Season == 0) {
+		return cli.Exit("--fixture or --league and --season are required", ExitUsage)
+	}
+	if err := r.setup(c); err != nil {
+		return err
+	}
+	if apiErr := services.FixtureDetailService.SyncMissing(c.Context, &req); apiErr != nil {
+		return apiError(apiErr)
+	}
+	msg := fmt.Sprintf("details synced for fixture %d", req.FixtureID)
+	if req.FixtureID == 0 {
+		msg = fmt.Sprintf("details synced for league %d season %d", req.LeagueID, req.Season)
+	}
+	return r.print(msg, syncResult{Resource: "details", FixtureID: req.FixtureID, LeagueID: req.LeagueID, Season: req.Season, Status: "ok"})
+}
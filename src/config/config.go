@@ -0,0 +1,372 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config holds every setting of the application. Values are loaded, from the lowest to the highest precedence,
+// from the defaults, the YAML config file, the environment variables and the command line flags
+type Config struct {
+	App       AppConfig       `yaml:"app"`
+	DB        DBConfig        `yaml:"db"`
+	Log       LogConfig       `yaml:"log"`
+	Auth      AuthConfig      `yaml:"auth"`
+	APISports APISportsConfig `yaml:"api_sports"`
+	CORS      CORSConfig      `yaml:"cors"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	ValueBets ValueBetsConfig `yaml:"value_bets"`
+}
+
+type AppConfig struct {
+	Env  string `yaml:"env"`
+	Port string `yaml:"port"`
+}
+
+type DBConfig struct {
+	Host            string        `yaml:"host"`
+	Port            string        `yaml:"port"`
+	Name            string        `yaml:"name"`
+	User            string        `yaml:"user"`
+	Pass            string        `yaml:"pass"`
+	PassFile        string        `yaml:"pass_file"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level"`
+	// Format is json or console, when empty production uses json and the other environments console
+	Format string `yaml:"format"`
+	File   string `yaml:"file"`
+	// The log file is rotated once it reaches MaxSizeMB, MaxBackups and MaxAgeDays limit the rotated files kept
+	MaxSizeMB  int  `yaml:"max_size_mb"`
+	MaxBackups int  `yaml:"max_backups"`
+	MaxAgeDays int  `yaml:"max_age_days"`
+	Compress   bool `yaml:"compress"`
+}
+
+type AuthConfig struct {
+	JWTSecret        string        `yaml:"jwt_secret"`
+	JWTSecretFile    string        `yaml:"jwt_secret_file"`
+	JWTTTL           time.Duration `yaml:"jwt_ttl"`
+	BootstrapKey     string        `yaml:"bootstrap_key"`
+	BootstrapKeyFile string        `yaml:"bootstrap_key_file"`
+}
+
+type APISportsConfig struct {
+	BaseURL string `yaml:"base_url"`
+	Key     string `yaml:"key"`
+	KeyFile string `yaml:"key_file"`
+	Host    string `yaml:"host"`
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins"`
+	AllowedMethods   []string      `yaml:"allowed_methods"`
+	AllowedHeaders   []string      `yaml:"allowed_headers"`
+	ExposedHeaders   []string      `yaml:"exposed_headers"`
+	AllowCredentials bool          `yaml:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age"`
+}
+
+type TracingConfig struct {
+	// Exporter is none, stdout or otlp
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the host:port of the OTLP HTTP collector
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+	ServiceName string  `yaml:"service_name"`
+}
+
+// ValueBetsConfig holds the defaults of the value bets requests, each can be overridden per request
+type ValueBetsConfig struct {
+	// MinEdge is the minimum difference between the model and the market probability
+	MinEdge float64 `yaml:"min_edge"`
+	// KellyFraction scales down the Kelly stake, 1 is full Kelly
+	KellyFraction float64 `yaml:"kelly_fraction"`
+	Bankroll      float64 `yaml:"bankroll"`
+}
+
+// Default returns the configuration used for any value that is not provided
+func Default() *Config {
+	return &Config{
+		App: AppConfig{
+			Env:  "development",
+			Port: "5000",
+		},
+		DB: DBConfig{
+			Port:            "3306",
+			MaxOpenConns:    100,
+			MaxIdleConns:    50,
+			ConnMaxLifetime: 10 * time.Second,
+		},
+		Log: LogConfig{
+			Level:      "info",
+			File:       "application.log",
+			MaxSizeMB:  100,
+			MaxBackups: 5,
+			MaxAgeDays: 30,
+		},
+		Auth: AuthConfig{
+			JWTTTL: time.Hour,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins:   []string{"https://localhost:8080"},
+			AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+			AllowedHeaders:   []string{"Content-Type", "Authorization", "X-Requested-With", "If-Match", "If-None-Match"},
+			ExposedHeaders:   []string{"X-Request-ID", "ETag"},
+			AllowCredentials: true,
+			MaxAge:           24 * time.Hour,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			Endpoint:    "localhost:4318",
+			SampleRatio: 1,
+			ServiceName: "footy-predictor",
+		},
+		ValueBets: ValueBetsConfig{
+			MinEdge:       0.05,
+			KellyFraction: 0.25,
+			Bankroll:      1000,
+		},
+	}
+}
+
+// IsProduction checks if the application runs in the production environment
+func (c *Config) IsProduction() bool {
+	env := strings.ToLower(c.App.Env)
+	return env == "prod" || env == "production"
+}
+
+// Load builds the configuration from the config file, the environment and the command line arguments and validates it.
+// The config file is taken from the -config flag or the CONFIG_FILE env variable
+func Load(args []string) (*Config, error) {
+	return load(args, os.LookupEnv)
+}
+
+func load(args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	fs, flags := newFlagSet()
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+
+	path, _ := lookupEnv("CONFIG_FILE")
+	if flags.configFile != "" {
+		path = flags.configFile
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.loadEnv(lookupEnv); err != nil {
+		return nil, err
+	}
+	flags.apply(fs, cfg)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: reading %s: %w", path, err)
+	}
+	if err := yaml.UnmarshalStrict(content, c); err != nil {
+		return fmt.Errorf("config: parsing %s: %w", path, err)
+	}
+
+	// Secrets referenced by path are resolved right away so a later source can still override them
+	secrets := []struct {
+		file  *string
+		value *string
+	}{
+		{&c.DB.PassFile, &c.DB.Pass},
+		{&c.Auth.JWTSecretFile, &c.Auth.JWTSecret},
+		{&c.Auth.BootstrapKeyFile, &c.Auth.BootstrapKey},
+		{&c.APISports.KeyFile, &c.APISports.Key},
+	}
+	for _, s := range secrets {
+		if *s.file == "" {
+			continue
+		}
+		v, err := readSecret(*s.file)
+		if err != nil {
+			return err
+		}
+		*s.value = v
+	}
+	return nil
+}
+
+func (c *Config) loadEnv(lookupEnv func(string) (string, bool)) error {
+	vars := []struct {
+		name   string
+		secret bool
+		set    func(string) error
+	}{
+		{"APP_ENV", false, setString(&c.App.Env)},
+		{"APP_PORT", false, setString(&c.App.Port)},
+		{"DB_HOST", false, setString(&c.DB.Host)},
+		{"DB_PORT", false, setString(&c.DB.Port)},
+		{"DB_NAME", false, setString(&c.DB.Name)},
+		{"DB_USER", false, setString(&c.DB.User)},
+		{"DB_PASS", true, setString(&c.DB.Pass)},
+		{"DB_MAX_OPEN_CONNS", false, setInt(&c.DB.MaxOpenConns)},
+		{"DB_MAX_IDLE_CONNS", false, setInt(&c.DB.MaxIdleConns)},
+		{"DB_CONN_MAX_LIFETIME", false, setDuration(&c.DB.ConnMaxLifetime)},
+		{"LOG_LEVEL", false, setString(&c.Log.Level)},
+		{"LOG_FORMAT", false, setString(&c.Log.Format)},
+		{"ERROR_LOG_PATH", false, setString(&c.Log.File)},
+		{"LOG_MAX_SIZE_MB", false, setInt(&c.Log.MaxSizeMB)},
+		{"LOG_MAX_BACKUPS", false, setInt(&c.Log.MaxBackups)},
+		{"LOG_MAX_AGE_DAYS", false, setInt(&c.Log.MaxAgeDays)},
+		{"LOG_COMPRESS", false, setBool(&c.Log.Compress)},
+		{"JWT_SECRET", true, setString(&c.Auth.JWTSecret)},
+		{"JWT_TTL", false, setDuration(&c.Auth.JWTTTL)},
+		{"AUTH_BOOTSTRAP_KEY", true, setString(&c.Auth.BootstrapKey)},
+		{"AS_BASE_URL", false, setString(&c.APISports.BaseURL)},
+		{"AS_KEY", true, setString(&c.APISports.Key)},
+		{"AS_HOST", false, setString(&c.APISports.Host)},
+		{"CORS_ALLOWED_ORIGINS", false, setList(&c.CORS.AllowedOrigins)},
+		{"CORS_ALLOWED_METHODS", false, setList(&c.CORS.AllowedMethods)},
+		{"CORS_ALLOWED_HEADERS", false, setList(&c.CORS.AllowedHeaders)},
+		{"CORS_EXPOSED_HEADERS", false, setList(&c.CORS.ExposedHeaders)},
+		{"CORS_ALLOW_CREDENTIALS", false, setBool(&c.CORS.AllowCredentials)},
+		{"CORS_MAX_AGE", false, setDuration(&c.CORS.MaxAge)},
+		{"TRACING_EXPORTER", false, setString(&c.Tracing.Exporter)},
+		{"TRACING_ENDPOINT", false, setString(&c.Tracing.Endpoint)},
+		{"TRACING_INSECURE", false, setBool(&c.Tracing.Insecure)},
+		{"TRACING_SAMPLE_RATIO", false, setFloat(&c.Tracing.SampleRatio)},
+		{"TRACING_SERVICE_NAME", false, setString(&c.Tracing.ServiceName)},
+		{"VALUE_BETS_MIN_EDGE", false, setFloat(&c.ValueBets.MinEdge)},
+		{"VALUE_BETS_KELLY_FRACTION", false, setFloat(&c.ValueBets.KellyFraction)},
+		{"VALUE_BETS_BANKROLL", false, setFloat(&c.ValueBets.Bankroll)},
+	}
+
+	for _, v := range vars {
+		value, ok := lookupEnv(v.name)
+		// Secrets can also be mounted as files, e.g. DB_PASS_FILE=/run/secrets/db_pass
+		if path, fileOk := lookupEnv(v.name + "_FILE"); v.secret && !ok && fileOk {
+			secret, err := readSecret(path)
+			if err != nil {
+				return err
+			}
+			value, ok = secret, true
+		}
+		if !ok {
+			continue
+		}
+		if err := v.set(value); err != nil {
+			return fmt.Errorf("config: invalid %s: %w", v.name, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the required values are set and the others are sensible, every problem is reported at once
+func (c *Config) Validate() error {
+	var problems []string
+	required := map[string]string{
+		"app.port":            c.App.Port,
+		"db.host":             c.DB.Host,
+		"db.port":             c.DB.Port,
+		"db.name":             c.DB.Name,
+		"db.user":             c.DB.User,
+		"auth.jwt_secret":     c.Auth.JWTSecret,
+		"api_sports.base_url": c.APISports.BaseURL,
+		"api_sports.key":      c.APISports.Key,
+	}
+	for _, key := range sortedKeys(required) {
+		if strings.TrimSpace(required[key]) == "" {
+			problems = append(problems, key+" is required")
+		}
+	}
+
+	if _, err := strconv.ParseUint(c.App.Port, 10, 16); c.App.Port != "" && err != nil {
+		problems = append(problems, "app.port must be a valid port number")
+	}
+	if _, err := strconv.ParseUint(c.DB.Port, 10, 16); c.DB.Port != "" && err != nil {
+		problems = append(problems, "db.port must be a valid port number")
+	}
+	if c.DB.MaxOpenConns < 0 {
+		problems = append(problems, "db.max_open_conns must not be negative")
+	}
+	if c.DB.MaxIdleConns < 0 {
+		problems = append(problems, "db.max_idle_conns must not be negative")
+	}
+	if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		problems = append(problems, "db.max_idle_conns must not exceed db.max_open_conns")
+	}
+	if c.DB.ConnMaxLifetime < 0 {
+		problems = append(problems, "db.conn_max_lifetime must not be negative")
+	}
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, "log.level must be one of debug, info, warn or error")
+	}
+	switch strings.ToLower(c.Log.Format) {
+	case "", "json", "console":
+	default:
+		problems = append(problems, "log.format must be json or console")
+	}
+	if c.Log.MaxSizeMB < 0 || c.Log.MaxBackups < 0 || c.Log.MaxAgeDays < 0 {
+		problems = append(problems, "log.max_size_mb, log.max_backups and log.max_age_days must not be negative")
+	}
+	if c.Auth.JWTTTL <= 0 {
+		problems = append(problems, "auth.jwt_ttl must be positive")
+	}
+	if c.CORS.MaxAge < 0 {
+		problems = append(problems, "cors.max_age must not be negative")
+	}
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if c.Tracing.Endpoint == "" {
+			problems = append(problems, "tracing.endpoint is required by the otlp exporter")
+		}
+	default:
+		problems = append(problems, "tracing.exporter must be one of none, stdout or otlp")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, "tracing.sample_ratio must be between 0 and 1")
+	}
+	if c.ValueBets.MinEdge < 0 || c.ValueBets.MinEdge >= 1 {
+		problems = append(problems, "value_bets.min_edge must be between 0 and 1")
+	}
+	if c.ValueBets.KellyFraction <= 0 || c.ValueBets.KellyFraction > 1 {
+		problems = append(problems, "value_bets.kelly_fraction must be greater than 0 and at most 1")
+	}
+	if c.ValueBets.Bankroll <= 0 {
+		problems = append(problems, "value_bets.bankroll must be positive")
+	}
+
+	if len(problems) > 0 {
+		return errors.New("config: invalid configuration: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func readSecret(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("config: reading secret %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("an error '%s' was not expected when writing %s", err, name)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating a temp dir", err)
+	}
+	defer os.RemoveAll(dir)
+
+	jwtSecretFile := writeFile(t, dir, "jwt_secret", "file-secret\n")
+	configFile := writeFile(t, dir, "config.yaml", `
+app:
+  port: "8000"
+db:
+  host: db.local
+  name: footy
+  user: footy
+  pass: yaml-pass
+  max_open_conns: 20
+  max_idle_conns: 5
+  conn_max_lifetime: 1m
+auth:
+  jwt_secret_file: `+jwtSecretFile+`
+api_sports:
+  base_url: https://api.test
+  key: yaml-key
+`)
+	dbPassFile := writeFile(t, dir, "db_pass", "env-file-pass")
+	invalidFile := writeFile(t, dir, "invalid.yaml", "unknown: true\n")
+
+	baseEnv := map[string]string{
+		"DB_HOST":     "localhost",
+		"DB_NAME":     "footy",
+		"DB_USER":     "root",
+		"JWT_SECRET":  "secret",
+		"AS_BASE_URL": "https://api.test",
+		"AS_KEY":      "key",
+	}
+
+	testCases := []struct {
+		title       string
+		args        []string
+		env         map[string]string
+		check       func(t *testing.T, cfg *Config)
+		expectedErr error
+	}{
+		{
+			title:       "error missing required values",
+			env:         map[string]string{"DB_HOST": "localhost"},
+			expectedErr: errors.New("config: invalid configuration: api_sports.base_url is required; api_sports.key is required; auth.jwt_secret is required; db.name is required; db.user is required"),
+		},
+		{
+			title: "error invalid values",
+			env: merge(baseEnv, map[string]string{
+				"APP_PORT":                  "http",
+				"DB_MAX_OPEN_CONNS":         "10",
+				"DB_MAX_IDLE_CONNS":         "20",
+				"LOG_LEVEL":                 "verbose",
+				"LOG_FORMAT":                "text",
+				"TRACING_EXPORTER":          "jaeger",
+				"VALUE_BETS_KELLY_FRACTION": "1.5",
+			}),
+			expectedErr: errors.New("config: invalid configuration: app.port must be a valid port number; db.max_idle_conns must not exceed db.max_open_conns; " +
+				"log.level must be one of debug, info, warn or error; log.format must be json or console; " +
+				"tracing.exporter must be one of none, stdout or otlp; value_bets.kelly_fraction must be greater than 0 and at most 1"),
+		},
+		{
+			title:       "error env parsing",
+			env:         merge(baseEnv, map[string]string{"JWT_TTL": "forever"}),
+			expectedErr: errors.New(`config: invalid JWT_TTL: time: invalid duration "forever"`),
+		},
+		{
+			title:       "error unknown file key",
+			args:        []string{"-config", invalidFile},
+			env:         baseEnv,
+			expectedErr: errors.New("config: parsing " + invalidFile + ": yaml: unmarshal errors:\n  line 1: field unknown not found in type config.Config"),
+		},
+		{
+			title:       "error missing secret file",
+			env:         merge(baseEnv, map[string]string{"DB_PASS_FILE": filepath.Join(dir, "missing")}),
+			expectedErr: errors.New("config: reading secret " + filepath.Join(dir, "missing") + ": open " + filepath.Join(dir, "missing") + ": no such file or directory"),
+		},
+		{
+			title: "success defaults",
+			env:   baseEnv,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "5000", cfg.App.Port)
+				assert.Equal(t, "3306", cfg.DB.Port)
+				assert.Equal(t, 100, cfg.DB.MaxOpenConns)
+				assert.Equal(t, 50, cfg.DB.MaxIdleConns)
+				assert.Equal(t, 10*time.Second, cfg.DB.ConnMaxLifetime)
+				assert.Equal(t, time.Hour, cfg.Auth.JWTTTL)
+				assert.Equal(t, "info", cfg.Log.Level)
+				assert.Equal(t, "", cfg.Log.Format)
+				assert.Equal(t, 100, cfg.Log.MaxSizeMB)
+				assert.Equal(t, 0.05, cfg.ValueBets.MinEdge)
+				assert.Equal(t, 0.25, cfg.ValueBets.KellyFraction)
+				assert.False(t, cfg.IsProduction())
+			},
+		},
+		{
+			title: "success file then env then flags",
+			args:  []string{"-db-host", "flag.local", "-env", "production", "-log-level", "debug"},
+			env: map[string]string{
+				"CONFIG_FILE":          configFile,
+				"DB_HOST":              "env.local",
+				"DB_PASS_FILE":         dbPassFile,
+				"CORS_ALLOWED_ORIGINS": "https://a.test, https://*.b.test",
+				"LOG_LEVEL":            "warn",
+				"LOG_COMPRESS":         "true",
+				"TRACING_EXPORTER":     "otlp",
+				"TRACING_SAMPLE_RATIO": "0.25",
+				"VALUE_BETS_BANKROLL":  "250",
+			},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "8000", cfg.App.Port)
+				assert.Equal(t, "flag.local", cfg.DB.Host)
+				assert.Equal(t, "env-file-pass", cfg.DB.Pass)
+				assert.Equal(t, 20, cfg.DB.MaxOpenConns)
+				assert.Equal(t, 5, cfg.DB.MaxIdleConns)
+				assert.Equal(t, time.Minute, cfg.DB.ConnMaxLifetime)
+				assert.Equal(t, "file-secret", cfg.Auth.JWTSecret)
+				assert.Equal(t, []string{"https://a.test", "https://*.b.test"}, cfg.CORS.AllowedOrigins)
+				assert.Equal(t, "debug", cfg.Log.Level)
+				assert.True(t, cfg.Log.Compress)
+				assert.Equal(t, "otlp", cfg.Tracing.Exporter)
+				assert.Equal(t, 0.25, cfg.Tracing.SampleRatio)
+				assert.Equal(t, float64(250), cfg.ValueBets.Bankroll)
+				assert.True(t, cfg.IsProduction())
+			},
+		},
+		{
+			title: "success env value wins over env file",
+			env:   merge(baseEnv, map[string]string{"DB_PASS": "plain", "DB_PASS_FILE": dbPassFile}),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "plain", cfg.DB.Pass)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			lookupEnv := func(key string) (string, bool) {
+				v, ok := testCase.env[key]
+				return v, ok
+			}
+
+			cfg, err := load(testCase.args, lookupEnv)
+
+			assert.Equal(t, testCase.expectedErr, unwrapped(err))
+			if testCase.check != nil && assert.NotNil(t, cfg) {
+				testCase.check(t, cfg)
+			}
+		})
+	}
+}
+
+func merge(a map[string]string, b map[string]string) map[string]string {
+	res := make(map[string]string, len(a)+len(b))
+	for k, v := range a {
+		res[k] = v
+	}
+	for k, v := range b {
+		res[k] = v
+	}
+	return res
+}
+
+// unwrapped flattens wrapped errors so they can be compared by message
+func unwrapped(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errors.New(err.Error())
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"flag"
+)
+
+// flagValues holds the command line flags, they are only applied when explicitly set
+type flagValues struct {
+	configFile string
+	env        string
+	port       string
+	dbHost     string
+	dbPort     string
+	dbName     string
+	dbUser     string
+	logFile    string
+	logLevel   string
+	logFormat  string
+}
+
+func newFlagSet() (*flag.FlagSet, *flagValues) {
+	v := &flagValues{}
+	fs := flag.NewFlagSet("footy-predictor", flag.ContinueOnError)
+	fs.StringVar(&v.configFile, "config", "", "path to the YAML config file")
+	fs.StringVar(&v.env, "env", "", "application environment")
+	fs.StringVar(&v.port, "port", "", "HTTP port")
+	fs.StringVar(&v.dbHost, "db-host", "", "database host")
+	fs.StringVar(&v.dbPort, "db-port", "", "database port")
+	fs.StringVar(&v.dbName, "db-name", "", "database name")
+	fs.StringVar(&v.dbUser, "db-user", "", "database user")
+	fs.StringVar(&v.logFile, "log-file", "", "log file path")
+	fs.StringVar(&v.logLevel, "log-level", "", "log level: debug, info, warn or error")
+	fs.StringVar(&v.logFormat, "log-format", "", "log format: json or console")
+	return fs, v
+}
+
+func (v *flagValues) apply(fs *flag.FlagSet, c *Config) {
+	targets := map[string]struct {
+		dst *string
+		src string
+	}{
+		"env":        {&c.App.Env, v.env},
+		"port":       {&c.App.Port, v.port},
+		"db-host":    {&c.DB.Host, v.dbHost},
+		"db-port":    {&c.DB.Port, v.dbPort},
+		"db-name":    {&c.DB.Name, v.dbName},
+		"db-user":    {&c.DB.User, v.dbUser},
+		"log-file":   {&c.Log.File, v.logFile},
+		"log-level":  {&c.Log.Level, v.logLevel},
+		"log-format": {&c.Log.Format, v.logFormat},
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if t, ok := targets[f.Name]; ok {
+			*t.dst = t.src
+		}
+	})
+}
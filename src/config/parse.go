@@ -0,0 +1,82 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func setString(dst *string) func(string) error {
+	return func(v string) error {
+		*dst = v
+		return nil
+	}
+}
+
+func setInt(dst *int) func(string) error {
+	return func(v string) error {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		*dst = i
+		return nil
+	}
+}
+
+func setBool(dst *bool) func(string) error {
+	return func(v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		*dst = b
+		return nil
+	}
+}
+
+func setFloat(dst *float64) func(string) error {
+	return func(v string) error {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return err
+		}
+		*dst = f
+		return nil
+	}
+}
+
+func setDuration(dst *time.Duration) func(string) error {
+	return func(v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*dst = d
+		return nil
+	}
+}
+
+// setList splits a comma separated value, empty items are ignored
+func setList(dst *[]string) func(string) error {
+	return func(v string) error {
+		var res []string
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				res = append(res, item)
+			}
+		}
+		*dst = res
+		return nil
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
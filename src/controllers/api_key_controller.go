@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/api_keys"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type apiKeyControllerInterface interface {
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Find(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type apiKeyController struct{}
+
+var ApiKeyController apiKeyControllerInterface = &apiKeyController{}
+
+// Create
+// @Summary Create API key
+// @Description Endpoint used to create a new API key. The plain key is only returned in this response.
+// @ID v1-api-keys-create
+// @Produce json
+// @Accept json
+// @Tags API Keys
+// @Security ApiKeyAuth
+// @Param JSON request body api_keys.ApiKeyInput true "Request Sample"
+// @Success 201 {object} swaggertypes.NoErrorI{data=api_keys.CreateApiKeyOutput}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 409 {object} swaggertypes.DatabaseError
+// @Failure 422 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /api-keys [post]
+func (c *apiKeyController) Create(ctx *gin.Context) {
+	var req api_keys.ApiKeyInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	result, err := services.ApiKeyService.Create(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusCreated,
+	})
+}
+
+// Update
+// @Summary Update API key
+// @Description Endpoint used to update the name, role or status of an existing API key
+// @ID v1-api-keys-update
+// @Produce json
+// @Accept json
+// @Tags API Keys
+// @Security ApiKeyAuth
+// @Param id path int true "API Key ID"
+// @Param JSON request body api_keys.UpdateApiKeyInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 409 {object} swaggertypes.DatabaseError
+// @Failure 422 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /api-keys/{id} [put]
+func (c *apiKeyController) Update(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_API_KEY_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	var req api_keys.UpdateApiKeyInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	if err := services.ApiKeyService.Update(ctx.Request.Context(), &req, id); err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
+
+// Find
+// @Summary Find API key
+// @Description Retrieve an API key identified by id
+// @ID v1-api-keys-find
+// @Produce json
+// @Tags API Keys
+// @Security ApiKeyAuth
+// @Param id path int true "API Key ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=api_keys.ApiKeyOutput}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /api-keys/{id} [get]
+func (c *apiKeyController) Find(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_API_KEY_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.ApiKeyService.Find(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// List
+// @Summary List API keys
+// @Description Retrieve all API keys
+// @ID v1-api-keys-list
+// @Produce json
+// @Tags API Keys
+// @Security ApiKeyAuth
+// @Param name query string false "filter by part of the name, also name[eq], name[ne] and name[in]"
+// @Param role query string false "filter by role, also role[ne] and role[in]" Enums(reader,editor,admin)
+// @Param active query bool false "filter by status" Enums(true,false)
+// @Param last_used_at[null] query bool false "keys that were never (true) or were (false) used, also last_used_at[gt], last_used_at[gte], last_used_at[lt] and last_used_at[lte]"
+// @Param created_at[gte] query string false "created at or after, YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ, also created_at[gt], created_at[lt] and created_at[lte]"
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order e.g. -created_at"
+// @Param fields query string false "comma separated fields to return e.g. id,name,role"
+// @Param order query string false "order direction" Enums(asc,desc)
+// @Param order_by query string false "order field" Enums(id,name,role,active,created_at)
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous response, replaces page"
+// @Param total query bool false "count the records, false skips the count and returns a total and last_page of -1" Enums(true,false)
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]api_keys.ApiKeyOutput}}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /api-keys [get]
+func (c *apiKeyController) List(ctx *gin.Context) {
+	var req api_keys.ListApiKeyInput
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldFilter(&req.Filter, api_keys.ApiKeyOutput{}),
+	); !ok {
+		return
+	}
+
+	results, apiErr := services.ApiKeyService.List(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
+
+// Delete
+// @Summary Delete API key
+// @Description Endpoint used to revoke an existing API key
+// @ID v1-api-keys-delete
+// @Produce json
+// @Accept json
+// @Tags API Keys
+// @Security ApiKeyAuth
+// @Param id path int true "API Key ID"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /api-keys/{id} [delete]
+func (c *apiKeyController) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_API_KEY_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	if err := services.ApiKeyService.Delete(ctx.Request.Context(), id); err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
@@ -0,0 +1,365 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/api_keys"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockApiKeyService struct {
+	FuncCreate func(req *api_keys.ApiKeyInput) (*api_keys.CreateApiKeyOutput, resterror.RestErrorI)
+	FuncUpdate func(req *api_keys.UpdateApiKeyInput, id int64) resterror.RestErrorI
+	FuncFind   func(id int64) (*api_keys.ApiKeyOutput, resterror.RestErrorI)
+	FuncList   func(req *api_keys.ListApiKeyInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	FuncDelete func(id int64) resterror.RestErrorI
+}
+
+func (m MockApiKeyService) Create(ctx context.Context, req *api_keys.ApiKeyInput) (*api_keys.CreateApiKeyOutput, resterror.RestErrorI) {
+	return m.FuncCreate(req)
+}
+func (m MockApiKeyService) Update(ctx context.Context, req *api_keys.UpdateApiKeyInput, id int64) resterror.RestErrorI {
+	return m.FuncUpdate(req, id)
+}
+func (m MockApiKeyService) Find(ctx context.Context, id int64) (*api_keys.ApiKeyOutput, resterror.RestErrorI) {
+	return m.FuncFind(id)
+}
+func (m MockApiKeyService) List(ctx context.Context, req *api_keys.ListApiKeyInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncList(req)
+}
+func (m MockApiKeyService) Delete(ctx context.Context, id int64) resterror.RestErrorI {
+	return m.FuncDelete(id)
+}
+
+var testApiKeyCreatedAt = time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func TestApiKeyController_Create(t *testing.T) {
+	testCases := []struct {
+		title          string
+		reqBody        io.Reader
+		serviceMock    services.ApiKeyServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error required fields",
+			reqBody:        strings.NewReader(`{}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"name":["The name field is required."],"role":["The role field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:          "error invalid role",
+			reqBody:        strings.NewReader(`{"name":"ci","role":"owner"}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"role":["The field: 'role' must be one of [reader editor admin]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error ApiKeyService.Create",
+			reqBody: strings.NewReader(`{"name":"ci","role":"editor","active":true}`),
+			serviceMock: &MockApiKeyService{
+				FuncCreate: func(req *api_keys.ApiKeyInput) (*api_keys.CreateApiKeyOutput, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title:   "success",
+			reqBody: strings.NewReader(`{"name":"ci","role":"editor","active":true}`),
+			serviceMock: &MockApiKeyService{
+				FuncCreate: func(req *api_keys.ApiKeyInput) (*api_keys.CreateApiKeyOutput, resterror.RestErrorI) {
+					return &api_keys.CreateApiKeyOutput{
+						ApiKeyOutput: api_keys.ApiKeyOutput{
+							ID:        1,
+							Name:      req.Name,
+							KeyPrefix: "fp_12345678",
+							Role:      req.Role,
+							Active:    req.Active,
+							CreatedAt: testApiKeyCreatedAt,
+						},
+						Key: "fp_12345678abc",
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusCreated,
+			expectedRes:    `{"data":{"id":1,"name":"ci","key_prefix":"fp_12345678","role":"editor","active":true,"last_used_at":null,"created_at":"2022-01-01T10:00:00Z","key":"fp_12345678abc"},"code":201}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/api-keys", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.ApiKeyService = testCase.serviceMock
+			ApiKeyController.Create(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestApiKeyController_Update(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		reqBody        io.Reader
+		serviceMock    services.ApiKeyServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid api key id",
+			id:             "abc",
+			reqBody:        strings.NewReader(`{}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_API_KEY_ID","error_code":"INVALID_API_KEY_ID","code":400}`,
+		},
+		{
+			title:          "error invalid role",
+			id:             "1",
+			reqBody:        strings.NewReader(`{"name":"ci","role":"owner"}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"role":["The field: 'role' must be one of [reader editor admin]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error ApiKeyService.Update",
+			id:      "1",
+			reqBody: strings.NewReader(`{"name":"ci","role":"reader"}`),
+			serviceMock: &MockApiKeyService{
+				FuncUpdate: func(req *api_keys.UpdateApiKeyInput, id int64) resterror.RestErrorI {
+					return resterror.NewBadRequestError("INVALID_API_KEY_ID")
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_API_KEY_ID","error_code":"INVALID_API_KEY_ID","code":400}`,
+		},
+		{
+			title:   "success",
+			id:      "1",
+			reqBody: strings.NewReader(`{"name":"ci","role":"reader"}`),
+			serviceMock: &MockApiKeyService{
+				FuncUpdate: func(req *api_keys.UpdateApiKeyInput, id int64) resterror.RestErrorI {
+					return nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("PUT", "https://localhost:8000/v1/api-keys/"+testCase.id, testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.ApiKeyService = testCase.serviceMock
+			ApiKeyController.Update(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestApiKeyController_Find(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.ApiKeyServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid api key id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_API_KEY_ID","error_code":"INVALID_API_KEY_ID","code":400}`,
+		},
+		{
+			title: "error ApiKeyService.Find",
+			id:    "1",
+			serviceMock: &MockApiKeyService{
+				FuncFind: func(id int64) (*api_keys.ApiKeyOutput, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			id:    "1",
+			serviceMock: &MockApiKeyService{
+				FuncFind: func(id int64) (*api_keys.ApiKeyOutput, resterror.RestErrorI) {
+					return &api_keys.ApiKeyOutput{
+						ID:        1,
+						Name:      "ci",
+						KeyPrefix: "fp_12345678",
+						Role:      "reader",
+						Active:    true,
+						CreatedAt: testApiKeyCreatedAt,
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"id":1,"name":"ci","key_prefix":"fp_12345678","role":"reader","active":true,"last_used_at":null,"created_at":"2022-01-01T10:00:00Z"},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/api-keys/"+testCase.id, nil)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.ApiKeyService = testCase.serviceMock
+			ApiKeyController.Find(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestApiKeyController_List(t *testing.T) {
+	testCases := []struct {
+		title          string
+		query          string
+		serviceMock    services.ApiKeyServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error validation invalid role",
+			query:          "?role=owner",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"role":["The field: 'role' must be one of [reader editor admin]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error ApiKeyService.List",
+			query: "?order=asc&order_by=id",
+			serviceMock: &MockApiKeyService{
+				FuncList: func(req *api_keys.ListApiKeyInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			query: "?order=asc&order_by=id",
+			serviceMock: &MockApiKeyService{
+				FuncList: func(req *api_keys.ListApiKeyInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return &pagination.PaginatedResponse{
+						From:        1,
+						Data:        []api_keys.ApiKeyOutput{{ID: 1, Name: "ci", KeyPrefix: "fp_12345678", Role: "admin", Active: true, CreatedAt: testApiKeyCreatedAt}},
+						CurrentPage: 1,
+						LastPage:    1,
+						PerPage:     20,
+						To:          1,
+						Total:       1,
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"from":1,"data":[{"id":1,"name":"ci","key_prefix":"fp_12345678","role":"admin","active":true,"last_used_at":null,"created_at":"2022-01-01T10:00:00Z"}],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/api-keys"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.ApiKeyService = testCase.serviceMock
+			ApiKeyController.List(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestApiKeyController_Delete(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.ApiKeyServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid api key id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_API_KEY_ID","error_code":"INVALID_API_KEY_ID","code":400}`,
+		},
+		{
+			title: "error ApiKeyService.Delete",
+			id:    "1",
+			serviceMock: &MockApiKeyService{
+				FuncDelete: func(id int64) resterror.RestErrorI {
+					return resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			id:    "1",
+			serviceMock: &MockApiKeyService{
+				FuncDelete: func(id int64) resterror.RestErrorI {
+					return nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("DELETE", "https://localhost:8000/v1/api-keys/"+testCase.id, nil)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.ApiKeyService = testCase.serviceMock
+			ApiKeyController.Delete(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/api_keys"
+	"github.com/development-raul/footy-predictor/src/domains/users"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type authControllerInterface interface {
+	Token(ctx *gin.Context)
+	Login(ctx *gin.Context)
+}
+
+type authController struct{}
+
+var AuthController authControllerInterface = &authController{}
+
+// Token
+// @Summary Issue access token
+// @Description Exchange an API key for a short-lived JWT bearer token with the same role
+// @ID v1-auth-token
+// @Produce json
+// @Accept json
+// @Tags Auth
+// @Param JSON request body api_keys.TokenInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorI{data=api_keys.TokenOutput}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /auth/token [post]
+func (c *authController) Token(ctx *gin.Context) {
+	var req api_keys.TokenInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	result, err := services.AuthService.Token(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// Login
+// @Summary Login
+// @Description Exchange the email and password of a registered user for a JWT bearer token
+// @ID v1-auth-login
+// @Produce json
+// @Accept json
+// @Tags Auth
+// @Param JSON request body users.LoginInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorI{data=api_keys.TokenOutput}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /auth/login [post]
+func (c *authController) Login(ctx *gin.Context) {
+	var req users.LoginInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	result, err := services.AuthService.Login(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
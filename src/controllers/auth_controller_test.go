@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/api_keys"
+	"github.com/development-raul/footy-predictor/src/domains/users"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockAuthService struct {
+	FuncAuthenticate func(credential string) (*auth.Claims, resterror.RestErrorI)
+	FuncToken        func(req *api_keys.TokenInput) (*api_keys.TokenOutput, resterror.RestErrorI)
+	FuncLogin        func(req *users.LoginInput) (*api_keys.TokenOutput, resterror.RestErrorI)
+}
+
+func (m MockAuthService) Authenticate(ctx context.Context, credential string) (*auth.Claims, resterror.RestErrorI) {
+	return m.FuncAuthenticate(credential)
+}
+func (m MockAuthService) Token(ctx context.Context, req *api_keys.TokenInput) (*api_keys.TokenOutput, resterror.RestErrorI) {
+	return m.FuncToken(req)
+}
+func (m MockAuthService) Login(ctx context.Context, req *users.LoginInput) (*api_keys.TokenOutput, resterror.RestErrorI) {
+	return m.FuncLogin(req)
+}
+
+func TestAuthController_Token(t *testing.T) {
+	testCases := []struct {
+		title          string
+		reqBody        io.Reader
+		serviceMock    services.AuthServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error required api key",
+			reqBody:        strings.NewReader(`{}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"api_key":["The api key field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error AuthService.Token",
+			reqBody: strings.NewReader(`{"api_key":"fp_key"}`),
+			serviceMock: &MockAuthService{
+				FuncToken: func(req *api_keys.TokenInput) (*api_keys.TokenOutput, resterror.RestErrorI) {
+					return nil, resterror.NewUnauthorizedError("INVALID_USER_AUTHENTICATION")
+				},
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedRes:    `{"error":"INVALID_USER_AUTHENTICATION","error_code":"INVALID_USER_AUTHENTICATION","code":401}`,
+		},
+		{
+			title:   "success",
+			reqBody: strings.NewReader(`{"api_key":"fp_key"}`),
+			serviceMock: &MockAuthService{
+				FuncToken: func(req *api_keys.TokenInput) (*api_keys.TokenOutput, resterror.RestErrorI) {
+					return &api_keys.TokenOutput{
+						Token:     "a.b.c",
+						TokenType: "Bearer",
+						ExpiresAt: time.Date(2022, 1, 1, 11, 0, 0, 0, time.UTC),
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"token":"a.b.c","token_type":"Bearer","expires_at":"2022-01-01T11:00:00Z"},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/auth/token", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.AuthService = testCase.serviceMock
+			AuthController.Token(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestAuthController_Login(t *testing.T) {
+	testCases := []struct {
+		title          string
+		reqBody        io.Reader
+		serviceMock    services.AuthServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid email",
+			reqBody:        strings.NewReader(`{"email":"john","password":"secret"}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"email":["The email must be a valid email address."]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error AuthService.Login",
+			reqBody: strings.NewReader(`{"email":"john@test.com","password":"secret"}`),
+			serviceMock: &MockAuthService{
+				FuncLogin: func(req *users.LoginInput) (*api_keys.TokenOutput, resterror.RestErrorI) {
+					return nil, resterror.NewUnauthorizedError("INVALID_CREDENTIALS")
+				},
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedRes:    `{"error":"INVALID_CREDENTIALS","error_code":"INVALID_CREDENTIALS","code":401}`,
+		},
+		{
+			title:   "success",
+			reqBody: strings.NewReader(`{"email":"john@test.com","password":"secret"}`),
+			serviceMock: &MockAuthService{
+				FuncLogin: func(req *users.LoginInput) (*api_keys.TokenOutput, resterror.RestErrorI) {
+					return &api_keys.TokenOutput{
+						Token:     "a.b.c",
+						TokenType: "Bearer",
+						ExpiresAt: time.Date(2022, 1, 1, 11, 0, 0, 0, time.UTC),
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"token":"a.b.c","token_type":"Bearer","expires_at":"2022-01-01T11:00:00Z"},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/auth/login", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.AuthService = testCase.serviceMock
+			AuthController.Login(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
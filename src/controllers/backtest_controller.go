@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/backtests"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type backtestControllerInterface interface {
+	Create(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Find(ctx *gin.Context)
+	Predictions(ctx *gin.Context)
+}
+
+type backtestController struct{}
+
+var BacktestController backtestControllerInterface = &backtestController{}
+
+// Create
+// @Summary Start backtest
+// @Description Start a backtest of a model over the finished fixtures of a league between two seasons. Every fixture is predicted in kickoff order by the model fitted on the results known before it and bet on with the 1x2 odds recorded before it. The backtest runs in the background, it is returned pending and its status is finished or failed once the results are saved
+// @ID v1-backtests-create
+// @Produce json
+// @Accept json
+// @Tags Backtests
+// @Security ApiKeyAuth
+// @Param JSON request body backtests.CreateBacktestInput true "Request Sample"
+// @Success 202 {object} swaggertypes.NoErrorI{data=backtests.Backtest}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /backtests [post]
+func (c *backtestController) Create(ctx *gin.Context) {
+	var req backtests.CreateBacktestInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	result, err := services.BacktestService.Create(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusAccepted,
+	})
+}
+
+// List
+// @Summary List backtests
+// @Description Retrieve the backtests, filtering on a league and sorting on a metric compares the runs of the models
+// @ID v1-backtests-list
+// @Produce json
+// @Tags Backtests
+// @Security ApiKeyAuth
+// @Param league_id query integer false "filter by league, also league_id[in]"
+// @Param model query string false "filter by model, also model[in]"
+// @Param status query string false "filter by status, also status[in]" Enums(pending,running,finished,failed)
+// @Param season_from query integer false "filter by first season, also season_from[gte] and season_from[lte]"
+// @Param season_to query integer false "filter by last season, also season_to[gte] and season_to[lte]"
+// @Param log_loss[lte] query number false "log-loss at most, also log_loss[lt] and log_loss[null]"
+// @Param roi[gte] query number false "ROI at least, also roi[gt], roi[lt], roi[lte] and roi[null]"
+// @Param sort query string false "comma separated sort fields e.g. log_loss, brier, rps, -accuracy or -roi, prefixed with - for descending order, -created_at by default"
+// @Param fields query string false "comma separated fields to return e.g. id,model,log_loss,roi"
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous response, replaces page"
+// @Param total query bool false "count the records, false skips the count and returns a total and last_page of -1" Enums(true,false)
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]backtests.Backtest}}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /backtests [get]
+func (c *backtestController) List(ctx *gin.Context) {
+	var req backtests.ListBacktestInput
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldFilter(&req.Filter, backtests.Backtest{}),
+	); !ok {
+		return
+	}
+
+	results, apiErr := services.BacktestService.List(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
+
+// Find
+// @Summary Find backtest
+// @Description Retrieve a backtest identified by id with its status and, once finished, its average log-loss, Brier score, ranked probability score, accuracy and the profit and ROI of its simulated bets
+// @ID v1-backtests-find
+// @Produce json
+// @Tags Backtests
+// @Security ApiKeyAuth
+// @Param id path int true "Backtest ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=backtests.Backtest}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /backtests/{id} [get]
+func (c *backtestController) Find(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_BACKTEST_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.BacktestService.Find(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// Predictions
+// @Summary Backtest predictions
+// @Description Retrieve the predictions of a backtest with the result of their fixture and their simulated bet
+// @ID v1-backtests-predictions
+// @Produce json
+// @Tags Backtests
+// @Security ApiKeyAuth
+// @Param id path int true "Backtest ID"
+// @Param fixture_id query integer false "filter by fixture, also fixture_id[in]"
+// @Param result query string false "filter by result, also result[in]" Enums(home,draw,away)
+// @Param bet query string false "filter by the selection bet on, also bet[ne] and bet[in], bet[ne]= keeps the bets"
+// @Param kickoff_at[gte] query string false "kickoff at or after, YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ, also kickoff_at[gt], kickoff_at[lt] and kickoff_at[lte]"
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order, kickoff_at by default"
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Param total query bool false "count the records, false skips the count and returns a total and last_page of -1" Enums(true,false)
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]backtests.Prediction}}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /backtests/{id}/predictions [get]
+func (c *backtestController) Predictions(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_BACKTEST_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	var req backtests.ListPredictionInput
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldFilter(&req.Filter, backtests.Prediction{}),
+	); !ok {
+		return
+	}
+	req.BacktestID = id
+
+	results, apiErr := services.BacktestService.Predictions(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
@@ -0,0 +1,327 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/backtests"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testBacktestJSON = `{"id":1,"league_id":39,"season_from":2020,"season_to":2021,"model":"poisson","min_edge":0.05,"status":"pending","error":"","fixtures":0,"skipped":0,"log_loss":null,"brier":null,"rps":null,"accuracy":null,"bets":0,"staked":0,"profit":0,"roi":null,"created_at":"2022-01-14T10:00:00Z","started_at":null,"finished_at":null}`
+
+type MockBacktestService struct {
+	FuncCreate      func(req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI)
+	FuncRun         func(req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI)
+	FuncFind        func(id int64) (*backtests.Backtest, resterror.RestErrorI)
+	FuncList        func(req *backtests.ListBacktestInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	FuncPredictions func(req *backtests.ListPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+}
+
+func (m MockBacktestService) Create(ctx context.Context, req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI) {
+	return m.FuncCreate(req)
+}
+func (m MockBacktestService) Run(ctx context.Context, req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI) {
+	return m.FuncRun(req)
+}
+func (m MockBacktestService) Find(ctx context.Context, id int64) (*backtests.Backtest, resterror.RestErrorI) {
+	return m.FuncFind(id)
+}
+func (m MockBacktestService) List(ctx context.Context, req *backtests.ListBacktestInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncList(req)
+}
+func (m MockBacktestService) Predictions(ctx context.Context, req *backtests.ListPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncPredictions(req)
+}
+
+func testBacktest() *backtests.Backtest {
+	return &backtests.Backtest{
+		ID:         1,
+		LeagueID:   39,
+		SeasonFrom: 2020,
+		SeasonTo:   2021,
+		Model:      "poisson",
+		MinEdge:    0.05,
+		Status:     backtests.StatusPending,
+		CreatedAt:  time.Date(2022, 1, 14, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestBacktestController_Create(t *testing.T) {
+	testCases := []struct {
+		title          string
+		reqBody        io.Reader
+		serviceMock    services.BacktestServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error required fields",
+			reqBody:        strings.NewReader(`{}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"league_id":["The league id field is required."],"season_from":["The season from field is required."],"season_to":["The season to field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:          "error invalid model",
+			reqBody:        strings.NewReader(`{"league_id":39,"season_from":2020,"season_to":2021,"model":"` + strings.Repeat("x", 51) + `"}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"model":["the model must have a length less than 50"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error BacktestService.Create",
+			reqBody: strings.NewReader(`{"league_id":39,"season_from":2021,"season_to":2020}`),
+			serviceMock: &MockBacktestService{
+				FuncCreate: func(req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI) {
+					return nil, resterror.NewBadRequestError("INVALID_SEASON_RANGE")
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_SEASON_RANGE","error_code":"INVALID_SEASON_RANGE","code":400}`,
+		},
+		{
+			title:   "success",
+			reqBody: strings.NewReader(`{"league_id":39,"season_from":2020,"season_to":2021,"model":"poisson","min_edge":0.05}`),
+			serviceMock: &MockBacktestService{
+				FuncCreate: func(req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI) {
+					if req.LeagueID != 39 || req.SeasonFrom != 2020 || req.SeasonTo != 2021 || *req.MinEdge != 0.05 {
+						return nil, resterror.NewStandardInternalServerError()
+					}
+					return testBacktest(), nil
+				},
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedRes:    `{"data":` + testBacktestJSON + `,"code":202}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/backtests", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.BacktestService = testCase.serviceMock
+			BacktestController.Create(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestBacktestController_List(t *testing.T) {
+	testCases := []struct {
+		title          string
+		query          string
+		serviceMock    services.BacktestServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error filter invalid status",
+			query:          "?status=done",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"status":["The field: 'status' must be one of [pending running finished failed]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error BacktestService.List",
+			query: "?league_id=39",
+			serviceMock: &MockBacktestService{
+				FuncList: func(req *backtests.ListBacktestInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			query: "?league_id=39&sort=log_loss",
+			serviceMock: &MockBacktestService{
+				FuncList: func(req *backtests.ListBacktestInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return &pagination.PaginatedResponse{
+						Data:        []backtests.Backtest{*testBacktest()},
+						CurrentPage: 1,
+						LastPage:    1,
+						PerPage:     20,
+						From:        1,
+						To:          1,
+						Total:       1,
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"from":1,"data":[` + testBacktestJSON + `],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/backtests"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.BacktestService = testCase.serviceMock
+			BacktestController.List(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestBacktestController_Find(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.BacktestServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid backtest id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_BACKTEST_ID","error_code":"INVALID_BACKTEST_ID","code":400}`,
+		},
+		{
+			title: "error BacktestService.Find",
+			id:    "1",
+			serviceMock: &MockBacktestService{
+				FuncFind: func(id int64) (*backtests.Backtest, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			id:    "1",
+			serviceMock: &MockBacktestService{
+				FuncFind: func(id int64) (*backtests.Backtest, resterror.RestErrorI) {
+					return testBacktest(), nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":` + testBacktestJSON + `,"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/backtests/"+testCase.id, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.BacktestService = testCase.serviceMock
+			BacktestController.Find(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestBacktestController_Predictions(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		query          string
+		serviceMock    services.BacktestServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid backtest id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_BACKTEST_ID","error_code":"INVALID_BACKTEST_ID","code":400}`,
+		},
+		{
+			title:          "error filter invalid result",
+			id:             "1",
+			query:          "?result=win",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"result":["The field: 'result' must be one of [home draw away]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error BacktestService.Predictions",
+			id:    "1",
+			serviceMock: &MockBacktestService{
+				FuncPredictions: func(req *backtests.ListPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			id:    "1",
+			query: "?bet=home",
+			serviceMock: &MockBacktestService{
+				FuncPredictions: func(req *backtests.ListPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					if req.BacktestID != 1 {
+						return nil, resterror.NewStandardInternalServerError()
+					}
+					return &pagination.PaginatedResponse{
+						Data: []backtests.Prediction{{
+							BacktestID:  1,
+							FixtureID:   10,
+							KickoffAt:   time.Date(2021, 8, 14, 14, 0, 0, 0, time.UTC),
+							MatchesUsed: 380,
+							HomeWin:     0.5,
+							Draw:        0.3,
+							AwayWin:     0.2,
+							Result:      "home",
+							Bet:         "home",
+							Price:       2.2,
+							Profit:      1.2,
+						}},
+						CurrentPage: 1,
+						LastPage:    1,
+						PerPage:     20,
+						From:        1,
+						To:          1,
+						Total:       1,
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"from":1,"data":[{"backtest_id":1,"fixture_id":10,"kickoff_at":"2021-08-14T14:00:00Z","matches_used":380,"home_win":0.5,"draw":0.3,"away_win":0.2,"result":"home","bet":"home","price":2.2,"profit":1.2}],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/backtests/"+testCase.id+"/predictions"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.BacktestService = testCase.serviceMock
+			BacktestController.Predictions(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/brackets"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type bracketControllerInterface interface {
+	Find(ctx *gin.Context)
+	Simulate(ctx *gin.Context)
+}
+
+type bracketController struct{}
+
+var BracketController bracketControllerInterface = &bracketController{}
+
+// Find
+// @Summary Get cup bracket
+// @Description Get the knockout rounds of a cup season in the order they are played, with the ties drawn so far, their fixtures, aggregate score and winner. The rounds are the league.round of the synced fixtures
+// @ID v1-leagues-bracket
+// @Produce json
+// @Tags Predictions
+// @Security ApiKeyAuth
+// @Param id path int true "League ID"
+// @Param season path int true "Season e.g. 2021"
+// @Success 200 {object} swaggertypes.NoErrorI{data=brackets.Bracket}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 422 {object} swaggertypes.StandardBadRequestError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /leagues/{id}/seasons/{season}/bracket [get]
+func (c *bracketController) Find(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_LEAGUE_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	season, err := strconv.ParseInt(ctx.Param("season"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_SEASON")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	result, apiErr := services.BracketService.Find(ctx.Request.Context(), id, season)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// Simulate
+// @Summary Simulate cup season
+// @Description Simulate the group fixtures and knockout ties left in a cup season many times, drawing the scores from the model fitted on the results known now, and retrieve the probability of each team reaching each knockout round and winning the cup. A tie level on aggregate goes to extra time then to a penalty shootout, the teams not drawn yet are drawn at random. The teams are sorted by the probability of winning the cup and the same seed gives the same simulation
+// @ID v1-leagues-bracket-simulation
+// @Produce json
+// @Tags Predictions
+// @Security ApiKeyAuth
+// @Param id path int true "League ID"
+// @Param season path int true "Season e.g. 2021"
+// @Param model query string false "model version e.g. dixon-coles@3, or a model name for its latest version, the champion by default"
+// @Param runs query integer false "number of simulated cups, between 100 and 100000, 10000 by default"
+// @Param seed query integer false "seed of the random numbers, a random one by default which the response returns"
+// @Param qualifiers query integer false "number of teams of each group going through to the knockout rounds, 2 by default"
+// @Success 200 {object} swaggertypes.NoErrorI{data=brackets.BracketSimulation}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 422 {object} swaggertypes.StandardBadRequestError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /leagues/{id}/seasons/{season}/bracket/simulation [get]
+func (c *bracketController) Simulate(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_LEAGUE_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	season, err := strconv.ParseInt(ctx.Param("season"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_SEASON")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	var req brackets.BracketSimulationInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+	req.LeagueID, req.Season = id, season
+
+	result, apiErr := services.BracketService.Simulate(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/brackets"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockBracketService struct {
+	FuncFind     func(leagueID int64, season int64) (*brackets.Bracket, resterror.RestErrorI)
+	FuncSimulate func(req *brackets.BracketSimulationInput) (*brackets.BracketSimulation, resterror.RestErrorI)
+}
+
+func (m MockBracketService) Find(ctx context.Context, leagueID int64, season int64) (*brackets.Bracket, resterror.RestErrorI) {
+	return m.FuncFind(leagueID, season)
+}
+func (m MockBracketService) Simulate(ctx context.Context, req *brackets.BracketSimulationInput) (*brackets.BracketSimulation, resterror.RestErrorI) {
+	return m.FuncSimulate(req)
+}
+
+func TestBracketController_Find(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		season         string
+		serviceMock    services.BracketServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid league id",
+			id:             "abc",
+			season:         "2021",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_LEAGUE_ID","error_code":"INVALID_LEAGUE_ID","code":400}`,
+		},
+		{
+			title:          "error invalid season",
+			id:             "2",
+			season:         "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_SEASON","error_code":"INVALID_SEASON","code":400}`,
+		},
+		{
+			title:  "error BracketService.Find",
+			id:     "39",
+			season: "2021",
+			serviceMock: &MockBracketService{
+				FuncFind: func(leagueID int64, season int64) (*brackets.Bracket, resterror.RestErrorI) {
+					return nil, resterror.NewUnprocessableEntityError("NOT_A_CUP")
+				},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedRes:    `{"error":"NOT_A_CUP","error_code":"NOT_A_CUP","code":422}`,
+		},
+		{
+			title:  "success",
+			id:     "2",
+			season: "2021",
+			serviceMock: &MockBracketService{
+				FuncFind: func(leagueID int64, season int64) (*brackets.Bracket, resterror.RestErrorI) {
+					if leagueID != 2 || season != 2021 {
+						return nil, resterror.NewStandardInternalServerError()
+					}
+					homeGoals, awayGoals, winner := int64(3), int64(1), int64(1)
+					return &brackets.Bracket{LeagueID: 2, Season: 2021, Rounds: []brackets.Round{
+						{Name: "Final", Legs: 1, Ties: []brackets.Tie{
+							{HomeTeamID: 1, HomeTeamName: "Chelsea", AwayTeamID: 2, AwayTeamName: "Manchester City",
+								FixtureIDs: []int64{10}, HomeGoals: &homeGoals, AwayGoals: &awayGoals, WinnerTeamID: &winner},
+						}},
+					}}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":{"league_id":2,"season":2021,"rounds":[{"name":"Final","legs":1,"ties":[` +
+				`{"home_team_id":1,"home_team_name":"Chelsea","away_team_id":2,"away_team_name":"Manchester City","fixture_ids":[10],"home_goals":3,"away_goals":1,"winner_team_id":1}]}]},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/leagues/"+testCase.id+"/seasons/"+testCase.season+"/bracket", nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}, {Key: "season", Value: testCase.season}}
+
+			services.BracketService = testCase.serviceMock
+			BracketController.Find(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestBracketController_Simulate(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		season         string
+		query          string
+		serviceMock    services.BracketServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid league id",
+			id:             "abc",
+			season:         "2021",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_LEAGUE_ID","error_code":"INVALID_LEAGUE_ID","code":400}`,
+		},
+		{
+			title:          "error invalid season",
+			id:             "2",
+			season:         "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_SEASON","error_code":"INVALID_SEASON","code":400}`,
+		},
+		{
+			title:          "error too many runs",
+			id:             "2",
+			season:         "2021",
+			query:          "?runs=200000",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"runs":["The runs must be at most 100000"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:  "error BracketService.Simulate",
+			id:     "2",
+			season: "2021",
+			serviceMock: &MockBracketService{
+				FuncSimulate: func(req *brackets.BracketSimulationInput) (*brackets.BracketSimulation, resterror.RestErrorI) {
+					return nil, resterror.NewNotFoundError("ROUNDS_NOT_FOUND")
+				},
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedRes:    `{"error":"ROUNDS_NOT_FOUND","error_code":"ROUNDS_NOT_FOUND","code":404}`,
+		},
+		{
+			title:  "success",
+			id:     "2",
+			season: "2021",
+			query:  "?runs=1000&seed=7&qualifiers=1",
+			serviceMock: &MockBracketService{
+				FuncSimulate: func(req *brackets.BracketSimulationInput) (*brackets.BracketSimulation, resterror.RestErrorI) {
+					if req.LeagueID != 2 || req.Season != 2021 || req.Runs != 1000 || *req.Seed != 7 || *req.Qualifiers != 1 {
+						return nil, resterror.NewStandardInternalServerError()
+					}
+					return &brackets.BracketSimulation{
+						LeagueID:   2,
+						Season:     2021,
+						Model:      "poisson@1",
+						Runs:       1000,
+						Seed:       7,
+						Qualifiers: 1,
+						Rounds:     []string{"Final"},
+						Teams: []brackets.Team{
+							{TeamID: 1, TeamName: "Chelsea", Rounds: []float64{1}, Winner: 0.625},
+							{TeamID: 2, TeamName: "Manchester City", Rounds: []float64{1}, Winner: 0.375},
+						},
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":{"league_id":2,"season":2021,"model":"poisson@1","runs":1000,"seed":7,"qualifiers":1,"rounds":["Final"],"teams":[` +
+				`{"team_id":1,"team_name":"Chelsea","rounds":[1],"winner":0.625},` +
+				`{"team_id":2,"team_name":"Manchester City","rounds":[1],"winner":0.375}]},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/leagues/"+testCase.id+"/seasons/"+testCase.season+"/bracket/simulation"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}, {Key: "season", Value: testCase.season}}
+
+			services.BracketService = testCase.serviceMock
+			BracketController.Simulate(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/competitions"
+	"github.com/development-raul/footy-predictor/src/domains/memberships"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type competitionControllerInterface interface {
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Find(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	Join(ctx *gin.Context)
+	Leave(ctx *gin.Context)
+	Leaderboard(ctx *gin.Context)
+}
+
+type competitionController struct{}
+
+var CompetitionController competitionControllerInterface = &competitionController{}
+
+// Create
+// @Summary Create competition
+// @Description Endpoint used to create a new prediction competition. The creator automatically joins it.
+// @ID v1-competitions-create
+// @Produce json
+// @Accept json
+// @Tags Competitions
+// @Security ApiKeyAuth
+// @Param JSON request body competitions.CompetitionInput true "Request Sample"
+// @Success 201 {object} swaggertypes.NoErrorI{data=competitions.Competition}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /competitions [post]
+func (c *competitionController) Create(ctx *gin.Context) {
+	var userID int64
+	var req competitions.CompetitionInput
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBeUser(&userID),
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+	); !ok {
+		return
+	}
+
+	result, err := services.CompetitionService.Create(ctx.Request.Context(), userID, &req)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusCreated,
+	})
+}
+
+// Update
+// @Summary Update competition
+// @Description Endpoint used by the owner to update the name or scoring rules of a competition
+// @ID v1-competitions-update
+// @Produce json
+// @Accept json
+// @Tags Competitions
+// @Security ApiKeyAuth
+// @Param id path int true "Competition ID"
+// @Param JSON request body competitions.UpdateCompetitionInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /competitions/{id} [put]
+func (c *competitionController) Update(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_COMPETITION_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	var userID int64
+	var req competitions.UpdateCompetitionInput
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBeUser(&userID),
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+	); !ok {
+		return
+	}
+	req.ID = id
+
+	if err := services.CompetitionService.Update(ctx.Request.Context(), userID, &req); err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
+
+// Find
+// @Summary Find competition
+// @Description Retrieve a competition identified by id
+// @ID v1-competitions-find
+// @Produce json
+// @Tags Competitions
+// @Security ApiKeyAuth
+// @Param id path int true "Competition ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=competitions.Competition}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /competitions/{id} [get]
+func (c *competitionController) Find(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_COMPETITION_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.CompetitionService.Find(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// List
+// @Summary List competitions
+// @Description Retrieve competitions, optionally only the ones the authenticated user takes part in
+// @ID v1-competitions-list
+// @Produce json
+// @Tags Competitions
+// @Security ApiKeyAuth
+// @Param name query string false "filter by part of the name, also name[eq], name[ne] and name[in]"
+// @Param owner_user_id query integer false "filter by owner, also owner_user_id[ne] and owner_user_id[in]"
+// @Param created_at[gte] query string false "created at or after, YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ, also created_at[gt], created_at[lt] and created_at[lte]"
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order e.g. -created_at"
+// @Param fields query string false "comma separated fields to return e.g. id,name"
+// @Param mine query bool false "only competitions the user is a member of" Enums(true,false)
+// @Param order query string false "order direction" Enums(asc,desc)
+// @Param order_by query string false "order field" Enums(id,name,created_at)
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous response, replaces page"
+// @Param total query bool false "count the records, false skips the count and returns a total and last_page of -1" Enums(true,false)
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]competitions.Competition}}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /competitions [get]
+func (c *competitionController) List(ctx *gin.Context) {
+	var req competitions.ListCompetitionInput
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldFilter(&req.Filter, competitions.Competition{}),
+	); !ok {
+		return
+	}
+	if req.Mine {
+		if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBeUser(&req.UserID)); !ok {
+			return
+		}
+	}
+
+	results, apiErr := services.CompetitionService.List(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
+
+// Delete
+// @Summary Delete competition
+// @Description Endpoint used by the owner to delete a competition
+// @ID v1-competitions-delete
+// @Produce json
+// @Accept json
+// @Tags Competitions
+// @Security ApiKeyAuth
+// @Param id path int true "Competition ID"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /competitions/{id} [delete]
+func (c *competitionController) Delete(ctx *gin.Context) {
+	userID, id, ok := c.memberRequest(ctx)
+	if !ok {
+		return
+	}
+
+	if err := services.CompetitionService.Delete(ctx.Request.Context(), userID, id); err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
+
+// Join
+// @Summary Join competition
+// @Description Endpoint used by the authenticated user to join a competition
+// @ID v1-competitions-join
+// @Produce json
+// @Tags Competitions
+// @Security ApiKeyAuth
+// @Param id path int true "Competition ID"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 409 {object} swaggertypes.StandardConflictError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /competitions/{id}/members [post]
+func (c *competitionController) Join(ctx *gin.Context) {
+	userID, id, ok := c.memberRequest(ctx)
+	if !ok {
+		return
+	}
+
+	if err := services.CompetitionService.Join(ctx.Request.Context(), userID, id); err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
+
+// Leave
+// @Summary Leave competition
+// @Description Endpoint used by the authenticated user to leave a competition
+// @ID v1-competitions-leave
+// @Produce json
+// @Tags Competitions
+// @Security ApiKeyAuth
+// @Param id path int true "Competition ID"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /competitions/{id}/members [delete]
+func (c *competitionController) Leave(ctx *gin.Context) {
+	userID, id, ok := c.memberRequest(ctx)
+	if !ok {
+		return
+	}
+
+	if err := services.CompetitionService.Leave(ctx.Request.Context(), userID, id); err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
+
+// Leaderboard
+// @Summary Competition leaderboard
+// @Description Retrieve the members of a competition ranked by points, then exact scores
+// @ID v1-competitions-leaderboard
+// @Produce json
+// @Tags Competitions
+// @Security ApiKeyAuth
+// @Param id path int true "Competition ID"
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]memberships.LeaderboardEntry}}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /competitions/{id}/leaderboard [get]
+func (c *competitionController) Leaderboard(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_COMPETITION_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	var req memberships.ListLeaderboardInput
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+	); !ok {
+		return
+	}
+
+	results, apiErr := services.CompetitionService.Leaderboard(ctx.Request.Context(), id, &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
+
+// memberRequest reads the competition id from the path and the id of the authenticated user
+func (c *competitionController) memberRequest(ctx *gin.Context) (int64, int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_COMPETITION_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return 0, 0, false
+	}
+
+	var userID int64
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBeUser(&userID)); !ok {
+		return 0, 0, false
+	}
+	return userID, id, true
+}
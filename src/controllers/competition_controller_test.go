@@ -0,0 +1,484 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/competitions"
+	"github.com/development-raul/footy-predictor/src/domains/memberships"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockCompetitionService struct {
+	FuncCreate      func(userID int64, req *competitions.CompetitionInput) (*competitions.Competition, resterror.RestErrorI)
+	FuncUpdate      func(userID int64, req *competitions.UpdateCompetitionInput) resterror.RestErrorI
+	FuncFind        func(id int64) (*competitions.Competition, resterror.RestErrorI)
+	FuncList        func(req *competitions.ListCompetitionInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	FuncDelete      func(userID int64, id int64) resterror.RestErrorI
+	FuncJoin        func(userID int64, id int64) resterror.RestErrorI
+	FuncLeave       func(userID int64, id int64) resterror.RestErrorI
+	FuncLeaderboard func(id int64, req *memberships.ListLeaderboardInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+}
+
+func (m MockCompetitionService) Create(ctx context.Context, userID int64, req *competitions.CompetitionInput) (*competitions.Competition, resterror.RestErrorI) {
+	return m.FuncCreate(userID, req)
+}
+func (m MockCompetitionService) Update(ctx context.Context, userID int64, req *competitions.UpdateCompetitionInput) resterror.RestErrorI {
+	return m.FuncUpdate(userID, req)
+}
+func (m MockCompetitionService) Find(ctx context.Context, id int64) (*competitions.Competition, resterror.RestErrorI) {
+	return m.FuncFind(id)
+}
+func (m MockCompetitionService) List(ctx context.Context, req *competitions.ListCompetitionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncList(req)
+}
+func (m MockCompetitionService) Delete(ctx context.Context, userID int64, id int64) resterror.RestErrorI {
+	return m.FuncDelete(userID, id)
+}
+func (m MockCompetitionService) Join(ctx context.Context, userID int64, id int64) resterror.RestErrorI {
+	return m.FuncJoin(userID, id)
+}
+func (m MockCompetitionService) Leave(ctx context.Context, userID int64, id int64) resterror.RestErrorI {
+	return m.FuncLeave(userID, id)
+}
+func (m MockCompetitionService) Leaderboard(ctx context.Context, id int64, req *memberships.ListLeaderboardInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncLeaderboard(id, req)
+}
+
+var testCompetition = competitions.Competition{
+	ID:                   4,
+	Name:                 "Office",
+	OwnerUserID:          1,
+	PointsExact:          3,
+	PointsGoalDifference: 2,
+	PointsResult:         1,
+	CreatedAt:            time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC),
+}
+
+const testCompetitionJSON = `{"id":4,"name":"Office","owner_user_id":1,"points_exact":3,"points_goal_difference":2,"points_result":1,"created_at":"2022-01-01T10:00:00Z"}`
+
+func TestCompetitionController_Create(t *testing.T) {
+	testCases := []struct {
+		title          string
+		subject        string
+		reqBody        io.Reader
+		serviceMock    services.CompetitionServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error api key caller",
+			subject:        "api_key:1",
+			reqBody:        strings.NewReader(`{}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusForbidden,
+			expectedRes:    `{"error":"USER_ACCOUNT_REQUIRED","error_code":"USER_ACCOUNT_REQUIRED","code":403}`,
+		},
+		{
+			title:          "error required fields",
+			subject:        "user:1",
+			reqBody:        strings.NewReader(`{}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"name":["The name field is required."],"points_exact":["The points exact field is required."],"points_result":["The points result field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error CompetitionService.Create",
+			subject: "user:1",
+			reqBody: strings.NewReader(`{"name":"Office","points_exact":3,"points_goal_difference":2,"points_result":1}`),
+			serviceMock: &MockCompetitionService{
+				FuncCreate: func(userID int64, req *competitions.CompetitionInput) (*competitions.Competition, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title:   "success",
+			subject: "user:1",
+			reqBody: strings.NewReader(`{"name":"Office","points_exact":3,"points_goal_difference":2,"points_result":1}`),
+			serviceMock: &MockCompetitionService{
+				FuncCreate: func(userID int64, req *competitions.CompetitionInput) (*competitions.Competition, resterror.RestErrorI) {
+					return &testCompetition, nil
+				},
+			},
+			expectedStatus: http.StatusCreated,
+			expectedRes:    `{"data":` + testCompetitionJSON + `,"code":201}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/competitions", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Set(constants.ContextKeyAuthSubject, testCase.subject)
+
+			services.CompetitionService = testCase.serviceMock
+			CompetitionController.Create(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestCompetitionController_Update(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		reqBody        io.Reader
+		serviceMock    services.CompetitionServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid competition id",
+			id:             "abc",
+			reqBody:        strings.NewReader(`{}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_COMPETITION_ID","error_code":"INVALID_COMPETITION_ID","code":400}`,
+		},
+		{
+			title:   "error CompetitionService.Update",
+			id:      "4",
+			reqBody: strings.NewReader(`{"name":"Office","points_exact":3,"points_result":1}`),
+			serviceMock: &MockCompetitionService{
+				FuncUpdate: func(userID int64, req *competitions.UpdateCompetitionInput) resterror.RestErrorI {
+					return resterror.NewForbiddenError("NOT_COMPETITION_OWNER")
+				},
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedRes:    `{"error":"NOT_COMPETITION_OWNER","error_code":"NOT_COMPETITION_OWNER","code":403}`,
+		},
+		{
+			title:   "success",
+			id:      "4",
+			reqBody: strings.NewReader(`{"name":"Office","points_exact":3,"points_result":1}`),
+			serviceMock: &MockCompetitionService{
+				FuncUpdate: func(userID int64, req *competitions.UpdateCompetitionInput) resterror.RestErrorI {
+					if req.ID != 4 || userID != 1 {
+						return resterror.NewBadRequestError("INVALID_COMPETITION_ID")
+					}
+					return nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("PUT", "https://localhost:8000/v1/competitions/"+testCase.id, testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+			c.Set(constants.ContextKeyAuthSubject, "user:1")
+
+			services.CompetitionService = testCase.serviceMock
+			CompetitionController.Update(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestCompetitionController_Find(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.CompetitionServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid competition id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_COMPETITION_ID","error_code":"INVALID_COMPETITION_ID","code":400}`,
+		},
+		{
+			title: "error competition not found",
+			id:    "4",
+			serviceMock: &MockCompetitionService{
+				FuncFind: func(id int64) (*competitions.Competition, resterror.RestErrorI) {
+					return nil, resterror.NewDatabaseError(sql.ErrNoRows, "competition")
+				},
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedRes:    `{"error":"The competition does not exist","error_code":"COMPETITION_NOT_FOUND","code":404}`,
+		},
+		{
+			title: "error CompetitionService.Find",
+			id:    "4",
+			serviceMock: &MockCompetitionService{
+				FuncFind: func(id int64) (*competitions.Competition, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			id:    "4",
+			serviceMock: &MockCompetitionService{
+				FuncFind: func(id int64) (*competitions.Competition, resterror.RestErrorI) {
+					return &testCompetition, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":` + testCompetitionJSON + `,"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/competitions/"+testCase.id, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.CompetitionService = testCase.serviceMock
+			CompetitionController.Find(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestCompetitionController_List(t *testing.T) {
+	testCases := []struct {
+		title          string
+		query          string
+		subject        string
+		serviceMock    services.CompetitionServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error validation invalid order_by",
+			query:          "?order_by=owner",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"order_by":["The field: 'order_by' must be one of [id name created_at]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:          "error mine requires a user",
+			query:          "?mine=true",
+			subject:        "api_key:1",
+			serviceMock:    nil,
+			expectedStatus: http.StatusForbidden,
+			expectedRes:    `{"error":"USER_ACCOUNT_REQUIRED","error_code":"USER_ACCOUNT_REQUIRED","code":403}`,
+		},
+		{
+			title: "error CompetitionService.List",
+			serviceMock: &MockCompetitionService{
+				FuncList: func(req *competitions.ListCompetitionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title:   "success",
+			query:   "?mine=true",
+			subject: "user:1",
+			serviceMock: &MockCompetitionService{
+				FuncList: func(req *competitions.ListCompetitionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					if req.UserID != 1 {
+						return nil, resterror.NewStandardInternalServerError()
+					}
+					res := pagination.GeneratePaginatedResponse([]competitions.Competition{testCompetition}, req.Page, req.PerPage, 1)
+					return &res, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"from":1,"data":[` + testCompetitionJSON + `],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/competitions"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Set(constants.ContextKeyAuthSubject, testCase.subject)
+
+			services.CompetitionService = testCase.serviceMock
+			CompetitionController.List(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestCompetitionController_MemberActions(t *testing.T) {
+	success := func(userID int64, id int64) resterror.RestErrorI {
+		if userID != 1 || id != 4 {
+			return resterror.NewBadRequestError("INVALID_COMPETITION_ID")
+		}
+		return nil
+	}
+	failure := func(userID int64, id int64) resterror.RestErrorI {
+		return resterror.NewConflictError("ALREADY_A_MEMBER")
+	}
+
+	testCases := []struct {
+		title          string
+		id             string
+		subject        string
+		handler        func(ctx *gin.Context)
+		serviceMock    services.CompetitionServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "Join error invalid competition id",
+			id:             "abc",
+			subject:        "user:1",
+			handler:        CompetitionController.Join,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_COMPETITION_ID","error_code":"INVALID_COMPETITION_ID","code":400}`,
+		},
+		{
+			title:          "Join error api key caller",
+			id:             "4",
+			subject:        "api_key:1",
+			handler:        CompetitionController.Join,
+			expectedStatus: http.StatusForbidden,
+			expectedRes:    `{"error":"USER_ACCOUNT_REQUIRED","error_code":"USER_ACCOUNT_REQUIRED","code":403}`,
+		},
+		{
+			title:          "Join error CompetitionService.Join",
+			id:             "4",
+			subject:        "user:1",
+			handler:        CompetitionController.Join,
+			serviceMock:    &MockCompetitionService{FuncJoin: failure},
+			expectedStatus: http.StatusConflict,
+			expectedRes:    `{"error":"ALREADY_A_MEMBER","error_code":"ALREADY_A_MEMBER","code":409}`,
+		},
+		{
+			title:          "Join success",
+			id:             "4",
+			subject:        "user:1",
+			handler:        CompetitionController.Join,
+			serviceMock:    &MockCompetitionService{FuncJoin: success},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+		},
+		{
+			title:          "Leave success",
+			id:             "4",
+			subject:        "user:1",
+			handler:        CompetitionController.Leave,
+			serviceMock:    &MockCompetitionService{FuncLeave: success},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+		},
+		{
+			title:          "Delete success",
+			id:             "4",
+			subject:        "user:1",
+			handler:        CompetitionController.Delete,
+			serviceMock:    &MockCompetitionService{FuncDelete: success},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/competitions/"+testCase.id+"/members", nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+			c.Set(constants.ContextKeyAuthSubject, testCase.subject)
+
+			services.CompetitionService = testCase.serviceMock
+			testCase.handler(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestCompetitionController_Leaderboard(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.CompetitionServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid competition id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_COMPETITION_ID","error_code":"INVALID_COMPETITION_ID","code":400}`,
+		},
+		{
+			title: "error CompetitionService.Leaderboard",
+			id:    "4",
+			serviceMock: &MockCompetitionService{
+				FuncLeaderboard: func(id int64, req *memberships.ListLeaderboardInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			id:    "4",
+			serviceMock: &MockCompetitionService{
+				FuncLeaderboard: func(id int64, req *memberships.ListLeaderboardInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					res := pagination.GeneratePaginatedResponse([]memberships.LeaderboardEntry{
+						{Position: 1, UserID: 1, Name: "John", Points: 7, ExactScores: 1, ScoredPredictions: 4},
+					}, req.Page, req.PerPage, 1)
+					return &res, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"from":1,"data":[{"position":1,"user_id":1,"name":"John","points":7,"exact_scores":1,"scored_predictions":4}],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/competitions/"+testCase.id+"/leaderboard", nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.CompetitionService = testCase.serviceMock
+			CompetitionController.Leaderboard(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
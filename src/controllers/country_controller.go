@@ -5,6 +5,11 @@ import (
 	"github.com/development-raul/footy-predictor/src/services"
 	"github.com/development-raul/footy-predictor/src/swaggertypes"
 	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/bulk"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/etag"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
 	"github.com/development-raul/footy-predictor/src/utils/resterror"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -14,9 +19,15 @@ import (
 type countryControllerInterface interface {
 	Create(ctx *gin.Context)
 	Update(ctx *gin.Context)
+	Patch(ctx *gin.Context)
 	Find(ctx *gin.Context)
 	List(ctx *gin.Context)
+	Export(ctx *gin.Context)
+	Import(ctx *gin.Context)
 	Delete(ctx *gin.Context)
+	BulkCreate(ctx *gin.Context)
+	BulkUpdate(ctx *gin.Context)
+	BulkDelete(ctx *gin.Context)
 	Sync(ctx *gin.Context)
 }
 
@@ -31,10 +42,14 @@ var CountryController countryControllerInterface = &countryController{}
 // @Produce json
 // @Accept json
 // @Tags Countries
+// @Security ApiKeyAuth
 // @Param JSON request body countries.CountryInput true "Request Sample"
 // @Success 201 {object} swaggertypes.NoErrorString
 // @Failure 400 {object} swaggertypes.StandardBadRequestError
 // @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 409 {object} swaggertypes.DatabaseError
+// @Failure 422 {object} swaggertypes.DatabaseError
 // @Failure 500 {object} swaggertypes.StandardInternalServerError
 // @Router /countries [post]
 func (c *countryController) Create(ctx *gin.Context) {
@@ -43,7 +58,7 @@ func (c *countryController) Create(ctx *gin.Context) {
 		return
 	}
 
-	if err := services.CountryService.Create(&req); err != nil {
+	if err := services.CountryService.Create(ctx.Request.Context(), &req); err != nil {
 		ctx.JSON(err.Code(), err)
 		return
 	}
@@ -56,16 +71,25 @@ func (c *countryController) Create(ctx *gin.Context) {
 
 // Update
 // @Summary Update country
-// @Description Endpoint used to update an existing country record
+// @Description Endpoint used to update an existing country record. The If-Match header must hold the ETag returned when the country was read, or * to overwrite any version, the new ETag is returned
 // @ID v1-countries-update
 // @Produce json
 // @Accept json
 // @Tags Countries
+// @Security ApiKeyAuth
 // @Param id path int true "Country ID"
+// @Param If-Match header string true "ETag of the country e.g. \"3\", or *"
 // @Param JSON request body countries.UpdateCountryInput true "Request Sample"
 // @Success 200 {object} swaggertypes.NoErrorString
+// @Header 200 {string} ETag "ETag of the updated country"
 // @Failure 400 {object} swaggertypes.StandardBadRequestError
 // @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 409 {object} swaggertypes.DatabaseError
+// @Failure 412 {object} swaggertypes.DatabaseError
+// @Failure 422 {object} swaggertypes.DatabaseError
+// @Failure 428 {object} swaggertypes.StandardPreconditionRequiredError
 // @Failure 500 {object} swaggertypes.StandardInternalServerError
 // @Router /countries/{id} [put]
 func (c *countryController) Update(ctx *gin.Context) {
@@ -77,15 +101,75 @@ func (c *countryController) Update(ctx *gin.Context) {
 	}
 
 	var req countries.UpdateCountryInput
-	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldMatch(&req.Version),
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+	); !ok {
 		return
 	}
 
-	if err := services.CountryService.Update(&req, id); err != nil {
+	if err := services.CountryService.Update(ctx.Request.Context(), &req, id); err != nil {
 		ctx.JSON(err.Code(), err)
 		return
 	}
 
+	ctx.Header("ETag", etag.Format(req.Version))
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
+
+// Patch
+// @Summary Patch country
+// @Description Endpoint used to change some fields of an existing country record with a JSON Merge Patch (RFC 7396), the members of the body replace the fields of the country and null resets a field. The If-Match header must hold the ETag returned when the country was read, or * to patch any version, the new ETag is returned
+// @ID v1-countries-patch
+// @Produce json
+// @Accept application/merge-patch+json,json
+// @Tags Countries
+// @Security ApiKeyAuth
+// @Param id path int true "Country ID"
+// @Param If-Match header string true "ETag of the country e.g. \"3\", or *"
+// @Param JSON request body countries.UpdateCountryInput true "Fields to change e.g. {\"active\": false}"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Header 200 {string} ETag "ETag of the updated country"
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 409 {object} swaggertypes.DatabaseError
+// @Failure 412 {object} swaggertypes.DatabaseError
+// @Failure 422 {object} swaggertypes.DatabaseError
+// @Failure 428 {object} swaggertypes.StandardPreconditionRequiredError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /countries/{id} [patch]
+func (c *countryController) Patch(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_COUNTRY_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	var version int64
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldMatch(&version)); !ok {
+		return
+	}
+	patch, err := ctx.GetRawData()
+	if err != nil {
+		apiErr := resterror.NewBadRequestError(constants.ErrorInvalidRequestBody)
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	version, apiErr := services.CountryService.Patch(ctx.Request.Context(), id, version, patch)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.Header("ETag", etag.Format(version))
 	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
 		Message: "SUCCESS",
 		Code:    http.StatusOK,
@@ -94,14 +178,20 @@ func (c *countryController) Update(ctx *gin.Context) {
 
 // Find
 // @Summary Find country
-// @Description Retrieve a country identified by id
+// @Description Retrieve a country identified by id, the ETag header holds its version. When the If-None-Match header has the current ETag nothing is returned but a 304
 // @ID v1-countries-find
 // @Produce json
 // @Tags Countries
+// @Security ApiKeyAuth
 // @Param id path int true "Country ID"
+// @Param If-None-Match header string false "ETag of the country already held by the client"
 // @Success 200 {object} swaggertypes.NoErrorI{data=countries.CountryOutput}
+// @Header 200 {string} ETag "ETag of the country"
+// @Success 304 "Not Modified"
 // @Failure 400 {object} swaggertypes.StandardBadRequestError
 // @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
 // @Failure 500 {object} swaggertypes.StandardInternalServerError
 // @Router /countries/{id} [get]
 func (c *countryController) Find(ctx *gin.Context) {
@@ -111,11 +201,14 @@ func (c *countryController) Find(ctx *gin.Context) {
 		ctx.JSON(apiErr.Code(), apiErr)
 		return
 	}
-	result, apiErr := services.CountryService.Find(id)
+	result, apiErr := services.CountryService.Find(ctx.Request.Context(), id)
 	if apiErr != nil {
 		ctx.JSON(apiErr.Code(), apiErr)
 		return
 	}
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBeModified(result.Version)); !ok {
+		return
+	}
 
 	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
 		Data: result,
@@ -129,16 +222,23 @@ func (c *countryController) Find(ctx *gin.Context) {
 // @ID v1-countries-list
 // @Produce json
 // @Tags Countries
-// @Param code query string false "filter by code"
-// @Param name query string false "filter by name"
+// @Security ApiKeyAuth
+// @Param id query integer false "filter by id, also id[ne], id[in], id[gt], id[gte], id[lt] and id[lte]"
+// @Param code query string false "filter by code, also code[ne], code[in] and code[like]"
+// @Param name query string false "filter by part of the name, also name[eq], name[ne] and name[in]"
 // @Param active query bool false "filter by status" Enums(true,false)
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order e.g. -active,name"
+// @Param fields query string false "comma separated fields to return e.g. id,name"
 // @Param order query string false "order direction" Enums(asc,desc)
 // @Param order_by query string false "order field" Enums(id,code,name,active)
 // @Param page query integer false "page number"
 // @Param per_page query integer false "records per page"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous response, replaces page"
+// @Param total query bool false "count the records, false skips the count and returns a total and last_page of -1" Enums(true,false)
 // @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]countries.CountryOutput}}
 // @Failure 400 {object} swaggertypes.StandardBadRequestError
 // @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
 // @Failure 500 {object} swaggertypes.StandardInternalServerError
 // @Router /countries [get]
 func (c *countryController) List(ctx *gin.Context) {
@@ -147,11 +247,12 @@ func (c *countryController) List(ctx *gin.Context) {
 	if ok := utils.GinShouldPassAll(ctx,
 		utils.GinShouldBind(&req),
 		utils.GinShouldValidate(&req),
+		utils.GinShouldFilter(&req.Filter, countries.CountryOutput{}),
 	); !ok {
 		return
 	}
 
-	results, apiErr := services.CountryService.List(&req)
+	results, apiErr := services.CountryService.List(ctx.Request.Context(), &req)
 	if apiErr != nil {
 		ctx.JSON(apiErr.Code(), apiErr)
 		return
@@ -163,17 +264,97 @@ func (c *countryController) List(ctx *gin.Context) {
 	})
 }
 
+// Export
+// @Summary Export countries
+// @Description Stream the countries matching the filters of the list endpoint as a CSV, JSON or NDJSON attachment, the fields parameter selects the columns
+// @ID v1-countries-export
+// @Produce json,text/csv,application/x-ndjson
+// @Tags Countries
+// @Security ApiKeyAuth
+// @Param format query string false "export format, json by default" Enums(csv,json,ndjson)
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order"
+// @Param fields query string false "comma separated fields to export"
+// @Success 200 {array} countries.CountryOutput
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /countries/export [get]
+func (c *countryController) Export(ctx *gin.Context) {
+	var req countries.ListCountryInput
+	var format export.Input
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldBind(&format),
+		utils.GinShouldValidate(&format),
+		utils.GinShouldFilter(&req.Filter, countries.CountryOutput{}),
+	); !ok {
+		return
+	}
+
+	w := export.NewWriter(export.Response(ctx.Writer, format.Format, "countries"), format.Format, req.Filter.Columns(countries.CountryOutput{}))
+	if apiErr := services.CountryService.Export(ctx.Request.Context(), &req, w); apiErr != nil && !ctx.Writer.Written() {
+		ctx.JSON(apiErr.Code(), apiErr)
+	}
+}
+
+// Import
+// @Summary Import countries
+// @Description Upsert the rows of a CSV or JSON upload, sent as the file field of a multipart form or as the request body. The columns are the fields of CountryInput: code, name (required), flag and active. Countries are matched by name. Valid rows are saved even when others fail, every failed row is reported
+// @ID v1-countries-import
+// @Produce json
+// @Accept mpfd,text/csv,json
+// @Tags Countries
+// @Security ApiKeyAuth
+// @Param file formData file false "CSV or JSON file"
+// @Param format query string false "upload format, by default from the file extension or the content type" Enums(csv,json)
+// @Success 200 {object} swaggertypes.NoErrorI{data=importer.Report}
+// @Success 207 {object} swaggertypes.NoErrorI{data=importer.Report}
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /countries/import [post]
+func (c *countryController) Import(ctx *gin.Context) {
+	file, format, apiErr := importer.Upload(ctx.Request)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	defer file.Close()
+
+	report, apiErr := services.CountryService.Import(ctx.Request.Context(), file, format)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(report.StatusCode(), swaggertypes.NoErrorData{
+		Data: report,
+		Code: report.StatusCode(),
+	})
+}
+
 // Delete
 // @Summary Delete country
-// @Description Endpoint used to delete an existing country record
+// @Description Endpoint used to delete an existing country record. The If-Match header must hold the ETag returned when the country was read, or * to delete any version
 // @ID v1-countries-delete
 // @Produce json
 // @Accept json
 // @Tags Countries
+// @Security ApiKeyAuth
 // @Param id path int true "Country ID"
+// @Param If-Match header string true "ETag of the country e.g. \"3\", or *"
 // @Success 200 {object} swaggertypes.NoErrorString
 // @Failure 400 {object} swaggertypes.StandardBadRequestError
 // @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 409 {object} swaggertypes.DatabaseError
+// @Failure 412 {object} swaggertypes.DatabaseError
+// @Failure 428 {object} swaggertypes.StandardPreconditionRequiredError
 // @Failure 500 {object} swaggertypes.StandardInternalServerError
 // @Router /countries/{id} [delete]
 func (c *countryController) Delete(ctx *gin.Context) {
@@ -184,7 +365,12 @@ func (c *countryController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := services.CountryService.Delete(id); err != nil {
+	var version int64
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldMatch(&version)); !ok {
+		return
+	}
+
+	if err := services.CountryService.Delete(ctx.Request.Context(), id, version); err != nil {
 		ctx.JSON(err.Code(), err)
 		return
 	}
@@ -195,8 +381,113 @@ func (c *countryController) Delete(ctx *gin.Context) {
 	})
 }
 
+// BulkCreate
+// @Summary Bulk create countries
+// @Description Endpoint used to create several country records in a single transaction. Mode atomic (default) rolls back every item when one fails, best_effort applies the valid items and reports the others
+// @ID v1-countries-bulk-create
+// @Produce json
+// @Accept json
+// @Tags Countries
+// @Security ApiKeyAuth
+// @Param JSON request body countries.BulkCreateCountryInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorI{data=bulk.Response}
+// @Success 207 {object} swaggertypes.NoErrorI{data=bulk.Response}
+// @Failure 400 {object} swaggertypes.BulkError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 422 {object} swaggertypes.BulkError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /countries/bulk [post]
+func (c *countryController) BulkCreate(ctx *gin.Context) {
+	var req countries.BulkCreateCountryInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	res, err := services.CountryService.BulkCreate(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(res.StatusCode(), swaggertypes.NoErrorData{
+		Data: res,
+		Code: res.StatusCode(),
+	})
+}
+
+// BulkUpdate
+// @Summary Bulk update countries
+// @Description Endpoint used to update several existing country records in a single transaction. Mode atomic (default) rolls back every item when one fails, best_effort applies the valid items and reports the others
+// @ID v1-countries-bulk-update
+// @Produce json
+// @Accept json
+// @Tags Countries
+// @Security ApiKeyAuth
+// @Param JSON request body countries.BulkUpdateCountryInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorI{data=bulk.Response}
+// @Success 207 {object} swaggertypes.NoErrorI{data=bulk.Response}
+// @Failure 400 {object} swaggertypes.BulkError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 422 {object} swaggertypes.BulkError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /countries/bulk [put]
+func (c *countryController) BulkUpdate(ctx *gin.Context) {
+	var req countries.BulkUpdateCountryInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	res, err := services.CountryService.BulkUpdate(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(res.StatusCode(), swaggertypes.NoErrorData{
+		Data: res,
+		Code: res.StatusCode(),
+	})
+}
+
+// BulkDelete
+// @Summary Bulk delete countries
+// @Description Endpoint used to delete several existing country records in a single transaction. Mode atomic (default) rolls back every item when one fails, best_effort applies the valid items and reports the others
+// @ID v1-countries-bulk-delete
+// @Produce json
+// @Accept json
+// @Tags Countries
+// @Security ApiKeyAuth
+// @Param JSON request body bulk.DeleteInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorI{data=bulk.Response}
+// @Success 207 {object} swaggertypes.NoErrorI{data=bulk.Response}
+// @Failure 400 {object} swaggertypes.BulkError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 422 {object} swaggertypes.BulkError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /countries/bulk [delete]
+func (c *countryController) BulkDelete(ctx *gin.Context) {
+	var req bulk.DeleteInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	res, err := services.CountryService.BulkDelete(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(res.StatusCode(), swaggertypes.NoErrorData{
+		Data: res,
+		Code: res.StatusCode(),
+	})
+}
+
 func (c *countryController) Sync(ctx *gin.Context) {
-	if err := services.CountryService.Sync(); err != nil {
+	if err := services.CountryService.Sync(ctx.Request.Context()); err != nil {
 		ctx.JSON(err.Code(), err)
 		return
 	}
@@ -1,46 +1,80 @@
 package controllers
 
 import (
+	"bytes"
+	"context"
+	"database/sql"
 	"github.com/development-raul/footy-predictor/src/domains/countries"
 	"github.com/development-raul/footy-predictor/src/services"
 	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/bulk"
 	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/etag"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
+	"github.com/development-raul/footy-predictor/src/utils/mergepatch"
 	"github.com/development-raul/footy-predictor/src/utils/pagination"
 	"github.com/development-raul/footy-predictor/src/utils/resterror"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"io"
+	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 type MockCountryService struct {
 	FuncCreate func(req *countries.CountryInput) resterror.RestErrorI
 	FuncUpdate func(req *countries.UpdateCountryInput, id int64) resterror.RestErrorI
+	FuncPatch  func(id int64, version int64, patch []byte) (int64, resterror.RestErrorI)
 	FuncFind   func(id int64) (*countries.CountryOutput, resterror.RestErrorI)
 	FuncList   func(req *countries.ListCountryInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
-	FuncDelete func(id int64) resterror.RestErrorI
+	FuncDelete func(id int64, version int64) resterror.RestErrorI
+	FuncExport func(req *countries.ListCountryInput, w export.Writer) resterror.RestErrorI
+	FuncImport func(r io.Reader, format string) (*importer.Report, resterror.RestErrorI)
+	FuncBulk   func(req interface{}) (*bulk.Response, resterror.RestErrorI)
 	FuncSync   func() resterror.RestErrorI
 }
 
-func (m MockCountryService) Create(req *countries.CountryInput) resterror.RestErrorI {
+func (m MockCountryService) Create(ctx context.Context, req *countries.CountryInput) resterror.RestErrorI {
 	return m.FuncCreate(req)
 }
-func (m MockCountryService) Update(req *countries.UpdateCountryInput, id int64) resterror.RestErrorI {
+func (m MockCountryService) Update(ctx context.Context, req *countries.UpdateCountryInput, id int64) resterror.RestErrorI {
 	return m.FuncUpdate(req, id)
 }
-func (m MockCountryService) Find(id int64) (*countries.CountryOutput, resterror.RestErrorI) {
+func (m MockCountryService) Patch(ctx context.Context, id int64, version int64, patch []byte) (int64, resterror.RestErrorI) {
+	return m.FuncPatch(id, version, patch)
+}
+func (m MockCountryService) Find(ctx context.Context, id int64) (*countries.CountryOutput, resterror.RestErrorI) {
 	return m.FuncFind(id)
 }
-func (m MockCountryService) List(req *countries.ListCountryInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+func (m MockCountryService) List(ctx context.Context, req *countries.ListCountryInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
 	return m.FuncList(req)
 }
-func (m MockCountryService) Delete(id int64) resterror.RestErrorI {
-	return m.FuncDelete(id)
+func (m MockCountryService) Export(ctx context.Context, req *countries.ListCountryInput, w export.Writer) resterror.RestErrorI {
+	return m.FuncExport(req, w)
+}
+func (m MockCountryService) Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI) {
+	return m.FuncImport(r, format)
+}
+func (m MockCountryService) Delete(ctx context.Context, id int64, version int64) resterror.RestErrorI {
+	return m.FuncDelete(id, version)
+}
+func (m MockCountryService) BulkCreate(ctx context.Context, req *countries.BulkCreateCountryInput) (*bulk.Response, resterror.RestErrorI) {
+	return m.FuncBulk(req)
 }
-func (m MockCountryService) Sync() resterror.RestErrorI {
+func (m MockCountryService) BulkUpdate(ctx context.Context, req *countries.BulkUpdateCountryInput) (*bulk.Response, resterror.RestErrorI) {
+	return m.FuncBulk(req)
+}
+func (m MockCountryService) BulkDelete(ctx context.Context, req *bulk.DeleteInput) (*bulk.Response, resterror.RestErrorI) {
+	return m.FuncBulk(req)
+}
+func (m MockCountryService) Sync(ctx context.Context) resterror.RestErrorI {
 	return m.FuncSync()
 }
 
@@ -57,14 +91,14 @@ func TestCountryController_Create(t *testing.T) {
 			reqBody:        strings.NewReader(`{"as_id":1}`),
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":{"name":["The name field is required."]},"code":400}`,
+			expectedRes:    `{"error":{"name":["The name field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
 		},
 		{
 			title:          "error invalid active",
 			reqBody:        strings.NewReader(`{"name":"England","active":2}`),
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":"Invalid request body.","code":400}`,
+			expectedRes:    `{"error":"Invalid request body.","error_code":"BAD_REQUEST","code":400}`,
 		},
 		{
 			title:   "error CountryService.Create",
@@ -75,7 +109,7 @@ func TestCountryController_Create(t *testing.T) {
 				},
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedRes:    `{"error":"Something went wrong. Please try again later.","code":500}`,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
 		},
 		{
 			title:   "success",
@@ -110,10 +144,12 @@ func TestCountryController_Update(t *testing.T) {
 	testCases := []struct {
 		title          string
 		id             string
+		ifMatch        string
 		reqBody        io.Reader
 		serviceMock    services.CountryServiceI
 		expectedStatus int
 		expectedRes    string
+		expectedETag   string
 	}{
 		{
 			title:          "error invalid country id",
@@ -121,27 +157,60 @@ func TestCountryController_Update(t *testing.T) {
 			reqBody:        strings.NewReader(`{}`),
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":"INVALID_COUNTRY_ID","code":400}`,
+			expectedRes:    `{"error":"INVALID_COUNTRY_ID","error_code":"INVALID_COUNTRY_ID","code":400}`,
 		},
 		{
 			title:          "error required name",
 			id:             "1",
+			ifMatch:        `"3"`,
 			reqBody:        strings.NewReader(`{}`),
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":{"name":["The name field is required."]},"code":400}`,
+			expectedRes:    `{"error":{"name":["The name field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
 		},
 		{
 			title:          "error invalid active",
 			id:             "1",
+			ifMatch:        `"3"`,
 			reqBody:        strings.NewReader(`{"name":"England","active":2}`),
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":"Invalid request body.","code":400}`,
+			expectedRes:    `{"error":"Invalid request body.","error_code":"BAD_REQUEST","code":400}`,
+		},
+		{
+			title:          "error missing If-Match",
+			id:             "1",
+			reqBody:        strings.NewReader(`{"name":"England","active":true}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedRes:    `{"error":"IF_MATCH_REQUIRED","error_code":"IF_MATCH_REQUIRED","code":428}`,
+		},
+		{
+			title:          "error invalid If-Match",
+			id:             "1",
+			ifMatch:        `W/"3"`,
+			reqBody:        strings.NewReader(`{"name":"England","active":true}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedRes:    `{"error":"INVALID_IF_MATCH","error_code":"INVALID_IF_MATCH","code":412}`,
+		},
+		{
+			title:   "error version mismatch",
+			id:      "1",
+			ifMatch: `"2"`,
+			reqBody: strings.NewReader(`{"name":"England","active":true}`),
+			serviceMock: &MockCountryService{
+				FuncUpdate: func(req *countries.UpdateCountryInput, id int64) resterror.RestErrorI {
+					return resterror.NewDatabaseError(dberror.ErrVersionMismatch, "country")
+				},
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedRes:    `{"error":"The country was changed by another request, read it again to get its current ETag","error_code":"COUNTRY_VERSION_MISMATCH","code":412}`,
 		},
 		{
 			title:   "error CountryService.Update",
 			id:      "1",
+			ifMatch: `"3"`,
 			reqBody: strings.NewReader(`{"name":"England","active":true}`),
 			serviceMock: &MockCountryService{
 				FuncUpdate: func(req *countries.UpdateCountryInput, id int64) resterror.RestErrorI {
@@ -149,19 +218,25 @@ func TestCountryController_Update(t *testing.T) {
 				},
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedRes:    `{"error":"Something went wrong. Please try again later.","code":500}`,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
 		},
 		{
 			title:   "success",
 			id:      "1",
+			ifMatch: `"3"`,
 			reqBody: strings.NewReader(`{"name":"England","active":true}`),
 			serviceMock: &MockCountryService{
 				FuncUpdate: func(req *countries.UpdateCountryInput, id int64) resterror.RestErrorI {
+					if req.Version != 3 {
+						return resterror.NewDatabaseError(dberror.ErrVersionMismatch, "country")
+					}
+					req.Version++
 					return nil
 				},
 			},
 			expectedStatus: http.StatusOK,
 			expectedRes:    `{"message":"SUCCESS","code":200}`,
+			expectedETag:   `"4"`,
 		},
 	}
 
@@ -169,6 +244,9 @@ func TestCountryController_Update(t *testing.T) {
 		t.Run(testCase.title, func(t *testing.T) {
 			req, _ := http.NewRequest("PUT", "https://localhost:8000/v1/countries"+testCase.id, testCase.reqBody)
 			req.Header.Set("Content-Type", "application/json")
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
 			res := httptest.NewRecorder()
 			c := utils.GetMockedContext(req, res)
 			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
@@ -178,6 +256,88 @@ func TestCountryController_Update(t *testing.T) {
 
 			assert.Equal(t, testCase.expectedStatus, res.Code)
 			assert.Equal(t, testCase.expectedRes, res.Body.String())
+			assert.Equal(t, testCase.expectedETag, res.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestCountryController_Patch(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		ifMatch        string
+		reqBody        string
+		serviceMock    services.CountryServiceI
+		expectedStatus int
+		expectedRes    string
+		expectedETag   string
+	}{
+		{
+			title:          "error invalid country id",
+			id:             "abc",
+			ifMatch:        `"3"`,
+			reqBody:        `{"active":false}`,
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_COUNTRY_ID","error_code":"INVALID_COUNTRY_ID","code":400}`,
+		},
+		{
+			title:          "error missing If-Match",
+			id:             "1",
+			reqBody:        `{"active":false}`,
+			serviceMock:    nil,
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedRes:    `{"error":"IF_MATCH_REQUIRED","error_code":"IF_MATCH_REQUIRED","code":428}`,
+		},
+		{
+			title:   "error CountryService.Patch",
+			id:      "1",
+			ifMatch: `"3"`,
+			reqBody: `{"name":null}`,
+			serviceMock: &MockCountryService{
+				FuncPatch: func(id int64, version int64, patch []byte) (int64, resterror.RestErrorI) {
+					return 0, resterror.NewValidationError(map[string][]string{"name": {"The name field is required."}})
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"name":["The name field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "success",
+			id:      "1",
+			ifMatch: "*",
+			reqBody: `{"active":false}`,
+			serviceMock: &MockCountryService{
+				FuncPatch: func(id int64, version int64, patch []byte) (int64, resterror.RestErrorI) {
+					if version != etag.Any || string(patch) != `{"active":false}` {
+						return 0, resterror.NewStandardInternalServerError()
+					}
+					return 4, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+			expectedETag:   `"4"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("PATCH", "https://localhost:8000/v1/countries/"+testCase.id, strings.NewReader(testCase.reqBody))
+			req.Header.Set("Content-Type", mergepatch.ContentType)
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.CountryService = testCase.serviceMock
+			CountryController.Patch(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+			assert.Equal(t, testCase.expectedETag, res.Header().Get("ETag"))
 		})
 	}
 }
@@ -186,16 +346,18 @@ func TestCountryController_Find(t *testing.T) {
 	testCases := []struct {
 		title          string
 		id             string
+		ifNoneMatch    string
 		serviceMock    services.CountryServiceI
 		expectedStatus int
 		expectedRes    string
+		expectedETag   string
 	}{
 		{
 			title:          "error invalid country id",
 			id:             "abc",
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":"INVALID_COUNTRY_ID","code":400}`,
+			expectedRes:    `{"error":"INVALID_COUNTRY_ID","error_code":"INVALID_COUNTRY_ID","code":400}`,
 		},
 		{
 			title: "error CountryService.Find",
@@ -206,7 +368,18 @@ func TestCountryController_Find(t *testing.T) {
 				},
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedRes:    `{"error":"Something went wrong. Please try again later.","code":500}`,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "error not found",
+			id:    "1",
+			serviceMock: &MockCountryService{
+				FuncFind: func(id int64) (*countries.CountryOutput, resterror.RestErrorI) {
+					return nil, resterror.NewDatabaseError(sql.ErrNoRows, "country")
+				},
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedRes:    `{"error":"The country does not exist","error_code":"COUNTRY_NOT_FOUND","code":404}`,
 		},
 		{
 			title: "success",
@@ -214,16 +387,45 @@ func TestCountryController_Find(t *testing.T) {
 			serviceMock: &MockCountryService{
 				FuncFind: func(id int64) (*countries.CountryOutput, resterror.RestErrorI) {
 					return &countries.CountryOutput{
-						ID:     1,
-						Code:   "code",
-						Name:   "name",
-						Flag:   "flag",
-						Active: true,
+						ID:        1,
+						Code:      "code",
+						Name:      "name",
+						Flag:      "flag",
+						Active:    true,
+						Version:   3,
+						UpdatedAt: time.Date(2021, 8, 13, 19, 0, 0, 0, time.UTC),
 					}, nil
 				},
 			},
 			expectedStatus: http.StatusOK,
-			expectedRes:    `{"data":{"id":1,"code":"code","name":"name","flag":"flag","active":true},"code":200}`,
+			expectedRes:    `{"data":{"id":1,"code":"code","name":"name","flag":"flag","active":true,"version":3,"updated_at":"2021-08-13T19:00:00Z"},"code":200}`,
+			expectedETag:   `"3"`,
+		},
+		{
+			title:       "success changed since If-None-Match",
+			id:          "1",
+			ifNoneMatch: `"2"`,
+			serviceMock: &MockCountryService{
+				FuncFind: func(id int64) (*countries.CountryOutput, resterror.RestErrorI) {
+					return &countries.CountryOutput{ID: 1, Name: "name", Version: 3}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"id":1,"code":"","name":"name","flag":"","active":false,"version":3,"updated_at":"0001-01-01T00:00:00Z"},"code":200}`,
+			expectedETag:   `"3"`,
+		},
+		{
+			title:       "success not modified",
+			id:          "1",
+			ifNoneMatch: `W/"3"`,
+			serviceMock: &MockCountryService{
+				FuncFind: func(id int64) (*countries.CountryOutput, resterror.RestErrorI) {
+					return &countries.CountryOutput{ID: 1, Name: "name", Version: 3}, nil
+				},
+			},
+			expectedStatus: http.StatusNotModified,
+			expectedRes:    ``,
+			expectedETag:   `"3"`,
 		},
 	}
 
@@ -231,6 +433,9 @@ func TestCountryController_Find(t *testing.T) {
 		t.Run(testCase.title, func(t *testing.T) {
 			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/countries"+testCase.id, nil)
 			req.Header.Set("Content-Type", "application/json")
+			if testCase.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", testCase.ifNoneMatch)
+			}
 			res := httptest.NewRecorder()
 			c := utils.GetMockedContext(req, res)
 			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
@@ -240,6 +445,7 @@ func TestCountryController_Find(t *testing.T) {
 
 			assert.Equal(t, testCase.expectedStatus, res.Code)
 			assert.Equal(t, testCase.expectedRes, res.Body.String())
+			assert.Equal(t, testCase.expectedETag, res.Header().Get("ETag"))
 		})
 	}
 }
@@ -257,14 +463,14 @@ func TestCountryController_List(t *testing.T) {
 			query:          "?order=test",
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":{"order":["The field: 'order' must be one of [desc asc]"]},"code":400}`,
+			expectedRes:    `{"error":{"order":["The field: 'order' must be one of [desc asc]"]},"error_code":"VALIDATION_FAILED","code":400}`,
 		},
 		{
 			title:          "error validation invalid order_by",
 			query:          "?order_by=test",
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":{"order_by":["The field: 'order_by' must be one of [id code name active]"]},"code":400}`,
+			expectedRes:    `{"error":{"order_by":["The field: 'order_by' must be one of [id code name active]"]},"error_code":"VALIDATION_FAILED","code":400}`,
 		},
 		{
 			title: "error CountryService.List",
@@ -275,7 +481,7 @@ func TestCountryController_List(t *testing.T) {
 				},
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedRes:    `{"error":"Something went wrong. Please try again later.","code":500}`,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
 		},
 		{
 			title: "success",
@@ -302,7 +508,7 @@ func TestCountryController_List(t *testing.T) {
 				},
 			},
 			expectedStatus: http.StatusOK,
-			expectedRes:    `{"data":{"from":1,"data":[{"id":1,"code":"code","name":"name","flag":"flag","active":true}],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+			expectedRes:    `{"data":{"from":1,"data":[{"id":1,"code":"code","name":"name","flag":"flag","active":true,"version":0,"updated_at":"0001-01-01T00:00:00Z"}],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
 		},
 	}
 
@@ -326,6 +532,7 @@ func TestCountryController_Delete(t *testing.T) {
 	testCases := []struct {
 		title          string
 		id             string
+		ifMatch        string
 		serviceMock    services.CountryServiceI
 		expectedStatus int
 		expectedRes    string
@@ -335,24 +542,48 @@ func TestCountryController_Delete(t *testing.T) {
 			id:             "abc",
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":"INVALID_COUNTRY_ID","code":400}`,
+			expectedRes:    `{"error":"INVALID_COUNTRY_ID","error_code":"INVALID_COUNTRY_ID","code":400}`,
 		},
 		{
-			title: "error CountryService.Delete",
-			id:    "1",
+			title:          "error missing If-Match",
+			id:             "1",
+			serviceMock:    nil,
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedRes:    `{"error":"IF_MATCH_REQUIRED","error_code":"IF_MATCH_REQUIRED","code":428}`,
+		},
+		{
+			title:   "error CountryService.Delete",
+			id:      "1",
+			ifMatch: `"3"`,
 			serviceMock: &MockCountryService{
-				FuncDelete: func(id int64) resterror.RestErrorI {
+				FuncDelete: func(id int64, version int64) resterror.RestErrorI {
 					return resterror.NewStandardInternalServerError()
 				},
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedRes:    `{"error":"Something went wrong. Please try again later.","code":500}`,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
 		},
 		{
-			title: "success",
-			id:    "1",
+			title:   "error version mismatch",
+			id:      "1",
+			ifMatch: `"2"`,
 			serviceMock: &MockCountryService{
-				FuncDelete: func(id int64) resterror.RestErrorI {
+				FuncDelete: func(id int64, version int64) resterror.RestErrorI {
+					return resterror.NewDatabaseError(dberror.ErrVersionMismatch, "country")
+				},
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedRes:    `{"error":"The country was changed by another request, read it again to get its current ETag","error_code":"COUNTRY_VERSION_MISMATCH","code":412}`,
+		},
+		{
+			title:   "success",
+			id:      "1",
+			ifMatch: `"3"`,
+			serviceMock: &MockCountryService{
+				FuncDelete: func(id int64, version int64) resterror.RestErrorI {
+					if version != 3 {
+						return resterror.NewDatabaseError(dberror.ErrVersionMismatch, "country")
+					}
 					return nil
 				},
 			},
@@ -365,6 +596,9 @@ func TestCountryController_Delete(t *testing.T) {
 		t.Run(testCase.title, func(t *testing.T) {
 			req, _ := http.NewRequest("DELETE", "https://localhost:8000/v1/countries"+testCase.id, nil)
 			req.Header.Set("Content-Type", "application/json")
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
 			res := httptest.NewRecorder()
 			c := utils.GetMockedContext(req, res)
 			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
@@ -393,7 +627,7 @@ func TestCountryController_Sync(t *testing.T) {
 				},
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedRes:    `{"error":"Something went wrong. Please try again later.","code":500}`,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
 		},
 		{
 			title: "success",
@@ -422,3 +656,251 @@ func TestCountryController_Sync(t *testing.T) {
 		})
 	}
 }
+
+func TestCountryController_Bulk(t *testing.T) {
+	testCases := []struct {
+		title          string
+		method         string
+		reqBody        io.Reader
+		serviceMock    services.CountryServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error validation invalid mode and no items",
+			method:         http.MethodPost,
+			reqBody:        strings.NewReader(`{"mode":"some"}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"items":["The items field is required."],"mode":["The field: 'mode' must be one of [atomic best_effort]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error atomic rolled back",
+			method:  http.MethodPut,
+			reqBody: strings.NewReader(`{"items":[{"id":1,"name":"Spain"},{"id":2,"name":"Italy"}]}`),
+			serviceMock: &MockCountryService{
+				FuncBulk: func(req interface{}) (*bulk.Response, resterror.RestErrorI) {
+					return nil, resterror.NewCustomError(&bulk.Response{
+						Mode:   bulk.ModeAtomic,
+						Failed: 1,
+						Results: []bulk.Result{
+							{Index: 0, Status: bulk.StatusSkipped},
+							{Index: 1, Status: bulk.StatusFailed, Errors: map[string][]string{"id": {bulk.ErrorNotFound}}},
+						},
+					}, http.StatusUnprocessableEntity)
+				},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedRes:    `{"error":{"mode":"atomic","applied":0,"failed":1,"results":[{"index":0,"status":"skipped"},{"index":1,"status":"failed","errors":{"id":["The id does not exist"]}}]},"error_code":"UNPROCESSABLE_ENTITY","code":422}`,
+		},
+		{
+			title:   "success best effort partially applied",
+			method:  http.MethodDelete,
+			reqBody: strings.NewReader(`{"mode":"best_effort","items":[{"id":1},{"id":0}]}`),
+			serviceMock: &MockCountryService{
+				FuncBulk: func(req interface{}) (*bulk.Response, resterror.RestErrorI) {
+					return &bulk.Response{
+						Mode:    bulk.ModeBestEffort,
+						Applied: 1,
+						Failed:  1,
+						Results: []bulk.Result{
+							{Index: 0, Status: bulk.StatusDeleted, ID: 1},
+							{Index: 1, Status: bulk.StatusInvalid, Errors: map[string][]string{"id": {"The id field is required."}}},
+						},
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusMultiStatus,
+			expectedRes:    `{"data":{"mode":"best_effort","applied":1,"failed":1,"results":[{"index":0,"status":"deleted","id":1},{"index":1,"status":"invalid","errors":{"id":["The id field is required."]}}]},"code":207}`,
+		},
+		{
+			title:   "success",
+			method:  http.MethodPost,
+			reqBody: strings.NewReader(`{"items":[{"name":"Spain"}]}`),
+			serviceMock: &MockCountryService{
+				FuncBulk: func(req interface{}) (*bulk.Response, resterror.RestErrorI) {
+					return &bulk.Response{
+						Mode:    bulk.ModeAtomic,
+						Applied: 1,
+						Results: []bulk.Result{{Index: 0, Status: bulk.StatusCreated, ID: 7}},
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"mode":"atomic","applied":1,"failed":0,"results":[{"index":0,"status":"created","id":7}]},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest(testCase.method, "https://localhost:8000/v1/countries/bulk", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.CountryService = testCase.serviceMock
+			switch testCase.method {
+			case http.MethodPost:
+				CountryController.BulkCreate(c)
+			case http.MethodPut:
+				CountryController.BulkUpdate(c)
+			case http.MethodDelete:
+				CountryController.BulkDelete(c)
+			}
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestCountryController_Export(t *testing.T) {
+	testCases := []struct {
+		title               string
+		query               string
+		serviceMock         services.CountryServiceI
+		expectedStatus      int
+		expectedContentType string
+		expectedRes         string
+	}{
+		{
+			title:          "error invalid format",
+			query:          "?format=xml",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"format":["The field: 'format' must be one of [csv json ndjson]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error CountryService.Export before the first page",
+			serviceMock: &MockCountryService{
+				FuncExport: func(req *countries.ListCountryInput, w export.Writer) resterror.RestErrorI {
+					return resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedRes:         `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success csv selected fields",
+			query: "?format=csv&fields=code,name&active=true",
+			serviceMock: &MockCountryService{
+				FuncExport: func(req *countries.ListCountryInput, w export.Writer) resterror.RestErrorI {
+					if len(req.Filter.Conditions()) != 1 {
+						return resterror.NewBadRequestError("expected the active filter")
+					}
+					_ = w.Write(req.Filter.Records([]countries.CountryOutput{{ID: 1, Code: "ES", Name: "Spain", Active: true}}))
+					_ = w.Close()
+					return nil
+				},
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedRes:         "code,name\nES,Spain\n",
+		},
+		{
+			title: "success empty json",
+			serviceMock: &MockCountryService{
+				FuncExport: func(req *countries.ListCountryInput, w export.Writer) resterror.RestErrorI {
+					_ = w.Close()
+					return nil
+				},
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedRes:         "[]\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "https://localhost:8000/v1/countries/export"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.CountryService = testCase.serviceMock
+			CountryController.Export(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+			if testCase.expectedContentType != "" {
+				assert.Equal(t, testCase.expectedContentType, res.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestCountryController_Import(t *testing.T) {
+	multipartBody := func(filename string, content string) (io.Reader, string) {
+		var buf bytes.Buffer
+		w := multipart.NewWriter(&buf)
+		part, _ := w.CreateFormFile("file", filename)
+		_, _ = part.Write([]byte(content))
+		_ = w.Close()
+		return &buf, w.FormDataContentType()
+	}
+	csvBody, csvContentType := multipartBody("countries.csv", "name\nSpain\n")
+
+	testCases := []struct {
+		title          string
+		reqBody        io.Reader
+		contentType    string
+		serviceMock    services.CountryServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error unknown format",
+			reqBody:        strings.NewReader("name\nSpain\n"),
+			contentType:    "text/plain",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"file":["The file must be a csv or json file"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:       "success multipart csv partially imported",
+			reqBody:     csvBody,
+			contentType: csvContentType,
+			serviceMock: &MockCountryService{
+				FuncImport: func(r io.Reader, format string) (*importer.Report, resterror.RestErrorI) {
+					body, _ := ioutil.ReadAll(r)
+					if format != importer.FormatCSV || string(body) != "name\nSpain\n" {
+						return nil, resterror.NewBadRequestError("unexpected upload")
+					}
+					return &importer.Report{Rows: 2, Imported: 1, Failed: 1, Errors: []importer.RowError{
+						{Row: 2, Errors: map[string][]string{"name": {"The name field is required."}}},
+					}}, nil
+				},
+			},
+			expectedStatus: http.StatusMultiStatus,
+			expectedRes:    `{"data":{"rows":2,"imported":1,"failed":1,"errors":[{"row":2,"errors":{"name":["The name field is required."]}}]},"code":207}`,
+		},
+		{
+			title:       "success json body",
+			reqBody:     strings.NewReader(`[{"name":"Spain"}]`),
+			contentType: "application/json",
+			serviceMock: &MockCountryService{
+				FuncImport: func(r io.Reader, format string) (*importer.Report, resterror.RestErrorI) {
+					return &importer.Report{Rows: 1, Imported: 1, Errors: []importer.RowError{}}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"rows":1,"imported":1,"failed":0,"errors":[]},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "https://localhost:8000/v1/countries/import", testCase.reqBody)
+			req.Header.Set("Content-Type", testCase.contentType)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.CountryService = testCase.serviceMock
+			CountryController.Import(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type fixtureControllerInterface interface {
+	Find(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Export(ctx *gin.Context)
+	Import(ctx *gin.Context)
+	Sync(ctx *gin.Context)
+}
+
+type fixtureController struct{}
+
+var FixtureController fixtureControllerInterface = &fixtureController{}
+
+// Find
+// @Summary Find fixture
+// @Description Retrieve a fixture identified by id
+// @ID v1-fixtures-find
+// @Produce json
+// @Tags Fixtures
+// @Security ApiKeyAuth
+// @Param id path int true "Fixture ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=fixtures.Fixture}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /fixtures/{id} [get]
+func (c *fixtureController) Find(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_FIXTURE_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.FixtureService.Find(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// List
+// @Summary List fixtures
+// @Description Retrieve fixtures filtered by league, season, team, status or kickoff date
+// @ID v1-fixtures-list
+// @Produce json
+// @Tags Fixtures
+// @Security ApiKeyAuth
+// @Param league_id query integer false "filter by league, also league_id[ne] and league_id[in]"
+// @Param season query integer false "filter by season, also season[ne], season[in], season[gt], season[gte], season[lt] and season[lte]"
+// @Param team_id query integer false "filter by home or away team"
+// @Param status query string false "filter by status short code e.g. NS, FT, also status[ne] and status[in] e.g. status[in]=FT,AET,PEN"
+// @Param kickoff_at[gte] query string false "kickoff at or after, YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ, also kickoff_at[gt], kickoff_at[lt] and kickoff_at[lte]"
+// @Param home_goals[null] query boolean false "fixtures without (true) or with (false) a score, also away_goals[null]"
+// @Param from query string false "kickoff from date YYYY-MM-DD"
+// @Param to query string false "kickoff until date YYYY-MM-DD"
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order e.g. -kickoff_at,id"
+// @Param fields query string false "comma separated fields to return e.g. id,kickoff_at,status"
+// @Param order query string false "order direction" Enums(asc,desc)
+// @Param order_by query string false "order field" Enums(id,kickoff_at,league_id,season,status)
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous response, replaces page"
+// @Param total query bool false "count the records, false skips the count and returns a total and last_page of -1" Enums(true,false)
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]fixtures.Fixture}}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /fixtures [get]
+func (c *fixtureController) List(ctx *gin.Context) {
+	var req fixtures.ListFixtureInput
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldFilter(&req.Filter, fixtures.Fixture{}),
+	); !ok {
+		return
+	}
+
+	results, apiErr := services.FixtureService.List(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
+
+// Export
+// @Summary Export fixtures
+// @Description Stream the fixtures matching the filters of the list endpoint as a CSV, JSON or NDJSON attachment, the fields parameter selects the columns
+// @ID v1-fixtures-export
+// @Produce json,text/csv,application/x-ndjson
+// @Tags Fixtures
+// @Security ApiKeyAuth
+// @Param format query string false "export format, json by default" Enums(csv,json,ndjson)
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order"
+// @Param fields query string false "comma separated fields to export"
+// @Success 200 {array} fixtures.Fixture
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /fixtures/export [get]
+func (c *fixtureController) Export(ctx *gin.Context) {
+	var req fixtures.ListFixtureInput
+	var format export.Input
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldBind(&format),
+		utils.GinShouldValidate(&format),
+		utils.GinShouldFilter(&req.Filter, fixtures.Fixture{}),
+	); !ok {
+		return
+	}
+
+	w := export.NewWriter(export.Response(ctx.Writer, format.Format, "fixtures"), format.Format, req.Filter.Columns(fixtures.Fixture{}))
+	if apiErr := services.FixtureService.Export(ctx.Request.Context(), &req, w); apiErr != nil && !ctx.Writer.Written() {
+		ctx.JSON(apiErr.Code(), apiErr)
+	}
+}
+
+// Import
+// @Summary Import fixtures
+// @Description Upsert the rows of a CSV or JSON upload, sent as the file field of a multipart form or as the request body. The columns are the fields of Fixture: id, league_id, season, kickoff_at, status, home_team_id and away_team_id (required), round, team names and goals. Valid rows are saved even when others fail, every failed row is reported
+// @ID v1-fixtures-import
+// @Produce json
+// @Accept mpfd,text/csv,json
+// @Tags Fixtures
+// @Security ApiKeyAuth
+// @Param file formData file false "CSV or JSON file"
+// @Param format query string false "upload format, by default from the file extension or the content type" Enums(csv,json)
+// @Success 200 {object} swaggertypes.NoErrorI{data=importer.Report}
+// @Success 207 {object} swaggertypes.NoErrorI{data=importer.Report}
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /fixtures/import [post]
+func (c *fixtureController) Import(ctx *gin.Context) {
+	file, format, apiErr := importer.Upload(ctx.Request)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	defer file.Close()
+
+	report, apiErr := services.FixtureService.Import(ctx.Request.Context(), file, format)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(report.StatusCode(), swaggertypes.NoErrorData{
+		Data: report,
+		Code: report.StatusCode(),
+	})
+}
+
+// Sync
+// @Summary Sync fixtures
+// @Description Import the fixtures of a league season from API Sports and score the predictions of finished fixtures
+// @ID v1-fixtures-sync
+// @Produce json
+// @Accept json
+// @Tags Fixtures
+// @Security ApiKeyAuth
+// @Param JSON request body fixtures.SyncFixtureInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /fixtures/sync [post]
+func (c *fixtureController) Sync(ctx *gin.Context) {
+	var req fixtures.SyncFixtureInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	if err := services.FixtureService.Sync(ctx.Request.Context(), &req); err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockFixtureService struct {
+	FuncFind func(id int64) (*fixtures.Fixture, resterror.RestErrorI)
+	FuncList func(req *fixtures.ListFixtureInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	FuncSync func(req *fixtures.SyncFixtureInput) resterror.RestErrorI
+}
+
+func (m MockFixtureService) Find(ctx context.Context, id int64) (*fixtures.Fixture, resterror.RestErrorI) {
+	return m.FuncFind(id)
+}
+func (m MockFixtureService) List(ctx context.Context, req *fixtures.ListFixtureInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncList(req)
+}
+func (m MockFixtureService) Export(ctx context.Context, req *fixtures.ListFixtureInput, w export.Writer) resterror.RestErrorI {
+	return nil
+}
+func (m MockFixtureService) Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI) {
+	return &importer.Report{}, nil
+}
+func (m MockFixtureService) Sync(ctx context.Context, req *fixtures.SyncFixtureInput) resterror.RestErrorI {
+	return m.FuncSync(req)
+}
+
+var testFixture = fixtures.Fixture{
+	ID:           10,
+	LeagueID:     39,
+	Season:       2021,
+	Round:        "Regular Season - 21",
+	KickoffAt:    time.Date(2022, 1, 15, 15, 0, 0, 0, time.UTC),
+	Status:       "NS",
+	HomeTeamID:   1,
+	HomeTeamName: "Home",
+	AwayTeamID:   2,
+	AwayTeamName: "Away",
+}
+
+const testFixtureJSON = `{"id":10,"league_id":39,"season":2021,"round":"Regular Season - 21","kickoff_at":"2022-01-15T15:00:00Z","status":"NS","home_team_id":1,"home_team_name":"Home","away_team_id":2,"away_team_name":"Away","home_goals":null,"away_goals":null,"winner_team_id":null,"scored_at":null,"details_synced_at":null}`
+
+func TestFixtureController_Find(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.FixtureServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid fixture id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_FIXTURE_ID","error_code":"INVALID_FIXTURE_ID","code":400}`,
+		},
+		{
+			title: "error FixtureService.Find",
+			id:    "10",
+			serviceMock: &MockFixtureService{
+				FuncFind: func(id int64) (*fixtures.Fixture, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			id:    "10",
+			serviceMock: &MockFixtureService{
+				FuncFind: func(id int64) (*fixtures.Fixture, resterror.RestErrorI) {
+					return &testFixture, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":` + testFixtureJSON + `,"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/fixtures/"+testCase.id, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.FixtureService = testCase.serviceMock
+			FixtureController.Find(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestFixtureController_List(t *testing.T) {
+	testCases := []struct {
+		title          string
+		query          string
+		serviceMock    services.FixtureServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error validation invalid from",
+			query:          "?from=15-01-2022",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"from":["The from must have the format YYYY-MM-DD"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:          "error validation invalid paging",
+			query:          "?page=0&per_page=-1",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"per_page":["The per page must be at least 1"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error FixtureService.List",
+			query: "?league_id=39",
+			serviceMock: &MockFixtureService{
+				FuncList: func(req *fixtures.ListFixtureInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			query: "?league_id=39&season=2021",
+			serviceMock: &MockFixtureService{
+				FuncList: func(req *fixtures.ListFixtureInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					res := pagination.GeneratePaginatedResponse([]fixtures.Fixture{testFixture}, req.Page, req.PerPage, 1)
+					return &res, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"from":1,"data":[` + testFixtureJSON + `],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/fixtures"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.FixtureService = testCase.serviceMock
+			FixtureController.List(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestFixtureController_Sync(t *testing.T) {
+	testCases := []struct {
+		title          string
+		reqBody        io.Reader
+		serviceMock    services.FixtureServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error required fields",
+			reqBody:        strings.NewReader(`{}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"league_id":["The league id field is required."],"season":["The season field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error FixtureService.Sync",
+			reqBody: strings.NewReader(`{"league_id":39,"season":2021}`),
+			serviceMock: &MockFixtureService{
+				FuncSync: func(req *fixtures.SyncFixtureInput) resterror.RestErrorI {
+					return resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title:   "success",
+			reqBody: strings.NewReader(`{"league_id":39,"season":2021}`),
+			serviceMock: &MockFixtureService{
+				FuncSync: func(req *fixtures.SyncFixtureInput) resterror.RestErrorI {
+					return nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/fixtures/sync", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.FixtureService = testCase.serviceMock
+			FixtureController.Sync(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
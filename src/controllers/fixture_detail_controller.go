@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type fixtureDetailControllerInterface interface {
+	Events(ctx *gin.Context)
+	Lineups(ctx *gin.Context)
+	Statistics(ctx *gin.Context)
+	Sync(ctx *gin.Context)
+}
+
+type fixtureDetailController struct{}
+
+var FixtureDetailController fixtureDetailControllerInterface = &fixtureDetailController{}
+
+// Events
+// @Summary Fixture events
+// @Description Retrieve the goals, cards, substitutions and VAR decisions of a fixture with their minute and players, in the order they happened. Synced once the fixture finishes
+// @ID v1-fixtures-events
+// @Produce json
+// @Tags Fixtures
+// @Security ApiKeyAuth
+// @Param id path int true "Fixture ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=[]fixture_events.Event}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /fixtures/{id}/events [get]
+func (c *fixtureDetailController) Events(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_FIXTURE_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.FixtureDetailService.Events(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// Lineups
+// @Summary Fixture lineups
+// @Description Retrieve the formations, coaches, starting elevens and substitutes of the teams of a fixture. Synced once the fixture finishes
+// @ID v1-fixtures-lineups
+// @Produce json
+// @Tags Fixtures
+// @Security ApiKeyAuth
+// @Param id path int true "Fixture ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=[]fixture_lineups.Lineup}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /fixtures/{id}/lineups [get]
+func (c *fixtureDetailController) Lineups(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_FIXTURE_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.FixtureDetailService.Lineups(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// Statistics
+// @Summary Fixture statistics
+// @Description Retrieve the match statistics of the teams of a fixture: shots, shots on target, possession, corners, cards, passes and expected goals when API Sports covers them. Synced once the fixture finishes
+// @ID v1-fixtures-statistics
+// @Produce json
+// @Tags Fixtures
+// @Security ApiKeyAuth
+// @Param id path int true "Fixture ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=[]fixture_statistics.TeamStatistic}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /fixtures/{id}/statistics [get]
+func (c *fixtureDetailController) Statistics(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_FIXTURE_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.FixtureDetailService.Statistics(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// Sync
+// @Summary Sync fixture details
+// @Description Import from API Sports the events, lineups and statistics of a fixture, or of the finished fixtures of a league season that do not have them all yet. The fixtures must be synced first
+// @ID v1-fixtures-details-sync
+// @Produce json
+// @Accept json
+// @Tags Fixtures
+// @Security ApiKeyAuth
+// @Param JSON request body fixtures.SyncDetailsInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /fixtures/details/sync [post]
+func (c *fixtureDetailController) Sync(ctx *gin.Context) {
+	var req fixtures.SyncDetailsInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	if err := services.FixtureDetailService.SyncMissing(ctx.Request.Context(), &req); err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
@@ -0,0 +1,256 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_events"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_lineups"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_statistics"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type MockFixtureDetailService struct {
+	FuncEvents      func(fixtureID int64) ([]fixture_events.Event, resterror.RestErrorI)
+	FuncLineups     func(fixtureID int64) ([]fixture_lineups.Lineup, resterror.RestErrorI)
+	FuncStatistics  func(fixtureID int64) ([]fixture_statistics.TeamStatistic, resterror.RestErrorI)
+	FuncSync        func(fixtureID int64) resterror.RestErrorI
+	FuncSyncMissing func(req *fixtures.SyncDetailsInput) resterror.RestErrorI
+}
+
+func (m MockFixtureDetailService) Events(ctx context.Context, fixtureID int64) ([]fixture_events.Event, resterror.RestErrorI) {
+	return m.FuncEvents(fixtureID)
+}
+func (m MockFixtureDetailService) Lineups(ctx context.Context, fixtureID int64) ([]fixture_lineups.Lineup, resterror.RestErrorI) {
+	return m.FuncLineups(fixtureID)
+}
+func (m MockFixtureDetailService) Statistics(ctx context.Context, fixtureID int64) ([]fixture_statistics.TeamStatistic, resterror.RestErrorI) {
+	return m.FuncStatistics(fixtureID)
+}
+func (m MockFixtureDetailService) Sync(ctx context.Context, fixtureID int64) resterror.RestErrorI {
+	return m.FuncSync(fixtureID)
+}
+func (m MockFixtureDetailService) SyncMissing(ctx context.Context, req *fixtures.SyncDetailsInput) resterror.RestErrorI {
+	return m.FuncSyncMissing(req)
+}
+
+func TestFixtureDetailController_Events(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.FixtureDetailServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid fixture id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_FIXTURE_ID","error_code":"INVALID_FIXTURE_ID","code":400}`,
+		},
+		{
+			title: "error FixtureDetailService.Events",
+			id:    "10",
+			serviceMock: &MockFixtureDetailService{
+				FuncEvents: func(fixtureID int64) ([]fixture_events.Event, resterror.RestErrorI) {
+					return nil, resterror.NewDatabaseError(sql.ErrNoRows, "fixture")
+				},
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedRes:    `{"error":"The fixture does not exist","error_code":"FIXTURE_NOT_FOUND","code":404}`,
+		},
+		{
+			title: "success",
+			id:    "10",
+			serviceMock: &MockFixtureDetailService{
+				FuncEvents: func(fixtureID int64) ([]fixture_events.Event, resterror.RestErrorI) {
+					return []fixture_events.Event{{FixtureID: fixtureID, Sequence: 1, Elapsed: 23, TeamID: 1, TeamName: "Home",
+						Type: fixture_events.TypeGoal, Detail: "Normal Goal"}}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":[{"fixture_id":10,"sequence":1,"elapsed":23,"extra":null,"team_id":1,"team_name":"Home",` +
+				`"player_id":null,"player_name":null,"assist_id":null,"assist_name":null,"type":"goal","detail":"Normal Goal","comments":null}],"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/fixtures/"+testCase.id+"/events", nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.FixtureDetailService = testCase.serviceMock
+			FixtureDetailController.Events(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestFixtureDetailController_Lineups(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.FixtureDetailServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid fixture id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_FIXTURE_ID","error_code":"INVALID_FIXTURE_ID","code":400}`,
+		},
+		{
+			title: "success",
+			id:    "10",
+			serviceMock: &MockFixtureDetailService{
+				FuncLineups: func(fixtureID int64) ([]fixture_lineups.Lineup, resterror.RestErrorI) {
+					return []fixture_lineups.Lineup{{FixtureID: fixtureID, TeamID: 1, TeamName: "Home", Formation: "4-3-3",
+						StartXI:     []fixture_lineups.Player{{PlayerName: "Keeper", Starter: true}},
+						Substitutes: []fixture_lineups.Player{}}}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":[{"fixture_id":10,"team_id":1,"team_name":"Home","formation":"4-3-3","coach_id":null,"coach_name":null,` +
+				`"start_xi":[{"player_id":null,"player_name":"Keeper","number":null,"position":null,"grid":null}],"substitutes":[]}],"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/fixtures/"+testCase.id+"/lineups", nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.FixtureDetailService = testCase.serviceMock
+			FixtureDetailController.Lineups(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestFixtureDetailController_Statistics(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.FixtureDetailServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid fixture id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_FIXTURE_ID","error_code":"INVALID_FIXTURE_ID","code":400}`,
+		},
+		{
+			title: "success",
+			id:    "10",
+			serviceMock: &MockFixtureDetailService{
+				FuncStatistics: func(fixtureID int64) ([]fixture_statistics.TeamStatistic, resterror.RestErrorI) {
+					return []fixture_statistics.TeamStatistic{{FixtureID: fixtureID, TeamID: 1, TeamName: "Home", Shots: 14, ShotsOnTarget: 6}}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":[{"fixture_id":10,"team_id":1,"team_name":"Home","shots":14,"shots_on_target":6,"shots_off_target":0,` +
+				`"blocked_shots":0,"shots_inside_box":0,"shots_outside_box":0,"fouls":0,"corners":0,"offsides":0,"possession":null,` +
+				`"yellow_cards":0,"red_cards":0,"saves":0,"passes":0,"passes_accurate":0,"expected_goals":null}],"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/fixtures/"+testCase.id+"/statistics", nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.FixtureDetailService = testCase.serviceMock
+			FixtureDetailController.Statistics(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestFixtureDetailController_Sync(t *testing.T) {
+	testCases := []struct {
+		title          string
+		reqBody        io.Reader
+		serviceMock    services.FixtureDetailServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error required fields",
+			reqBody:        strings.NewReader(`{}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"fixture_id":["The fixture id field is required if league id is not present"],"league_id":["The league id field is required if fixture id is not present"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:          "error league without season",
+			reqBody:        strings.NewReader(`{"league_id":39}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"season":["The season field is required with league id"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error FixtureDetailService.SyncMissing",
+			reqBody: strings.NewReader(`{"fixture_id":10}`),
+			serviceMock: &MockFixtureDetailService{
+				FuncSyncMissing: func(req *fixtures.SyncDetailsInput) resterror.RestErrorI {
+					return resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title:   "success",
+			reqBody: strings.NewReader(`{"league_id":39,"season":2021}`),
+			serviceMock: &MockFixtureDetailService{
+				FuncSyncMissing: func(req *fixtures.SyncDetailsInput) resterror.RestErrorI {
+					return nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/fixtures/details/sync", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.FixtureDetailService = testCase.serviceMock
+			FixtureDetailController.Sync(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
@@ -24,4 +24,4 @@ var HealthController healthControllerInterface = &healthController{}
 // @Router / [get]
 func (hc *healthController) Check(c *gin.Context) {
 	c.String(http.StatusOK, "I'm alive")
-}
\ No newline at end of file
+}
@@ -10,7 +10,7 @@ import (
 
 func TestHealthController_Check(t *testing.T) {
 	// Initialization
-	res:= httptest.NewRecorder()
+	res := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	c := test.GetMockedContext(req, res)
 
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/injuries"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type injuryControllerInterface interface {
+	Unavailable(ctx *gin.Context)
+	Sync(ctx *gin.Context)
+}
+
+type injuryController struct{}
+
+var InjuryController injuryControllerInterface = &injuryController{}
+
+// Unavailable
+// @Summary Team unavailable players
+// @Description Retrieve the injured and suspended players of a team listed out of its next fixture, with their expected return and the share of the minutes of the team they played, the ones who played the most first. Empty until the injuries of the fixture are synced
+// @ID v1-teams-unavailable
+// @Produce json
+// @Tags Injuries
+// @Security ApiKeyAuth
+// @Param id path int true "Team ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=[]injuries.Unavailable}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /teams/{id}/unavailable [get]
+func (c *injuryController) Unavailable(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_TEAM_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.InjuryService.Unavailable(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// Sync
+// @Summary Sync injuries
+// @Description Import from API Sports the injured and suspended players missing a fixture, or the fixtures of a league season, with the end of their sideline period as expected return. The fixtures must be synced first
+// @ID v1-injuries-sync
+// @Produce json
+// @Accept json
+// @Tags Injuries
+// @Security ApiKeyAuth
+// @Param JSON request body injuries.SyncInjuryInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /injuries/sync [post]
+func (c *injuryController) Sync(ctx *gin.Context) {
+	var req injuries.SyncInjuryInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	if err := services.InjuryService.Sync(ctx.Request.Context(), &req); err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
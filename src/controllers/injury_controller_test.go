@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/injuries"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockInjuryService struct {
+	FuncUnavailable func(teamID int64) ([]injuries.Unavailable, resterror.RestErrorI)
+	FuncSync        func(req *injuries.SyncInjuryInput) resterror.RestErrorI
+}
+
+func (m MockInjuryService) Unavailable(ctx context.Context, teamID int64) ([]injuries.Unavailable, resterror.RestErrorI) {
+	return m.FuncUnavailable(teamID)
+}
+func (m MockInjuryService) Sync(ctx context.Context, req *injuries.SyncInjuryInput) resterror.RestErrorI {
+	return m.FuncSync(req)
+}
+
+func TestInjuryController_Unavailable(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.InjuryServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid team id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_TEAM_ID","error_code":"INVALID_TEAM_ID","code":400}`,
+		},
+		{
+			title: "error InjuryService.Unavailable",
+			id:    "85",
+			serviceMock: &MockInjuryService{
+				FuncUnavailable: func(teamID int64) ([]injuries.Unavailable, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			id:    "85",
+			serviceMock: &MockInjuryService{
+				FuncUnavailable: func(teamID int64) ([]injuries.Unavailable, resterror.RestErrorI) {
+					return []injuries.Unavailable{{
+						Injury: injuries.Injury{FixtureID: 10, PlayerID: 276, PlayerName: "Neymar", TeamID: teamID,
+							TeamName: "Paris Saint Germain", Type: injuries.TypeMissing, Reason: "Knee Injury"},
+						KickoffAt:    time.Date(2021, 11, 6, 20, 0, 0, 0, time.UTC),
+						MinutesShare: 0.82,
+					}}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":[{"fixture_id":10,"player_id":276,"player_name":"Neymar","team_id":85,"team_name":"Paris Saint Germain",` +
+				`"type":"missing","reason":"Knee Injury","expected_return":null,"kickoff_at":"2021-11-06T20:00:00Z","minutes_share":0.82}],"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/teams/"+testCase.id+"/unavailable", nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.InjuryService = testCase.serviceMock
+			InjuryController.Unavailable(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestInjuryController_Sync(t *testing.T) {
+	testCases := []struct {
+		title          string
+		reqBody        io.Reader
+		serviceMock    services.InjuryServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error required fields",
+			reqBody:        strings.NewReader(`{}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"fixture_id":["The fixture id field is required if league id is not present"],"league_id":["The league id field is required if fixture id is not present"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:          "error league without season",
+			reqBody:        strings.NewReader(`{"league_id":61}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"season":["The season field is required with league id"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error InjuryService.Sync",
+			reqBody: strings.NewReader(`{"fixture_id":10}`),
+			serviceMock: &MockInjuryService{
+				FuncSync: func(req *injuries.SyncInjuryInput) resterror.RestErrorI {
+					return resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title:   "success",
+			reqBody: strings.NewReader(`{"league_id":61,"season":2021}`),
+			serviceMock: &MockInjuryService{
+				FuncSync: func(req *injuries.SyncInjuryInput) resterror.RestErrorI {
+					return nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/injuries/sync", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.InjuryService = testCase.serviceMock
+			InjuryController.Sync(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/leagues"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type leagueControllerInterface interface {
+	Find(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Export(ctx *gin.Context)
+	Import(ctx *gin.Context)
+	Sync(ctx *gin.Context)
+}
+
+type leagueController struct{}
+
+var LeagueController leagueControllerInterface = &leagueController{}
+
+// Find
+// @Summary Find league
+// @Description Retrieve a league identified by id
+// @ID v1-leagues-find
+// @Produce json
+// @Tags Leagues
+// @Security ApiKeyAuth
+// @Param id path int true "League ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=leagues.League}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /leagues/{id} [get]
+func (c *leagueController) Find(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_LEAGUE_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.LeagueService.Find(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// List
+// @Summary List leagues
+// @Description Retrieve leagues filtered by name, type or country
+// @ID v1-leagues-list
+// @Produce json
+// @Tags Leagues
+// @Security ApiKeyAuth
+// @Param name query string false "filter by part of the name, also name[eq], name[ne] and name[in]"
+// @Param type query string false "filter by type, also type[ne] and type[in]" Enums(League,Cup)
+// @Param country_name query string false "filter by country name, also country_name[ne], country_name[in] and country_name[like]"
+// @Param country_code query string false "filter by country code, also country_code[ne], country_code[in] and country_code[null]"
+// @Param active query boolean false "filter by status" Enums(true,false)
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order e.g. country_name,-name"
+// @Param fields query string false "comma separated fields to return e.g. id,name"
+// @Param order query string false "order direction" Enums(asc,desc)
+// @Param order_by query string false "order field" Enums(id,name,country_name)
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous response, replaces page"
+// @Param total query bool false "count the records, false skips the count and returns a total and last_page of -1" Enums(true,false)
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]leagues.League}}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /leagues [get]
+func (c *leagueController) List(ctx *gin.Context) {
+	var req leagues.ListLeagueInput
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldFilter(&req.Filter, leagues.League{}),
+	); !ok {
+		return
+	}
+
+	results, apiErr := services.LeagueService.List(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
+
+// Export
+// @Summary Export leagues
+// @Description Stream the leagues matching the filters of the list endpoint as a CSV, JSON or NDJSON attachment, the fields parameter selects the columns
+// @ID v1-leagues-export
+// @Produce json,text/csv,application/x-ndjson
+// @Tags Leagues
+// @Security ApiKeyAuth
+// @Param format query string false "export format, json by default" Enums(csv,json,ndjson)
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order"
+// @Param fields query string false "comma separated fields to export"
+// @Success 200 {array} leagues.League
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /leagues/export [get]
+func (c *leagueController) Export(ctx *gin.Context) {
+	var req leagues.ListLeagueInput
+	var format export.Input
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldBind(&format),
+		utils.GinShouldValidate(&format),
+		utils.GinShouldFilter(&req.Filter, leagues.League{}),
+	); !ok {
+		return
+	}
+
+	w := export.NewWriter(export.Response(ctx.Writer, format.Format, "leagues"), format.Format, req.Filter.Columns(leagues.League{}))
+	if apiErr := services.LeagueService.Export(ctx.Request.Context(), &req, w); apiErr != nil && !ctx.Writer.Written() {
+		ctx.JSON(apiErr.Code(), apiErr)
+	}
+}
+
+// Import
+// @Summary Import leagues
+// @Description Upsert the rows of a CSV or JSON upload, sent as the file field of a multipart form or as the request body. The columns are the fields of League: id, name and type (required), logo, country_name, country_code and active. Valid rows are saved even when others fail, every failed row is reported
+// @ID v1-leagues-import
+// @Produce json
+// @Accept mpfd,text/csv,json
+// @Tags Leagues
+// @Security ApiKeyAuth
+// @Param file formData file false "CSV or JSON file"
+// @Param format query string false "upload format, by default from the file extension or the content type" Enums(csv,json)
+// @Success 200 {object} swaggertypes.NoErrorI{data=importer.Report}
+// @Success 207 {object} swaggertypes.NoErrorI{data=importer.Report}
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /leagues/import [post]
+func (c *leagueController) Import(ctx *gin.Context) {
+	file, format, apiErr := importer.Upload(ctx.Request)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	defer file.Close()
+
+	report, apiErr := services.LeagueService.Import(ctx.Request.Context(), file, format)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(report.StatusCode(), swaggertypes.NoErrorData{
+		Data: report,
+		Code: report.StatusCode(),
+	})
+}
+
+// Sync
+// @Summary Sync leagues
+// @Description Import the leagues from API Sports
+// @ID v1-leagues-sync
+// @Produce json
+// @Tags Leagues
+// @Security ApiKeyAuth
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /leagues/sync [post]
+func (c *leagueController) Sync(ctx *gin.Context) {
+	if err := services.LeagueService.Sync(ctx.Request.Context()); err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/leagues"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockLeagueService struct {
+	FuncFind func(id int64) (*leagues.League, resterror.RestErrorI)
+	FuncList func(req *leagues.ListLeagueInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	FuncSync func() resterror.RestErrorI
+}
+
+func (m MockLeagueService) Find(ctx context.Context, id int64) (*leagues.League, resterror.RestErrorI) {
+	return m.FuncFind(id)
+}
+func (m MockLeagueService) List(ctx context.Context, req *leagues.ListLeagueInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncList(req)
+}
+func (m MockLeagueService) Export(ctx context.Context, req *leagues.ListLeagueInput, w export.Writer) resterror.RestErrorI {
+	return nil
+}
+func (m MockLeagueService) Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI) {
+	return &importer.Report{}, nil
+}
+func (m MockLeagueService) Sync(ctx context.Context) resterror.RestErrorI {
+	return m.FuncSync()
+}
+
+var testLeague = leagues.League{
+	ID:          39,
+	Name:        "Premier League",
+	Type:        "League",
+	Logo:        "39.png",
+	CountryName: "England",
+	CountryCode: "GB",
+	Active:      true,
+}
+
+const testLeagueJSON = `{"id":39,"name":"Premier League","type":"League","logo":"39.png","country_name":"England","country_code":"GB","active":true}`
+
+func TestLeagueController_Find(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.LeagueServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid league id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_LEAGUE_ID","error_code":"INVALID_LEAGUE_ID","code":400}`,
+		},
+		{
+			title: "error LeagueService.Find",
+			id:    "39",
+			serviceMock: &MockLeagueService{
+				FuncFind: func(id int64) (*leagues.League, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			id:    "39",
+			serviceMock: &MockLeagueService{
+				FuncFind: func(id int64) (*leagues.League, resterror.RestErrorI) {
+					return &testLeague, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":` + testLeagueJSON + `,"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/leagues/"+testCase.id, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.LeagueService = testCase.serviceMock
+			LeagueController.Find(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestLeagueController_List(t *testing.T) {
+	testCases := []struct {
+		title          string
+		query          string
+		serviceMock    services.LeagueServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error validation invalid type",
+			query:          "?type=Friendly",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"type":["The field: 'type' must be one of [League Cup]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error LeagueService.List",
+			query: "?country_code=GB",
+			serviceMock: &MockLeagueService{
+				FuncList: func(req *leagues.ListLeagueInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			query: "?country_code=GB&type=League",
+			serviceMock: &MockLeagueService{
+				FuncList: func(req *leagues.ListLeagueInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					res := pagination.GeneratePaginatedResponse([]leagues.League{testLeague}, req.Page, req.PerPage, 1)
+					return &res, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"from":1,"data":[` + testLeagueJSON + `],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/leagues"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.LeagueService = testCase.serviceMock
+			LeagueController.List(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestLeagueController_Sync(t *testing.T) {
+	testCases := []struct {
+		title          string
+		serviceMock    services.LeagueServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title: "error LeagueService.Sync",
+			serviceMock: &MockLeagueService{
+				FuncSync: func() resterror.RestErrorI {
+					return resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			serviceMock: &MockLeagueService{
+				FuncSync: func() resterror.RestErrorI {
+					return nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/leagues/sync", nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.LeagueService = testCase.serviceMock
+			LeagueController.Sync(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
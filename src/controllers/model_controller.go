@@ -0,0 +1,250 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/models"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type modelControllerInterface interface {
+	Create(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Find(ctx *gin.Context)
+	Champion(ctx *gin.Context)
+	Compare(ctx *gin.Context)
+	Fit(ctx *gin.Context)
+}
+
+type modelController struct{}
+
+var ModelController modelControllerInterface = &modelController{}
+
+// Create
+// @Summary Register model version
+// @Description Register the next version of a prediction model with its hyperparameters, which can not change afterwards. The hyperparameters left out take their default: none for poisson, rho, the fixed correction of the low scores, for poisson-rho, xi, the time decay per day, for dixon-coles, k, home_advantage and supremacy for elo, and members, a list of model, weight and hyperparameters, for ensemble
+// @ID v1-models-create
+// @Produce json
+// @Accept json
+// @Tags Models
+// @Security ApiKeyAuth
+// @Param JSON request body models.CreateModelInput true "Request Sample"
+// @Success 201 {object} swaggertypes.NoErrorI{data=models.Model}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 409 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /models [post]
+func (c *modelController) Create(ctx *gin.Context) {
+	var req models.CreateModelInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	result, err := services.ModelService.Create(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusCreated,
+	})
+}
+
+// List
+// @Summary List model versions
+// @Description Retrieve the registered model versions, the champion is the one used when a prediction does not request a model
+// @ID v1-models-list
+// @Produce json
+// @Tags Models
+// @Security ApiKeyAuth
+// @Param name query string false "filter by model, also name[in]" Enums(poisson,poisson-rho,dixon-coles,elo,ensemble)
+// @Param version query integer false "filter by version, also version[gte] and version[lte]"
+// @Param champion query bool false "filter the champion" Enums(true,false)
+// @Param description[like] query string false "description containing the value"
+// @Param sort query string false "comma separated sort fields e.g. id or -version, prefixed with - for descending order, -created_at by default"
+// @Param fields query string false "comma separated fields to return e.g. id,name,version"
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous response, replaces page"
+// @Param total query bool false "count the records, false skips the count and returns a total and last_page of -1" Enums(true,false)
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]models.Model}}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /models [get]
+func (c *modelController) List(ctx *gin.Context) {
+	var req models.ListModelInput
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldFilter(&req.Filter, models.Model{}),
+	); !ok {
+		return
+	}
+
+	results, apiErr := services.ModelService.List(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
+
+// Find
+// @Summary Find model version
+// @Description Retrieve a model version identified by id with its hyperparameters
+// @ID v1-models-find
+// @Produce json
+// @Tags Models
+// @Security ApiKeyAuth
+// @Param id path int true "Model ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=models.Model}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /models/{id} [get]
+func (c *modelController) Find(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_MODEL_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.ModelService.Find(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// Champion
+// @Summary Make model version champion
+// @Description Make a model version the champion, the one used by the predictions that do not request a model and by the value bets. The previous champion becomes a challenger
+// @ID v1-models-champion
+// @Produce json
+// @Tags Models
+// @Security ApiKeyAuth
+// @Param id path int true "Model ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=models.Model}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /models/{id}/champion [put]
+func (c *modelController) Champion(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_MODEL_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.ModelService.Champion(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// Compare
+// @Summary Compare model versions
+// @Description Compare model versions side by side with their prediction of a fixture and their latest finished backtest of its league. The prediction is null without a fixture or when the model has not enough data, the backtest is null when the version was not backtested
+// @ID v1-models-compare
+// @Produce json
+// @Tags Models
+// @Security ApiKeyAuth
+// @Param models query string true "comma separated model versions e.g. poisson@1,dixon-coles@3, a name alone is its latest version, at most 10"
+// @Param fixture_id query integer false "fixture to predict"
+// @Param league_id query integer false "league of the backtests, the league of the fixture by default and any league without a fixture"
+// @Success 200 {object} swaggertypes.NoErrorI{data=[]models.Comparison}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /models/compare [get]
+func (c *modelController) Compare(ctx *gin.Context) {
+	var req models.CompareModelInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	results, apiErr := services.ModelService.Compare(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
+
+// Fit
+// @Summary Fit model version
+// @Description Fit a model version on the fixtures of a league that finished until now in the season and the previous one and retrieve its parameters: the league averages and team strengths for poisson, the rho, home advantage and team attacks and defences for dixon-coles, the ratings for elo and the members for ensemble. The fit is not stored
+// @ID v1-models-fit
+// @Produce json
+// @Tags Models
+// @Security ApiKeyAuth
+// @Param id path int true "Model ID"
+// @Param league_id query integer true "league of the fixtures"
+// @Param season query integer true "season of the fixtures"
+// @Success 200 {object} swaggertypes.NoErrorI{data=models.Fit}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 422 {object} swaggertypes.StandardBadRequestError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /models/{id}/fit [get]
+func (c *modelController) Fit(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_MODEL_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	var req models.FitInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	result, apiErr := services.ModelService.Fit(ctx.Request.Context(), id, &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
@@ -0,0 +1,422 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/development-raul/footy-predictor/src/domains/models"
+	"github.com/development-raul/footy-predictor/src/domains/predictions"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testModelJSON = `{"id":3,"name":"dixon-coles","version":2,"hyperparameters":{"xi":0.005},"description":"Faster decay","champion":false,"created_at":"2022-01-14T10:00:00Z"}`
+
+type MockModelService struct {
+	FuncCreate   func(req *models.CreateModelInput) (*models.Model, resterror.RestErrorI)
+	FuncFind     func(id int64) (*models.Model, resterror.RestErrorI)
+	FuncList     func(req *models.ListModelInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	FuncChampion func(id int64) (*models.Model, resterror.RestErrorI)
+	FuncCompare  func(req *models.CompareModelInput) ([]models.Comparison, resterror.RestErrorI)
+	FuncFit      func(id int64, req *models.FitInput) (*models.Fit, resterror.RestErrorI)
+}
+
+func (m MockModelService) Create(ctx context.Context, req *models.CreateModelInput) (*models.Model, resterror.RestErrorI) {
+	return m.FuncCreate(req)
+}
+func (m MockModelService) Find(ctx context.Context, id int64) (*models.Model, resterror.RestErrorI) {
+	return m.FuncFind(id)
+}
+func (m MockModelService) List(ctx context.Context, req *models.ListModelInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncList(req)
+}
+func (m MockModelService) Champion(ctx context.Context, id int64) (*models.Model, resterror.RestErrorI) {
+	return m.FuncChampion(id)
+}
+func (m MockModelService) Compare(ctx context.Context, req *models.CompareModelInput) ([]models.Comparison, resterror.RestErrorI) {
+	return m.FuncCompare(req)
+}
+
+func (m MockModelService) Fit(ctx context.Context, id int64, req *models.FitInput) (*models.Fit, resterror.RestErrorI) {
+	return m.FuncFit(id, req)
+}
+
+func testModel() *models.Model {
+	return &models.Model{
+		ID:              3,
+		Name:            "dixon-coles",
+		Version:         2,
+		Hyperparameters: json.RawMessage(`{"xi":0.005}`),
+		Description:     "Faster decay",
+		CreatedAt:       time.Date(2022, 1, 14, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestModelController_Create(t *testing.T) {
+	testCases := []struct {
+		title          string
+		reqBody        io.Reader
+		serviceMock    services.ModelServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid name",
+			reqBody:        strings.NewReader(`{"name":"xgboost"}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"name":["The field: 'name' must be one of [poisson poisson-rho dixon-coles elo ensemble]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error ModelService.Create",
+			reqBody: strings.NewReader(`{"name":"dixon-coles","hyperparameters":{"xi":-1}}`),
+			serviceMock: &MockModelService{
+				FuncCreate: func(req *models.CreateModelInput) (*models.Model, resterror.RestErrorI) {
+					return nil, resterror.NewBadRequestError("INVALID_HYPERPARAMETERS")
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_HYPERPARAMETERS","error_code":"INVALID_HYPERPARAMETERS","code":400}`,
+		},
+		{
+			title:   "success",
+			reqBody: strings.NewReader(`{"name":"dixon-coles","hyperparameters":{"xi":0.005},"description":"Faster decay"}`),
+			serviceMock: &MockModelService{
+				FuncCreate: func(req *models.CreateModelInput) (*models.Model, resterror.RestErrorI) {
+					if req.Name != "dixon-coles" || string(req.Hyperparameters) != `{"xi":0.005}` {
+						return nil, resterror.NewStandardInternalServerError()
+					}
+					return testModel(), nil
+				},
+			},
+			expectedStatus: http.StatusCreated,
+			expectedRes:    `{"data":` + testModelJSON + `,"code":201}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/models", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.ModelService = testCase.serviceMock
+			ModelController.Create(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestModelController_List(t *testing.T) {
+	testCases := []struct {
+		title          string
+		query          string
+		serviceMock    services.ModelServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error filter invalid name",
+			query:          "?name=xgboost",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"name":["The field: 'name' must be one of [poisson poisson-rho dixon-coles elo ensemble]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "success",
+			query: "?name=dixon-coles&sort=-version",
+			serviceMock: &MockModelService{
+				FuncList: func(req *models.ListModelInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return &pagination.PaginatedResponse{
+						Data:        []models.Model{*testModel()},
+						CurrentPage: 1,
+						LastPage:    1,
+						PerPage:     20,
+						From:        1,
+						To:          1,
+						Total:       1,
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"from":1,"data":[` + testModelJSON + `],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/models"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.ModelService = testCase.serviceMock
+			ModelController.List(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestModelController_Find(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.ModelServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid model id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_MODEL_ID","error_code":"INVALID_MODEL_ID","code":400}`,
+		},
+		{
+			title: "success",
+			id:    "3",
+			serviceMock: &MockModelService{
+				FuncFind: func(id int64) (*models.Model, resterror.RestErrorI) {
+					return testModel(), nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":` + testModelJSON + `,"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/models/"+testCase.id, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.ModelService = testCase.serviceMock
+			ModelController.Find(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestModelController_Champion(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.ModelServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid model id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_MODEL_ID","error_code":"INVALID_MODEL_ID","code":400}`,
+		},
+		{
+			title: "error ModelService.Champion",
+			id:    "9",
+			serviceMock: &MockModelService{
+				FuncChampion: func(id int64) (*models.Model, resterror.RestErrorI) {
+					return nil, resterror.NewNotFoundError("MODEL_NOT_FOUND")
+				},
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedRes:    `{"error":"MODEL_NOT_FOUND","error_code":"MODEL_NOT_FOUND","code":404}`,
+		},
+		{
+			title: "success",
+			id:    "3",
+			serviceMock: &MockModelService{
+				FuncChampion: func(id int64) (*models.Model, resterror.RestErrorI) {
+					model := testModel()
+					model.Champion = true
+					return model, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":` + strings.Replace(testModelJSON, `"champion":false`, `"champion":true`, 1) + `,"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("PUT", "https://localhost:8000/v1/models/"+testCase.id+"/champion", nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.ModelService = testCase.serviceMock
+			ModelController.Champion(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestModelController_Compare(t *testing.T) {
+	testCases := []struct {
+		title          string
+		query          string
+		serviceMock    services.ModelServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error models required",
+			query:          "?fixture_id=10",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"models":["The models field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error ModelService.Compare",
+			query: "?models=poisson@x",
+			serviceMock: &MockModelService{
+				FuncCompare: func(req *models.CompareModelInput) ([]models.Comparison, resterror.RestErrorI) {
+					return nil, resterror.NewBadRequestError("INVALID_MODEL")
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_MODEL","error_code":"INVALID_MODEL","code":400}`,
+		},
+		{
+			title: "success",
+			query: "?models=dixon-coles@2&fixture_id=10",
+			serviceMock: &MockModelService{
+				FuncCompare: func(req *models.CompareModelInput) ([]models.Comparison, resterror.RestErrorI) {
+					if req.Models != "dixon-coles@2" || req.FixtureID != 10 {
+						return nil, resterror.NewStandardInternalServerError()
+					}
+					return []models.Comparison{{
+						Model: *testModel(),
+						Prediction: &predictions.Prediction{
+							FixtureID:   10,
+							Model:       "dixon-coles@2",
+							HomeWin:     0.5,
+							Draw:        0.3,
+							AwayWin:     0.2,
+							MatchesUsed: 20,
+						},
+					}}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":[{"model":` + testModelJSON + `,"prediction":{"fixture_id":10,"model":"dixon-coles@2","home_team_id":0,"away_team_id":0,` +
+				`"home_expected_goals":0,"away_expected_goals":0,"home_win":0.5,"draw":0.3,"away_win":0.2,` +
+				`"most_likely_score":{"home_goals":0,"away_goals":0,"probability":0},` +
+				`"markets":{"over_under":null,"both_teams_score":{"yes":0,"no":0},"asian_handicap":null},"matches_used":20},"backtest":null}],"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/models/compare"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.ModelService = testCase.serviceMock
+			ModelController.Compare(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestModelController_Fit(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		query          string
+		serviceMock    services.ModelServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid model id",
+			id:             "abc",
+			query:          "?league_id=39&season=2021",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_MODEL_ID","error_code":"INVALID_MODEL_ID","code":400}`,
+		},
+		{
+			title:          "error season required",
+			id:             "3",
+			query:          "?league_id=39",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"season":["The season field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error ModelService.Fit",
+			id:    "3",
+			query: "?league_id=39&season=2021",
+			serviceMock: &MockModelService{
+				FuncFit: func(id int64, req *models.FitInput) (*models.Fit, resterror.RestErrorI) {
+					return nil, resterror.NewUnprocessableEntityError("NOT_ENOUGH_DATA")
+				},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedRes:    `{"error":"NOT_ENOUGH_DATA","error_code":"NOT_ENOUGH_DATA","code":422}`,
+		},
+		{
+			title: "success",
+			id:    "3",
+			query: "?league_id=39&season=2021",
+			serviceMock: &MockModelService{
+				FuncFit: func(id int64, req *models.FitInput) (*models.Fit, resterror.RestErrorI) {
+					if id != 3 || req.LeagueID != 39 || req.Season != 2021 {
+						return nil, resterror.NewStandardInternalServerError()
+					}
+					now := time.Date(2022, 1, 15, 10, 0, 0, 0, time.UTC)
+					return &models.Fit{
+						ModelID:      3,
+						LeagueID:     39,
+						Season:       2021,
+						FittedBefore: now,
+						Matches:      20,
+						Parameters:   json.RawMessage(`{"home_advantage":0.25,"rho":-0.08,"teams":{"1":{"attack":0.1,"defence":0.05}}}`),
+						CreatedAt:    now,
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":{"model_id":3,"league_id":39,"season":2021,"fitted_before":"2022-01-15T10:00:00Z","matches":20,` +
+				`"parameters":{"home_advantage":0.25,"rho":-0.08,"teams":{"1":{"attack":0.1,"defence":0.05}}},"created_at":"2022-01-15T10:00:00Z"},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/models/"+testCase.id+"/fit"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.ModelService = testCase.serviceMock
+			ModelController.Fit(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
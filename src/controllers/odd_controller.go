@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/odds"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type oddControllerInterface interface {
+	List(ctx *gin.Context)
+	Fixture(ctx *gin.Context)
+	Sync(ctx *gin.Context)
+}
+
+type oddController struct{}
+
+var OddController oddControllerInterface = &oddController{}
+
+// List
+// @Summary List odds
+// @Description Retrieve the recorded prices, every price a bookmaker offered is kept so the odds of a selection sorted by recorded_at are its line movement
+// @ID v1-odds-list
+// @Produce json
+// @Tags Odds
+// @Security ApiKeyAuth
+// @Param fixture_id query integer false "filter by fixture, also fixture_id[in]"
+// @Param bookmaker_id query integer false "filter by bookmaker, also bookmaker_id[ne] and bookmaker_id[in]"
+// @Param market query string false "filter by market, also market[ne] and market[in]" Enums(1x2,over_under,btts,asian_handicap)
+// @Param selection query string false "filter by selection, also selection[in]" Enums(home,draw,away,over,under,yes,no)
+// @Param line query number false "filter by the goals of over_under or the home handicap of asian_handicap, also line[gt], line[gte], line[lt] and line[lte]"
+// @Param recorded_at[gte] query string false "recorded at or after, YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ, also recorded_at[gt], recorded_at[lt] and recorded_at[lte]"
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order, -recorded_at by default"
+// @Param fields query string false "comma separated fields to return e.g. selection,price,recorded_at"
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous response, replaces page"
+// @Param total query bool false "count the records, false skips the count and returns a total and last_page of -1" Enums(true,false)
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]odds.Odd}}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /odds [get]
+func (c *oddController) List(ctx *gin.Context) {
+	var req odds.ListOddInput
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldFilter(&req.Filter, odds.Odd{}),
+	); !ok {
+		return
+	}
+
+	results, apiErr := services.OddService.List(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
+
+// Fixture
+// @Summary Fixture odds
+// @Description Retrieve the current odds of a fixture per bookmaker, market and line with the implied probabilities of the selections once the bookmaker margin (overround) is removed. The probabilities of a line missing a selection are 0
+// @ID v1-fixtures-odds
+// @Produce json
+// @Tags Odds
+// @Security ApiKeyAuth
+// @Param id path int true "Fixture ID"
+// @Param market query string false "only this market" Enums(1x2,over_under,btts,asian_handicap)
+// @Param bookmaker_id query integer false "only this bookmaker"
+// @Param method query string false "overround removal, basic normalisation by default" Enums(basic,shin,power)
+// @Success 200 {object} swaggertypes.NoErrorI{data=[]odds.MarketOdds}
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /fixtures/{id}/odds [get]
+func (c *oddController) Fixture(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_FIXTURE_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	var req odds.LatestOddInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+	req.FixtureID = id
+
+	results, apiErr := services.OddService.Fixture(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
+
+// Sync
+// @Summary Sync odds
+// @Description Import from API Sports the pre-match odds of a fixture, or of the fixtures of a league season, of every bookmaker or of a single one. The 1x2, over_under, btts and asian_handicap markets are stored
+// @ID v1-odds-sync
+// @Produce json
+// @Accept json
+// @Tags Odds
+// @Security ApiKeyAuth
+// @Param JSON request body odds.SyncOddInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /odds/sync [post]
+func (c *oddController) Sync(ctx *gin.Context) {
+	var req odds.SyncOddInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	if err := services.OddService.Sync(ctx.Request.Context(), &req); err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
@@ -0,0 +1,237 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/odds"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockOddService struct {
+	FuncList    func(req *odds.ListOddInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	FuncFixture func(req *odds.LatestOddInput) ([]odds.MarketOdds, resterror.RestErrorI)
+	FuncSync    func(req *odds.SyncOddInput) resterror.RestErrorI
+}
+
+func (m MockOddService) List(ctx context.Context, req *odds.ListOddInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncList(req)
+}
+func (m MockOddService) Fixture(ctx context.Context, req *odds.LatestOddInput) ([]odds.MarketOdds, resterror.RestErrorI) {
+	return m.FuncFixture(req)
+}
+func (m MockOddService) Sync(ctx context.Context, req *odds.SyncOddInput) resterror.RestErrorI {
+	return m.FuncSync(req)
+}
+
+func TestOddController_List(t *testing.T) {
+	testCases := []struct {
+		title          string
+		query          string
+		serviceMock    services.OddServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error filter invalid market",
+			query:          "?market=corners",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"market":["The field: 'market' must be one of [1x2 over_under btts asian_handicap]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error OddService.List",
+			query: "?fixture_id=10",
+			serviceMock: &MockOddService{
+				FuncList: func(req *odds.ListOddInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			query: "?fixture_id=10&market=btts",
+			serviceMock: &MockOddService{
+				FuncList: func(req *odds.ListOddInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return &pagination.PaginatedResponse{
+						Data:        []odds.Odd{{ID: 1, FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketBTTS, Selection: odds.SelectionYes, Price: 1.7, RecordedAt: time.Date(2022, 1, 14, 10, 0, 0, 0, time.UTC)}},
+						CurrentPage: 1,
+						LastPage:    1,
+						PerPage:     20,
+						From:        1,
+						To:          1,
+						Total:       1,
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"from":1,"data":[{"id":1,"fixture_id":10,"bookmaker_id":8,"bookmaker":"Bet365","market":"btts","selection":"yes","line":0,"price":1.7,"recorded_at":"2022-01-14T10:00:00Z"}],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/odds"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.OddService = testCase.serviceMock
+			OddController.List(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestOddController_Fixture(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		query          string
+		serviceMock    services.OddServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid fixture id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_FIXTURE_ID","error_code":"INVALID_FIXTURE_ID","code":400}`,
+		},
+		{
+			title:          "error validation invalid method",
+			id:             "10",
+			query:          "?method=other",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"method":["The field: 'method' must be one of [basic shin power]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error OddService.Fixture",
+			id:    "10",
+			serviceMock: &MockOddService{
+				FuncFixture: func(req *odds.LatestOddInput) ([]odds.MarketOdds, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			id:    "10",
+			query: "?market=btts&method=shin",
+			serviceMock: &MockOddService{
+				FuncFixture: func(req *odds.LatestOddInput) ([]odds.MarketOdds, resterror.RestErrorI) {
+					if req.FixtureID != 10 || req.Market != odds.MarketBTTS || req.Method != "shin" {
+						return nil, resterror.NewStandardInternalServerError()
+					}
+					return []odds.MarketOdds{{
+						BookmakerID: 8,
+						Bookmaker:   "Bet365",
+						Market:      odds.MarketBTTS,
+						RecordedAt:  time.Date(2022, 1, 14, 10, 0, 0, 0, time.UTC),
+						Overround:   0.05,
+						Selections: []odds.SelectionOdds{
+							{Selection: odds.SelectionYes, Price: 1.9, Probability: 0.5},
+							{Selection: odds.SelectionNo, Price: 1.9, Probability: 0.5},
+						},
+					}}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":[{"bookmaker_id":8,"bookmaker":"Bet365","market":"btts","line":0,"recorded_at":"2022-01-14T10:00:00Z","overround":0.05,` +
+				`"selections":[{"selection":"yes","price":1.9,"probability":0.5},{"selection":"no","price":1.9,"probability":0.5}]}],"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/fixtures/"+testCase.id+"/odds"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.OddService = testCase.serviceMock
+			OddController.Fixture(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestOddController_Sync(t *testing.T) {
+	testCases := []struct {
+		title          string
+		reqBody        io.Reader
+		serviceMock    services.OddServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error required fields",
+			reqBody:        strings.NewReader(`{}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"fixture_id":["The fixture id field is required if league id is not present"],"league_id":["The league id field is required if fixture id is not present"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:          "error league without season",
+			reqBody:        strings.NewReader(`{"league_id":39}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"season":["The season field is required with league id"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error OddService.Sync",
+			reqBody: strings.NewReader(`{"fixture_id":10}`),
+			serviceMock: &MockOddService{
+				FuncSync: func(req *odds.SyncOddInput) resterror.RestErrorI {
+					return resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title:   "success",
+			reqBody: strings.NewReader(`{"league_id":39,"season":2021,"bookmaker_id":8}`),
+			serviceMock: &MockOddService{
+				FuncSync: func(req *odds.SyncOddInput) resterror.RestErrorI {
+					return nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/odds/sync", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.OddService = testCase.serviceMock
+			OddController.Sync(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
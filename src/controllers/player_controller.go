@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/players"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type playerControllerInterface interface {
+	Find(ctx *gin.Context)
+	List(ctx *gin.Context)
+	TopScorers(ctx *gin.Context)
+	TopAssists(ctx *gin.Context)
+	Squad(ctx *gin.Context)
+	Sync(ctx *gin.Context)
+}
+
+type playerController struct{}
+
+var PlayerController playerControllerInterface = &playerController{}
+
+// Find
+// @Summary Find player
+// @Description Retrieve a player identified by id with the team of its current squad and its statistics of every synced season, the latest first
+// @ID v1-players-find
+// @Produce json
+// @Tags Players
+// @Security ApiKeyAuth
+// @Param id path int true "Player ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=players.PlayerOutput}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /players/{id} [get]
+func (c *playerController) Find(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_PLAYER_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.PlayerService.Find(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// List
+// @Summary List players
+// @Description Retrieve players filtered by name, team or position, the team, number and position are the ones of the current squad of the player
+// @ID v1-players-list
+// @Produce json
+// @Tags Players
+// @Security ApiKeyAuth
+// @Param name query string false "filter by part of the name, also name[eq]"
+// @Param lastname query string false "filter by part of the last name"
+// @Param nationality query string false "filter by nationality, also nationality[in] and nationality[like]"
+// @Param age query integer false "filter by age, also age[gt], age[gte], age[lt] and age[lte]"
+// @Param team_id query integer false "filter by the team of the squad, also team_id[in] and team_id[null]"
+// @Param team_name query string false "filter by part of the team name, also team_name[eq]"
+// @Param position query string false "filter by position, also position[in]" Enums(Goalkeeper,Defender,Midfielder,Attacker)
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order e.g. lastname,-age"
+// @Param fields query string false "comma separated fields to return e.g. id,name,team_name"
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous response, replaces page"
+// @Param total query bool false "count the records, false skips the count and returns a total and last_page of -1" Enums(true,false)
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]players.Player}}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /players [get]
+func (c *playerController) List(ctx *gin.Context) {
+	var req players.ListPlayerInput
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldFilter(&req.Filter, players.Player{}),
+	); !ok {
+		return
+	}
+
+	results, apiErr := services.PlayerService.List(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
+
+// TopScorers
+// @Summary Top scorers
+// @Description Retrieve the players of a league season by goals scored, ties are ranked by assists then by the fewest minutes played. A player who moved during the season has a row per team
+// @ID v1-players-top-scorers
+// @Produce json
+// @Tags Players
+// @Security ApiKeyAuth
+// @Param league_id query integer true "League ID"
+// @Param season query integer true "Season year"
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]players.Leader}}
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /players/top-scorers [get]
+func (c *playerController) TopScorers(ctx *gin.Context) {
+	c.leaders(ctx, players.LeaderGoals)
+}
+
+// TopAssists
+// @Summary Top assists
+// @Description Retrieve the players of a league season by assists, ties are ranked by goals then by the fewest minutes played. A player who moved during the season has a row per team
+// @ID v1-players-top-assists
+// @Produce json
+// @Tags Players
+// @Security ApiKeyAuth
+// @Param league_id query integer true "League ID"
+// @Param season query integer true "Season year"
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]players.Leader}}
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /players/top-assists [get]
+func (c *playerController) TopAssists(ctx *gin.Context) {
+	c.leaders(ctx, players.LeaderAssists)
+}
+
+func (c *playerController) leaders(ctx *gin.Context, stat string) {
+	var req players.ListLeaderInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+	req.Stat = stat
+
+	results, apiErr := services.PlayerService.Leaders(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
+
+// Squad
+// @Summary Team squad
+// @Description Retrieve the current squad of a team, goalkeepers first then defenders, midfielders and attackers
+// @ID v1-teams-squad
+// @Produce json
+// @Tags Players
+// @Security ApiKeyAuth
+// @Param id path int true "Team ID"
+// @Success 200 {object} swaggertypes.NoErrorI{data=[]squads.SquadPlayer}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.StandardNotFoundError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /teams/{id}/squad [get]
+func (c *playerController) Squad(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_TEAM_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	result, apiErr := services.PlayerService.Squad(ctx.Request.Context(), id)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// Sync
+// @Summary Sync players
+// @Description Import from API Sports the current squad of a team, or the players of a league season with their statistics. Squads set the team and position the players are searched by, the statistics feed the leaderboards
+// @ID v1-players-sync
+// @Produce json
+// @Accept json
+// @Tags Players
+// @Security ApiKeyAuth
+// @Param JSON request body players.SyncPlayerInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /players/sync [post]
+func (c *playerController) Sync(ctx *gin.Context) {
+	var req players.SyncPlayerInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	if err := services.PlayerService.Sync(ctx.Request.Context(), &req); err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
@@ -0,0 +1,370 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/players"
+	"github.com/development-raul/footy-predictor/src/domains/squads"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type MockPlayerService struct {
+	FuncFind    func(id int64) (*players.PlayerOutput, resterror.RestErrorI)
+	FuncList    func(req *players.ListPlayerInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	FuncLeaders func(req *players.ListLeaderInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	FuncSquad   func(teamID int64) ([]squads.SquadPlayer, resterror.RestErrorI)
+	FuncSync    func(req *players.SyncPlayerInput) resterror.RestErrorI
+}
+
+func (m MockPlayerService) Find(ctx context.Context, id int64) (*players.PlayerOutput, resterror.RestErrorI) {
+	return m.FuncFind(id)
+}
+func (m MockPlayerService) List(ctx context.Context, req *players.ListPlayerInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncList(req)
+}
+func (m MockPlayerService) Leaders(ctx context.Context, req *players.ListLeaderInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncLeaders(req)
+}
+func (m MockPlayerService) Squad(ctx context.Context, teamID int64) ([]squads.SquadPlayer, resterror.RestErrorI) {
+	return m.FuncSquad(teamID)
+}
+func (m MockPlayerService) Sync(ctx context.Context, req *players.SyncPlayerInput) resterror.RestErrorI {
+	return m.FuncSync(req)
+}
+
+func testPlayer() players.Player {
+	teamID, teamName, position := int64(85), "Paris Saint Germain", "Attacker"
+	return players.Player{ID: 154, Name: "L. Messi", Nationality: "Argentina", Photo: "https://media.api-sports.io/football/players/154.png",
+		TeamID: &teamID, TeamName: &teamName, Position: &position}
+}
+
+const testPlayerJSON = `{"id":154,"name":"L. Messi","firstname":"","lastname":"","age":null,"birth_date":null,"nationality":"Argentina",` +
+	`"height":null,"weight":null,"photo":"https://media.api-sports.io/football/players/154.png","team_id":85,` +
+	`"team_name":"Paris Saint Germain","number":null,"position":"Attacker"}`
+
+func TestPlayerController_Find(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.PlayerServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid player id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_PLAYER_ID","error_code":"INVALID_PLAYER_ID","code":400}`,
+		},
+		{
+			title: "error PlayerService.Find",
+			id:    "154",
+			serviceMock: &MockPlayerService{
+				FuncFind: func(id int64) (*players.PlayerOutput, resterror.RestErrorI) {
+					return nil, resterror.NewDatabaseError(sql.ErrNoRows, "player")
+				},
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedRes:    `{"error":"The player does not exist","error_code":"PLAYER_NOT_FOUND","code":404}`,
+		},
+		{
+			title: "success",
+			id:    "154",
+			serviceMock: &MockPlayerService{
+				FuncFind: func(id int64) (*players.PlayerOutput, resterror.RestErrorI) {
+					return &players.PlayerOutput{Player: testPlayer(), Statistics: []players.Statistic{{PlayerID: id, TeamID: 85,
+						TeamName: "Paris Saint Germain", LeagueID: 61, Season: 2021, Position: "Attacker", Appearances: 26,
+						Lineups: 24, Minutes: 2153, Goals: 6, Assists: 14, YellowCards: 2}}}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":` + strings.TrimSuffix(testPlayerJSON, "}") + `,"statistics":[{"player_id":154,"team_id":85,` +
+				`"team_name":"Paris Saint Germain","league_id":61,"season":2021,"position":"Attacker","appearances":26,"lineups":24,` +
+				`"minutes":2153,"goals":6,"assists":14,"yellow_cards":2,"red_cards":0,"rating":null}]},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/players/"+testCase.id, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.PlayerService = testCase.serviceMock
+			PlayerController.Find(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestPlayerController_List(t *testing.T) {
+	testCases := []struct {
+		title          string
+		query          string
+		serviceMock    services.PlayerServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error filter invalid position",
+			query:          "?position=Striker",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"position":["The field: 'position' must be one of [Goalkeeper Defender Midfielder Attacker]"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error PlayerService.List",
+			query: "?name=Messi",
+			serviceMock: &MockPlayerService{
+				FuncList: func(req *players.ListPlayerInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			query: "?name=Messi&team_id=85&position=Attacker",
+			serviceMock: &MockPlayerService{
+				FuncList: func(req *players.ListPlayerInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return &pagination.PaginatedResponse{
+						Data:        []players.Player{testPlayer()},
+						CurrentPage: 1,
+						LastPage:    1,
+						PerPage:     20,
+						From:        1,
+						To:          1,
+						Total:       1,
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"from":1,"data":[` + testPlayerJSON + `],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/players"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.PlayerService = testCase.serviceMock
+			PlayerController.List(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestPlayerController_Leaders(t *testing.T) {
+	serviceMock := &MockPlayerService{
+		FuncLeaders: func(req *players.ListLeaderInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+			if req.LeagueID != 61 || req.Season != 2021 {
+				return nil, resterror.NewStandardInternalServerError()
+			}
+			return &pagination.PaginatedResponse{
+				Data:        []players.Leader{{Position: 1, PlayerID: 154, Name: req.Stat, TeamID: 85, Goals: 6, Assists: 14}},
+				CurrentPage: 1,
+				LastPage:    1,
+				PerPage:     20,
+				From:        1,
+				To:          1,
+				Total:       1,
+			}, nil
+		},
+	}
+	leaderJSON := func(stat string) string {
+		return `{"data":{"from":1,"data":[{"position":1,"player_id":154,"name":"` + stat + `","photo":"","team_id":85,"team_name":"",` +
+			`"appearances":0,"minutes":0,"goals":6,"assists":14,"rating":null}],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`
+	}
+
+	testCases := []struct {
+		title          string
+		query          string
+		handler        func(ctx *gin.Context)
+		serviceMock    services.PlayerServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error validation",
+			query:          "?league_id=61",
+			handler:        PlayerController.TopScorers,
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"season":["The season field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:          "error PlayerService.Leaders",
+			query:          "?league_id=39&season=2021",
+			handler:        PlayerController.TopScorers,
+			serviceMock:    serviceMock,
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title:          "success top scorers",
+			query:          "?league_id=61&season=2021",
+			handler:        PlayerController.TopScorers,
+			serviceMock:    serviceMock,
+			expectedStatus: http.StatusOK,
+			expectedRes:    leaderJSON(players.LeaderGoals),
+		},
+		{
+			title:          "success top assists",
+			query:          "?league_id=61&season=2021",
+			handler:        PlayerController.TopAssists,
+			serviceMock:    serviceMock,
+			expectedStatus: http.StatusOK,
+			expectedRes:    leaderJSON(players.LeaderAssists),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/players/top-scorers"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.PlayerService = testCase.serviceMock
+			testCase.handler(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestPlayerController_Squad(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.PlayerServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid team id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_TEAM_ID","error_code":"INVALID_TEAM_ID","code":400}`,
+		},
+		{
+			title: "error PlayerService.Squad",
+			id:    "85",
+			serviceMock: &MockPlayerService{
+				FuncSquad: func(teamID int64) ([]squads.SquadPlayer, resterror.RestErrorI) {
+					return nil, resterror.NewNotFoundError("SQUAD_NOT_FOUND")
+				},
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedRes:    `{"error":"SQUAD_NOT_FOUND","error_code":"SQUAD_NOT_FOUND","code":404}`,
+		},
+		{
+			title: "success",
+			id:    "85",
+			serviceMock: &MockPlayerService{
+				FuncSquad: func(teamID int64) ([]squads.SquadPlayer, resterror.RestErrorI) {
+					return []squads.SquadPlayer{{PlayerID: 154, Name: "L. Messi", Position: "Attacker"}}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":[{"player_id":154,"name":"L. Messi","age":null,"number":null,"position":"Attacker","photo":""}],"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/teams/"+testCase.id+"/squad", nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.PlayerService = testCase.serviceMock
+			PlayerController.Squad(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestPlayerController_Sync(t *testing.T) {
+	testCases := []struct {
+		title          string
+		reqBody        io.Reader
+		serviceMock    services.PlayerServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error required fields",
+			reqBody:        strings.NewReader(`{}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"league_id":["The league id field is required if team id is not present"],"team_id":["The team id field is required if league id is not present"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:          "error league without season",
+			reqBody:        strings.NewReader(`{"league_id":61}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"season":["The season field is required with league id"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error PlayerService.Sync",
+			reqBody: strings.NewReader(`{"team_id":85}`),
+			serviceMock: &MockPlayerService{
+				FuncSync: func(req *players.SyncPlayerInput) resterror.RestErrorI {
+					return resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title:   "success",
+			reqBody: strings.NewReader(`{"league_id":61,"season":2021}`),
+			serviceMock: &MockPlayerService{
+				FuncSync: func(req *players.SyncPlayerInput) resterror.RestErrorI {
+					return nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/players/sync", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.PlayerService = testCase.serviceMock
+			PlayerController.Sync(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/predictions"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type predictionControllerInterface interface {
+	Predict(ctx *gin.Context)
+}
+
+type predictionController struct{}
+
+var PredictionController predictionControllerInterface = &predictionController{}
+
+// Predict
+// @Summary Predict fixture
+// @Description Forecast the result of a fixture from the finished fixtures of its league played before kickoff. The model is the champion unless a registered version is requested, the prediction of a fixture by a version is saved and does not change once every earlier result is known
+// @ID v1-fixtures-prediction
+// @Produce json
+// @Tags Predictions
+// @Security ApiKeyAuth
+// @Param id path int true "Fixture ID"
+// @Param model query string false "model version e.g. dixon-coles@3, or a model name for its latest version, the champion by default"
+// @Success 200 {object} swaggertypes.NoErrorI{data=predictions.Prediction}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 422 {object} swaggertypes.StandardBadRequestError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /fixtures/{id}/prediction [get]
+func (c *predictionController) Predict(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_FIXTURE_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	var req predictions.PredictionInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	result, apiErr := services.PredictionService.Predict(ctx.Request.Context(), id, req.Model)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/predictions"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockPredictionService struct {
+	FuncPredict func(fixtureID int64, model string) (*predictions.Prediction, resterror.RestErrorI)
+}
+
+func (m MockPredictionService) Predict(ctx context.Context, fixtureID int64, model string) (*predictions.Prediction, resterror.RestErrorI) {
+	return m.FuncPredict(fixtureID, model)
+}
+
+func TestPredictionController_Predict(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		query          string
+		serviceMock    services.PredictionServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid fixture id",
+			id:             "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_FIXTURE_ID","error_code":"INVALID_FIXTURE_ID","code":400}`,
+		},
+		{
+			title: "error PredictionService.Predict",
+			id:    "10",
+			serviceMock: &MockPredictionService{
+				FuncPredict: func(fixtureID int64, model string) (*predictions.Prediction, resterror.RestErrorI) {
+					return nil, resterror.NewUnprocessableEntityError("NOT_ENOUGH_DATA")
+				},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedRes:    `{"error":"NOT_ENOUGH_DATA","error_code":"NOT_ENOUGH_DATA","code":422}`,
+		},
+		{
+			title: "success",
+			id:    "10",
+			query: "?model=dixon-coles@3",
+			serviceMock: &MockPredictionService{
+				FuncPredict: func(fixtureID int64, model string) (*predictions.Prediction, resterror.RestErrorI) {
+					return &predictions.Prediction{
+						FixtureID:         fixtureID,
+						Model:             model,
+						HomeTeamID:        1,
+						AwayTeamID:        2,
+						HomeExpectedGoals: 1.5,
+						AwayExpectedGoals: 1,
+						HomeWin:           0.5,
+						Draw:              0.25,
+						AwayWin:           0.25,
+						MostLikelyScore:   predictions.Score{HomeGoals: 1, AwayGoals: 1, Probability: 0.125},
+						Markets: predictions.Markets{
+							OverUnder:      []predictions.GoalLine{{Line: 2.5, Over: 0.5, Under: 0.5}},
+							BothTeamsScore: predictions.BothTeamsScore{Yes: 0.5, No: 0.5},
+							AsianHandicap:  []predictions.Handicap{{Line: -0.5, Home: 0.5, Away: 0.5}},
+						},
+						MatchesUsed: 20,
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":{"fixture_id":10,"model":"dixon-coles@3","home_team_id":1,"away_team_id":2,"home_expected_goals":1.5,"away_expected_goals":1,` +
+				`"home_win":0.5,"draw":0.25,"away_win":0.25,"most_likely_score":{"home_goals":1,"away_goals":1,"probability":0.125},` +
+				`"markets":{"over_under":[{"line":2.5,"over":0.5,"under":0.5}],"both_teams_score":{"yes":0.5,"no":0.5},` +
+				`"asian_handicap":[{"line":-0.5,"home":0.5,"away":0.5}]},"matches_used":20},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/fixtures/"+testCase.id+"/prediction"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.PredictionService = testCase.serviceMock
+			PredictionController.Predict(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
@@ -5,6 +5,11 @@ import (
 	"github.com/development-raul/footy-predictor/src/services"
 	"github.com/development-raul/footy-predictor/src/swaggertypes"
 	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/bulk"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/etag"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
 	"github.com/development-raul/footy-predictor/src/utils/resterror"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -13,9 +18,15 @@ import (
 
 type seasonControllerInterface interface {
 	Create(ctx *gin.Context)
+	Patch(ctx *gin.Context)
 	Find(ctx *gin.Context)
 	List(ctx *gin.Context)
+	Export(ctx *gin.Context)
+	Import(ctx *gin.Context)
+	Current(ctx *gin.Context)
 	Delete(ctx *gin.Context)
+	BulkCreate(ctx *gin.Context)
+	BulkDelete(ctx *gin.Context)
 	Sync(ctx *gin.Context)
 }
 
@@ -25,15 +36,19 @@ var SeasonController seasonControllerInterface = &seasonController{}
 
 // Create
 // @Summary Create season
-// @Description Endpoint used to create a new season record
+// @Description Endpoint used to create a new season record, the label defaults to the two years format e.g. 2021/22
 // @ID v1-seasons-create
 // @Produce json
 // @Accept json
 // @Tags Seasons
+// @Security ApiKeyAuth
 // @Param JSON request body seasons.Season true "Request Sample"
 // @Success 201 {object} swaggertypes.NoErrorString
 // @Failure 400 {object} swaggertypes.StandardBadRequestError
 // @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 409 {object} swaggertypes.DatabaseError
+// @Failure 422 {object} swaggertypes.DatabaseError
 // @Failure 500 {object} swaggertypes.StandardInternalServerError
 // @Router /seasons [post]
 func (c *seasonController) Create(ctx *gin.Context) {
@@ -42,7 +57,7 @@ func (c *seasonController) Create(ctx *gin.Context) {
 		return
 	}
 
-	if err := services.SeasonService.Create(req.ID); err != nil {
+	if err := services.SeasonService.Create(ctx.Request.Context(), &req); err != nil {
 		ctx.JSON(err.Code(), err)
 		return
 	}
@@ -55,14 +70,20 @@ func (c *seasonController) Create(ctx *gin.Context) {
 
 // Find
 // @Summary Find season
-// @Description Retrieve a season identified by id
+// @Description Retrieve a season identified by id, the ETag header holds its version. When the If-None-Match header has the current ETag nothing is returned but a 304
 // @ID v1-seasons-find
 // @Produce json
 // @Tags Seasons
+// @Security ApiKeyAuth
 // @Param id path int true "Season ID"
+// @Param If-None-Match header string false "ETag of the season already held by the client"
 // @Success 200 {object} swaggertypes.NoErrorI{data=seasons.Season}
+// @Header 200 {string} ETag "ETag of the season"
+// @Success 304 "Not Modified"
 // @Failure 400 {object} swaggertypes.StandardBadRequestError
 // @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
 // @Failure 500 {object} swaggertypes.StandardInternalServerError
 // @Router /seasons/{id} [get]
 func (c *seasonController) Find(ctx *gin.Context) {
@@ -72,11 +93,14 @@ func (c *seasonController) Find(ctx *gin.Context) {
 		ctx.JSON(apiErr.Code(), apiErr)
 		return
 	}
-	result, apiErr := services.SeasonService.Find(id)
+	result, apiErr := services.SeasonService.Find(ctx.Request.Context(), id)
 	if apiErr != nil {
 		ctx.JSON(apiErr.Code(), apiErr)
 		return
 	}
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBeModified(result.Version)); !ok {
+		return
+	}
 
 	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
 		Data: result,
@@ -90,11 +114,21 @@ func (c *seasonController) Find(ctx *gin.Context) {
 // @ID v1-seasons-list
 // @Produce json
 // @Tags Seasons
-// @Param id query string false "filter by id"
+// @Security ApiKeyAuth
+// @Param id query integer false "filter by id, also id[ne], id[in], id[gt], id[gte], id[lt] and id[lte]"
+// @Param label query string false "filter by label, also label[in] and label[like]"
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order e.g. -id"
+// @Param fields query string false "comma separated fields to return e.g. id,label"
 // @Param order query string false "order direction" Enums(asc,desc)
-// @Success 200 {object} swaggertypes.NoErrorI{data=[]seasons.Season}
+// @Param order_by query string false "order field" Enums(id,label)
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous response, replaces page"
+// @Param total query bool false "count the records, false skips the count and returns a total and last_page of -1" Enums(true,false)
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]seasons.Season}}
 // @Failure 400 {object} swaggertypes.StandardBadRequestError
 // @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
 // @Failure 500 {object} swaggertypes.StandardInternalServerError
 // @Router /seasons [get]
 func (c *seasonController) List(ctx *gin.Context) {
@@ -103,11 +137,12 @@ func (c *seasonController) List(ctx *gin.Context) {
 	if ok := utils.GinShouldPassAll(ctx,
 		utils.GinShouldBind(&req),
 		utils.GinShouldValidate(&req),
+		utils.GinShouldFilter(&req.Filter, seasons.Season{}),
 	); !ok {
 		return
 	}
 
-	results, apiErr := services.SeasonService.List(&req)
+	results, apiErr := services.SeasonService.List(ctx.Request.Context(), &req)
 	if apiErr != nil {
 		ctx.JSON(apiErr.Code(), apiErr)
 		return
@@ -119,17 +154,190 @@ func (c *seasonController) List(ctx *gin.Context) {
 	})
 }
 
+// Export
+// @Summary Export seasons
+// @Description Stream the seasons matching the filters of the list endpoint as a CSV, JSON or NDJSON attachment, the fields parameter selects the columns
+// @ID v1-seasons-export
+// @Produce json,text/csv,application/x-ndjson
+// @Tags Seasons
+// @Security ApiKeyAuth
+// @Param format query string false "export format, json by default" Enums(csv,json,ndjson)
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order"
+// @Param fields query string false "comma separated fields to export"
+// @Success 200 {array} seasons.Season
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /seasons/export [get]
+func (c *seasonController) Export(ctx *gin.Context) {
+	var req seasons.ListSeasonInput
+	var format export.Input
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldBind(&format),
+		utils.GinShouldValidate(&format),
+		utils.GinShouldFilter(&req.Filter, seasons.Season{}),
+	); !ok {
+		return
+	}
+
+	w := export.NewWriter(export.Response(ctx.Writer, format.Format, "seasons"), format.Format, req.Filter.Columns(seasons.Season{}))
+	if apiErr := services.SeasonService.Export(ctx.Request.Context(), &req, w); apiErr != nil && !ctx.Writer.Written() {
+		ctx.JSON(apiErr.Code(), apiErr)
+	}
+}
+
+// Import
+// @Summary Import seasons
+// @Description Upsert the rows of a CSV or JSON upload, sent as the file field of a multipart form or as the request body. The columns are the fields of Season: id (required) and label, which defaults to e.g. 2021/22. Valid rows are saved even when others fail, every failed row is reported
+// @ID v1-seasons-import
+// @Produce json
+// @Accept mpfd,text/csv,json
+// @Tags Seasons
+// @Security ApiKeyAuth
+// @Param file formData file false "CSV or JSON file"
+// @Param format query string false "upload format, by default from the file extension or the content type" Enums(csv,json)
+// @Success 200 {object} swaggertypes.NoErrorI{data=importer.Report}
+// @Success 207 {object} swaggertypes.NoErrorI{data=importer.Report}
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /seasons/import [post]
+func (c *seasonController) Import(ctx *gin.Context) {
+	file, format, apiErr := importer.Upload(ctx.Request)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	defer file.Close()
+
+	report, apiErr := services.SeasonService.Import(ctx.Request.Context(), file, format)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(report.StatusCode(), swaggertypes.NoErrorData{
+		Data: report,
+		Code: report.StatusCode(),
+	})
+}
+
+// Current
+// @Summary Current season
+// @Description Resolve the season of a league active on a date. Without a date the season marked as current by API Sports is returned, between two seasons the season that ended last is returned
+// @ID v1-seasons-current
+// @Produce json
+// @Tags Seasons
+// @Security ApiKeyAuth
+// @Param league_id query integer true "league id"
+// @Param date query string false "date formatted as YYYY-MM-DD, defaults to today"
+// @Success 200 {object} swaggertypes.NoErrorI{data=seasons.LeagueSeason}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /seasons/current [get]
+func (c *seasonController) Current(ctx *gin.Context) {
+	var req seasons.CurrentSeasonInput
+
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+	); !ok {
+		return
+	}
+
+	result, apiErr := services.SeasonService.Current(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// Patch
+// @Summary Patch season
+// @Description Endpoint used to change some fields of an existing season record with a JSON Merge Patch (RFC 7396), the members of the body replace the fields of the season and null resets a field. The If-Match header must hold the ETag returned when the season was read, or * to patch any version, the new ETag is returned
+// @ID v1-seasons-patch
+// @Produce json
+// @Accept application/merge-patch+json,json
+// @Tags Seasons
+// @Security ApiKeyAuth
+// @Param id path int true "Season ID"
+// @Param If-Match header string true "ETag of the season e.g. \"3\", or *"
+// @Param JSON request body seasons.UpdateSeasonInput true "Fields to change e.g. {\"label\": \"2021/22\"}"
+// @Success 200 {object} swaggertypes.NoErrorString
+// @Header 200 {string} ETag "ETag of the updated season"
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 409 {object} swaggertypes.DatabaseError
+// @Failure 412 {object} swaggertypes.DatabaseError
+// @Failure 422 {object} swaggertypes.DatabaseError
+// @Failure 428 {object} swaggertypes.StandardPreconditionRequiredError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /seasons/{id} [patch]
+func (c *seasonController) Patch(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_SEASON_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	var version int64
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldMatch(&version)); !ok {
+		return
+	}
+	patch, err := ctx.GetRawData()
+	if err != nil {
+		apiErr := resterror.NewBadRequestError(constants.ErrorInvalidRequestBody)
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	version, apiErr := services.SeasonService.Patch(ctx.Request.Context(), id, version, patch)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.Header("ETag", etag.Format(version))
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorString{
+		Message: "SUCCESS",
+		Code:    http.StatusOK,
+	})
+}
+
 // Delete
 // @Summary Delete season
-// @Description Endpoint used to delete an existing season record
+// @Description Endpoint used to delete an existing season record. The If-Match header must hold the ETag returned when the season was read, or * to delete any version
 // @ID v1-seasons-delete
 // @Produce json
 // @Accept json
 // @Tags Seasons
+// @Security ApiKeyAuth
 // @Param id path int true "Season ID"
+// @Param If-Match header string true "ETag of the season e.g. \"3\", or *"
 // @Success 200 {object} swaggertypes.NoErrorString
 // @Failure 400 {object} swaggertypes.StandardBadRequestError
 // @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 409 {object} swaggertypes.DatabaseError
+// @Failure 412 {object} swaggertypes.DatabaseError
+// @Failure 428 {object} swaggertypes.StandardPreconditionRequiredError
 // @Failure 500 {object} swaggertypes.StandardInternalServerError
 // @Router /seasons/{id} [delete]
 func (c *seasonController) Delete(ctx *gin.Context) {
@@ -140,7 +348,12 @@ func (c *seasonController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := services.SeasonService.Delete(id); err != nil {
+	var version int64
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldMatch(&version)); !ok {
+		return
+	}
+
+	if err := services.SeasonService.Delete(ctx.Request.Context(), id, version); err != nil {
 		ctx.JSON(err.Code(), err)
 		return
 	}
@@ -151,8 +364,78 @@ func (c *seasonController) Delete(ctx *gin.Context) {
 	})
 }
 
+// BulkCreate
+// @Summary Bulk create seasons
+// @Description Endpoint used to create several season records in a single transaction. Mode atomic (default) rolls back every item when one fails, best_effort applies the valid items and reports the others
+// @ID v1-seasons-bulk-create
+// @Produce json
+// @Accept json
+// @Tags Seasons
+// @Security ApiKeyAuth
+// @Param JSON request body seasons.BulkCreateSeasonInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorI{data=bulk.Response}
+// @Success 207 {object} swaggertypes.NoErrorI{data=bulk.Response}
+// @Failure 400 {object} swaggertypes.BulkError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 422 {object} swaggertypes.BulkError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /seasons/bulk [post]
+func (c *seasonController) BulkCreate(ctx *gin.Context) {
+	var req seasons.BulkCreateSeasonInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	res, err := services.SeasonService.BulkCreate(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(res.StatusCode(), swaggertypes.NoErrorData{
+		Data: res,
+		Code: res.StatusCode(),
+	})
+}
+
+// BulkDelete
+// @Summary Bulk delete seasons
+// @Description Endpoint used to delete several existing season records in a single transaction. Mode atomic (default) rolls back every item when one fails, best_effort applies the valid items and reports the others
+// @ID v1-seasons-bulk-delete
+// @Produce json
+// @Accept json
+// @Tags Seasons
+// @Security ApiKeyAuth
+// @Param JSON request body bulk.DeleteInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorI{data=bulk.Response}
+// @Success 207 {object} swaggertypes.NoErrorI{data=bulk.Response}
+// @Failure 400 {object} swaggertypes.BulkError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 422 {object} swaggertypes.BulkError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /seasons/bulk [delete]
+func (c *seasonController) BulkDelete(ctx *gin.Context) {
+	var req bulk.DeleteInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	res, err := services.SeasonService.BulkDelete(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(res.StatusCode(), swaggertypes.NoErrorData{
+		Data: res,
+		Code: res.StatusCode(),
+	})
+}
+
 func (c *seasonController) Sync(ctx *gin.Context) {
-	if err := services.SeasonService.Sync(); err != nil {
+	if err := services.SeasonService.Sync(ctx.Request.Context()); err != nil {
 		ctx.JSON(err.Code(), err)
 		return
 	}
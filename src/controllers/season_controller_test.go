@@ -1,9 +1,17 @@
 package controllers
 
 import (
+	"context"
 	"github.com/development-raul/footy-predictor/src/domains/seasons"
 	"github.com/development-raul/footy-predictor/src/services"
 	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/bulk"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/etag"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
+	"github.com/development-raul/footy-predictor/src/utils/mergepatch"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
 	"github.com/development-raul/footy-predictor/src/utils/resterror"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -12,29 +20,51 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 type MockSeasonService struct {
-	FuncCreate func(id int64) resterror.RestErrorI
-	FuncFind   func(id int64) (*seasons.Season, resterror.RestErrorI)
-	FuncList   func(req *seasons.ListSeasonInput) ([]seasons.Season, resterror.RestErrorI)
-	FuncDelete func(id int64) resterror.RestErrorI
-	FuncSync   func() resterror.RestErrorI
+	FuncCreate  func(season *seasons.Season) resterror.RestErrorI
+	FuncPatch   func(id int64, version int64, patch []byte) (int64, resterror.RestErrorI)
+	FuncFind    func(id int64) (*seasons.Season, resterror.RestErrorI)
+	FuncList    func(req *seasons.ListSeasonInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	FuncCurrent func(req *seasons.CurrentSeasonInput) (*seasons.LeagueSeason, resterror.RestErrorI)
+	FuncDelete  func(id int64, version int64) resterror.RestErrorI
+	FuncBulk    func(req interface{}) (*bulk.Response, resterror.RestErrorI)
+	FuncSync    func() resterror.RestErrorI
 }
 
-func (m MockSeasonService) Create(id int64) resterror.RestErrorI {
-	return m.FuncCreate(id)
+func (m MockSeasonService) Create(ctx context.Context, season *seasons.Season) resterror.RestErrorI {
+	return m.FuncCreate(season)
 }
-func (m MockSeasonService) Find(id int64) (*seasons.Season, resterror.RestErrorI) {
+func (m MockSeasonService) Patch(ctx context.Context, id int64, version int64, patch []byte) (int64, resterror.RestErrorI) {
+	return m.FuncPatch(id, version, patch)
+}
+func (m MockSeasonService) Find(ctx context.Context, id int64) (*seasons.Season, resterror.RestErrorI) {
 	return m.FuncFind(id)
 }
-func (m MockSeasonService) List(req *seasons.ListSeasonInput) ([]seasons.Season, resterror.RestErrorI) {
+func (m MockSeasonService) List(ctx context.Context, req *seasons.ListSeasonInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
 	return m.FuncList(req)
 }
-func (m MockSeasonService) Delete(id int64) resterror.RestErrorI {
-	return m.FuncDelete(id)
+func (m MockSeasonService) Export(ctx context.Context, req *seasons.ListSeasonInput, w export.Writer) resterror.RestErrorI {
+	return nil
+}
+func (m MockSeasonService) Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI) {
+	return &importer.Report{}, nil
+}
+func (m MockSeasonService) Current(ctx context.Context, req *seasons.CurrentSeasonInput) (*seasons.LeagueSeason, resterror.RestErrorI) {
+	return m.FuncCurrent(req)
+}
+func (m MockSeasonService) Delete(ctx context.Context, id int64, version int64) resterror.RestErrorI {
+	return m.FuncDelete(id, version)
+}
+func (m MockSeasonService) BulkCreate(ctx context.Context, req *seasons.BulkCreateSeasonInput) (*bulk.Response, resterror.RestErrorI) {
+	return m.FuncBulk(req)
 }
-func (m MockSeasonService) Sync() resterror.RestErrorI {
+func (m MockSeasonService) BulkDelete(ctx context.Context, req *bulk.DeleteInput) (*bulk.Response, resterror.RestErrorI) {
+	return m.FuncBulk(req)
+}
+func (m MockSeasonService) Sync(ctx context.Context) resterror.RestErrorI {
 	return m.FuncSync()
 }
 
@@ -51,31 +81,31 @@ func TestSeasonController_Create(t *testing.T) {
 			reqBody:        strings.NewReader(`{}`),
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":{"id":["The id field is required."]},"code":400}`,
+			expectedRes:    `{"error":{"id":["The id field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
 		},
 		{
 			title:          "error invalid id",
 			reqBody:        strings.NewReader(`{"id":"test"}`),
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":"Invalid request body.","code":400}`,
+			expectedRes:    `{"error":"Invalid request body.","error_code":"BAD_REQUEST","code":400}`,
 		},
 		{
 			title:   "error SeasonService.Create",
 			reqBody: strings.NewReader(`{"id":1}`),
 			serviceMock: &MockSeasonService{
-				FuncCreate: func(id int64) resterror.RestErrorI {
+				FuncCreate: func(season *seasons.Season) resterror.RestErrorI {
 					return resterror.NewStandardInternalServerError()
 				},
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedRes:    `{"error":"Something went wrong. Please try again later.","code":500}`,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
 		},
 		{
 			title:   "success",
 			reqBody: strings.NewReader(`{"id":1}`),
 			serviceMock: &MockSeasonService{
-				FuncCreate: func(id int64) resterror.RestErrorI {
+				FuncCreate: func(season *seasons.Season) resterror.RestErrorI {
 					return nil
 				},
 			},
@@ -104,16 +134,18 @@ func TestSeasonController_Find(t *testing.T) {
 	testCases := []struct {
 		title          string
 		id             string
+		ifNoneMatch    string
 		serviceMock    services.SeasonServiceI
 		expectedStatus int
 		expectedRes    string
+		expectedETag   string
 	}{
 		{
 			title:          "error invalid season id",
 			id:             "abc",
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":"INVALID_SEASON_ID","code":400}`,
+			expectedRes:    `{"error":"INVALID_SEASON_ID","error_code":"INVALID_SEASON_ID","code":400}`,
 		},
 		{
 			title: "error SeasonService.Find",
@@ -124,18 +156,32 @@ func TestSeasonController_Find(t *testing.T) {
 				},
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedRes:    `{"error":"Something went wrong. Please try again later.","code":500}`,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
 		},
 		{
 			title: "success",
 			id:    "1",
 			serviceMock: &MockSeasonService{
 				FuncFind: func(id int64) (*seasons.Season, resterror.RestErrorI) {
-					return &seasons.Season{ID: 1}, nil
+					return &seasons.Season{ID: 1, Label: "2021/22", Version: 2}, nil
 				},
 			},
 			expectedStatus: http.StatusOK,
-			expectedRes:    `{"data":{"id":1},"code":200}`,
+			expectedRes:    `{"data":{"id":1,"label":"2021/22","version":2,"updated_at":"0001-01-01T00:00:00Z"},"code":200}`,
+			expectedETag:   `"2"`,
+		},
+		{
+			title:       "success not modified",
+			id:          "1",
+			ifNoneMatch: `"2"`,
+			serviceMock: &MockSeasonService{
+				FuncFind: func(id int64) (*seasons.Season, resterror.RestErrorI) {
+					return &seasons.Season{ID: 1, Label: "2021/22", Version: 2}, nil
+				},
+			},
+			expectedStatus: http.StatusNotModified,
+			expectedRes:    ``,
+			expectedETag:   `"2"`,
 		},
 	}
 
@@ -143,6 +189,9 @@ func TestSeasonController_Find(t *testing.T) {
 		t.Run(testCase.title, func(t *testing.T) {
 			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/seasons"+testCase.id, nil)
 			req.Header.Set("Content-Type", "application/json")
+			if testCase.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", testCase.ifNoneMatch)
+			}
 			res := httptest.NewRecorder()
 			c := utils.GetMockedContext(req, res)
 			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
@@ -152,6 +201,7 @@ func TestSeasonController_Find(t *testing.T) {
 
 			assert.Equal(t, testCase.expectedStatus, res.Code)
 			assert.Equal(t, testCase.expectedRes, res.Body.String())
+			assert.Equal(t, testCase.expectedETag, res.Header().Get("ETag"))
 		})
 	}
 }
@@ -169,36 +219,44 @@ func TestSeasonController_List(t *testing.T) {
 			query:          "?order=test",
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":{"order":["The field: 'order' must be one of [desc asc]"]},"code":400}`,
+			expectedRes:    `{"error":{"order":["The field: 'order' must be one of [desc asc]"]},"error_code":"VALIDATION_FAILED","code":400}`,
 		},
 		{
 			title:          "error validation invalid id",
 			query:          "?id=test",
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":"Invalid request body.","code":400}`,
+			expectedRes:    `{"error":{"id":["The id must be a valid integer"]},"error_code":"VALIDATION_FAILED","code":400}`,
 		},
 		{
 			title: "error SeasonService.List",
 			query: "?order=asc&order_by=id",
 			serviceMock: &MockSeasonService{
-				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, resterror.RestErrorI) {
+				FuncList: func(req *seasons.ListSeasonInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
 					return nil, resterror.NewStandardInternalServerError()
 				},
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedRes:    `{"error":"Something went wrong. Please try again later.","code":500}`,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
 		},
 		{
 			title: "success",
 			query: "?order=asc&order_by=id",
 			serviceMock: &MockSeasonService{
-				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, resterror.RestErrorI) {
-					return []seasons.Season{{ID: 1}}, nil
+				FuncList: func(req *seasons.ListSeasonInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return &pagination.PaginatedResponse{
+						From:        1,
+						Data:        []seasons.Season{{ID: 1, Label: "2021/22"}},
+						CurrentPage: 1,
+						LastPage:    1,
+						PerPage:     constants.DefaultPerPage,
+						To:          1,
+						Total:       1,
+					}, nil
 				},
 			},
 			expectedStatus: http.StatusOK,
-			expectedRes:    `{"data":[{"id":1}],"code":200}`,
+			expectedRes:    `{"data":{"from":1,"data":[{"id":1,"label":"2021/22","version":0,"updated_at":"0001-01-01T00:00:00Z"}],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
 		},
 	}
 
@@ -218,10 +276,160 @@ func TestSeasonController_List(t *testing.T) {
 	}
 }
 
+func TestSeasonController_Current(t *testing.T) {
+	testCases := []struct {
+		title          string
+		query          string
+		serviceMock    services.SeasonServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error validation required league_id",
+			query:          "",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"league_id":["The league id field is required."]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:          "error validation invalid date",
+			query:          "?league_id=39&date=2021-13-01",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"date":["The date must have the format YYYY-MM-DD"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error SeasonService.Current",
+			query: "?league_id=39",
+			serviceMock: &MockSeasonService{
+				FuncCurrent: func(req *seasons.CurrentSeasonInput) (*seasons.LeagueSeason, resterror.RestErrorI) {
+					return nil, resterror.NewNotFoundError("SEASON_NOT_FOUND")
+				},
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedRes:    `{"error":"SEASON_NOT_FOUND","error_code":"SEASON_NOT_FOUND","code":404}`,
+		},
+		{
+			title: "success",
+			query: "?league_id=39&date=2021-10-01",
+			serviceMock: &MockSeasonService{
+				FuncCurrent: func(req *seasons.CurrentSeasonInput) (*seasons.LeagueSeason, resterror.RestErrorI) {
+					return &seasons.LeagueSeason{
+						LeagueID:  req.LeagueID,
+						Season:    2021,
+						Label:     "2021/22",
+						StartDate: time.Date(2021, 8, 13, 0, 0, 0, 0, time.UTC),
+						EndDate:   time.Date(2022, 5, 22, 0, 0, 0, 0, time.UTC),
+						Current:   true,
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"league_id":39,"season":2021,"label":"2021/22","start_date":"2021-08-13T00:00:00Z","end_date":"2022-05-22T00:00:00Z","current":true},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/seasons/current"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.SeasonService = testCase.serviceMock
+			SeasonController.Current(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestSeasonController_Patch(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		ifMatch        string
+		reqBody        string
+		serviceMock    services.SeasonServiceI
+		expectedStatus int
+		expectedRes    string
+		expectedETag   string
+	}{
+		{
+			title:          "error invalid season id",
+			id:             "abc",
+			ifMatch:        `"3"`,
+			reqBody:        `{"label":"2021/22"}`,
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_SEASON_ID","error_code":"INVALID_SEASON_ID","code":400}`,
+		},
+		{
+			title:          "error missing If-Match",
+			id:             "2021",
+			reqBody:        `{"label":"2021/22"}`,
+			serviceMock:    nil,
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedRes:    `{"error":"IF_MATCH_REQUIRED","error_code":"IF_MATCH_REQUIRED","code":428}`,
+		},
+		{
+			title:   "error SeasonService.Patch",
+			id:      "2021",
+			ifMatch: `"3"`,
+			reqBody: `{"label":"2021/2022/23"}`,
+			serviceMock: &MockSeasonService{
+				FuncPatch: func(id int64, version int64, patch []byte) (int64, resterror.RestErrorI) {
+					return 0, resterror.NewValidationError(map[string][]string{"label": {"the label must have a length less than 10"}})
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"label":["the label must have a length less than 10"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "success",
+			id:      "2021",
+			ifMatch: "*",
+			reqBody: `{"label":"2021/22"}`,
+			serviceMock: &MockSeasonService{
+				FuncPatch: func(id int64, version int64, patch []byte) (int64, resterror.RestErrorI) {
+					if id != 2021 || version != etag.Any || string(patch) != `{"label":"2021/22"}` {
+						return 0, resterror.NewStandardInternalServerError()
+					}
+					return 4, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"message":"SUCCESS","code":200}`,
+			expectedETag:   `"4"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("PATCH", "https://localhost:8000/v1/seasons/"+testCase.id, strings.NewReader(testCase.reqBody))
+			req.Header.Set("Content-Type", mergepatch.ContentType)
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+
+			services.SeasonService = testCase.serviceMock
+			SeasonController.Patch(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+			assert.Equal(t, testCase.expectedETag, res.Header().Get("ETag"))
+		})
+	}
+}
+
 func TestSeasonController_Delete(t *testing.T) {
 	testCases := []struct {
 		title          string
 		id             string
+		ifMatch        string
 		serviceMock    services.SeasonServiceI
 		expectedStatus int
 		expectedRes    string
@@ -231,24 +439,36 @@ func TestSeasonController_Delete(t *testing.T) {
 			id:             "abc",
 			serviceMock:    nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedRes:    `{"error":"INVALID_SEASON_ID","code":400}`,
+			expectedRes:    `{"error":"INVALID_SEASON_ID","error_code":"INVALID_SEASON_ID","code":400}`,
 		},
 		{
-			title: "error SeasonService.Delete",
-			id:    "1",
+			title:          "error missing If-Match",
+			id:             "1",
+			serviceMock:    nil,
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedRes:    `{"error":"IF_MATCH_REQUIRED","error_code":"IF_MATCH_REQUIRED","code":428}`,
+		},
+		{
+			title:   "error SeasonService.Delete",
+			id:      "1",
+			ifMatch: `"2"`,
 			serviceMock: &MockSeasonService{
-				FuncDelete: func(id int64) resterror.RestErrorI {
+				FuncDelete: func(id int64, version int64) resterror.RestErrorI {
 					return resterror.NewStandardInternalServerError()
 				},
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedRes:    `{"error":"Something went wrong. Please try again later.","code":500}`,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
 		},
 		{
-			title: "success",
-			id:    "1",
+			title:   "success",
+			id:      "1",
+			ifMatch: `"2"`,
 			serviceMock: &MockSeasonService{
-				FuncDelete: func(id int64) resterror.RestErrorI {
+				FuncDelete: func(id int64, version int64) resterror.RestErrorI {
+					if version != 2 {
+						return resterror.NewStandardInternalServerError()
+					}
 					return nil
 				},
 			},
@@ -261,6 +481,9 @@ func TestSeasonController_Delete(t *testing.T) {
 		t.Run(testCase.title, func(t *testing.T) {
 			req, _ := http.NewRequest("DELETE", "https://localhost:8000/v1/seasons"+testCase.id, nil)
 			req.Header.Set("Content-Type", "application/json")
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
 			res := httptest.NewRecorder()
 			c := utils.GetMockedContext(req, res)
 			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
@@ -289,7 +512,7 @@ func TestSeasonController_Sync(t *testing.T) {
 				},
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedRes:    `{"error":"Something went wrong. Please try again later.","code":500}`,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
 		},
 		{
 			title: "success",
@@ -318,3 +541,70 @@ func TestSeasonController_Sync(t *testing.T) {
 		})
 	}
 }
+
+func TestSeasonController_Bulk(t *testing.T) {
+	testCases := []struct {
+		title          string
+		method         string
+		reqBody        io.Reader
+		serviceMock    services.SeasonServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error validation too many items",
+			method:         http.MethodPost,
+			reqBody:        strings.NewReader(`{"items":[` + strings.Repeat(`{"id":2021},`, bulk.MaxItems) + `{"id":2022}]}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"items":["the items must have a length less than 500"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error SeasonService.BulkCreate",
+			method:  http.MethodPost,
+			reqBody: strings.NewReader(`{"items":[{"id":2021}]}`),
+			serviceMock: &MockSeasonService{
+				FuncBulk: func(req interface{}) (*bulk.Response, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title:   "success",
+			method:  http.MethodDelete,
+			reqBody: strings.NewReader(`{"items":[{"id":2021}]}`),
+			serviceMock: &MockSeasonService{
+				FuncBulk: func(req interface{}) (*bulk.Response, resterror.RestErrorI) {
+					return &bulk.Response{
+						Mode:    bulk.ModeAtomic,
+						Applied: 1,
+						Results: []bulk.Result{{Index: 0, Status: bulk.StatusDeleted, ID: 2021}},
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"mode":"atomic","applied":1,"failed":0,"results":[{"index":0,"status":"deleted","id":2021}]},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest(testCase.method, "https://localhost:8000/v1/seasons/bulk", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.SeasonService = testCase.serviceMock
+			if testCase.method == http.MethodPost {
+				SeasonController.BulkCreate(c)
+			} else {
+				SeasonController.BulkDelete(c)
+			}
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/simulations"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type simulationControllerInterface interface {
+	Simulate(ctx *gin.Context)
+}
+
+type simulationController struct{}
+
+var SimulationController simulationControllerInterface = &simulationController{}
+
+// Simulate
+// @Summary Simulate league season
+// @Description Simulate the fixtures left in a league season many times from the current table, drawing the scores from the model fitted on the results known now, and retrieve the probability of each team finishing in each position, winning the title, finishing in the europe places and being relegated, with its expected points. The teams are sorted by expected points and the same seed gives the same simulation
+// @ID v1-leagues-simulation
+// @Produce json
+// @Tags Predictions
+// @Security ApiKeyAuth
+// @Param id path int true "League ID"
+// @Param season path int true "Season e.g. 2021"
+// @Param model query string false "model version e.g. dixon-coles@3, or a model name for its latest version, the champion by default"
+// @Param runs query integer false "number of simulated seasons, between 100 and 100000, 10000 by default"
+// @Param seed query integer false "seed of the random numbers, a random one by default which the response returns"
+// @Param europe_places query integer false "number of places qualifying for Europe, 4 by default"
+// @Param relegation_places query integer false "number of places relegated, 3 by default"
+// @Success 200 {object} swaggertypes.NoErrorI{data=simulations.Simulation}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 422 {object} swaggertypes.StandardBadRequestError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /leagues/{id}/seasons/{season}/simulation [get]
+func (c *simulationController) Simulate(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_LEAGUE_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	season, err := strconv.ParseInt(ctx.Param("season"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_SEASON")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+	var req simulations.SimulationInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+	req.LeagueID, req.Season = id, season
+
+	result, apiErr := services.SimulationService.Simulate(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
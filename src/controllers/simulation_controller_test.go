@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/simulations"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockSimulationService struct {
+	FuncSimulate func(req *simulations.SimulationInput) (*simulations.Simulation, resterror.RestErrorI)
+}
+
+func (m MockSimulationService) Simulate(ctx context.Context, req *simulations.SimulationInput) (*simulations.Simulation, resterror.RestErrorI) {
+	return m.FuncSimulate(req)
+}
+
+func TestSimulationController_Simulate(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		season         string
+		query          string
+		serviceMock    services.SimulationServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid league id",
+			id:             "abc",
+			season:         "2021",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_LEAGUE_ID","error_code":"INVALID_LEAGUE_ID","code":400}`,
+		},
+		{
+			title:          "error invalid season",
+			id:             "39",
+			season:         "abc",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_SEASON","error_code":"INVALID_SEASON","code":400}`,
+		},
+		{
+			title:          "error too many runs",
+			id:             "39",
+			season:         "2021",
+			query:          "?runs=200000",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"runs":["The runs must be at most 100000"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:  "error SimulationService.Simulate",
+			id:     "45",
+			season: "2021",
+			serviceMock: &MockSimulationService{
+				FuncSimulate: func(req *simulations.SimulationInput) (*simulations.Simulation, resterror.RestErrorI) {
+					return nil, resterror.NewUnprocessableEntityError("NOT_A_LEAGUE")
+				},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedRes:    `{"error":"NOT_A_LEAGUE","error_code":"NOT_A_LEAGUE","code":422}`,
+		},
+		{
+			title:  "success",
+			id:     "39",
+			season: "2021",
+			query:  "?runs=1000&seed=7&relegation_places=1",
+			serviceMock: &MockSimulationService{
+				FuncSimulate: func(req *simulations.SimulationInput) (*simulations.Simulation, resterror.RestErrorI) {
+					if req.LeagueID != 39 || req.Season != 2021 || req.Runs != 1000 || *req.Seed != 7 || *req.RelegationPlaces != 1 || req.EuropePlaces != nil {
+						return nil, resterror.NewStandardInternalServerError()
+					}
+					return &simulations.Simulation{
+						LeagueID:         39,
+						Season:           2021,
+						Model:            "poisson@1",
+						Runs:             1000,
+						Seed:             7,
+						FixturesLeft:     1,
+						EuropePlaces:     4,
+						RelegationPlaces: 1,
+						Teams: []simulations.Team{
+							{TeamID: 1, TeamName: "Arsenal", Played: 1, Points: 3, GoalDifference: 1, ExpectedPoints: 4.5, Title: 0.75, Europe: 1, Relegation: 0.25, Positions: []float64{0.75, 0.25}},
+							{TeamID: 2, TeamName: "Chelsea", Played: 1, Points: 0, GoalDifference: -1, ExpectedPoints: 1.25, Title: 0.25, Europe: 1, Relegation: 0.75, Positions: []float64{0.25, 0.75}},
+						},
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":{"league_id":39,"season":2021,"model":"poisson@1","runs":1000,"seed":7,"fixtures_left":1,"europe_places":4,"relegation_places":1,"teams":[` +
+				`{"team_id":1,"team_name":"Arsenal","played":1,"points":3,"goal_difference":1,"expected_points":4.5,"title":0.75,"europe":1,"relegation":0.25,"positions":[0.75,0.25]},` +
+				`{"team_id":2,"team_name":"Chelsea","played":1,"points":0,"goal_difference":-1,"expected_points":1.25,"title":0.25,"europe":1,"relegation":0.75,"positions":[0.25,0.75]}]},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/leagues/"+testCase.id+"/seasons/"+testCase.season+"/simulation"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}, {Key: "season", Value: testCase.season}}
+
+			services.SimulationService = testCase.serviceMock
+			SimulationController.Simulate(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
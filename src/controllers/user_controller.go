@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/users"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type userControllerInterface interface {
+	Register(ctx *gin.Context)
+	Me(ctx *gin.Context)
+}
+
+type userController struct{}
+
+var UserController userControllerInterface = &userController{}
+
+// Register
+// @Summary Register user
+// @Description Endpoint used to create a new user account that can take part in prediction competitions
+// @ID v1-users-register
+// @Produce json
+// @Accept json
+// @Tags Users
+// @Param JSON request body users.RegisterUserInput true "Request Sample"
+// @Success 201 {object} swaggertypes.NoErrorI{data=users.UserOutput}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 409 {object} swaggertypes.StandardConflictError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /users/register [post]
+func (c *userController) Register(ctx *gin.Context) {
+	var req users.RegisterUserInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	result, err := services.UserService.Register(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusCreated,
+	})
+}
+
+// Me
+// @Summary Current user
+// @Description Retrieve the account of the authenticated user
+// @ID v1-users-me
+// @Produce json
+// @Tags Users
+// @Security ApiKeyAuth
+// @Success 200 {object} swaggertypes.NoErrorI{data=users.UserOutput}
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 404 {object} swaggertypes.DatabaseError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /users/me [get]
+func (c *userController) Me(ctx *gin.Context) {
+	var userID int64
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBeUser(&userID)); !ok {
+		return
+	}
+
+	result, err := services.UserService.Find(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(err.Code(), err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/users"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockUserService struct {
+	FuncRegister func(req *users.RegisterUserInput) (*users.UserOutput, resterror.RestErrorI)
+	FuncFind     func(id int64) (*users.UserOutput, resterror.RestErrorI)
+}
+
+func (m MockUserService) Register(ctx context.Context, req *users.RegisterUserInput) (*users.UserOutput, resterror.RestErrorI) {
+	return m.FuncRegister(req)
+}
+func (m MockUserService) Find(ctx context.Context, id int64) (*users.UserOutput, resterror.RestErrorI) {
+	return m.FuncFind(id)
+}
+
+var testUser = users.UserOutput{
+	ID:        1,
+	Email:     "john@test.com",
+	Name:      "John",
+	Role:      "reader",
+	CreatedAt: time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC),
+}
+
+func TestUserController_Register(t *testing.T) {
+	testCases := []struct {
+		title          string
+		reqBody        io.Reader
+		serviceMock    services.UserServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error validation",
+			reqBody:        strings.NewReader(`{"email":"john@test.com","name":"John","password":"short"}`),
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"password":["The password must have a length of at least 8"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error UserService.Register",
+			reqBody: strings.NewReader(`{"email":"john@test.com","name":"John","password":"secret-password"}`),
+			serviceMock: &MockUserService{
+				FuncRegister: func(req *users.RegisterUserInput) (*users.UserOutput, resterror.RestErrorI) {
+					return nil, resterror.NewConflictError("EMAIL_ALREADY_REGISTERED")
+				},
+			},
+			expectedStatus: http.StatusConflict,
+			expectedRes:    `{"error":"EMAIL_ALREADY_REGISTERED","error_code":"EMAIL_ALREADY_REGISTERED","code":409}`,
+		},
+		{
+			title:   "success",
+			reqBody: strings.NewReader(`{"email":"john@test.com","name":"John","password":"secret-password"}`),
+			serviceMock: &MockUserService{
+				FuncRegister: func(req *users.RegisterUserInput) (*users.UserOutput, resterror.RestErrorI) {
+					return &testUser, nil
+				},
+			},
+			expectedStatus: http.StatusCreated,
+			expectedRes:    `{"data":{"id":1,"email":"john@test.com","name":"John","role":"reader","created_at":"2022-01-01T10:00:00Z"},"code":201}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "https://localhost:8000/v1/users/register", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.UserService = testCase.serviceMock
+			UserController.Register(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestUserController_Me(t *testing.T) {
+	testCases := []struct {
+		title          string
+		subject        string
+		serviceMock    services.UserServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error api key caller",
+			subject:        "api_key:1",
+			serviceMock:    nil,
+			expectedStatus: http.StatusForbidden,
+			expectedRes:    `{"error":"USER_ACCOUNT_REQUIRED","error_code":"USER_ACCOUNT_REQUIRED","code":403}`,
+		},
+		{
+			title:   "error UserService.Find",
+			subject: "user:1",
+			serviceMock: &MockUserService{
+				FuncFind: func(id int64) (*users.UserOutput, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title:   "success",
+			subject: "user:1",
+			serviceMock: &MockUserService{
+				FuncFind: func(id int64) (*users.UserOutput, resterror.RestErrorI) {
+					return &testUser, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"id":1,"email":"john@test.com","name":"John","role":"reader","created_at":"2022-01-01T10:00:00Z"},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/users/me", nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Set(constants.ContextKeyAuthSubject, testCase.subject)
+
+			services.UserService = testCase.serviceMock
+			UserController.Me(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/user_predictions"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type userPredictionControllerInterface interface {
+	Submit(ctx *gin.Context)
+	List(ctx *gin.Context)
+}
+
+type userPredictionController struct{}
+
+var UserPredictionController userPredictionControllerInterface = &userPredictionController{}
+
+// Submit
+// @Summary Submit prediction
+// @Description Create or replace the score prediction of the authenticated user for a fixture. Predictions are locked at kickoff.
+// @ID v1-competitions-predictions-submit
+// @Produce json
+// @Accept json
+// @Tags Competitions
+// @Security ApiKeyAuth
+// @Param id path int true "Competition ID"
+// @Param JSON request body user_predictions.UserPredictionInput true "Request Sample"
+// @Success 200 {object} swaggertypes.NoErrorI{data=user_predictions.UserPrediction}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /competitions/{id}/predictions [put]
+func (c *userPredictionController) Submit(ctx *gin.Context) {
+	competitionID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_COMPETITION_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	var userID int64
+	var req user_predictions.UserPredictionInput
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBeUser(&userID),
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+	); !ok {
+		return
+	}
+
+	result, apiErr := services.UserPredictionService.Submit(ctx.Request.Context(), competitionID, userID, &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: result,
+		Code: http.StatusOK,
+	})
+}
+
+// List
+// @Summary List predictions
+// @Description Retrieve the predictions of the authenticated user in a competition
+// @ID v1-competitions-predictions-list
+// @Produce json
+// @Tags Competitions
+// @Security ApiKeyAuth
+// @Param id path int true "Competition ID"
+// @Param fixture_id query integer false "filter by fixture, also fixture_id[ne] and fixture_id[in]"
+// @Param points[null] query bool false "predictions that were not (true) or were (false) scored, also points, points[ne], points[gt], points[gte], points[lt] and points[lte]"
+// @Param sort query string false "comma separated sort fields, prefixed with - for descending order e.g. fixture_id,-created_at"
+// @Param fields query string false "comma separated fields to return e.g. fixture_id,home_goals,away_goals"
+// @Param order query string false "order direction" Enums(asc,desc)
+// @Param order_by query string false "order field" Enums(id,fixture_id,created_at)
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous response, replaces page"
+// @Param total query bool false "count the records, false skips the count and returns a total and last_page of -1" Enums(true,false)
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]user_predictions.UserPrediction}}
+// @Failure 400 {object} swaggertypes.StandardBadRequestError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /competitions/{id}/predictions [get]
+func (c *userPredictionController) List(ctx *gin.Context) {
+	competitionID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apiErr := resterror.NewBadRequestError("INVALID_COMPETITION_ID")
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	var req user_predictions.ListUserPredictionInput
+	if ok := utils.GinShouldPassAll(ctx,
+		utils.GinShouldBeUser(&req.UserID),
+		utils.GinShouldBind(&req),
+		utils.GinShouldValidate(&req),
+		utils.GinShouldFilter(&req.Filter, user_predictions.UserPrediction{}),
+	); !ok {
+		return
+	}
+	req.CompetitionID = competitionID
+
+	results, apiErr := services.UserPredictionService.List(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
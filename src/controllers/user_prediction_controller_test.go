@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/user_predictions"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockUserPredictionService struct {
+	FuncSubmit       func(competitionID int64, userID int64, req *user_predictions.UserPredictionInput) (*user_predictions.UserPrediction, resterror.RestErrorI)
+	FuncList         func(req *user_predictions.ListUserPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	FuncScoreFixture func(fixture *fixtures.Fixture) resterror.RestErrorI
+}
+
+func (m MockUserPredictionService) Submit(ctx context.Context, competitionID int64, userID int64, req *user_predictions.UserPredictionInput) (*user_predictions.UserPrediction, resterror.RestErrorI) {
+	return m.FuncSubmit(competitionID, userID, req)
+}
+func (m MockUserPredictionService) List(ctx context.Context, req *user_predictions.ListUserPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncList(req)
+}
+func (m MockUserPredictionService) ScoreFixture(ctx context.Context, fixture *fixtures.Fixture) resterror.RestErrorI {
+	return m.FuncScoreFixture(fixture)
+}
+
+var testUserPrediction = user_predictions.UserPrediction{
+	ID:            5,
+	CompetitionID: 4,
+	UserID:        1,
+	FixtureID:     10,
+	HomeGoals:     2,
+	AwayGoals:     1,
+	CreatedAt:     time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC),
+	UpdatedAt:     time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC),
+}
+
+const testUserPredictionJSON = `{"id":5,"competition_id":4,"user_id":1,"fixture_id":10,"home_goals":2,"away_goals":1,"points":null,"scored_at":null,"created_at":"2022-01-01T10:00:00Z","updated_at":"2022-01-01T10:00:00Z"}`
+
+func TestUserPredictionController_Submit(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		subject        string
+		reqBody        io.Reader
+		serviceMock    services.UserPredictionServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid competition id",
+			id:             "abc",
+			subject:        "user:1",
+			reqBody:        strings.NewReader(`{}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_COMPETITION_ID","error_code":"INVALID_COMPETITION_ID","code":400}`,
+		},
+		{
+			title:          "error api key caller",
+			id:             "4",
+			subject:        "api_key:1",
+			reqBody:        strings.NewReader(`{}`),
+			expectedStatus: http.StatusForbidden,
+			expectedRes:    `{"error":"USER_ACCOUNT_REQUIRED","error_code":"USER_ACCOUNT_REQUIRED","code":403}`,
+		},
+		{
+			title:          "error validation negative goals",
+			id:             "4",
+			subject:        "user:1",
+			reqBody:        strings.NewReader(`{"fixture_id":10,"home_goals":-1,"away_goals":0}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"home_goals":["The home goals must have a length of at least 0"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "error UserPredictionService.Submit",
+			id:      "4",
+			subject: "user:1",
+			reqBody: strings.NewReader(`{"fixture_id":10,"home_goals":2,"away_goals":0}`),
+			serviceMock: &MockUserPredictionService{
+				FuncSubmit: func(competitionID int64, userID int64, req *user_predictions.UserPredictionInput) (*user_predictions.UserPrediction, resterror.RestErrorI) {
+					return nil, resterror.NewValidationError(map[string][]string{
+						"kickoff_at": {"The kickoff at must be a date in the future"},
+					})
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"kickoff_at":["The kickoff at must be a date in the future"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:   "success",
+			id:      "4",
+			subject: "user:1",
+			reqBody: strings.NewReader(`{"fixture_id":10,"home_goals":2,"away_goals":1}`),
+			serviceMock: &MockUserPredictionService{
+				FuncSubmit: func(competitionID int64, userID int64, req *user_predictions.UserPredictionInput) (*user_predictions.UserPrediction, resterror.RestErrorI) {
+					return &testUserPrediction, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":` + testUserPredictionJSON + `,"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("PUT", "https://localhost:8000/v1/competitions/"+testCase.id+"/predictions", testCase.reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+			c.Set(constants.ContextKeyAuthSubject, testCase.subject)
+
+			services.UserPredictionService = testCase.serviceMock
+			UserPredictionController.Submit(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestUserPredictionController_List(t *testing.T) {
+	testCases := []struct {
+		title          string
+		id             string
+		serviceMock    services.UserPredictionServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error invalid competition id",
+			id:             "abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":"INVALID_COMPETITION_ID","error_code":"INVALID_COMPETITION_ID","code":400}`,
+		},
+		{
+			title: "error UserPredictionService.List",
+			id:    "4",
+			serviceMock: &MockUserPredictionService{
+				FuncList: func(req *user_predictions.ListUserPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			id:    "4",
+			serviceMock: &MockUserPredictionService{
+				FuncList: func(req *user_predictions.ListUserPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					if req.CompetitionID != 4 || req.UserID != 1 {
+						return nil, resterror.NewStandardInternalServerError()
+					}
+					res := pagination.GeneratePaginatedResponse([]user_predictions.UserPrediction{testUserPrediction}, req.Page, req.PerPage, 1)
+					return &res, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes:    `{"data":{"from":1,"data":[` + testUserPredictionJSON + `],"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/competitions/"+testCase.id+"/predictions", nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+			c.Params = []gin.Param{{Key: "id", Value: testCase.id}}
+			c.Set(constants.ContextKeyAuthSubject, "user:1")
+
+			services.UserPredictionService = testCase.serviceMock
+			UserPredictionController.List(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
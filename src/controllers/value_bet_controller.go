@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"github.com/development-raul/footy-predictor/src/domains/value_bets"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/swaggertypes"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type valueBetControllerInterface interface {
+	List(ctx *gin.Context)
+}
+
+type valueBetController struct{}
+
+var ValueBetController valueBetControllerInterface = &valueBetController{}
+
+// List
+// @Summary List value bets
+// @Description Compare the model probabilities of the fixtures that have not kicked off with the latest odds of every bookmaker once their margin is removed. The selections whose edge, the model minus the market probability, reaches min_edge and whose expected value is positive are returned with the highest edge first, with the fractional Kelly stake for the bankroll. Over/under and asian handicap bets are only rated on half goal lines
+// @ID v1-value-bets-list
+// @Produce json
+// @Tags Odds
+// @Security ApiKeyAuth
+// @Param league_id query integer false "filter by league"
+// @Param market query string false "filter by market" Enums(1x2,over_under,btts,asian_handicap)
+// @Param bookmaker_id query integer false "filter by bookmaker"
+// @Param min_edge query number false "minimum edge between 0 and 1, value_bets.min_edge by default"
+// @Param from query string false "kickoff from date YYYY-MM-DD, today by default"
+// @Param to query string false "kickoff until date YYYY-MM-DD, 7 days after from by default"
+// @Param method query string false "overround removal of the market probabilities, basic by default" Enums(basic,shin,power)
+// @Param bankroll query number false "bankroll the stakes are computed for, value_bets.bankroll by default"
+// @Param kelly_fraction query number false "fraction of the Kelly stake up to 1, value_bets.kelly_fraction by default"
+// @Param page query integer false "page number"
+// @Param per_page query integer false "records per page"
+// @Success 200 {object} swaggertypes.PaginatedData{data=pagination.PaginatedResponse{data=[]value_bets.ValueBet}}
+// @Failure 400 {object} swaggertypes.StandardValidationError
+// @Failure 401 {object} swaggertypes.StandardUnauthorisedError
+// @Failure 403 {object} swaggertypes.StandardForbiddenError
+// @Failure 500 {object} swaggertypes.StandardInternalServerError
+// @Router /value-bets [get]
+func (c *valueBetController) List(ctx *gin.Context) {
+	var req value_bets.ListValueBetInput
+	if ok := utils.GinShouldPassAll(ctx, utils.GinShouldBind(&req), utils.GinShouldValidate(&req)); !ok {
+		return
+	}
+
+	results, apiErr := services.ValueBetService.List(ctx.Request.Context(), &req)
+	if apiErr != nil {
+		ctx.JSON(apiErr.Code(), apiErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, swaggertypes.NoErrorData{
+		Data: results,
+		Code: http.StatusOK,
+	})
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/value_bets"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type MockValueBetService struct {
+	FuncList func(req *value_bets.ListValueBetInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+}
+
+func (m MockValueBetService) List(ctx context.Context, req *value_bets.ListValueBetInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	return m.FuncList(req)
+}
+
+func TestValueBetController_List(t *testing.T) {
+	testCases := []struct {
+		title          string
+		query          string
+		serviceMock    services.ValueBetServiceI
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error validation",
+			query:          "?min_edge=1.5&kelly_fraction=0&bankroll=-10&market=corners",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"bankroll":["The bankroll must be greater than 0"],"market":["The field: 'market' must be one of [1x2 over_under btts asian_handicap]"],"min_edge":["The min edge must be less than 1"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:          "error validation paging",
+			query:          "?page=-1&per_page=-5",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"page":["The page must be at least 1"],"per_page":["The per page must be at least 1"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title:          "error validation per page above the maximum",
+			query:          "?per_page=101",
+			serviceMock:    nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedRes:    `{"error":{"per_page":["The per page must be at most 100"]},"error_code":"VALIDATION_FAILED","code":400}`,
+		},
+		{
+			title: "error ValueBetService.List",
+			serviceMock: &MockValueBetService{
+				FuncList: func(req *value_bets.ListValueBetInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					return nil, resterror.NewStandardInternalServerError()
+				},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedRes:    `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+		{
+			title: "success",
+			query: "?league_id=39&market=1x2&min_edge=0&from=2022-01-15&to=2022-01-16",
+			serviceMock: &MockValueBetService{
+				FuncList: func(req *value_bets.ListValueBetInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+					if req.LeagueID != 39 || req.MinEdge == nil || *req.MinEdge != 0 || req.From != "2022-01-15" {
+						return nil, resterror.NewStandardInternalServerError()
+					}
+					res := pagination.GeneratePaginatedResponse([]value_bets.ValueBet{{
+						FixtureID:         10,
+						LeagueID:          39,
+						KickoffAt:         time.Date(2022, 1, 15, 15, 0, 0, 0, time.UTC),
+						HomeTeamName:      "Home",
+						AwayTeamName:      "Away",
+						BookmakerID:       8,
+						Bookmaker:         "Bet365",
+						Market:            "1x2",
+						Selection:         "home",
+						Price:             2.5,
+						RecordedAt:        time.Date(2022, 1, 14, 10, 0, 0, 0, time.UTC),
+						Model:             "poisson",
+						ModelProbability:  0.5,
+						MarketProbability: 0.38,
+						Edge:              0.12,
+						ExpectedValue:     0.25,
+						Kelly:             0.1667,
+						Stake:             41.67,
+					}}, 1, 20, 1)
+					return &res, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedRes: `{"data":{"from":1,"data":[{"fixture_id":10,"league_id":39,"kickoff_at":"2022-01-15T15:00:00Z","home_team_name":"Home","away_team_name":"Away",` +
+				`"bookmaker_id":8,"bookmaker":"Bet365","market":"1x2","selection":"home","line":0,"price":2.5,"recorded_at":"2022-01-14T10:00:00Z","model":"poisson",` +
+				`"model_probability":0.5,"market_probability":0.38,"edge":0.12,"expected_value":0.25,"kelly":0.1667,"stake":41.67}],` +
+				`"current_page":1,"last_page":1,"per_page":20,"to":1,"total":1},"code":200}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://localhost:8000/v1/value-bets"+testCase.query, nil)
+			res := httptest.NewRecorder()
+			c := utils.GetMockedContext(req, res)
+
+			services.ValueBetService = testCase.serviceMock
+			ValueBetController.List(c)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
@@ -68,28 +68,38 @@ var doc = `{
                 }
             }
         },
-        "/countries": {
+        "/api-keys": {
             "get": {
-                "description": "Retrieve all countries",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all API keys",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Countries"
+                    "API Keys"
                 ],
-                "summary": "List countries",
-                "operationId": "v1-countries-list",
+                "summary": "List API keys",
+                "operationId": "v1-api-keys-list",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "filter by code",
-                        "name": "code",
+                        "description": "filter by part of the name, also name[eq], name[ne] and name[in]",
+                        "name": "name",
                         "in": "query"
                     },
                     {
+                        "enum": [
+                            "reader",
+                            "editor",
+                            "admin"
+                        ],
                         "type": "string",
-                        "description": "filter by name",
-                        "name": "name",
+                        "description": "filter by role, also role[ne] and role[in]",
+                        "name": "role",
                         "in": "query"
                     },
                     {
@@ -102,6 +112,30 @@ var doc = `{
                         "name": "active",
                         "in": "query"
                     },
+                    {
+                        "type": "boolean",
+                        "description": "keys that were never (true) or were (false) used, also last_used_at[gt], last_used_at[gte], last_used_at[lt] and last_used_at[lte]",
+                        "name": "last_used_at[null]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or after, YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ, also created_at[gt], created_at[lt] and created_at[lte]",
+                        "name": "created_at[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order e.g. -created_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return e.g. id,name,role",
+                        "name": "fields",
+                        "in": "query"
+                    },
                     {
                         "enum": [
                             "asc",
@@ -115,9 +149,10 @@ var doc = `{
                     {
                         "enum": [
                             "id",
-                            "code",
                             "name",
-                            "active"
+                            "role",
+                            "active",
+                            "created_at"
                         ],
                         "type": "string",
                         "description": "order field",
@@ -135,6 +170,22 @@ var doc = `{
                         "description": "records per page",
                         "name": "per_page",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous response, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "count the records, false skips the count and returns a total and last_page of -1",
+                        "name": "total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -159,7 +210,7 @@ var doc = `{
                                                         "data": {
                                                             "type": "array",
                                                             "items": {
-                                                                "$ref": "#/definitions/countries.CountryOutput"
+                                                                "$ref": "#/definitions/api_keys.ApiKeyOutput"
                                                             }
                                                         }
                                                     }
@@ -183,6 +234,12 @@ var doc = `{
                             "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -192,7 +249,12 @@ var doc = `{
                 }
             },
             "post": {
-                "description": "Endpoint used to create a new country record",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to create a new API key. The plain key is only returned in this response.",
                 "consumes": [
                     "application/json"
                 ],
@@ -200,10 +262,10 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Countries"
+                    "API Keys"
                 ],
-                "summary": "Create country",
-                "operationId": "v1-countries-create",
+                "summary": "Create API key",
+                "operationId": "v1-api-keys-create",
                 "parameters": [
                     {
                         "description": "Request Sample",
@@ -211,7 +273,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/countries.CountryInput"
+                            "$ref": "#/definitions/api_keys.ApiKeyInput"
                         }
                     }
                 ],
@@ -219,7 +281,19 @@ var doc = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/api_keys.CreateApiKeyOutput"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -234,6 +308,24 @@ var doc = `{
                             "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -243,21 +335,26 @@ var doc = `{
                 }
             }
         },
-        "/countries/{id}": {
+        "/api-keys/{id}": {
             "get": {
-                "description": "Retrieve a country identified by id",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve an API key identified by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Countries"
+                    "API Keys"
                 ],
-                "summary": "Find country",
-                "operationId": "v1-countries-find",
+                "summary": "Find API key",
+                "operationId": "v1-api-keys-find",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Country ID",
+                        "description": "API Key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -275,7 +372,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/countries.CountryOutput"
+                                            "$ref": "#/definitions/api_keys.ApiKeyOutput"
                                         }
                                     }
                                 }
@@ -294,6 +391,18 @@ var doc = `{
                             "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -303,7 +412,12 @@ var doc = `{
                 }
             },
             "put": {
-                "description": "Endpoint used to update an existing country record",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to update the name, role or status of an existing API key",
                 "consumes": [
                     "application/json"
                 ],
@@ -311,14 +425,14 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Countries"
+                    "API Keys"
                 ],
-                "summary": "Update country",
-                "operationId": "v1-countries-update",
+                "summary": "Update API key",
+                "operationId": "v1-api-keys-update",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Country ID",
+                        "description": "API Key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -329,7 +443,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/countries.UpdateCountryInput"
+                            "$ref": "#/definitions/api_keys.UpdateApiKeyInput"
                         }
                     }
                 ],
@@ -352,6 +466,30 @@ var doc = `{
                             "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -361,7 +499,12 @@ var doc = `{
                 }
             },
             "delete": {
-                "description": "Endpoint used to delete an existing country record",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to revoke an existing API key",
                 "consumes": [
                     "application/json"
                 ],
@@ -369,14 +512,14 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Countries"
+                    "API Keys"
                 ],
-                "summary": "Delete country",
-                "operationId": "v1-countries-delete",
+                "summary": "Delete API key",
+                "operationId": "v1-api-keys-delete",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Country ID",
+                        "description": "API Key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -401,6 +544,18 @@ var doc = `{
                             "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -409,100 +564,9172 @@ var doc = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "countries.CountryInput": {
-            "type": "object",
-            "required": [
-                "as_id",
-                "name"
-            ],
-            "properties": {
-                "active": {
-                    "type": "boolean"
-                },
-                "as_id": {
-                    "type": "integer"
-                },
-                "code": {
-                    "type": "string"
-                },
-                "flag": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "countries.CountryOutput": {
-            "type": "object",
-            "properties": {
-                "active": {
-                    "type": "boolean"
-                },
-                "as_id": {
-                    "type": "integer"
-                },
-                "code": {
-                    "type": "string"
-                },
-                "flag": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                }
-            }
         },
-        "countries.UpdateCountryInput": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "active": {
-                    "type": "boolean"
-                },
-                "code": {
-                    "type": "string"
-                },
-                "flag": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
+        "/auth/login": {
+            "post": {
+                "description": "Exchange the email and password of a registered user for a JWT bearer token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login",
+                "operationId": "v1-auth-login",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/users.LoginInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/api_keys.TokenOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
                 }
             }
         },
-        "pagination.PaginatedResponse": {
-            "type": "object",
-            "properties": {
-                "current_page": {
+        "/auth/token": {
+            "post": {
+                "description": "Exchange an API key for a short-lived JWT bearer token with the same role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Issue access token",
+                "operationId": "v1-auth-token",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api_keys.TokenInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/api_keys.TokenOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/backtests": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the backtests, filtering on a league and sorting on a metric compares the runs of the models",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backtests"
+                ],
+                "summary": "List backtests",
+                "operationId": "v1-backtests-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "filter by league, also league_id[in]",
+                        "name": "league_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by model, also model[in]",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "running",
+                            "finished",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "filter by status, also status[in]",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by first season, also season_from[gte] and season_from[lte]",
+                        "name": "season_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by last season, also season_to[gte] and season_to[lte]",
+                        "name": "season_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "log-loss at most, also log_loss[lt] and log_loss[null]",
+                        "name": "log_loss[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "ROI at least, also roi[gt], roi[lt], roi[lte] and roi[null]",
+                        "name": "roi[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields e.g. log_loss, brier, rps, -accuracy or -roi, prefixed with - for descending order, -created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return e.g. id,model,log_loss,roi",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous response, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "count the records, false skips the count and returns a total and last_page of -1",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/backtests.Backtest"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Start a backtest of a model over the finished fixtures of a league between two seasons. Every fixture is predicted in kickoff order by the model fitted on the results known before it and bet on with the 1x2 odds recorded before it. The backtest runs in the background, it is returned pending and its status is finished or failed once the results are saved",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backtests"
+                ],
+                "summary": "Start backtest",
+                "operationId": "v1-backtests-create",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backtests.CreateBacktestInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backtests.Backtest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/backtests/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a backtest identified by id with its status and, once finished, its average log-loss, Brier score, ranked probability score, accuracy and the profit and ROI of its simulated bets",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backtests"
+                ],
+                "summary": "Find backtest",
+                "operationId": "v1-backtests-find",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Backtest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backtests.Backtest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/backtests/{id}/predictions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the predictions of a backtest with the result of their fixture and their simulated bet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backtests"
+                ],
+                "summary": "Backtest predictions",
+                "operationId": "v1-backtests-predictions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Backtest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by fixture, also fixture_id[in]",
+                        "name": "fixture_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "home",
+                            "draw",
+                            "away"
+                        ],
+                        "type": "string",
+                        "description": "filter by result, also result[in]",
+                        "name": "result",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by the selection bet on, also bet[ne] and bet[in], bet[ne]= keeps the bets",
+                        "name": "bet",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "kickoff at or after, YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ, also kickoff_at[gt], kickoff_at[lt] and kickoff_at[lte]",
+                        "name": "kickoff_at[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order, kickoff_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "count the records, false skips the count and returns a total and last_page of -1",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/backtests.Prediction"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/competitions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve competitions, optionally only the ones the authenticated user takes part in",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Competitions"
+                ],
+                "summary": "List competitions",
+                "operationId": "v1-competitions-list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "filter by part of the name, also name[eq], name[ne] and name[in]",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by owner, also owner_user_id[ne] and owner_user_id[in]",
+                        "name": "owner_user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or after, YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ, also created_at[gt], created_at[lt] and created_at[lte]",
+                        "name": "created_at[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order e.g. -created_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return e.g. id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "only competitions the user is a member of",
+                        "name": "mine",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "order direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "order field",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous response, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "count the records, false skips the count and returns a total and last_page of -1",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/competitions.Competition"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to create a new prediction competition. The creator automatically joins it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Competitions"
+                ],
+                "summary": "Create competition",
+                "operationId": "v1-competitions-create",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/competitions.CompetitionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/competitions.Competition"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/competitions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a competition identified by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Competitions"
+                ],
+                "summary": "Find competition",
+                "operationId": "v1-competitions-find",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Competition ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/competitions.Competition"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used by the owner to update the name or scoring rules of a competition",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Competitions"
+                ],
+                "summary": "Update competition",
+                "operationId": "v1-competitions-update",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Competition ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/competitions.UpdateCompetitionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used by the owner to delete a competition",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Competitions"
+                ],
+                "summary": "Delete competition",
+                "operationId": "v1-competitions-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Competition ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/competitions/{id}/leaderboard": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the members of a competition ranked by points, then exact scores",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Competitions"
+                ],
+                "summary": "Competition leaderboard",
+                "operationId": "v1-competitions-leaderboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Competition ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/memberships.LeaderboardEntry"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/competitions/{id}/members": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used by the authenticated user to join a competition",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Competitions"
+                ],
+                "summary": "Join competition",
+                "operationId": "v1-competitions-join",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Competition ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardConflictError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used by the authenticated user to leave a competition",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Competitions"
+                ],
+                "summary": "Leave competition",
+                "operationId": "v1-competitions-leave",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Competition ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/competitions/{id}/predictions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the predictions of the authenticated user in a competition",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Competitions"
+                ],
+                "summary": "List predictions",
+                "operationId": "v1-competitions-predictions-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Competition ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by fixture, also fixture_id[ne] and fixture_id[in]",
+                        "name": "fixture_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "predictions that were not (true) or were (false) scored, also points, points[ne], points[gt], points[gte], points[lt] and points[lte]",
+                        "name": "points[null]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order e.g. fixture_id,-created_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return e.g. fixture_id,home_goals,away_goals",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "order direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "fixture_id",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "order field",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous response, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "count the records, false skips the count and returns a total and last_page of -1",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/user_predictions.UserPrediction"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create or replace the score prediction of the authenticated user for a fixture. Predictions are locked at kickoff.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Competitions"
+                ],
+                "summary": "Submit prediction",
+                "operationId": "v1-competitions-predictions-submit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Competition ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user_predictions.UserPredictionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user_predictions.UserPrediction"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/countries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all countries",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "List countries",
+                "operationId": "v1-countries-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "filter by id, also id[ne], id[in], id[gt], id[gte], id[lt] and id[lte]",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by code, also code[ne], code[in] and code[like]",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by part of the name, also name[eq], name[ne] and name[in]",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "filter by status",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order e.g. -active,name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return e.g. id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "order direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "code",
+                            "name",
+                            "active"
+                        ],
+                        "type": "string",
+                        "description": "order field",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous response, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "count the records, false skips the count and returns a total and last_page of -1",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/countries.CountryOutput"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to create a new country record",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Create country",
+                "operationId": "v1-countries-create",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/countries.CountryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/countries/bulk": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to update several existing country records in a single transaction. Mode atomic (default) rolls back every item when one fails, best_effort applies the valid items and reports the others",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Bulk update countries",
+                "operationId": "v1-countries-bulk-update",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/countries.BulkUpdateCountryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/bulk.Response"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/bulk.Response"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.BulkError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.BulkError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to create several country records in a single transaction. Mode atomic (default) rolls back every item when one fails, best_effort applies the valid items and reports the others",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Bulk create countries",
+                "operationId": "v1-countries-bulk-create",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/countries.BulkCreateCountryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/bulk.Response"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/bulk.Response"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.BulkError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.BulkError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to delete several existing country records in a single transaction. Mode atomic (default) rolls back every item when one fails, best_effort applies the valid items and reports the others",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Bulk delete countries",
+                "operationId": "v1-countries-bulk-delete",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bulk.DeleteInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/bulk.Response"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/bulk.Response"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.BulkError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.BulkError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/countries/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream the countries matching the filters of the list endpoint as a CSV, JSON or NDJSON attachment, the fields parameter selects the columns",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Export countries",
+                "operationId": "v1-countries-export",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "json",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "export format, json by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to export",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/countries.CountryOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/countries/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upsert the rows of a CSV or JSON upload, sent as the file field of a multipart form or as the request body. The columns are the fields of CountryInput: code, name (required), flag and active. Countries are matched by name. Valid rows are saved even when others fail, every failed row is reported",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Import countries",
+                "operationId": "v1-countries-import",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or JSON file",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "upload format, by default from the file extension or the content type",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/importer.Report"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/importer.Report"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/countries/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a country identified by id, the ETag header holds its version. When the If-None-Match header has the current ETag nothing is returned but a 304",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Find country",
+                "operationId": "v1-countries-find",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the country already held by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/countries.CountryOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "ETag of the country"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to update an existing country record. The If-Match header must hold the ETag returned when the country was read, or * to overwrite any version, the new ETag is returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Update country",
+                "operationId": "v1-countries-update",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the country e.g. \\",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/countries.UpdateCountryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "ETag of the updated country"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardPreconditionRequiredError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to delete an existing country record. The If-Match header must hold the ETag returned when the country was read, or * to delete any version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Delete country",
+                "operationId": "v1-countries-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the country e.g. \\",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardPreconditionRequiredError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to change some fields of an existing country record with a JSON Merge Patch (RFC 7396), the members of the body replace the fields of the country and null resets a field. The If-Match header must hold the ETag returned when the country was read, or * to patch any version, the new ETag is returned",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Countries"
+                ],
+                "summary": "Patch country",
+                "operationId": "v1-countries-patch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the country e.g. \\",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change e.g. {\\",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/countries.UpdateCountryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "ETag of the updated country"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardPreconditionRequiredError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fixtures": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve fixtures filtered by league, season, team, status or kickoff date",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fixtures"
+                ],
+                "summary": "List fixtures",
+                "operationId": "v1-fixtures-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "filter by league, also league_id[ne] and league_id[in]",
+                        "name": "league_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by season, also season[ne], season[in], season[gt], season[gte], season[lt] and season[lte]",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by home or away team",
+                        "name": "team_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by status short code e.g. NS, FT, also status[ne] and status[in] e.g. status[in]=FT,AET,PEN",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "kickoff at or after, YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ, also kickoff_at[gt], kickoff_at[lt] and kickoff_at[lte]",
+                        "name": "kickoff_at[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "fixtures without (true) or with (false) a score, also away_goals[null]",
+                        "name": "home_goals[null]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "kickoff from date YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "kickoff until date YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order e.g. -kickoff_at,id",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return e.g. id,kickoff_at,status",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "order direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "kickoff_at",
+                            "league_id",
+                            "season",
+                            "status"
+                        ],
+                        "type": "string",
+                        "description": "order field",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous response, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "count the records, false skips the count and returns a total and last_page of -1",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/fixtures.Fixture"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fixtures/details/sync": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import from API Sports the events, lineups and statistics of a fixture, or of the finished fixtures of a league season that do not have them all yet. The fixtures must be synced first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fixtures"
+                ],
+                "summary": "Sync fixture details",
+                "operationId": "v1-fixtures-details-sync",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/fixtures.SyncDetailsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fixtures/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream the fixtures matching the filters of the list endpoint as a CSV, JSON or NDJSON attachment, the fields parameter selects the columns",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Fixtures"
+                ],
+                "summary": "Export fixtures",
+                "operationId": "v1-fixtures-export",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "json",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "export format, json by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to export",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/fixtures.Fixture"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fixtures/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upsert the rows of a CSV or JSON upload, sent as the file field of a multipart form or as the request body. The columns are the fields of Fixture: id, league_id, season, kickoff_at, status, home_team_id and away_team_id (required), round, team names and goals. Valid rows are saved even when others fail, every failed row is reported",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fixtures"
+                ],
+                "summary": "Import fixtures",
+                "operationId": "v1-fixtures-import",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or JSON file",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "upload format, by default from the file extension or the content type",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/importer.Report"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/importer.Report"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fixtures/sync": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import the fixtures of a league season from API Sports and score the predictions of finished fixtures",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fixtures"
+                ],
+                "summary": "Sync fixtures",
+                "operationId": "v1-fixtures-sync",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/fixtures.SyncFixtureInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fixtures/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a fixture identified by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fixtures"
+                ],
+                "summary": "Find fixture",
+                "operationId": "v1-fixtures-find",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fixture ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/fixtures.Fixture"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fixtures/{id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the goals, cards, substitutions and VAR decisions of a fixture with their minute and players, in the order they happened. Synced once the fixture finishes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fixtures"
+                ],
+                "summary": "Fixture events",
+                "operationId": "v1-fixtures-events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fixture ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/fixture_events.Event"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fixtures/{id}/lineups": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the formations, coaches, starting elevens and substitutes of the teams of a fixture. Synced once the fixture finishes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fixtures"
+                ],
+                "summary": "Fixture lineups",
+                "operationId": "v1-fixtures-lineups",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fixture ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/fixture_lineups.Lineup"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fixtures/{id}/odds": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the current odds of a fixture per bookmaker, market and line with the implied probabilities of the selections once the bookmaker margin (overround) is removed. The probabilities of a line missing a selection are 0",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Odds"
+                ],
+                "summary": "Fixture odds",
+                "operationId": "v1-fixtures-odds",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fixture ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "1x2",
+                            "over_under",
+                            "btts",
+                            "asian_handicap"
+                        ],
+                        "type": "string",
+                        "description": "only this market",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only this bookmaker",
+                        "name": "bookmaker_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "basic",
+                            "shin",
+                            "power"
+                        ],
+                        "type": "string",
+                        "description": "overround removal, basic normalisation by default",
+                        "name": "method",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/odds.MarketOdds"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fixtures/{id}/prediction": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Forecast the result of a fixture from the finished fixtures of its league played before kickoff. The model is the champion unless a registered version is requested, the prediction of a fixture by a version is saved and does not change once every earlier result is known",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Predictions"
+                ],
+                "summary": "Predict fixture",
+                "operationId": "v1-fixtures-prediction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fixture ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "model version e.g. dixon-coles@3, or a model name for its latest version, the champion by default",
+                        "name": "model",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/predictions.Prediction"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/fixtures/{id}/statistics": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the match statistics of the teams of a fixture: shots, shots on target, possession, corners, cards, passes and expected goals when API Sports covers them. Synced once the fixture finishes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fixtures"
+                ],
+                "summary": "Fixture statistics",
+                "operationId": "v1-fixtures-statistics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fixture ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/fixture_statistics.TeamStatistic"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/injuries/sync": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import from API Sports the injured and suspended players missing a fixture, or the fixtures of a league season, with the end of their sideline period as expected return. The fixtures must be synced first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Injuries"
+                ],
+                "summary": "Sync injuries",
+                "operationId": "v1-injuries-sync",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/injuries.SyncInjuryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve leagues filtered by name, type or country",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leagues"
+                ],
+                "summary": "List leagues",
+                "operationId": "v1-leagues-list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "filter by part of the name, also name[eq], name[ne] and name[in]",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "League",
+                            "Cup"
+                        ],
+                        "type": "string",
+                        "description": "filter by type, also type[ne] and type[in]",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by country name, also country_name[ne], country_name[in] and country_name[like]",
+                        "name": "country_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by country code, also country_code[ne], country_code[in] and country_code[null]",
+                        "name": "country_code",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "filter by status",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order e.g. country_name,-name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return e.g. id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "order direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "country_name"
+                        ],
+                        "type": "string",
+                        "description": "order field",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous response, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "count the records, false skips the count and returns a total and last_page of -1",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/leagues.League"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream the leagues matching the filters of the list endpoint as a CSV, JSON or NDJSON attachment, the fields parameter selects the columns",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Leagues"
+                ],
+                "summary": "Export leagues",
+                "operationId": "v1-leagues-export",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "json",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "export format, json by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to export",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/leagues.League"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upsert the rows of a CSV or JSON upload, sent as the file field of a multipart form or as the request body. The columns are the fields of League: id, name and type (required), logo, country_name, country_code and active. Valid rows are saved even when others fail, every failed row is reported",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leagues"
+                ],
+                "summary": "Import leagues",
+                "operationId": "v1-leagues-import",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or JSON file",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "upload format, by default from the file extension or the content type",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/importer.Report"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/importer.Report"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/sync": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import the leagues from API Sports",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leagues"
+                ],
+                "summary": "Sync leagues",
+                "operationId": "v1-leagues-sync",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a league identified by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leagues"
+                ],
+                "summary": "Find league",
+                "operationId": "v1-leagues-find",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "League ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/leagues.League"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{id}/seasons/{season}/bracket": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the knockout rounds of a cup season in the order they are played, with the ties drawn so far, their fixtures, aggregate score and winner. The rounds are the league.round of the synced fixtures",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Predictions"
+                ],
+                "summary": "Get cup bracket",
+                "operationId": "v1-leagues-bracket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "League ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season e.g. 2021",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/brackets.Bracket"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{id}/seasons/{season}/bracket/simulation": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Simulate the group fixtures and knockout ties left in a cup season many times, drawing the scores from the model fitted on the results known now, and retrieve the probability of each team reaching each knockout round and winning the cup. A tie level on aggregate goes to extra time then to a penalty shootout, the teams not drawn yet are drawn at random. The teams are sorted by the probability of winning the cup and the same seed gives the same simulation",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Predictions"
+                ],
+                "summary": "Simulate cup season",
+                "operationId": "v1-leagues-bracket-simulation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "League ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season e.g. 2021",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "model version e.g. dixon-coles@3, or a model name for its latest version, the champion by default",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of simulated cups, between 100 and 100000, 10000 by default",
+                        "name": "runs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "seed of the random numbers, a random one by default which the response returns",
+                        "name": "seed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of teams of each group going through to the knockout rounds, 2 by default",
+                        "name": "qualifiers",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/brackets.BracketSimulation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{id}/seasons/{season}/simulation": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Simulate the fixtures left in a league season many times from the current table, drawing the scores from the model fitted on the results known now, and retrieve the probability of each team finishing in each position, winning the title, finishing in the europe places and being relegated, with its expected points. The teams are sorted by expected points and the same seed gives the same simulation",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Predictions"
+                ],
+                "summary": "Simulate league season",
+                "operationId": "v1-leagues-simulation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "League ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season e.g. 2021",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "model version e.g. dixon-coles@3, or a model name for its latest version, the champion by default",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of simulated seasons, between 100 and 100000, 10000 by default",
+                        "name": "runs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "seed of the random numbers, a random one by default which the response returns",
+                        "name": "seed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of places qualifying for Europe, 4 by default",
+                        "name": "europe_places",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of places relegated, 3 by default",
+                        "name": "relegation_places",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/simulations.Simulation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/models": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the registered model versions, the champion is the one used when a prediction does not request a model",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Models"
+                ],
+                "summary": "List model versions",
+                "operationId": "v1-models-list",
+                "parameters": [
+                    {
+                        "enum": [
+                            "poisson",
+                            "poisson-rho",
+                            "dixon-coles",
+                            "elo",
+                            "ensemble"
+                        ],
+                        "type": "string",
+                        "description": "filter by model, also name[in]",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by version, also version[gte] and version[lte]",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "filter the champion",
+                        "name": "champion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "description containing the value",
+                        "name": "description[like]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields e.g. id or -version, prefixed with - for descending order, -created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return e.g. id,name,version",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous response, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "count the records, false skips the count and returns a total and last_page of -1",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/models.Model"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Register the next version of a prediction model with its hyperparameters, which can not change afterwards. The hyperparameters left out take their default: none for poisson, rho, the fixed correction of the low scores, for poisson-rho, xi, the time decay per day, for dixon-coles, k, home_advantage and supremacy for elo, and members, a list of model, weight and hyperparameters, for ensemble",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Models"
+                ],
+                "summary": "Register model version",
+                "operationId": "v1-models-create",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateModelInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Model"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/models/compare": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Compare model versions side by side with their prediction of a fixture and their latest finished backtest of its league. The prediction is null without a fixture or when the model has not enough data, the backtest is null when the version was not backtested",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Models"
+                ],
+                "summary": "Compare model versions",
+                "operationId": "v1-models-compare",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated model versions e.g. poisson@1,dixon-coles@3, a name alone is its latest version, at most 10",
+                        "name": "models",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "fixture to predict",
+                        "name": "fixture_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "league of the backtests, the league of the fixture by default and any league without a fixture",
+                        "name": "league_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Comparison"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/models/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a model version identified by id with its hyperparameters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Models"
+                ],
+                "summary": "Find model version",
+                "operationId": "v1-models-find",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Model"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/models/{id}/champion": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Make a model version the champion, the one used by the predictions that do not request a model and by the value bets. The previous champion becomes a challenger",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Models"
+                ],
+                "summary": "Make model version champion",
+                "operationId": "v1-models-champion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Model"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/models/{id}/fit": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fit a model version on the fixtures of a league that finished until now in the season and the previous one and retrieve its parameters: the league averages and team strengths for poisson, the rho, home advantage and team attacks and defences for dixon-coles, the ratings for elo and the members for ensemble. The fit is not stored",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Models"
+                ],
+                "summary": "Fit model version",
+                "operationId": "v1-models-fit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "league of the fixtures",
+                        "name": "league_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "season of the fixtures",
+                        "name": "season",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Fit"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/odds": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the recorded prices, every price a bookmaker offered is kept so the odds of a selection sorted by recorded_at are its line movement",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Odds"
+                ],
+                "summary": "List odds",
+                "operationId": "v1-odds-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "filter by fixture, also fixture_id[in]",
+                        "name": "fixture_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by bookmaker, also bookmaker_id[ne] and bookmaker_id[in]",
+                        "name": "bookmaker_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "1x2",
+                            "over_under",
+                            "btts",
+                            "asian_handicap"
+                        ],
+                        "type": "string",
+                        "description": "filter by market, also market[ne] and market[in]",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "home",
+                            "draw",
+                            "away",
+                            "over",
+                            "under",
+                            "yes",
+                            "no"
+                        ],
+                        "type": "string",
+                        "description": "filter by selection, also selection[in]",
+                        "name": "selection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "filter by the goals of over_under or the home handicap of asian_handicap, also line[gt], line[gte], line[lt] and line[lte]",
+                        "name": "line",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "recorded at or after, YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ, also recorded_at[gt], recorded_at[lt] and recorded_at[lte]",
+                        "name": "recorded_at[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order, -recorded_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return e.g. selection,price,recorded_at",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous response, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "count the records, false skips the count and returns a total and last_page of -1",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/odds.Odd"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/odds/sync": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import from API Sports the pre-match odds of a fixture, or of the fixtures of a league season, of every bookmaker or of a single one. The 1x2, over_under, btts and asian_handicap markets are stored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Odds"
+                ],
+                "summary": "Sync odds",
+                "operationId": "v1-odds-sync",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/odds.SyncOddInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/players": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve players filtered by name, team or position, the team, number and position are the ones of the current squad of the player",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Players"
+                ],
+                "summary": "List players",
+                "operationId": "v1-players-list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "filter by part of the name, also name[eq]",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by part of the last name",
+                        "name": "lastname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by nationality, also nationality[in] and nationality[like]",
+                        "name": "nationality",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by age, also age[gt], age[gte], age[lt] and age[lte]",
+                        "name": "age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by the team of the squad, also team_id[in] and team_id[null]",
+                        "name": "team_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by part of the team name, also team_name[eq]",
+                        "name": "team_name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "Goalkeeper",
+                            "Defender",
+                            "Midfielder",
+                            "Attacker"
+                        ],
+                        "type": "string",
+                        "description": "filter by position, also position[in]",
+                        "name": "position",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order e.g. lastname,-age",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return e.g. id,name,team_name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous response, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "count the records, false skips the count and returns a total and last_page of -1",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/players.Player"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/sync": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import from API Sports the current squad of a team, or the players of a league season with their statistics. Squads set the team and position the players are searched by, the statistics feed the leaderboards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Players"
+                ],
+                "summary": "Sync players",
+                "operationId": "v1-players-sync",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/players.SyncPlayerInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/top-assists": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the players of a league season by assists, ties are ranked by goals then by the fewest minutes played. A player who moved during the season has a row per team",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Players"
+                ],
+                "summary": "Top assists",
+                "operationId": "v1-players-top-assists",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "League ID",
+                        "name": "league_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "season",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/players.Leader"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/top-scorers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the players of a league season by goals scored, ties are ranked by assists then by the fewest minutes played. A player who moved during the season has a row per team",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Players"
+                ],
+                "summary": "Top scorers",
+                "operationId": "v1-players-top-scorers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "League ID",
+                        "name": "league_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "season",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/players.Leader"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a player identified by id with the team of its current squad and its statistics of every synced season, the latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Players"
+                ],
+                "summary": "Find player",
+                "operationId": "v1-players-find",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/players.PlayerOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all seasons",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seasons"
+                ],
+                "summary": "List seasons",
+                "operationId": "v1-seasons-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "filter by id, also id[ne], id[in], id[gt], id[gte], id[lt] and id[lte]",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by label, also label[in] and label[like]",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order e.g. -id",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return e.g. id,label",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "order direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "label"
+                        ],
+                        "type": "string",
+                        "description": "order field",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous response, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            true,
+                            false
+                        ],
+                        "type": "boolean",
+                        "description": "count the records, false skips the count and returns a total and last_page of -1",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/seasons.Season"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to create a new season record, the label defaults to the two years format e.g. 2021/22",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seasons"
+                ],
+                "summary": "Create season",
+                "operationId": "v1-seasons-create",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/seasons.Season"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to create several season records in a single transaction. Mode atomic (default) rolls back every item when one fails, best_effort applies the valid items and reports the others",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seasons"
+                ],
+                "summary": "Bulk create seasons",
+                "operationId": "v1-seasons-bulk-create",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/seasons.BulkCreateSeasonInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/bulk.Response"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/bulk.Response"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.BulkError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.BulkError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to delete several existing season records in a single transaction. Mode atomic (default) rolls back every item when one fails, best_effort applies the valid items and reports the others",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seasons"
+                ],
+                "summary": "Bulk delete seasons",
+                "operationId": "v1-seasons-bulk-delete",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bulk.DeleteInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/bulk.Response"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/bulk.Response"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.BulkError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.BulkError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/current": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Resolve the season of a league active on a date. Without a date the season marked as current by API Sports is returned, between two seasons the season that ended last is returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seasons"
+                ],
+                "summary": "Current season",
+                "operationId": "v1-seasons-current",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "league id",
+                        "name": "league_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "date formatted as YYYY-MM-DD, defaults to today",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/seasons.LeagueSeason"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream the seasons matching the filters of the list endpoint as a CSV, JSON or NDJSON attachment, the fields parameter selects the columns",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Seasons"
+                ],
+                "summary": "Export seasons",
+                "operationId": "v1-seasons-export",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "json",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "export format, json by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to export",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/seasons.Season"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upsert the rows of a CSV or JSON upload, sent as the file field of a multipart form or as the request body. The columns are the fields of Season: id (required) and label, which defaults to e.g. 2021/22. Valid rows are saved even when others fail, every failed row is reported",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seasons"
+                ],
+                "summary": "Import seasons",
+                "operationId": "v1-seasons-import",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or JSON file",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "upload format, by default from the file extension or the content type",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/importer.Report"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/importer.Report"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a season identified by id, the ETag header holds its version. When the If-None-Match header has the current ETag nothing is returned but a 304",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seasons"
+                ],
+                "summary": "Find season",
+                "operationId": "v1-seasons-find",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the season already held by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/seasons.Season"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "ETag of the season"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to delete an existing season record. The If-Match header must hold the ETag returned when the season was read, or * to delete any version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seasons"
+                ],
+                "summary": "Delete season",
+                "operationId": "v1-seasons-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the season e.g. \\",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardPreconditionRequiredError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Endpoint used to change some fields of an existing season record with a JSON Merge Patch (RFC 7396), the members of the body replace the fields of the season and null resets a field. The If-Match header must hold the ETag returned when the season was read, or * to patch any version, the new ETag is returned",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seasons"
+                ],
+                "summary": "Patch season",
+                "operationId": "v1-seasons-patch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the season e.g. \\",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change e.g. {\\",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/seasons.UpdateSeasonInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.NoErrorString"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "ETag of the updated season"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardPreconditionRequiredError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}/squad": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the current squad of a team, goalkeepers first then defenders, midfielders and attackers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Players"
+                ],
+                "summary": "Team squad",
+                "operationId": "v1-teams-squad",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/squads.SquadPlayer"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}/unavailable": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the injured and suspended players of a team listed out of its next fixture, with their expected return and the share of the minutes of the team they played, the ones who played the most first. Empty until the injuries of the fixture are synced",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Injuries"
+                ],
+                "summary": "Team unavailable players",
+                "operationId": "v1-teams-unavailable",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/injuries.Unavailable"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the account of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Current user",
+                "operationId": "v1-users-me",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/users.UserOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.DatabaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/register": {
+            "post": {
+                "description": "Endpoint used to create a new user account that can take part in prediction competitions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Register user",
+                "operationId": "v1-users-register",
+                "parameters": [
+                    {
+                        "description": "Request Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/users.RegisterUserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.NoErrorI"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/users.UserOutput"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardBadRequestError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardConflictError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/value-bets": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Compare the model probabilities of the fixtures that have not kicked off with the latest odds of every bookmaker once their margin is removed. The selections whose edge, the model minus the market probability, reaches min_edge and whose expected value is positive are returned with the highest edge first, with the fractional Kelly stake for the bankroll. Over/under and asian handicap bets are only rated on half goal lines",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Odds"
+                ],
+                "summary": "List value bets",
+                "operationId": "v1-value-bets-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "filter by league",
+                        "name": "league_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "1x2",
+                            "over_under",
+                            "btts",
+                            "asian_handicap"
+                        ],
+                        "type": "string",
+                        "description": "filter by market",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by bookmaker",
+                        "name": "bookmaker_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum edge between 0 and 1, value_bets.min_edge by default",
+                        "name": "min_edge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "kickoff from date YYYY-MM-DD, today by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "kickoff until date YYYY-MM-DD, 7 days after from by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "basic",
+                            "shin",
+                            "power"
+                        ],
+                        "type": "string",
+                        "description": "overround removal of the market probabilities, basic by default",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "bankroll the stakes are computed for, value_bets.bankroll by default",
+                        "name": "bankroll",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "fraction of the Kelly stake up to 1, value_bets.kelly_fraction by default",
+                        "name": "kelly_fraction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "records per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/swaggertypes.PaginatedData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "data": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/value_bets.ValueBet"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardUnauthorisedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/swaggertypes.StandardInternalServerError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api_keys.ApiKeyInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "role"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "reader",
+                        "editor",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "api_keys.ApiKeyOutput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key_prefix": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "api_keys.CreateApiKeyOutput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "key_prefix": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "api_keys.TokenInput": {
+            "type": "object",
+            "required": [
+                "api_key"
+            ],
+            "properties": {
+                "api_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "api_keys.TokenOutput": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "api_keys.UpdateApiKeyInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "role"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "reader",
+                        "editor",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "backtests.Backtest": {
+            "type": "object",
+            "properties": {
+                "accuracy": {
+                    "type": "number"
+                },
+                "bets": {
+                    "type": "integer"
+                },
+                "brier": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "fixtures": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "log_loss": {
+                    "type": "number"
+                },
+                "min_edge": {
+                    "type": "number"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "profit": {
+                    "type": "number"
+                },
+                "roi": {
+                    "type": "number"
+                },
+                "rps": {
+                    "type": "number"
+                },
+                "season_from": {
+                    "type": "integer"
+                },
+                "season_to": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "staked": {
+                    "type": "number"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "backtests.CreateBacktestInput": {
+            "type": "object",
+            "required": [
+                "league_id",
+                "season_from",
+                "season_to"
+            ],
+            "properties": {
+                "league_id": {
+                    "type": "integer"
+                },
+                "min_edge": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "model": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "season_from": {
+                    "type": "integer"
+                },
+                "season_to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backtests.Prediction": {
+            "type": "object",
+            "properties": {
+                "away_win": {
+                    "type": "number"
+                },
+                "backtest_id": {
+                    "type": "integer"
+                },
+                "bet": {
+                    "type": "string"
+                },
+                "draw": {
+                    "type": "number"
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "home_win": {
+                    "type": "number"
+                },
+                "kickoff_at": {
+                    "type": "string"
+                },
+                "matches_used": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "profit": {
+                    "type": "number"
+                },
+                "result": {
+                    "type": "string"
+                }
+            }
+        },
+        "brackets.Bracket": {
+            "type": "object",
+            "properties": {
+                "league_id": {
+                    "type": "integer"
+                },
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/brackets.Round"
+                    }
+                },
+                "season": {
+                    "type": "integer"
+                }
+            }
+        },
+        "brackets.BracketSimulation": {
+            "type": "object",
+            "properties": {
+                "league_id": {
+                    "type": "integer"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "qualifiers": {
+                    "type": "integer"
+                },
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "runs": {
+                    "type": "integer"
+                },
+                "season": {
+                    "type": "integer"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/brackets.Team"
+                    }
+                }
+            }
+        },
+        "brackets.Round": {
+            "type": "object",
+            "properties": {
+                "legs": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ties": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/brackets.Tie"
+                    }
+                }
+            }
+        },
+        "brackets.Team": {
+            "type": "object",
+            "properties": {
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "winner": {
+                    "type": "number"
+                }
+            }
+        },
+        "brackets.Tie": {
+            "type": "object",
+            "properties": {
+                "away_goals": {
+                    "type": "integer"
+                },
+                "away_team_id": {
+                    "type": "integer"
+                },
+                "away_team_name": {
+                    "type": "string"
+                },
+                "fixture_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "home_goals": {
+                    "type": "integer"
+                },
+                "home_team_id": {
+                    "type": "integer"
+                },
+                "home_team_name": {
+                    "type": "string"
+                },
+                "winner_team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "bulk.DeleteInput": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/bulk.DeleteItem"
+                    }
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ]
+                }
+            }
+        },
+        "bulk.DeleteItem": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "bulk.Response": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bulk.Result"
+                    }
+                }
+            }
+        },
+        "bulk.Result": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "competitions.Competition": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_user_id": {
+                    "type": "integer"
+                },
+                "points_exact": {
+                    "type": "integer"
+                },
+                "points_goal_difference": {
+                    "type": "integer"
+                },
+                "points_result": {
+                    "type": "integer"
+                }
+            }
+        },
+        "competitions.CompetitionInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "points_exact",
+                "points_result"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "points_exact": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "points_goal_difference": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "points_result": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "competitions.UpdateCompetitionInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "points_exact",
+                "points_result"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "points_exact": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "points_goal_difference": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "points_result": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "countries.BulkCreateCountryInput": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/countries.CountryInput"
+                    }
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ]
+                }
+            }
+        },
+        "countries.BulkUpdateCountryInput": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/countries.BulkUpdateCountryItem"
+                    }
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ]
+                }
+            }
+        },
+        "countries.BulkUpdateCountryItem": {
+            "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "flag": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "countries.CountryInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "flag": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "countries.CountryOutput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "flag": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "countries.UpdateCountryInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "flag": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "fixture_events.Event": {
+            "type": "object",
+            "properties": {
+                "assist_id": {
+                    "type": "integer"
+                },
+                "assist_name": {
+                    "type": "string"
+                },
+                "comments": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "elapsed": {
+                    "type": "integer"
+                },
+                "extra": {
+                    "type": "integer"
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "player_name": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "fixture_lineups.Lineup": {
+            "type": "object",
+            "properties": {
+                "coach_id": {
+                    "type": "integer"
+                },
+                "coach_name": {
+                    "type": "string"
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "formation": {
+                    "type": "string"
+                },
+                "start_xi": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fixture_lineups.Player"
+                    }
+                },
+                "substitutes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fixture_lineups.Player"
+                    }
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "fixture_lineups.Player": {
+            "type": "object",
+            "properties": {
+                "grid": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "player_name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                }
+            }
+        },
+        "fixture_statistics.TeamStatistic": {
+            "type": "object",
+            "properties": {
+                "blocked_shots": {
+                    "type": "integer"
+                },
+                "corners": {
+                    "type": "integer"
+                },
+                "expected_goals": {
+                    "type": "number"
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "fouls": {
+                    "type": "integer"
+                },
+                "offsides": {
+                    "type": "integer"
+                },
+                "passes": {
+                    "type": "integer"
+                },
+                "passes_accurate": {
+                    "type": "integer"
+                },
+                "possession": {
+                    "type": "integer"
+                },
+                "red_cards": {
+                    "type": "integer"
+                },
+                "saves": {
+                    "type": "integer"
+                },
+                "shots": {
+                    "type": "integer"
+                },
+                "shots_inside_box": {
+                    "type": "integer"
+                },
+                "shots_off_target": {
+                    "type": "integer"
+                },
+                "shots_on_target": {
+                    "type": "integer"
+                },
+                "shots_outside_box": {
+                    "type": "integer"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "yellow_cards": {
+                    "type": "integer"
+                }
+            }
+        },
+        "fixtures.Fixture": {
+            "type": "object",
+            "required": [
+                "away_team_id",
+                "home_team_id",
+                "id",
+                "kickoff_at",
+                "league_id",
+                "season",
+                "status"
+            ],
+            "properties": {
+                "away_goals": {
+                    "type": "integer"
+                },
+                "away_team_id": {
+                    "type": "integer"
+                },
+                "away_team_name": {
+                    "type": "string"
+                },
+                "details_synced_at": {
+                    "description": "DetailsSyncedAt is when the events, lineups and statistics were all imported, a sync imports them again for\nthe fixtures that finished recently where it is null",
+                    "type": "string"
+                },
+                "home_goals": {
+                    "type": "integer"
+                },
+                "home_team_id": {
+                    "type": "integer"
+                },
+                "home_team_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kickoff_at": {
+                    "type": "string"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "string"
+                },
+                "scored_at": {
+                    "description": "ScoredAt is when the user predictions were last scored, a sync scores the finished fixtures where it is null\nagain and a corrected score resets it",
+                    "type": "string"
+                },
+                "season": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "winner_team_id": {
+                    "description": "WinnerTeamID is the team API Sports marks as the winner, set for the draws decided by a penalty shootout",
+                    "type": "integer"
+                }
+            }
+        },
+        "fixtures.SyncDetailsInput": {
+            "type": "object",
+            "properties": {
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "season": {
+                    "type": "integer"
+                }
+            }
+        },
+        "fixtures.SyncFixtureInput": {
+            "type": "object",
+            "required": [
+                "league_id",
+                "season"
+            ],
+            "properties": {
+                "league_id": {
+                    "type": "integer"
+                },
+                "season": {
+                    "type": "integer"
+                }
+            }
+        },
+        "importer.Report": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/importer.RowError"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "importer.RowError": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "injuries.SyncInjuryInput": {
+            "type": "object",
+            "properties": {
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "season": {
+                    "type": "integer"
+                }
+            }
+        },
+        "injuries.Unavailable": {
+            "type": "object",
+            "properties": {
+                "expected_return": {
+                    "type": "string"
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "kickoff_at": {
+                    "type": "string"
+                },
+                "minutes_share": {
+                    "type": "number"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "player_name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "leagues.League": {
+            "type": "object",
+            "required": [
+                "id",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "country_code": {
+                    "type": "string"
+                },
+                "country_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "logo": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "League",
+                        "Cup"
+                    ]
+                }
+            }
+        },
+        "memberships.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "exact_scores": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "scored_predictions": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Comparison": {
+            "type": "object",
+            "properties": {
+                "backtest": {
+                    "$ref": "#/definitions/backtests.Backtest"
+                },
+                "model": {
+                    "$ref": "#/definitions/models.Model"
+                },
+                "prediction": {
+                    "$ref": "#/definitions/predictions.Prediction"
+                }
+            }
+        },
+        "models.CreateModelInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "hyperparameters": {
+                    "type": "object"
+                },
+                "name": {
+                    "type": "string",
+                    "enum": [
+                        "poisson",
+                        "poisson-rho",
+                        "dixon-coles",
+                        "elo",
+                        "ensemble"
+                    ]
+                }
+            }
+        },
+        "models.Fit": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "fitted_before": {
+                    "type": "string"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "type": "integer"
+                },
+                "model_id": {
+                    "type": "integer"
+                },
+                "parameters": {
+                    "type": "object"
+                },
+                "season": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Model": {
+            "type": "object",
+            "properties": {
+                "champion": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "hyperparameters": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "odds.MarketOdds": {
+            "type": "object",
+            "properties": {
+                "bookmaker": {
+                    "type": "string"
+                },
+                "bookmaker_id": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "number"
+                },
+                "market": {
+                    "type": "string"
+                },
+                "overround": {
+                    "type": "number"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "selections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/odds.SelectionOdds"
+                    }
+                }
+            }
+        },
+        "odds.Odd": {
+            "type": "object",
+            "properties": {
+                "bookmaker": {
+                    "type": "string"
+                },
+                "bookmaker_id": {
+                    "type": "integer"
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "number"
+                },
+                "market": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "selection": {
+                    "type": "string"
+                }
+            }
+        },
+        "odds.SelectionOdds": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "type": "number"
+                },
+                "probability": {
+                    "type": "number"
+                },
+                "selection": {
+                    "type": "string"
+                }
+            }
+        },
+        "odds.SyncOddInput": {
+            "type": "object",
+            "properties": {
+                "bookmaker_id": {
+                    "type": "integer"
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "season": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pagination.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "data": {},
+                "from": {
+                    "type": "integer"
+                },
+                "last_page": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "NextCursor and PrevCursor are only set by the lists supporting cursors, when there is such a page",
+                    "type": "string"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "players.Leader": {
+            "type": "object",
+            "properties": {
+                "appearances": {
+                    "type": "integer"
+                },
+                "assists": {
+                    "type": "integer"
+                },
+                "goals": {
+                    "type": "integer"
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "photo": {
+                    "type": "string"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "players.Player": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "birth_date": {
+                    "type": "string"
+                },
+                "firstname": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastname": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "photo": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "string"
+                }
+            }
+        },
+        "players.PlayerOutput": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "birth_date": {
+                    "type": "string"
+                },
+                "firstname": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastname": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "photo": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "statistics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/players.Statistic"
+                    }
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "string"
+                }
+            }
+        },
+        "players.Statistic": {
+            "type": "object",
+            "properties": {
+                "appearances": {
+                    "type": "integer"
+                },
+                "assists": {
+                    "type": "integer"
+                },
+                "goals": {
+                    "type": "integer"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "lineups": {
+                    "type": "integer"
+                },
+                "minutes": {
+                    "type": "integer"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "red_cards": {
+                    "type": "integer"
+                },
+                "season": {
+                    "type": "integer"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "yellow_cards": {
+                    "type": "integer"
+                }
+            }
+        },
+        "players.SyncPlayerInput": {
+            "type": "object",
+            "properties": {
+                "league_id": {
+                    "type": "integer"
+                },
+                "season": {
+                    "type": "integer"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "predictions.Availability": {
+            "type": "object",
+            "properties": {
+                "away_factor": {
+                    "type": "number"
+                },
+                "away_missing": {
+                    "type": "number"
+                },
+                "away_players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/predictions.MissingPlayer"
+                    }
+                },
+                "home_factor": {
+                    "type": "number"
+                },
+                "home_missing": {
+                    "type": "number"
+                },
+                "home_players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/predictions.MissingPlayer"
+                    }
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "predictions.BothTeamsScore": {
+            "type": "object",
+            "properties": {
+                "no": {
+                    "type": "number"
+                },
+                "yes": {
+                    "type": "number"
+                }
+            }
+        },
+        "predictions.GoalLine": {
+            "type": "object",
+            "properties": {
+                "line": {
+                    "type": "number"
+                },
+                "over": {
+                    "type": "number"
+                },
+                "under": {
+                    "type": "number"
+                }
+            }
+        },
+        "predictions.Handicap": {
+            "type": "object",
+            "properties": {
+                "away": {
+                    "type": "number"
+                },
+                "home": {
+                    "type": "number"
+                },
+                "line": {
+                    "type": "number"
+                }
+            }
+        },
+        "predictions.Markets": {
+            "type": "object",
+            "properties": {
+                "asian_handicap": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/predictions.Handicap"
+                    }
+                },
+                "both_teams_score": {
+                    "$ref": "#/definitions/predictions.BothTeamsScore"
+                },
+                "over_under": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/predictions.GoalLine"
+                    }
+                }
+            }
+        },
+        "predictions.MissingPlayer": {
+            "type": "object",
+            "properties": {
+                "minutes_share": {
+                    "type": "number"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "player_name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "predictions.Prediction": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "description": "Availability is set when the model version applies an availability_weight",
+                    "$ref": "#/definitions/predictions.Availability"
+                },
+                "away_expected_goals": {
+                    "type": "number"
+                },
+                "away_team_id": {
+                    "type": "integer"
+                },
+                "away_win": {
+                    "type": "number"
+                },
+                "draw": {
+                    "type": "number"
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "home_expected_goals": {
+                    "type": "number"
+                },
+                "home_team_id": {
+                    "type": "integer"
+                },
+                "home_win": {
+                    "type": "number"
+                },
+                "markets": {
+                    "$ref": "#/definitions/predictions.Markets"
+                },
+                "matches_used": {
+                    "description": "MatchesUsed is the number of finished fixtures the model was fitted on",
+                    "type": "integer"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "most_likely_score": {
+                    "$ref": "#/definitions/predictions.Score"
+                }
+            }
+        },
+        "predictions.Score": {
+            "type": "object",
+            "properties": {
+                "away_goals": {
+                    "type": "integer"
+                },
+                "home_goals": {
+                    "type": "integer"
+                },
+                "probability": {
+                    "type": "number"
+                }
+            }
+        },
+        "seasons.BulkCreateSeasonInput": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/seasons.Season"
+                    }
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ]
+                }
+            }
+        },
+        "seasons.LeagueSeason": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "boolean"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "season": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "seasons.Season": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "seasons.UpdateSeasonInput": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "maxLength": 10
+                }
+            }
+        },
+        "simulations.Simulation": {
+            "type": "object",
+            "properties": {
+                "europe_places": {
+                    "type": "integer"
+                },
+                "fixtures_left": {
                     "type": "integer"
                 },
-                "data": {},
-                "from": {
+                "league_id": {
                     "type": "integer"
                 },
-                "last_page": {
+                "model": {
+                    "type": "string"
+                },
+                "relegation_places": {
                     "type": "integer"
                 },
-                "per_page": {
+                "runs": {
                     "type": "integer"
                 },
-                "to": {
+                "season": {
                     "type": "integer"
                 },
-                "total": {
+                "seed": {
+                    "type": "integer"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/simulations.Team"
+                    }
+                }
+            }
+        },
+        "simulations.Team": {
+            "type": "object",
+            "properties": {
+                "europe": {
+                    "type": "number"
+                },
+                "expected_points": {
+                    "type": "number"
+                },
+                "goal_difference": {
+                    "type": "integer"
+                },
+                "played": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "positions": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "relegation": {
+                    "type": "number"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "number"
+                }
+            }
+        },
+        "squads.SquadPlayer": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "photo": {
+                    "type": "string"
+                },
+                "player_id": {
                     "type": "integer"
+                },
+                "position": {
+                    "type": "string"
+                }
+            }
+        },
+        "swaggertypes.BulkError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 422
+                },
+                "error": {
+                    "$ref": "#/definitions/bulk.Response"
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "UNPROCESSABLE_ENTITY"
+                }
+            }
+        },
+        "swaggertypes.DatabaseError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "error": {
+                    "type": "string",
+                    "example": "The country does not exist"
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "COUNTRY_NOT_FOUND"
                 }
             }
         },
@@ -546,10 +9773,16 @@ var doc = `{
                         "last_page": {
                             "type": "integer"
                         },
+                        "next_cursor": {
+                            "type": "string"
+                        },
                         "per_page": {
                             "type": "string",
                             "example": "0"
                         },
+                        "prev_cursor": {
+                            "type": "string"
+                        },
                         "to": {
                             "type": "integer"
                         },
@@ -570,6 +9803,44 @@ var doc = `{
                 "error": {
                     "type": "string",
                     "example": "Bad Request"
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "BAD_REQUEST"
+                }
+            }
+        },
+        "swaggertypes.StandardConflictError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Conflict"
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "CONFLICT"
+                }
+            }
+        },
+        "swaggertypes.StandardForbiddenError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 403
+                },
+                "error": {
+                    "type": "string",
+                    "example": "INSUFFICIENT_PERMISSIONS"
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "INSUFFICIENT_PERMISSIONS"
                 }
             }
         },
@@ -583,6 +9854,44 @@ var doc = `{
                 "error": {
                     "type": "string",
                     "example": "Server Error"
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "INTERNAL_SERVER_ERROR"
+                }
+            }
+        },
+        "swaggertypes.StandardNotFoundError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Not found"
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "NOT_FOUND"
+                }
+            }
+        },
+        "swaggertypes.StandardPreconditionRequiredError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 428
+                },
+                "error": {
+                    "type": "string",
+                    "example": "IF_MATCH_REQUIRED"
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "IF_MATCH_REQUIRED"
                 }
             }
         },
@@ -596,6 +9905,213 @@ var doc = `{
                 "error": {
                     "type": "string",
                     "example": "INVALID_USER_AUTHENTICATION"
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "INVALID_USER_AUTHENTICATION"
+                }
+            }
+        },
+        "swaggertypes.StandardValidationError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "error": {
+                    "type": "object",
+                    "properties": {
+                        "field_name": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            },
+                            "example": [
+                                "example error"
+                            ]
+                        }
+                    }
+                },
+                "error_code": {
+                    "type": "string",
+                    "example": "VALIDATION_FAILED"
+                }
+            }
+        },
+        "user_predictions.UserPrediction": {
+            "type": "object",
+            "properties": {
+                "away_goals": {
+                    "type": "integer"
+                },
+                "competition_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "home_goals": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "scored_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "user_predictions.UserPredictionInput": {
+            "type": "object",
+            "required": [
+                "away_goals",
+                "fixture_id",
+                "home_goals"
+            ],
+            "properties": {
+                "away_goals": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "home_goals": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "users.LoginInput": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "users.RegisterUserInput": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "users.UserOutput": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "value_bets.ValueBet": {
+            "type": "object",
+            "properties": {
+                "away_team_name": {
+                    "type": "string"
+                },
+                "bookmaker": {
+                    "type": "string"
+                },
+                "bookmaker_id": {
+                    "type": "integer"
+                },
+                "edge": {
+                    "type": "number"
+                },
+                "expected_value": {
+                    "type": "number"
+                },
+                "fixture_id": {
+                    "type": "integer"
+                },
+                "home_team_name": {
+                    "type": "string"
+                },
+                "kelly": {
+                    "type": "number"
+                },
+                "kickoff_at": {
+                    "type": "string"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "number"
+                },
+                "market": {
+                    "type": "string"
+                },
+                "market_probability": {
+                    "type": "number"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "model_probability": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "selection": {
+                    "type": "string"
+                },
+                "stake": {
+                    "type": "number"
                 }
             }
         }
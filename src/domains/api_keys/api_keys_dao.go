@@ -0,0 +1,170 @@
+package api_keys
+
+import (
+	"context"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"time"
+)
+
+type ApiKeyDaoI interface {
+	Create(ctx context.Context, key *ApiKey) error
+	Update(ctx context.Context, key *UpdateApiKeyInput) error
+	UpdateLastUsed(ctx context.Context, id int64, usedAt time.Time) error
+	FindByID(ctx context.Context, id int64) (*ApiKeyOutput, error)
+	FindByHash(ctx context.Context, hash string) (*ApiKey, error)
+	List(ctx context.Context, req *ListApiKeyInput) ([]ApiKeyOutput, int64, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type apiKeyDao struct{}
+
+var ApiKeyDao ApiKeyDaoI = &apiKeyDao{}
+
+func (d *apiKeyDao) Create(ctx context.Context, key *ApiKey) error {
+	defer metrics.TimeQuery("ApiKeyDao", "Create")()
+	ctx, span := tracing.Start(ctx, "ApiKeyDao.Create")
+	defer span.End()
+
+	res, err := footy_db.Client.NamedExecContext(ctx, queryCreate, key)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ApiKeyDao Create NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ApiKeyDao Create LastInsertId", "error", err)
+		return dberror.Wrap(err)
+	}
+	key.ID = id
+	return nil
+}
+
+func (d *apiKeyDao) Update(ctx context.Context, key *UpdateApiKeyInput) error {
+	defer metrics.TimeQuery("ApiKeyDao", "Update")()
+	ctx, span := tracing.Start(ctx, "ApiKeyDao.Update")
+	defer span.End()
+
+	_, err := footy_db.Client.NamedExecContext(ctx, queryUpdate, key)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ApiKeyDao Update NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *apiKeyDao) UpdateLastUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	defer metrics.TimeQuery("ApiKeyDao", "UpdateLastUsed")()
+	ctx, span := tracing.Start(ctx, "ApiKeyDao.UpdateLastUsed")
+	defer span.End()
+
+	_, err := footy_db.Client.ExecContext(ctx, queryUpdateLastUsed, usedAt, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ApiKeyDao UpdateLastUsed Exec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *apiKeyDao) FindByID(ctx context.Context, id int64) (*ApiKeyOutput, error) {
+	defer metrics.TimeQuery("ApiKeyDao", "FindByID")()
+	ctx, span := tracing.Start(ctx, "ApiKeyDao.FindByID")
+	defer span.End()
+
+	var result ApiKeyOutput
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindByID, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ApiKeyDao FindByID Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+func (d *apiKeyDao) FindByHash(ctx context.Context, hash string) (*ApiKey, error) {
+	defer metrics.TimeQuery("ApiKeyDao", "FindByHash")()
+	ctx, span := tracing.Start(ctx, "ApiKeyDao.FindByHash")
+	defer span.End()
+
+	var result ApiKey
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindByHash, hash)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ApiKeyDao FindByHash Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+func (d *apiKeyDao) List(ctx context.Context, req *ListApiKeyInput) ([]ApiKeyOutput, int64, error) {
+	defer metrics.TimeQuery("ApiKeyDao", "List")()
+	ctx, span := tracing.Start(ctx, "ApiKeyDao.List")
+	defer span.End()
+
+	var results []ApiKeyOutput
+	// Create where, limit and order by clauses
+	where, args := d.generateListWhereClause(req)
+	page := req.Filter.Page(req.Page, req.PerPage, results)
+	query := fmt.Sprintf(queryList, page.Select(listColumns), page.Where(where), page.OrderBy(), page.Limit())
+
+	// Get the records
+	err := footy_db.Client.SelectContext(ctx, &results, query, page.Args(args)...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ApiKeyDao List Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+	results = page.Rows(results).([]ApiKeyOutput)
+
+	// Get total records so we can use them for pagination, unless the client asked to skip the count
+	if !req.Filter.Total() {
+		return results, pagination.TotalNotCounted, nil
+	}
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryListTotal, where), args...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ApiKeyDao List GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	return results, total, nil
+}
+
+func (d *apiKeyDao) generateListWhereClause(req *ListApiKeyInput) (string, []interface{}) {
+	w := helpers.NewWhere()
+	w.AppendWhereAtStart()
+	w.Where("true") // add this just in case we do not have any param passed
+	w.Conditions(req.Filter.Conditions())
+
+	return w.String()
+}
+
+func (d *apiKeyDao) Delete(ctx context.Context, id int64) error {
+	defer metrics.TimeQuery("ApiKeyDao", "Delete")()
+	ctx, span := tracing.Start(ctx, "ApiKeyDao.Delete")
+	defer span.End()
+
+	res, err := footy_db.Client.ExecContext(ctx, queryDelete, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ApiKeyDao Delete Exec", "error", err)
+		return dberror.Wrap(err)
+	}
+	// Nothing is deleted when the record does not exist
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return dberror.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,433 @@
+package api_keys
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var testCreatedAt = time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func TestApiKeyDao_Create(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedID  int64
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO api_keys").
+					WithArgs("name", "fp_12345678", "hash", "admin", true, testCreatedAt).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "error LastInsertId",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO api_keys").
+					WithArgs("name", "fp_12345678", "hash", "admin", true, testCreatedAt).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("test LastInsertId")))
+			},
+			expectedErr: errors.New("test LastInsertId"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO api_keys").
+					WithArgs("name", "fp_12345678", "hash", "admin", true, testCreatedAt).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+			},
+			expectedID:  5,
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			key := &ApiKey{
+				Name:      "name",
+				KeyPrefix: "fp_12345678",
+				KeyHash:   "hash",
+				Role:      "admin",
+				Active:    true,
+				CreatedAt: testCreatedAt,
+			}
+			err = ApiKeyDao.Create(context.Background(), key)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedID, key.ID)
+		})
+	}
+}
+
+func TestApiKeyDao_Update(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE api_keys SET").
+					WithArgs("name", "editor", true, 1).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE api_keys SET").
+					WithArgs("name", "editor", true, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = ApiKeyDao.Update(context.Background(), &UpdateApiKeyInput{
+				ID:     1,
+				Name:   "name",
+				Role:   "editor",
+				Active: true,
+			})
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestApiKeyDao_UpdateLastUsed(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE api_keys SET last_used_at").
+					WithArgs(testCreatedAt, 1).
+					WillReturnError(errors.New("test Exec"))
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE api_keys SET last_used_at").
+					WithArgs(testCreatedAt, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = ApiKeyDao.UpdateLastUsed(context.Background(), 1, testCreatedAt)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestApiKeyDao_FindByID(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *ApiKeyOutput
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM api_keys").
+					WithArgs(1).
+					WillReturnError(errors.New("test Get"))
+			},
+			expectedErr: errors.New("test Get"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM api_keys").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{
+						"id",
+						"name",
+						"key_prefix",
+						"role",
+						"active",
+						"last_used_at",
+						"created_at",
+					}).AddRow(
+						1,
+						"name",
+						"fp_12345678",
+						"reader",
+						1,
+						nil,
+						testCreatedAt,
+					))
+			},
+			expectedRes: &ApiKeyOutput{
+				ID:        1,
+				Name:      "name",
+				KeyPrefix: "fp_12345678",
+				Role:      "reader",
+				Active:    true,
+				CreatedAt: testCreatedAt,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := ApiKeyDao.FindByID(context.Background(), 1)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestApiKeyDao_FindByHash(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *ApiKey
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM api_keys").
+					WithArgs("hash").
+					WillReturnError(errors.New("test Get"))
+			},
+			expectedErr: errors.New("test Get"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM api_keys").
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows([]string{
+						"id",
+						"name",
+						"key_prefix",
+						"key_hash",
+						"role",
+						"active",
+						"last_used_at",
+						"created_at",
+					}).AddRow(
+						1,
+						"name",
+						"fp_12345678",
+						"hash",
+						"editor",
+						1,
+						testCreatedAt,
+						testCreatedAt,
+					))
+			},
+			expectedRes: &ApiKey{
+				ID:         1,
+				Name:       "name",
+				KeyPrefix:  "fp_12345678",
+				KeyHash:    "hash",
+				Role:       "editor",
+				Active:     true,
+				LastUsedAt: &testCreatedAt,
+				CreatedAt:  testCreatedAt,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := ApiKeyDao.FindByHash(context.Background(), "hash")
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestApiKeyDao_List(t *testing.T) {
+	testCases := []struct {
+		title         string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []ApiKeyOutput
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM api_keys").
+					WithArgs(true, "%name%", "admin").
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM api_keys").
+					WithArgs(true, "%name%", "admin").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "key_prefix", "role", "active", "last_used_at", "created_at"}).
+						AddRow(1, "name", "fp_12345678", "admin", 1, nil, testCreatedAt))
+				m.ExpectQuery("SELECT (.+) FROM api_keys").
+					WithArgs(true, "%name%", "admin").
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM api_keys").
+					WithArgs(true, "%name%", "admin").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "key_prefix", "role", "active", "last_used_at", "created_at"}).
+						AddRow(1, "name", "fp_12345678", "admin", 1, nil, testCreatedAt))
+				m.ExpectQuery("SELECT (.+) FROM api_keys").
+					WithArgs(true, "%name%", "admin").
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes: []ApiKeyOutput{
+				{
+					ID:        1,
+					Name:      "name",
+					KeyPrefix: "fp_12345678",
+					Role:      "admin",
+					Active:    true,
+					CreatedAt: testCreatedAt,
+				},
+			},
+			expectedTotal: 1,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			query, errs := filter.Parse(url.Values{"name": {"name"}, "role": {"admin"}, "active": {"true"}}, ApiKeyOutput{})
+			assert.Nil(t, errs)
+
+			res, total, err := ApiKeyDao.List(context.Background(), &ListApiKeyInput{Filter: *query})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestApiKeyDao_Delete(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("DELETE FROM api_keys").
+					WithArgs(1).
+					WillReturnError(errors.New("test Exec"))
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("DELETE FROM api_keys").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = ApiKeyDao.Delete(context.Background(), 1)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
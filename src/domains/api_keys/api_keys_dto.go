@@ -0,0 +1,63 @@
+package api_keys
+
+import (
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"time"
+)
+
+type ApiKey struct {
+	ID         int64      `db:"id"`
+	Name       string     `db:"name"`
+	KeyPrefix  string     `db:"key_prefix"`
+	KeyHash    string     `db:"key_hash"`
+	Role       string     `db:"role"`
+	Active     bool       `db:"active"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+type ApiKeyInput struct {
+	Name   string `json:"name" form:"name" validate:"required,max=100"`
+	Role   string `json:"role" form:"role" validate:"required,oneof=reader editor admin"`
+	Active bool   `json:"active" form:"active"`
+}
+
+type UpdateApiKeyInput struct {
+	ID     int64  `json:"-" form:"-" db:"id"`
+	Name   string `json:"name" form:"name" db:"name" validate:"required,max=100"`
+	Role   string `json:"role" form:"role" db:"role" validate:"required,oneof=reader editor admin"`
+	Active bool   `json:"active" form:"active" db:"active"`
+}
+
+// ListApiKeyInput filters on the fields of ApiKeyOutput, see its filter tags
+type ListApiKeyInput struct {
+	Page    int64        `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage int64        `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+	Filter  filter.Query `json:"-" form:"-"`
+}
+
+type ApiKeyOutput struct {
+	ID         int64      `json:"id" db:"id" filter:"eq,ne,in,gt,gte,lt,lte,sort,default"`
+	Name       string     `json:"name" db:"name" filter:"like,eq,ne,in,sort"`
+	KeyPrefix  string     `json:"key_prefix" db:"key_prefix" filter:"eq,like"`
+	Role       string     `json:"role" db:"role" filter:"eq,ne,in,sort,oneof=reader editor admin"`
+	Active     bool       `json:"active" db:"active" filter:"eq,sort"`
+	LastUsedAt *time.Time `json:"last_used_at" db:"last_used_at" filter:"gt,gte,lt,lte,null"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at" filter:"gt,gte,lt,lte,sort"`
+}
+
+// CreateApiKeyOutput is the only response that contains the plain API key, it is never stored or returned again
+type CreateApiKeyOutput struct {
+	ApiKeyOutput
+	Key string `json:"key"`
+}
+
+type TokenInput struct {
+	ApiKey string `json:"api_key" form:"api_key" validate:"required"`
+}
+
+type TokenOutput struct {
+	Token     string    `json:"token"`
+	TokenType string    `json:"token_type"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,39 @@
+package api_keys
+
+const (
+	queryCreate = `INSERT INTO api_keys(
+		name,
+		key_prefix,
+		key_hash,
+		role,
+		active,
+		created_at)
+	VALUES (
+		:name,
+		:key_prefix,
+		:key_hash,
+		:role,
+		:active,
+		:created_at)`
+
+	queryUpdate = `UPDATE api_keys
+	  SET
+		name = :name,
+		role = :role,
+		active = :active
+	  WHERE
+		id = :id`
+
+	queryUpdateLastUsed = `UPDATE api_keys SET last_used_at = ? WHERE id = ?`
+
+	queryFindByID = `SELECT id, name, key_prefix, role, active, last_used_at, created_at FROM api_keys WHERE id = ? LIMIT 1`
+
+	queryFindByHash = `SELECT * FROM api_keys WHERE key_hash = ? LIMIT 1`
+
+	// listColumns leaves the key hash out of the listed keys
+	listColumns    = `id, name, key_prefix, role, active, last_used_at, created_at`
+	queryList      = `SELECT %s FROM api_keys %s ORDER BY %s %s`
+	queryListTotal = `SELECT count(id) FROM api_keys %s`
+
+	queryDelete = `DELETE FROM api_keys WHERE id = ?`
+)
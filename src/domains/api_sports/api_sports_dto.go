@@ -38,3 +38,374 @@ type GetSeasonsOutput struct {
 	Paging   Paging   `json:"paging"`
 	Response []int64  `json:"response"`
 }
+
+type FixtureStatus struct {
+	Long    string `json:"long"`
+	Short   string `json:"short"`
+	Elapsed *int64 `json:"elapsed"`
+}
+
+type FixtureDetails struct {
+	ID        int64         `json:"id"`
+	Referee   string        `json:"referee"`
+	Timezone  string        `json:"timezone"`
+	Date      string        `json:"date"`
+	Timestamp int64         `json:"timestamp"`
+	Status    FixtureStatus `json:"status"`
+}
+
+type FixtureLeague struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Country string `json:"country"`
+	Season  int64  `json:"season"`
+	Round   string `json:"round"`
+}
+
+type FixtureTeam struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Logo   string `json:"logo"`
+	Winner *bool  `json:"winner"`
+}
+
+type FixtureTeams struct {
+	Home FixtureTeam `json:"home"`
+	Away FixtureTeam `json:"away"`
+}
+
+type FixtureGoals struct {
+	Home *int64 `json:"home"`
+	Away *int64 `json:"away"`
+}
+
+type FixturesResponse struct {
+	Fixture FixtureDetails `json:"fixture"`
+	League  FixtureLeague  `json:"league"`
+	Teams   FixtureTeams   `json:"teams"`
+	Goals   FixtureGoals   `json:"goals"`
+}
+
+type GetFixturesOutput struct {
+	Get      string             `json:"get"`
+	Errors   []Errors           `json:"errors"`
+	Results  int64              `json:"results"`
+	Paging   Paging             `json:"paging"`
+	Response []FixturesResponse `json:"response"`
+}
+
+type LeagueDetails struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Logo string `json:"logo"`
+}
+
+type LeagueCountry struct {
+	Name string  `json:"name"`
+	Code *string `json:"code"`
+	Flag *string `json:"flag"`
+}
+
+type LeagueSeason struct {
+	Year    int64  `json:"year"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+	Current bool   `json:"current"`
+}
+
+type LeaguesResponse struct {
+	League  LeagueDetails  `json:"league"`
+	Country LeagueCountry  `json:"country"`
+	Seasons []LeagueSeason `json:"seasons"`
+}
+
+type GetLeaguesOutput struct {
+	Get      string            `json:"get"`
+	Errors   []Errors          `json:"errors"`
+	Results  int64             `json:"results"`
+	Paging   Paging            `json:"paging"`
+	Response []LeaguesResponse `json:"response"`
+}
+
+// OddsRequest selects the pre-match odds of a fixture, or of every fixture of a league season, optionally of
+// a single bookmaker
+type OddsRequest struct {
+	Fixture   int64
+	League    int64
+	Season    int64
+	Bookmaker int64
+}
+
+// OddsValue is the price of a selection, e.g. {"value": "Over 2.5", "odd": "1.85"}
+type OddsValue struct {
+	Value string `json:"value"`
+	Odd   string `json:"odd"`
+}
+
+type OddsBet struct {
+	ID     int64       `json:"id"`
+	Name   string      `json:"name"`
+	Values []OddsValue `json:"values"`
+}
+
+type OddsBookmaker struct {
+	ID   int64     `json:"id"`
+	Name string    `json:"name"`
+	Bets []OddsBet `json:"bets"`
+}
+
+type OddsFixture struct {
+	ID        int64  `json:"id"`
+	Timezone  string `json:"timezone"`
+	Date      string `json:"date"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// OddsResponse holds the odds of a fixture, update is the time the bookmakers last changed them
+type OddsResponse struct {
+	League     FixtureLeague   `json:"league"`
+	Fixture    OddsFixture     `json:"fixture"`
+	Update     string          `json:"update"`
+	Bookmakers []OddsBookmaker `json:"bookmakers"`
+}
+
+type GetOddsOutput struct {
+	Get      string         `json:"get"`
+	Errors   []Errors       `json:"errors"`
+	Results  int64          `json:"results"`
+	Paging   Paging         `json:"paging"`
+	Response []OddsResponse `json:"response"`
+}
+
+type PlayerTeam struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Logo string `json:"logo"`
+}
+
+// SquadPlayer is a player of the current squad of a team, the number is null until the team gives one
+type SquadPlayer struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Age      *int64 `json:"age"`
+	Number   *int64 `json:"number"`
+	Position string `json:"position"`
+	Photo    string `json:"photo"`
+}
+
+type SquadsResponse struct {
+	Team    PlayerTeam    `json:"team"`
+	Players []SquadPlayer `json:"players"`
+}
+
+type GetSquadsOutput struct {
+	Get      string           `json:"get"`
+	Errors   []Errors         `json:"errors"`
+	Results  int64            `json:"results"`
+	Paging   Paging           `json:"paging"`
+	Response []SquadsResponse `json:"response"`
+}
+
+// PlayerBirth holds the birth date of a player as YYYY-MM-DD
+type PlayerBirth struct {
+	Date    *string `json:"date"`
+	Place   *string `json:"place"`
+	Country *string `json:"country"`
+}
+
+// PlayerDetails describes a player, the height and weight are text like "180 cm" and "75 kg"
+type PlayerDetails struct {
+	ID          int64       `json:"id"`
+	Name        string      `json:"name"`
+	Firstname   string      `json:"firstname"`
+	Lastname    string      `json:"lastname"`
+	Age         *int64      `json:"age"`
+	Birth       PlayerBirth `json:"birth"`
+	Nationality string      `json:"nationality"`
+	Height      *string     `json:"height"`
+	Weight      *string     `json:"weight"`
+	Injured     bool        `json:"injured"`
+	Photo       string      `json:"photo"`
+}
+
+// PlayerGames is the playing time of a player, Appearences is spelled like API Sports does and the rating is text
+// like "7.126667"
+type PlayerGames struct {
+	Appearences *int64  `json:"appearences"`
+	Lineups     *int64  `json:"lineups"`
+	Minutes     *int64  `json:"minutes"`
+	Number      *int64  `json:"number"`
+	Position    string  `json:"position"`
+	Rating      *string `json:"rating"`
+	Captain     bool    `json:"captain"`
+}
+
+type PlayerGoals struct {
+	Total    *int64 `json:"total"`
+	Conceded *int64 `json:"conceded"`
+	Assists  *int64 `json:"assists"`
+	Saves    *int64 `json:"saves"`
+}
+
+// PlayerCards counts the cards of a player, Yellowred is a second yellow card
+type PlayerCards struct {
+	Yellow    *int64 `json:"yellow"`
+	Yellowred *int64 `json:"yellowred"`
+	Red       *int64 `json:"red"`
+}
+
+// PlayerStatistics are the statistics of a player for a team in a league season
+type PlayerStatistics struct {
+	Team   PlayerTeam    `json:"team"`
+	League FixtureLeague `json:"league"`
+	Games  PlayerGames   `json:"games"`
+	Goals  PlayerGoals   `json:"goals"`
+	Cards  PlayerCards   `json:"cards"`
+}
+
+type PlayersResponse struct {
+	Player     PlayerDetails      `json:"player"`
+	Statistics []PlayerStatistics `json:"statistics"`
+}
+
+type GetPlayersOutput struct {
+	Get      string            `json:"get"`
+	Errors   []Errors          `json:"errors"`
+	Results  int64             `json:"results"`
+	Paging   Paging            `json:"paging"`
+	Response []PlayersResponse `json:"response"`
+}
+
+// EventTime is the minute of an event, Extra is the minute of stoppage time
+type EventTime struct {
+	Elapsed int64  `json:"elapsed"`
+	Extra   *int64 `json:"extra"`
+}
+
+// EventPlayer is a player of an event, API Sports gives null when it does not know the player
+type EventPlayer struct {
+	ID   *int64  `json:"id"`
+	Name *string `json:"name"`
+}
+
+// FixtureEvent is a goal, card, substitution or VAR decision. The type is Goal, Card, subst or Var, the detail is
+// e.g. "Normal Goal", "Yellow Card", "Substitution 1" or "Goal cancelled"
+type FixtureEvent struct {
+	Time     EventTime   `json:"time"`
+	Team     PlayerTeam  `json:"team"`
+	Player   EventPlayer `json:"player"`
+	Assist   EventPlayer `json:"assist"`
+	Type     string      `json:"type"`
+	Detail   string      `json:"detail"`
+	Comments *string     `json:"comments"`
+}
+
+type GetFixtureEventsOutput struct {
+	Get      string         `json:"get"`
+	Errors   []Errors       `json:"errors"`
+	Results  int64          `json:"results"`
+	Paging   Paging         `json:"paging"`
+	Response []FixtureEvent `json:"response"`
+}
+
+// LineupPlayer is a player of a lineup, Pos is G, D, M or F and Grid is the row:column of the starters on the pitch
+type LineupPlayer struct {
+	ID     *int64  `json:"id"`
+	Name   string  `json:"name"`
+	Number *int64  `json:"number"`
+	Pos    *string `json:"pos"`
+	Grid   *string `json:"grid"`
+}
+
+type LineupEntry struct {
+	Player LineupPlayer `json:"player"`
+}
+
+type LineupCoach struct {
+	ID    *int64  `json:"id"`
+	Name  *string `json:"name"`
+	Photo *string `json:"photo"`
+}
+
+type FixtureLineup struct {
+	Team        PlayerTeam    `json:"team"`
+	Formation   string        `json:"formation"`
+	StartXI     []LineupEntry `json:"startXI"`
+	Substitutes []LineupEntry `json:"substitutes"`
+	Coach       LineupCoach   `json:"coach"`
+}
+
+type GetFixtureLineupsOutput struct {
+	Get      string          `json:"get"`
+	Errors   []Errors        `json:"errors"`
+	Results  int64           `json:"results"`
+	Paging   Paging          `json:"paging"`
+	Response []FixtureLineup `json:"response"`
+}
+
+// StatisticValue is a statistic of a team in a fixture, the value is a number, a text like "55%" or "1.23", or null
+type StatisticValue struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+type FixtureStatistics struct {
+	Team       PlayerTeam       `json:"team"`
+	Statistics []StatisticValue `json:"statistics"`
+}
+
+type GetFixtureStatisticsOutput struct {
+	Get      string              `json:"get"`
+	Errors   []Errors            `json:"errors"`
+	Results  int64               `json:"results"`
+	Paging   Paging              `json:"paging"`
+	Response []FixtureStatistics `json:"response"`
+}
+
+// InjuriesRequest selects the players missing a fixture, or every fixture of a league season
+type InjuriesRequest struct {
+	Fixture int64
+	League  int64
+	Season  int64
+}
+
+// InjuredPlayer is a player out of a fixture, the type is "Missing Fixture" or "Questionable" and the reason is
+// e.g. "Knee Injury", "Red Card" or "Suspended"
+type InjuredPlayer struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Photo  string `json:"photo"`
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+type InjuriesResponse struct {
+	Player  InjuredPlayer `json:"player"`
+	Team    PlayerTeam    `json:"team"`
+	Fixture OddsFixture   `json:"fixture"`
+	League  FixtureLeague `json:"league"`
+}
+
+type GetInjuriesOutput struct {
+	Get      string             `json:"get"`
+	Errors   []Errors           `json:"errors"`
+	Results  int64              `json:"results"`
+	Paging   Paging             `json:"paging"`
+	Response []InjuriesResponse `json:"response"`
+}
+
+// Sideline is a period a player was or is out for, the dates are YYYY-MM-DD and the end is null when unknown
+type Sideline struct {
+	Type  string  `json:"type"`
+	Start string  `json:"start"`
+	End   *string `json:"end"`
+}
+
+type GetSidelinedOutput struct {
+	Get      string     `json:"get"`
+	Errors   []Errors   `json:"errors"`
+	Results  int64      `json:"results"`
+	Paging   Paging     `json:"paging"`
+	Response []Sideline `json:"response"`
+}
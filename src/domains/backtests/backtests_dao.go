@@ -0,0 +1,179 @@
+package backtests
+
+import (
+	"context"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type BacktestDaoI interface {
+	Create(ctx context.Context, backtest *Backtest) error
+	Start(ctx context.Context, backtest *Backtest) error
+	Finish(ctx context.Context, backtest *Backtest, predictions []Prediction) error
+	FindByID(ctx context.Context, id int64) (*Backtest, error)
+	List(ctx context.Context, req *ListBacktestInput) ([]Backtest, int64, error)
+	ListPredictions(ctx context.Context, req *ListPredictionInput) ([]Prediction, int64, error)
+}
+
+type backtestDao struct{}
+
+var BacktestDao BacktestDaoI = &backtestDao{}
+
+func (d *backtestDao) Create(ctx context.Context, backtest *Backtest) error {
+	defer metrics.TimeQuery("BacktestDao", "Create")()
+	ctx, span := tracing.Start(ctx, "BacktestDao.Create")
+	defer span.End()
+
+	res, err := footy_db.DB(ctx).NamedExecContext(ctx, queryCreate, backtest)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("BacktestDao Create NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("BacktestDao Create LastInsertId", "error", err)
+		return dberror.Wrap(err)
+	}
+	backtest.ID = id
+	return nil
+}
+
+// Start saves the status and start time of a run
+func (d *backtestDao) Start(ctx context.Context, backtest *Backtest) error {
+	defer metrics.TimeQuery("BacktestDao", "Start")()
+	ctx, span := tracing.Start(ctx, "BacktestDao.Start")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryStart, backtest)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("BacktestDao Start NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+// Finish saves the predictions and the results of a run in a single transaction, so a finished backtest always
+// has all of its predictions
+func (d *backtestDao) Finish(ctx context.Context, backtest *Backtest, predictions []Prediction) error {
+	defer metrics.TimeQuery("BacktestDao", "Finish")()
+	ctx, span := tracing.Start(ctx, "BacktestDao.Finish")
+	defer span.End()
+
+	err := footy_db.Transaction(ctx, func(ctx context.Context) error {
+		for i := range predictions {
+			predictions[i].BacktestID = backtest.ID
+			if _, err := footy_db.DB(ctx).NamedExecContext(ctx, queryCreatePrediction, &predictions[i]); err != nil {
+				return err
+			}
+		}
+		_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryFinish, backtest)
+		return err
+	})
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("BacktestDao Finish Transaction", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *backtestDao) FindByID(ctx context.Context, id int64) (*Backtest, error) {
+	defer metrics.TimeQuery("BacktestDao", "FindByID")()
+	ctx, span := tracing.Start(ctx, "BacktestDao.FindByID")
+	defer span.End()
+
+	var result Backtest
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindByID, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("BacktestDao FindByID Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+func (d *backtestDao) List(ctx context.Context, req *ListBacktestInput) ([]Backtest, int64, error) {
+	defer metrics.TimeQuery("BacktestDao", "List")()
+	ctx, span := tracing.Start(ctx, "BacktestDao.List")
+	defer span.End()
+
+	var results []Backtest
+	// Create where, limit and order by clauses
+	w := helpers.NewWhere()
+	w.AppendWhereAtStart()
+	w.Where("true") // add this just in case we do not have any param passed
+	w.Conditions(req.Filter.Conditions())
+	where, args := w.String()
+	page := req.Filter.Page(req.Page, req.PerPage, results)
+	query := fmt.Sprintf(queryList, page.Select("*"), page.Where(where), page.OrderBy(), page.Limit())
+
+	// Get the records
+	err := footy_db.Client.SelectContext(ctx, &results, query, page.Args(args)...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("BacktestDao List Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+	results = page.Rows(results).([]Backtest)
+
+	// Get total records so we can use them for pagination, unless the client asked to skip the count
+	if !req.Filter.Total() {
+		return results, pagination.TotalNotCounted, nil
+	}
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryListTotal, where), args...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("BacktestDao List GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	return results, total, nil
+}
+
+func (d *backtestDao) ListPredictions(ctx context.Context, req *ListPredictionInput) ([]Prediction, int64, error) {
+	defer metrics.TimeQuery("BacktestDao", "ListPredictions")()
+	ctx, span := tracing.Start(ctx, "BacktestDao.ListPredictions")
+	defer span.End()
+
+	var results []Prediction
+	// Create where, limit and order by clauses
+	w := helpers.NewWhere()
+	w.AppendWhereAtStart()
+	w.Where("backtest_id = ?", req.BacktestID)
+	w.Conditions(req.Filter.Conditions())
+	where, args := w.String()
+	page := req.Filter.Page(req.Page, req.PerPage, results)
+	query := fmt.Sprintf(queryListPredictions, page.Select("*"), page.Where(where), page.OrderBy(), page.Limit())
+
+	// Get the records
+	err := footy_db.Client.SelectContext(ctx, &results, query, page.Args(args)...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("BacktestDao ListPredictions Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+	results = page.Rows(results).([]Prediction)
+
+	// Get total records so we can use them for pagination, unless the client asked to skip the count
+	if !req.Filter.Total() {
+		return results, pagination.TotalNotCounted, nil
+	}
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryListPredictionsTotal, where), args...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("BacktestDao ListPredictions GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	return results, total, nil
+}
@@ -0,0 +1,452 @@
+package backtests
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var (
+	testCreatedAt  = time.Date(2022, 1, 14, 10, 0, 0, 0, time.UTC)
+	testFinishedAt = time.Date(2022, 1, 14, 10, 5, 0, 0, time.UTC)
+	testKickoffAt  = time.Date(2021, 8, 14, 14, 0, 0, 0, time.UTC)
+	testLogLoss    = 0.98
+	testColumns    = []string{
+		"id",
+		"league_id",
+		"season_from",
+		"season_to",
+		"model",
+		"min_edge",
+		"status",
+		"error",
+		"fixtures",
+		"skipped",
+		"log_loss",
+		"brier",
+		"rps",
+		"accuracy",
+		"bets",
+		"staked",
+		"profit",
+		"roi",
+		"created_at",
+		"started_at",
+		"finished_at",
+	}
+	testPredictionColumns = []string{
+		"backtest_id",
+		"fixture_id",
+		"kickoff_at",
+		"matches_used",
+		"home_win",
+		"draw",
+		"away_win",
+		"result",
+		"bet",
+		"price",
+		"profit",
+	}
+)
+
+func testBacktest() Backtest {
+	return Backtest{
+		ID:         1,
+		LeagueID:   39,
+		SeasonFrom: 2020,
+		SeasonTo:   2021,
+		Model:      "poisson",
+		MinEdge:    0.05,
+		Status:     StatusPending,
+		CreatedAt:  testCreatedAt,
+	}
+}
+
+func testPrediction() Prediction {
+	return Prediction{
+		BacktestID:  1,
+		FixtureID:   10,
+		KickoffAt:   testKickoffAt,
+		MatchesUsed: 380,
+		HomeWin:     0.5,
+		Draw:        0.3,
+		AwayWin:     0.2,
+		Result:      "home",
+		Bet:         "home",
+		Price:       2.2,
+		Profit:      1.2,
+	}
+}
+
+func TestBacktestDao_Create(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedID  int64
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO backtests").
+					WithArgs(39, 2020, 2021, "poisson", 0.05, "pending", testCreatedAt).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "error LastInsertId",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO backtests").
+					WithArgs(39, 2020, 2021, "poisson", 0.05, "pending", testCreatedAt).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("test LastInsertId")))
+			},
+			expectedErr: errors.New("test LastInsertId"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO backtests").
+					WithArgs(39, 2020, 2021, "poisson", 0.05, "pending", testCreatedAt).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			expectedID:  3,
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			backtest := testBacktest()
+			backtest.ID = 0
+			err = BacktestDao.Create(context.Background(), &backtest)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedID, backtest.ID)
+		})
+	}
+}
+
+func TestBacktestDao_Start(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE backtests SET status").
+					WithArgs("running", testCreatedAt, 1).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE backtests SET status").
+					WithArgs("running", testCreatedAt, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			backtest := testBacktest()
+			backtest.Status = StatusRunning
+			backtest.StartedAt = &testCreatedAt
+			err = BacktestDao.Start(context.Background(), &backtest)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestBacktestDao_Finish(t *testing.T) {
+	finishArgs := []driver.Value{"finished", "", 1, 0, testLogLoss, nil, nil, nil, 1, 1.0, 1.2, nil, testFinishedAt, 1}
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error prediction NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO backtest_predictions").
+					WithArgs(1, 10, testKickoffAt, 380, 0.5, 0.3, 0.2, "home", "home", 2.2, 1.2).
+					WillReturnError(errors.New("test NamedExec"))
+				m.ExpectRollback()
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "error backtest NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO backtest_predictions").
+					WithArgs(1, 10, testKickoffAt, 380, 0.5, 0.3, 0.2, "home", "home", 2.2, 1.2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("UPDATE backtests SET").
+					WithArgs(finishArgs...).
+					WillReturnError(errors.New("test NamedExec"))
+				m.ExpectRollback()
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO backtest_predictions").
+					WithArgs(1, 10, testKickoffAt, 380, 0.5, 0.3, 0.2, "home", "home", 2.2, 1.2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("UPDATE backtests SET").
+					WithArgs(finishArgs...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			backtest := testBacktest()
+			backtest.Status = StatusFinished
+			backtest.Fixtures = 1
+			backtest.LogLoss = &testLogLoss
+			backtest.Bets = 1
+			backtest.Staked = 1
+			backtest.Profit = 1.2
+			backtest.FinishedAt = &testFinishedAt
+			prediction := testPrediction()
+			prediction.BacktestID = 0
+			err = BacktestDao.Finish(context.Background(), &backtest, []Prediction{prediction})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBacktestDao_FindByID(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *Backtest
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM backtests WHERE id = ?").
+					WithArgs(1).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM backtests WHERE id = ?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(1, 39, 2020, 2021, "poisson", 0.05, "pending", "", 0, 0, nil, nil, nil, nil, 0, 0, 0, nil, testCreatedAt, nil, nil))
+			},
+			expectedRes: func() *Backtest { b := testBacktest(); return &b }(),
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := BacktestDao.FindByID(context.Background(), 1)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestBacktestDao_List(t *testing.T) {
+	testCases := []struct {
+		title         string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []Backtest
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM backtests").
+					WithArgs(39).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM backtests").
+					WithArgs(39).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(1, 39, 2020, 2021, "poisson", 0.05, "pending", "", 0, 0, nil, nil, nil, nil, 0, 0, 0, nil, testCreatedAt, nil, nil))
+				m.ExpectQuery("SELECT (.+) FROM backtests").
+					WithArgs(39).
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM backtests (.+) ORDER BY log_loss").
+					WithArgs(39).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(1, 39, 2020, 2021, "poisson", 0.05, "pending", "", 0, 0, nil, nil, nil, nil, 0, 0, 0, nil, testCreatedAt, nil, nil))
+				m.ExpectQuery("SELECT (.+) FROM backtests").
+					WithArgs(39).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes:   []Backtest{testBacktest()},
+			expectedTotal: 1,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			query, errs := filter.Parse(url.Values{"league_id": {"39"}, "sort": {"log_loss"}}, Backtest{})
+			assert.Nil(t, errs)
+
+			res, total, err := BacktestDao.List(context.Background(), &ListBacktestInput{Filter: *query})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestBacktestDao_ListPredictions(t *testing.T) {
+	testCases := []struct {
+		title         string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []Prediction
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM backtest_predictions WHERE backtest_id = \\? AND bet = \\?").
+					WithArgs(1, "home").
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM backtest_predictions").
+					WithArgs(1, "home").
+					WillReturnRows(sqlmock.NewRows(testPredictionColumns).
+						AddRow(1, 10, testKickoffAt, 380, 0.5, 0.3, 0.2, "home", "home", 2.2, 1.2))
+				m.ExpectQuery("SELECT (.+) FROM backtest_predictions").
+					WithArgs(1, "home").
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM backtest_predictions").
+					WithArgs(1, "home").
+					WillReturnRows(sqlmock.NewRows(testPredictionColumns).
+						AddRow(1, 10, testKickoffAt, 380, 0.5, 0.3, 0.2, "home", "home", 2.2, 1.2))
+				m.ExpectQuery("SELECT (.+) FROM backtest_predictions").
+					WithArgs(1, "home").
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes:   []Prediction{testPrediction()},
+			expectedTotal: 1,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			query, errs := filter.Parse(url.Values{"bet": {"home"}}, Prediction{})
+			assert.Nil(t, errs)
+
+			res, total, err := BacktestDao.ListPredictions(context.Background(), &ListPredictionInput{BacktestID: 1, Filter: *query})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package backtests
+
+import (
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"time"
+)
+
+// Statuses of a backtest, a run created through the API is pending until it is picked up
+const (
+	StatusPending  = "pending"
+	StatusRunning  = "running"
+	StatusFinished = "finished"
+	StatusFailed   = "failed"
+)
+
+// Backtest is a run of a model over the finished fixtures of a league between two seasons. The metrics are
+// averaged over the predicted fixtures and are null until the run finished, ROI is the profit of the simulated
+// bets per unit staked and is null when no bet was placed
+type Backtest struct {
+	ID         int64      `json:"id" db:"id" filter:"eq,in,sort"`
+	LeagueID   int64      `json:"league_id" db:"league_id" filter:"eq,in"`
+	SeasonFrom int64      `json:"season_from" db:"season_from" filter:"eq,gte,lte"`
+	SeasonTo   int64      `json:"season_to" db:"season_to" filter:"eq,gte,lte"`
+	Model      string     `json:"model" db:"model" filter:"eq,in"`
+	MinEdge    float64    `json:"min_edge" db:"min_edge"`
+	Status     string     `json:"status" db:"status" filter:"eq,in,oneof=pending running finished failed"`
+	Error      string     `json:"error" db:"error"`
+	Fixtures   int64      `json:"fixtures" db:"fixtures" filter:"gte,lte,sort"`
+	Skipped    int64      `json:"skipped" db:"skipped"`
+	LogLoss    *float64   `json:"log_loss" db:"log_loss" filter:"lt,lte,sort,null"`
+	Brier      *float64   `json:"brier" db:"brier" filter:"lt,lte,sort,null"`
+	RPS        *float64   `json:"rps" db:"rps" filter:"lt,lte,sort,null"`
+	Accuracy   *float64   `json:"accuracy" db:"accuracy" filter:"gt,gte,sort,null"`
+	Bets       int64      `json:"bets" db:"bets" filter:"gte,lte,sort"`
+	Staked     float64    `json:"staked" db:"staked"`
+	Profit     float64    `json:"profit" db:"profit" filter:"gt,gte,lt,lte,sort"`
+	ROI        *float64   `json:"roi" db:"roi" filter:"gt,gte,lt,lte,sort,null"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at" filter:"gt,gte,lt,lte,sort,default=desc"`
+	StartedAt  *time.Time `json:"started_at" db:"started_at"`
+	FinishedAt *time.Time `json:"finished_at" db:"finished_at"`
+}
+
+// Prediction is the forecast of a fixture made by a backtest with the model fitted on the fixtures that finished
+// before its kickoff. Bet is the simulated 1x2 selection, empty when the odds stored before the kickoff had no
+// value, Profit is the result of a stake of 1
+type Prediction struct {
+	BacktestID  int64     `json:"backtest_id" db:"backtest_id"`
+	FixtureID   int64     `json:"fixture_id" db:"fixture_id" filter:"eq,in"`
+	KickoffAt   time.Time `json:"kickoff_at" db:"kickoff_at" filter:"gt,gte,lt,lte,sort,default"`
+	MatchesUsed int64     `json:"matches_used" db:"matches_used"`
+	HomeWin     float64   `json:"home_win" db:"home_win"`
+	Draw        float64   `json:"draw" db:"draw"`
+	AwayWin     float64   `json:"away_win" db:"away_win"`
+	Result      string    `json:"result" db:"result" filter:"eq,in,oneof=home draw away"`
+	Bet         string    `json:"bet" db:"bet" filter:"eq,ne,in"`
+	Price       float64   `json:"price" db:"price"`
+	Profit      float64   `json:"profit" db:"profit" filter:"gt,gte,lt,lte,sort"`
+}
+
+// CreateBacktestInput starts a backtest of the seasons SeasonFrom to SeasonTo. Model is a name@version reference
+// or a name for its latest version, the champion by default, MinEdge defaults to the edge of the value bets
+type CreateBacktestInput struct {
+	LeagueID   int64    `json:"league_id" form:"league_id" validate:"required"`
+	SeasonFrom int64    `json:"season_from" form:"season_from" validate:"required"`
+	SeasonTo   int64    `json:"season_to" form:"season_to" validate:"required"`
+	Model      string   `json:"model" form:"model" validate:"max=50"`
+	MinEdge    *float64 `json:"min_edge" form:"min_edge" validate:"omitempty,gte=0,lt=1"`
+}
+
+// ListBacktestInput filters on the fields of Backtest, see its filter tags. Sorting on a metric compares runs
+type ListBacktestInput struct {
+	Page    int64        `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage int64        `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+	Filter  filter.Query `json:"-" form:"-"`
+}
+
+// ListPredictionInput filters on the fields of Prediction, see its filter tags
+type ListPredictionInput struct {
+	BacktestID int64        `json:"-" form:"-"`
+	Page       int64        `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage    int64        `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+	Filter     filter.Query `json:"-" form:"-"`
+}
@@ -0,0 +1,71 @@
+package backtests
+
+const (
+	queryCreate = `INSERT INTO backtests(
+		league_id,
+		season_from,
+		season_to,
+		model,
+		min_edge,
+		status,
+		created_at)
+	VALUES (
+		:league_id,
+		:season_from,
+		:season_to,
+		:model,
+		:min_edge,
+		:status,
+		:created_at)`
+
+	queryStart = `UPDATE backtests SET status = :status, started_at = :started_at WHERE id = :id`
+
+	queryFinish = `UPDATE backtests SET
+		status = :status,
+		error = :error,
+		fixtures = :fixtures,
+		skipped = :skipped,
+		log_loss = :log_loss,
+		brier = :brier,
+		rps = :rps,
+		accuracy = :accuracy,
+		bets = :bets,
+		staked = :staked,
+		profit = :profit,
+		roi = :roi,
+		finished_at = :finished_at
+	WHERE id = :id`
+
+	queryCreatePrediction = `INSERT INTO backtest_predictions(
+		backtest_id,
+		fixture_id,
+		kickoff_at,
+		matches_used,
+		home_win,
+		draw,
+		away_win,
+		result,
+		bet,
+		price,
+		profit)
+	VALUES (
+		:backtest_id,
+		:fixture_id,
+		:kickoff_at,
+		:matches_used,
+		:home_win,
+		:draw,
+		:away_win,
+		:result,
+		:bet,
+		:price,
+		:profit)`
+
+	queryFindByID = `SELECT * FROM backtests WHERE id = ?`
+
+	queryList      = `SELECT %s FROM backtests %s ORDER BY %s %s`
+	queryListTotal = `SELECT count(id) FROM backtests %s`
+
+	queryListPredictions      = `SELECT %s FROM backtest_predictions %s ORDER BY %s %s`
+	queryListPredictionsTotal = `SELECT count(fixture_id) FROM backtest_predictions %s`
+)
@@ -0,0 +1,63 @@
+package brackets
+
+// Bracket is the draw of the knockout rounds of a cup season as far as its fixtures are known, the rounds are
+// in the order they are played
+type Bracket struct {
+	LeagueID int64   `json:"league_id"`
+	Season   int64   `json:"season"`
+	Rounds   []Round `json:"rounds"`
+}
+
+// Round is a knockout round with its drawn ties
+type Round struct {
+	Name string `json:"name"`
+	Legs int64  `json:"legs"`
+	Ties []Tie  `json:"ties"`
+}
+
+// Tie is a knockout tie, the home team plays the first leg at home. The goals are the aggregate of the legs
+// played, null until one is, and the winner is set once the tie is decided
+type Tie struct {
+	HomeTeamID   int64   `json:"home_team_id"`
+	HomeTeamName string  `json:"home_team_name"`
+	AwayTeamID   int64   `json:"away_team_id"`
+	AwayTeamName string  `json:"away_team_name"`
+	FixtureIDs   []int64 `json:"fixture_ids"`
+	HomeGoals    *int64  `json:"home_goals"`
+	AwayGoals    *int64  `json:"away_goals"`
+	WinnerTeamID *int64  `json:"winner_team_id"`
+}
+
+// BracketSimulationInput simulates the rest of a cup season. LeagueID and Season are only set by the controller
+// from the path. Qualifiers is the number of teams of each group going through to the knockout rounds and Seed
+// makes the simulation reproducible, a random one is used and returned when it is left out
+type BracketSimulationInput struct {
+	LeagueID   int64  `json:"-" form:"-"`
+	Season     int64  `json:"-" form:"-"`
+	Model      string `json:"model" form:"model" validate:"max=50"`
+	Runs       int    `json:"runs" form:"runs" validate:"omitempty,gte=100,lte=100000"`
+	Seed       *int64 `json:"seed" form:"seed"`
+	Qualifiers *int   `json:"qualifiers" form:"qualifiers" validate:"omitempty,gte=1,lte=36"`
+}
+
+// BracketSimulation is the outcome of Runs simulations of the group fixtures and knockout ties left in a cup
+// season by the model. Rounds names the knockout rounds, in the order of the probabilities of the teams
+type BracketSimulation struct {
+	LeagueID   int64    `json:"league_id"`
+	Season     int64    `json:"season"`
+	Model      string   `json:"model"`
+	Runs       int      `json:"runs"`
+	Seed       int64    `json:"seed"`
+	Qualifiers int      `json:"qualifiers"`
+	Rounds     []string `json:"rounds"`
+	Teams      []Team   `json:"teams"`
+}
+
+// Team holds the probabilities of a team reaching each knockout round and of winning the cup, the teams are
+// sorted by the probability of winning it
+type Team struct {
+	TeamID   int64     `json:"team_id"`
+	TeamName string    `json:"team_name"`
+	Rounds   []float64 `json:"rounds"`
+	Winner   float64   `json:"winner"`
+}
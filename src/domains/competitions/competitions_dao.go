@@ -0,0 +1,137 @@
+package competitions
+
+import (
+	"context"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type CompetitionDaoI interface {
+	Create(ctx context.Context, competition *Competition) error
+	Update(ctx context.Context, competition *UpdateCompetitionInput) error
+	FindByID(ctx context.Context, id int64) (*Competition, error)
+	List(ctx context.Context, req *ListCompetitionInput) ([]Competition, int64, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type competitionDao struct{}
+
+var CompetitionDao CompetitionDaoI = &competitionDao{}
+
+func (d *competitionDao) Create(ctx context.Context, competition *Competition) error {
+	defer metrics.TimeQuery("CompetitionDao", "Create")()
+	ctx, span := tracing.Start(ctx, "CompetitionDao.Create")
+	defer span.End()
+
+	res, err := footy_db.DB(ctx).NamedExecContext(ctx, queryCreate, competition)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CompetitionDao Create NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CompetitionDao Create LastInsertId", "error", err)
+		return dberror.Wrap(err)
+	}
+	competition.ID = id
+	return nil
+}
+
+func (d *competitionDao) Update(ctx context.Context, competition *UpdateCompetitionInput) error {
+	defer metrics.TimeQuery("CompetitionDao", "Update")()
+	ctx, span := tracing.Start(ctx, "CompetitionDao.Update")
+	defer span.End()
+
+	_, err := footy_db.Client.NamedExecContext(ctx, queryUpdate, competition)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CompetitionDao Update NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *competitionDao) FindByID(ctx context.Context, id int64) (*Competition, error) {
+	defer metrics.TimeQuery("CompetitionDao", "FindByID")()
+	ctx, span := tracing.Start(ctx, "CompetitionDao.FindByID")
+	defer span.End()
+
+	var result Competition
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindByID, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CompetitionDao FindByID Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+func (d *competitionDao) List(ctx context.Context, req *ListCompetitionInput) ([]Competition, int64, error) {
+	defer metrics.TimeQuery("CompetitionDao", "List")()
+	ctx, span := tracing.Start(ctx, "CompetitionDao.List")
+	defer span.End()
+
+	var results []Competition
+	// Create where, limit and order by clauses
+	where, args := d.generateListWhereClause(req)
+	page := req.Filter.Page(req.Page, req.PerPage, results)
+	query := fmt.Sprintf(queryList, page.Select("*"), page.Where(where), page.OrderBy(), page.Limit())
+
+	// Get the records
+	err := footy_db.Client.SelectContext(ctx, &results, query, page.Args(args)...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CompetitionDao List Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+	results = page.Rows(results).([]Competition)
+
+	// Get total records so we can use them for pagination, unless the client asked to skip the count
+	if !req.Filter.Total() {
+		return results, pagination.TotalNotCounted, nil
+	}
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryListTotal, where), args...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CompetitionDao List GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	return results, total, nil
+}
+
+func (d *competitionDao) generateListWhereClause(req *ListCompetitionInput) (string, []interface{}) {
+	w := helpers.NewWhere()
+	w.AppendWhereAtStart()
+	w.Where("true") // add this just in case we do not have any param passed
+	w.Conditions(req.Filter.Conditions())
+
+	if req.Mine {
+		w.Where("id IN (SELECT competition_id FROM memberships WHERE user_id = ?)", req.UserID)
+	}
+
+	return w.String()
+}
+
+func (d *competitionDao) Delete(ctx context.Context, id int64) error {
+	defer metrics.TimeQuery("CompetitionDao", "Delete")()
+	ctx, span := tracing.Start(ctx, "CompetitionDao.Delete")
+	defer span.End()
+
+	_, err := footy_db.Client.ExecContext(ctx, queryDelete, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CompetitionDao Delete Exec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package competitions
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var (
+	testCreatedAt = time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	testColumns   = []string{"id", "name", "owner_user_id", "points_exact", "points_goal_difference", "points_result", "created_at"}
+)
+
+func TestCompetitionDao_Create(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedID  int64
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO competitions").
+					WithArgs("Office", 1, 3, 2, 1, testCreatedAt).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "error LastInsertId",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO competitions").
+					WithArgs("Office", 1, 3, 2, 1, testCreatedAt).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("test LastInsertId")))
+			},
+			expectedErr: errors.New("test LastInsertId"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO competitions").
+					WithArgs("Office", 1, 3, 2, 1, testCreatedAt).
+					WillReturnResult(sqlmock.NewResult(4, 1))
+			},
+			expectedID:  4,
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			competition := &Competition{
+				Name:                 "Office",
+				OwnerUserID:          1,
+				PointsExact:          3,
+				PointsGoalDifference: 2,
+				PointsResult:         1,
+				CreatedAt:            testCreatedAt,
+			}
+			err = CompetitionDao.Create(context.Background(), competition)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedID, competition.ID)
+		})
+	}
+}
+
+func TestCompetitionDao_Update(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE competitions SET").
+					WithArgs("Office", 5, 2, 1, 1).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE competitions SET").
+					WithArgs("Office", 5, 2, 1, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = CompetitionDao.Update(context.Background(), &UpdateCompetitionInput{
+				ID:                   1,
+				Name:                 "Office",
+				PointsExact:          5,
+				PointsGoalDifference: 2,
+				PointsResult:         1,
+			})
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestCompetitionDao_FindByID(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *Competition
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM competitions").
+					WithArgs(1).
+					WillReturnError(errors.New("test Get"))
+			},
+			expectedErr: errors.New("test Get"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM competitions").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(testColumns).AddRow(1, "Office", 1, 3, 2, 1, testCreatedAt))
+			},
+			expectedRes: &Competition{
+				ID:                   1,
+				Name:                 "Office",
+				OwnerUserID:          1,
+				PointsExact:          3,
+				PointsGoalDifference: 2,
+				PointsResult:         1,
+				CreatedAt:            testCreatedAt,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := CompetitionDao.FindByID(context.Background(), 1)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestCompetitionDao_List(t *testing.T) {
+	testCases := []struct {
+		title         string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []Competition
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM competitions").
+					WithArgs("%Office%", 1).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM competitions").
+					WithArgs("%Office%", 1).
+					WillReturnRows(sqlmock.NewRows(testColumns).AddRow(1, "Office", 1, 3, 2, 1, testCreatedAt))
+				m.ExpectQuery("SELECT (.+) FROM competitions").
+					WithArgs("%Office%", 1).
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM competitions").
+					WithArgs("%Office%", 1).
+					WillReturnRows(sqlmock.NewRows(testColumns).AddRow(1, "Office", 1, 3, 2, 1, testCreatedAt))
+				m.ExpectQuery("SELECT (.+) FROM competitions").
+					WithArgs("%Office%", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes: []Competition{
+				{
+					ID:                   1,
+					Name:                 "Office",
+					OwnerUserID:          1,
+					PointsExact:          3,
+					PointsGoalDifference: 2,
+					PointsResult:         1,
+					CreatedAt:            testCreatedAt,
+				},
+			},
+			expectedTotal: 1,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			query, errs := filter.Parse(url.Values{"name": {"Office"}}, Competition{})
+			assert.Nil(t, errs)
+
+			res, total, err := CompetitionDao.List(context.Background(), &ListCompetitionInput{
+				Mine:   true,
+				UserID: 1,
+				Filter: *query,
+			})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestCompetitionDao_Delete(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("DELETE FROM competitions").
+					WithArgs(1).
+					WillReturnError(errors.New("test Exec"))
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("DELETE FROM competitions").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = CompetitionDao.Delete(context.Background(), 1)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
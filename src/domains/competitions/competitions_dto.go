@@ -0,0 +1,40 @@
+package competitions
+
+import (
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"time"
+)
+
+type Competition struct {
+	ID                   int64     `json:"id" db:"id" filter:"eq,ne,in,gt,gte,lt,lte,sort"`
+	Name                 string    `json:"name" db:"name" filter:"like,eq,ne,in,sort,default"`
+	OwnerUserID          int64     `json:"owner_user_id" db:"owner_user_id" filter:"eq,ne,in"`
+	PointsExact          int64     `json:"points_exact" db:"points_exact"`
+	PointsGoalDifference int64     `json:"points_goal_difference" db:"points_goal_difference"`
+	PointsResult         int64     `json:"points_result" db:"points_result"`
+	CreatedAt            time.Time `json:"created_at" db:"created_at" filter:"gt,gte,lt,lte,sort"`
+}
+
+type CompetitionInput struct {
+	Name                 string `json:"name" form:"name" validate:"required,max=100"`
+	PointsExact          int64  `json:"points_exact" form:"points_exact" validate:"required,min=1"`
+	PointsGoalDifference int64  `json:"points_goal_difference" form:"points_goal_difference" validate:"min=0"`
+	PointsResult         int64  `json:"points_result" form:"points_result" validate:"required,min=1"`
+}
+
+type UpdateCompetitionInput struct {
+	ID                   int64  `json:"-" form:"-" db:"id"`
+	Name                 string `json:"name" form:"name" db:"name" validate:"required,max=100"`
+	PointsExact          int64  `json:"points_exact" form:"points_exact" db:"points_exact" validate:"required,min=1"`
+	PointsGoalDifference int64  `json:"points_goal_difference" form:"points_goal_difference" db:"points_goal_difference" validate:"min=0"`
+	PointsResult         int64  `json:"points_result" form:"points_result" db:"points_result" validate:"required,min=1"`
+}
+
+// ListCompetitionInput filters on the fields of Competition, see its filter tags
+type ListCompetitionInput struct {
+	Mine    bool         `json:"mine" form:"mine"`
+	UserID  int64        `json:"-" form:"-"`
+	Page    int64        `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage int64        `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+	Filter  filter.Query `json:"-" form:"-"`
+}
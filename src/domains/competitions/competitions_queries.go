@@ -0,0 +1,34 @@
+package competitions
+
+const (
+	queryCreate = `INSERT INTO competitions(
+		name,
+		owner_user_id,
+		points_exact,
+		points_goal_difference,
+		points_result,
+		created_at)
+	VALUES (
+		:name,
+		:owner_user_id,
+		:points_exact,
+		:points_goal_difference,
+		:points_result,
+		:created_at)`
+
+	queryUpdate = `UPDATE competitions
+	  SET
+		name = :name,
+		points_exact = :points_exact,
+		points_goal_difference = :points_goal_difference,
+		points_result = :points_result
+	  WHERE
+		id = :id`
+
+	queryFindByID = `SELECT * FROM competitions WHERE id = ? LIMIT 1`
+
+	queryList      = `SELECT %s FROM competitions %s ORDER BY %s %s`
+	queryListTotal = `SELECT count(id) FROM competitions %s`
+
+	queryDelete = `DELETE FROM competitions WHERE id = ?`
+)
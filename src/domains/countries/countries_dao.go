@@ -1,80 +1,131 @@
 package countries
 
 import (
+	"context"
 	"fmt"
 	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
 	"github.com/development-raul/footy-predictor/src/utils/helpers"
 	"github.com/development-raul/footy-predictor/src/utils/pagination"
 	"github.com/development-raul/footy-predictor/src/zlog"
-	"strings"
 )
 
 type CountryDaoI interface {
-	Create(country *Country) error
-	Update(country *UpdateCountryInput) error
-	FindByID(id int64) (*CountryOutput, error)
-	List(req *ListCountryInput) ([]CountryOutput, int64, error)
-	Delete(id int64) error
+	Create(ctx context.Context, country *Country) error
+	Upsert(ctx context.Context, country *Country) error
+	// Update applies when the country is still at country.Version and sets it to the new version, otherwise it
+	// returns dberror.ErrVersionMismatch
+	Update(ctx context.Context, country *UpdateCountryInput) error
+	FindByID(ctx context.Context, id int64) (*CountryOutput, error)
+	List(ctx context.Context, req *ListCountryInput) ([]CountryOutput, int64, error)
+	// Delete applies when the country is still at version, otherwise it returns dberror.ErrVersionMismatch
+	Delete(ctx context.Context, id int64, version int64) error
 }
 type countryDao struct{}
 
 var CountryDao CountryDaoI = &countryDao{}
 
-func (d *countryDao) Create(country *Country) error {
-	res, err := footy_db.Client.NamedExec(queryCreate, country)
+func (d *countryDao) Create(ctx context.Context, country *Country) error {
+	defer metrics.TimeQuery("CountryDao", "Create")()
+	ctx, span := tracing.Start(ctx, "CountryDao.Create")
+	defer span.End()
+
+	res, err := footy_db.DB(ctx).NamedExecContext(ctx, queryCreate, country)
 	if err != nil {
-		zlog.Logger.Error("CountryDao Create NamedExec", err)
-		return err
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CountryDao Create NamedExec", "error", err)
+		return dberror.Wrap(err)
 	}
 	id, err := res.LastInsertId()
 	if err != nil {
-		zlog.Logger.Error("CountryDao Create LastInsertId", err)
-		return err
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CountryDao Create LastInsertId", "error", err)
+		return dberror.Wrap(err)
 	}
 	country.ID = id
 	return nil
 }
 
-func (d *countryDao) Update(country *UpdateCountryInput) error {
-	_, err := footy_db.Client.NamedExec(queryUpdate, country)
+func (d *countryDao) Upsert(ctx context.Context, country *Country) error {
+	defer metrics.TimeQuery("CountryDao", "Upsert")()
+	ctx, span := tracing.Start(ctx, "CountryDao.Upsert")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryUpsert, country)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CountryDao Upsert NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *countryDao) Update(ctx context.Context, country *UpdateCountryInput) error {
+	defer metrics.TimeQuery("CountryDao", "Update")()
+	ctx, span := tracing.Start(ctx, "CountryDao.Update")
+	defer span.End()
+
+	res, err := footy_db.DB(ctx).NamedExecContext(ctx, queryUpdate, country)
 	if err != nil {
-		zlog.Logger.Error("CountryDao Update NamedExec", err)
-		return err
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CountryDao Update NamedExec", "error", err)
+		return dberror.Wrap(err)
 	}
+	// The version always changes, so nothing is updated only when the country is not at the expected version
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return dberror.ErrVersionMismatch
+	}
+	country.Version++
 	return nil
 }
 
-func (d *countryDao) FindByID(id int64) (*CountryOutput, error) {
+func (d *countryDao) FindByID(ctx context.Context, id int64) (*CountryOutput, error) {
+	defer metrics.TimeQuery("CountryDao", "FindByID")()
+	ctx, span := tracing.Start(ctx, "CountryDao.FindByID")
+	defer span.End()
+
 	var result CountryOutput
 
-	err := footy_db.Client.Get(&result, queryFindByID, id)
+	err := footy_db.DB(ctx).GetContext(ctx, &result, queryFindByID, id)
 	if err != nil {
-		zlog.Logger.Error("CountryDao FindByID Get", err)
-		return nil, err
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CountryDao FindByID Get", "error", err)
+		return nil, dberror.Wrap(err)
 	}
 	return &result, nil
 }
 
-func (d *countryDao) List(req *ListCountryInput) ([]CountryOutput, int64, error) {
+func (d *countryDao) List(ctx context.Context, req *ListCountryInput) ([]CountryOutput, int64, error) {
+	defer metrics.TimeQuery("CountryDao", "List")()
+	ctx, span := tracing.Start(ctx, "CountryDao.List")
+	defer span.End()
+
 	var results []CountryOutput
 	// Create where, limit and order by clauses
 	where, args := d.generateListWhereClause(req)
-	limit := pagination.GeneratePaginationQuery(req.Page, req.PerPage)
-	order := pagination.GeneratePaginationSort("name ASC", req.OrderBy, req.Order)
-	query := fmt.Sprintf(queryList, where, order, limit)
+	page := req.Filter.Page(req.Page, req.PerPage, results)
+	query := fmt.Sprintf(queryList, page.Select("*"), page.Where(where), page.OrderBy(), page.Limit())
 
 	// Get the records
-	err := footy_db.Client.Select(&results, query, args...)
+	err := footy_db.Client.SelectContext(ctx, &results, query, page.Args(args)...)
 	if err != nil {
-		zlog.Logger.Error("CountryDao List Select", err)
-		return nil, 0, err
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CountryDao List Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
 	}
+	results = page.Rows(results).([]CountryOutput)
 
-	// Get total records so we can use them for pagination
-	total, err := pagination.GetTableTotalRowsArgs(fmt.Sprintf(queryListTotal, where), args...)
+	// Get total records so we can use them for pagination, unless the client asked to skip the count
+	if !req.Filter.Total() {
+		return results, pagination.TotalNotCounted, nil
+	}
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryListTotal, where), args...)
 	if err != nil {
-		zlog.Logger.Error("CountryDao List GetTableTotalRowsArgs", err)
-		return nil, 0, err
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CountryDao List GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
 	}
 
 	return results, total, nil
@@ -84,27 +135,25 @@ func (d *countryDao) generateListWhereClause(req *ListCountryInput) (string, []i
 	w := helpers.NewWhere()
 	w.AppendWhereAtStart()
 	w.Where("true") // add this just in case we do not have any param passed
-
-	if strings.TrimSpace(req.Code) != "" {
-		w.Where("code = ?", req.Code)
-	}
-
-	if strings.TrimSpace(req.Name) != "" {
-		w.CustomWhere(" AND (name LIKE ?)", fmt.Sprintf("%%%s%%", req.Name))
-	}
-
-	if req.Active {
-		w.Where("active = 1")
-	}
+	w.Conditions(req.Filter.Conditions())
 
 	return w.String()
 }
 
-func (d *countryDao) Delete(id int64) error {
-	_, err := footy_db.Client.Exec(queryDelete, id)
+func (d *countryDao) Delete(ctx context.Context, id int64, version int64) error {
+	defer metrics.TimeQuery("CountryDao", "Delete")()
+	ctx, span := tracing.Start(ctx, "CountryDao.Delete")
+	defer span.End()
+
+	res, err := footy_db.DB(ctx).ExecContext(ctx, queryDelete, id, version)
 	if err != nil {
-		zlog.Logger.Error("CountryDao Delete Exec", err)
-		return err
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CountryDao Delete Exec", "error", err)
+		return dberror.Wrap(err)
+	}
+	// Nothing is deleted when the record does not exist or is at another version
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return dberror.ErrVersionMismatch
 	}
 	return nil
 }
@@ -1,11 +1,20 @@
 package countries
 
 import (
+	"context"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"net/url"
 	"testing"
 )
 
@@ -28,6 +37,22 @@ func TestCountryDao_Create(t *testing.T) {
 			},
 			expectedErr: errors.New("test NamedExec"),
 		},
+		{
+			title: "error duplicate name",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO countries").
+					WithArgs(
+						"code",
+						"name",
+						"flag",
+						true).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'name' for key 'countries_name_unique'"})
+			},
+			expectedErr: &dberror.Error{
+				Kind: dberror.ErrDuplicate,
+				Err:  &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'name' for key 'countries_name_unique'"},
+			},
+		},
 		{
 			title: "error LastInsertId",
 			funcMock: func(m sqlmock.Sqlmock) {
@@ -66,7 +91,7 @@ func TestCountryDao_Create(t *testing.T) {
 			footy_db.Client = sqlx.NewDb(db, "sqlmock")
 			testCase.funcMock(mock)
 
-			err = CountryDao.Create(&Country{
+			err = CountryDao.Create(context.Background(), &Country{
 				Code:   "code",
 				Name:   "name",
 				Flag:   "flag",
@@ -80,9 +105,10 @@ func TestCountryDao_Create(t *testing.T) {
 
 func TestCountryDao_Update(t *testing.T) {
 	testCases := []struct {
-		title       string
-		funcMock    func(sqlmock.Sqlmock)
-		expectedErr error
+		title           string
+		funcMock        func(sqlmock.Sqlmock)
+		expectedVersion int64
+		expectedErr     error
 	}{
 		{
 			title: "error Client.NamedExec",
@@ -93,10 +119,28 @@ func TestCountryDao_Update(t *testing.T) {
 						"name",
 						"flag",
 						true,
-						1).
+						1,
+						2).
 					WillReturnError(errors.New("test NamedExec"))
 			},
-			expectedErr: errors.New("test NamedExec"),
+			expectedVersion: 2,
+			expectedErr:     errors.New("test NamedExec"),
+		},
+		{
+			title: "error version mismatch",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE countries SET").
+					WithArgs(
+						"code",
+						"name",
+						"flag",
+						true,
+						1,
+						2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedVersion: 2,
+			expectedErr:     dberror.ErrVersionMismatch,
 		},
 		{
 			title: "success",
@@ -107,10 +151,12 @@ func TestCountryDao_Update(t *testing.T) {
 						"name",
 						"flag",
 						true,
-						1).
+						1,
+						2).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
-			expectedErr: nil,
+			expectedVersion: 3,
+			expectedErr:     nil,
 		},
 	}
 
@@ -124,14 +170,17 @@ func TestCountryDao_Update(t *testing.T) {
 			footy_db.Client = sqlx.NewDb(db, "sqlmock")
 			testCase.funcMock(mock)
 
-			err = CountryDao.Update(&UpdateCountryInput{
-				ID:     1,
-				Code:   "code",
-				Name:   "name",
-				Flag:   "flag",
-				Active: true,
-			})
+			req := UpdateCountryInput{
+				ID:      1,
+				Version: 2,
+				Code:    "code",
+				Name:    "name",
+				Flag:    "flag",
+				Active:  true,
+			}
+			err = CountryDao.Update(context.Background(), &req)
 
+			assert.Equal(t, testCase.expectedVersion, req.Version)
 			assert.Equal(t, testCase.expectedErr, err)
 		})
 	}
@@ -193,7 +242,7 @@ func TestCountryDao_FindByID(t *testing.T) {
 			footy_db.Client = sqlx.NewDb(db, "sqlmock")
 			testCase.funcMock(mock)
 
-			res, err := CountryDao.FindByID(1)
+			res, err := CountryDao.FindByID(context.Background(), 1)
 
 			assert.Equal(t, testCase.expectedRes, res)
 			assert.Equal(t, testCase.expectedErr, err)
@@ -201,6 +250,29 @@ func TestCountryDao_FindByID(t *testing.T) {
 	}
 }
 
+func TestCountryDao_FindByID_RequestLogger(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	footy_db.Client = sqlx.NewDb(db, "sqlmock")
+	mock.ExpectQuery("SELECT (.+) FROM countries").
+		WithArgs(1).
+		WillReturnError(errors.New("test Get"))
+	core, logs := observer.New(zapcore.DebugLevel)
+	ctx := zlog.NewContext(context.Background(), zap.New(core).Sugar().With("request_id", "req-1"))
+
+	_, err = CountryDao.FindByID(ctx, 1)
+
+	assert.Equal(t, errors.New("test Get"), err)
+	// The error is logged with the fields of the request
+	entries := logs.FilterMessage("CountryDao FindByID Get").All()
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "req-1", entries[0].ContextMap()["request_id"])
+	}
+}
+
 func TestCountryDao_List(t *testing.T) {
 	testCases := []struct {
 		title         string
@@ -213,7 +285,7 @@ func TestCountryDao_List(t *testing.T) {
 			title: "error Client.Select",
 			funcMock: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery("SELECT (.+) FROM countries").
-					WithArgs("code", "%name%").
+					WithArgs(true, "code", "%name%").
 					WillReturnError(errors.New("error Select"))
 			},
 			expectedErr: errors.New("error Select"),
@@ -222,7 +294,7 @@ func TestCountryDao_List(t *testing.T) {
 			title: "error GetTableTotalRowsArgs",
 			funcMock: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery("SELECT (.+) FROM countries").
-					WithArgs("code", "%name%").
+					WithArgs(true, "code", "%name%").
 					WillReturnRows(sqlmock.NewRows([]string{
 						"id",
 						"code",
@@ -237,7 +309,7 @@ func TestCountryDao_List(t *testing.T) {
 						1,
 					))
 				m.ExpectQuery("SELECT (.+) FROM countries").
-					WithArgs("code", "%name%").
+					WithArgs(true, "code", "%name%").
 					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
 			},
 			expectedErr: errors.New("error GetTableTotalRowsArgs"),
@@ -246,7 +318,7 @@ func TestCountryDao_List(t *testing.T) {
 			title: "success",
 			funcMock: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery("SELECT (.+) FROM countries").
-					WithArgs("code", "%name%").
+					WithArgs(true, "code", "%name%").
 					WillReturnRows(sqlmock.NewRows([]string{
 						"id",
 						"code",
@@ -261,7 +333,7 @@ func TestCountryDao_List(t *testing.T) {
 						1,
 					))
 				m.ExpectQuery("SELECT (.+) FROM countries").
-					WithArgs("code", "%name%").
+					WithArgs(true, "code", "%name%").
 					WillReturnRows(sqlmock.NewRows([]string{
 						"total",
 					}).AddRow(1))
@@ -290,11 +362,10 @@ func TestCountryDao_List(t *testing.T) {
 			footy_db.Client = sqlx.NewDb(db, "sqlmock")
 			testCase.funcMock(mock)
 
-			res, total, err := CountryDao.List(&ListCountryInput{
-				Code:   "code",
-				Name:   "name",
-				Active: true,
-			})
+			query, errs := filter.Parse(url.Values{"code": {"code"}, "name": {"name"}, "active": {"true"}}, CountryOutput{})
+			assert.Nil(t, errs)
+
+			res, total, err := CountryDao.List(context.Background(), &ListCountryInput{Filter: *query})
 
 			assert.Equal(t, testCase.expectedRes, res)
 			assert.Equal(t, testCase.expectedTotal, total)
@@ -313,16 +384,25 @@ func TestCountryDao_Delete(t *testing.T) {
 			title: "error Client.Exec",
 			funcMock: func(m sqlmock.Sqlmock) {
 				m.ExpectExec("DELETE FROM countries").
-					WithArgs(1).
+					WithArgs(1, 2).
 					WillReturnError(errors.New("test Exec"))
 			},
 			expectedErr: errors.New("test Exec"),
 		},
+		{
+			title: "error version mismatch",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("DELETE FROM countries").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: dberror.ErrVersionMismatch,
+		},
 		{
 			title: "success",
 			funcMock: func(m sqlmock.Sqlmock) {
 				m.ExpectExec("DELETE FROM countries").
-					WithArgs(1).
+					WithArgs(1, 2).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			expectedErr: nil,
@@ -339,7 +419,7 @@ func TestCountryDao_Delete(t *testing.T) {
 			footy_db.Client = sqlx.NewDb(db, "sqlmock")
 			testCase.funcMock(mock)
 
-			err = CountryDao.Delete(1)
+			err = CountryDao.Delete(context.Background(), 1, 2)
 
 			assert.Equal(t, testCase.expectedErr, err)
 		})
@@ -1,5 +1,10 @@
 package countries
 
+import (
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"time"
+)
+
 type Country struct {
 	ID     int64  `db:"id"`
 	Code   string `db:"code"`
@@ -15,28 +20,54 @@ type CountryInput struct {
 	Active bool   `json:"active" form:"active"`
 }
 
+// ListCountryInput filters on the fields of CountryOutput, see its filter tags
 type ListCountryInput struct {
-	Code    string `json:"code" form:"code"`
-	Name    string `json:"name" form:"name"`
-	Active  bool   `json:"active" form:"active"`
-	Order   string `json:"order" form:"order" validate:"omitempty,oneof=desc asc"`
-	OrderBy string `json:"order_by" form:"order_by,omitempty" validate:"omitempty,oneof=id code name active"`
-	Page    int64  `json:"page" form:"page"`
-	PerPage int64  `json:"per_page" form:"per_page"`
+	Page    int64        `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage int64        `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+	Filter  filter.Query `json:"-" form:"-"`
+}
+
+// BulkCreateCountryInput creates every item, mode is atomic (default) or best_effort, see the bulk package
+type BulkCreateCountryInput struct {
+	Mode  string         `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+	Items []CountryInput `json:"items" validate:"required,min=1,max=500"`
+}
+
+// BulkUpdateCountryItem updates the country id, when version is set the item fails unless the country is still
+// at that version
+type BulkUpdateCountryItem struct {
+	ID      int64  `json:"id" validate:"required"`
+	Version int64  `json:"version"`
+	Code    string `json:"code"`
+	Name    string `json:"name" validate:"required"`
+	Flag    string `json:"flag"`
+	Active  bool   `json:"active"`
+}
+
+// BulkUpdateCountryInput updates the country of every item, mode is atomic (default) or best_effort
+type BulkUpdateCountryInput struct {
+	Mode  string                  `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+	Items []BulkUpdateCountryItem `json:"items" validate:"required,min=1,max=500"`
 }
 
+// UpdateCountryInput replaces the fields of a country. Version is the version of the If-Match header, the update
+// only applies if the country is still at that version and Version holds the new one afterwards
 type UpdateCountryInput struct {
-	ID     int64  `json:"-" form:"-" db:"id"`
-	Code   string `json:"code" form:"code" db:"code"`
-	Name   string `json:"name" form:"name" db:"name" validate:"required"`
-	Flag   string `json:"flag" form:"flag" db:"flag"`
-	Active bool   `json:"active" form:"active" db:"active"`
+	ID      int64  `json:"-" form:"-" db:"id"`
+	Version int64  `json:"-" form:"-" db:"version"`
+	Code    string `json:"code" form:"code" db:"code"`
+	Name    string `json:"name" form:"name" db:"name" validate:"required"`
+	Flag    string `json:"flag" form:"flag" db:"flag"`
+	Active  bool   `json:"active" form:"active" db:"active"`
 }
 
+// CountryOutput is a country with its version, bumped by every update, the ETag of the country is its version
 type CountryOutput struct {
-	ID     int64  `json:"id" db:"id"`
-	Code   string `json:"code" db:"code"`
-	Name   string `json:"name" db:"name"`
-	Flag   string `json:"flag" db:"flag"`
-	Active bool   `json:"active" db:"active"`
+	ID        int64     `json:"id" db:"id" filter:"eq,ne,in,gt,gte,lt,lte,sort"`
+	Code      string    `json:"code" db:"code" filter:"eq,ne,in,like,sort"`
+	Name      string    `json:"name" db:"name" filter:"like,eq,ne,in,sort,default"`
+	Flag      string    `json:"flag" db:"flag"`
+	Active    bool      `json:"active" db:"active" filter:"eq,sort"`
+	Version   int64     `json:"version" db:"version"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" filter:"gt,gte,lt,lte,sort"`
 }
@@ -12,19 +12,38 @@ const (
 		:flag,
 		:active)`
 
+	// Countries are identified by their unique name when imported, the version is only bumped when a field
+	// changes. It is assigned first since MySQL evaluates the assignments in order
+	queryUpsert = `INSERT INTO countries(
+		code,
+		name,
+		flag,
+		active)
+	VALUES (
+		:code,
+		:name,
+		:flag,
+		:active)
+	ON DUPLICATE KEY UPDATE
+		version = IF(code = VALUES(code) AND flag = VALUES(flag) AND active = VALUES(active), version, version + 1),
+		code = VALUES(code),
+		flag = VALUES(flag),
+		active = VALUES(active)`
+
 	queryUpdate = `UPDATE countries
 	  SET
 		code = :code,
 		name = :name,
 		flag = :flag,
-		active = :active
+		active = :active,
+		version = version + 1
 	  WHERE
-		id = :id`
+		id = :id AND version = :version`
 
 	queryFindByID = `SELECT * FROM countries WHERE id = ? LIMIT 1`
 
-	queryList      = `SELECT * FROM countries %s ORDER BY %s %s`
+	queryList      = `SELECT %s FROM countries %s ORDER BY %s %s`
 	queryListTotal = `SELECT count(id) FROM countries %s`
 
-	queryDelete = `DELETE FROM countries WHERE id = ?`
+	queryDelete = `DELETE FROM countries WHERE id = ? AND version = ?`
 )
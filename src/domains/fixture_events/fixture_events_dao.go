@@ -0,0 +1,62 @@
+package fixture_events
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type FixtureEventDaoI interface {
+	Replace(ctx context.Context, fixtureID int64, events []Event) error
+	ListByFixture(ctx context.Context, fixtureID int64) ([]Event, error)
+}
+
+type fixtureEventDao struct{}
+
+var FixtureEventDao FixtureEventDaoI = &fixtureEventDao{}
+
+// Replace swaps the events of a fixture for the given ones in a single transaction, so a fixture synced again
+// never mixes events of two syncs
+func (d *fixtureEventDao) Replace(ctx context.Context, fixtureID int64, events []Event) error {
+	defer metrics.TimeQuery("FixtureEventDao", "Replace")()
+	ctx, span := tracing.Start(ctx, "FixtureEventDao.Replace")
+	defer span.End()
+
+	err := footy_db.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := footy_db.DB(ctx).ExecContext(ctx, queryDeleteByFixture, fixtureID); err != nil {
+			return err
+		}
+		for i := range events {
+			events[i].FixtureID = fixtureID
+			if _, err := footy_db.DB(ctx).NamedExecContext(ctx, queryCreate, &events[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureEventDao Replace Transaction", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *fixtureEventDao) ListByFixture(ctx context.Context, fixtureID int64) ([]Event, error) {
+	defer metrics.TimeQuery("FixtureEventDao", "ListByFixture")()
+	ctx, span := tracing.Start(ctx, "FixtureEventDao.ListByFixture")
+	defer span.End()
+
+	var results []Event
+
+	err := footy_db.Client.SelectContext(ctx, &results, queryListByFixture, fixtureID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureEventDao ListByFixture Select", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return results, nil
+}
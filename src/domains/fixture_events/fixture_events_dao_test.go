@@ -0,0 +1,162 @@
+package fixture_events
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testColumns = []string{"fixture_id", "sequence", "elapsed", "extra", "team_id", "team_name", "player_id", "player_name",
+	"assist_id", "assist_name", "type", "detail", "comments"}
+
+func testEvent() Event {
+	extra, playerID, assistID := int64(2), int64(154), int64(278)
+	playerName, assistName := "L. Messi", "K. Mbappé"
+	return Event{
+		FixtureID:  10,
+		Sequence:   1,
+		Elapsed:    45,
+		Extra:      &extra,
+		TeamID:     85,
+		TeamName:   "Paris Saint Germain",
+		PlayerID:   &playerID,
+		PlayerName: &playerName,
+		AssistID:   &assistID,
+		AssistName: &assistName,
+		Type:       TypeGoal,
+		Detail:     "Normal Goal",
+	}
+}
+
+func TestFixtureEventDao_Replace(t *testing.T) {
+	eventArgs := []driver.Value{10, 1, 45, 2, 85, "Paris Saint Germain", 154, "L. Messi", 278, "K. Mbappé", "goal", "Normal Goal", nil}
+
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error delete Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM fixture_events").
+					WithArgs(10).
+					WillReturnError(errors.New("test Exec"))
+				m.ExpectRollback()
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "error event NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM fixture_events").
+					WithArgs(10).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				m.ExpectExec("INSERT INTO fixture_events").
+					WithArgs(eventArgs...).
+					WillReturnError(errors.New("test NamedExec"))
+				m.ExpectRollback()
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM fixture_events").
+					WithArgs(10).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				m.ExpectExec("INSERT INTO fixture_events").
+					WithArgs(eventArgs...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			event := testEvent()
+			event.FixtureID = 0
+			err = FixtureEventDao.Replace(context.Background(), 10, []Event{event})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFixtureEventDao_ListByFixture(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes []Event
+		expectedErr error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixture_events").
+					WithArgs(10).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixture_events").
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(10, 1, 45, 2, 85, "Paris Saint Germain", 154, "L. Messi", 278, "K. Mbappé", "goal", "Normal Goal", nil))
+			},
+			expectedRes: []Event{testEvent()},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := FixtureEventDao.ListByFixture(context.Background(), 10)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestTypeOf(t *testing.T) {
+	testCases := map[string]string{
+		"Goal":  TypeGoal,
+		"Card":  TypeCard,
+		"subst": TypeSubstitution,
+		"Var":   TypeVar,
+	}
+	for apiType, expected := range testCases {
+		assert.Equal(t, expected, TypeOf(apiType), apiType)
+	}
+}
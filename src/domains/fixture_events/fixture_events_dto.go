@@ -0,0 +1,40 @@
+package fixture_events
+
+import "strings"
+
+// The types of the events
+const (
+	TypeGoal         = "goal"
+	TypeCard         = "card"
+	TypeSubstitution = "substitution"
+	TypeVar          = "var"
+)
+
+// Event is a goal, card, substitution or VAR decision of a fixture, Sequence is its order in the fixture. The detail
+// is the one of API Sports e.g. "Normal Goal", "Own Goal", "Penalty", "Yellow Card" or "Goal cancelled". The assist
+// of a goal is the player who gave the pass, for a substitution API Sports gives the two players of the change as
+// the player and the assist
+type Event struct {
+	FixtureID  int64   `json:"fixture_id" db:"fixture_id"`
+	Sequence   int64   `json:"sequence" db:"sequence"`
+	Elapsed    int64   `json:"elapsed" db:"elapsed"`
+	Extra      *int64  `json:"extra" db:"extra"`
+	TeamID     int64   `json:"team_id" db:"team_id"`
+	TeamName   string  `json:"team_name" db:"team_name"`
+	PlayerID   *int64  `json:"player_id" db:"player_id"`
+	PlayerName *string `json:"player_name" db:"player_name"`
+	AssistID   *int64  `json:"assist_id" db:"assist_id"`
+	AssistName *string `json:"assist_name" db:"assist_name"`
+	Type       string  `json:"type" db:"type"`
+	Detail     string  `json:"detail" db:"detail"`
+	Comments   *string `json:"comments" db:"comments"`
+}
+
+// TypeOf returns the type of an API Sports event type: Goal, Card, subst or Var
+func TypeOf(apiType string) string {
+	t := strings.ToLower(apiType)
+	if t == "subst" {
+		return TypeSubstitution
+	}
+	return t
+}
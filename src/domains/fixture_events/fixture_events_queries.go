@@ -0,0 +1,36 @@
+package fixture_events
+
+const (
+	queryDeleteByFixture = `DELETE FROM fixture_events WHERE fixture_id = ?`
+
+	queryCreate = `INSERT INTO fixture_events(
+		fixture_id,
+		sequence,
+		elapsed,
+		extra,
+		team_id,
+		team_name,
+		player_id,
+		player_name,
+		assist_id,
+		assist_name,
+		type,
+		detail,
+		comments)
+	VALUES (
+		:fixture_id,
+		:sequence,
+		:elapsed,
+		:extra,
+		:team_id,
+		:team_name,
+		:player_id,
+		:player_name,
+		:assist_id,
+		:assist_name,
+		:type,
+		:detail,
+		:comments)`
+
+	queryListByFixture = `SELECT * FROM fixture_events WHERE fixture_id = ? ORDER BY sequence`
+)
@@ -0,0 +1,91 @@
+package fixture_lineups
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type FixtureLineupDaoI interface {
+	Replace(ctx context.Context, fixtureID int64, lineups []Lineup) error
+	ListByFixture(ctx context.Context, fixtureID int64) ([]Lineup, error)
+	ListPlayers(ctx context.Context, fixtureID int64) ([]Player, error)
+}
+
+type fixtureLineupDao struct{}
+
+var FixtureLineupDao FixtureLineupDaoI = &fixtureLineupDao{}
+
+// Replace swaps the lineups of a fixture and their players for the given ones in a single transaction. The
+// players are numbered in the order of the starters then the substitutes of their lineup
+func (d *fixtureLineupDao) Replace(ctx context.Context, fixtureID int64, lineups []Lineup) error {
+	defer metrics.TimeQuery("FixtureLineupDao", "Replace")()
+	ctx, span := tracing.Start(ctx, "FixtureLineupDao.Replace")
+	defer span.End()
+
+	err := footy_db.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := footy_db.DB(ctx).ExecContext(ctx, queryDeleteByFixture, fixtureID); err != nil {
+			return err
+		}
+		for i := range lineups {
+			lineup := &lineups[i]
+			lineup.FixtureID = fixtureID
+			if _, err := footy_db.DB(ctx).NamedExecContext(ctx, queryCreate, lineup); err != nil {
+				return err
+			}
+			sequence := int64(0)
+			for group, players := range [][]Player{lineup.StartXI, lineup.Substitutes} {
+				for j := range players {
+					sequence++
+					player := &players[j]
+					player.FixtureID, player.TeamID, player.Sequence, player.Starter = fixtureID, lineup.TeamID, sequence, group == 0
+					if _, err := footy_db.DB(ctx).NamedExecContext(ctx, queryCreatePlayer, player); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureLineupDao Replace Transaction", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *fixtureLineupDao) ListByFixture(ctx context.Context, fixtureID int64) ([]Lineup, error) {
+	defer metrics.TimeQuery("FixtureLineupDao", "ListByFixture")()
+	ctx, span := tracing.Start(ctx, "FixtureLineupDao.ListByFixture")
+	defer span.End()
+
+	var results []Lineup
+
+	err := footy_db.Client.SelectContext(ctx, &results, queryListByFixture, fixtureID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureLineupDao ListByFixture Select", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return results, nil
+}
+
+func (d *fixtureLineupDao) ListPlayers(ctx context.Context, fixtureID int64) ([]Player, error) {
+	defer metrics.TimeQuery("FixtureLineupDao", "ListPlayers")()
+	ctx, span := tracing.Start(ctx, "FixtureLineupDao.ListPlayers")
+	defer span.End()
+
+	var results []Player
+
+	err := footy_db.Client.SelectContext(ctx, &results, queryListPlayers, fixtureID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureLineupDao ListPlayers Select", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return results, nil
+}
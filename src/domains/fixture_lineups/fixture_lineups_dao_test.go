@@ -0,0 +1,207 @@
+package fixture_lineups
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testLineup() Lineup {
+	coachID, coachName := int64(4), "M. Pochettino"
+	goalkeeperID, goalkeeperNumber, goalkeeperPosition, goalkeeperGrid := int64(1622), int64(50), "G", "1:1"
+	substituteID, substitutePosition := int64(154), "F"
+	return Lineup{
+		TeamID:    85,
+		TeamName:  "Paris Saint Germain",
+		Formation: "4-3-3",
+		CoachID:   &coachID,
+		CoachName: &coachName,
+		StartXI: []Player{
+			{PlayerID: &goalkeeperID, PlayerName: "G. Donnarumma", Number: &goalkeeperNumber, Position: &goalkeeperPosition, Grid: &goalkeeperGrid},
+		},
+		Substitutes: []Player{
+			{PlayerID: &substituteID, PlayerName: "L. Messi", Position: &substitutePosition},
+		},
+	}
+}
+
+func TestFixtureLineupDao_Replace(t *testing.T) {
+	lineupArgs := []driver.Value{10, 85, "Paris Saint Germain", "4-3-3", 4, "M. Pochettino"}
+
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error delete Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM fixture_lineups").
+					WithArgs(10).
+					WillReturnError(errors.New("test Exec"))
+				m.ExpectRollback()
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "error player NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM fixture_lineups").
+					WithArgs(10).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("INSERT INTO fixture_lineups").
+					WithArgs(lineupArgs...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("INSERT INTO fixture_lineup_players").
+					WithArgs(10, 85, 1, 1622, "G. Donnarumma", 50, "G", "1:1", true).
+					WillReturnError(errors.New("test NamedExec"))
+				m.ExpectRollback()
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM fixture_lineups").
+					WithArgs(10).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("INSERT INTO fixture_lineups").
+					WithArgs(lineupArgs...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("INSERT INTO fixture_lineup_players").
+					WithArgs(10, 85, 1, 1622, "G. Donnarumma", 50, "G", "1:1", true).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("INSERT INTO fixture_lineup_players").
+					WithArgs(10, 85, 2, 154, "L. Messi", nil, "F", nil, false).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = FixtureLineupDao.Replace(context.Background(), 10, []Lineup{testLineup()})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFixtureLineupDao_ListByFixture(t *testing.T) {
+	columns := []string{"fixture_id", "team_id", "team_name", "formation", "coach_id", "coach_name"}
+	expected := testLineup()
+	expected.FixtureID, expected.StartXI, expected.Substitutes = 10, nil, nil
+
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes []Lineup
+		expectedErr error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixture_lineups").
+					WithArgs(10).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixture_lineups").
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(10, 85, "Paris Saint Germain", "4-3-3", 4, "M. Pochettino"))
+			},
+			expectedRes: []Lineup{expected},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := FixtureLineupDao.ListByFixture(context.Background(), 10)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestFixtureLineupDao_ListPlayers(t *testing.T) {
+	columns := []string{"fixture_id", "team_id", "sequence", "player_id", "player_name", "number", "position", "grid", "starter"}
+	expected := testLineup().StartXI[0]
+	expected.FixtureID, expected.TeamID, expected.Sequence, expected.Starter = 10, 85, 1, true
+
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes []Player
+		expectedErr error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixture_lineup_players").
+					WithArgs(10).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixture_lineup_players").
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(10, 85, 1, 1622, "G. Donnarumma", 50, "G", "1:1", true))
+			},
+			expectedRes: []Player{expected},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := FixtureLineupDao.ListPlayers(context.Background(), 10)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package fixture_lineups
+
+// Lineup is the lineup of a team in a fixture, the formation is e.g. "4-3-3". The starters and the substitutes are
+// filled from the players of the lineup
+type Lineup struct {
+	FixtureID   int64    `json:"fixture_id" db:"fixture_id"`
+	TeamID      int64    `json:"team_id" db:"team_id"`
+	TeamName    string   `json:"team_name" db:"team_name"`
+	Formation   string   `json:"formation" db:"formation"`
+	CoachID     *int64   `json:"coach_id" db:"coach_id"`
+	CoachName   *string  `json:"coach_name" db:"coach_name"`
+	StartXI     []Player `json:"start_xi" db:"-"`
+	Substitutes []Player `json:"substitutes" db:"-"`
+}
+
+// Player is a player of a lineup in the order API Sports lists it. The position is G, D, M or F and the grid is the
+// row:column of a starter on the pitch, the goalkeeper being 1:1
+type Player struct {
+	FixtureID  int64   `json:"-" db:"fixture_id"`
+	TeamID     int64   `json:"-" db:"team_id"`
+	Sequence   int64   `json:"-" db:"sequence"`
+	PlayerID   *int64  `json:"player_id" db:"player_id"`
+	PlayerName string  `json:"player_name" db:"player_name"`
+	Number     *int64  `json:"number" db:"number"`
+	Position   *string `json:"position" db:"position"`
+	Grid       *string `json:"grid" db:"grid"`
+	Starter    bool    `json:"-" db:"starter"`
+}
@@ -0,0 +1,47 @@
+package fixture_lineups
+
+const (
+	// The players of the lineups are deleted with them
+	queryDeleteByFixture = `DELETE FROM fixture_lineups WHERE fixture_id = ?`
+
+	queryCreate = `INSERT INTO fixture_lineups(
+		fixture_id,
+		team_id,
+		team_name,
+		formation,
+		coach_id,
+		coach_name)
+	VALUES (
+		:fixture_id,
+		:team_id,
+		:team_name,
+		:formation,
+		:coach_id,
+		:coach_name)`
+
+	queryCreatePlayer = `INSERT INTO fixture_lineup_players(
+		fixture_id,
+		team_id,
+		sequence,
+		player_id,
+		player_name,
+		number,
+		position,
+		grid,
+		starter)
+	VALUES (
+		:fixture_id,
+		:team_id,
+		:sequence,
+		:player_id,
+		:player_name,
+		:number,
+		:position,
+		:grid,
+		:starter)`
+
+	queryListByFixture = `SELECT fixture_id, team_id, team_name, formation, coach_id, coach_name
+	FROM fixture_lineups WHERE fixture_id = ? ORDER BY team_id`
+
+	queryListPlayers = `SELECT * FROM fixture_lineup_players WHERE fixture_id = ? ORDER BY team_id, sequence`
+)
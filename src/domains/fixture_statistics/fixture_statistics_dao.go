@@ -0,0 +1,61 @@
+package fixture_statistics
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type FixtureStatisticDaoI interface {
+	Replace(ctx context.Context, fixtureID int64, statistics []TeamStatistic) error
+	ListByFixture(ctx context.Context, fixtureID int64) ([]TeamStatistic, error)
+}
+
+type fixtureStatisticDao struct{}
+
+var FixtureStatisticDao FixtureStatisticDaoI = &fixtureStatisticDao{}
+
+// Replace swaps the statistics of a fixture for the given ones in a single transaction
+func (d *fixtureStatisticDao) Replace(ctx context.Context, fixtureID int64, statistics []TeamStatistic) error {
+	defer metrics.TimeQuery("FixtureStatisticDao", "Replace")()
+	ctx, span := tracing.Start(ctx, "FixtureStatisticDao.Replace")
+	defer span.End()
+
+	err := footy_db.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := footy_db.DB(ctx).ExecContext(ctx, queryDeleteByFixture, fixtureID); err != nil {
+			return err
+		}
+		for i := range statistics {
+			statistics[i].FixtureID = fixtureID
+			if _, err := footy_db.DB(ctx).NamedExecContext(ctx, queryCreate, &statistics[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureStatisticDao Replace Transaction", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *fixtureStatisticDao) ListByFixture(ctx context.Context, fixtureID int64) ([]TeamStatistic, error) {
+	defer metrics.TimeQuery("FixtureStatisticDao", "ListByFixture")()
+	ctx, span := tracing.Start(ctx, "FixtureStatisticDao.ListByFixture")
+	defer span.End()
+
+	var results []TeamStatistic
+
+	err := footy_db.Client.SelectContext(ctx, &results, queryListByFixture, fixtureID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureStatisticDao ListByFixture Select", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return results, nil
+}
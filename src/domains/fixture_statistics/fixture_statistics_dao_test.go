@@ -0,0 +1,158 @@
+package fixture_statistics
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testColumns = []string{"fixture_id", "team_id", "team_name", "shots", "shots_on_target", "shots_off_target", "blocked_shots",
+	"shots_inside_box", "shots_outside_box", "fouls", "corners", "offsides", "possession", "yellow_cards", "red_cards", "saves",
+	"passes", "passes_accurate", "expected_goals"}
+
+func testTeamStatistic() TeamStatistic {
+	possession, expectedGoals := int64(62), 1.87
+	return TeamStatistic{
+		FixtureID:       10,
+		TeamID:          85,
+		TeamName:        "Paris Saint Germain",
+		Shots:           14,
+		ShotsOnTarget:   6,
+		ShotsOffTarget:  5,
+		BlockedShots:    3,
+		ShotsInsideBox:  9,
+		ShotsOutsideBox: 5,
+		Fouls:           11,
+		Corners:         7,
+		Offsides:        2,
+		Possession:      &possession,
+		YellowCards:     2,
+		RedCards:        0,
+		Saves:           3,
+		Passes:          612,
+		PassesAccurate:  548,
+		ExpectedGoals:   &expectedGoals,
+	}
+}
+
+func testTeamStatisticRow() []driver.Value {
+	return []driver.Value{10, 85, "Paris Saint Germain", 14, 6, 5, 3, 9, 5, 11, 7, 2, 62, 2, 0, 3, 612, 548, 1.87}
+}
+
+func TestFixtureStatisticDao_Replace(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error delete Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM fixture_statistics").
+					WithArgs(10).
+					WillReturnError(errors.New("test Exec"))
+				m.ExpectRollback()
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "error statistic NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM fixture_statistics").
+					WithArgs(10).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec("INSERT INTO fixture_statistics").
+					WithArgs(testTeamStatisticRow()...).
+					WillReturnError(errors.New("test NamedExec"))
+				m.ExpectRollback()
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM fixture_statistics").
+					WithArgs(10).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec("INSERT INTO fixture_statistics").
+					WithArgs(testTeamStatisticRow()...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			statistic := testTeamStatistic()
+			statistic.FixtureID = 0
+			err = FixtureStatisticDao.Replace(context.Background(), 10, []TeamStatistic{statistic})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFixtureStatisticDao_ListByFixture(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes []TeamStatistic
+		expectedErr error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixture_statistics").
+					WithArgs(10).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixture_statistics").
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows(testColumns).AddRow(testTeamStatisticRow()...))
+			},
+			expectedRes: []TeamStatistic{testTeamStatistic()},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := FixtureStatisticDao.ListByFixture(context.Background(), 10)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package fixture_statistics
+
+// TeamStatistic holds the match statistics of a team in a fixture. API Sports gives no value for what did not
+// happen, so the counts default to 0. The possession, in percent, and the expected goals are null when API
+// Sports does not cover them for the league
+type TeamStatistic struct {
+	FixtureID       int64    `json:"fixture_id" db:"fixture_id"`
+	TeamID          int64    `json:"team_id" db:"team_id"`
+	TeamName        string   `json:"team_name" db:"team_name"`
+	Shots           int64    `json:"shots" db:"shots"`
+	ShotsOnTarget   int64    `json:"shots_on_target" db:"shots_on_target"`
+	ShotsOffTarget  int64    `json:"shots_off_target" db:"shots_off_target"`
+	BlockedShots    int64    `json:"blocked_shots" db:"blocked_shots"`
+	ShotsInsideBox  int64    `json:"shots_inside_box" db:"shots_inside_box"`
+	ShotsOutsideBox int64    `json:"shots_outside_box" db:"shots_outside_box"`
+	Fouls           int64    `json:"fouls" db:"fouls"`
+	Corners         int64    `json:"corners" db:"corners"`
+	Offsides        int64    `json:"offsides" db:"offsides"`
+	Possession      *int64   `json:"possession" db:"possession"`
+	YellowCards     int64    `json:"yellow_cards" db:"yellow_cards"`
+	RedCards        int64    `json:"red_cards" db:"red_cards"`
+	Saves           int64    `json:"saves" db:"saves"`
+	Passes          int64    `json:"passes" db:"passes"`
+	PassesAccurate  int64    `json:"passes_accurate" db:"passes_accurate"`
+	ExpectedGoals   *float64 `json:"expected_goals" db:"expected_goals"`
+}
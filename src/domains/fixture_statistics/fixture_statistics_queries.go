@@ -0,0 +1,48 @@
+package fixture_statistics
+
+const (
+	queryDeleteByFixture = `DELETE FROM fixture_statistics WHERE fixture_id = ?`
+
+	queryCreate = `INSERT INTO fixture_statistics(
+		fixture_id,
+		team_id,
+		team_name,
+		shots,
+		shots_on_target,
+		shots_off_target,
+		blocked_shots,
+		shots_inside_box,
+		shots_outside_box,
+		fouls,
+		corners,
+		offsides,
+		possession,
+		yellow_cards,
+		red_cards,
+		saves,
+		passes,
+		passes_accurate,
+		expected_goals)
+	VALUES (
+		:fixture_id,
+		:team_id,
+		:team_name,
+		:shots,
+		:shots_on_target,
+		:shots_off_target,
+		:blocked_shots,
+		:shots_inside_box,
+		:shots_outside_box,
+		:fouls,
+		:corners,
+		:offsides,
+		:possession,
+		:yellow_cards,
+		:red_cards,
+		:saves,
+		:passes,
+		:passes_accurate,
+		:expected_goals)`
+
+	queryListByFixture = `SELECT * FROM fixture_statistics WHERE fixture_id = ? ORDER BY team_id`
+)
@@ -0,0 +1,150 @@
+package fixtures
+
+import (
+	"context"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"time"
+)
+
+type FixtureDaoI interface {
+	Upsert(ctx context.Context, fixture *Fixture) error
+	FindByID(ctx context.Context, id int64) (*Fixture, error)
+	List(ctx context.Context, req *ListFixtureInput) ([]Fixture, int64, error)
+	MarkScored(ctx context.Context, id int64, scoredAt time.Time) error
+	MarkDetailsSynced(ctx context.Context, id int64, syncedAt time.Time) error
+}
+
+type fixtureDao struct{}
+
+var FixtureDao FixtureDaoI = &fixtureDao{}
+
+func (d *fixtureDao) Upsert(ctx context.Context, fixture *Fixture) error {
+	defer metrics.TimeQuery("FixtureDao", "Upsert")()
+	ctx, span := tracing.Start(ctx, "FixtureDao.Upsert")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryUpsert, fixture)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureDao Upsert NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *fixtureDao) FindByID(ctx context.Context, id int64) (*Fixture, error) {
+	defer metrics.TimeQuery("FixtureDao", "FindByID")()
+	ctx, span := tracing.Start(ctx, "FixtureDao.FindByID")
+	defer span.End()
+
+	var result Fixture
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindByID, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureDao FindByID Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+// MarkScored records when the user predictions of a fixture were scored
+func (d *fixtureDao) MarkScored(ctx context.Context, id int64, scoredAt time.Time) error {
+	defer metrics.TimeQuery("FixtureDao", "MarkScored")()
+	ctx, span := tracing.Start(ctx, "FixtureDao.MarkScored")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).ExecContext(ctx, queryMarkScored, scoredAt, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureDao MarkScored Exec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+// MarkDetailsSynced records when the events, lineups and statistics of a fixture were all imported
+func (d *fixtureDao) MarkDetailsSynced(ctx context.Context, id int64, syncedAt time.Time) error {
+	defer metrics.TimeQuery("FixtureDao", "MarkDetailsSynced")()
+	ctx, span := tracing.Start(ctx, "FixtureDao.MarkDetailsSynced")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).ExecContext(ctx, queryMarkDetailsSynced, syncedAt, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureDao MarkDetailsSynced Exec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *fixtureDao) List(ctx context.Context, req *ListFixtureInput) ([]Fixture, int64, error) {
+	defer metrics.TimeQuery("FixtureDao", "List")()
+	ctx, span := tracing.Start(ctx, "FixtureDao.List")
+	defer span.End()
+
+	var results []Fixture
+	// Create where, limit and order by clauses
+	where, args := d.generateListWhereClause(req)
+	page := req.Filter.Page(req.Page, req.PerPage, results)
+	query := fmt.Sprintf(queryList, page.Select("*"), page.Where(where), page.OrderBy(), page.Limit())
+
+	// Get the records
+	err := footy_db.Client.SelectContext(ctx, &results, query, page.Args(args)...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureDao List Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+	results = page.Rows(results).([]Fixture)
+
+	// Get total records so we can use them for pagination, unless the client asked to skip the count
+	if !req.Filter.Total() {
+		return results, pagination.TotalNotCounted, nil
+	}
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryListTotal, where), args...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureDao List GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	return results, total, nil
+}
+
+func (d *fixtureDao) generateListWhereClause(req *ListFixtureInput) (string, []interface{}) {
+	w := helpers.NewWhere()
+	w.AppendWhereAtStart()
+	w.Where("true") // add this just in case we do not have any param passed
+	w.Conditions(req.Filter.Conditions())
+
+	if req.LeagueID != 0 {
+		w.Where("league_id = ?", req.LeagueID)
+	}
+
+	if req.Season != 0 {
+		w.Where("season = ?", req.Season)
+	}
+
+	if req.TeamID != 0 {
+		w.CustomWhere(" AND (home_team_id = ? OR away_team_id = ?)", req.TeamID, req.TeamID)
+	}
+
+	if req.From != "" {
+		w.Where("kickoff_at >= ?", req.From)
+	}
+
+	if req.To != "" {
+		// Include the whole day of the upper bound
+		w.Where("kickoff_at < DATE_ADD(?, INTERVAL 1 DAY)", req.To)
+	}
+
+	return w.String()
+}
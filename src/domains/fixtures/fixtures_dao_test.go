@@ -0,0 +1,317 @@
+package fixtures
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var (
+	testKickoff   = time.Date(2022, 1, 15, 15, 0, 0, 0, time.UTC)
+	testHomeGoals = int64(2)
+	testAwayGoals = int64(1)
+	testColumns   = []string{
+		"id",
+		"league_id",
+		"season",
+		"round",
+		"kickoff_at",
+		"status",
+		"home_team_id",
+		"home_team_name",
+		"away_team_id",
+		"away_team_name",
+		"home_goals",
+		"away_goals",
+	}
+)
+
+func testFixture() Fixture {
+	return Fixture{
+		ID:           10,
+		LeagueID:     39,
+		Season:       2021,
+		Round:        "Regular Season - 21",
+		KickoffAt:    testKickoff,
+		Status:       "FT",
+		HomeTeamID:   1,
+		HomeTeamName: "Home",
+		AwayTeamID:   2,
+		AwayTeamName: "Away",
+		HomeGoals:    &testHomeGoals,
+		AwayGoals:    &testAwayGoals,
+	}
+}
+
+func TestFixture_Finished(t *testing.T) {
+	f := testFixture()
+	assert.True(t, f.Finished())
+
+	f.Status = "1H"
+	assert.False(t, f.Finished())
+
+	f.Status = "PEN"
+	f.AwayGoals = nil
+	assert.False(t, f.Finished())
+}
+
+func TestFixtureDao_Upsert(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO fixtures").
+					WithArgs(10, 39, 2021, "Regular Season - 21", testKickoff, "FT", 1, "Home", 2, "Away", 2, 1, nil).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO fixtures").
+					WithArgs(10, 39, 2021, "Regular Season - 21", testKickoff, "FT", 1, "Home", 2, "Away", 2, 1, nil).
+					WillReturnResult(sqlmock.NewResult(10, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			fixture := testFixture()
+			err = FixtureDao.Upsert(context.Background(), &fixture)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestFixtureDao_FindByID(t *testing.T) {
+	expected := testFixture()
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *Fixture
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixtures").
+					WithArgs(10).
+					WillReturnError(errors.New("test Get"))
+			},
+			expectedErr: errors.New("test Get"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixtures").
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(10, 39, 2021, "Regular Season - 21", testKickoff, "FT", 1, "Home", 2, "Away", 2, 1))
+			},
+			expectedRes: &expected,
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := FixtureDao.FindByID(context.Background(), 10)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestFixtureDao_MarkScored(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE fixtures SET scored_at").
+					WithArgs(testKickoff, 10).
+					WillReturnError(errors.New("test Exec"))
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE fixtures SET scored_at").
+					WithArgs(testKickoff, 10).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = FixtureDao.MarkScored(context.Background(), 10, testKickoff)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestFixtureDao_MarkDetailsSynced(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE fixtures SET details_synced_at").
+					WithArgs(testKickoff, 10).
+					WillReturnError(errors.New("test Exec"))
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE fixtures SET details_synced_at").
+					WithArgs(testKickoff, 10).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = FixtureDao.MarkDetailsSynced(context.Background(), 10, testKickoff)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestFixtureDao_List(t *testing.T) {
+	testCases := []struct {
+		title         string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []Fixture
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixtures").
+					WithArgs("FT", 39, 2021, "2022-01-01", "2022-01-31", 1, 1).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixtures").
+					WithArgs("FT", 39, 2021, "2022-01-01", "2022-01-31", 1, 1).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(10, 39, 2021, "Regular Season - 21", testKickoff, "FT", 1, "Home", 2, "Away", 2, 1))
+				m.ExpectQuery("SELECT (.+) FROM fixtures").
+					WithArgs("FT", 39, 2021, "2022-01-01", "2022-01-31", 1, 1).
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM fixtures").
+					WithArgs("FT", 39, 2021, "2022-01-01", "2022-01-31", 1, 1).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(10, 39, 2021, "Regular Season - 21", testKickoff, "FT", 1, "Home", 2, "Away", 2, 1))
+				m.ExpectQuery("SELECT (.+) FROM fixtures").
+					WithArgs("FT", 39, 2021, "2022-01-01", "2022-01-31", 1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes:   []Fixture{testFixture()},
+			expectedTotal: 1,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			query, errs := filter.Parse(url.Values{"status": {"FT"}}, Fixture{})
+			assert.Nil(t, errs)
+
+			res, total, err := FixtureDao.List(context.Background(), &ListFixtureInput{
+				LeagueID: 39,
+				Season:   2021,
+				TeamID:   1,
+				From:     "2022-01-01",
+				To:       "2022-01-31",
+				Filter:   *query,
+			})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
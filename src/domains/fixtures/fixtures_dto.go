@@ -0,0 +1,73 @@
+package fixtures
+
+import (
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"time"
+)
+
+// Short statuses used by API Sports for fixtures that have been completed
+var finishedStatuses = map[string]bool{
+	"FT":  true,
+	"AET": true,
+	"PEN": true,
+}
+
+// Fixture is also the row of the imports, validated with its validate tags
+type Fixture struct {
+	ID           int64     `json:"id" db:"id" validate:"required" filter:"eq,ne,in,gt,gte,lt,lte,sort"`
+	LeagueID     int64     `json:"league_id" db:"league_id" validate:"required" filter:"eq,ne,in,sort"`
+	Season       int64     `json:"season" db:"season" validate:"required" filter:"eq,ne,in,gt,gte,lt,lte,sort"`
+	Round        string    `json:"round" db:"round" filter:"eq,ne,in,like"`
+	KickoffAt    time.Time `json:"kickoff_at" db:"kickoff_at" validate:"required" filter:"eq,gt,gte,lt,lte,sort,default"`
+	Status       string    `json:"status" db:"status" validate:"required" filter:"eq,ne,in,sort"`
+	HomeTeamID   int64     `json:"home_team_id" db:"home_team_id" validate:"required" filter:"eq,ne,in"`
+	HomeTeamName string    `json:"home_team_name" db:"home_team_name" filter:"like,eq,ne"`
+	AwayTeamID   int64     `json:"away_team_id" db:"away_team_id" validate:"required" filter:"eq,ne,in"`
+	AwayTeamName string    `json:"away_team_name" db:"away_team_name" filter:"like,eq,ne"`
+	HomeGoals    *int64    `json:"home_goals" db:"home_goals" filter:"eq,ne,gt,gte,lt,lte,null"`
+	AwayGoals    *int64    `json:"away_goals" db:"away_goals" filter:"eq,ne,gt,gte,lt,lte,null"`
+	// WinnerTeamID is the team API Sports marks as the winner, set for the draws decided by a penalty shootout
+	WinnerTeamID *int64 `json:"winner_team_id" db:"winner_team_id" filter:"eq,null"`
+	// ScoredAt is when the user predictions were last scored, a sync scores the finished fixtures where it is null
+	// again and a corrected score resets it
+	ScoredAt *time.Time `json:"scored_at" db:"scored_at" filter:"null"`
+	// DetailsSyncedAt is when the events, lineups and statistics were all imported, a sync imports them again for
+	// the fixtures that finished recently where it is null
+	DetailsSyncedAt *time.Time `json:"details_synced_at" db:"details_synced_at" filter:"null"`
+}
+
+// Finished checks if the fixture has been completed and has a final score
+func (f *Fixture) Finished() bool {
+	return finishedStatuses[f.Status] && f.HomeGoals != nil && f.AwayGoals != nil
+}
+
+// Cancelled checks if the fixture will not be played
+func (f *Fixture) Cancelled() bool {
+	return f.Status == "CANC"
+}
+
+// ListFixtureInput filters on the fields of Fixture, see its filter tags. LeagueID and Season are only set by
+// the services, requests filter them with the league_id and season filters
+type ListFixtureInput struct {
+	LeagueID int64        `json:"-" form:"-"`
+	Season   int64        `json:"-" form:"-"`
+	TeamID   int64        `json:"team_id" form:"team_id"`
+	From     string       `json:"from" form:"from" validate:"omitempty,YYYY-MM-DD"`
+	To       string       `json:"to" form:"to" validate:"omitempty,YYYY-MM-DD"`
+	Page     int64        `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage  int64        `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+	Filter   filter.Query `json:"-" form:"-"`
+}
+
+type SyncFixtureInput struct {
+	LeagueID int64 `json:"league_id" form:"league_id" validate:"required"`
+	Season   int64 `json:"season" form:"season" validate:"required"`
+}
+
+// SyncDetailsInput imports the details of a fixture, or of the finished fixtures of a league season whose details
+// are missing
+type SyncDetailsInput struct {
+	FixtureID int64 `json:"fixture_id" form:"fixture_id" validate:"required_without=LeagueID"`
+	LeagueID  int64 `json:"league_id" form:"league_id" validate:"required_without=FixtureID"`
+	Season    int64 `json:"season" form:"season" validate:"required_with=LeagueID"`
+}
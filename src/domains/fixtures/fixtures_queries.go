@@ -0,0 +1,52 @@
+package fixtures
+
+const (
+	// The assignments run in order, so scored_at compares the stored goals before they are updated
+	queryUpsert = `INSERT INTO fixtures(
+		id,
+		league_id,
+		season,
+		round,
+		kickoff_at,
+		status,
+		home_team_id,
+		home_team_name,
+		away_team_id,
+		away_team_name,
+		home_goals,
+		away_goals,
+		winner_team_id)
+	VALUES (
+		:id,
+		:league_id,
+		:season,
+		:round,
+		:kickoff_at,
+		:status,
+		:home_team_id,
+		:home_team_name,
+		:away_team_id,
+		:away_team_name,
+		:home_goals,
+		:away_goals,
+		:winner_team_id)
+	ON DUPLICATE KEY UPDATE
+		scored_at = IF(home_goals <=> VALUES(home_goals) AND away_goals <=> VALUES(away_goals), scored_at, NULL),
+		round = VALUES(round),
+		kickoff_at = VALUES(kickoff_at),
+		status = VALUES(status),
+		home_team_name = VALUES(home_team_name),
+		away_team_name = VALUES(away_team_name),
+		home_goals = VALUES(home_goals),
+		away_goals = VALUES(away_goals),
+		winner_team_id = VALUES(winner_team_id)`
+
+	queryFindByID = `SELECT * FROM fixtures WHERE id = ? LIMIT 1`
+
+	queryMarkScored = `UPDATE fixtures SET scored_at = ? WHERE id = ?`
+
+	queryMarkDetailsSynced = `UPDATE fixtures SET details_synced_at = ? WHERE id = ?`
+
+	queryList      = `SELECT %s FROM fixtures %s ORDER BY %s %s`
+	queryListTotal = `SELECT count(id) FROM fixtures %s`
+)
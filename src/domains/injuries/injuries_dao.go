@@ -0,0 +1,82 @@
+package injuries
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"time"
+)
+
+type InjuryDaoI interface {
+	Replace(ctx context.Context, fixtureID int64, injuries []Injury) error
+	ListByFixture(ctx context.Context, fixtureID int64) ([]Unavailable, error)
+	ListByTeam(ctx context.Context, teamID int64, from time.Time) ([]Unavailable, error)
+}
+
+type injuryDao struct{}
+
+var InjuryDao InjuryDaoI = &injuryDao{}
+
+// Replace swaps the injuries of a fixture for the given ones in a single transaction, so the players who recovered
+// before the fixture are no longer listed
+func (d *injuryDao) Replace(ctx context.Context, fixtureID int64, injuries []Injury) error {
+	defer metrics.TimeQuery("InjuryDao", "Replace")()
+	ctx, span := tracing.Start(ctx, "InjuryDao.Replace")
+	defer span.End()
+
+	err := footy_db.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := footy_db.DB(ctx).ExecContext(ctx, queryDeleteByFixture, fixtureID); err != nil {
+			return err
+		}
+		for i := range injuries {
+			injuries[i].FixtureID = fixtureID
+			if _, err := footy_db.DB(ctx).NamedExecContext(ctx, queryCreate, &injuries[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("InjuryDao Replace Transaction", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+// ListByFixture returns the players missing a fixture by team, the ones who played the most first
+func (d *injuryDao) ListByFixture(ctx context.Context, fixtureID int64) ([]Unavailable, error) {
+	defer metrics.TimeQuery("InjuryDao", "ListByFixture")()
+	ctx, span := tracing.Start(ctx, "InjuryDao.ListByFixture")
+	defer span.End()
+
+	var results []Unavailable
+
+	err := footy_db.Client.SelectContext(ctx, &results, queryListByFixture, fixtureID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("InjuryDao ListByFixture Select", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return results, nil
+}
+
+// ListByTeam returns the players of a team missing its next fixture kicking off from the given time
+func (d *injuryDao) ListByTeam(ctx context.Context, teamID int64, from time.Time) ([]Unavailable, error) {
+	defer metrics.TimeQuery("InjuryDao", "ListByTeam")()
+	ctx, span := tracing.Start(ctx, "InjuryDao.ListByTeam")
+	defer span.End()
+
+	var results []Unavailable
+
+	err := footy_db.Client.SelectContext(ctx, &results, queryListByTeam, teamID, teamID, teamID, from)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("InjuryDao ListByTeam Select", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return results, nil
+}
@@ -0,0 +1,207 @@
+package injuries
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var testColumns = []string{"fixture_id", "player_id", "player_name", "team_id", "team_name", "type", "reason",
+	"expected_return", "kickoff_at", "minutes_share"}
+
+func testUnavailable() Unavailable {
+	expectedReturn := time.Date(2021, 11, 20, 0, 0, 0, 0, time.UTC)
+	return Unavailable{
+		Injury: Injury{
+			FixtureID:      10,
+			PlayerID:       276,
+			PlayerName:     "Neymar",
+			TeamID:         85,
+			TeamName:       "Paris Saint Germain",
+			Type:           TypeMissing,
+			Reason:         "Knee Injury",
+			ExpectedReturn: &expectedReturn,
+		},
+		KickoffAt:    time.Date(2021, 11, 6, 20, 0, 0, 0, time.UTC),
+		MinutesShare: 0.82,
+	}
+}
+
+func testUnavailableRow() []driver.Value {
+	return []driver.Value{10, 276, "Neymar", 85, "Paris Saint Germain", "missing", "Knee Injury",
+		time.Date(2021, 11, 20, 0, 0, 0, 0, time.UTC), time.Date(2021, 11, 6, 20, 0, 0, 0, time.UTC), 0.82}
+}
+
+func TestInjuryDao_Replace(t *testing.T) {
+	injuryArgs := []driver.Value{10, 276, "Neymar", 85, "Paris Saint Germain", "missing", "Knee Injury", sqlmock.AnyArg()}
+
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error delete Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM injuries").
+					WithArgs(10).
+					WillReturnError(errors.New("test Exec"))
+				m.ExpectRollback()
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "error injury NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM injuries").
+					WithArgs(10).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec("INSERT INTO injuries").
+					WithArgs(injuryArgs...).
+					WillReturnError(errors.New("test NamedExec"))
+				m.ExpectRollback()
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM injuries").
+					WithArgs(10).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec("INSERT INTO injuries").
+					WithArgs(injuryArgs...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			injury := testUnavailable().Injury
+			injury.FixtureID = 0
+			err = InjuryDao.Replace(context.Background(), 10, []Injury{injury})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestInjuryDao_ListByFixture(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes []Unavailable
+		expectedErr error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM injuries i (.+) WHERE i.fixture_id = ?").
+					WithArgs(10).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM injuries i (.+) WHERE i.fixture_id = ?").
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows(testColumns).AddRow(testUnavailableRow()...))
+			},
+			expectedRes: []Unavailable{testUnavailable()},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := InjuryDao.ListByFixture(context.Background(), 10)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestInjuryDao_ListByTeam(t *testing.T) {
+	from := time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes []Unavailable
+		expectedErr error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM injuries i (.+) WHERE i.team_id = ?").
+					WithArgs(85, 85, 85, from).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM injuries i (.+) WHERE i.team_id = ?").
+					WithArgs(85, 85, 85, from).
+					WillReturnRows(sqlmock.NewRows(testColumns).AddRow(testUnavailableRow()...))
+			},
+			expectedRes: []Unavailable{testUnavailable()},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := InjuryDao.ListByTeam(context.Background(), 85, from)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestTypeOf(t *testing.T) {
+	assert.Equal(t, TypeMissing, TypeOf("Missing Fixture"))
+	assert.Equal(t, TypeQuestionable, TypeOf("Questionable"))
+}
@@ -0,0 +1,46 @@
+package injuries
+
+import "time"
+
+// The types of the injuries, a questionable player may still play
+const (
+	TypeMissing      = "missing"
+	TypeQuestionable = "questionable"
+)
+
+// TypeOf returns the type of an API Sports injury type: Missing Fixture or Questionable
+func TypeOf(apiType string) string {
+	if apiType == "Questionable" {
+		return TypeQuestionable
+	}
+	return TypeMissing
+}
+
+// Injury is a player out of a fixture of their team, injured or suspended. The reason is the one of API Sports e.g.
+// "Knee Injury", "Red Card" or "Suspended", the expected return is the end of the sideline period of the player
+// when it is known
+type Injury struct {
+	FixtureID      int64      `json:"fixture_id" db:"fixture_id"`
+	PlayerID       int64      `json:"player_id" db:"player_id"`
+	PlayerName     string     `json:"player_name" db:"player_name"`
+	TeamID         int64      `json:"team_id" db:"team_id"`
+	TeamName       string     `json:"team_name" db:"team_name"`
+	Type           string     `json:"type" db:"type"`
+	Reason         string     `json:"reason" db:"reason"`
+	ExpectedReturn *time.Time `json:"expected_return" db:"expected_return"`
+}
+
+// Unavailable is an injury with the kickoff of the fixture and the minutes share of the player: the share of the
+// minutes of their team in the league season they were on the pitch for, 1 for a player who played every minute
+type Unavailable struct {
+	Injury
+	KickoffAt    time.Time `json:"kickoff_at" db:"kickoff_at"`
+	MinutesShare float64   `json:"minutes_share" db:"minutes_share"`
+}
+
+// SyncInjuryInput imports the players missing a fixture, or the fixtures of a league season
+type SyncInjuryInput struct {
+	FixtureID int64 `json:"fixture_id" form:"fixture_id" validate:"required_without=LeagueID"`
+	LeagueID  int64 `json:"league_id" form:"league_id" validate:"required_without=FixtureID"`
+	Season    int64 `json:"season" form:"season" validate:"required_with=LeagueID"`
+}
@@ -0,0 +1,60 @@
+package injuries
+
+const (
+	queryDeleteByFixture = `DELETE FROM injuries WHERE fixture_id = ?`
+
+	queryCreate = `INSERT INTO injuries(
+		fixture_id,
+		player_id,
+		player_name,
+		team_id,
+		team_name,
+		type,
+		reason,
+		expected_return)
+	VALUES (
+		:fixture_id,
+		:player_id,
+		:player_name,
+		:team_id,
+		:team_name,
+		:type,
+		:reason,
+		:expected_return)`
+
+	// The minutes share is 11 times the minutes of the player over the minutes of every player of their team in the
+	// league season of the fixture, so a player who played every minute counts for 1
+	querySelectUnavailable = `SELECT
+		i.fixture_id,
+		i.player_id,
+		i.player_name,
+		i.team_id,
+		i.team_name,
+		i.type,
+		i.reason,
+		i.expected_return,
+		f.kickoff_at,
+		LEAST(COALESCE(11 * ps.minutes / NULLIF(t.minutes, 0), 0), 1) AS minutes_share
+	FROM injuries i
+	INNER JOIN fixtures f ON f.id = i.fixture_id
+	LEFT JOIN player_statistics ps ON ps.player_id = i.player_id AND ps.team_id = i.team_id
+		AND ps.league_id = f.league_id AND ps.season = f.season
+	LEFT JOIN (
+		SELECT team_id, league_id, season, SUM(minutes) AS minutes
+		FROM player_statistics
+		GROUP BY team_id, league_id, season
+	) t ON t.team_id = i.team_id AND t.league_id = f.league_id AND t.season = f.season`
+
+	queryListByFixture = querySelectUnavailable + `
+	WHERE i.fixture_id = ?
+	ORDER BY i.team_id, minutes_share DESC, i.player_name`
+
+	// The next fixture of the team is the first one kicking off from the given time
+	queryListByTeam = querySelectUnavailable + `
+	WHERE i.team_id = ? AND i.fixture_id = (
+		SELECT id FROM fixtures
+		WHERE (home_team_id = ? OR away_team_id = ?) AND kickoff_at >= ?
+		ORDER BY kickoff_at
+		LIMIT 1)
+	ORDER BY minutes_share DESC, i.player_name`
+)
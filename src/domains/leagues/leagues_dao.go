@@ -0,0 +1,96 @@
+package leagues
+
+import (
+	"context"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type LeagueDaoI interface {
+	Upsert(ctx context.Context, league *League) error
+	FindByID(ctx context.Context, id int64) (*League, error)
+	List(ctx context.Context, req *ListLeagueInput) ([]League, int64, error)
+}
+
+type leagueDao struct{}
+
+var LeagueDao LeagueDaoI = &leagueDao{}
+
+func (d *leagueDao) Upsert(ctx context.Context, league *League) error {
+	defer metrics.TimeQuery("LeagueDao", "Upsert")()
+	ctx, span := tracing.Start(ctx, "LeagueDao.Upsert")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryUpsert, league)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("LeagueDao Upsert NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *leagueDao) FindByID(ctx context.Context, id int64) (*League, error) {
+	defer metrics.TimeQuery("LeagueDao", "FindByID")()
+	ctx, span := tracing.Start(ctx, "LeagueDao.FindByID")
+	defer span.End()
+
+	var result League
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindByID, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("LeagueDao FindByID Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+func (d *leagueDao) List(ctx context.Context, req *ListLeagueInput) ([]League, int64, error) {
+	defer metrics.TimeQuery("LeagueDao", "List")()
+	ctx, span := tracing.Start(ctx, "LeagueDao.List")
+	defer span.End()
+
+	var results []League
+	// Create where, limit and order by clauses
+	where, args := d.generateListWhereClause(req)
+	page := req.Filter.Page(req.Page, req.PerPage, results)
+	query := fmt.Sprintf(queryList, page.Select("*"), page.Where(where), page.OrderBy(), page.Limit())
+
+	// Get the records
+	err := footy_db.Client.SelectContext(ctx, &results, query, page.Args(args)...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("LeagueDao List Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+	results = page.Rows(results).([]League)
+
+	// Get total records so we can use them for pagination, unless the client asked to skip the count
+	if !req.Filter.Total() {
+		return results, pagination.TotalNotCounted, nil
+	}
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryListTotal, where), args...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("LeagueDao List GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	return results, total, nil
+}
+
+func (d *leagueDao) generateListWhereClause(req *ListLeagueInput) (string, []interface{}) {
+	w := helpers.NewWhere()
+	w.AppendWhereAtStart()
+	w.Where("true") // add this just in case we do not have any param passed
+	w.Conditions(req.Filter.Conditions())
+
+	return w.String()
+}
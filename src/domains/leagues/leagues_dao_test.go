@@ -0,0 +1,188 @@
+package leagues
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+)
+
+var testColumns = []string{"id", "name", "type", "logo", "country_name", "country_code", "active"}
+
+func testLeague() League {
+	return League{
+		ID:          39,
+		Name:        "Premier League",
+		Type:        "League",
+		Logo:        "https://media.api-sports.io/football/leagues/39.png",
+		CountryName: "England",
+		CountryCode: "GB",
+		Active:      true,
+	}
+}
+
+func TestLeagueDao_Upsert(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO leagues").
+					WithArgs(39, "Premier League", "League", "https://media.api-sports.io/football/leagues/39.png", "England", "GB", true).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO leagues").
+					WithArgs(39, "Premier League", "League", "https://media.api-sports.io/football/leagues/39.png", "England", "GB", true).
+					WillReturnResult(sqlmock.NewResult(39, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			league := testLeague()
+			err = LeagueDao.Upsert(context.Background(), &league)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestLeagueDao_FindByID(t *testing.T) {
+	expected := testLeague()
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *League
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM leagues").
+					WithArgs(39).
+					WillReturnError(errors.New("test Get"))
+			},
+			expectedErr: errors.New("test Get"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM leagues").
+					WithArgs(39).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(39, "Premier League", "League", "https://media.api-sports.io/football/leagues/39.png", "England", "GB", true))
+			},
+			expectedRes: &expected,
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := LeagueDao.FindByID(context.Background(), 39)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestLeagueDao_List(t *testing.T) {
+	testCases := []struct {
+		title         string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []League
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM leagues").
+					WithArgs(true, "GB", "%Premier%", "League").
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM leagues").
+					WithArgs(true, "GB", "%Premier%", "League").
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(39, "Premier League", "League", "https://media.api-sports.io/football/leagues/39.png", "England", "GB", true))
+				m.ExpectQuery("SELECT (.+) FROM leagues").
+					WithArgs(true, "GB", "%Premier%", "League").
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM leagues").
+					WithArgs(true, "GB", "%Premier%", "League").
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(39, "Premier League", "League", "https://media.api-sports.io/football/leagues/39.png", "England", "GB", true))
+				m.ExpectQuery("SELECT (.+) FROM leagues").
+					WithArgs(true, "GB", "%Premier%", "League").
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes:   []League{testLeague()},
+			expectedTotal: 1,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			query, errs := filter.Parse(url.Values{"name": {"Premier"}, "type": {"League"}, "country_code": {"GB"}, "active": {"true"}}, League{})
+			assert.Nil(t, errs)
+
+			res, total, err := LeagueDao.List(context.Background(), &ListLeagueInput{Filter: *query})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
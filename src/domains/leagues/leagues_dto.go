@@ -0,0 +1,21 @@
+package leagues
+
+import "github.com/development-raul/footy-predictor/src/utils/filter"
+
+// League is also the row of the imports, validated with its validate tags
+type League struct {
+	ID          int64  `json:"id" db:"id" validate:"required" filter:"eq,ne,in,gt,gte,lt,lte,sort"`
+	Name        string `json:"name" db:"name" validate:"required" filter:"like,eq,ne,in,sort,default"`
+	Type        string `json:"type" db:"type" validate:"required,oneof=League Cup" filter:"eq,ne,in,oneof=League Cup"`
+	Logo        string `json:"logo" db:"logo"`
+	CountryName string `json:"country_name" db:"country_name" filter:"eq,ne,in,like,sort"`
+	CountryCode string `json:"country_code" db:"country_code" filter:"eq,ne,in,null"`
+	Active      bool   `json:"active" db:"active" filter:"eq"`
+}
+
+// ListLeagueInput filters on the fields of League, see its filter tags
+type ListLeagueInput struct {
+	Page    int64        `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage int64        `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+	Filter  filter.Query `json:"-" form:"-"`
+}
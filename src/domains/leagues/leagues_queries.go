@@ -0,0 +1,32 @@
+package leagues
+
+const (
+	// active is not updated on duplicates, so leagues disabled locally stay disabled after a sync
+	queryUpsert = `INSERT INTO leagues(
+		id,
+		name,
+		type,
+		logo,
+		country_name,
+		country_code,
+		active)
+	VALUES (
+		:id,
+		:name,
+		:type,
+		:logo,
+		:country_name,
+		:country_code,
+		:active)
+	ON DUPLICATE KEY UPDATE
+		name = VALUES(name),
+		type = VALUES(type),
+		logo = VALUES(logo),
+		country_name = VALUES(country_name),
+		country_code = VALUES(country_code)`
+
+	queryFindByID = `SELECT * FROM leagues WHERE id = ? LIMIT 1`
+
+	queryList      = `SELECT %s FROM leagues %s ORDER BY %s %s`
+	queryListTotal = `SELECT count(id) FROM leagues %s`
+)
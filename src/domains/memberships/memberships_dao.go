@@ -0,0 +1,94 @@
+package memberships
+
+import (
+	"context"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type MembershipDaoI interface {
+	Create(ctx context.Context, membership *Membership) error
+	Exists(ctx context.Context, competitionID int64, userID int64) (bool, error)
+	Leaderboard(ctx context.Context, competitionID int64, req *ListLeaderboardInput) ([]LeaderboardEntry, int64, error)
+	Delete(ctx context.Context, competitionID int64, userID int64) error
+}
+
+type membershipDao struct{}
+
+var MembershipDao MembershipDaoI = &membershipDao{}
+
+func (d *membershipDao) Create(ctx context.Context, membership *Membership) error {
+	defer metrics.TimeQuery("MembershipDao", "Create")()
+	ctx, span := tracing.Start(ctx, "MembershipDao.Create")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryCreate, membership)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("MembershipDao Create NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *membershipDao) Exists(ctx context.Context, competitionID int64, userID int64) (bool, error) {
+	defer metrics.TimeQuery("MembershipDao", "Exists")()
+	ctx, span := tracing.Start(ctx, "MembershipDao.Exists")
+	defer span.End()
+
+	var total int64
+
+	err := footy_db.Client.GetContext(ctx, &total, queryExists, competitionID, userID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("MembershipDao Exists Get", "error", err)
+		return false, dberror.Wrap(err)
+	}
+	return total > 0, nil
+}
+
+func (d *membershipDao) Leaderboard(ctx context.Context, competitionID int64, req *ListLeaderboardInput) ([]LeaderboardEntry, int64, error) {
+	defer metrics.TimeQuery("MembershipDao", "Leaderboard")()
+	ctx, span := tracing.Start(ctx, "MembershipDao.Leaderboard")
+	defer span.End()
+
+	var results []LeaderboardEntry
+	limit := pagination.GeneratePaginationQuery(req.Page, req.PerPage)
+
+	// Get the records
+	err := footy_db.Client.SelectContext(ctx, &results, fmt.Sprintf(queryLeaderboard, limit), competitionID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("MembershipDao Leaderboard Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	// Get total records so we can use them for pagination
+	total, err := pagination.GetTableTotalRowsArgs(ctx, queryLeaderboardTotal, competitionID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("MembershipDao Leaderboard GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	return results, total, nil
+}
+
+func (d *membershipDao) Delete(ctx context.Context, competitionID int64, userID int64) error {
+	defer metrics.TimeQuery("MembershipDao", "Delete")()
+	ctx, span := tracing.Start(ctx, "MembershipDao.Delete")
+	defer span.End()
+
+	_, err := footy_db.Client.ExecContext(ctx, queryDelete, competitionID, userID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("MembershipDao Delete Exec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
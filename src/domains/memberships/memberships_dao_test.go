@@ -0,0 +1,227 @@
+package memberships
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var testJoinedAt = time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func TestMembershipDao_Create(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO memberships").
+					WithArgs(1, 2, testJoinedAt).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO memberships").
+					WithArgs(1, 2, testJoinedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = MembershipDao.Create(context.Background(), &Membership{
+				CompetitionID: 1,
+				UserID:        2,
+				JoinedAt:      testJoinedAt,
+			})
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestMembershipDao_Exists(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes bool
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM memberships").
+					WithArgs(1, 2).
+					WillReturnError(errors.New("test Get"))
+			},
+			expectedErr: errors.New("test Get"),
+		},
+		{
+			title: "success not a member",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM memberships").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(0))
+			},
+			expectedRes: false,
+			expectedErr: nil,
+		},
+		{
+			title: "success member",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM memberships").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes: true,
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := MembershipDao.Exists(context.Background(), 1, 2)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestMembershipDao_Leaderboard(t *testing.T) {
+	columns := []string{"user_id", "name", "points", "exact_scores", "scored_predictions"}
+	testCases := []struct {
+		title         string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []LeaderboardEntry
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM memberships").
+					WithArgs(1).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM memberships").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "John", 7, 1, 4))
+				m.ExpectQuery("SELECT (.+) FROM memberships").
+					WithArgs(1).
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM memberships").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "John", 7, 1, 4))
+				m.ExpectQuery("SELECT (.+) FROM memberships").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes: []LeaderboardEntry{
+				{UserID: 2, Name: "John", Points: 7, ExactScores: 1, ScoredPredictions: 4},
+			},
+			expectedTotal: 1,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, total, err := MembershipDao.Leaderboard(context.Background(), 1, &ListLeaderboardInput{})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestMembershipDao_Delete(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("DELETE FROM memberships").
+					WithArgs(1, 2).
+					WillReturnError(errors.New("test Exec"))
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("DELETE FROM memberships").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = MembershipDao.Delete(context.Background(), 1, 2)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
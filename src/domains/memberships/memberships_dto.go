@@ -0,0 +1,23 @@
+package memberships
+
+import "time"
+
+type Membership struct {
+	CompetitionID int64     `json:"competition_id" db:"competition_id"`
+	UserID        int64     `json:"user_id" db:"user_id"`
+	JoinedAt      time.Time `json:"joined_at" db:"joined_at"`
+}
+
+type ListLeaderboardInput struct {
+	Page    int64 `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage int64 `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+}
+
+type LeaderboardEntry struct {
+	Position          int64  `json:"position" db:"-"`
+	UserID            int64  `json:"user_id" db:"user_id"`
+	Name              string `json:"name" db:"name"`
+	Points            int64  `json:"points" db:"points"`
+	ExactScores       int64  `json:"exact_scores" db:"exact_scores"`
+	ScoredPredictions int64  `json:"scored_predictions" db:"scored_predictions"`
+}
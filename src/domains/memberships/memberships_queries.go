@@ -0,0 +1,31 @@
+package memberships
+
+const (
+	queryCreate = `INSERT INTO memberships(
+		competition_id,
+		user_id,
+		joined_at)
+	VALUES (
+		:competition_id,
+		:user_id,
+		:joined_at)`
+
+	queryExists = `SELECT count(*) FROM memberships WHERE competition_id = ? AND user_id = ?`
+
+	queryLeaderboard = `SELECT
+		m.user_id,
+		u.name,
+		COALESCE(SUM(p.points), 0) AS points,
+		COALESCE(SUM(CASE WHEN p.scored_at IS NOT NULL AND p.home_goals = f.home_goals AND p.away_goals = f.away_goals THEN 1 ELSE 0 END), 0) AS exact_scores,
+		COUNT(p.scored_at) AS scored_predictions
+	FROM memberships m
+	INNER JOIN users u ON u.id = m.user_id
+	LEFT JOIN user_predictions p ON p.competition_id = m.competition_id AND p.user_id = m.user_id
+	LEFT JOIN fixtures f ON f.id = p.fixture_id
+	WHERE m.competition_id = ?
+	GROUP BY m.user_id, u.name
+	ORDER BY points DESC, exact_scores DESC, u.name ASC %s`
+	queryLeaderboardTotal = `SELECT count(*) FROM memberships WHERE competition_id = ?`
+
+	queryDelete = `DELETE FROM memberships WHERE competition_id = ? AND user_id = ?`
+)
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"time"
+)
+
+type ModelDaoI interface {
+	Create(ctx context.Context, model *Model) error
+	FindByID(ctx context.Context, id int64) (*Model, error)
+	FindByVersion(ctx context.Context, name string, version int64) (*Model, error)
+	FindChampion(ctx context.Context) (*Model, error)
+	List(ctx context.Context, req *ListModelInput) ([]Model, int64, error)
+	SetChampion(ctx context.Context, id int64) error
+	FindFit(ctx context.Context, modelID int64, leagueID int64, season int64, before time.Time) (*Fit, error)
+	SaveFit(ctx context.Context, fit *Fit) error
+}
+
+type modelDao struct{}
+
+var ModelDao ModelDaoI = &modelDao{}
+
+func (d *modelDao) Create(ctx context.Context, model *Model) error {
+	defer metrics.TimeQuery("ModelDao", "Create")()
+	ctx, span := tracing.Start(ctx, "ModelDao.Create")
+	defer span.End()
+
+	res, err := footy_db.DB(ctx).NamedExecContext(ctx, queryCreate, model)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ModelDao Create NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ModelDao Create LastInsertId", "error", err)
+		return dberror.Wrap(err)
+	}
+	model.ID = id
+	return nil
+}
+
+func (d *modelDao) FindByID(ctx context.Context, id int64) (*Model, error) {
+	defer metrics.TimeQuery("ModelDao", "FindByID")()
+	ctx, span := tracing.Start(ctx, "ModelDao.FindByID")
+	defer span.End()
+
+	var result Model
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindByID, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ModelDao FindByID Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+// FindByVersion returns a version of a model, its latest version when version is 0
+func (d *modelDao) FindByVersion(ctx context.Context, name string, version int64) (*Model, error) {
+	defer metrics.TimeQuery("ModelDao", "FindByVersion")()
+	ctx, span := tracing.Start(ctx, "ModelDao.FindByVersion")
+	defer span.End()
+
+	var result Model
+
+	var err error
+	if version == 0 {
+		err = footy_db.Client.GetContext(ctx, &result, queryFindLatest, name)
+	} else {
+		err = footy_db.Client.GetContext(ctx, &result, queryFindByVersion, name, version)
+	}
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ModelDao FindByVersion Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+func (d *modelDao) FindChampion(ctx context.Context) (*Model, error) {
+	defer metrics.TimeQuery("ModelDao", "FindChampion")()
+	ctx, span := tracing.Start(ctx, "ModelDao.FindChampion")
+	defer span.End()
+
+	var result Model
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindChampion)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ModelDao FindChampion Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+func (d *modelDao) List(ctx context.Context, req *ListModelInput) ([]Model, int64, error) {
+	defer metrics.TimeQuery("ModelDao", "List")()
+	ctx, span := tracing.Start(ctx, "ModelDao.List")
+	defer span.End()
+
+	var results []Model
+	// Create where, limit and order by clauses
+	w := helpers.NewWhere()
+	w.AppendWhereAtStart()
+	w.Where("true") // add this just in case we do not have any param passed
+	w.Conditions(req.Filter.Conditions())
+	where, args := w.String()
+	page := req.Filter.Page(req.Page, req.PerPage, results)
+	query := fmt.Sprintf(queryList, page.Select("*"), page.Where(where), page.OrderBy(), page.Limit())
+
+	// Get the records
+	err := footy_db.Client.SelectContext(ctx, &results, query, page.Args(args)...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ModelDao List Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+	results = page.Rows(results).([]Model)
+
+	// Get total records so we can use them for pagination, unless the client asked to skip the count
+	if !req.Filter.Total() {
+		return results, pagination.TotalNotCounted, nil
+	}
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryListTotal, where), args...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ModelDao List GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	return results, total, nil
+}
+
+// SetChampion makes the model version the champion and every other version a challenger
+func (d *modelDao) SetChampion(ctx context.Context, id int64) error {
+	defer metrics.TimeQuery("ModelDao", "SetChampion")()
+	ctx, span := tracing.Start(ctx, "ModelDao.SetChampion")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).ExecContext(ctx, querySetChampion, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ModelDao SetChampion Exec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *modelDao) FindFit(ctx context.Context, modelID int64, leagueID int64, season int64, before time.Time) (*Fit, error) {
+	defer metrics.TimeQuery("ModelDao", "FindFit")()
+	ctx, span := tracing.Start(ctx, "ModelDao.FindFit")
+	defer span.End()
+
+	var result Fit
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindFit, modelID, leagueID, season, before)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ModelDao FindFit Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+// SaveFit stores the fitted parameters, a fit of the same model, league, season and time is replaced
+func (d *modelDao) SaveFit(ctx context.Context, fit *Fit) error {
+	defer metrics.TimeQuery("ModelDao", "SaveFit")()
+	ctx, span := tracing.Start(ctx, "ModelDao.SaveFit")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, querySaveFit, fit)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("ModelDao SaveFit NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,476 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var (
+	testCreatedAt    = time.Date(2022, 1, 14, 10, 0, 0, 0, time.UTC)
+	testFittedBefore = time.Date(2021, 8, 14, 14, 0, 0, 0, time.UTC)
+	testColumns      = []string{
+		"id",
+		"name",
+		"version",
+		"hyperparameters",
+		"description",
+		"champion",
+		"created_at",
+	}
+	testFitColumns = []string{
+		"model_id",
+		"league_id",
+		"season",
+		"fitted_before",
+		"matches",
+		"parameters",
+		"created_at",
+	}
+)
+
+func testModel() Model {
+	return Model{
+		ID:              3,
+		Name:            "dixon-coles",
+		Version:         2,
+		Hyperparameters: json.RawMessage(`{"xi":0.005}`),
+		Description:     "Faster decay",
+		CreatedAt:       testCreatedAt,
+	}
+}
+
+func testFit() Fit {
+	return Fit{
+		ModelID:      3,
+		LeagueID:     39,
+		Season:       2021,
+		FittedBefore: testFittedBefore,
+		Matches:      380,
+		Parameters:   json.RawMessage(`{"rho":-0.05}`),
+		CreatedAt:    testCreatedAt,
+	}
+}
+
+func testModelRow() *sqlmock.Rows {
+	return sqlmock.NewRows(testColumns).
+		AddRow(3, "dixon-coles", 2, []byte(`{"xi":0.005}`), "Faster decay", false, testCreatedAt)
+}
+
+func TestModelDao_Create(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedID  int64
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO models(.+)SELECT(.+)COALESCE\\(MAX\\(version\\), 0\\) \\+ 1").
+					WithArgs("dixon-coles", []byte(`{"xi":0.005}`), "Faster decay", false, testCreatedAt, "dixon-coles").
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "error LastInsertId",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO models").
+					WithArgs("dixon-coles", []byte(`{"xi":0.005}`), "Faster decay", false, testCreatedAt, "dixon-coles").
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("test LastInsertId")))
+			},
+			expectedErr: errors.New("test LastInsertId"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO models").
+					WithArgs("dixon-coles", []byte(`{"xi":0.005}`), "Faster decay", false, testCreatedAt, "dixon-coles").
+					WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			expectedID:  3,
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			model := testModel()
+			model.ID = 0
+			err = ModelDao.Create(context.Background(), &model)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedID, model.ID)
+		})
+	}
+}
+
+func TestModelDao_FindByID(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *Model
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM models WHERE id = ?").
+					WithArgs(3).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM models WHERE id = ?").
+					WithArgs(3).
+					WillReturnRows(testModelRow())
+			},
+			expectedRes: func() *Model { m := testModel(); return &m }(),
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := ModelDao.FindByID(context.Background(), 3)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestModelDao_FindByVersion(t *testing.T) {
+	testCases := []struct {
+		title       string
+		version     int64
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *Model
+		expectedErr error
+	}{
+		{
+			title:   "error Client.Get",
+			version: 2,
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM models WHERE name = \\? AND version = \\?").
+					WithArgs("dixon-coles", 2).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			title:   "success version",
+			version: 2,
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM models WHERE name = \\? AND version = \\?").
+					WithArgs("dixon-coles", 2).
+					WillReturnRows(testModelRow())
+			},
+			expectedRes: func() *Model { m := testModel(); return &m }(),
+			expectedErr: nil,
+		},
+		{
+			title:   "success latest",
+			version: 0,
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM models WHERE name = \\? ORDER BY version DESC LIMIT 1").
+					WithArgs("dixon-coles").
+					WillReturnRows(testModelRow())
+			},
+			expectedRes: func() *Model { m := testModel(); return &m }(),
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := ModelDao.FindByVersion(context.Background(), "dixon-coles", testCase.version)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestModelDao_FindChampion(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *Model
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM models WHERE champion = 1").
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM models WHERE champion = 1").
+					WillReturnRows(testModelRow())
+			},
+			expectedRes: func() *Model { m := testModel(); return &m }(),
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := ModelDao.FindChampion(context.Background())
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestModelDao_List(t *testing.T) {
+	testCases := []struct {
+		title         string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []Model
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM models").
+					WithArgs("dixon-coles").
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM models").
+					WithArgs("dixon-coles").
+					WillReturnRows(testModelRow())
+				m.ExpectQuery("SELECT (.+) FROM models").
+					WithArgs("dixon-coles").
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM models (.+) ORDER BY version DESC").
+					WithArgs("dixon-coles").
+					WillReturnRows(testModelRow())
+				m.ExpectQuery("SELECT (.+) FROM models").
+					WithArgs("dixon-coles").
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes:   []Model{testModel()},
+			expectedTotal: 1,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			query, errs := filter.Parse(url.Values{"name": {"dixon-coles"}, "sort": {"-version"}}, Model{})
+			assert.Nil(t, errs)
+
+			res, total, err := ModelDao.List(context.Background(), &ListModelInput{Filter: *query})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestModelDao_SetChampion(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE models SET champion = \\(id = \\?\\)").
+					WithArgs(3).
+					WillReturnError(errors.New("test Exec"))
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE models SET champion = \\(id = \\?\\)").
+					WithArgs(3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = ModelDao.SetChampion(context.Background(), 3)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestModelDao_FindFit(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *Fit
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM model_fits WHERE model_id = \\? AND league_id = \\? AND season = \\? AND fitted_before = \\?").
+					WithArgs(3, 39, 2021, testFittedBefore).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM model_fits WHERE model_id = \\? AND league_id = \\? AND season = \\? AND fitted_before = \\?").
+					WithArgs(3, 39, 2021, testFittedBefore).
+					WillReturnRows(sqlmock.NewRows(testFitColumns).
+						AddRow(3, 39, 2021, testFittedBefore, 380, []byte(`{"rho":-0.05}`), testCreatedAt))
+			},
+			expectedRes: func() *Fit { f := testFit(); return &f }(),
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := ModelDao.FindFit(context.Background(), 3, 39, 2021, testFittedBefore)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestModelDao_SaveFit(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO model_fits(.+)ON DUPLICATE KEY UPDATE").
+					WithArgs(3, 39, 2021, testFittedBefore, 380, []byte(`{"rho":-0.05}`), testCreatedAt).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO model_fits(.+)ON DUPLICATE KEY UPDATE").
+					WithArgs(3, 39, 2021, testFittedBefore, 380, []byte(`{"rho":-0.05}`), testCreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			fit := testFit()
+			err = ModelDao.SaveFit(context.Background(), &fit)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
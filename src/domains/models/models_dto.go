@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/domains/backtests"
+	"github.com/development-raul/footy-predictor/src/domains/predictions"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"time"
+)
+
+// Model is a registered version of a prediction model with its hyperparameters, the versions of a model are
+// numbered from 1 and never change once registered. The champion is the version used when no model is requested
+type Model struct {
+	ID              int64           `json:"id" db:"id" filter:"eq,in,sort"`
+	Name            string          `json:"name" db:"name" filter:"eq,in,oneof=poisson poisson-rho dixon-coles elo ensemble"`
+	Version         int64           `json:"version" db:"version" filter:"eq,gte,lte,sort"`
+	Hyperparameters json.RawMessage `json:"hyperparameters" db:"hyperparameters" swaggertype:"object"`
+	Description     string          `json:"description" db:"description" filter:"like"`
+	Champion        bool            `json:"champion" db:"champion" filter:"eq"`
+	CreatedAt       time.Time       `json:"created_at" db:"created_at" filter:"gt,gte,lt,lte,sort,default=desc"`
+}
+
+// Ref returns the name@version reference of the model version
+func (m *Model) Ref() string {
+	return fmt.Sprintf("%s@%d", m.Name, m.Version)
+}
+
+// Fit holds the fitted parameters of a model version on the fixtures of a league that finished before a time
+// in a season and the previous one, see predictor.Load
+type Fit struct {
+	ModelID      int64           `json:"model_id" db:"model_id"`
+	LeagueID     int64           `json:"league_id" db:"league_id"`
+	Season       int64           `json:"season" db:"season"`
+	FittedBefore time.Time       `json:"fitted_before" db:"fitted_before"`
+	Matches      int64           `json:"matches" db:"matches"`
+	Parameters   json.RawMessage `json:"parameters" db:"parameters" swaggertype:"object"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+// FitInput selects the league season a model version is fitted on
+type FitInput struct {
+	LeagueID int64 `json:"league_id" form:"league_id" validate:"required"`
+	Season   int64 `json:"season" form:"season" validate:"required"`
+}
+
+// CreateModelInput registers the next version of a model, the hyperparameters left out take their default value
+type CreateModelInput struct {
+	Name            string          `json:"name" validate:"required,oneof=poisson poisson-rho dixon-coles elo ensemble"`
+	Hyperparameters json.RawMessage `json:"hyperparameters" swaggertype:"object"`
+	Description     string          `json:"description" validate:"max=255"`
+}
+
+// ListModelInput filters on the fields of Model, see its filter tags
+type ListModelInput struct {
+	Page    int64        `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage int64        `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+	Filter  filter.Query `json:"-" form:"-"`
+}
+
+// CompareModelInput compares model versions given as comma separated references, e.g. poisson@1,dixon-coles,
+// a name alone is its latest version. The backtests compared are of the league of the fixture unless LeagueID
+// is sent
+type CompareModelInput struct {
+	Models    string `json:"models" form:"models" validate:"required"`
+	FixtureID int64  `json:"fixture_id" form:"fixture_id"`
+	LeagueID  int64  `json:"league_id" form:"league_id"`
+}
+
+// Comparison is a model version with its prediction of the fixture, null without a fixture or when the model
+// could not be fitted, and its latest finished backtest of the league, null when it has none
+type Comparison struct {
+	Model      Model                   `json:"model"`
+	Prediction *predictions.Prediction `json:"prediction"`
+	Backtest   *backtests.Backtest     `json:"backtest"`
+}
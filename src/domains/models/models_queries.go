@@ -0,0 +1,54 @@
+package models
+
+const (
+	// The version is the next one of the model name, two registrations racing for it conflict on the unique key
+	queryCreate = `INSERT INTO models(
+		name,
+		version,
+		hyperparameters,
+		description,
+		champion,
+		created_at)
+	SELECT
+		:name,
+		COALESCE(MAX(version), 0) + 1,
+		:hyperparameters,
+		:description,
+		:champion,
+		:created_at
+	FROM models WHERE name = :name`
+
+	queryFindByID      = `SELECT * FROM models WHERE id = ?`
+	queryFindByVersion = `SELECT * FROM models WHERE name = ? AND version = ?`
+	queryFindLatest    = `SELECT * FROM models WHERE name = ? ORDER BY version DESC LIMIT 1`
+	queryFindChampion  = `SELECT * FROM models WHERE champion = 1 LIMIT 1`
+
+	queryList      = `SELECT %s FROM models %s ORDER BY %s %s`
+	queryListTotal = `SELECT count(id) FROM models %s`
+
+	// A single statement moves the champion so there is never none or two
+	querySetChampion = `UPDATE models SET champion = (id = ?)`
+
+	queryFindFit = `SELECT * FROM model_fits WHERE model_id = ? AND league_id = ? AND season = ? AND fitted_before = ?`
+
+	querySaveFit = `INSERT INTO model_fits(
+		model_id,
+		league_id,
+		season,
+		fitted_before,
+		matches,
+		parameters,
+		created_at)
+	VALUES (
+		:model_id,
+		:league_id,
+		:season,
+		:fitted_before,
+		:matches,
+		:parameters,
+		:created_at)
+	ON DUPLICATE KEY UPDATE
+		matches = VALUES(matches),
+		parameters = VALUES(parameters),
+		created_at = VALUES(created_at)`
+)
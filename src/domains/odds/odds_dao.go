@@ -0,0 +1,110 @@
+package odds
+
+import (
+	"context"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type OddDaoI interface {
+	Upsert(ctx context.Context, odd *Odd) error
+	List(ctx context.Context, req *ListOddInput) ([]Odd, int64, error)
+	Latest(ctx context.Context, req *LatestOddInput) ([]Odd, error)
+}
+
+type oddDao struct{}
+
+var OddDao OddDaoI = &oddDao{}
+
+func (d *oddDao) Upsert(ctx context.Context, odd *Odd) error {
+	defer metrics.TimeQuery("OddDao", "Upsert")()
+	ctx, span := tracing.Start(ctx, "OddDao.Upsert")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryUpsert, odd)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("OddDao Upsert NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *oddDao) List(ctx context.Context, req *ListOddInput) ([]Odd, int64, error) {
+	defer metrics.TimeQuery("OddDao", "List")()
+	ctx, span := tracing.Start(ctx, "OddDao.List")
+	defer span.End()
+
+	var results []Odd
+	// Create where, limit and order by clauses
+	where, args := d.generateListWhereClause(req)
+	page := req.Filter.Page(req.Page, req.PerPage, results)
+	query := fmt.Sprintf(queryList, page.Select("*"), page.Where(where), page.OrderBy(), page.Limit())
+
+	// Get the records
+	err := footy_db.Client.SelectContext(ctx, &results, query, page.Args(args)...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("OddDao List Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+	results = page.Rows(results).([]Odd)
+
+	// Get total records so we can use them for pagination, unless the client asked to skip the count
+	if !req.Filter.Total() {
+		return results, pagination.TotalNotCounted, nil
+	}
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryListTotal, where), args...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("OddDao List GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	return results, total, nil
+}
+
+// Latest returns the last recorded price of every selection of a fixture, grouped by bookmaker, market and line
+func (d *oddDao) Latest(ctx context.Context, req *LatestOddInput) ([]Odd, error) {
+	defer metrics.TimeQuery("OddDao", "Latest")()
+	ctx, span := tracing.Start(ctx, "OddDao.Latest")
+	defer span.End()
+
+	w := helpers.NewWhere()
+	w.AppendWhereAtStart()
+	w.Where("fixture_id = ?", req.FixtureID)
+	if req.Market != "" {
+		w.Where("market = ?", req.Market)
+	}
+	if req.BookmakerID != 0 {
+		w.Where("bookmaker_id = ?", req.BookmakerID)
+	}
+	if !req.Before.IsZero() {
+		w.Where("recorded_at < ?", req.Before)
+	}
+	where, args := w.String()
+
+	var results []Odd
+	err := footy_db.Client.SelectContext(ctx, &results, fmt.Sprintf(queryLatest, where), args...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("OddDao Latest Select", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return results, nil
+}
+
+func (d *oddDao) generateListWhereClause(req *ListOddInput) (string, []interface{}) {
+	w := helpers.NewWhere()
+	w.AppendWhereAtStart()
+	w.Where("true") // add this just in case we do not have any param passed
+	w.Conditions(req.Filter.Conditions())
+
+	return w.String()
+}
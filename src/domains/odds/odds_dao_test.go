@@ -0,0 +1,227 @@
+package odds
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var (
+	testRecordedAt = time.Date(2022, 1, 14, 10, 0, 0, 0, time.UTC)
+	testColumns    = []string{
+		"id",
+		"fixture_id",
+		"bookmaker_id",
+		"bookmaker",
+		"market",
+		"selection",
+		"line",
+		"price",
+		"recorded_at",
+	}
+)
+
+func testOdd() Odd {
+	return Odd{
+		ID:          1,
+		FixtureID:   10,
+		BookmakerID: 8,
+		Bookmaker:   "Bet365",
+		Market:      MarketOverUnder,
+		Selection:   SelectionOver,
+		Line:        2.5,
+		Price:       1.85,
+		RecordedAt:  testRecordedAt,
+	}
+}
+
+func TestOddDao_Upsert(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO odds").
+					WithArgs(10, 8, "Bet365", "over_under", "over", 2.5, 1.85, testRecordedAt, 10, 8, "over_under", "over", 2.5, testRecordedAt, 1.85).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO odds").
+					WithArgs(10, 8, "Bet365", "over_under", "over", 2.5, 1.85, testRecordedAt, 10, 8, "over_under", "over", 2.5, testRecordedAt, 1.85).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			title: "success unchanged price skipped",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO odds(.|\n)+WHERE NOT EXISTS(.|\n)+WHERE previous.price = ?").
+					WithArgs(10, 8, "Bet365", "over_under", "over", 2.5, 1.85, testRecordedAt, 10, 8, "over_under", "over", 2.5, testRecordedAt, 1.85).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			odd := testOdd()
+			err = OddDao.Upsert(context.Background(), &odd)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestOddDao_List(t *testing.T) {
+	testCases := []struct {
+		title         string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []Odd
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM odds").
+					WithArgs(10, "over_under").
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM odds").
+					WithArgs(10, "over_under").
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(1, 10, 8, "Bet365", "over_under", "over", 2.5, 1.85, testRecordedAt))
+				m.ExpectQuery("SELECT (.+) FROM odds").
+					WithArgs(10, "over_under").
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM odds").
+					WithArgs(10, "over_under").
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(1, 10, 8, "Bet365", "over_under", "over", 2.5, 1.85, testRecordedAt))
+				m.ExpectQuery("SELECT (.+) FROM odds").
+					WithArgs(10, "over_under").
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes:   []Odd{testOdd()},
+			expectedTotal: 1,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			query, errs := filter.Parse(url.Values{"fixture_id": {"10"}, "market": {"over_under"}}, Odd{})
+			assert.Nil(t, errs)
+
+			res, total, err := OddDao.List(context.Background(), &ListOddInput{Filter: *query})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestOddDao_Latest(t *testing.T) {
+	testCases := []struct {
+		title       string
+		req         LatestOddInput
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes []Odd
+		expectedErr error
+	}{
+		{
+			title: "error Client.Select",
+			req:   LatestOddInput{FixtureID: 10},
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT o.\\* FROM odds o").
+					WithArgs(10).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "success",
+			req:   LatestOddInput{FixtureID: 10, Market: MarketOverUnder, BookmakerID: 8},
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT o.\\* FROM odds o (.+) WHERE fixture_id = \\? AND market = \\? AND bookmaker_id = \\?").
+					WithArgs(10, "over_under", 8).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(1, 10, 8, "Bet365", "over_under", "over", 2.5, 1.85, testRecordedAt))
+			},
+			expectedRes: []Odd{testOdd()},
+			expectedErr: nil,
+		},
+		{
+			title: "success before",
+			req:   LatestOddInput{FixtureID: 10, Before: testRecordedAt.Add(time.Hour)},
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT o.\\* FROM odds o (.+) WHERE fixture_id = \\? AND recorded_at < \\?").
+					WithArgs(10, testRecordedAt.Add(time.Hour)).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(1, 10, 8, "Bet365", "over_under", "over", 2.5, 1.85, testRecordedAt))
+			},
+			expectedRes: []Odd{testOdd()},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := OddDao.Latest(context.Background(), &testCase.req)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
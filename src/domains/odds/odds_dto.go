@@ -0,0 +1,84 @@
+package odds
+
+import (
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"time"
+)
+
+// Markets stored for every bookmaker
+const (
+	Market1X2           = "1x2"
+	MarketOverUnder     = "over_under"
+	MarketBTTS          = "btts"
+	MarketAsianHandicap = "asian_handicap"
+)
+
+// Selections of the markets, over_under has over and under, btts has yes and no, the others home and away
+// and 1x2 also draw
+const (
+	SelectionHome  = "home"
+	SelectionDraw  = "draw"
+	SelectionAway  = "away"
+	SelectionOver  = "over"
+	SelectionUnder = "under"
+	SelectionYes   = "yes"
+	SelectionNo    = "no"
+)
+
+// Odd is the decimal price of a selection at the time the bookmaker last changed it. Every change is kept so
+// the rows of a selection are its line movement. Line is the goals of over_under and the handicap of the home
+// team for asian_handicap, so both selections of a line share it, it is 0 for the other markets
+type Odd struct {
+	ID          int64     `json:"id" db:"id" filter:"eq,in"`
+	FixtureID   int64     `json:"fixture_id" db:"fixture_id" filter:"eq,in,sort"`
+	BookmakerID int64     `json:"bookmaker_id" db:"bookmaker_id" filter:"eq,ne,in"`
+	Bookmaker   string    `json:"bookmaker" db:"bookmaker" filter:"like,eq"`
+	Market      string    `json:"market" db:"market" filter:"eq,ne,in,oneof=1x2 over_under btts asian_handicap"`
+	Selection   string    `json:"selection" db:"selection" filter:"eq,in,oneof=home draw away over under yes no"`
+	Line        float64   `json:"line" db:"line" filter:"eq,gt,gte,lt,lte"`
+	Price       float64   `json:"price" db:"price" filter:"gt,gte,lt,lte,sort"`
+	RecordedAt  time.Time `json:"recorded_at" db:"recorded_at" filter:"gt,gte,lt,lte,sort,default=desc"`
+}
+
+// ListOddInput filters on the fields of Odd, see its filter tags
+type ListOddInput struct {
+	Page    int64        `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage int64        `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+	Filter  filter.Query `json:"-" form:"-"`
+}
+
+// LatestOddInput selects the current odds of a fixture, Method removes the overround of their implied
+// probabilities, see predictor.Implied. Before keeps the prices recorded before a time, e.g. the kickoff
+type LatestOddInput struct {
+	FixtureID   int64     `json:"-" form:"-"`
+	Before      time.Time `json:"-" form:"-"`
+	Market      string    `json:"market" form:"market" validate:"omitempty,oneof=1x2 over_under btts asian_handicap"`
+	BookmakerID int64     `json:"bookmaker_id" form:"bookmaker_id"`
+	Method      string    `json:"method" form:"method" validate:"omitempty,oneof=basic shin power"`
+}
+
+// MarketOdds are the current odds of a market line of a bookmaker with their implied probabilities
+type MarketOdds struct {
+	BookmakerID int64           `json:"bookmaker_id"`
+	Bookmaker   string          `json:"bookmaker"`
+	Market      string          `json:"market"`
+	Line        float64         `json:"line"`
+	RecordedAt  time.Time       `json:"recorded_at"`
+	Overround   float64         `json:"overround"`
+	Selections  []SelectionOdds `json:"selections"`
+}
+
+type SelectionOdds struct {
+	Selection   string  `json:"selection"`
+	Price       float64 `json:"price"`
+	Probability float64 `json:"probability"`
+}
+
+// SyncOddInput imports the odds of a fixture, or of the fixtures of a league season, optionally of a single
+// bookmaker
+type SyncOddInput struct {
+	FixtureID   int64 `json:"fixture_id" form:"fixture_id" validate:"required_without=LeagueID"`
+	LeagueID    int64 `json:"league_id" form:"league_id" validate:"required_without=FixtureID"`
+	Season      int64 `json:"season" form:"season" validate:"required_with=LeagueID"`
+	BookmakerID int64 `json:"bookmaker_id" form:"bookmaker_id"`
+}
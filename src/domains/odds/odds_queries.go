@@ -0,0 +1,48 @@
+package odds
+
+const (
+	// A price recorded again at the same time is the same row, so syncing twice does not duplicate the history. A
+	// price equal to the previous one of the selection is skipped since the bookmaker update time moves with any of
+	// the prices of the fixture. The previous price is read through a derived table, which MySQL materializes
+	// before inserting into the same table
+	queryUpsert = `INSERT INTO odds(
+		fixture_id,
+		bookmaker_id,
+		bookmaker,
+		market,
+		selection,
+		line,
+		price,
+		recorded_at)
+	SELECT
+		:fixture_id,
+		:bookmaker_id,
+		:bookmaker,
+		:market,
+		:selection,
+		:line,
+		:price,
+		:recorded_at
+	FROM DUAL
+	WHERE NOT EXISTS (SELECT 1 FROM (
+		SELECT price FROM odds
+		WHERE fixture_id = :fixture_id AND bookmaker_id = :bookmaker_id AND market = :market AND selection = :selection
+			AND line = :line AND recorded_at < :recorded_at
+		ORDER BY recorded_at DESC
+		LIMIT 1) previous
+		WHERE previous.price = :price)
+	ON DUPLICATE KEY UPDATE
+		bookmaker = VALUES(bookmaker),
+		price = VALUES(price)`
+
+	queryList      = `SELECT %s FROM odds %s ORDER BY %s %s`
+	queryListTotal = `SELECT count(id) FROM odds %s`
+
+	// The last recorded price of every selection of the fixture
+	queryLatest = `SELECT o.* FROM odds o
+	JOIN (SELECT fixture_id, bookmaker_id, market, selection, line, MAX(recorded_at) AS recorded_at
+		FROM odds %s
+		GROUP BY fixture_id, bookmaker_id, market, selection, line) latest
+	USING (fixture_id, bookmaker_id, market, selection, line, recorded_at)
+	ORDER BY o.bookmaker_id, o.market, o.line, o.id`
+)
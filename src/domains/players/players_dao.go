@@ -0,0 +1,161 @@
+package players
+
+import (
+	"context"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type PlayerDaoI interface {
+	Upsert(ctx context.Context, player *Player) error
+	FindByID(ctx context.Context, id int64) (*Player, error)
+	List(ctx context.Context, req *ListPlayerInput) ([]Player, int64, error)
+	UpsertStatistic(ctx context.Context, statistic *Statistic) error
+	ListStatistics(ctx context.Context, playerID int64) ([]Statistic, error)
+	Leaders(ctx context.Context, req *ListLeaderInput) ([]Leader, int64, error)
+}
+
+type playerDao struct{}
+
+var PlayerDao PlayerDaoI = &playerDao{}
+
+func (d *playerDao) Upsert(ctx context.Context, player *Player) error {
+	defer metrics.TimeQuery("PlayerDao", "Upsert")()
+	ctx, span := tracing.Start(ctx, "PlayerDao.Upsert")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryUpsert, player)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("PlayerDao Upsert NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *playerDao) FindByID(ctx context.Context, id int64) (*Player, error) {
+	defer metrics.TimeQuery("PlayerDao", "FindByID")()
+	ctx, span := tracing.Start(ctx, "PlayerDao.FindByID")
+	defer span.End()
+
+	var result Player
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindByID, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("PlayerDao FindByID Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+func (d *playerDao) List(ctx context.Context, req *ListPlayerInput) ([]Player, int64, error) {
+	defer metrics.TimeQuery("PlayerDao", "List")()
+	ctx, span := tracing.Start(ctx, "PlayerDao.List")
+	defer span.End()
+
+	var results []Player
+	// Create where, limit and order by clauses
+	where, args := d.generateListWhereClause(req)
+	page := req.Filter.Page(req.Page, req.PerPage, results)
+	query := fmt.Sprintf(queryList, page.Select("*"), page.Where(where), page.OrderBy(), page.Limit())
+
+	// Get the records
+	err := footy_db.Client.SelectContext(ctx, &results, query, page.Args(args)...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("PlayerDao List Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+	results = page.Rows(results).([]Player)
+
+	// Get total records so we can use them for pagination, unless the client asked to skip the count
+	if !req.Filter.Total() {
+		return results, pagination.TotalNotCounted, nil
+	}
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryListTotal, where), args...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("PlayerDao List GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	return results, total, nil
+}
+
+func (d *playerDao) generateListWhereClause(req *ListPlayerInput) (string, []interface{}) {
+	w := helpers.NewWhere()
+	w.AppendWhereAtStart()
+	w.Where("true") // add this just in case we do not have any param passed
+	w.Conditions(req.Filter.Conditions())
+
+	return w.String()
+}
+
+func (d *playerDao) UpsertStatistic(ctx context.Context, statistic *Statistic) error {
+	defer metrics.TimeQuery("PlayerDao", "UpsertStatistic")()
+	ctx, span := tracing.Start(ctx, "PlayerDao.UpsertStatistic")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryUpsertStatistic, statistic)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("PlayerDao UpsertStatistic NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *playerDao) ListStatistics(ctx context.Context, playerID int64) ([]Statistic, error) {
+	defer metrics.TimeQuery("PlayerDao", "ListStatistics")()
+	ctx, span := tracing.Start(ctx, "PlayerDao.ListStatistics")
+	defer span.End()
+
+	var results []Statistic
+
+	err := footy_db.Client.SelectContext(ctx, &results, queryListStatistics, playerID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("PlayerDao ListStatistics Select", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return results, nil
+}
+
+// Leaders ranks the players of a league season by goals or assists, see queryLeaders
+func (d *playerDao) Leaders(ctx context.Context, req *ListLeaderInput) ([]Leader, int64, error) {
+	defer metrics.TimeQuery("PlayerDao", "Leaders")()
+	ctx, span := tracing.Start(ctx, "PlayerDao.Leaders")
+	defer span.End()
+
+	stat, other := LeaderGoals, LeaderAssists
+	if req.Stat == LeaderAssists {
+		stat, other = LeaderAssists, LeaderGoals
+	}
+	var results []Leader
+	limit := pagination.GeneratePaginationQuery(req.Page, req.PerPage)
+
+	// Get the records
+	err := footy_db.Client.SelectContext(ctx, &results, fmt.Sprintf(queryLeaders, stat, other, limit), req.LeagueID, req.Season)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("PlayerDao Leaders Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	// Get total records so we can use them for pagination
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryLeadersTotal, stat), req.LeagueID, req.Season)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("PlayerDao Leaders GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	return results, total, nil
+}
@@ -0,0 +1,394 @@
+package players
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var testColumns = []string{"id", "name", "firstname", "lastname", "age", "birth_date", "nationality", "height", "weight",
+	"photo", "team_id", "team_name", "number", "position"}
+
+var testStatisticColumns = []string{"player_id", "team_id", "team_name", "league_id", "season", "position", "appearances",
+	"lineups", "minutes", "goals", "assists", "yellow_cards", "red_cards", "rating"}
+
+var testBirthDate = time.Date(1987, 6, 24, 0, 0, 0, 0, time.UTC)
+
+func testPlayer() Player {
+	age, teamID, number := int64(34), int64(85), int64(30)
+	height, weight := "170 cm", "72 kg"
+	teamName, position := "Paris Saint Germain", "Attacker"
+	return Player{
+		ID:          154,
+		Name:        "L. Messi",
+		Firstname:   "Lionel Andrés",
+		Lastname:    "Messi Cuccittini",
+		Age:         &age,
+		BirthDate:   &testBirthDate,
+		Nationality: "Argentina",
+		Height:      &height,
+		Weight:      &weight,
+		Photo:       "https://media.api-sports.io/football/players/154.png",
+		TeamID:      &teamID,
+		TeamName:    &teamName,
+		Number:      &number,
+		Position:    &position,
+	}
+}
+
+func testPlayerRow() []driver.Value {
+	return []driver.Value{154, "L. Messi", "Lionel Andrés", "Messi Cuccittini", 34, testBirthDate, "Argentina", "170 cm",
+		"72 kg", "https://media.api-sports.io/football/players/154.png", 85, "Paris Saint Germain", 30, "Attacker"}
+}
+
+func testStatistic() Statistic {
+	rating := 7.235
+	return Statistic{
+		PlayerID:    154,
+		TeamID:      85,
+		TeamName:    "Paris Saint Germain",
+		LeagueID:    61,
+		Season:      2021,
+		Position:    "Attacker",
+		Appearances: 26,
+		Lineups:     24,
+		Minutes:     2153,
+		Goals:       6,
+		Assists:     14,
+		YellowCards: 2,
+		RedCards:    0,
+		Rating:      &rating,
+	}
+}
+
+func TestPlayerDao_Upsert(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO players").
+					WithArgs(154, "L. Messi", "Lionel Andrés", "Messi Cuccittini", 34, testBirthDate, "Argentina", "170 cm", "72 kg",
+						"https://media.api-sports.io/football/players/154.png").
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO players").
+					WithArgs(154, "L. Messi", "Lionel Andrés", "Messi Cuccittini", 34, testBirthDate, "Argentina", "170 cm", "72 kg",
+						"https://media.api-sports.io/football/players/154.png").
+					WillReturnResult(sqlmock.NewResult(154, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			player := testPlayer()
+			err = PlayerDao.Upsert(context.Background(), &player)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestPlayerDao_FindByID(t *testing.T) {
+	expected := testPlayer()
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *Player
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM (.+) players").
+					WithArgs(154).
+					WillReturnError(errors.New("test Get"))
+			},
+			expectedErr: errors.New("test Get"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM (.+) LEFT JOIN squads s ON s.player_id = p.id AND s.team_id = (.+) LIMIT 1\\)\\) players WHERE id = \\? LIMIT 1").
+					WithArgs(154).
+					WillReturnRows(sqlmock.NewRows(testColumns).AddRow(testPlayerRow()...))
+			},
+			expectedRes: &expected,
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := PlayerDao.FindByID(context.Background(), 154)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestPlayerDao_List(t *testing.T) {
+	testCases := []struct {
+		title         string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []Player
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM (.+) players").
+					WithArgs("%Messi%", "Attacker", 85).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM (.+) players").
+					WithArgs("%Messi%", "Attacker", 85).
+					WillReturnRows(sqlmock.NewRows(testColumns).AddRow(testPlayerRow()...))
+				m.ExpectQuery("SELECT count(.+) FROM (.+) players").
+					WithArgs("%Messi%", "Attacker", 85).
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM (.+) players").
+					WithArgs("%Messi%", "Attacker", 85).
+					WillReturnRows(sqlmock.NewRows(testColumns).AddRow(testPlayerRow()...))
+				m.ExpectQuery("SELECT count(.+) FROM (.+) players").
+					WithArgs("%Messi%", "Attacker", 85).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes:   []Player{testPlayer()},
+			expectedTotal: 1,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			query, errs := filter.Parse(url.Values{"name": {"Messi"}, "team_id": {"85"}, "position": {"Attacker"}}, Player{})
+			assert.Nil(t, errs)
+
+			res, total, err := PlayerDao.List(context.Background(), &ListPlayerInput{Filter: *query})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestPlayerDao_UpsertStatistic(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO player_statistics").
+					WithArgs(154, 85, "Paris Saint Germain", 61, 2021, "Attacker", 26, 24, 2153, 6, 14, 2, 0, 7.235).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO player_statistics").
+					WithArgs(154, 85, "Paris Saint Germain", 61, 2021, "Attacker", 26, 24, 2153, 6, 14, 2, 0, 7.235).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			statistic := testStatistic()
+			err = PlayerDao.UpsertStatistic(context.Background(), &statistic)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestPlayerDao_ListStatistics(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes []Statistic
+		expectedErr error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM player_statistics").
+					WithArgs(154).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM player_statistics").
+					WithArgs(154).
+					WillReturnRows(sqlmock.NewRows(testStatisticColumns).
+						AddRow(154, 85, "Paris Saint Germain", 61, 2021, "Attacker", 26, 24, 2153, 6, 14, 2, 0, 7.235))
+			},
+			expectedRes: []Statistic{testStatistic()},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := PlayerDao.ListStatistics(context.Background(), 154)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestPlayerDao_Leaders(t *testing.T) {
+	rating := 7.235
+	leaderColumns := []string{"player_id", "name", "photo", "team_id", "team_name", "appearances", "minutes", "goals", "assists", "rating"}
+	leader := Leader{PlayerID: 154, Name: "L. Messi", Photo: "https://media.api-sports.io/football/players/154.png", TeamID: 85,
+		TeamName: "Paris Saint Germain", Appearances: 26, Minutes: 2153, Goals: 6, Assists: 14, Rating: &rating}
+
+	testCases := []struct {
+		title         string
+		stat          string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []Leader
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM player_statistics (.+) GROUP BY s.player_id(.+) HAVING goals > 0 ORDER BY goals DESC, assists DESC").
+					WithArgs(61, 2021).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM player_statistics (.+) GROUP BY s.player_id(.+) HAVING goals > 0 ORDER BY goals DESC, assists DESC").
+					WithArgs(61, 2021).
+					WillReturnRows(sqlmock.NewRows(leaderColumns).
+						AddRow(154, "L. Messi", "https://media.api-sports.io/football/players/154.png", 85, "Paris Saint Germain", 26, 2153, 6, 14, 7.235))
+				m.ExpectQuery("SELECT count(.+) FROM player_statistics (.+) GROUP BY player_id HAVING SUM\\(goals\\) > 0").
+					WithArgs(61, 2021).
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
+		{
+			title: "success assists",
+			stat:  LeaderAssists,
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM player_statistics (.+) GROUP BY s.player_id(.+) HAVING assists > 0 ORDER BY assists DESC, goals DESC").
+					WithArgs(61, 2021).
+					WillReturnRows(sqlmock.NewRows(leaderColumns).
+						AddRow(154, "L. Messi", "https://media.api-sports.io/football/players/154.png", 85, "Paris Saint Germain", 26, 2153, 6, 14, 7.235))
+				m.ExpectQuery("SELECT count(.+) FROM player_statistics (.+) GROUP BY player_id HAVING SUM\\(assists\\) > 0").
+					WithArgs(61, 2021).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes:   []Leader{leader},
+			expectedTotal: 1,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, total, err := PlayerDao.Leaders(context.Background(), &ListLeaderInput{LeagueID: 61, Season: 2021, Stat: testCase.stat})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
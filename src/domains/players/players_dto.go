@@ -0,0 +1,96 @@
+package players
+
+import (
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"time"
+)
+
+// The statistics the players are ranked by on the leaderboards
+const (
+	LeaderGoals   = "goals"
+	LeaderAssists = "assists"
+)
+
+// Player is a player with the team of its current squad, the one synced last when the player is in several, e.g. of
+// a club and of a national team. The team, number and position are null when the player is in no synced squad
+type Player struct {
+	ID          int64      `json:"id" db:"id" filter:"eq,in,sort"`
+	Name        string     `json:"name" db:"name" filter:"like,eq,sort,default"`
+	Firstname   string     `json:"firstname" db:"firstname" filter:"like"`
+	Lastname    string     `json:"lastname" db:"lastname" filter:"like,sort"`
+	Age         *int64     `json:"age" db:"age" filter:"eq,gt,gte,lt,lte,sort"`
+	BirthDate   *time.Time `json:"birth_date" db:"birth_date"`
+	Nationality string     `json:"nationality" db:"nationality" filter:"eq,in,like"`
+	Height      *string    `json:"height" db:"height"`
+	Weight      *string    `json:"weight" db:"weight"`
+	Photo       string     `json:"photo" db:"photo"`
+	TeamID      *int64     `json:"team_id" db:"team_id" filter:"eq,in,null"`
+	TeamName    *string    `json:"team_name" db:"team_name" filter:"like,eq"`
+	Number      *int64     `json:"number" db:"number" filter:"eq"`
+	Position    *string    `json:"position" db:"position" filter:"eq,in,oneof=Goalkeeper Defender Midfielder Attacker"`
+}
+
+// ListPlayerInput filters on the fields of Player, see its filter tags
+type ListPlayerInput struct {
+	Page    int64        `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage int64        `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+	Filter  filter.Query `json:"-" form:"-"`
+}
+
+// Statistic holds the statistics of a player for a team in a league season, a player moving during the season
+// has one per team. RedCards counts the second yellow cards and the rating is the average of the matches played
+type Statistic struct {
+	PlayerID    int64    `json:"player_id" db:"player_id"`
+	TeamID      int64    `json:"team_id" db:"team_id"`
+	TeamName    string   `json:"team_name" db:"team_name"`
+	LeagueID    int64    `json:"league_id" db:"league_id"`
+	Season      int64    `json:"season" db:"season"`
+	Position    string   `json:"position" db:"position"`
+	Appearances int64    `json:"appearances" db:"appearances"`
+	Lineups     int64    `json:"lineups" db:"lineups"`
+	Minutes     int64    `json:"minutes" db:"minutes"`
+	Goals       int64    `json:"goals" db:"goals"`
+	Assists     int64    `json:"assists" db:"assists"`
+	YellowCards int64    `json:"yellow_cards" db:"yellow_cards"`
+	RedCards    int64    `json:"red_cards" db:"red_cards"`
+	Rating      *float64 `json:"rating" db:"rating"`
+}
+
+// PlayerOutput is a player with its statistics of every synced season, the latest first
+type PlayerOutput struct {
+	Player
+	Statistics []Statistic `json:"statistics"`
+}
+
+// ListLeaderInput selects a leaderboard of a league season, Stat is set by the controller from the path
+type ListLeaderInput struct {
+	LeagueID int64  `json:"league_id" form:"league_id" validate:"required"`
+	Season   int64  `json:"season" form:"season" validate:"required"`
+	Stat     string `json:"-" form:"-"`
+	Page     int64  `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage  int64  `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+}
+
+// Leader is a player of a leaderboard with its statistics summed over the teams it played for in the season, TeamID
+// is the team it played the most minutes for and Rating the average over its appearances. Position continues across
+// the pages
+type Leader struct {
+	Position    int64    `json:"position" db:"-"`
+	PlayerID    int64    `json:"player_id" db:"player_id"`
+	Name        string   `json:"name" db:"name"`
+	Photo       string   `json:"photo" db:"photo"`
+	TeamID      int64    `json:"team_id" db:"team_id"`
+	TeamName    string   `json:"team_name" db:"team_name"`
+	Appearances int64    `json:"appearances" db:"appearances"`
+	Minutes     int64    `json:"minutes" db:"minutes"`
+	Goals       int64    `json:"goals" db:"goals"`
+	Assists     int64    `json:"assists" db:"assists"`
+	Rating      *float64 `json:"rating" db:"rating"`
+}
+
+// SyncPlayerInput imports the squad of a team, or the players of a league season with their statistics
+type SyncPlayerInput struct {
+	TeamID   int64 `json:"team_id" form:"team_id" validate:"required_without=LeagueID"`
+	LeagueID int64 `json:"league_id" form:"league_id" validate:"required_without=TeamID"`
+	Season   int64 `json:"season" form:"season" validate:"required_with=LeagueID"`
+}
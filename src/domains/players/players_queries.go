@@ -0,0 +1,122 @@
+package players
+
+const (
+	// The squads only know the name, age and photo of a player, the other fields are kept when they are empty
+	queryUpsert = `INSERT INTO players(
+		id,
+		name,
+		firstname,
+		lastname,
+		age,
+		birth_date,
+		nationality,
+		height,
+		weight,
+		photo)
+	VALUES (
+		:id,
+		:name,
+		:firstname,
+		:lastname,
+		:age,
+		:birth_date,
+		:nationality,
+		:height,
+		:weight,
+		:photo)
+	ON DUPLICATE KEY UPDATE
+		name = VALUES(name),
+		firstname = COALESCE(NULLIF(VALUES(firstname), ''), firstname),
+		lastname = COALESCE(NULLIF(VALUES(lastname), ''), lastname),
+		age = COALESCE(VALUES(age), age),
+		birth_date = COALESCE(VALUES(birth_date), birth_date),
+		nationality = COALESCE(NULLIF(VALUES(nationality), ''), nationality),
+		height = COALESCE(VALUES(height), height),
+		weight = COALESCE(VALUES(weight), weight),
+		photo = COALESCE(NULLIF(VALUES(photo), ''), photo)`
+
+	// The players are selected with the team of their squad, so they can be filtered on it. A player in several
+	// squads is joined to the one synced last, the lowest team id first, so every player is a single row
+	queryPlayers = `(SELECT p.*, s.team_id, s.team_name, s.number, s.position
+		FROM players p LEFT JOIN squads s ON s.player_id = p.id AND s.team_id = (
+			SELECT latest.team_id FROM squads latest
+			WHERE latest.player_id = p.id
+			ORDER BY latest.synced_at DESC, latest.team_id
+			LIMIT 1)) players`
+
+	queryFindByID = `SELECT * FROM ` + queryPlayers + ` WHERE id = ? LIMIT 1`
+
+	queryList      = `SELECT %s FROM ` + queryPlayers + ` %s ORDER BY %s %s`
+	queryListTotal = `SELECT count(id) FROM ` + queryPlayers + ` %s`
+
+	queryUpsertStatistic = `INSERT INTO player_statistics(
+		player_id,
+		team_id,
+		team_name,
+		league_id,
+		season,
+		position,
+		appearances,
+		lineups,
+		minutes,
+		goals,
+		assists,
+		yellow_cards,
+		red_cards,
+		rating)
+	VALUES (
+		:player_id,
+		:team_id,
+		:team_name,
+		:league_id,
+		:season,
+		:position,
+		:appearances,
+		:lineups,
+		:minutes,
+		:goals,
+		:assists,
+		:yellow_cards,
+		:red_cards,
+		:rating)
+	ON DUPLICATE KEY UPDATE
+		team_name = VALUES(team_name),
+		position = VALUES(position),
+		appearances = VALUES(appearances),
+		lineups = VALUES(lineups),
+		minutes = VALUES(minutes),
+		goals = VALUES(goals),
+		assists = VALUES(assists),
+		yellow_cards = VALUES(yellow_cards),
+		red_cards = VALUES(red_cards),
+		rating = VALUES(rating)`
+
+	queryListStatistics = `SELECT * FROM player_statistics WHERE player_id = ? ORDER BY season DESC, league_id, team_id`
+
+	// The leaderboards sum the statistics of a player over the teams of the season, the team shown is the one the
+	// player played the most minutes for and the rating is the average over the appearances. They rank by the stat,
+	// then by the other one of goals and assists, then by the fewest minutes. The stat is a column name, never a user
+	// input
+	queryLeaders = `SELECT
+		s.player_id,
+		p.name,
+		p.photo,
+		CAST(SUBSTRING_INDEX(GROUP_CONCAT(s.team_id ORDER BY s.minutes DESC, s.team_id), ',', 1) AS UNSIGNED) AS team_id,
+		SUBSTRING_INDEX(GROUP_CONCAT(s.team_name ORDER BY s.minutes DESC, s.team_id SEPARATOR '\n'), '\n', 1) AS team_name,
+		SUM(s.appearances) AS appearances,
+		SUM(s.minutes) AS minutes,
+		SUM(s.goals) AS goals,
+		SUM(s.assists) AS assists,
+		ROUND(SUM(s.rating * s.appearances) / NULLIF(SUM(IF(s.rating IS NULL, 0, s.appearances)), 0), 3) AS rating
+	FROM player_statistics s
+	INNER JOIN players p ON p.id = s.player_id
+	WHERE s.league_id = ? AND s.season = ?
+	GROUP BY s.player_id, p.name, p.photo
+	HAVING %[1]s > 0
+	ORDER BY %[1]s DESC, %[2]s DESC, minutes ASC, p.name ASC, s.player_id ASC %[3]s`
+	queryLeadersTotal = `SELECT count(*) FROM (
+		SELECT player_id FROM player_statistics
+		WHERE league_id = ? AND season = ?
+		GROUP BY player_id
+		HAVING SUM(%s) > 0) leaders`
+)
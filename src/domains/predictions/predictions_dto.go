@@ -0,0 +1,79 @@
+package predictions
+
+type Score struct {
+	HomeGoals   int64   `json:"home_goals"`
+	AwayGoals   int64   `json:"away_goals"`
+	Probability float64 `json:"probability"`
+}
+
+// GoalLine is the probability of more and fewer total goals than a half line
+type GoalLine struct {
+	Line  float64 `json:"line"`
+	Over  float64 `json:"over"`
+	Under float64 `json:"under"`
+}
+
+// BothTeamsScore is the probability of both teams scoring or not
+type BothTeamsScore struct {
+	Yes float64 `json:"yes"`
+	No  float64 `json:"no"`
+}
+
+// Handicap is the probability of each team winning once the half line is added to the goals of the home team
+type Handicap struct {
+	Line float64 `json:"line"`
+	Home float64 `json:"home"`
+	Away float64 `json:"away"`
+}
+
+// Markets are the betting markets derived from the score matrix of the model
+type Markets struct {
+	OverUnder      []GoalLine     `json:"over_under"`
+	BothTeamsScore BothTeamsScore `json:"both_teams_score"`
+	AsianHandicap  []Handicap     `json:"asian_handicap"`
+}
+
+// MissingPlayer is a player missing the fixture with the share of the minutes of their team they played
+type MissingPlayer struct {
+	PlayerID     int64   `json:"player_id"`
+	PlayerName   string  `json:"player_name"`
+	Reason       string  `json:"reason"`
+	MinutesShare float64 `json:"minutes_share"`
+}
+
+// Availability is the adjustment of the expected goals for the players missing the fixture. HomeMissing and
+// AwayMissing are the parts of the usual eleven of each team missing, the factors multiply the expected goals of
+// the model
+type Availability struct {
+	Weight      float64         `json:"weight"`
+	HomeMissing float64         `json:"home_missing"`
+	AwayMissing float64         `json:"away_missing"`
+	HomeFactor  float64         `json:"home_factor"`
+	AwayFactor  float64         `json:"away_factor"`
+	HomePlayers []MissingPlayer `json:"home_players"`
+	AwayPlayers []MissingPlayer `json:"away_players"`
+}
+
+type Prediction struct {
+	FixtureID         int64   `json:"fixture_id"`
+	Model             string  `json:"model"`
+	HomeTeamID        int64   `json:"home_team_id"`
+	AwayTeamID        int64   `json:"away_team_id"`
+	HomeExpectedGoals float64 `json:"home_expected_goals"`
+	AwayExpectedGoals float64 `json:"away_expected_goals"`
+	HomeWin           float64 `json:"home_win"`
+	Draw              float64 `json:"draw"`
+	AwayWin           float64 `json:"away_win"`
+	MostLikelyScore   Score   `json:"most_likely_score"`
+	Markets           Markets `json:"markets"`
+	// Availability is set when the model version applies an availability_weight
+	Availability *Availability `json:"availability,omitempty"`
+	// MatchesUsed is the number of finished fixtures the model was fitted on
+	MatchesUsed int `json:"matches_used"`
+}
+
+// PredictionInput selects the model version by its name@version reference, or its name for the latest version,
+// the champion when empty
+type PredictionInput struct {
+	Model string `json:"model" form:"model" validate:"max=50"`
+}
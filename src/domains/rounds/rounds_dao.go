@@ -0,0 +1,50 @@
+package rounds
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type RoundDaoI interface {
+	Upsert(ctx context.Context, round *Round) error
+	ListBySeason(ctx context.Context, leagueID int64, season int64) ([]Round, error)
+}
+
+type roundDao struct{}
+
+var RoundDao RoundDaoI = &roundDao{}
+
+func (d *roundDao) Upsert(ctx context.Context, round *Round) error {
+	defer metrics.TimeQuery("RoundDao", "Upsert")()
+	ctx, span := tracing.Start(ctx, "RoundDao.Upsert")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryUpsert, round)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("RoundDao Upsert NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+// ListBySeason returns the rounds of a league season in the order they are played
+func (d *roundDao) ListBySeason(ctx context.Context, leagueID int64, season int64) ([]Round, error) {
+	defer metrics.TimeQuery("RoundDao", "ListBySeason")()
+	ctx, span := tracing.Start(ctx, "RoundDao.ListBySeason")
+	defer span.End()
+
+	var results []Round
+
+	err := footy_db.Client.SelectContext(ctx, &results, queryListBySeason, leagueID, season)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("RoundDao ListBySeason Select", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return results, nil
+}
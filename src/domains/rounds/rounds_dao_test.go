@@ -0,0 +1,133 @@
+package rounds
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testColumns = []string{"league_id", "season", "name", "position", "stage", "legs"}
+
+func testRound() Round {
+	return Round{
+		LeagueID: 2,
+		Season:   2021,
+		Name:     "Round of 16",
+		Position: 7,
+		Stage:    StageKnockout,
+		Legs:     2,
+	}
+}
+
+func TestRoundDao_Upsert(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO rounds").
+					WithArgs(2, 2021, "Round of 16", 7, "knockout", 2).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO rounds").
+					WithArgs(2, 2021, "Round of 16", 7, "knockout", 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			round := testRound()
+			err = RoundDao.Upsert(context.Background(), &round)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestRoundDao_ListBySeason(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes []Round
+		expectedErr error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM rounds").
+					WithArgs(2, 2021).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM rounds").
+					WithArgs(2, 2021).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(2, 2021, "Round of 16", 7, "knockout", 2))
+			},
+			expectedRes: []Round{testRound()},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := RoundDao.ListBySeason(context.Background(), 2, 2021)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestStageOf(t *testing.T) {
+	testCases := map[string]string{
+		"Regular Season - 21":      StageLeague,
+		"1st Qualifying Round":     StageQualifying,
+		"Preliminary Round":        StageQualifying,
+		"Group A - 3":              StageGroup,
+		"League Stage - 8":         StageGroup,
+		"Round of 16":              StageKnockout,
+		"Quarter-finals":           StageKnockout,
+		"Final":                    StageKnockout,
+		"3rd Place Final":          StagePlacement,
+		"Knockout Round Play-offs": StageKnockout,
+	}
+	for name, expected := range testCases {
+		assert.Equal(t, expected, StageOf(name), name)
+	}
+}
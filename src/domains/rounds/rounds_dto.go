@@ -0,0 +1,47 @@
+package rounds
+
+import "strings"
+
+// The stages of a competition, a league season has a single league round per matchday while the cups have
+// groups and knockout rounds
+const (
+	StageLeague     = "league"
+	StageQualifying = "qualifying"
+	StageGroup      = "group"
+	StageKnockout   = "knockout"
+	StagePlacement  = "placement"
+)
+
+// Round is a round of a league season as named by the league.round field of API Sports, e.g. "Round of 16".
+// Position orders the rounds by their first kickoff and Legs is 2 when the teams of a tie meet home and away
+type Round struct {
+	LeagueID int64  `json:"league_id" db:"league_id"`
+	Season   int64  `json:"season" db:"season"`
+	Name     string `json:"name" db:"name"`
+	Position int64  `json:"position" db:"position"`
+	Stage    string `json:"stage" db:"stage"`
+	Legs     int64  `json:"legs" db:"legs"`
+}
+
+// Knockout reports whether the teams of the round play ties, the losers leaving the competition
+func (r *Round) Knockout() bool {
+	return r.Stage == StageKnockout
+}
+
+// StageOf guesses the stage of a round from its API Sports name. The knockout rounds played before the group
+// stage are qualifying rounds, which only the order of the rounds tells, see Round.Position
+func StageOf(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasPrefix(lower, "regular season"):
+		return StageLeague
+	case strings.Contains(lower, "qualifying"), strings.Contains(lower, "preliminary"):
+		return StageQualifying
+	case strings.Contains(lower, "group"), strings.HasPrefix(lower, "league stage"):
+		return StageGroup
+	case strings.Contains(lower, "3rd place"), strings.Contains(lower, "third place"):
+		return StagePlacement
+	default:
+		return StageKnockout
+	}
+}
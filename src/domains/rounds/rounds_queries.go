@@ -0,0 +1,24 @@
+package rounds
+
+const (
+	queryUpsert = `INSERT INTO rounds(
+		league_id,
+		season,
+		name,
+		position,
+		stage,
+		legs)
+	VALUES (
+		:league_id,
+		:season,
+		:name,
+		:position,
+		:stage,
+		:legs)
+	ON DUPLICATE KEY UPDATE
+		position = VALUES(position),
+		stage = VALUES(stage),
+		legs = VALUES(legs)`
+
+	queryListBySeason = `SELECT * FROM rounds WHERE league_id = ? AND season = ? ORDER BY position`
+)
@@ -1,78 +1,205 @@
 package seasons
 
 import (
+	"context"
 	"fmt"
 	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
 	"github.com/development-raul/footy-predictor/src/utils/helpers"
 	"github.com/development-raul/footy-predictor/src/utils/pagination"
 	"github.com/development-raul/footy-predictor/src/zlog"
+	"time"
 )
 
 type SeasonDaoI interface {
-	Create(id int64) error
-	Find(id int64) (*Season, error)
-	List(req *ListSeasonInput) ([]Season, error)
-	Delete(id int64) error
+	Create(ctx context.Context, season *Season) error
+	Upsert(ctx context.Context, season *Season) error
+	// Update applies when the season is still at season.Version and sets it to the new version, otherwise it
+	// returns dberror.ErrVersionMismatch
+	Update(ctx context.Context, season *UpdateSeasonInput) error
+	Find(ctx context.Context, id int64) (*Season, error)
+	List(ctx context.Context, req *ListSeasonInput) ([]Season, int64, error)
+	// Delete applies when the season is still at version, otherwise it returns dberror.ErrVersionMismatch
+	Delete(ctx context.Context, id int64, version int64) error
+	UpsertLeagueSeason(ctx context.Context, leagueSeason *LeagueSeason) error
+	FindCurrent(ctx context.Context, leagueID int64) (*LeagueSeason, error)
+	FindByDate(ctx context.Context, leagueID int64, date time.Time) (*LeagueSeason, error)
 }
 
 type seasonDao struct{}
 
 var SeasonDao SeasonDaoI = &seasonDao{}
 
-func (d *seasonDao) Create(id int64) error {
-	_, err := footy_db.Client.Exec(queryCreate, id)
+func (d *seasonDao) Create(ctx context.Context, season *Season) error {
+	defer metrics.TimeQuery("SeasonDao", "Create")()
+	ctx, span := tracing.Start(ctx, "SeasonDao.Create")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryCreate, season)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SeasonDao Create NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *seasonDao) Upsert(ctx context.Context, season *Season) error {
+	defer metrics.TimeQuery("SeasonDao", "Upsert")()
+	ctx, span := tracing.Start(ctx, "SeasonDao.Upsert")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryUpsert, season)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SeasonDao Upsert NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *seasonDao) Update(ctx context.Context, season *UpdateSeasonInput) error {
+	defer metrics.TimeQuery("SeasonDao", "Update")()
+	ctx, span := tracing.Start(ctx, "SeasonDao.Update")
+	defer span.End()
+
+	res, err := footy_db.DB(ctx).NamedExecContext(ctx, queryUpdate, season)
 	if err != nil {
-		zlog.Logger.Error("SeasonDao Create Exec", err)
-		return err
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SeasonDao Update NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	// The version always changes, so nothing is updated only when the season is not at the expected version
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return dberror.ErrVersionMismatch
 	}
+	season.Version++
 	return nil
 }
 
-func (d *seasonDao) Find(id int64) (*Season, error) {
+func (d *seasonDao) Find(ctx context.Context, id int64) (*Season, error) {
+	defer metrics.TimeQuery("SeasonDao", "Find")()
+	ctx, span := tracing.Start(ctx, "SeasonDao.Find")
+	defer span.End()
+
 	var result Season
 
-	err := footy_db.Client.Get(&result, queryFind, id)
+	err := footy_db.DB(ctx).GetContext(ctx, &result, queryFind, id)
 	if err != nil {
-		zlog.Logger.Error("SeasonDao Find Get", err)
-		return nil, err
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SeasonDao Find Get", "error", err)
+		return nil, dberror.Wrap(err)
 	}
 	return &result, nil
 }
 
-func (d *seasonDao) List(req *ListSeasonInput) ([]Season, error) {
-	var results []Season
+func (d *seasonDao) List(ctx context.Context, req *ListSeasonInput) ([]Season, int64, error) {
+	defer metrics.TimeQuery("SeasonDao", "List")()
+	ctx, span := tracing.Start(ctx, "SeasonDao.List")
+	defer span.End()
 
-	// Create where, and order by clauses
+	var results []Season
+	// Create where, limit and order by clauses
 	where, args := d.generateListWhereClause(req)
-	order := pagination.GeneratePaginationSort("id ASC", "id", req.Order)
-	query := fmt.Sprintf(queryList, where, order)
+	page := req.Filter.Page(req.Page, req.PerPage, results)
+	query := fmt.Sprintf(queryList, page.Select("*"), page.Where(where), page.OrderBy(), page.Limit())
 
 	// Get the records
-	err := footy_db.Client.Select(&results, query, args...)
+	err := footy_db.Client.SelectContext(ctx, &results, query, page.Args(args)...)
 	if err != nil {
-		zlog.Logger.Error("SeasonDao List Select", err)
-		return nil, err
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SeasonDao List Select", "error", err)
+		return nil, 0, dberror.Wrap(err)
 	}
+	results = page.Rows(results).([]Season)
 
-	return results, nil
+	// Get total records so we can use them for pagination, unless the client asked to skip the count
+	if !req.Filter.Total() {
+		return results, pagination.TotalNotCounted, nil
+	}
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryListTotal, where), args...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SeasonDao List GetTableTotalRowsArgs", "error", err)
+		return nil, 0, dberror.Wrap(err)
+	}
+
+	return results, total, nil
 }
 
 func (d *seasonDao) generateListWhereClause(req *ListSeasonInput) (string, []interface{}) {
 	w := helpers.NewWhere()
 	w.AppendWhereAtStart()
 	w.Where("true") // add this just in case we do not have any param passed
+	w.Conditions(req.Filter.Conditions())
 
-	if req.ID != 0 {
-		w.Where("id = ?", req.ID)
-	}
 	return w.String()
 }
 
-func (d *seasonDao) Delete(id int64) error {
-	_, err := footy_db.Client.Exec(queryDelete, id)
+func (d *seasonDao) Delete(ctx context.Context, id int64, version int64) error {
+	defer metrics.TimeQuery("SeasonDao", "Delete")()
+	ctx, span := tracing.Start(ctx, "SeasonDao.Delete")
+	defer span.End()
+
+	res, err := footy_db.DB(ctx).ExecContext(ctx, queryDelete, id, version)
 	if err != nil {
-		zlog.Logger.Error("SeasonDao Delete Exec", err)
-		return err
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SeasonDao Delete Exec", "error", err)
+		return dberror.Wrap(err)
+	}
+	// Nothing is deleted when the record does not exist or is at another version
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return dberror.ErrVersionMismatch
 	}
 	return nil
 }
+
+func (d *seasonDao) UpsertLeagueSeason(ctx context.Context, leagueSeason *LeagueSeason) error {
+	defer metrics.TimeQuery("SeasonDao", "UpsertLeagueSeason")()
+	ctx, span := tracing.Start(ctx, "SeasonDao.UpsertLeagueSeason")
+	defer span.End()
+
+	_, err := footy_db.Client.NamedExecContext(ctx, queryUpsertLeagueSeason, leagueSeason)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SeasonDao UpsertLeagueSeason NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+// FindCurrent returns the season of the league marked as current
+func (d *seasonDao) FindCurrent(ctx context.Context, leagueID int64) (*LeagueSeason, error) {
+	defer metrics.TimeQuery("SeasonDao", "FindCurrent")()
+	ctx, span := tracing.Start(ctx, "SeasonDao.FindCurrent")
+	defer span.End()
+
+	var result LeagueSeason
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindCurrent, leagueID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SeasonDao FindCurrent Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+// FindByDate returns the season of the league active on date, between two seasons it is the one that ended last
+func (d *seasonDao) FindByDate(ctx context.Context, leagueID int64, date time.Time) (*LeagueSeason, error) {
+	defer metrics.TimeQuery("SeasonDao", "FindByDate")()
+	ctx, span := tracing.Start(ctx, "SeasonDao.FindByDate")
+	defer span.End()
+
+	var result LeagueSeason
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindByDate, leagueID, date.Format(DateLayout))
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SeasonDao FindByDate Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
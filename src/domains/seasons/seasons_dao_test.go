@@ -1,12 +1,18 @@
 package seasons
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+	"net/url"
 	"testing"
+	"time"
 )
 
 func TestSeasonDao_Create(t *testing.T) {
@@ -19,7 +25,7 @@ func TestSeasonDao_Create(t *testing.T) {
 			title: "error Client.Exec",
 			funcMock: func(m sqlmock.Sqlmock) {
 				m.ExpectExec("INSERT INTO seasons").
-					WithArgs(1).
+					WithArgs(2021, "2021/22").
 					WillReturnError(errors.New("test Exec"))
 			},
 			expectedErr: errors.New("test Exec"),
@@ -28,7 +34,7 @@ func TestSeasonDao_Create(t *testing.T) {
 			title: "success",
 			funcMock: func(m sqlmock.Sqlmock) {
 				m.ExpectExec("INSERT INTO seasons").
-					WithArgs(1).
+					WithArgs(2021, "2021/22").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			expectedErr: nil,
@@ -45,9 +51,67 @@ func TestSeasonDao_Create(t *testing.T) {
 			footy_db.Client = sqlx.NewDb(db, "sqlmock")
 			testCase.funcMock(mock)
 
-			err = SeasonDao.Create(1)
+			err = SeasonDao.Create(context.Background(), &Season{ID: 2021, Label: "2021/22"})
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestSeasonDao_Update(t *testing.T) {
+	testCases := []struct {
+		title           string
+		funcMock        func(sqlmock.Sqlmock)
+		expectedVersion int64
+		expectedErr     error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE seasons SET").
+					WithArgs("2021/22", 1, 2).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedVersion: 2,
+			expectedErr:     errors.New("test NamedExec"),
+		},
+		{
+			title: "error version mismatch",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE seasons SET").
+					WithArgs("2021/22", 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedVersion: 2,
+			expectedErr:     dberror.ErrVersionMismatch,
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE seasons SET").
+					WithArgs("2021/22", 1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedVersion: 3,
+			expectedErr:     nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			season := &UpdateSeasonInput{ID: 1, Version: 2, Label: "2021/22"}
+			err = SeasonDao.Update(context.Background(), season)
 
 			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedVersion, season.Version)
 		})
 	}
 }
@@ -73,10 +137,11 @@ func TestSeasonDao_Find(t *testing.T) {
 			funcMock: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery("SELECT (.+) FROM seasons").
 					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow(1, "2021/22"))
 			},
 			expectedRes: &Season{
-				ID: 1,
+				ID:    1,
+				Label: "2021/22",
 			},
 			expectedErr: nil,
 		},
@@ -92,7 +157,7 @@ func TestSeasonDao_Find(t *testing.T) {
 			footy_db.Client = sqlx.NewDb(db, "sqlmock")
 			testCase.funcMock(mock)
 
-			res, err := SeasonDao.Find(1)
+			res, err := SeasonDao.Find(context.Background(), 1)
 
 			assert.Equal(t, testCase.expectedRes, res)
 			assert.Equal(t, testCase.expectedErr, err)
@@ -102,33 +167,51 @@ func TestSeasonDao_Find(t *testing.T) {
 
 func TestSeasonDao_List(t *testing.T) {
 	testCases := []struct {
-		title       string
-		funcMock    func(sqlmock.Sqlmock)
-		expectedRes []Season
-		expectedErr error
+		title         string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []Season
+		expectedTotal int64
+		expectedErr   error
 	}{
 		{
 			title: "error Client.Select",
 			funcMock: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery("SELECT (.+) FROM seasons").
-					WithArgs(1).
+					WithArgs(int64(2020)).
 					WillReturnError(errors.New("error Select"))
 			},
 			expectedErr: errors.New("error Select"),
 		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM seasons").
+					WithArgs(int64(2020)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow(2021, "2021/22"))
+				m.ExpectQuery("SELECT (.+) FROM seasons").
+					WithArgs(int64(2020)).
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
 		{
 			title: "success",
 			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM seasons WHERE true AND id > \\? ORDER BY id ASC LIMIT 21").
+					WithArgs(int64(2020)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow(2021, "2021/22"))
 				m.ExpectQuery("SELECT (.+) FROM seasons").
-					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+					WithArgs(int64(2020)).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
 			},
 			expectedErr: nil,
 			expectedRes: []Season{
 				{
-					ID: 1,
+					ID:    2021,
+					Label: "2021/22",
 				},
 			},
+			expectedTotal: 1,
 		},
 	}
 
@@ -142,12 +225,13 @@ func TestSeasonDao_List(t *testing.T) {
 			footy_db.Client = sqlx.NewDb(db, "sqlmock")
 			testCase.funcMock(mock)
 
-			res, err := SeasonDao.List(&ListSeasonInput{
-				ID:    1,
-				Order: "asc",
-			})
+			query, errs := filter.Parse(url.Values{"id[gt]": {"2020"}}, Season{})
+			assert.Nil(t, errs)
+
+			res, total, err := SeasonDao.List(context.Background(), &ListSeasonInput{Filter: *query})
 
 			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
 			assert.Equal(t, testCase.expectedErr, err)
 		})
 	}
@@ -163,16 +247,25 @@ func TestSeasonDao_Delete(t *testing.T) {
 			title: "error Client.Exec",
 			funcMock: func(m sqlmock.Sqlmock) {
 				m.ExpectExec("DELETE FROM seasons").
-					WithArgs(1).
+					WithArgs(1, 2).
 					WillReturnError(errors.New("test Exec"))
 			},
 			expectedErr: errors.New("test Exec"),
 		},
+		{
+			title: "error version mismatch",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("DELETE FROM seasons").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: dberror.ErrVersionMismatch,
+		},
 		{
 			title: "success",
 			funcMock: func(m sqlmock.Sqlmock) {
 				m.ExpectExec("DELETE FROM seasons").
-					WithArgs(1).
+					WithArgs(1, 2).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			expectedErr: nil,
@@ -189,9 +282,166 @@ func TestSeasonDao_Delete(t *testing.T) {
 			footy_db.Client = sqlx.NewDb(db, "sqlmock")
 			testCase.funcMock(mock)
 
-			err = SeasonDao.Delete(1)
+			err = SeasonDao.Delete(context.Background(), 1, 2)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestSeasonDao_UpsertLeagueSeason(t *testing.T) {
+	start := time.Date(2021, 8, 13, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, 5, 22, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO league_seasons").
+					WithArgs(39, 2021, "2021/22", start, end, true).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO league_seasons").
+					WithArgs(39, 2021, "2021/22", start, end, true).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = SeasonDao.UpsertLeagueSeason(context.Background(), &LeagueSeason{
+				LeagueID:  39,
+				Season:    2021,
+				Label:     "2021/22",
+				StartDate: start,
+				EndDate:   end,
+				Current:   true,
+			})
 
 			assert.Equal(t, testCase.expectedErr, err)
 		})
 	}
 }
+
+func TestSeasonDao_FindCurrent(t *testing.T) {
+	start := time.Date(2021, 8, 13, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, 5, 22, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *LeagueSeason
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM league_seasons WHERE league_id = \\? AND current = 1").
+					WithArgs(39).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM league_seasons WHERE league_id = \\? AND current = 1").
+					WithArgs(39).
+					WillReturnRows(sqlmock.NewRows([]string{"league_id", "season", "label", "start_date", "end_date", "current"}).
+						AddRow(39, 2021, "2021/22", start, end, 1))
+			},
+			expectedRes: &LeagueSeason{LeagueID: 39, Season: 2021, Label: "2021/22", StartDate: start, EndDate: end, Current: true},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := SeasonDao.FindCurrent(context.Background(), 39)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestSeasonDao_FindByDate(t *testing.T) {
+	start := time.Date(2021, 8, 13, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, 5, 22, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *LeagueSeason
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM league_seasons WHERE league_id = \\? AND start_date <= \\?").
+					WithArgs(39, "2022-07-01").
+					WillReturnError(errors.New("test Get"))
+			},
+			expectedErr: errors.New("test Get"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM league_seasons WHERE league_id = \\? AND start_date <= \\?").
+					WithArgs(39, "2022-07-01").
+					WillReturnRows(sqlmock.NewRows([]string{"league_id", "season", "label", "start_date", "end_date", "current"}).
+						AddRow(39, 2021, "2021/22", start, end, 0))
+			},
+			expectedRes: &LeagueSeason{LeagueID: 39, Season: 2021, Label: "2021/22", StartDate: start, EndDate: end},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := SeasonDao.FindByDate(context.Background(), 39, time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC))
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestLabel(t *testing.T) {
+	assert.Equal(t, "2021/22", DefaultLabel(2021))
+	assert.Equal(t, "1999/00", DefaultLabel(1999))
+	assert.Equal(t, "2021/22", Label(time.Date(2021, 8, 13, 0, 0, 0, 0, time.UTC), time.Date(2022, 5, 22, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2022", Label(time.Date(2022, 2, 26, 0, 0, 0, 0, time.UTC), time.Date(2022, 11, 5, 0, 0, 0, 0, time.UTC)))
+}
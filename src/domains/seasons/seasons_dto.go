@@ -1,10 +1,70 @@
 package seasons
 
+import (
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"time"
+)
+
+// DateLayout is the format of the season start and end dates
+const DateLayout = "2006-01-02"
+
+// Season is a season with its version, bumped when the label changes, the ETag of the season is its version.
+// Version and UpdatedAt are ignored when creating or importing a season
 type Season struct {
-	ID int64 `json:"id" form:"id" db:"id" validate:"required"`
+	ID        int64     `json:"id" form:"id" db:"id" validate:"required" filter:"eq,ne,in,gt,gte,lt,lte,sort,default"`
+	Label     string    `json:"label" form:"label" db:"label" validate:"omitempty,max=10" filter:"eq,in,like,sort"`
+	Version   int64     `json:"version" form:"-" db:"version"`
+	UpdatedAt time.Time `json:"updated_at" form:"-" db:"updated_at" filter:"gt,gte,lt,lte,sort"`
+}
+
+// UpdateSeasonInput replaces the fields of a season. Version is the version of the If-Match header, the update
+// only applies if the season is still at that version and Version holds the new one afterwards
+type UpdateSeasonInput struct {
+	ID      int64  `json:"-" form:"-" db:"id"`
+	Version int64  `json:"-" form:"-" db:"version"`
+	Label   string `json:"label" form:"label" db:"label" validate:"omitempty,max=10"`
 }
 
+// BulkCreateSeasonInput creates every item, mode is atomic (default) or best_effort, see the bulk package
+type BulkCreateSeasonInput struct {
+	Mode  string   `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+	Items []Season `json:"items" validate:"required,min=1,max=500"`
+}
+
+// ListSeasonInput filters on the fields of Season, see its filter tags
 type ListSeasonInput struct {
-	ID    int64  `json:"id" form:"id"`
-	Order string `json:"order" form:"order" validate:"omitempty,oneof=desc asc"`
+	Page    int64        `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage int64        `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+	Filter  filter.Query `json:"-" form:"-"`
+}
+
+// LeagueSeason holds the dates of a season in a league, current marks the season API Sports reports as the
+// current one of the league
+type LeagueSeason struct {
+	LeagueID  int64     `json:"league_id" db:"league_id"`
+	Season    int64     `json:"season" db:"season"`
+	Label     string    `json:"label" db:"label"`
+	StartDate time.Time `json:"start_date" db:"start_date"`
+	EndDate   time.Time `json:"end_date" db:"end_date"`
+	Current   bool      `json:"current" db:"current"`
+}
+
+// CurrentSeasonInput resolves the season of the league active on date, or today when date is empty
+type CurrentSeasonInput struct {
+	LeagueID int64  `json:"league_id" form:"league_id" validate:"required"`
+	Date     string `json:"date" form:"date" validate:"omitempty,YYYY-MM-DD"`
+}
+
+// DefaultLabel returns the label of a season spanning two years, e.g. 2021/22
+func DefaultLabel(year int64) string {
+	return fmt.Sprintf("%d/%02d", year, (year+1)%100)
+}
+
+// Label returns the label of a season from its dates, 2021/22 when it spans two years and 2021 otherwise
+func Label(start, end time.Time) string {
+	if start.Year() == end.Year() {
+		return fmt.Sprintf("%d", start.Year())
+	}
+	return fmt.Sprintf("%d/%02d", start.Year(), end.Year()%100)
 }
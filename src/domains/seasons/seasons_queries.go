@@ -1,8 +1,48 @@
 package seasons
 
 const (
-	queryCreate = `INSERT INTO seasons (id) VALUES (?)`
-	queryFind   = `SELECT id FROM seasons WHERE id = ? LIMIT 1`
-	queryList   = `SELECT id FROM seasons %s ORDER BY %s`
-	queryDelete = `DELETE FROM seasons WHERE id = ?`
+	queryCreate = `INSERT INTO seasons (id, label) VALUES (:id, :label)`
+	// The version is only bumped when the label changes, it is assigned first since MySQL evaluates the
+	// assignments in order
+	queryUpsert = `INSERT INTO seasons (id, label) VALUES (:id, :label) ON DUPLICATE KEY UPDATE
+		version = IF(label = VALUES(label), version, version + 1),
+		label = VALUES(label)`
+	queryUpdate = `UPDATE seasons
+	  SET
+		label = :label,
+		version = version + 1
+	  WHERE
+		id = :id AND version = :version`
+	queryFind = `SELECT id, label, version, updated_at FROM seasons WHERE id = ? LIMIT 1`
+
+	queryList      = `SELECT %s FROM seasons %s ORDER BY %s %s`
+	queryListTotal = `SELECT count(id) FROM seasons %s`
+
+	queryDelete = `DELETE FROM seasons WHERE id = ? AND version = ?`
+
+	queryUpsertLeagueSeason = `INSERT INTO league_seasons(
+		league_id,
+		season,
+		label,
+		start_date,
+		end_date,
+		current)
+	VALUES (
+		:league_id,
+		:season,
+		:label,
+		:start_date,
+		:end_date,
+		:current)
+	ON DUPLICATE KEY UPDATE
+		label = VALUES(label),
+		start_date = VALUES(start_date),
+		end_date = VALUES(end_date),
+		current = VALUES(current)`
+
+	queryFindCurrent = `SELECT * FROM league_seasons WHERE league_id = ? AND current = 1
+		ORDER BY start_date DESC LIMIT 1`
+	// The latest season started on the date is the one being played, or the one that just ended between seasons
+	queryFindByDate = `SELECT * FROM league_seasons WHERE league_id = ? AND start_date <= ?
+		ORDER BY start_date DESC LIMIT 1`
 )
@@ -0,0 +1,42 @@
+package simulations
+
+// SimulationInput simulates the rest of a league season. LeagueID and Season are only set by the controller from
+// the path. Seed makes the simulation reproducible, a random one is used and returned when it is left out
+type SimulationInput struct {
+	LeagueID         int64  `json:"-" form:"-"`
+	Season           int64  `json:"-" form:"-"`
+	Model            string `json:"model" form:"model" validate:"max=50"`
+	Runs             int    `json:"runs" form:"runs" validate:"omitempty,gte=100,lte=100000"`
+	Seed             *int64 `json:"seed" form:"seed"`
+	EuropePlaces     *int   `json:"europe_places" form:"europe_places" validate:"omitempty,gte=0,lte=20"`
+	RelegationPlaces *int   `json:"relegation_places" form:"relegation_places" validate:"omitempty,gte=0,lte=20"`
+}
+
+// Simulation is the outcome of Runs simulations of the fixtures left in a league season by the model
+type Simulation struct {
+	LeagueID         int64  `json:"league_id"`
+	Season           int64  `json:"season"`
+	Model            string `json:"model"`
+	Runs             int    `json:"runs"`
+	Seed             int64  `json:"seed"`
+	FixturesLeft     int    `json:"fixtures_left"`
+	EuropePlaces     int    `json:"europe_places"`
+	RelegationPlaces int    `json:"relegation_places"`
+	Teams            []Team `json:"teams"`
+}
+
+// Team is the current standing of a team and the probabilities of its final one, sorted by expected points.
+// Positions[0] is the probability of finishing first, Europe of finishing in the europe places and Relegation
+// in the relegation places
+type Team struct {
+	TeamID         int64     `json:"team_id"`
+	TeamName       string    `json:"team_name"`
+	Played         int64     `json:"played"`
+	Points         int64     `json:"points"`
+	GoalDifference int64     `json:"goal_difference"`
+	ExpectedPoints float64   `json:"expected_points"`
+	Title          float64   `json:"title"`
+	Europe         float64   `json:"europe"`
+	Relegation     float64   `json:"relegation"`
+	Positions      []float64 `json:"positions"`
+}
@@ -0,0 +1,66 @@
+package squads
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"time"
+)
+
+type SquadDaoI interface {
+	Upsert(ctx context.Context, squad *Squad) error
+	DeleteStale(ctx context.Context, teamID int64, before time.Time) error
+	ListByTeam(ctx context.Context, teamID int64) ([]SquadPlayer, error)
+}
+
+type squadDao struct{}
+
+var SquadDao SquadDaoI = &squadDao{}
+
+func (d *squadDao) Upsert(ctx context.Context, squad *Squad) error {
+	defer metrics.TimeQuery("SquadDao", "Upsert")()
+	ctx, span := tracing.Start(ctx, "SquadDao.Upsert")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).NamedExecContext(ctx, queryUpsert, squad)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SquadDao Upsert NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+// DeleteStale removes the players of the squad of a team that the sync started at before did not list
+func (d *squadDao) DeleteStale(ctx context.Context, teamID int64, before time.Time) error {
+	defer metrics.TimeQuery("SquadDao", "DeleteStale")()
+	ctx, span := tracing.Start(ctx, "SquadDao.DeleteStale")
+	defer span.End()
+
+	_, err := footy_db.DB(ctx).ExecContext(ctx, queryDeleteStale, teamID, before)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SquadDao DeleteStale Exec", "error", err)
+		return dberror.Wrap(err)
+	}
+	return nil
+}
+
+func (d *squadDao) ListByTeam(ctx context.Context, teamID int64) ([]SquadPlayer, error) {
+	defer metrics.TimeQuery("SquadDao", "ListByTeam")()
+	ctx, span := tracing.Start(ctx, "SquadDao.ListByTeam")
+	defer span.End()
+
+	var results []SquadPlayer
+
+	err := footy_db.Client.SelectContext(ctx, &results, queryListByTeam, teamID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SquadDao ListByTeam Select", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return results, nil
+}
@@ -0,0 +1,164 @@
+package squads
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var testSyncedAt = time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func testSquad() Squad {
+	number := int64(30)
+	return Squad{
+		PlayerID: 154,
+		TeamID:   85,
+		TeamName: "Paris Saint Germain",
+		Number:   &number,
+		Position: "Attacker",
+		SyncedAt: testSyncedAt,
+	}
+}
+
+func TestSquadDao_Upsert(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO squads").
+					WithArgs(154, 85, "Paris Saint Germain", 30, "Attacker", testSyncedAt).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO squads").
+					WithArgs(154, 85, "Paris Saint Germain", 30, "Attacker", testSyncedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			squad := testSquad()
+			err = SquadDao.Upsert(context.Background(), &squad)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestSquadDao_DeleteStale(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("DELETE FROM squads").
+					WithArgs(85, testSyncedAt).
+					WillReturnError(errors.New("test Exec"))
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("DELETE FROM squads").
+					WithArgs(85, testSyncedAt).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = SquadDao.DeleteStale(context.Background(), 85, testSyncedAt)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestSquadDao_ListByTeam(t *testing.T) {
+	age, number := int64(34), int64(30)
+	columns := []string{"player_id", "name", "age", "number", "position", "photo"}
+
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes []SquadPlayer
+		expectedErr error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM squads").
+					WithArgs(85).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM squads").
+					WithArgs(85).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(154, "L. Messi", 34, 30, "Attacker", "https://media.api-sports.io/football/players/154.png"))
+			},
+			expectedRes: []SquadPlayer{{PlayerID: 154, Name: "L. Messi", Age: &age, Number: &number, Position: "Attacker",
+				Photo: "https://media.api-sports.io/football/players/154.png"}},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := SquadDao.ListByTeam(context.Background(), 85)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package squads
+
+import "time"
+
+// Squad places a player in the current squad of a team, a player can be in several squads, e.g. of a club and of a
+// national team. SyncedAt is the last sync of the squad that listed the player
+type Squad struct {
+	PlayerID int64     `json:"player_id" db:"player_id"`
+	TeamID   int64     `json:"team_id" db:"team_id"`
+	TeamName string    `json:"team_name" db:"team_name"`
+	Number   *int64    `json:"number" db:"number"`
+	Position string    `json:"position" db:"position"`
+	SyncedAt time.Time `json:"synced_at" db:"synced_at"`
+}
+
+// SquadPlayer is a player of the squad of a team
+type SquadPlayer struct {
+	PlayerID int64  `json:"player_id" db:"player_id"`
+	Name     string `json:"name" db:"name"`
+	Age      *int64 `json:"age" db:"age"`
+	Number   *int64 `json:"number" db:"number"`
+	Position string `json:"position" db:"position"`
+	Photo    string `json:"photo" db:"photo"`
+}
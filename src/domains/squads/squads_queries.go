@@ -0,0 +1,38 @@
+package squads
+
+const (
+	queryUpsert = `INSERT INTO squads(
+		player_id,
+		team_id,
+		team_name,
+		number,
+		position,
+		synced_at)
+	VALUES (
+		:player_id,
+		:team_id,
+		:team_name,
+		:number,
+		:position,
+		:synced_at)
+	ON DUPLICATE KEY UPDATE
+		team_name = VALUES(team_name),
+		number = VALUES(number),
+		position = VALUES(position),
+		synced_at = VALUES(synced_at)`
+
+	queryDeleteStale = `DELETE FROM squads WHERE team_id = ? AND synced_at < ?`
+
+	// The goalkeepers first, then the defenders, the midfielders and the attackers
+	queryListByTeam = `SELECT
+		s.player_id,
+		p.name,
+		p.age,
+		s.number,
+		s.position,
+		p.photo
+	FROM squads s
+	INNER JOIN players p ON p.id = s.player_id
+	WHERE s.team_id = ?
+	ORDER BY FIELD(s.position, 'Goalkeeper', 'Defender', 'Midfielder', 'Attacker'), s.number IS NULL, s.number, p.name`
+)
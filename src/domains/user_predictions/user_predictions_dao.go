@@ -0,0 +1,119 @@
+package user_predictions
+
+import (
+	"context"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"time"
+)
+
+type UserPredictionDaoI interface {
+	Upsert(ctx context.Context, prediction *UserPrediction) error
+	List(ctx context.Context, req *ListUserPredictionInput) ([]UserPrediction, int64, error)
+	ListForScoring(ctx context.Context, fixtureID int64) ([]ScoringRow, error)
+	UpdatePoints(ctx context.Context, id int64, points int64, scoredAt time.Time) error
+}
+
+type userPredictionDao struct{}
+
+var UserPredictionDao UserPredictionDaoI = &userPredictionDao{}
+
+func (d *userPredictionDao) Upsert(ctx context.Context, prediction *UserPrediction) error {
+	defer metrics.TimeQuery("UserPredictionDao", "Upsert")()
+	ctx, span := tracing.Start(ctx, "UserPredictionDao.Upsert")
+	defer span.End()
+
+	_, err := footy_db.Client.NamedExecContext(ctx, queryUpsert, prediction)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("UserPredictionDao Upsert NamedExec", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (d *userPredictionDao) List(ctx context.Context, req *ListUserPredictionInput) ([]UserPrediction, int64, error) {
+	defer metrics.TimeQuery("UserPredictionDao", "List")()
+	ctx, span := tracing.Start(ctx, "UserPredictionDao.List")
+	defer span.End()
+
+	var results []UserPrediction
+	// Create where, limit and order by clauses
+	where, args := d.generateListWhereClause(req)
+	page := req.Filter.Page(req.Page, req.PerPage, results)
+	query := fmt.Sprintf(queryList, page.Select("*"), page.Where(where), page.OrderBy(), page.Limit())
+
+	// Get the records
+	err := footy_db.Client.SelectContext(ctx, &results, query, page.Args(args)...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("UserPredictionDao List Select", "error", err)
+		return nil, 0, err
+	}
+	results = page.Rows(results).([]UserPrediction)
+
+	// Get total records so we can use them for pagination, unless the client asked to skip the count
+	if !req.Filter.Total() {
+		return results, pagination.TotalNotCounted, nil
+	}
+	total, err := pagination.GetTableTotalRowsArgs(ctx, fmt.Sprintf(queryListTotal, where), args...)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("UserPredictionDao List GetTableTotalRowsArgs", "error", err)
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+func (d *userPredictionDao) generateListWhereClause(req *ListUserPredictionInput) (string, []interface{}) {
+	w := helpers.NewWhere()
+	w.AppendWhereAtStart()
+	w.Where("true") // add this just in case we do not have any param passed
+	w.Conditions(req.Filter.Conditions())
+
+	if req.CompetitionID != 0 {
+		w.Where("competition_id = ?", req.CompetitionID)
+	}
+
+	if req.UserID != 0 {
+		w.Where("user_id = ?", req.UserID)
+	}
+
+	return w.String()
+}
+
+func (d *userPredictionDao) ListForScoring(ctx context.Context, fixtureID int64) ([]ScoringRow, error) {
+	defer metrics.TimeQuery("UserPredictionDao", "ListForScoring")()
+	ctx, span := tracing.Start(ctx, "UserPredictionDao.ListForScoring")
+	defer span.End()
+
+	var results []ScoringRow
+
+	err := footy_db.Client.SelectContext(ctx, &results, queryListForScoring, fixtureID)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("UserPredictionDao ListForScoring Select", "error", err)
+		return nil, err
+	}
+	return results, nil
+}
+
+func (d *userPredictionDao) UpdatePoints(ctx context.Context, id int64, points int64, scoredAt time.Time) error {
+	defer metrics.TimeQuery("UserPredictionDao", "UpdatePoints")()
+	ctx, span := tracing.Start(ctx, "UserPredictionDao.UpdatePoints")
+	defer span.End()
+
+	_, err := footy_db.Client.ExecContext(ctx, queryUpdatePoints, points, scoredAt, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("UserPredictionDao UpdatePoints Exec", "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package user_predictions
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var (
+	testCreatedAt = time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	testColumns   = []string{
+		"id",
+		"competition_id",
+		"user_id",
+		"fixture_id",
+		"home_goals",
+		"away_goals",
+		"points",
+		"scored_at",
+		"created_at",
+		"updated_at",
+	}
+)
+
+func TestUserPredictionDao_Upsert(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO user_predictions").
+					WithArgs(1, 2, 10, 2, 1, testCreatedAt, testCreatedAt).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO user_predictions").
+					WithArgs(1, 2, 10, 2, 1, testCreatedAt, testCreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = UserPredictionDao.Upsert(context.Background(), &UserPrediction{
+				CompetitionID: 1,
+				UserID:        2,
+				FixtureID:     10,
+				HomeGoals:     2,
+				AwayGoals:     1,
+				CreatedAt:     testCreatedAt,
+				UpdatedAt:     testCreatedAt,
+			})
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestUserPredictionDao_List(t *testing.T) {
+	testCases := []struct {
+		title         string
+		funcMock      func(sqlmock.Sqlmock)
+		expectedRes   []UserPrediction
+		expectedTotal int64
+		expectedErr   error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM user_predictions").
+					WithArgs(10, 1, 2).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "error GetTableTotalRowsArgs",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM user_predictions").
+					WithArgs(10, 1, 2).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(5, 1, 2, 10, 2, 1, nil, nil, testCreatedAt, testCreatedAt))
+				m.ExpectQuery("SELECT (.+) FROM user_predictions").
+					WithArgs(10, 1, 2).
+					WillReturnError(errors.New("error GetTableTotalRowsArgs"))
+			},
+			expectedErr: errors.New("error GetTableTotalRowsArgs"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM user_predictions").
+					WithArgs(10, 1, 2).
+					WillReturnRows(sqlmock.NewRows(testColumns).
+						AddRow(5, 1, 2, 10, 2, 1, nil, nil, testCreatedAt, testCreatedAt))
+				m.ExpectQuery("SELECT (.+) FROM user_predictions").
+					WithArgs(10, 1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1))
+			},
+			expectedRes: []UserPrediction{
+				{
+					ID:            5,
+					CompetitionID: 1,
+					UserID:        2,
+					FixtureID:     10,
+					HomeGoals:     2,
+					AwayGoals:     1,
+					CreatedAt:     testCreatedAt,
+					UpdatedAt:     testCreatedAt,
+				},
+			},
+			expectedTotal: 1,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			query, errs := filter.Parse(url.Values{"fixture_id": {"10"}}, UserPrediction{})
+			assert.Nil(t, errs)
+
+			res, total, err := UserPredictionDao.List(context.Background(), &ListUserPredictionInput{
+				CompetitionID: 1,
+				UserID:        2,
+				Filter:        *query,
+			})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedTotal, total)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestUserPredictionDao_ListForScoring(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes []ScoringRow
+		expectedErr error
+	}{
+		{
+			title: "error Client.Select",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM user_predictions").
+					WithArgs(10).
+					WillReturnError(errors.New("error Select"))
+			},
+			expectedErr: errors.New("error Select"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM user_predictions").
+					WithArgs(10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "home_goals", "away_goals", "points_exact", "points_goal_difference", "points_result"}).
+						AddRow(5, 2, 1, 3, 2, 1))
+			},
+			expectedRes: []ScoringRow{
+				{ID: 5, HomeGoals: 2, AwayGoals: 1, PointsExact: 3, PointsGoalDifference: 2, PointsResult: 1},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := UserPredictionDao.ListForScoring(context.Background(), 10)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestUserPredictionDao_UpdatePoints(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			title: "error Client.Exec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE user_predictions SET").
+					WithArgs(3, testCreatedAt, 5).
+					WillReturnError(errors.New("test Exec"))
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE user_predictions SET").
+					WithArgs(3, testCreatedAt, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			err = UserPredictionDao.UpdatePoints(context.Background(), 5, 3, testCreatedAt)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
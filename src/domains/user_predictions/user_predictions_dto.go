@@ -0,0 +1,50 @@
+package user_predictions
+
+import (
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"time"
+)
+
+type UserPrediction struct {
+	ID            int64      `json:"id" db:"id" filter:"eq,ne,in,gt,gte,lt,lte,sort,default=desc"`
+	CompetitionID int64      `json:"competition_id" db:"competition_id"`
+	UserID        int64      `json:"user_id" db:"user_id"`
+	FixtureID     int64      `json:"fixture_id" db:"fixture_id" filter:"eq,ne,in,sort"`
+	HomeGoals     int64      `json:"home_goals" db:"home_goals" filter:"eq,ne,gt,gte,lt,lte"`
+	AwayGoals     int64      `json:"away_goals" db:"away_goals" filter:"eq,ne,gt,gte,lt,lte"`
+	Points        *int64     `json:"points" db:"points" filter:"eq,ne,gt,gte,lt,lte,null"`
+	ScoredAt      *time.Time `json:"scored_at" db:"scored_at" filter:"gt,gte,lt,lte,null"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at" filter:"gt,gte,lt,lte,sort"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at" filter:"gt,gte,lt,lte"`
+}
+
+type UserPredictionInput struct {
+	FixtureID int64  `json:"fixture_id" form:"fixture_id" validate:"required"`
+	HomeGoals *int64 `json:"home_goals" form:"home_goals" validate:"required,min=0"`
+	AwayGoals *int64 `json:"away_goals" form:"away_goals" validate:"required,min=0"`
+}
+
+// KickoffDeadline is validated before accepting a prediction so that
+// nobody can submit or change a prediction once the fixture has started
+type KickoffDeadline struct {
+	KickoffAt string `json:"kickoff_at" validate:"futureOnly"`
+}
+
+// ListUserPredictionInput filters on the fields of UserPrediction, see its filter tags
+type ListUserPredictionInput struct {
+	CompetitionID int64        `json:"-" form:"-"`
+	UserID        int64        `json:"-" form:"-"`
+	Page          int64        `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage       int64        `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+	Filter        filter.Query `json:"-" form:"-"`
+}
+
+// ScoringRow is a prediction joined with the scoring rules of its competition
+type ScoringRow struct {
+	ID                   int64 `db:"id"`
+	HomeGoals            int64 `db:"home_goals"`
+	AwayGoals            int64 `db:"away_goals"`
+	PointsExact          int64 `db:"points_exact"`
+	PointsGoalDifference int64 `db:"points_goal_difference"`
+	PointsResult         int64 `db:"points_result"`
+}
@@ -0,0 +1,40 @@
+package user_predictions
+
+const (
+	queryUpsert = `INSERT INTO user_predictions(
+		competition_id,
+		user_id,
+		fixture_id,
+		home_goals,
+		away_goals,
+		created_at,
+		updated_at)
+	VALUES (
+		:competition_id,
+		:user_id,
+		:fixture_id,
+		:home_goals,
+		:away_goals,
+		:created_at,
+		:updated_at)
+	ON DUPLICATE KEY UPDATE
+		home_goals = VALUES(home_goals),
+		away_goals = VALUES(away_goals),
+		updated_at = VALUES(updated_at)`
+
+	queryList      = `SELECT %s FROM user_predictions %s ORDER BY %s %s`
+	queryListTotal = `SELECT count(id) FROM user_predictions %s`
+
+	queryListForScoring = `SELECT
+		p.id,
+		p.home_goals,
+		p.away_goals,
+		c.points_exact,
+		c.points_goal_difference,
+		c.points_result
+	FROM user_predictions p
+	INNER JOIN competitions c ON c.id = p.competition_id
+	WHERE p.fixture_id = ?`
+
+	queryUpdatePoints = `UPDATE user_predictions SET points = ?, scored_at = ? WHERE id = ?`
+)
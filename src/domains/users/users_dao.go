@@ -0,0 +1,73 @@
+package users
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type UserDaoI interface {
+	Create(ctx context.Context, user *User) error
+	FindByID(ctx context.Context, id int64) (*UserOutput, error)
+	FindByEmail(ctx context.Context, email string) (*User, error)
+}
+
+type userDao struct{}
+
+var UserDao UserDaoI = &userDao{}
+
+func (d *userDao) Create(ctx context.Context, user *User) error {
+	defer metrics.TimeQuery("UserDao", "Create")()
+	ctx, span := tracing.Start(ctx, "UserDao.Create")
+	defer span.End()
+
+	res, err := footy_db.Client.NamedExecContext(ctx, queryCreate, user)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("UserDao Create NamedExec", "error", err)
+		return dberror.Wrap(err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("UserDao Create LastInsertId", "error", err)
+		return dberror.Wrap(err)
+	}
+	user.ID = id
+	return nil
+}
+
+func (d *userDao) FindByID(ctx context.Context, id int64) (*UserOutput, error) {
+	defer metrics.TimeQuery("UserDao", "FindByID")()
+	ctx, span := tracing.Start(ctx, "UserDao.FindByID")
+	defer span.End()
+
+	var result UserOutput
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindByID, id)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("UserDao FindByID Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
+
+func (d *userDao) FindByEmail(ctx context.Context, email string) (*User, error) {
+	defer metrics.TimeQuery("UserDao", "FindByEmail")()
+	ctx, span := tracing.Start(ctx, "UserDao.FindByEmail")
+	defer span.End()
+
+	var result User
+
+	err := footy_db.Client.GetContext(ctx, &result, queryFindByEmail, email)
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("UserDao FindByEmail Get", "error", err)
+		return nil, dberror.Wrap(err)
+	}
+	return &result, nil
+}
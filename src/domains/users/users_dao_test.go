@@ -0,0 +1,183 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var testCreatedAt = time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func TestUserDao_Create(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedID  int64
+		expectedErr error
+	}{
+		{
+			title: "error Client.NamedExec",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO users").
+					WithArgs("john@test.com", "John", "hash", "reader", testCreatedAt).
+					WillReturnError(errors.New("test NamedExec"))
+			},
+			expectedErr: errors.New("test NamedExec"),
+		},
+		{
+			title: "error LastInsertId",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO users").
+					WithArgs("john@test.com", "John", "hash", "reader", testCreatedAt).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("test LastInsertId")))
+			},
+			expectedErr: errors.New("test LastInsertId"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO users").
+					WithArgs("john@test.com", "John", "hash", "reader", testCreatedAt).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			expectedID:  3,
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			user := &User{
+				Email:        "john@test.com",
+				Name:         "John",
+				PasswordHash: "hash",
+				Role:         "reader",
+				CreatedAt:    testCreatedAt,
+			}
+			err = UserDao.Create(context.Background(), user)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedID, user.ID)
+		})
+	}
+}
+
+func TestUserDao_FindByID(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *UserOutput
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM users").
+					WithArgs(1).
+					WillReturnError(errors.New("test Get"))
+			},
+			expectedErr: errors.New("test Get"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM users").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "role", "created_at"}).
+						AddRow(1, "john@test.com", "John", "reader", testCreatedAt))
+			},
+			expectedRes: &UserOutput{
+				ID:        1,
+				Email:     "john@test.com",
+				Name:      "John",
+				Role:      "reader",
+				CreatedAt: testCreatedAt,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := UserDao.FindByID(context.Background(), 1)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestUserDao_FindByEmail(t *testing.T) {
+	testCases := []struct {
+		title       string
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *User
+		expectedErr error
+	}{
+		{
+			title: "error Client.Get",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM users").
+					WithArgs("john@test.com").
+					WillReturnError(errors.New("test Get"))
+			},
+			expectedErr: errors.New("test Get"),
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM users").
+					WithArgs("john@test.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "password_hash", "role", "created_at"}).
+						AddRow(1, "john@test.com", "John", "hash", "reader", testCreatedAt))
+			},
+			expectedRes: &User{
+				ID:           1,
+				Email:        "john@test.com",
+				Name:         "John",
+				PasswordHash: "hash",
+				Role:         "reader",
+				CreatedAt:    testCreatedAt,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			res, err := UserDao.FindByEmail(context.Background(), "john@test.com")
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package users
+
+import "time"
+
+type User struct {
+	ID           int64     `db:"id"`
+	Email        string    `db:"email"`
+	Name         string    `db:"name"`
+	PasswordHash string    `db:"password_hash"`
+	Role         string    `db:"role"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+type RegisterUserInput struct {
+	Email    string `json:"email" form:"email" validate:"required,email,max=255"`
+	Name     string `json:"name" form:"name" validate:"required,max=100"`
+	Password string `json:"password" form:"password" validate:"required,min=8,max=72"`
+}
+
+type LoginInput struct {
+	Email    string `json:"email" form:"email" validate:"required,email"`
+	Password string `json:"password" form:"password" validate:"required"`
+}
+
+type UserOutput struct {
+	ID        int64     `json:"id" db:"id"`
+	Email     string    `json:"email" db:"email"`
+	Name      string    `json:"name" db:"name"`
+	Role      string    `json:"role" db:"role"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
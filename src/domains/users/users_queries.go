@@ -0,0 +1,20 @@
+package users
+
+const (
+	queryCreate = `INSERT INTO users(
+		email,
+		name,
+		password_hash,
+		role,
+		created_at)
+	VALUES (
+		:email,
+		:name,
+		:password_hash,
+		:role,
+		:created_at)`
+
+	queryFindByID = `SELECT id, email, name, role, created_at FROM users WHERE id = ? LIMIT 1`
+
+	queryFindByEmail = `SELECT * FROM users WHERE email = ? LIMIT 1`
+)
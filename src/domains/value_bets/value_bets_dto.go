@@ -0,0 +1,46 @@
+package value_bets
+
+import "time"
+
+// ListValueBetInput selects the value bets of the fixtures that have not kicked off yet. The kickoff window is
+// From until To included, today and the following 7 days by default. MinEdge, Bankroll and KellyFraction
+// default to the value_bets configuration. The bets are paginated in memory, at most 100 per page
+type ListValueBetInput struct {
+	LeagueID      int64    `json:"league_id" form:"league_id"`
+	Market        string   `json:"market" form:"market" validate:"omitempty,oneof=1x2 over_under btts asian_handicap"`
+	BookmakerID   int64    `json:"bookmaker_id" form:"bookmaker_id"`
+	MinEdge       *float64 `json:"min_edge" form:"min_edge" validate:"omitempty,gte=0,lt=1"`
+	From          string   `json:"from" form:"from" validate:"omitempty,YYYY-MM-DD"`
+	To            string   `json:"to" form:"to" validate:"omitempty,YYYY-MM-DD"`
+	Method        string   `json:"method" form:"method" validate:"omitempty,oneof=basic shin power"`
+	Bankroll      float64  `json:"bankroll" form:"bankroll" validate:"omitempty,gt=0"`
+	KellyFraction float64  `json:"kelly_fraction" form:"kelly_fraction" validate:"omitempty,gt=0,lte=1"`
+	Page          int64    `json:"page" form:"page" validate:"omitempty,gte=1"`
+	PerPage       int64    `json:"per_page" form:"per_page" validate:"omitempty,gte=1,lte=100"`
+}
+
+// ValueBet is a selection whose model probability is higher than the probability the bookmaker price implies
+// once its margin is removed. Edge is the difference of the two probabilities, ExpectedValue the expected profit
+// per unit staked at Price, Kelly the full Kelly share of the bankroll and Stake the bankroll times the Kelly
+// fraction times Kelly
+type ValueBet struct {
+	FixtureID         int64     `json:"fixture_id"`
+	LeagueID          int64     `json:"league_id"`
+	KickoffAt         time.Time `json:"kickoff_at"`
+	HomeTeamName      string    `json:"home_team_name"`
+	AwayTeamName      string    `json:"away_team_name"`
+	BookmakerID       int64     `json:"bookmaker_id"`
+	Bookmaker         string    `json:"bookmaker"`
+	Market            string    `json:"market"`
+	Selection         string    `json:"selection"`
+	Line              float64   `json:"line"`
+	Price             float64   `json:"price"`
+	RecordedAt        time.Time `json:"recorded_at"`
+	Model             string    `json:"model"`
+	ModelProbability  float64   `json:"model_probability"`
+	MarketProbability float64   `json:"market_probability"`
+	Edge              float64   `json:"edge"`
+	ExpectedValue     float64   `json:"expected_value"`
+	Kelly             float64   `json:"kelly"`
+	Stake             float64   `json:"stake"`
+}
@@ -1,7 +1,7 @@
 package main
 
 import (
-	"github.com/development-raul/footy-predictor/src/app"
+	"github.com/development-raul/footy-predictor/src/cmd"
 	"github.com/development-raul/footy-predictor/src/docs"
 	"os"
 )
@@ -34,15 +34,6 @@ func main() {
 	docs.SwaggerInfo.BasePath = "/v1"
 	docs.SwaggerInfo.Schemes = []string{"http", "https"}
 
-	appCred := app.Credentials{
-		DBName:  os.Getenv("DB_NAME"),
-		DBUser:  os.Getenv("DB_USER"),
-		DBPass:  os.Getenv("DB_PASS"),
-		DBHost:  os.Getenv("DB_HOST"),
-		DBPort:  os.Getenv("DB_PORT"),
-		AppPort: os.Getenv("APP_PORT"),
-	}
-
-	app.StartApplication(&appCred)
-	// Start application will panic so if we got here all is good :)
+	// Without a command the binary starts the API, see `footy-predictor help` for the maintenance commands
+	os.Exit(cmd.Run(os.Args, os.Stdout, os.Stderr))
 }
@@ -0,0 +1,150 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const namespace = "footy"
+
+// Outcomes of the rows processed by a sync job
+const (
+	RowCreated  = "created"
+	RowUpserted = "upserted"
+	RowSkipped  = "skipped"
+	RowFailed   = "failed"
+)
+
+// Metrics holds the application collectors
+type Metrics struct {
+	HTTPRequestDuration     *prometheus.HistogramVec
+	DBQueryDuration         *prometheus.HistogramVec
+	APISportsRequests       *prometheus.CounterVec
+	APISportsDuration       *prometheus.HistogramVec
+	APISportsQuotaRemaining *prometheus.GaugeVec
+	SyncDuration            *prometheus.HistogramVec
+	SyncRows                *prometheus.CounterVec
+}
+
+// Registry is served on /metrics, it holds the Go runtime and process collectors besides Default
+var Registry = newRegistry()
+
+// Default is used by the middlewares, DAOs, provider and services, tests replace it with New(prometheus.NewRegistry())
+var Default = New(Registry)
+
+func newRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
+
+// New creates the application collectors and registers them with reg
+func New(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		HTTPRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of the HTTP requests by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		DBQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Duration of the DAO calls by DAO and method.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"dao", "method"}),
+		APISportsRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "api_sports_requests_total",
+			Help:      "API Sports calls by action and status code, failed calls without a response have the error status.",
+		}, []string{"action", "status"}),
+		APISportsDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "api_sports_request_duration_seconds",
+			Help:      "Duration of the API Sports calls by action.",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"action"}),
+		APISportsQuotaRemaining: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "api_sports_quota_remaining",
+			Help:      "Remaining API Sports requests reported by the last response, by window (day or minute).",
+		}, []string{"window"}),
+		SyncDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "sync_duration_seconds",
+			Help:      "Duration of the sync jobs by job and result.",
+			Buckets:   []float64{.1, .5, 1, 5, 10, 30, 60, 120, 300},
+		}, []string{"job", "result"}),
+		SyncRows: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sync_rows_total",
+			Help:      "Rows processed by the sync jobs by job and outcome.",
+		}, []string{"job", "outcome"}),
+	}
+
+	reg.MustRegister(
+		m.HTTPRequestDuration,
+		m.DBQueryDuration,
+		m.APISportsRequests,
+		m.APISportsDuration,
+		m.APISportsQuotaRemaining,
+		m.SyncDuration,
+		m.SyncRows,
+	)
+	return m
+}
+
+// Handler serves the metrics gathered by g in the Prometheus text format
+func Handler(g prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(g, promhttp.HandlerOpts{})
+}
+
+// RegisterDB exposes the connection pool stats of db, e.g. open, in use and idle connections and wait time
+func RegisterDB(reg prometheus.Registerer, db *sql.DB, name string) error {
+	return reg.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// TimeQuery starts timing a DAO call, the returned func records it: defer metrics.TimeQuery("CountryDao", "List")()
+func TimeQuery(dao string, method string) func() {
+	m := Default
+	start := time.Now()
+	return func() {
+		m.DBQueryDuration.WithLabelValues(dao, method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveAPISports records an API Sports call
+func ObserveAPISports(action string, status string, duration time.Duration) {
+	Default.APISportsRequests.WithLabelValues(action, status).Inc()
+	Default.APISportsDuration.WithLabelValues(action).Observe(duration.Seconds())
+}
+
+// SetAPISportsQuota records the remaining requests of a quota window
+func SetAPISportsQuota(window string, remaining float64) {
+	Default.APISportsQuotaRemaining.WithLabelValues(window).Set(remaining)
+}
+
+// SampleCount returns the number of observations of a histogram or summary, it is meant for tests
+func SampleCount(observer prometheus.Observer) uint64 {
+	metric, ok := observer.(prometheus.Metric)
+	if !ok {
+		return 0
+	}
+	var m dto.Metric
+	if err := metric.Write(&m); err != nil {
+		return 0
+	}
+	if m.Histogram != nil {
+		return m.Histogram.GetSampleCount()
+	}
+	return m.Summary.GetSampleCount()
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// useRegistry replaces Default with collectors registered on a new registry for the duration of a test
+func useRegistry(t *testing.T) *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	defaultMetrics := Default
+	Default = New(reg)
+	t.Cleanup(func() { Default = defaultMetrics })
+	return reg
+}
+
+func TestTimeQuery(t *testing.T) {
+	useRegistry(t)
+
+	TimeQuery("CountryDao", "List")()
+	TimeQuery("CountryDao", "List")()
+	TimeQuery("CountryDao", "Create")()
+
+	assert.Equal(t, 2, testutil.CollectAndCount(Default.DBQueryDuration))
+	assert.Equal(t, uint64(2), SampleCount(Default.DBQueryDuration.WithLabelValues("CountryDao", "List")))
+}
+
+func TestAPISports(t *testing.T) {
+	useRegistry(t)
+
+	ObserveAPISports("GetCountries", "200", time.Second)
+	ObserveAPISports("GetCountries", "200", time.Second)
+	ObserveAPISports("GetCountries", "error", time.Second)
+	SetAPISportsQuota("day", 95)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(Default.APISportsRequests.WithLabelValues("GetCountries", "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(Default.APISportsRequests.WithLabelValues("GetCountries", "error")))
+	assert.Equal(t, float64(95), testutil.ToFloat64(Default.APISportsQuotaRemaining.WithLabelValues("day")))
+}
+
+func TestSyncRun(t *testing.T) {
+	useRegistry(t)
+
+	run := StartSync("countries")
+	run.Row(RowCreated)
+	run.Row(RowCreated)
+	run.Row(RowSkipped)
+	run.Done()
+
+	failed := StartSync("countries")
+	failed.Fail()
+	failed.Done()
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(Default.SyncRows.WithLabelValues("countries", RowCreated)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(Default.SyncRows.WithLabelValues("countries", RowSkipped)))
+	assert.Equal(t, 2, testutil.CollectAndCount(Default.SyncDuration))
+}
+
+func TestHandler(t *testing.T) {
+	reg := useRegistry(t)
+	Default.SyncRows.WithLabelValues("leagues", RowUpserted).Add(3)
+
+	res := httptest.NewRecorder()
+	Handler(reg).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.True(t, strings.Contains(res.Body.String(), `footy_sync_rows_total{job="leagues",outcome="upserted"} 3`))
+}
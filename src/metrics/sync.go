@@ -0,0 +1,35 @@
+package metrics
+
+import "time"
+
+// SyncRun measures a single run of a sync job
+type SyncRun struct {
+	metrics *Metrics
+	job     string
+	start   time.Time
+	failed  bool
+}
+
+// StartSync starts measuring a sync job, call Done once it returns, usually with defer
+func StartSync(job string) *SyncRun {
+	return &SyncRun{metrics: Default, job: job, start: time.Now()}
+}
+
+// Row counts a row processed by the job with one of the Row outcomes
+func (r *SyncRun) Row(outcome string) {
+	r.metrics.SyncRows.WithLabelValues(r.job, outcome).Inc()
+}
+
+// Fail marks the run as failed
+func (r *SyncRun) Fail() {
+	r.failed = true
+}
+
+// Done records the duration of the run with its result
+func (r *SyncRun) Done() {
+	result := "ok"
+	if r.failed {
+		result = "error"
+	}
+	r.metrics.SyncDuration.WithLabelValues(r.job, result).Observe(time.Since(r.start).Seconds())
+}
@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"github.com/gin-gonic/gin"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// AccessLog writes one structured log line per request once it is handled, replacing the gin text logger.
+// It must run after RequestID so the line carries the request ID, route and authenticated user
+func AccessLog() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		fields := []interface{}{
+			"method", ctx.Request.Method,
+			"path", ctx.Request.URL.Path,
+			"status", status,
+			"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"bytes", ctx.Writer.Size(),
+			"client_ip", ctx.ClientIP(),
+			"user_agent", ctx.Request.UserAgent(),
+		}
+		if len(ctx.Errors) > 0 {
+			fields = append(fields, "errors", ctx.Errors.String())
+		}
+
+		logger := zlog.FromContext(ctx)
+		switch {
+		case status >= http.StatusInternalServerError:
+			logger.Errorw("request", fields...)
+		case status >= http.StatusBadRequest:
+			logger.Warnw("request", fields...)
+		default:
+			logger.Infow("request", fields...)
+		}
+	}
+}
+
+// Recovery turns panics into a standard 500 response and logs them with the request logger
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(ioutil.Discard, func(ctx *gin.Context, recovered interface{}) {
+		zlog.FromContext(ctx).Errorw("panic recovered", "panic", recovered)
+		apiErr := resterror.NewStandardInternalServerError()
+		ctx.AbortWithStatusJSON(apiErr.Code(), apiErr)
+	})
+}
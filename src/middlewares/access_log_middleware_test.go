@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccessLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	services.AuthService = &MockAuthService{
+		FuncAuthenticate: func(credential string) (*auth.Claims, resterror.RestErrorI) {
+			claims := auth.Claims{Role: auth.RoleReader}
+			claims.Subject = "user:7"
+			return &claims, nil
+		},
+	}
+
+	testCases := []struct {
+		title          string
+		path           string
+		expectedStatus int
+		expectedLevel  zapcore.Level
+		expectedFields map[string]interface{}
+	}{
+		{
+			title:          "success authenticated request",
+			path:           "/teams/3",
+			expectedStatus: http.StatusOK,
+			expectedLevel:  zapcore.InfoLevel,
+			expectedFields: map[string]interface{}{
+				"request_id": "req-1",
+				"route":      "/teams/:id",
+				"user":       "user:7",
+				"method":     "GET",
+				"path":       "/teams/3",
+				"status":     int64(http.StatusOK),
+			},
+		},
+		{
+			title:          "not found",
+			path:           "/missing",
+			expectedStatus: http.StatusNotFound,
+			expectedLevel:  zapcore.WarnLevel,
+			expectedFields: map[string]interface{}{
+				"request_id": "req-1",
+				"route":      "",
+				"status":     int64(http.StatusNotFound),
+			},
+		},
+		{
+			title:          "panic",
+			path:           "/panic",
+			expectedStatus: http.StatusInternalServerError,
+			expectedLevel:  zapcore.ErrorLevel,
+			expectedFields: map[string]interface{}{
+				"request_id": "req-1",
+				"route":      "/panic",
+				"status":     int64(http.StatusInternalServerError),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			defaultLogger := zlog.Logger
+			zlog.Logger = zap.New(core).Sugar()
+			defer func() { zlog.Logger = defaultLogger }()
+
+			router := gin.New()
+			router.Use(RequestID(), AccessLog(), Recovery())
+			router.GET("/teams/:id", Authenticate(), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, "ok")
+			})
+			router.GET("/panic", func(ctx *gin.Context) {
+				panic("boom")
+			})
+
+			req, _ := http.NewRequest("GET", testCase.path, nil)
+			req.Header.Set(constants.HeaderRequestID, "req-1")
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			entries := logs.FilterMessage("request").All()
+			if assert.Len(t, entries, 1) {
+				assert.Equal(t, testCase.expectedLevel, entries[0].Level)
+				fields := entries[0].ContextMap()
+				for key, value := range testCase.expectedFields {
+					assert.Equal(t, value, fields[key], key)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+// Authenticate validates the Authorization header, accepting either "Bearer <jwt>" or "ApiKey <key>",
+// and stores the caller subject and role in the context
+func Authenticate() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, apiErr := services.AuthService.Authenticate(ctx.Request.Context(), credentialFromHeader(ctx.GetHeader("Authorization")))
+		if apiErr != nil {
+			ctx.AbortWithStatusJSON(apiErr.Code(), apiErr)
+			return
+		}
+
+		ctx.Set(constants.ContextKeyAuthSubject, claims.Subject)
+		ctx.Set(constants.ContextKeyAuthRole, claims.Role)
+		zlog.With(ctx, "user", claims.Subject)
+		ctx.Next()
+	}
+}
+
+// Authorize makes sure the authenticated caller has at least the required role
+func Authorize(required string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		role := ctx.GetString(constants.ContextKeyAuthRole)
+		if role == "" {
+			apiErr := resterror.NewUnauthorizedError("INVALID_USER_AUTHENTICATION")
+			ctx.AbortWithStatusJSON(apiErr.Code(), apiErr)
+			return
+		}
+		if !auth.HasRole(role, required) {
+			apiErr := resterror.NewForbiddenError("INSUFFICIENT_PERMISSIONS")
+			ctx.AbortWithStatusJSON(apiErr.Code(), apiErr)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func credentialFromHeader(header string) string {
+	parts := strings.SplitN(strings.TrimSpace(header), " ", 2)
+	if len(parts) != 2 {
+		return strings.TrimSpace(header)
+	}
+	switch strings.ToLower(parts[0]) {
+	case "bearer", "apikey":
+		return strings.TrimSpace(parts[1])
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,127 @@
+package middlewares
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/api_keys"
+	"github.com/development-raul/footy-predictor/src/domains/users"
+	"github.com/development-raul/footy-predictor/src/services"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockAuthService struct {
+	FuncAuthenticate func(credential string) (*auth.Claims, resterror.RestErrorI)
+	FuncToken        func(req *api_keys.TokenInput) (*api_keys.TokenOutput, resterror.RestErrorI)
+	FuncLogin        func(req *users.LoginInput) (*api_keys.TokenOutput, resterror.RestErrorI)
+}
+
+func (m MockAuthService) Authenticate(ctx context.Context, credential string) (*auth.Claims, resterror.RestErrorI) {
+	return m.FuncAuthenticate(credential)
+}
+func (m MockAuthService) Token(ctx context.Context, req *api_keys.TokenInput) (*api_keys.TokenOutput, resterror.RestErrorI) {
+	return m.FuncToken(req)
+}
+func (m MockAuthService) Login(ctx context.Context, req *users.LoginInput) (*api_keys.TokenOutput, resterror.RestErrorI) {
+	return m.FuncLogin(req)
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authServiceMock := &MockAuthService{
+		FuncAuthenticate: func(credential string) (*auth.Claims, resterror.RestErrorI) {
+			roles := map[string]string{
+				"reader-key": auth.RoleReader,
+				"editor-key": auth.RoleEditor,
+			}
+			role, ok := roles[credential]
+			if !ok {
+				return nil, resterror.NewUnauthorizedError("INVALID_USER_AUTHENTICATION")
+			}
+			claims := auth.Claims{Role: role}
+			claims.Subject = credential
+			return &claims, nil
+		},
+	}
+
+	testCases := []struct {
+		title          string
+		header         string
+		expectedStatus int
+		expectedRes    string
+	}{
+		{
+			title:          "error missing header",
+			header:         "",
+			expectedStatus: http.StatusUnauthorized,
+			expectedRes:    `{"error":"INVALID_USER_AUTHENTICATION","error_code":"INVALID_USER_AUTHENTICATION","code":401}`,
+		},
+		{
+			title:          "error unsupported scheme",
+			header:         "Basic editor-key",
+			expectedStatus: http.StatusUnauthorized,
+			expectedRes:    `{"error":"INVALID_USER_AUTHENTICATION","error_code":"INVALID_USER_AUTHENTICATION","code":401}`,
+		},
+		{
+			title:          "error insufficient role",
+			header:         "ApiKey reader-key",
+			expectedStatus: http.StatusForbidden,
+			expectedRes:    `{"error":"INSUFFICIENT_PERMISSIONS","error_code":"INSUFFICIENT_PERMISSIONS","code":403}`,
+		},
+		{
+			title:          "success bearer",
+			header:         "Bearer editor-key",
+			expectedStatus: http.StatusOK,
+			expectedRes:    `editor-key`,
+		},
+		{
+			title:          "success without scheme",
+			header:         "editor-key",
+			expectedStatus: http.StatusOK,
+			expectedRes:    `editor-key`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			services.AuthService = authServiceMock
+
+			router := gin.New()
+			router.POST("/test", Authenticate(), Authorize(auth.RoleEditor), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, ctx.GetString(constants.ContextKeyAuthSubject))
+			})
+
+			req, _ := http.NewRequest("POST", "/test", nil)
+			if testCase.header != "" {
+				req.Header.Set("Authorization", testCase.header)
+			}
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			assert.Equal(t, testCase.expectedRes, res.Body.String())
+		})
+	}
+}
+
+func TestAuthorize_WithoutAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/test", Authorize(auth.RoleReader), func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, "ok")
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+	assert.Equal(t, `{"error":"INVALID_USER_AUTHENTICATION","error_code":"INVALID_USER_AUTHENTICATION","code":401}`, res.Body.String())
+}
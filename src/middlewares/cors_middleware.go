@@ -0,0 +1,150 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSConfig defines which cross origin requests are allowed
+type CORSConfig struct {
+	// AllowedOrigins accepts exact origins, "*" for any origin or wildcard patterns such as "https://*.example.com".
+	// The origins only allowed by "*" never get credentials
+	AllowedOrigins []string
+	AllowedMethods []string
+	// AllowedHeaders accepts "*" to allow any header requested by the browser
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// DefaultCORSConfig returns the settings used when nothing else is configured
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedOrigins:   []string{"https://localhost:8080"},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-Requested-With", "If-Match", "If-None-Match"},
+		AllowCredentials: true,
+		MaxAge:           24 * time.Hour,
+	}
+}
+
+// CORS adds the CORS headers for allowed origins and answers preflight requests without reaching the routes
+func CORS(cfg CORSConfig) gin.HandlerFunc {
+	methods := strings.Join(upperAll(cfg.AllowedMethods), ", ")
+	exposed := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.FormatInt(int64(cfg.MaxAge/time.Second), 10)
+	anyHeader := containsString(cfg.AllowedHeaders, "*")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+
+	return func(ctx *gin.Context) {
+		origin := ctx.GetHeader("Origin")
+		if origin == "" {
+			// Not a cross origin request
+			ctx.Next()
+			return
+		}
+		// The response depends on the origin, make sure caches do not mix them up
+		ctx.Writer.Header().Add("Vary", "Origin")
+
+		preflight := ctx.Request.Method == http.MethodOptions && ctx.GetHeader("Access-Control-Request-Method") != ""
+		allowed, listed := originAllowed(cfg.AllowedOrigins, origin)
+		if !allowed {
+			if preflight {
+				ctx.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			// Let the request through without CORS headers, the browser will block the response
+			ctx.Next()
+			return
+		}
+
+		// Credentials are only sent to the origins listed or matching a pattern, any other site gets the wildcard
+		// origin without them
+		if !listed {
+			ctx.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			ctx.Header("Access-Control-Allow-Origin", origin)
+			if cfg.AllowCredentials {
+				ctx.Header("Access-Control-Allow-Credentials", "true")
+			}
+		}
+
+		if !preflight {
+			if exposed != "" {
+				ctx.Header("Access-Control-Expose-Headers", exposed)
+			}
+			ctx.Next()
+			return
+		}
+
+		ctx.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+		ctx.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+		if !containsString(upperAll(cfg.AllowedMethods), strings.ToUpper(ctx.GetHeader("Access-Control-Request-Method"))) {
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		ctx.Header("Access-Control-Allow-Methods", methods)
+		if anyHeader {
+			ctx.Header("Access-Control-Allow-Headers", ctx.GetHeader("Access-Control-Request-Headers"))
+		} else if headers != "" {
+			ctx.Header("Access-Control-Allow-Headers", headers)
+		}
+		if cfg.MaxAge > 0 {
+			ctx.Header("Access-Control-Max-Age", maxAge)
+		}
+		ctx.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// originAllowed checks if the origin is allowed and if it is listed or matches a pattern, rather than being only
+// allowed by "*"
+func originAllowed(allowed []string, origin string) (bool, bool) {
+	origin = strings.ToLower(origin)
+	for _, pattern := range allowed {
+		if pattern != "*" && matchWildcard(strings.ToLower(pattern), origin) {
+			return true, true
+		}
+	}
+	return containsString(allowed, "*"), false
+}
+
+// matchWildcard checks value against a pattern where every "*" matches any sequence of characters
+func matchWildcard(pattern string, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, v := range haystack {
+		if v == needle {
+			return true
+		}
+	}
+	return false
+}
+
+func upperAll(values []string) []string {
+	res := make([]string, len(values))
+	for i, v := range values {
+		res[i] = strings.ToUpper(strings.TrimSpace(v))
+	}
+	return res
+}
@@ -0,0 +1,226 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.footy.dev", "http://localhost:*"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+
+	testCases := []struct {
+		title           string
+		cfg             CORSConfig
+		method          string
+		headers         map[string]string
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			title:          "no origin",
+			cfg:            cfg,
+			method:         "GET",
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+				"Vary":                        "",
+			},
+		},
+		{
+			title:          "origin not allowed",
+			cfg:            cfg,
+			method:         "GET",
+			headers:        map[string]string{"Origin": "https://evil.com"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+				"Vary":                        "Origin",
+			},
+		},
+		{
+			title:          "exact origin",
+			cfg:            cfg,
+			method:         "GET",
+			headers:        map[string]string{"Origin": "https://app.example.com"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "X-Request-ID",
+			},
+		},
+		{
+			title:          "wildcard subdomain",
+			cfg:            cfg,
+			method:         "GET",
+			headers:        map[string]string{"Origin": "https://staging.footy.dev"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "https://staging.footy.dev",
+			},
+		},
+		{
+			title:          "wildcard does not match the bare domain",
+			cfg:            cfg,
+			method:         "GET",
+			headers:        map[string]string{"Origin": "https://footy.dev"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+		{
+			title:          "wildcard port",
+			cfg:            cfg,
+			method:         "GET",
+			headers:        map[string]string{"Origin": "http://localhost:3000"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "http://localhost:3000",
+			},
+		},
+		{
+			title: "any origin without credentials",
+			cfg: CORSConfig{
+				AllowedOrigins: []string{"*"},
+				AllowedMethods: []string{"GET"},
+			},
+			method:         "GET",
+			headers:        map[string]string{"Origin": "https://anything.com"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "*",
+				"Access-Control-Allow-Credentials": "",
+			},
+		},
+		{
+			title: "any origin never gets credentials",
+			cfg: CORSConfig{
+				AllowedOrigins:   []string{"*", "https://app.example.com"},
+				AllowedMethods:   []string{"GET"},
+				AllowCredentials: true,
+			},
+			method:         "GET",
+			headers:        map[string]string{"Origin": "https://anything.com"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "*",
+				"Access-Control-Allow-Credentials": "",
+			},
+		},
+		{
+			title: "listed origin next to any origin gets credentials",
+			cfg: CORSConfig{
+				AllowedOrigins:   []string{"*", "https://app.example.com"},
+				AllowedMethods:   []string{"GET"},
+				AllowCredentials: true,
+			},
+			method:         "GET",
+			headers:        map[string]string{"Origin": "https://app.example.com"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+			},
+		},
+		{
+			title:  "preflight origin not allowed",
+			cfg:    cfg,
+			method: "OPTIONS",
+			headers: map[string]string{
+				"Origin":                        "https://evil.com",
+				"Access-Control-Request-Method": "POST",
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+		{
+			title:  "preflight method not allowed",
+			cfg:    cfg,
+			method: "OPTIONS",
+			headers: map[string]string{
+				"Origin":                        "https://app.example.com",
+				"Access-Control-Request-Method": "DELETE",
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Methods": "",
+			},
+		},
+		{
+			title:  "preflight success",
+			cfg:    cfg,
+			method: "OPTIONS",
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  "post",
+				"Access-Control-Request-Headers": "Content-Type",
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Methods":     "GET, POST",
+				"Access-Control-Allow-Headers":     "Content-Type, Authorization",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Max-Age":           "600",
+			},
+		},
+		{
+			title: "preflight any header",
+			cfg: CORSConfig{
+				AllowedOrigins: []string{"https://app.example.com"},
+				AllowedMethods: []string{"PUT"},
+				AllowedHeaders: []string{"*"},
+			},
+			method: "OPTIONS",
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  "PUT",
+				"Access-Control-Request-Headers": "X-Custom",
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Headers": "X-Custom",
+				"Access-Control-Max-Age":       "",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			// Preflight requests are answered by the middleware even though no OPTIONS route exists
+			router := gin.New()
+			router.Use(CORS(testCase.cfg))
+			router.GET("/test", func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, "ok")
+			})
+
+			req, _ := http.NewRequest(testCase.method, "/test", nil)
+			for k, v := range testCase.headers {
+				req.Header.Set(k, v)
+			}
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+
+			assert.Equal(t, testCase.expectedStatus, res.Code)
+			for k, v := range testCase.expectedHeaders {
+				assert.Equal(t, v, res.Header().Get(k), k)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package middlewares
+
+import (
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"time"
+)
+
+// unmatchedRoute labels the requests that did not match any route, so unknown paths cannot
+// create new series
+const unmatchedRoute = "unmatched"
+
+// Metrics records the duration of every request by method, route template and status code
+func Metrics() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		metrics.Default.HTTPRequestDuration.
+			WithLabelValues(ctx.Request.Method, route, strconv.Itoa(ctx.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	defaultMetrics := metrics.Default
+	metrics.Default = metrics.New(prometheus.NewRegistry())
+	defer func() { metrics.Default = defaultMetrics }()
+
+	router := gin.New()
+	router.Use(Metrics())
+	router.GET("/teams/:id", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, "ok")
+	})
+
+	for _, path := range []string{"/teams/1", "/teams/2", "/missing", "/other"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	histogram := metrics.Default.HTTPRequestDuration
+	assert.Equal(t, 2, testutil.CollectAndCount(histogram))
+	assert.Equal(t, uint64(2), metrics.SampleCount(histogram.WithLabelValues("GET", "/teams/:id", "200")))
+	assert.Equal(t, uint64(2), metrics.SampleCount(histogram.WithLabelValues("GET", unmatchedRoute, "404")))
+}
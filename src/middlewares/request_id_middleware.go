@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"github.com/gin-gonic/gin"
+)
+
+const maxRequestIDLength = 128
+
+// RequestID keeps the X-Request-ID sent by the client, or generates one, returns it in the response
+// and scopes the request logger to it and to the matched route
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(constants.HeaderRequestID)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		ctx.Set(constants.ContextKeyRequestID, id)
+		ctx.Header(constants.HeaderRequestID, id)
+		zlog.With(ctx, "request_id", id, "route", ctx.FullPath())
+		ctx.Next()
+	}
+}
+
+// validRequestID only accepts short IDs made of characters that are safe to log and echo back
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		zlog.Logger.Errorw("RequestID rand.Read", "error", err)
+	}
+	return hex.EncodeToString(b)
+}
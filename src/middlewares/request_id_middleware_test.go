@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		title      string
+		header     string
+		expectedID string
+	}{
+		{
+			title:  "generated when missing",
+			header: "",
+		},
+		{
+			title:  "generated when too long",
+			header: strings.Repeat("a", maxRequestIDLength+1),
+		},
+		{
+			title:  "generated when it has unsafe characters",
+			header: "abc\ndef",
+		},
+		{
+			title:      "kept from the client",
+			header:     "client-id_1.2:3",
+			expectedID: "client-id_1.2:3",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			router := gin.New()
+			router.Use(RequestID())
+			router.GET("/test", func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, ctx.GetString(constants.ContextKeyRequestID))
+			})
+
+			req, _ := http.NewRequest("GET", "/test", nil)
+			if testCase.header != "" {
+				req.Header.Set(constants.HeaderRequestID, testCase.header)
+			}
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+
+			id := res.Header().Get(constants.HeaderRequestID)
+			assert.Equal(t, id, res.Body.String())
+			if testCase.expectedID != "" {
+				assert.Equal(t, testCase.expectedID, id)
+				return
+			}
+			assert.Len(t, id, 32)
+			assert.NotEqual(t, testCase.header, id)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		title           string
+		cfg             SecurityHeadersConfig
+		expectedHeaders map[string]string
+	}{
+		{
+			title: "development defaults",
+			cfg:   DefaultSecurityHeadersConfig("dev"),
+			expectedHeaders: map[string]string{
+				"Strict-Transport-Security": "",
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "DENY",
+				"Referrer-Policy":           "no-referrer",
+				"Content-Security-Policy":   "",
+			},
+		},
+		{
+			title: "production defaults",
+			cfg:   DefaultSecurityHeadersConfig("production"),
+			expectedHeaders: map[string]string{
+				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "DENY",
+			},
+		},
+		{
+			title: "custom",
+			cfg: SecurityHeadersConfig{
+				HSTSMaxAge:            time.Hour,
+				HSTSPreload:           true,
+				FrameOptions:          "sameorigin",
+				ContentSecurityPolicy: "default-src 'self'",
+			},
+			expectedHeaders: map[string]string{
+				"Strict-Transport-Security": "max-age=3600; preload",
+				"X-Content-Type-Options":    "",
+				"X-Frame-Options":           "SAMEORIGIN",
+				"Referrer-Policy":           "",
+				"Content-Security-Policy":   "default-src 'self'",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			router := gin.New()
+			router.Use(SecurityHeaders(testCase.cfg))
+			router.GET("/test", func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, "ok")
+			})
+
+			req, _ := http.NewRequest("GET", "/test", nil)
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+
+			assert.Equal(t, http.StatusOK, res.Code)
+			for k, v := range testCase.expectedHeaders {
+				assert.Equal(t, v, res.Header().Get(k), k)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+// Tracing starts the server span of every request, continuing the trace of the caller when a W3C traceparent
+// header is sent. The span context is stored in the request context, which the controllers pass down to the
+// services, DAOs and API Sports calls, and the trace ID is added to the request logger
+func Tracing(serviceName string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		parent := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+		spanCtx, span := tracing.Start(parent, ctx.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			tracing.Attributes(semconv.HTTPServerAttributesFromHTTPRequest(serviceName, route, ctx.Request)...),
+		)
+		defer span.End()
+
+		ctx.Request = ctx.Request.WithContext(spanCtx)
+		if sc := span.SpanContext(); sc.IsValid() {
+			zlog.With(ctx, "trace_id", sc.TraceID().String())
+		}
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+		// Client errors are not failures of the server span
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package middlewares
+
+import (
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTracing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		title          string
+		path           string
+		traceparent    string
+		status         int
+		expectedName   string
+		expectedTrace  string
+		expectedStatus codes.Code
+	}{
+		{
+			title:          "success new trace",
+			path:           "/fixtures/10",
+			status:         http.StatusOK,
+			expectedName:   "GET /fixtures/:id",
+			expectedStatus: codes.Unset,
+		},
+		{
+			title:          "success continues the caller trace",
+			path:           "/fixtures/10",
+			traceparent:    "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			status:         http.StatusOK,
+			expectedName:   "GET /fixtures/:id",
+			expectedTrace:  "4bf92f3577b34da6a3ce929d0e0e4736",
+			expectedStatus: codes.Unset,
+		},
+		{
+			title:          "client error is not a span error",
+			path:           "/fixtures/10",
+			status:         http.StatusNotFound,
+			expectedName:   "GET /fixtures/:id",
+			expectedStatus: codes.Unset,
+		},
+		{
+			title:          "server error",
+			path:           "/fixtures/10",
+			status:         http.StatusInternalServerError,
+			expectedName:   "GET /fixtures/:id",
+			expectedStatus: codes.Error,
+		},
+		{
+			title:          "unmatched route",
+			path:           "/unknown",
+			status:         http.StatusNotFound,
+			expectedName:   "GET unmatched",
+			expectedStatus: codes.Unset,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			tracing.Use(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+			var handlerSpan trace.SpanContext
+			router := gin.New()
+			router.Use(Tracing("footy-predictor"))
+			router.GET("/fixtures/:id", func(ctx *gin.Context) {
+				handlerSpan = trace.SpanContextFromContext(ctx.Request.Context())
+				ctx.Status(testCase.status)
+			})
+
+			req, _ := http.NewRequest("GET", testCase.path, nil)
+			if testCase.traceparent != "" {
+				req.Header.Set("traceparent", testCase.traceparent)
+			}
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+
+			assert.Equal(t, testCase.status, res.Code)
+			spans := recorder.Ended()
+			if !assert.Len(t, spans, 1) {
+				return
+			}
+			span := spans[0]
+			assert.Equal(t, testCase.expectedName, span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Equal(t, testCase.expectedStatus, span.Status().Code)
+			if testCase.expectedTrace != "" {
+				assert.Equal(t, testCase.expectedTrace, span.SpanContext().TraceID().String())
+				assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+			}
+			if testCase.path != "/unknown" {
+				assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID())
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+// Migration is a single schema change. MySQL commits DDL statements implicitly, so every migration
+// holds one statement to keep a failure from leaving a migration half applied
+type Migration struct {
+	Version int64  `json:"version"`
+	Name    string `json:"name"`
+	Up      string `json:"-"`
+}
+
+// Pending returns the migrations that have not been applied yet
+func Pending() ([]Migration, error) {
+	if _, err := footy_db.Client.Exec(queryCreateMigrationsTable); err != nil {
+		zlog.Logger.Errorw("Migrations Pending Exec", "error", err)
+		return nil, err
+	}
+
+	var versions []int64
+	if err := footy_db.Client.Select(&versions, queryListApplied); err != nil {
+		zlog.Logger.Errorw("Migrations Pending Select", "error", err)
+		return nil, err
+	}
+	applied := make(map[int64]bool, len(versions))
+	for _, v := range versions {
+		applied[v] = true
+	}
+
+	var pending []Migration
+	for _, m := range All {
+		if !applied[m.Version] {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies every pending migration in order and returns the ones that were applied,
+// it stops at the first failure so later migrations never run on top of a broken schema
+func Up() ([]Migration, error) {
+	pending, err := Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, m := range pending {
+		if _, err := footy_db.Client.Exec(m.Up); err != nil {
+			zlog.Logger.Errorw("Migrations Up Exec", "version", m.Version, "name", m.Name, "error", err)
+			return applied, err
+		}
+		if _, err := footy_db.Client.Exec(queryInsertApplied, m.Version, m.Name, helpers.GetNow()); err != nil {
+			zlog.Logger.Errorw("Migrations Up Exec schema_migrations", "error", err)
+			return applied, err
+		}
+		zlog.Logger.Infow("applied migration", "version", m.Version, "name", m.Name)
+		applied = append(applied, m)
+	}
+	return applied, nil
+}
@@ -0,0 +1,12 @@
+package migrations
+
+const (
+	queryCreateMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at DATETIME NOT NULL)`
+
+	queryListApplied = `SELECT version FROM schema_migrations ORDER BY version`
+
+	queryInsertApplied = `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`
+)
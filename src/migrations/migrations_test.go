@@ -0,0 +1,114 @@
+package migrations
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	// Versions must be unique and increasing, otherwise the apply order would not be deterministic
+	for i := 1; i < len(All); i++ {
+		assert.Greater(t, All[i].Version, All[i-1].Version, All[i].Name)
+	}
+}
+
+func TestUp(t *testing.T) {
+	testCases := []struct {
+		title           string
+		funcMock        func(sqlmock.Sqlmock)
+		expectedApplied int
+		expectedErr     error
+	}{
+		{
+			title: "error create schema_migrations",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+					WillReturnError(errors.New("test Exec"))
+			},
+			expectedErr: errors.New("test Exec"),
+		},
+		{
+			title: "error list applied",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery("SELECT version FROM schema_migrations").
+					WillReturnError(errors.New("test Select"))
+			},
+			expectedErr: errors.New("test Select"),
+		},
+		{
+			title: "error migration stops the run",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				rows := sqlmock.NewRows([]string{"version"})
+				for _, migration := range All[:len(All)-2] {
+					rows.AddRow(migration.Version)
+				}
+				m.ExpectQuery("SELECT version FROM schema_migrations").WillReturnRows(rows)
+				m.ExpectExec(regexp.QuoteMeta(All[len(All)-2].Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("INSERT INTO schema_migrations").
+					WithArgs(All[len(All)-2].Version, All[len(All)-2].Name, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta(All[len(All)-1].Up)).WillReturnError(errors.New("test migration"))
+			},
+			expectedApplied: 1,
+			expectedErr:     errors.New("test migration"),
+		},
+		{
+			title: "success nothing pending",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				rows := sqlmock.NewRows([]string{"version"})
+				for _, migration := range All {
+					rows.AddRow(migration.Version)
+				}
+				m.ExpectQuery("SELECT version FROM schema_migrations").WillReturnRows(rows)
+			},
+			expectedApplied: 0,
+			expectedErr:     nil,
+		},
+		{
+			title: "success",
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery("SELECT version FROM schema_migrations").
+					WillReturnRows(sqlmock.NewRows([]string{"version"}))
+				for _, migration := range All {
+					m.ExpectExec(regexp.QuoteMeta(migration.Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+					m.ExpectExec("INSERT INTO schema_migrations").
+						WithArgs(migration.Version, migration.Name, sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+			},
+			expectedApplied: len(All),
+			expectedErr:     nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+
+			applied, err := Up()
+
+			assert.Equal(t, testCase.expectedApplied, len(applied))
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
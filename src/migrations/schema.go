@@ -0,0 +1,440 @@
+package migrations
+
+// All holds every schema change in the order it has to be applied. Migrations are never edited once released,
+// changes to an existing table get a new migration. The first migrations use IF NOT EXISTS so databases created
+// before migrations existed can be brought under version control
+var All = []Migration{
+	{
+		Version: 1,
+		Name:    "create_countries",
+		Up: `CREATE TABLE IF NOT EXISTS countries (
+			id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			code VARCHAR(10) NOT NULL DEFAULT '',
+			name VARCHAR(100) NOT NULL,
+			flag VARCHAR(255) NOT NULL DEFAULT '',
+			active TINYINT(1) NOT NULL DEFAULT 1,
+			UNIQUE KEY countries_name_unique (name))`,
+	},
+	{
+		Version: 2,
+		Name:    "create_seasons",
+		Up: `CREATE TABLE IF NOT EXISTS seasons (
+			id INT UNSIGNED NOT NULL PRIMARY KEY)`,
+	},
+	{
+		Version: 3,
+		Name:    "create_leagues",
+		Up: `CREATE TABLE IF NOT EXISTS leagues (
+			id BIGINT UNSIGNED NOT NULL PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			type VARCHAR(20) NOT NULL,
+			logo VARCHAR(255) NOT NULL DEFAULT '',
+			country_name VARCHAR(100) NOT NULL DEFAULT '',
+			country_code VARCHAR(10) NOT NULL DEFAULT '',
+			active TINYINT(1) NOT NULL DEFAULT 1,
+			KEY leagues_country_code_index (country_code))`,
+	},
+	{
+		Version: 4,
+		Name:    "create_fixtures",
+		Up: `CREATE TABLE IF NOT EXISTS fixtures (
+			id BIGINT UNSIGNED NOT NULL PRIMARY KEY,
+			league_id BIGINT UNSIGNED NOT NULL,
+			season INT UNSIGNED NOT NULL,
+			round VARCHAR(100) NOT NULL DEFAULT '',
+			kickoff_at DATETIME NOT NULL,
+			status VARCHAR(10) NOT NULL,
+			home_team_id BIGINT UNSIGNED NOT NULL,
+			home_team_name VARCHAR(100) NOT NULL,
+			away_team_id BIGINT UNSIGNED NOT NULL,
+			away_team_name VARCHAR(100) NOT NULL,
+			home_goals INT UNSIGNED NULL,
+			away_goals INT UNSIGNED NULL,
+			KEY fixtures_league_season_index (league_id, season),
+			KEY fixtures_kickoff_at_index (kickoff_at),
+			KEY fixtures_home_team_index (home_team_id),
+			KEY fixtures_away_team_index (away_team_id))`,
+	},
+	{
+		Version: 5,
+		Name:    "create_api_keys",
+		Up: `CREATE TABLE IF NOT EXISTS api_keys (
+			id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			key_prefix VARCHAR(16) NOT NULL,
+			key_hash CHAR(64) NOT NULL,
+			role VARCHAR(20) NOT NULL,
+			active TINYINT(1) NOT NULL DEFAULT 1,
+			last_used_at DATETIME NULL,
+			created_at DATETIME NOT NULL,
+			UNIQUE KEY api_keys_key_hash_unique (key_hash))`,
+	},
+	{
+		Version: 6,
+		Name:    "create_users",
+		Up: `CREATE TABLE IF NOT EXISTS users (
+			id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			email VARCHAR(255) NOT NULL,
+			name VARCHAR(100) NOT NULL,
+			password_hash VARCHAR(255) NOT NULL,
+			role VARCHAR(20) NOT NULL,
+			created_at DATETIME NOT NULL,
+			UNIQUE KEY users_email_unique (email))`,
+	},
+	{
+		Version: 7,
+		Name:    "create_competitions",
+		Up: `CREATE TABLE IF NOT EXISTS competitions (
+			id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			owner_user_id BIGINT UNSIGNED NOT NULL,
+			points_exact INT UNSIGNED NOT NULL,
+			points_goal_difference INT UNSIGNED NOT NULL,
+			points_result INT UNSIGNED NOT NULL,
+			created_at DATETIME NOT NULL,
+			KEY competitions_owner_user_index (owner_user_id),
+			CONSTRAINT competitions_owner_user_fk FOREIGN KEY (owner_user_id) REFERENCES users (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 8,
+		Name:    "create_memberships",
+		Up: `CREATE TABLE IF NOT EXISTS memberships (
+			competition_id BIGINT UNSIGNED NOT NULL,
+			user_id BIGINT UNSIGNED NOT NULL,
+			joined_at DATETIME NOT NULL,
+			PRIMARY KEY (competition_id, user_id),
+			KEY memberships_user_index (user_id),
+			CONSTRAINT memberships_competition_fk FOREIGN KEY (competition_id) REFERENCES competitions (id) ON DELETE CASCADE,
+			CONSTRAINT memberships_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 9,
+		Name:    "create_user_predictions",
+		Up: `CREATE TABLE IF NOT EXISTS user_predictions (
+			id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			competition_id BIGINT UNSIGNED NOT NULL,
+			user_id BIGINT UNSIGNED NOT NULL,
+			fixture_id BIGINT UNSIGNED NOT NULL,
+			home_goals INT UNSIGNED NOT NULL,
+			away_goals INT UNSIGNED NOT NULL,
+			points INT UNSIGNED NULL,
+			scored_at DATETIME NULL,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			UNIQUE KEY user_predictions_unique (competition_id, user_id, fixture_id),
+			KEY user_predictions_fixture_index (fixture_id),
+			CONSTRAINT user_predictions_competition_fk FOREIGN KEY (competition_id) REFERENCES competitions (id) ON DELETE CASCADE,
+			CONSTRAINT user_predictions_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 10,
+		Name:    "add_seasons_label",
+		Up:      `ALTER TABLE seasons ADD COLUMN label VARCHAR(10) NOT NULL DEFAULT ''`,
+	},
+	{
+		Version: 11,
+		Name:    "fill_seasons_label",
+		Up:      `UPDATE seasons SET label = CONCAT(id, '/', LPAD(MOD(id + 1, 100), 2, '0')) WHERE label = ''`,
+	},
+	{
+		Version: 12,
+		Name:    "create_league_seasons",
+		Up: `CREATE TABLE IF NOT EXISTS league_seasons (
+			league_id BIGINT UNSIGNED NOT NULL,
+			season INT UNSIGNED NOT NULL,
+			label VARCHAR(10) NOT NULL,
+			start_date DATE NOT NULL,
+			end_date DATE NOT NULL,
+			current TINYINT(1) NOT NULL DEFAULT 0,
+			PRIMARY KEY (league_id, season),
+			KEY league_seasons_season_index (season),
+			CONSTRAINT league_seasons_league_fk FOREIGN KEY (league_id) REFERENCES leagues (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 13,
+		Name:    "add_countries_version",
+		Up: `ALTER TABLE countries
+			ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1,
+			ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP`,
+	},
+	{
+		Version: 14,
+		Name:    "add_seasons_version",
+		Up: `ALTER TABLE seasons
+			ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1,
+			ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP`,
+	},
+	{
+		Version: 15,
+		Name:    "create_odds",
+		Up: `CREATE TABLE IF NOT EXISTS odds (
+			id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			fixture_id BIGINT UNSIGNED NOT NULL,
+			bookmaker_id INT UNSIGNED NOT NULL,
+			bookmaker VARCHAR(100) NOT NULL,
+			market VARCHAR(20) NOT NULL,
+			selection VARCHAR(10) NOT NULL,
+			line DECIMAL(6,2) NOT NULL DEFAULT 0,
+			price DECIMAL(8,3) NOT NULL,
+			recorded_at DATETIME NOT NULL,
+			UNIQUE KEY odds_price_unique (fixture_id, bookmaker_id, market, selection, line, recorded_at),
+			KEY odds_fixture_market_index (fixture_id, market),
+			CONSTRAINT odds_fixture_fk FOREIGN KEY (fixture_id) REFERENCES fixtures (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 16,
+		Name:    "create_backtests",
+		Up: `CREATE TABLE IF NOT EXISTS backtests (
+			id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			league_id BIGINT UNSIGNED NOT NULL,
+			season_from INT UNSIGNED NOT NULL,
+			season_to INT UNSIGNED NOT NULL,
+			model VARCHAR(50) NOT NULL,
+			min_edge DECIMAL(5,4) NOT NULL DEFAULT 0,
+			status VARCHAR(10) NOT NULL,
+			error VARCHAR(255) NOT NULL DEFAULT '',
+			fixtures INT UNSIGNED NOT NULL DEFAULT 0,
+			skipped INT UNSIGNED NOT NULL DEFAULT 0,
+			log_loss DOUBLE NULL,
+			brier DOUBLE NULL,
+			rps DOUBLE NULL,
+			accuracy DOUBLE NULL,
+			bets INT UNSIGNED NOT NULL DEFAULT 0,
+			staked DOUBLE NOT NULL DEFAULT 0,
+			profit DOUBLE NOT NULL DEFAULT 0,
+			roi DOUBLE NULL,
+			created_at DATETIME NOT NULL,
+			started_at DATETIME NULL,
+			finished_at DATETIME NULL,
+			KEY backtests_league_model_index (league_id, model))`,
+	},
+	{
+		Version: 17,
+		Name:    "create_backtest_predictions",
+		Up: `CREATE TABLE IF NOT EXISTS backtest_predictions (
+			backtest_id BIGINT UNSIGNED NOT NULL,
+			fixture_id BIGINT UNSIGNED NOT NULL,
+			kickoff_at DATETIME NOT NULL,
+			matches_used INT UNSIGNED NOT NULL,
+			home_win DOUBLE NOT NULL,
+			draw DOUBLE NOT NULL,
+			away_win DOUBLE NOT NULL,
+			result VARCHAR(4) NOT NULL,
+			bet VARCHAR(4) NOT NULL DEFAULT '',
+			price DECIMAL(8,3) NOT NULL DEFAULT 0,
+			profit DOUBLE NOT NULL DEFAULT 0,
+			PRIMARY KEY (backtest_id, fixture_id),
+			CONSTRAINT backtest_predictions_backtest_fk FOREIGN KEY (backtest_id) REFERENCES backtests (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 18,
+		Name:    "create_models",
+		Up: `CREATE TABLE IF NOT EXISTS models (
+			id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			name VARCHAR(50) NOT NULL,
+			version INT UNSIGNED NOT NULL,
+			hyperparameters TEXT NOT NULL,
+			description VARCHAR(255) NOT NULL DEFAULT '',
+			champion TINYINT(1) NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL,
+			UNIQUE KEY models_version_unique (name, version))`,
+	},
+	{
+		Version: 19,
+		Name:    "create_model_fits",
+		Up: `CREATE TABLE IF NOT EXISTS model_fits (
+			model_id BIGINT UNSIGNED NOT NULL,
+			league_id BIGINT UNSIGNED NOT NULL,
+			season INT UNSIGNED NOT NULL,
+			fitted_before DATETIME NOT NULL,
+			matches INT UNSIGNED NOT NULL,
+			parameters MEDIUMTEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			PRIMARY KEY (model_id, league_id, season, fitted_before),
+			CONSTRAINT model_fits_model_fk FOREIGN KEY (model_id) REFERENCES models (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 20,
+		Name:    "seed_poisson_champion",
+		Up: `INSERT IGNORE INTO models (name, version, hyperparameters, description, champion, created_at)
+			VALUES ('poisson', 1, '{}', 'Independent Poisson goals from the team strengths', 1, UTC_TIMESTAMP())`,
+	},
+	{
+		Version: 21,
+		Name:    "add_fixtures_winner",
+		Up:      `ALTER TABLE fixtures ADD COLUMN winner_team_id BIGINT UNSIGNED NULL`,
+	},
+	{
+		Version: 22,
+		Name:    "create_rounds",
+		Up: `CREATE TABLE IF NOT EXISTS rounds (
+			league_id BIGINT UNSIGNED NOT NULL,
+			season INT UNSIGNED NOT NULL,
+			name VARCHAR(100) NOT NULL,
+			position INT UNSIGNED NOT NULL,
+			stage VARCHAR(20) NOT NULL,
+			legs TINYINT UNSIGNED NOT NULL DEFAULT 1,
+			PRIMARY KEY (league_id, season, name))`,
+	},
+	{
+		Version: 23,
+		Name:    "create_players",
+		Up: `CREATE TABLE IF NOT EXISTS players (
+			id BIGINT UNSIGNED NOT NULL PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			firstname VARCHAR(100) NOT NULL DEFAULT '',
+			lastname VARCHAR(100) NOT NULL DEFAULT '',
+			age INT UNSIGNED NULL,
+			birth_date DATE NULL,
+			nationality VARCHAR(100) NOT NULL DEFAULT '',
+			height VARCHAR(20) NULL,
+			weight VARCHAR(20) NULL,
+			photo VARCHAR(255) NOT NULL DEFAULT '',
+			KEY players_name_index (name))`,
+	},
+	{
+		Version: 24,
+		Name:    "create_squads",
+		Up: `CREATE TABLE IF NOT EXISTS squads (
+			player_id BIGINT UNSIGNED NOT NULL PRIMARY KEY,
+			team_id BIGINT UNSIGNED NOT NULL,
+			team_name VARCHAR(100) NOT NULL,
+			number INT UNSIGNED NULL,
+			position VARCHAR(20) NOT NULL,
+			synced_at DATETIME NOT NULL,
+			KEY squads_team_index (team_id),
+			CONSTRAINT squads_player_fk FOREIGN KEY (player_id) REFERENCES players (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 25,
+		Name:    "create_player_statistics",
+		Up: `CREATE TABLE IF NOT EXISTS player_statistics (
+			player_id BIGINT UNSIGNED NOT NULL,
+			team_id BIGINT UNSIGNED NOT NULL,
+			team_name VARCHAR(100) NOT NULL,
+			league_id BIGINT UNSIGNED NOT NULL,
+			season INT UNSIGNED NOT NULL,
+			position VARCHAR(20) NOT NULL DEFAULT '',
+			appearances INT UNSIGNED NOT NULL DEFAULT 0,
+			lineups INT UNSIGNED NOT NULL DEFAULT 0,
+			minutes INT UNSIGNED NOT NULL DEFAULT 0,
+			goals INT UNSIGNED NOT NULL DEFAULT 0,
+			assists INT UNSIGNED NOT NULL DEFAULT 0,
+			yellow_cards INT UNSIGNED NOT NULL DEFAULT 0,
+			red_cards INT UNSIGNED NOT NULL DEFAULT 0,
+			rating DECIMAL(5,3) NULL,
+			PRIMARY KEY (player_id, team_id, league_id, season),
+			KEY player_statistics_league_season_index (league_id, season),
+			CONSTRAINT player_statistics_player_fk FOREIGN KEY (player_id) REFERENCES players (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 26,
+		Name:    "create_fixture_events",
+		Up: `CREATE TABLE IF NOT EXISTS fixture_events (
+			fixture_id BIGINT UNSIGNED NOT NULL,
+			sequence INT UNSIGNED NOT NULL,
+			elapsed INT UNSIGNED NOT NULL,
+			extra INT UNSIGNED NULL,
+			team_id BIGINT UNSIGNED NOT NULL,
+			team_name VARCHAR(100) NOT NULL,
+			player_id BIGINT UNSIGNED NULL,
+			player_name VARCHAR(100) NULL,
+			assist_id BIGINT UNSIGNED NULL,
+			assist_name VARCHAR(100) NULL,
+			type VARCHAR(20) NOT NULL,
+			detail VARCHAR(50) NOT NULL,
+			comments VARCHAR(255) NULL,
+			PRIMARY KEY (fixture_id, sequence),
+			CONSTRAINT fixture_events_fixture_fk FOREIGN KEY (fixture_id) REFERENCES fixtures (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 27,
+		Name:    "create_fixture_lineups",
+		Up: `CREATE TABLE IF NOT EXISTS fixture_lineups (
+			fixture_id BIGINT UNSIGNED NOT NULL,
+			team_id BIGINT UNSIGNED NOT NULL,
+			team_name VARCHAR(100) NOT NULL,
+			formation VARCHAR(20) NOT NULL DEFAULT '',
+			coach_id BIGINT UNSIGNED NULL,
+			coach_name VARCHAR(100) NULL,
+			PRIMARY KEY (fixture_id, team_id),
+			CONSTRAINT fixture_lineups_fixture_fk FOREIGN KEY (fixture_id) REFERENCES fixtures (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 28,
+		Name:    "create_fixture_lineup_players",
+		Up: `CREATE TABLE IF NOT EXISTS fixture_lineup_players (
+			fixture_id BIGINT UNSIGNED NOT NULL,
+			team_id BIGINT UNSIGNED NOT NULL,
+			sequence INT UNSIGNED NOT NULL,
+			player_id BIGINT UNSIGNED NULL,
+			player_name VARCHAR(100) NOT NULL,
+			number INT UNSIGNED NULL,
+			position VARCHAR(5) NULL,
+			grid VARCHAR(10) NULL,
+			starter TINYINT(1) NOT NULL,
+			PRIMARY KEY (fixture_id, team_id, sequence),
+			KEY fixture_lineup_players_player_index (player_id),
+			CONSTRAINT fixture_lineup_players_lineup_fk FOREIGN KEY (fixture_id, team_id) REFERENCES fixture_lineups (fixture_id, team_id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 29,
+		Name:    "create_fixture_statistics",
+		Up: `CREATE TABLE IF NOT EXISTS fixture_statistics (
+			fixture_id BIGINT UNSIGNED NOT NULL,
+			team_id BIGINT UNSIGNED NOT NULL,
+			team_name VARCHAR(100) NOT NULL,
+			shots INT UNSIGNED NOT NULL DEFAULT 0,
+			shots_on_target INT UNSIGNED NOT NULL DEFAULT 0,
+			shots_off_target INT UNSIGNED NOT NULL DEFAULT 0,
+			blocked_shots INT UNSIGNED NOT NULL DEFAULT 0,
+			shots_inside_box INT UNSIGNED NOT NULL DEFAULT 0,
+			shots_outside_box INT UNSIGNED NOT NULL DEFAULT 0,
+			fouls INT UNSIGNED NOT NULL DEFAULT 0,
+			corners INT UNSIGNED NOT NULL DEFAULT 0,
+			offsides INT UNSIGNED NOT NULL DEFAULT 0,
+			possession INT UNSIGNED NULL,
+			yellow_cards INT UNSIGNED NOT NULL DEFAULT 0,
+			red_cards INT UNSIGNED NOT NULL DEFAULT 0,
+			saves INT UNSIGNED NOT NULL DEFAULT 0,
+			passes INT UNSIGNED NOT NULL DEFAULT 0,
+			passes_accurate INT UNSIGNED NOT NULL DEFAULT 0,
+			expected_goals DECIMAL(5,2) NULL,
+			PRIMARY KEY (fixture_id, team_id),
+			CONSTRAINT fixture_statistics_fixture_fk FOREIGN KEY (fixture_id) REFERENCES fixtures (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 30,
+		Name:    "create_injuries",
+		Up: `CREATE TABLE IF NOT EXISTS injuries (
+			fixture_id BIGINT UNSIGNED NOT NULL,
+			player_id BIGINT UNSIGNED NOT NULL,
+			player_name VARCHAR(100) NOT NULL,
+			team_id BIGINT UNSIGNED NOT NULL,
+			team_name VARCHAR(100) NOT NULL,
+			type VARCHAR(20) NOT NULL,
+			reason VARCHAR(100) NOT NULL DEFAULT '',
+			expected_return DATE NULL,
+			PRIMARY KEY (fixture_id, player_id),
+			KEY injuries_team_index (team_id),
+			CONSTRAINT injuries_fixture_fk FOREIGN KEY (fixture_id) REFERENCES fixtures (id) ON DELETE CASCADE)`,
+	},
+	{
+		Version: 31,
+		Name:    "add_fixtures_scored_at",
+		Up:      `ALTER TABLE fixtures ADD COLUMN scored_at DATETIME NULL`,
+	},
+	{
+		Version: 32,
+		Name:    "key_squads_by_team_and_player",
+		Up: `ALTER TABLE squads
+			DROP PRIMARY KEY,
+			ADD PRIMARY KEY (team_id, player_id),
+			ADD KEY squads_player_index (player_id)`,
+	},
+	{
+		Version: 33,
+		Name:    "add_fixtures_details_synced_at",
+		Up:      `ALTER TABLE fixtures ADD COLUMN details_synced_at DATETIME NULL`,
+	},
+}
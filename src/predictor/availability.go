@@ -0,0 +1,89 @@
+package predictor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Availability is the part of the usual eleven of each team missing a fixture: the sum of the minutes shares of the
+// missing players over 11, so missing a player who played every minute is 1/11
+type Availability struct {
+	HomeMissing float64
+	AwayMissing float64
+}
+
+// Factors returns the factors of the expected goals of the home and away team. A team missing m of its eleven
+// scores 1 - weight*m times its goals and its opponent 1 + weight*m times theirs
+func (a Availability) Factors(weight float64) (float64, float64) {
+	home, away := math.Min(a.HomeMissing, 1), math.Min(a.AwayMissing, 1)
+	return (1 - weight*home) * (1 + weight*away), (1 - weight*away) * (1 + weight*home)
+}
+
+// Scale returns the forecast with the expected goals of the home and away team multiplied by the factors. The
+// probability of each score is tilted by homeFactor^h * awayFactor^a, which gives a Poisson distribution the new
+// mean and keeps the shape of the model, like the low scores correction of Dixon-Coles
+func (f *Forecast) Scale(homeFactor float64, awayFactor float64) *Forecast {
+	res := &Forecast{
+		HomeExpectedGoals: f.HomeExpectedGoals * homeFactor,
+		AwayExpectedGoals: f.AwayExpectedGoals * awayFactor,
+		Matrix:            make([][]float64, len(f.Matrix)),
+	}
+	var total float64
+	for h := range f.Matrix {
+		res.Matrix[h] = make([]float64, len(f.Matrix[h]))
+		for a, p := range f.Matrix[h] {
+			res.Matrix[h][a] = p * math.Pow(homeFactor, float64(h)) * math.Pow(awayFactor, float64(a))
+			total += res.Matrix[h][a]
+		}
+	}
+	if total > 0 {
+		for h := range res.Matrix {
+			for a := range res.Matrix[h] {
+				res.Matrix[h][a] /= total
+			}
+		}
+	}
+	return res
+}
+
+// commonHyperparameters are accepted by every model on top of its own. AvailabilityWeight, between 0 and 1, is how
+// much the players missing a fixture lower the goals of their team, 0 leaves the forecasts as they are
+type commonHyperparameters struct {
+	AvailabilityWeight float64 `json:"availability_weight"`
+}
+
+// splitHyperparameters takes the common hyperparameters out of the ones of a model
+func splitHyperparameters(hyperparameters json.RawMessage) (json.RawMessage, commonHyperparameters, error) {
+	var common commonHyperparameters
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(hyperparameters, &fields); err != nil || fields == nil {
+		// Left to the decoding of the model
+		return hyperparameters, common, nil
+	}
+	weight, ok := fields["availability_weight"]
+	if !ok {
+		return hyperparameters, common, nil
+	}
+	if err := json.Unmarshal(weight, &common.AvailabilityWeight); err != nil {
+		return nil, common, fmt.Errorf("%w: %v", ErrInvalidHyperparameters, err)
+	}
+	if common.AvailabilityWeight < 0 || common.AvailabilityWeight > 1 {
+		return nil, common, fmt.Errorf("%w: availability_weight must be between 0 and 1", ErrInvalidHyperparameters)
+	}
+	delete(fields, "availability_weight")
+	rest, err := json.Marshal(fields)
+	if err != nil {
+		return nil, common, err
+	}
+	return rest, common, nil
+}
+
+// AvailabilityWeight returns the availability_weight hyperparameter of a model, 0 when it is not set
+func AvailabilityWeight(hyperparameters json.RawMessage) float64 {
+	_, common, err := splitHyperparameters(hyperparameters)
+	if err != nil {
+		return 0
+	}
+	return common.AvailabilityWeight
+}
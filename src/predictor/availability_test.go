@@ -0,0 +1,50 @@
+package predictor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvailability_Factors(t *testing.T) {
+	// The home team misses two players who played every minute
+	home, away := Availability{HomeMissing: 2.0 / 11}.Factors(0.5)
+	assert.InDelta(t, 1-1.0/11, home, 1e-9)
+	assert.InDelta(t, 1+1.0/11, away, 1e-9)
+
+	home, away = Availability{HomeMissing: 0.3, AwayMissing: 0.3}.Factors(0)
+	assert.Equal(t, 1.0, home)
+	assert.Equal(t, 1.0, away)
+}
+
+func TestForecast_Scale(t *testing.T) {
+	// Tilting a Poisson forecast gives the Poisson forecast of the scaled means
+	res := NewForecast(1.5, 1.1).Scale(1.2, 0.8)
+	expected := NewForecast(1.8, 0.88)
+
+	assert.InDelta(t, 1.8, res.HomeExpectedGoals, 1e-9)
+	assert.InDelta(t, 0.88, res.AwayExpectedGoals, 1e-9)
+	for h := range expected.Matrix {
+		for a := range expected.Matrix[h] {
+			assert.InDelta(t, expected.Matrix[h][a], res.Matrix[h][a], 1e-6)
+		}
+	}
+	homeWin, _, awayWin := res.Outcome()
+	baseHomeWin, _, baseAwayWin := NewForecast(1.5, 1.1).Outcome()
+	assert.True(t, homeWin > baseHomeWin)
+	assert.True(t, awayWin < baseAwayWin)
+}
+
+func TestAvailabilityWeight(t *testing.T) {
+	assert.Equal(t, 0.0, AvailabilityWeight(nil))
+	assert.Equal(t, 0.0, AvailabilityWeight(json.RawMessage(`{"xi":0.005}`)))
+	assert.Equal(t, 0.5, AvailabilityWeight(json.RawMessage(`{"xi":0.005,"availability_weight":0.5}`)))
+	assert.Equal(t, 0.0, AvailabilityWeight(json.RawMessage(`{"availability_weight":2}`)))
+
+	// The weight is not a hyperparameter of the model itself
+	model, err := Fit(ModelDixonColes, json.RawMessage(`{"xi":0.005,"availability_weight":0.5}`), testMatches)
+	assert.Nil(t, err)
+	expected, _ := Fit(ModelDixonColes, json.RawMessage(`{"xi":0.005}`), testMatches)
+	assert.Equal(t, expected, model)
+}
@@ -0,0 +1,344 @@
+package predictor
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// extraTimeShare is the share of the goals of a match expected in the 30 minutes of extra time
+const extraTimeShare = 1.0 / 3
+
+// Cup is a cup competition: an optional group stage whose first Qualifiers teams of every group go through,
+// followed by knockout rounds. The groups are the sets of teams playing each other in the group fixtures
+type Cup struct {
+	GroupPlayed    []Match
+	GroupRemaining []Match
+	Qualifiers     int
+	Rounds         []KnockoutRound
+}
+
+// KnockoutRound is a knockout round played over Legs legs, Ties holds the ties already drawn
+type KnockoutRound struct {
+	Legs int
+	Ties []Tie
+}
+
+// Tie is a drawn tie, HomeTeamID plays the first leg at home. Played holds the legs already played, goals of
+// the extra time included, and WinnerTeamID is set once the tie is decided
+type Tie struct {
+	HomeTeamID   int64
+	AwayTeamID   int64
+	Played       []Match
+	WinnerTeamID int64
+}
+
+// CupSimulation is the outcome of the simulated cups, one entry per team of the cup
+type CupSimulation struct {
+	Runs  int
+	Teams []TeamProgress
+}
+
+// TeamProgress holds the probabilities of a team playing each knockout round and of winning the last one, the final
+type TeamProgress struct {
+	TeamID int64
+	Rounds []float64
+	Winner float64
+}
+
+// cupTie is a drawn tie, the goals are the aggregate of its played legs and winner is -1 until it is decided
+type cupTie struct {
+	home, away           int
+	homeGoals, awayGoals int64
+	played               int
+	winner               int
+}
+
+type cupRound struct {
+	legs  int
+	ties  []cupTie
+	drawn []bool
+}
+
+type cupSimulator struct {
+	model      Model
+	teams      []int64
+	groups     [][]int
+	current    *standing
+	fixtures   []simulatedFixture
+	qualifiers int
+	rounds     []cupRound
+}
+
+// cupBatch counts the rounds played and the cups won by the teams over a batch of runs
+type cupBatch struct {
+	reached [][]int64
+	won     []int64
+}
+
+// SimulateCup plays the remaining group fixtures and knockout ties of a cup runs times, drawing every score from
+// the forecast of the model, and returns the probabilities of the teams reaching each round. The teams going
+// through that are not in a drawn tie are drawn at random, an odd one out going through to the next round. A
+// tie level on aggregate after its last leg goes to extra time, then to a penalty shootout either team wins
+// with the same probability. The away goals rule is not applied. The same seed gives the same result
+func SimulateCup(model Model, cup Cup, runs int, seed int64) *CupSimulation {
+	c := newCupSimulator(model, cup)
+	results := make([]cupBatch, batchCount(runs))
+	runBatches(runs, seed, func(b int, size int, seed int64) {
+		results[b] = c.simulateBatch(size, seed)
+	})
+
+	simulation := &CupSimulation{Runs: runs, Teams: make([]TeamProgress, len(c.teams))}
+	for i, id := range c.teams {
+		team := TeamProgress{TeamID: id, Rounds: make([]float64, len(c.rounds))}
+		if runs > 0 {
+			for _, result := range results {
+				for r, count := range result.reached[i] {
+					team.Rounds[r] += float64(count)
+				}
+				team.Winner += float64(result.won[i])
+			}
+			for r := range team.Rounds {
+				team.Rounds[r] /= float64(runs)
+			}
+			team.Winner /= float64(runs)
+		}
+		simulation.Teams[i] = team
+	}
+	return simulation
+}
+
+func newCupSimulator(model Model, cup Cup) *cupSimulator {
+	index := make(map[int64]int)
+	var teams []int64
+	addTeam := func(id int64) {
+		if _, ok := index[id]; !ok {
+			index[id] = 0
+			teams = append(teams, id)
+		}
+	}
+	for _, matches := range [][]Match{cup.GroupPlayed, cup.GroupRemaining} {
+		for _, m := range matches {
+			addTeam(m.HomeTeamID)
+			addTeam(m.AwayTeamID)
+		}
+	}
+	for _, round := range cup.Rounds {
+		for _, tie := range round.Ties {
+			addTeam(tie.HomeTeamID)
+			addTeam(tie.AwayTeamID)
+		}
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i] < teams[j] })
+	for i, id := range teams {
+		index[id] = i
+	}
+
+	c := &cupSimulator{model: model, teams: teams, current: newStanding(len(teams)), qualifiers: cup.Qualifiers}
+	// The groups are the connected teams of the group fixtures
+	parent := make([]int, len(teams))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	inGroup := make([]bool, len(teams))
+	for _, matches := range [][]Match{cup.GroupPlayed, cup.GroupRemaining} {
+		for _, m := range matches {
+			home, away := index[m.HomeTeamID], index[m.AwayTeamID]
+			inGroup[home], inGroup[away] = true, true
+			parent[find(home)] = find(away)
+		}
+	}
+	groups := make(map[int]int)
+	for i := range teams {
+		if !inGroup[i] {
+			continue
+		}
+		g, ok := groups[find(i)]
+		if !ok {
+			g = len(c.groups)
+			groups[find(i)] = g
+			c.groups = append(c.groups, nil)
+		}
+		c.groups[g] = append(c.groups[g], i)
+	}
+	for _, m := range cup.GroupPlayed {
+		c.current.add(index[m.HomeTeamID], index[m.AwayTeamID], m.HomeGoals, m.AwayGoals)
+	}
+	for _, m := range cup.GroupRemaining {
+		c.fixtures = append(c.fixtures, newSimulatedFixture(model.Forecast(m.HomeTeamID, m.AwayTeamID), index[m.HomeTeamID], index[m.AwayTeamID]))
+	}
+
+	for _, round := range cup.Rounds {
+		r := cupRound{legs: round.Legs, drawn: make([]bool, len(teams))}
+		if r.legs < 1 {
+			r.legs = 1
+		}
+		for _, tie := range round.Ties {
+			t := cupTie{home: index[tie.HomeTeamID], away: index[tie.AwayTeamID], played: len(tie.Played), winner: -1}
+			for _, m := range tie.Played {
+				if m.HomeTeamID == tie.HomeTeamID {
+					t.homeGoals += m.HomeGoals
+					t.awayGoals += m.AwayGoals
+				} else {
+					t.homeGoals += m.AwayGoals
+					t.awayGoals += m.HomeGoals
+				}
+			}
+			if w, ok := index[tie.WinnerTeamID]; ok && tie.WinnerTeamID != 0 {
+				t.winner = w
+			}
+			r.drawn[t.home], r.drawn[t.away] = true, true
+			r.ties = append(r.ties, t)
+		}
+		c.rounds = append(c.rounds, r)
+	}
+	return c
+}
+
+// scoreSamplers caches the samplers of the fixtures of a batch, the extra time ones apart
+type scoreSamplers struct {
+	model   Model
+	teams   []int64
+	regular map[[2]int]*simulatedFixture
+	extra   map[[2]int]*simulatedFixture
+}
+
+func (s *scoreSamplers) sample(random *rand.Rand, home int, away int, extraTime bool) (int64, int64) {
+	cache := s.regular
+	if extraTime {
+		cache = s.extra
+	}
+	key := [2]int{home, away}
+	f, ok := cache[key]
+	if !ok {
+		forecast := s.model.Forecast(s.teams[home], s.teams[away])
+		if extraTime {
+			forecast = NewForecast(forecast.HomeExpectedGoals*extraTimeShare, forecast.AwayExpectedGoals*extraTimeShare)
+		}
+		fixture := newSimulatedFixture(forecast, home, away)
+		f = &fixture
+		cache[key] = f
+	}
+	return f.sample(random)
+}
+
+// play decides a tie over the given legs, the odd legs are played at the ground of the home team
+func (c *cupSimulator) play(random *rand.Rand, samplers *scoreSamplers, legs int, tie cupTie) int {
+	if tie.winner >= 0 {
+		return tie.winner
+	}
+	homeGoals, awayGoals := tie.homeGoals, tie.awayGoals
+	for leg := tie.played; leg < legs; leg++ {
+		if leg%2 == 0 {
+			h, a := samplers.sample(random, tie.home, tie.away, false)
+			homeGoals, awayGoals = homeGoals+h, awayGoals+a
+		} else {
+			h, a := samplers.sample(random, tie.away, tie.home, false)
+			homeGoals, awayGoals = homeGoals+a, awayGoals+h
+		}
+	}
+	// The extra time of a tie whose legs were all played is already in its goals
+	if homeGoals == awayGoals && tie.played < legs {
+		if (legs-1)%2 == 0 {
+			h, a := samplers.sample(random, tie.home, tie.away, true)
+			homeGoals, awayGoals = homeGoals+h, awayGoals+a
+		} else {
+			h, a := samplers.sample(random, tie.away, tie.home, true)
+			homeGoals, awayGoals = homeGoals+a, awayGoals+h
+		}
+	}
+	switch {
+	case homeGoals > awayGoals:
+		return tie.home
+	case homeGoals < awayGoals:
+		return tie.away
+	case random.Intn(2) == 0:
+		return tie.home
+	default:
+		return tie.away
+	}
+}
+
+func (c *cupSimulator) simulateBatch(runs int, seed int64) cupBatch {
+	teams := len(c.teams)
+	random := rand.New(rand.NewSource(seed))
+	samplers := &scoreSamplers{
+		model:   c.model,
+		teams:   c.teams,
+		regular: make(map[[2]int]*simulatedFixture),
+		extra:   make(map[[2]int]*simulatedFixture),
+	}
+	result := cupBatch{reached: make([][]int64, teams), won: make([]int64, teams)}
+	for i := range result.reached {
+		result.reached[i] = make([]int64, len(c.rounds))
+	}
+	table := newStanding(teams)
+	var participants, open, winners []int
+	// seen stamps the teams counted in a round of a run, so teams in a drawn tie are not counted twice
+	seen := make([]int, teams)
+	stamp := 0
+	for run := 0; run < runs; run++ {
+		participants = participants[:0]
+		if len(c.groups) > 0 {
+			c.current.copyTo(table)
+			for i := range c.fixtures {
+				homeGoals, awayGoals := c.fixtures[i].sample(random)
+				table.add(c.fixtures[i].home, c.fixtures[i].away, homeGoals, awayGoals)
+			}
+			for _, group := range c.groups {
+				tiebreak := make([]int, len(group))
+				for i, p := range random.Perm(len(group)) {
+					tiebreak[i] = group[p]
+				}
+				order := table.rank(tiebreak)
+				if len(order) > c.qualifiers {
+					order = order[:c.qualifiers]
+				}
+				participants = append(participants, order...)
+			}
+		}
+
+		for r, round := range c.rounds {
+			stamp++
+			count := func(team int) {
+				if seen[team] != stamp {
+					seen[team] = stamp
+					result.reached[team][r]++
+				}
+			}
+			winners = winners[:0]
+			for _, tie := range round.ties {
+				count(tie.home)
+				count(tie.away)
+				winners = append(winners, c.play(random, samplers, round.legs, tie))
+			}
+			open = open[:0]
+			for _, team := range participants {
+				count(team)
+				if !round.drawn[team] {
+					open = append(open, team)
+				}
+			}
+			random.Shuffle(len(open), func(i, j int) { open[i], open[j] = open[j], open[i] })
+			for i := 0; i+1 < len(open); i += 2 {
+				winners = append(winners, c.play(random, samplers, round.legs, cupTie{home: open[i], away: open[i+1], winner: -1}))
+			}
+			if len(open)%2 == 1 {
+				winners = append(winners, open[len(open)-1])
+			}
+			participants, winners = winners, participants
+		}
+		if len(c.rounds) > 0 {
+			for _, team := range participants {
+				result.won[team]++
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package predictor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testSemiFinals is a cup with two single leg semi-finals drawn and a final to play
+func testSemiFinals() Cup {
+	return Cup{Rounds: []KnockoutRound{
+		{Legs: 1, Ties: []Tie{{HomeTeamID: 1, AwayTeamID: 4}, {HomeTeamID: 2, AwayTeamID: 3}}},
+		{Legs: 1},
+	}}
+}
+
+func TestSimulateCup(t *testing.T) {
+	res := SimulateCup(testRatings, testSemiFinals(), 2000, 42)
+
+	assert.Equal(t, 2000, res.Runs)
+	assert.Len(t, res.Teams, 4)
+	var finalists, winners float64
+	for i, team := range res.Teams {
+		assert.Equal(t, int64(i+1), team.TeamID)
+		assert.Len(t, team.Rounds, 2)
+		assert.Equal(t, 1.0, team.Rounds[0])
+		assert.True(t, team.Winner <= team.Rounds[1])
+		finalists += team.Rounds[1]
+		winners += team.Winner
+	}
+	assert.InDelta(t, 2, finalists, 1e-9)
+	assert.InDelta(t, 1, winners, 1e-9)
+	// Team 1 is by far the strongest and team 4 the weakest
+	assert.True(t, res.Teams[0].Winner > 0.5)
+	assert.True(t, res.Teams[0].Rounds[1] > res.Teams[3].Rounds[1])
+
+	// The same seed gives the same result
+	assert.Equal(t, res, SimulateCup(testRatings, testSemiFinals(), 2000, 42))
+}
+
+func TestSimulateCup_PlayedTies(t *testing.T) {
+	cup := Cup{Rounds: []KnockoutRound{
+		{Legs: 2, Ties: []Tie{
+			// Team 4 won the first semi-final, team 3 won the first leg of the second one 3-0
+			{HomeTeamID: 1, AwayTeamID: 4, WinnerTeamID: 4, Played: []Match{
+				{HomeTeamID: 1, AwayTeamID: 4, HomeGoals: 0, AwayGoals: 1},
+				{HomeTeamID: 4, AwayTeamID: 1, HomeGoals: 1, AwayGoals: 1},
+			}},
+			{HomeTeamID: 3, AwayTeamID: 2, Played: []Match{{HomeTeamID: 3, AwayTeamID: 2, HomeGoals: 3, AwayGoals: 0}}},
+		}},
+		{Legs: 1},
+	}}
+
+	res := SimulateCup(testRatings, cup, 2000, 7)
+
+	assert.Equal(t, 0.0, res.Teams[0].Rounds[1])
+	assert.Equal(t, 1.0, res.Teams[3].Rounds[1])
+	assert.True(t, res.Teams[2].Rounds[1] > 0.8)
+	assert.InDelta(t, 1, res.Teams[1].Rounds[1]+res.Teams[2].Rounds[1], 1e-9)
+}
+
+func TestSimulateCup_Shootout(t *testing.T) {
+	// The tie is level after its legs and extra time, only the shootout is left and either team wins it
+	cup := Cup{Rounds: []KnockoutRound{{Legs: 1, Ties: []Tie{
+		{HomeTeamID: 1, AwayTeamID: 4, Played: []Match{{HomeTeamID: 1, AwayTeamID: 4, HomeGoals: 2, AwayGoals: 2}}},
+	}}}}
+
+	res := SimulateCup(testRatings, cup, 4000, 3)
+
+	assert.InDelta(t, 0.5, res.Teams[0].Winner, 0.05)
+	assert.InDelta(t, 1, res.Teams[0].Winner+res.Teams[1].Winner, 1e-9)
+}
+
+func TestSimulateCup_GroupStage(t *testing.T) {
+	// Two groups of four, the first two go through to the semi-finals which are not drawn yet
+	cup := Cup{
+		GroupPlayed:    []Match{{HomeTeamID: 1, AwayTeamID: 2, HomeGoals: 3, AwayGoals: 0}},
+		GroupRemaining: append(roundRobin(5, 6, 7, 8), roundRobin(1, 2, 3, 4)[1:]...),
+		Qualifiers:     2,
+		Rounds:         []KnockoutRound{{Legs: 2}, {Legs: 1}},
+	}
+
+	res := SimulateCup(testRatings, cup, 2000, 11)
+
+	assert.Len(t, res.Teams, 8)
+	var first, second, semiFinalists, finalists, winners float64
+	for i, team := range res.Teams {
+		if i < 4 {
+			first += team.Rounds[0]
+		} else {
+			second += team.Rounds[0]
+		}
+		semiFinalists += team.Rounds[0]
+		finalists += team.Rounds[1]
+		winners += team.Winner
+	}
+	assert.InDelta(t, 2, first, 1e-9)
+	assert.InDelta(t, 2, second, 1e-9)
+	assert.InDelta(t, 4, semiFinalists, 1e-9)
+	assert.InDelta(t, 2, finalists, 1e-9)
+	assert.InDelta(t, 1, winners, 1e-9)
+	assert.True(t, res.Teams[0].Rounds[0] > 0.9)
+}
+
+func TestSimulateCup_Bye(t *testing.T) {
+	// Three teams go through, one of them to the final without playing
+	cup := Cup{
+		GroupRemaining: roundRobin(1, 2, 3),
+		Qualifiers:     3,
+		Rounds:         []KnockoutRound{{Legs: 1}, {Legs: 1}},
+	}
+
+	res := SimulateCup(testRatings, cup, 1000, 5)
+
+	var finalists float64
+	for _, team := range res.Teams {
+		assert.Equal(t, 1.0, team.Rounds[0])
+		finalists += team.Rounds[1]
+	}
+	assert.InDelta(t, 2, finalists, 1e-9)
+}
@@ -0,0 +1,354 @@
+package predictor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+const (
+	// defaultXi is the time decay per day, a match played a year ago weighs half as much as one played today
+	defaultXi = 0.0019
+	// The fit stops when the gradient scaled by the information is below dixonColesTolerance
+	dixonColesIterations = 100
+	dixonColesTolerance  = 1e-12
+)
+
+// DixonColes models the goals as Poisson variables whose means are exp(attack of the team + defence of the
+// opponent), plus the home advantage for the home team, with the correction of Dixon and Coles for the low scores,
+// which are not independent: a negative rho makes 0-0 and 1-1 more likely and 1-0 and 0-1 less likely. The
+// parameters are fitted by maximum likelihood with the older matches weighted down, the attacks sum to 0
+type DixonColes struct {
+	HomeAdvantage float64                     `json:"home_advantage"`
+	Rho           float64                     `json:"rho"`
+	Teams         map[int64]*DixonColesRating `json:"teams"`
+}
+
+// DixonColesRating is the attack and defence of a team on the log scale of the expected goals, a higher defence
+// concedes more goals
+type DixonColesRating struct {
+	Attack  float64 `json:"attack"`
+	Defence float64 `json:"defence"`
+}
+
+type dixonColesHyperparameters struct {
+	Xi  *float64 `json:"xi"`
+	Rho *float64 `json:"rho"`
+}
+
+// fitDixonColes also fits the versions registered before rho was fitted, whose hyperparameters are a fixed rho: they
+// keep forecasting as poisson-rho
+func fitDixonColes(matches []Match, hyperparameters json.RawMessage) (Model, error) {
+	var params dixonColesHyperparameters
+	if err := decode(hyperparameters, &params); err != nil {
+		return nil, err
+	}
+	if params.Rho != nil {
+		if params.Xi != nil {
+			return nil, fmt.Errorf("%w: rho is fitted, use poisson-rho for a fixed rho", ErrInvalidHyperparameters)
+		}
+		return fitPoissonRho(matches, hyperparameters)
+	}
+	xi := defaultXi
+	if params.Xi != nil {
+		xi = *params.Xi
+	}
+	if xi < 0 || xi > 1 {
+		return nil, fmt.Errorf("%w: xi must be between 0 and 1", ErrInvalidHyperparameters)
+	}
+	if len(matches) == 0 {
+		return nil, ErrNotEnoughData
+	}
+	model, err := newDixonColesFit(matches, xi).run()
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// loadDixonColes reads the fitted parameters, the fits of the versions with a fixed rho hold a poisson model and are
+// loaded as poisson-rho, see fitDixonColes
+func loadDixonColes(parameters json.RawMessage) (Model, error) {
+	var legacy struct {
+		Poisson json.RawMessage `json:"poisson"`
+	}
+	if err := json.Unmarshal(parameters, &legacy); err == nil && legacy.Poisson != nil {
+		model := &PoissonRho{}
+		return model, loadParameters(parameters, model)
+	}
+	model := &DixonColes{}
+	return model, loadParameters(parameters, model)
+}
+
+func (d *DixonColes) UnmarshalJSON(data []byte) error {
+	type parameters DixonColes
+	var params parameters
+	if err := json.Unmarshal(data, &params); err != nil {
+		return err
+	}
+	if len(params.Teams) == 0 {
+		return ErrInvalidParameters
+	}
+	*d = DixonColes(params)
+	return nil
+}
+
+// rating returns the rating of a team, the teams without matches have an average attack and defence
+func (d *DixonColes) rating(teamID int64) DixonColesRating {
+	if r, ok := d.Teams[teamID]; ok {
+		return *r
+	}
+	var defence float64
+	for _, r := range d.Teams {
+		defence += r.Defence
+	}
+	return DixonColesRating{Defence: defence / float64(len(d.Teams))}
+}
+
+// ExpectedGoals returns the expected goals of the home and away team
+func (d *DixonColes) ExpectedGoals(homeTeamID int64, awayTeamID int64) (float64, float64) {
+	home, away := d.rating(homeTeamID), d.rating(awayTeamID)
+	return math.Exp(home.Attack + away.Defence + d.HomeAdvantage), math.Exp(away.Attack + home.Defence)
+}
+
+// Forecast returns the Poisson score matrix with the low scores corrected by tau
+func (d *DixonColes) Forecast(homeTeamID int64, awayTeamID int64) *Forecast {
+	forecast := NewForecast(d.ExpectedGoals(homeTeamID, awayTeamID))
+	for h := 0; h <= 1; h++ {
+		for a := 0; a <= 1; a++ {
+			forecast.Matrix[h][a] *= tau(h, a, forecast.HomeExpectedGoals, forecast.AwayExpectedGoals, d.Rho)
+		}
+	}
+	return forecast
+}
+
+// tau is the Dixon-Coles adjustment of the probability of a score, it is 1 above one goal per team and never
+// negative
+func tau(homeGoals int, awayGoals int, lambda float64, mu float64, rho float64) float64 {
+	return math.Max(rawTau(homeGoals, awayGoals, lambda, mu, rho), 0)
+}
+
+func rawTau(homeGoals int, awayGoals int, lambda float64, mu float64, rho float64) float64 {
+	switch {
+	case homeGoals == 0 && awayGoals == 0:
+		return 1 - lambda*mu*rho
+	case homeGoals == 0 && awayGoals == 1:
+		return 1 + lambda*rho
+	case homeGoals == 1 && awayGoals == 0:
+		return 1 + mu*rho
+	case homeGoals == 1 && awayGoals == 1:
+		return 1 - rho
+	}
+	return 1
+}
+
+// dixonColesFit maximises the weighted log-likelihood over the vector of the attacks, the defences, the home
+// advantage and rho, in this order
+type dixonColesFit struct {
+	matches []Match
+	weights []float64
+	teams   []int64
+	index   map[int64]int
+}
+
+func newDixonColesFit(matches []Match, xi float64) *dixonColesFit {
+	f := &dixonColesFit{matches: matches, weights: make([]float64, len(matches)), index: make(map[int64]int)}
+	latest := matches[0].PlayedAt
+	for _, m := range matches {
+		if m.PlayedAt.After(latest) {
+			latest = m.PlayedAt
+		}
+		for _, id := range []int64{m.HomeTeamID, m.AwayTeamID} {
+			if _, ok := f.index[id]; !ok {
+				f.index[id] = len(f.teams)
+				f.teams = append(f.teams, id)
+			}
+		}
+	}
+	for i, m := range matches {
+		f.weights[i] = math.Exp(-xi * latest.Sub(m.PlayedAt).Hours() / 24)
+	}
+	return f
+}
+
+func (f *dixonColesFit) home() int { return 2 * len(f.teams) }
+func (f *dixonColesFit) rho() int  { return 2*len(f.teams) + 1 }
+
+// means returns the expected goals of a match for the parameters x
+func (f *dixonColesFit) means(x []float64, m Match) (float64, float64) {
+	h, a := f.index[m.HomeTeamID], f.index[m.AwayTeamID]
+	n := len(f.teams)
+	return math.Exp(x[h] + x[n+a] + x[f.home()]), math.Exp(x[a] + x[n+h])
+}
+
+// logLikelihood leaves out the terms that do not depend on the parameters, it is -Inf when rho makes the
+// probability of a low score of any match negative
+func (f *dixonColesFit) logLikelihood(x []float64) float64 {
+	var ll float64
+	rho := x[f.rho()]
+	for i, m := range f.matches {
+		lambda, mu := f.means(x, m)
+		if rho <= math.Max(-1/lambda, -1/mu) || rho >= math.Min(1/(lambda*mu), 1) {
+			return math.Inf(-1)
+		}
+		t := rawTau(int(m.HomeGoals), int(m.AwayGoals), lambda, mu, rho)
+		ll += f.weights[i] * (math.Log(t) + float64(m.HomeGoals)*math.Log(lambda) - lambda + float64(m.AwayGoals)*math.Log(mu) - mu)
+	}
+	return ll
+}
+
+// gradient returns the gradient of the log-likelihood and the expected information of the Poisson goals, with
+// the information of rho alone, which turns the gradient into the steps of the parameters
+func (f *dixonColesFit) gradient(x []float64) ([]float64, [][]float64) {
+	grad, info := make([]float64, len(x)), make([][]float64, len(x))
+	for i := range info {
+		info[i] = make([]float64, len(x))
+	}
+	n := len(f.teams)
+	rho := x[f.rho()]
+	for i, m := range f.matches {
+		w := f.weights[i]
+		h, a := f.index[m.HomeTeamID], f.index[m.AwayTeamID]
+		lambda, mu := f.means(x, m)
+		t := rawTau(int(m.HomeGoals), int(m.AwayGoals), lambda, mu, rho)
+		// The derivatives of log tau by log lambda, log mu and rho
+		var dLambda, dMu, dRho float64
+		switch {
+		case m.HomeGoals == 0 && m.AwayGoals == 0:
+			dLambda, dMu, dRho = -lambda*mu*rho/t, -lambda*mu*rho/t, -lambda*mu/t
+		case m.HomeGoals == 0 && m.AwayGoals == 1:
+			dLambda, dRho = lambda*rho/t, lambda/t
+		case m.HomeGoals == 1 && m.AwayGoals == 0:
+			dMu, dRho = mu*rho/t, mu/t
+		case m.HomeGoals == 1 && m.AwayGoals == 1:
+			dRho = -1 / t
+		}
+		gLambda := w * (float64(m.HomeGoals) - lambda + dLambda)
+		gMu := w * (float64(m.AwayGoals) - mu + dMu)
+		grad[h] += gLambda
+		grad[n+a] += gLambda
+		grad[f.home()] += gLambda
+		grad[a] += gMu
+		grad[n+h] += gMu
+		grad[f.rho()] += w * dRho
+
+		for _, i := range []int{h, n + a, f.home()} {
+			for _, j := range []int{h, n + a, f.home()} {
+				info[i][j] += w * lambda
+			}
+		}
+		for _, i := range []int{a, n + h} {
+			for _, j := range []int{a, n + h} {
+				info[i][j] += w * mu
+			}
+		}
+		info[f.rho()][f.rho()] += w * dRho * dRho
+	}
+	return grad, info
+}
+
+// center moves the mean attack to the defences, which leaves the expected goals unchanged
+func (f *dixonColesFit) center(x []float64) {
+	n := len(f.teams)
+	var mean float64
+	for i := 0; i < n; i++ {
+		mean += x[i]
+	}
+	mean /= float64(n)
+	for i := 0; i < n; i++ {
+		x[i] -= mean
+		x[n+i] += mean
+	}
+}
+
+// run climbs the log-likelihood from the Poisson averages by Fisher scoring, with the steps halved until they
+// increase it enough, up to the maximum or dixonColesIterations steps
+func (f *dixonColesFit) run() (*DixonColes, error) {
+	n := len(f.teams)
+	var homeGoals, awayGoals, total float64
+	for i, m := range f.matches {
+		homeGoals += f.weights[i] * float64(m.HomeGoals)
+		awayGoals += f.weights[i] * float64(m.AwayGoals)
+		total += f.weights[i]
+	}
+	if homeGoals == 0 || awayGoals == 0 {
+		return nil, ErrNotEnoughData
+	}
+	x := make([]float64, 2*n+2)
+	for i := 0; i < n; i++ {
+		x[n+i] = math.Log(awayGoals / total)
+	}
+	x[f.home()] = math.Log(homeGoals / awayGoals)
+
+	ll := f.logLikelihood(x)
+	candidate := make([]float64, len(x))
+	for iteration := 0; iteration < dixonColesIterations; iteration++ {
+		grad, info := f.gradient(x)
+		// The information is singular along the moves of the attacks that center undoes
+		for i := range info {
+			info[i][i] += 1e-6
+		}
+		direction := solve(info, grad)
+		var slope float64
+		for i := range x {
+			slope += grad[i] * direction[i]
+		}
+		if slope < dixonColesTolerance {
+			break
+		}
+		step, next := 1.0, math.Inf(-1)
+		for ; step > 1e-10; step /= 2 {
+			for i := range x {
+				candidate[i] = x[i] + step*direction[i]
+			}
+			f.center(candidate)
+			if next = f.logLikelihood(candidate); next >= ll+1e-4*step*slope {
+				break
+			}
+		}
+		if step <= 1e-10 {
+			break
+		}
+		copy(x, candidate)
+		ll = next
+	}
+
+	model := &DixonColes{HomeAdvantage: x[f.home()], Rho: x[f.rho()], Teams: make(map[int64]*DixonColesRating, n)}
+	for i, id := range f.teams {
+		model.Teams[id] = &DixonColesRating{Attack: x[i], Defence: x[n+i]}
+	}
+	return model, nil
+}
+
+// solve returns the solution of the linear system a x = b by Gaussian elimination with partial pivoting, a is
+// overwritten
+func solve(a [][]float64, b []float64) []float64 {
+	n := len(b)
+	b = append([]float64(nil), b...)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x
+}
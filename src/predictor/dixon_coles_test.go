@@ -0,0 +1,172 @@
+package predictor
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSeasonStart = time.Date(2021, 8, 1, 15, 0, 0, 0, time.UTC)
+
+// simulateLeague plays rounds of home and away matches between the teams, whose attacks and defences grow with
+// their id, with a home advantage of 0.3 and a rho of -0.1, a match a day
+func simulateLeague(seed int64, rounds int) []Match {
+	const teams = 10
+	random := rand.New(rand.NewSource(seed))
+	truth := &DixonColes{HomeAdvantage: 0.3, Rho: -0.1, Teams: make(map[int64]*DixonColesRating)}
+	for id := int64(1); id <= teams; id++ {
+		truth.Teams[id] = &DixonColesRating{Attack: 0.1 * float64(id-5), Defence: 0.05*float64(id-5) - 0.1}
+	}
+	var matches []Match
+	day := 0
+	for round := 0; round < rounds; round++ {
+		for home := int64(1); home <= teams; home++ {
+			for away := int64(1); away <= teams; away++ {
+				if home == away {
+					continue
+				}
+				homeGoals, awayGoals := sample(random, truth.Forecast(home, away))
+				matches = append(matches, Match{HomeTeamID: home, AwayTeamID: away, HomeGoals: homeGoals,
+					AwayGoals: awayGoals, PlayedAt: testSeasonStart.AddDate(0, 0, day)})
+				day++
+			}
+		}
+	}
+	return matches
+}
+
+func sample(random *rand.Rand, forecast *Forecast) (int64, int64) {
+	p := random.Float64()
+	for h := range forecast.Matrix {
+		for a := range forecast.Matrix[h] {
+			if p -= forecast.Matrix[h][a]; p <= 0 {
+				return int64(h), int64(a)
+			}
+		}
+	}
+	return 0, 0
+}
+
+func TestFitDixonColes(t *testing.T) {
+	matches := simulateLeague(1, 10)
+	model, err := Fit(ModelDixonColes, json.RawMessage(`{"xi":0}`), matches)
+	assert.Nil(t, err)
+	fitted := model.(*DixonColes)
+
+	assert.InDelta(t, 0.3, fitted.HomeAdvantage, 0.1)
+	assert.InDelta(t, -0.1, fitted.Rho, 0.1)
+	assert.Len(t, fitted.Teams, 10)
+	var attacks float64
+	for _, team := range fitted.Teams {
+		attacks += team.Attack
+	}
+	assert.InDelta(t, 0, attacks, 1e-9)
+	assert.Greater(t, fitted.Teams[10].Attack, fitted.Teams[1].Attack)
+	assert.Greater(t, fitted.Teams[10].Defence, fitted.Teams[1].Defence)
+
+	// The fit is a maximum of the log-likelihood
+	fit := newDixonColesFit(matches, 0)
+	x := make([]float64, 2*len(fit.teams)+2)
+	for i, id := range fit.teams {
+		x[i], x[len(fit.teams)+i] = fitted.Teams[id].Attack, fitted.Teams[id].Defence
+	}
+	x[fit.home()], x[fit.rho()] = fitted.HomeAdvantage, fitted.Rho
+	best := fit.logLikelihood(x)
+	grad, _ := fit.gradient(x)
+	for i := range x {
+		assert.InDelta(t, 0, grad[i], 1e-3)
+		for _, delta := range []float64{-0.01, 0.01} {
+			x[i] += delta
+			assert.Less(t, fit.logLikelihood(x), best)
+			x[i] -= delta
+		}
+	}
+}
+
+func TestFitDixonColes_TimeDecay(t *testing.T) {
+	// Team 1 loses the old matches against team 2 and wins the recent ones
+	var matches []Match
+	for i := 0; i < 20; i++ {
+		match := Match{HomeTeamID: 1, AwayTeamID: 2, HomeGoals: 0, AwayGoals: 2, PlayedAt: testSeasonStart.AddDate(0, 0, 7*i)}
+		if i >= 15 {
+			match.HomeGoals, match.AwayGoals = 2, 0
+		}
+		matches = append(matches, match, Match{HomeTeamID: 3, AwayTeamID: 1, HomeGoals: 1, AwayGoals: 1, PlayedAt: match.PlayedAt})
+	}
+
+	flat, err := Fit(ModelDixonColes, json.RawMessage(`{"xi":0}`), matches)
+	assert.Nil(t, err)
+	decayed, err := Fit(ModelDixonColes, json.RawMessage(`{"xi":0.05}`), matches)
+	assert.Nil(t, err)
+
+	flatWin, _, _ := flat.Forecast(1, 2).Outcome()
+	decayedWin, _, _ := decayed.Forecast(1, 2).Outcome()
+	assert.Greater(t, decayedWin, flatWin+0.1)
+}
+
+func TestFitDixonColes_Errors(t *testing.T) {
+	_, err := Fit(ModelDixonColes, json.RawMessage(`{"xi":-1}`), testMatches)
+	assert.ErrorIs(t, err, ErrInvalidHyperparameters)
+	_, err = Fit(ModelDixonColes, json.RawMessage(`{"xi":0.005,"rho":-0.1}`), testMatches)
+	assert.ErrorIs(t, err, ErrInvalidHyperparameters)
+	_, err = Fit(ModelDixonColes, nil, []Match{{HomeTeamID: 1, AwayTeamID: 2}})
+	assert.ErrorIs(t, err, ErrNotEnoughData)
+}
+
+// The versions registered with a fixed rho and their saved fits keep forecasting as poisson-rho
+func TestFitDixonColes_FixedRho(t *testing.T) {
+	expected, err := Fit(ModelPoissonRho, json.RawMessage(`{"rho":-0.1}`), testMatches)
+	assert.Nil(t, err)
+
+	model, err := Fit(ModelDixonColes, json.RawMessage(`{"rho":-0.1}`), testMatches)
+	assert.Nil(t, err)
+	assert.IsType(t, &PoissonRho{}, model)
+	assert.Equal(t, expected.Forecast(3, 4), model.Forecast(3, 4))
+
+	parameters, err := json.Marshal(model)
+	assert.Nil(t, err)
+	loaded, err := Load(ModelDixonColes, parameters)
+	assert.Nil(t, err)
+	assert.Equal(t, expected.Forecast(3, 4), loaded.Forecast(3, 4))
+
+	_, err = Load(ModelDixonColes, json.RawMessage(`{"poisson":{"home_avg":0,"away_avg":1},"rho":-0.1}`))
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
+
+func TestDixonColes_Forecast(t *testing.T) {
+	model := &DixonColes{HomeAdvantage: 0.25, Rho: -0.1, Teams: map[int64]*DixonColesRating{
+		3: {Attack: 0.2, Defence: 0.1},
+		4: {Attack: -0.2, Defence: 0.3},
+	}}
+	lambda, mu := math.Exp(0.2+0.3+0.25), math.Exp(-0.2+0.1)
+
+	base := NewForecast(lambda, mu)
+	corrected := model.Forecast(3, 4)
+
+	assert.InDelta(t, lambda, corrected.HomeExpectedGoals, 1e-12)
+	assert.InDelta(t, mu, corrected.AwayExpectedGoals, 1e-12)
+	assert.InDelta(t, base.Matrix[0][0]*(1+0.1*lambda*mu), corrected.Matrix[0][0], 1e-12)
+	assert.InDelta(t, base.Matrix[1][0]*(1-0.1*mu), corrected.Matrix[1][0], 1e-12)
+	assert.InDelta(t, base.Matrix[0][1]*(1-0.1*lambda), corrected.Matrix[0][1], 1e-12)
+	assert.InDelta(t, base.Matrix[1][1]*1.1, corrected.Matrix[1][1], 1e-12)
+	assert.Equal(t, base.Matrix[2][1], corrected.Matrix[2][1])
+	// A negative rho moves probability to the draws
+	_, poissonDraw, _ := base.Outcome()
+	_, draw, _ := corrected.Outcome()
+	assert.Greater(t, draw, poissonDraw)
+
+	// A team without matches has no attack and the mean defence
+	unknownLambda, _ := model.ExpectedGoals(5, 4)
+	assert.InDelta(t, math.Exp(0.3+0.25), unknownLambda, 1e-12)
+	_, unknownMu := model.ExpectedGoals(3, 5)
+	assert.InDelta(t, math.Exp(0.1), unknownMu, 1e-12)
+}
+
+func TestTau_NeverNegative(t *testing.T) {
+	assert.Equal(t, 0.0, tau(0, 1, 5, 1, -0.5))
+	assert.Equal(t, 1.0, tau(2, 0, 1.5, 1, -0.1))
+}
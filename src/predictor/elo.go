@@ -0,0 +1,101 @@
+package predictor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+const (
+	// eloInitialRating is the rating of a team before its first match
+	eloInitialRating = 1500
+	// eloMinGoals keeps the expected goals of a much weaker team positive
+	eloMinGoals = 0.05
+)
+
+// Elo rates the teams match by match and derives the expected goals from the expected result of the rating
+// difference: the league average goals are shifted by Supremacy goals per unit of expected result above the one
+// of two teams of the same rating
+type Elo struct {
+	Ratings       map[int64]float64 `json:"ratings"`
+	HomeAdvantage float64           `json:"home_advantage"`
+	Supremacy     float64           `json:"supremacy"`
+	HomeAvg       float64           `json:"home_avg"`
+	AwayAvg       float64           `json:"away_avg"`
+}
+
+type eloHyperparameters struct {
+	K             float64 `json:"k"`
+	HomeAdvantage float64 `json:"home_advantage"`
+	Supremacy     float64 `json:"supremacy"`
+}
+
+// fitElo plays the matches in order, every match moves the ratings of its teams by K times the difference between
+// the result and the expected result
+func fitElo(matches []Match, hyperparameters json.RawMessage) (Model, error) {
+	params := eloHyperparameters{K: 20, HomeAdvantage: 60, Supremacy: 3}
+	if err := decode(hyperparameters, &params); err != nil {
+		return nil, err
+	}
+	if params.K <= 0 || params.Supremacy <= 0 || params.HomeAdvantage < 0 {
+		return nil, fmt.Errorf("%w: k and supremacy must be positive and home_advantage not negative", ErrInvalidHyperparameters)
+	}
+	if len(matches) == 0 {
+		return nil, ErrNotEnoughData
+	}
+
+	e := &Elo{
+		Ratings:       make(map[int64]float64),
+		HomeAdvantage: params.HomeAdvantage,
+		Supremacy:     params.Supremacy,
+	}
+	for _, m := range matches {
+		e.HomeAvg += float64(m.HomeGoals)
+		e.AwayAvg += float64(m.AwayGoals)
+		expected := e.expected(m.HomeTeamID, m.AwayTeamID)
+		var result float64
+		switch Result(m.HomeGoals, m.AwayGoals) {
+		case HomeWin:
+			result = 1
+		case Draw:
+			result = 0.5
+		}
+		change := params.K * (result - expected)
+		e.Ratings[m.HomeTeamID] = e.rating(m.HomeTeamID) + change
+		e.Ratings[m.AwayTeamID] = e.rating(m.AwayTeamID) - change
+	}
+	e.HomeAvg /= float64(len(matches))
+	e.AwayAvg /= float64(len(matches))
+	if e.HomeAvg == 0 || e.AwayAvg == 0 {
+		return nil, ErrNotEnoughData
+	}
+	return e, nil
+}
+
+func (e *Elo) rating(teamID int64) float64 {
+	if rating, ok := e.Ratings[teamID]; ok {
+		return rating
+	}
+	return eloInitialRating
+}
+
+// expected returns the expected result of the home team, 1 for a certain win
+func (e *Elo) expected(homeTeamID int64, awayTeamID int64) float64 {
+	return expectedResult(e.rating(homeTeamID) + e.HomeAdvantage - e.rating(awayTeamID))
+}
+
+func expectedResult(difference float64) float64 {
+	return 1 / (1 + math.Pow(10, -difference/400))
+}
+
+// ExpectedGoals returns the expected goals of the home and away team
+func (e *Elo) ExpectedGoals(homeTeamID int64, awayTeamID int64) (float64, float64) {
+	shift := e.Supremacy * (e.expected(homeTeamID, awayTeamID) - expectedResult(e.HomeAdvantage)) / 2
+	return math.Max(e.HomeAvg+shift, eloMinGoals), math.Max(e.AwayAvg-shift, eloMinGoals)
+}
+
+// Forecast returns the probabilities of every score between the two teams
+func (e *Elo) Forecast(homeTeamID int64, awayTeamID int64) *Forecast {
+	homeGoals, awayGoals := e.ExpectedGoals(homeTeamID, awayTeamID)
+	return NewForecast(homeGoals, awayGoals)
+}
@@ -0,0 +1,32 @@
+package predictor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElo_Forecast(t *testing.T) {
+	model, err := Fit(ModelElo, json.RawMessage(`{"k":32,"home_advantage":0,"supremacy":3}`), []Match{
+		{HomeTeamID: 1, AwayTeamID: 2, HomeGoals: 2, AwayGoals: 0},
+		{HomeTeamID: 2, AwayTeamID: 1, HomeGoals: 1, AwayGoals: 1},
+	})
+	assert.Nil(t, err)
+	elo := model.(*Elo)
+
+	// The first match is won by 16 points, the draw then moves 32 * (0.5 - expected) back
+	expected := expectedResult(-32)
+	assert.InDelta(t, 1516-32*(0.5-expected), elo.Ratings[1], 1e-9)
+	assert.InDelta(t, 1484+32*(0.5-expected), elo.Ratings[2], 1e-9)
+	assert.Equal(t, 1.5, elo.HomeAvg)
+	assert.Equal(t, 0.5, elo.AwayAvg)
+
+	// Teams of the same rating get the league averages
+	home, away := elo.ExpectedGoals(3, 4)
+	assert.Equal(t, 1.5, home)
+	assert.Equal(t, 0.5, away)
+	home, away = elo.ExpectedGoals(1, 2)
+	assert.Greater(t, home, 1.5)
+	assert.Less(t, away, 0.5)
+}
@@ -0,0 +1,129 @@
+package predictor
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Ensemble averages the score matrices of its members weighted by their weight
+type Ensemble struct {
+	Members []EnsembleMember
+}
+
+// EnsembleMember is a fitted model of an ensemble, its JSON form holds the fitted parameters of the model
+type EnsembleMember struct {
+	Name   string
+	Weight float64
+	Model  Model
+}
+
+type ensembleMemberParameters struct {
+	Model      string          `json:"model"`
+	Weight     float64         `json:"weight"`
+	Parameters json.RawMessage `json:"parameters"`
+}
+
+type ensembleHyperparameters struct {
+	Members []struct {
+		Model           string          `json:"model"`
+		Weight          float64         `json:"weight"`
+		Hyperparameters json.RawMessage `json:"hyperparameters"`
+	} `json:"members"`
+}
+
+// defaultEnsemble weighs the other models equally with their default hyperparameters, it is used when no member
+// is given
+const defaultEnsemble = `{"members":[{"model":"poisson","weight":1},{"model":"dixon-coles","weight":1},{"model":"elo","weight":1}]}`
+
+// fitEnsemble validates the hyperparameters of every member before fitting them, so invalid hyperparameters are
+// reported even without matches
+func fitEnsemble(matches []Match, hyperparameters json.RawMessage) (Model, error) {
+	var params ensembleHyperparameters
+	if err := decode(hyperparameters, &params); err != nil {
+		return nil, err
+	}
+	if len(params.Members) == 0 {
+		_ = json.Unmarshal([]byte(defaultEnsemble), &params)
+	}
+	for _, m := range params.Members {
+		if m.Model == ModelEnsemble {
+			return nil, fmt.Errorf("%w: an ensemble cannot contain an ensemble", ErrInvalidHyperparameters)
+		}
+		if m.Weight <= 0 {
+			return nil, fmt.Errorf("%w: the weight of every member must be positive", ErrInvalidHyperparameters)
+		}
+		if err := Validate(m.Model, m.Hyperparameters); err != nil {
+			return nil, err
+		}
+	}
+
+	e := &Ensemble{}
+	for _, m := range params.Members {
+		model, err := Fit(m.Model, m.Hyperparameters, matches)
+		if err != nil {
+			return nil, err
+		}
+		e.Members = append(e.Members, EnsembleMember{Name: m.Model, Weight: m.Weight, Model: model})
+	}
+	return e, nil
+}
+
+func (e *Ensemble) MarshalJSON() ([]byte, error) {
+	members := make([]ensembleMemberParameters, len(e.Members))
+	for i, m := range e.Members {
+		parameters, err := json.Marshal(m.Model)
+		if err != nil {
+			return nil, err
+		}
+		members[i] = ensembleMemberParameters{Model: m.Name, Weight: m.Weight, Parameters: parameters}
+	}
+	return json.Marshal(map[string]interface{}{"members": members})
+}
+
+func (e *Ensemble) UnmarshalJSON(data []byte) error {
+	var params struct {
+		Members []ensembleMemberParameters `json:"members"`
+	}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return err
+	}
+	if len(params.Members) == 0 {
+		return ErrInvalidParameters
+	}
+	e.Members = nil
+	for _, m := range params.Members {
+		if m.Model == ModelEnsemble || m.Weight <= 0 {
+			return ErrInvalidParameters
+		}
+		model, err := Load(m.Model, m.Parameters)
+		if err != nil {
+			return err
+		}
+		e.Members = append(e.Members, EnsembleMember{Name: m.Model, Weight: m.Weight, Model: model})
+	}
+	return nil
+}
+
+// Forecast returns the weighted average of the forecasts of the members
+func (e *Ensemble) Forecast(homeTeamID int64, awayTeamID int64) *Forecast {
+	res := &Forecast{Matrix: make([][]float64, MaxGoals+1)}
+	for h := range res.Matrix {
+		res.Matrix[h] = make([]float64, MaxGoals+1)
+	}
+	var total float64
+	for _, m := range e.Members {
+		total += m.Weight
+	}
+	for _, m := range e.Members {
+		forecast := m.Model.Forecast(homeTeamID, awayTeamID)
+		weight := m.Weight / total
+		res.HomeExpectedGoals += weight * forecast.HomeExpectedGoals
+		res.AwayExpectedGoals += weight * forecast.AwayExpectedGoals
+		for h := range forecast.Matrix {
+			for a, p := range forecast.Matrix[h] {
+				res.Matrix[h][a] += weight * p
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,21 @@
+package predictor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnsemble_Forecast(t *testing.T) {
+	model, err := Fit(ModelEnsemble, json.RawMessage(`{"members":[{"model":"poisson","weight":3},{"model":"elo","weight":1}]}`), testMatches)
+	assert.Nil(t, err)
+	poisson, _ := Fit(ModelPoisson, nil, testMatches)
+	elo, _ := Fit(ModelElo, nil, testMatches)
+
+	forecast := model.Forecast(1, 2)
+	p, e := poisson.Forecast(1, 2), elo.Forecast(1, 2)
+
+	assert.InDelta(t, 0.75*p.HomeExpectedGoals+0.25*e.HomeExpectedGoals, forecast.HomeExpectedGoals, 1e-12)
+	assert.InDelta(t, 0.75*p.Matrix[2][1]+0.25*e.Matrix[2][1], forecast.Matrix[2][1], 1e-12)
+}
@@ -0,0 +1,134 @@
+package predictor
+
+import (
+	"errors"
+	"math"
+)
+
+// Methods removing the bookmaker margin from the implied probabilities of a market
+const (
+	// MethodBasic divides every implied probability by the booksum
+	MethodBasic = "basic"
+	// MethodShin assumes the margin protects the bookmaker from insiders, see Shin (1993). It moves more
+	// probability away from the longshots than from the favourites
+	MethodShin = "shin"
+	// MethodPower raises every implied probability to the same power k so that they sum to 1
+	MethodPower = "power"
+)
+
+var (
+	ErrInvalidOdds   = errors.New("decimal odds must be greater than 1")
+	ErrUnknownMethod = errors.New("unknown overround removal method")
+)
+
+// iterations of the bisections, enough to reach the float64 precision
+const bisections = 100
+
+// Overround returns the bookmaker margin of a market, the sum of the implied probabilities 1/odd minus 1
+func Overround(odds []float64) float64 {
+	var booksum float64
+	for _, odd := range odds {
+		booksum += 1 / odd
+	}
+	return booksum - 1
+}
+
+// Implied converts the decimal odds of every outcome of a market to probabilities that sum to 1, removing the
+// overround with the given method. Markets without margin are only normalised whatever the method
+func Implied(odds []float64, method string) ([]float64, error) {
+	if len(odds) == 0 {
+		return nil, ErrInvalidOdds
+	}
+	raw := make([]float64, len(odds))
+	var booksum float64
+	for i, odd := range odds {
+		if !(odd > 1) || math.IsInf(odd, 1) {
+			return nil, ErrInvalidOdds
+		}
+		raw[i] = 1 / odd
+		booksum += raw[i]
+	}
+
+	switch method {
+	case MethodBasic:
+		return normalise(raw, booksum), nil
+	case MethodShin:
+		if booksum <= 1 {
+			return normalise(raw, booksum), nil
+		}
+		return shin(raw, booksum), nil
+	case MethodPower:
+		if booksum <= 1 {
+			return normalise(raw, booksum), nil
+		}
+		return power(raw), nil
+	}
+	return nil, ErrUnknownMethod
+}
+
+func normalise(raw []float64, booksum float64) []float64 {
+	probabilities := make([]float64, len(raw))
+	for i := range raw {
+		probabilities[i] = raw[i] / booksum
+	}
+	return probabilities
+}
+
+// shin finds the share z of insider money for which the Shin probabilities sum to 1. The sum decreases with z,
+// from the square root of the booksum at 0 to below 1 close to 1
+func shin(raw []float64, booksum float64) []float64 {
+	probability := func(pi, z float64) float64 {
+		return (math.Sqrt(z*z+4*(1-z)*pi*pi/booksum) - z) / (2 * (1 - z))
+	}
+	lo, hi := 0.0, 1.0
+	for n := 0; n < bisections; n++ {
+		z := (lo + hi) / 2
+		var sum float64
+		for _, pi := range raw {
+			sum += probability(pi, z)
+		}
+		if sum > 1 {
+			lo = z
+		} else {
+			hi = z
+		}
+	}
+	z := (lo + hi) / 2
+	probabilities := make([]float64, len(raw))
+	var sum float64
+	for i, pi := range raw {
+		probabilities[i] = probability(pi, z)
+		sum += probabilities[i]
+	}
+	// Clear the rounding left by the bisection
+	return normalise(probabilities, sum)
+}
+
+// power finds the exponent k > 1 for which the implied probabilities raised to k sum to 1
+func power(raw []float64) []float64 {
+	sum := func(k float64) float64 {
+		var s float64
+		for _, pi := range raw {
+			s += math.Pow(pi, k)
+		}
+		return s
+	}
+	lo, hi := 1.0, 2.0
+	for sum(hi) > 1 {
+		lo, hi = hi, hi*2
+	}
+	for n := 0; n < bisections; n++ {
+		k := (lo + hi) / 2
+		if sum(k) > 1 {
+			lo = k
+		} else {
+			hi = k
+		}
+	}
+	k := (lo + hi) / 2
+	probabilities := make([]float64, len(raw))
+	for i, pi := range raw {
+		probabilities[i] = math.Pow(pi, k)
+	}
+	return normalise(probabilities, sum(k))
+}
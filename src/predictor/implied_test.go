@@ -0,0 +1,67 @@
+package predictor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverround(t *testing.T) {
+	assert.InDelta(t, 0.05, Overround([]float64{1.904762, 1.904762}), 1e-6)
+	assert.InDelta(t, 0, Overround([]float64{2, 2}), 1e-9)
+}
+
+func TestImplied(t *testing.T) {
+	testCases := []struct {
+		title       string
+		odds        []float64
+		method      string
+		expectedRes []float64
+		expectedErr error
+	}{
+		{title: "error no odds", method: MethodBasic, expectedErr: ErrInvalidOdds},
+		{title: "error odd of 1", odds: []float64{1, 3}, method: MethodBasic, expectedErr: ErrInvalidOdds},
+		{title: "error unknown method", odds: []float64{2, 2}, method: "other", expectedErr: ErrUnknownMethod},
+		{title: "basic", odds: []float64{1.8, 2.0}, method: MethodBasic, expectedRes: []float64{0.526316, 0.473684}},
+		{title: "basic equal odds", odds: []float64{1.9, 1.9}, method: MethodBasic, expectedRes: []float64{0.5, 0.5}},
+		{title: "shin equal odds", odds: []float64{1.9, 1.9}, method: MethodShin, expectedRes: []float64{0.5, 0.5}},
+		{title: "power equal odds", odds: []float64{1.9, 1.9}, method: MethodPower, expectedRes: []float64{0.5, 0.5}},
+		{title: "shin without margin", odds: []float64{2, 2}, method: MethodShin, expectedRes: []float64{0.5, 0.5}},
+		{title: "power without margin", odds: []float64{4, 4, 2}, method: MethodPower, expectedRes: []float64{0.25, 0.25, 0.5}},
+		// Shin checked against the fixed point iteration of Jullien and Salanié, z = 0.024082
+		{title: "shin 1x2", odds: []float64{2.1, 3.4, 3.6}, method: MethodShin, expectedRes: []float64{0.458666, 0.278738, 0.262597}},
+		{title: "power 1x2", odds: []float64{2.1, 3.4, 3.6}, method: MethodPower, expectedRes: []float64{0.460174, 0.277980, 0.261846}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			res, err := Implied(testCase.odds, testCase.method)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, len(testCase.expectedRes), len(res))
+			for i := range testCase.expectedRes {
+				assert.InDelta(t, testCase.expectedRes[i], res[i], 1e-4)
+			}
+		})
+	}
+}
+
+func TestImplied_FavouriteLongshot(t *testing.T) {
+	odds := []float64{1.25, 5.5, 11}
+	basic, err := Implied(odds, MethodBasic)
+	assert.Nil(t, err)
+
+	for _, method := range []string{MethodShin, MethodPower} {
+		res, err := Implied(odds, method)
+		assert.Nil(t, err)
+
+		var sum float64
+		for _, p := range res {
+			sum += p
+		}
+		assert.InDelta(t, 1, sum, 1e-9, method)
+		// Both methods take the margin mostly from the longshot
+		assert.Greater(t, res[0], basic[0], method)
+		assert.Less(t, res[2], basic[2], method)
+	}
+}
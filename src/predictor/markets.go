@@ -0,0 +1,56 @@
+package predictor
+
+import "math"
+
+// Over returns the probability of more than line total goals, e.g. 2.5. Like Outcome the probabilities are
+// normalised by the scores of the matrix
+func (f *Forecast) Over(line float64) float64 {
+	return f.probability(func(h, a int) bool { return float64(h+a) > line })
+}
+
+// BothScore returns the probability of both teams scoring
+func (f *Forecast) BothScore() float64 {
+	return f.probability(func(h, a int) bool { return h > 0 && a > 0 })
+}
+
+// HomeCovers returns the probability of the home team winning once the handicap is added to its goals,
+// e.g. -0.5 is a home win and +0.5 a home win or a draw
+func (f *Forecast) HomeCovers(handicap float64) float64 {
+	return f.probability(func(h, a int) bool { return float64(h)+handicap > float64(a) })
+}
+
+func (f *Forecast) probability(event func(h, a int) bool) float64 {
+	var matched, total float64
+	for h := range f.Matrix {
+		for a, p := range f.Matrix[h] {
+			total += p
+			if event(h, a) {
+				matched += p
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return matched / total
+}
+
+// HalfLine tells if a goal line or handicap ends in .5, so the bet cannot be void. Whole and quarter lines
+// refund all or half the stake on some scores and are not a plain win or loss
+func HalfLine(line float64) bool {
+	_, fraction := math.Modf(math.Abs(line))
+	return fraction == 0.5
+}
+
+// Kelly returns the fraction of the bankroll to stake on a bet at the decimal odd won with the given probability,
+// 0 when the expected value of the bet is not positive
+func Kelly(probability float64, odd float64) float64 {
+	if odd <= 1 {
+		return 0
+	}
+	stake := (probability*odd - 1) / (odd - 1)
+	if stake < 0 {
+		return 0
+	}
+	return stake
+}
@@ -0,0 +1,51 @@
+package predictor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecast_Markets(t *testing.T) {
+	forecast := NewForecast(1.5, 1.1)
+	home, draw, away := forecast.Outcome()
+
+	// A home handicap of -0.5 is a home win and +0.5 anything but an away win
+	assert.InDelta(t, home, forecast.HomeCovers(-0.5), 1e-9)
+	assert.InDelta(t, home+draw, forecast.HomeCovers(0.5), 1e-9)
+	assert.InDelta(t, 1-away-draw, forecast.HomeCovers(-0.5), 1e-9)
+
+	// The total goals follow a Poisson distribution of mean 2.6, P(0 or 1 or 2 goals) = 0.5184
+	assert.InDelta(t, 1-0.518, forecast.Over(2.5), 1e-3)
+	assert.Greater(t, forecast.Over(1.5), forecast.Over(2.5))
+
+	// P(home scores) * P(away scores) since the goals are independent
+	assert.InDelta(t, (1-0.22313)*(1-0.33287), forecast.BothScore(), 1e-4)
+}
+
+func TestHalfLine(t *testing.T) {
+	assert.True(t, HalfLine(2.5))
+	assert.True(t, HalfLine(-0.5))
+	assert.False(t, HalfLine(2))
+	assert.False(t, HalfLine(-0.25))
+	assert.False(t, HalfLine(0))
+}
+
+func TestKelly(t *testing.T) {
+	testCases := []struct {
+		title       string
+		probability float64
+		odd         float64
+		expectedRes float64
+	}{
+		{title: "value bet", probability: 0.55, odd: 2, expectedRes: 0.1},
+		{title: "fair odd", probability: 0.5, odd: 2, expectedRes: 0},
+		{title: "negative expected value", probability: 0.4, odd: 2, expectedRes: 0},
+		{title: "invalid odd", probability: 0.9, odd: 1, expectedRes: 0},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			assert.InDelta(t, testCase.expectedRes, Kelly(testCase.probability, testCase.odd), 1e-9)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package predictor
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+)
+
+// MaxGoals is the highest number of goals per team considered when building the score matrix
+const MaxGoals = 10
+
+var ErrNotEnoughData = errors.New("not enough finished matches to fit the model")
+
+// Match is a finished match used to fit a model, PlayedAt weighs the older matches down in the models that decay
+type Match struct {
+	HomeTeamID int64
+	AwayTeamID int64
+	HomeGoals  int64
+	AwayGoals  int64
+	PlayedAt   time.Time
+}
+
+type strength struct {
+	Scored   float64 `json:"scored"`
+	Conceded float64 `json:"conceded"`
+	Played   float64 `json:"played"`
+}
+
+// Poisson models the goals of each team as independent Poisson variables whose means come from the
+// attack and defence strengths of the teams relative to the league averages, split by home and away.
+// Its fitted parameters are the league averages and the totals of every team
+type Poisson struct {
+	homeAvg float64
+	awayAvg float64
+	home    map[int64]*strength
+	away    map[int64]*strength
+}
+
+// poissonParameters is the JSON form of a fitted Poisson model
+type poissonParameters struct {
+	HomeAvg float64             `json:"home_avg"`
+	AwayAvg float64             `json:"away_avg"`
+	Home    map[int64]*strength `json:"home"`
+	Away    map[int64]*strength `json:"away"`
+}
+
+func (p *Poisson) MarshalJSON() ([]byte, error) {
+	return json.Marshal(poissonParameters{HomeAvg: p.homeAvg, AwayAvg: p.awayAvg, Home: p.home, Away: p.away})
+}
+
+func (p *Poisson) UnmarshalJSON(data []byte) error {
+	var params poissonParameters
+	if err := json.Unmarshal(data, &params); err != nil {
+		return err
+	}
+	if params.HomeAvg <= 0 || params.AwayAvg <= 0 {
+		return ErrInvalidParameters
+	}
+	p.homeAvg, p.awayAvg = params.HomeAvg, params.AwayAvg
+	p.home, p.away = params.Home, params.Away
+	if p.home == nil {
+		p.home = make(map[int64]*strength)
+	}
+	if p.away == nil {
+		p.away = make(map[int64]*strength)
+	}
+	return nil
+}
+
+// FitPoisson computes the team strengths from the given matches
+func FitPoisson(matches []Match) (*Poisson, error) {
+	if len(matches) == 0 {
+		return nil, ErrNotEnoughData
+	}
+
+	p := &Poisson{
+		home: make(map[int64]*strength),
+		away: make(map[int64]*strength),
+	}
+	for _, m := range matches {
+		p.homeAvg += float64(m.HomeGoals)
+		p.awayAvg += float64(m.AwayGoals)
+		add(p.home, m.HomeTeamID, m.HomeGoals, m.AwayGoals)
+		add(p.away, m.AwayTeamID, m.AwayGoals, m.HomeGoals)
+	}
+	p.homeAvg /= float64(len(matches))
+	p.awayAvg /= float64(len(matches))
+	if p.homeAvg == 0 || p.awayAvg == 0 {
+		return nil, ErrNotEnoughData
+	}
+	return p, nil
+}
+
+func add(teams map[int64]*strength, id int64, scored int64, conceded int64) {
+	s, ok := teams[id]
+	if !ok {
+		s = &strength{}
+		teams[id] = s
+	}
+	s.Scored += float64(scored)
+	s.Conceded += float64(conceded)
+	s.Played++
+}
+
+// ratio returns the team average relative to the league average, teams without matches are considered average
+func ratio(teams map[int64]*strength, id int64, scored bool, leagueAvg float64) float64 {
+	s, ok := teams[id]
+	if !ok || s.Played == 0 {
+		return 1
+	}
+	if scored {
+		return s.Scored / s.Played / leagueAvg
+	}
+	return s.Conceded / s.Played / leagueAvg
+}
+
+// ExpectedGoals returns the expected goals of the home and away team
+func (p *Poisson) ExpectedGoals(homeTeamID int64, awayTeamID int64) (float64, float64) {
+	homeGoals := ratio(p.home, homeTeamID, true, p.homeAvg) * ratio(p.away, awayTeamID, false, p.homeAvg) * p.homeAvg
+	awayGoals := ratio(p.away, awayTeamID, true, p.awayAvg) * ratio(p.home, homeTeamID, false, p.awayAvg) * p.awayAvg
+	return homeGoals, awayGoals
+}
+
+// Forecast returns the probabilities of every score between the two teams
+func (p *Poisson) Forecast(homeTeamID int64, awayTeamID int64) *Forecast {
+	homeGoals, awayGoals := p.ExpectedGoals(homeTeamID, awayTeamID)
+	return NewForecast(homeGoals, awayGoals)
+}
+
+// Forecast holds the probability of each score, Matrix[h][a] is the probability of h home goals and a away goals
+type Forecast struct {
+	HomeExpectedGoals float64
+	AwayExpectedGoals float64
+	Matrix            [][]float64
+}
+
+// NewForecast builds the score matrix from two independent Poisson distributions
+func NewForecast(homeGoals float64, awayGoals float64) *Forecast {
+	homeProbs := poissonProbs(homeGoals)
+	awayProbs := poissonProbs(awayGoals)
+
+	matrix := make([][]float64, MaxGoals+1)
+	for h := range matrix {
+		matrix[h] = make([]float64, MaxGoals+1)
+		for a := range matrix[h] {
+			matrix[h][a] = homeProbs[h] * awayProbs[a]
+		}
+	}
+	return &Forecast{
+		HomeExpectedGoals: homeGoals,
+		AwayExpectedGoals: awayGoals,
+		Matrix:            matrix,
+	}
+}
+
+func poissonProbs(lambda float64) []float64 {
+	probs := make([]float64, MaxGoals+1)
+	probs[0] = math.Exp(-lambda)
+	for k := 1; k <= MaxGoals; k++ {
+		probs[k] = probs[k-1] * lambda / float64(k)
+	}
+	return probs
+}
+
+// Outcome returns the home win, draw and away win probabilities, normalised so they add up to 1
+// despite the scores above MaxGoals being left out
+func (f *Forecast) Outcome() (float64, float64, float64) {
+	var home, draw, away float64
+	for h := range f.Matrix {
+		for a, p := range f.Matrix[h] {
+			switch {
+			case h > a:
+				home += p
+			case h == a:
+				draw += p
+			default:
+				away += p
+			}
+		}
+	}
+	total := home + draw + away
+	if total == 0 {
+		return 0, 0, 0
+	}
+	return home / total, draw / total, away / total
+}
+
+// MostLikelyScore returns the score with the highest probability
+func (f *Forecast) MostLikelyScore() (int64, int64, float64) {
+	var bestHome, bestAway int64
+	best := -1.0
+	for h := range f.Matrix {
+		for a, p := range f.Matrix[h] {
+			if p > best {
+				best = p
+				bestHome, bestAway = int64(h), int64(a)
+			}
+		}
+	}
+	return bestHome, bestAway, best
+}
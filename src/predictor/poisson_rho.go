@@ -0,0 +1,60 @@
+package predictor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// defaultRho is the usual dependence of the low scores found in league football
+const defaultRho = -0.1
+
+// PoissonRho is the Poisson model with the low scores corrected by a fixed rho, as Dixon and Coles do, while
+// DixonColes fits rho together with the team ratings
+type PoissonRho struct {
+	Poisson *Poisson `json:"poisson"`
+	Rho     float64  `json:"rho"`
+}
+
+type poissonRhoHyperparameters struct {
+	Rho float64 `json:"rho"`
+}
+
+func fitPoissonRho(matches []Match, hyperparameters json.RawMessage) (Model, error) {
+	params := poissonRhoHyperparameters{Rho: defaultRho}
+	if err := decode(hyperparameters, &params); err != nil {
+		return nil, err
+	}
+	if math.Abs(params.Rho) > 0.5 {
+		return nil, fmt.Errorf("%w: rho must be between -0.5 and 0.5", ErrInvalidHyperparameters)
+	}
+	poisson, err := FitPoisson(matches)
+	if err != nil {
+		return nil, err
+	}
+	return &PoissonRho{Poisson: poisson, Rho: params.Rho}, nil
+}
+
+func (p *PoissonRho) UnmarshalJSON(data []byte) error {
+	type parameters PoissonRho
+	var params parameters
+	if err := json.Unmarshal(data, &params); err != nil {
+		return err
+	}
+	if params.Poisson == nil {
+		return ErrInvalidParameters
+	}
+	*p = PoissonRho(params)
+	return nil
+}
+
+// Forecast returns the Poisson score matrix with the low scores corrected by tau
+func (p *PoissonRho) Forecast(homeTeamID int64, awayTeamID int64) *Forecast {
+	forecast := p.Poisson.Forecast(homeTeamID, awayTeamID)
+	for h := 0; h <= 1; h++ {
+		for a := 0; a <= 1; a++ {
+			forecast.Matrix[h][a] *= tau(h, a, forecast.HomeExpectedGoals, forecast.AwayExpectedGoals, p.Rho)
+		}
+	}
+	return forecast
+}
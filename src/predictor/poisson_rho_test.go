@@ -0,0 +1,41 @@
+package predictor
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoissonRho_Forecast(t *testing.T) {
+	poisson, _ := FitPoisson(testMatches)
+	model, err := Fit(ModelPoissonRho, json.RawMessage(`{"rho":-0.1}`), testMatches)
+	assert.Nil(t, err)
+
+	base := poisson.Forecast(3, 4)
+	corrected := model.Forecast(3, 4)
+	lambda, mu := base.HomeExpectedGoals, base.AwayExpectedGoals
+
+	assert.Equal(t, base.HomeExpectedGoals, corrected.HomeExpectedGoals)
+	assert.InDelta(t, base.Matrix[0][0]*(1+0.1*lambda*mu), corrected.Matrix[0][0], 1e-12)
+	assert.InDelta(t, base.Matrix[1][0]*(1-0.1*mu), corrected.Matrix[1][0], 1e-12)
+	assert.InDelta(t, base.Matrix[0][1]*(1-0.1*lambda), corrected.Matrix[0][1], 1e-12)
+	assert.InDelta(t, base.Matrix[1][1]*1.1, corrected.Matrix[1][1], 1e-12)
+	assert.Equal(t, base.Matrix[2][1], corrected.Matrix[2][1])
+	// A negative rho moves probability to the draws
+	_, poissonDraw, _ := base.Outcome()
+	_, draw, _ := corrected.Outcome()
+	assert.Greater(t, draw, poissonDraw)
+}
+
+func TestPoissonRho_Errors(t *testing.T) {
+	_, err := Fit(ModelPoissonRho, json.RawMessage(`{"rho":0.6}`), testMatches)
+	assert.True(t, errors.Is(err, ErrInvalidHyperparameters))
+
+	_, err = Fit(ModelPoissonRho, json.RawMessage(`{"xi":0.005}`), testMatches)
+	assert.True(t, errors.Is(err, ErrInvalidHyperparameters))
+
+	_, err = Load(ModelPoissonRho, json.RawMessage(`{"rho":-0.1}`))
+	assert.True(t, errors.Is(err, ErrInvalidParameters))
+}
@@ -0,0 +1,76 @@
+package predictor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitPoisson(t *testing.T) {
+	testCases := []struct {
+		title       string
+		matches     []Match
+		expectedErr error
+	}{
+		{
+			title:       "error no matches",
+			matches:     nil,
+			expectedErr: ErrNotEnoughData,
+		},
+		{
+			title:       "error no goals",
+			matches:     []Match{{HomeTeamID: 1, AwayTeamID: 2}},
+			expectedErr: ErrNotEnoughData,
+		},
+		{
+			title:       "success",
+			matches:     []Match{{HomeTeamID: 1, AwayTeamID: 2, HomeGoals: 2, AwayGoals: 1}},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			_, err := FitPoisson(testCase.matches)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestPoisson_Forecast(t *testing.T) {
+	// Team 1 is strong at home, team 3 is weak away
+	model, err := FitPoisson([]Match{
+		{HomeTeamID: 1, AwayTeamID: 2, HomeGoals: 3, AwayGoals: 0},
+		{HomeTeamID: 2, AwayTeamID: 3, HomeGoals: 1, AwayGoals: 1},
+		{HomeTeamID: 3, AwayTeamID: 1, HomeGoals: 0, AwayGoals: 2},
+		{HomeTeamID: 1, AwayTeamID: 3, HomeGoals: 2, AwayGoals: 0},
+		{HomeTeamID: 2, AwayTeamID: 1, HomeGoals: 1, AwayGoals: 1},
+		{HomeTeamID: 3, AwayTeamID: 2, HomeGoals: 1, AwayGoals: 2},
+	})
+	assert.Nil(t, err)
+
+	forecast := model.Forecast(1, 3)
+	assert.Greater(t, forecast.HomeExpectedGoals, forecast.AwayExpectedGoals)
+
+	home, draw, away := forecast.Outcome()
+	assert.InDelta(t, 1, home+draw+away, 1e-9)
+	assert.Greater(t, home, away)
+
+	// Unknown teams get the league averages
+	homeGoals, awayGoals := model.ExpectedGoals(10, 11)
+	assert.InDelta(t, 8.0/6, homeGoals, 1e-9)
+	assert.InDelta(t, 1.0, awayGoals, 1e-9)
+}
+
+func TestForecast_MostLikelyScore(t *testing.T) {
+	forecast := NewForecast(0.1, 0.1)
+	home, away, probability := forecast.MostLikelyScore()
+	assert.Equal(t, int64(0), home)
+	assert.Equal(t, int64(0), away)
+	assert.InDelta(t, 0.8187, probability, 1e-4)
+
+	forecast = NewForecast(2.6, 0.4)
+	home, away, _ = forecast.MostLikelyScore()
+	assert.Equal(t, int64(2), home)
+	assert.Equal(t, int64(0), away)
+}
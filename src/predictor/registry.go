@@ -0,0 +1,146 @@
+package predictor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Names of the models that can be registered
+const (
+	ModelPoisson    = "poisson"
+	ModelPoissonRho = "poisson-rho"
+	ModelDixonColes = "dixon-coles"
+	ModelElo        = "elo"
+	ModelEnsemble   = "ensemble"
+)
+
+var (
+	ErrUnknownModel           = errors.New("unknown model")
+	ErrInvalidHyperparameters = errors.New("invalid hyperparameters")
+	ErrInvalidParameters      = errors.New("invalid fitted parameters")
+)
+
+// Model forecasts the score of a fixture, it is fitted by Fit and its fitted parameters are its JSON form,
+// which Load reads back
+type Model interface {
+	Forecast(homeTeamID int64, awayTeamID int64) *Forecast
+}
+
+type definition struct {
+	// fit validates the hyperparameters and fits the model, validation alone fits no match
+	fit  func(matches []Match, hyperparameters json.RawMessage) (Model, error)
+	load func(parameters json.RawMessage) (Model, error)
+}
+
+var definitions map[string]definition
+
+// The definitions are set in init since the ensemble fits its members through them
+func init() {
+	definitions = map[string]definition{
+		ModelPoisson: {
+			fit: func(matches []Match, hyperparameters json.RawMessage) (Model, error) {
+				if err := decode(hyperparameters, &struct{}{}); err != nil {
+					return nil, err
+				}
+				return FitPoisson(matches)
+			},
+			load: func(parameters json.RawMessage) (Model, error) {
+				model := &Poisson{}
+				return model, loadParameters(parameters, model)
+			},
+		},
+		ModelPoissonRho: {
+			fit: fitPoissonRho,
+			load: func(parameters json.RawMessage) (Model, error) {
+				model := &PoissonRho{}
+				return model, loadParameters(parameters, model)
+			},
+		},
+		ModelDixonColes: {
+			fit:  fitDixonColes,
+			load: loadDixonColes,
+		},
+		ModelElo: {
+			fit: fitElo,
+			load: func(parameters json.RawMessage) (Model, error) {
+				model := &Elo{}
+				return model, loadParameters(parameters, model)
+			},
+		},
+		ModelEnsemble: {
+			fit: fitEnsemble,
+			load: func(parameters json.RawMessage) (Model, error) {
+				model := &Ensemble{}
+				return model, loadParameters(parameters, model)
+			},
+		},
+	}
+}
+
+// Models returns the names of the models that can be registered
+func Models() []string {
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Fit fits the named model with its hyperparameters on the matches, given in the order they were played.
+// Empty hyperparameters use the defaults of the model, the common hyperparameters are left to the caller
+func Fit(name string, hyperparameters json.RawMessage, matches []Match) (Model, error) {
+	d, ok := definitions[name]
+	if !ok {
+		return nil, ErrUnknownModel
+	}
+	own, _, err := splitHyperparameters(hyperparameters)
+	if err != nil {
+		return nil, err
+	}
+	return d.fit(matches, own)
+}
+
+// Validate checks the hyperparameters of the named model
+func Validate(name string, hyperparameters json.RawMessage) error {
+	_, err := Fit(name, hyperparameters, nil)
+	if err == ErrNotEnoughData {
+		return nil
+	}
+	return err
+}
+
+// Load rebuilds the named model from the JSON form of its fitted parameters
+func Load(name string, parameters json.RawMessage) (Model, error) {
+	d, ok := definitions[name]
+	if !ok {
+		return nil, ErrUnknownModel
+	}
+	return d.load(parameters)
+}
+
+// decode reads the hyperparameters into v, which holds the defaults, unknown fields are refused
+func decode(hyperparameters json.RawMessage, v interface{}) error {
+	if len(bytes.TrimSpace(hyperparameters)) == 0 || string(hyperparameters) == "null" {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(hyperparameters))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidHyperparameters, err)
+	}
+	return nil
+}
+
+func loadParameters(parameters json.RawMessage, model Model) error {
+	if err := json.Unmarshal(parameters, model); err != nil {
+		if errors.Is(err, ErrInvalidParameters) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrInvalidParameters, err)
+	}
+	return nil
+}
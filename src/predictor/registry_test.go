@@ -0,0 +1,98 @@
+package predictor
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testMatches = []Match{
+	{HomeTeamID: 1, AwayTeamID: 2, HomeGoals: 3, AwayGoals: 0},
+	{HomeTeamID: 3, AwayTeamID: 4, HomeGoals: 1, AwayGoals: 1},
+	{HomeTeamID: 2, AwayTeamID: 3, HomeGoals: 0, AwayGoals: 2},
+	{HomeTeamID: 4, AwayTeamID: 1, HomeGoals: 1, AwayGoals: 2},
+	{HomeTeamID: 1, AwayTeamID: 3, HomeGoals: 2, AwayGoals: 1},
+	{HomeTeamID: 2, AwayTeamID: 4, HomeGoals: 1, AwayGoals: 1},
+}
+
+func TestModels(t *testing.T) {
+	assert.Equal(t, []string{"dixon-coles", "elo", "ensemble", "poisson", "poisson-rho"}, Models())
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		title           string
+		name            string
+		hyperparameters string
+		expectedErr     error
+	}{
+		{title: "error unknown model", name: "xg", expectedErr: ErrUnknownModel},
+		{title: "error unknown hyperparameter", name: ModelPoisson, hyperparameters: `{"rho":0.1}`, expectedErr: ErrInvalidHyperparameters},
+		{title: "error dixon-coles xi", name: ModelDixonColes, hyperparameters: `{"xi":-1}`, expectedErr: ErrInvalidHyperparameters},
+		{title: "error elo k", name: ModelElo, hyperparameters: `{"k":0}`, expectedErr: ErrInvalidHyperparameters},
+		{title: "error ensemble of ensembles", name: ModelEnsemble, hyperparameters: `{"members":[{"model":"ensemble","weight":1}]}`, expectedErr: ErrInvalidHyperparameters},
+		{title: "error ensemble weight", name: ModelEnsemble, hyperparameters: `{"members":[{"model":"poisson","weight":0}]}`, expectedErr: ErrInvalidHyperparameters},
+		{title: "error ensemble member", name: ModelEnsemble, hyperparameters: `{"members":[{"model":"elo","weight":1,"hyperparameters":{"k":-1}}]}`, expectedErr: ErrInvalidHyperparameters},
+		{title: "error ensemble unknown member", name: ModelEnsemble, hyperparameters: `{"members":[{"model":"xg","weight":1}]}`, expectedErr: ErrUnknownModel},
+		{title: "error availability weight", name: ModelPoisson, hyperparameters: `{"availability_weight":1.5}`, expectedErr: ErrInvalidHyperparameters},
+		{title: "error availability weight type", name: ModelElo, hyperparameters: `{"availability_weight":"high"}`, expectedErr: ErrInvalidHyperparameters},
+		{title: "success defaults", name: ModelEnsemble, hyperparameters: ``},
+		{title: "success availability weight", name: ModelDixonColes, hyperparameters: `{"xi":0.005,"availability_weight":0.5}`},
+		{title: "success", name: ModelDixonColes, hyperparameters: `{"xi":0.005}`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			err := Validate(testCase.name, json.RawMessage(testCase.hyperparameters))
+
+			if testCase.expectedErr == nil {
+				assert.Nil(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, testCase.expectedErr), err)
+		})
+	}
+}
+
+// Every model fitted and loaded back from its parameters forecasts the same
+func TestFit_Load(t *testing.T) {
+	for _, name := range Models() {
+		t.Run(name, func(t *testing.T) {
+			model, err := Fit(name, nil, testMatches)
+			assert.Nil(t, err)
+			parameters, err := json.Marshal(model)
+			assert.Nil(t, err)
+
+			loaded, err := Load(name, parameters)
+
+			assert.Nil(t, err)
+			assert.Equal(t, model.Forecast(1, 2), loaded.Forecast(1, 2))
+			assert.Equal(t, model.Forecast(5, 3), loaded.Forecast(5, 3))
+		})
+	}
+}
+
+func TestFit_NotEnoughData(t *testing.T) {
+	for _, name := range Models() {
+		t.Run(name, func(t *testing.T) {
+			_, err := Fit(name, nil, nil)
+
+			assert.Equal(t, ErrNotEnoughData, err)
+		})
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := Load("xg", json.RawMessage(`{}`))
+	assert.Equal(t, ErrUnknownModel, err)
+
+	_, err = Load(ModelPoisson, json.RawMessage(`{"home_avg":0,"away_avg":1}`))
+	assert.True(t, errors.Is(err, ErrInvalidParameters))
+
+	_, err = Load(ModelElo, json.RawMessage(`[]`))
+	assert.True(t, errors.Is(err, ErrInvalidParameters))
+
+	_, err = Load(ModelEnsemble, json.RawMessage(`{"members":[]}`))
+	assert.True(t, errors.Is(err, ErrInvalidParameters))
+}
@@ -0,0 +1,69 @@
+package predictor
+
+import "math"
+
+// Results of a match, the probabilities scored by LogLoss, Brier and RPS are given in this order
+const (
+	HomeWin = iota
+	Draw
+	AwayWin
+)
+
+// minProbability bounds the probability given to the result so LogLoss stays finite
+const minProbability = 1e-15
+
+// Result returns HomeWin, Draw or AwayWin for the final score
+func Result(homeGoals int64, awayGoals int64) int {
+	switch {
+	case homeGoals > awayGoals:
+		return HomeWin
+	case homeGoals == awayGoals:
+		return Draw
+	default:
+		return AwayWin
+	}
+}
+
+// LogLoss returns the negative natural logarithm of the probability given to the result, lower is better
+func LogLoss(probabilities []float64, result int) float64 {
+	return -math.Log(math.Max(probabilities[result], minProbability))
+}
+
+// Brier returns the sum of the squared differences between the probabilities and the result, from 0 for a
+// certain correct forecast to 2 for a certain wrong one
+func Brier(probabilities []float64, result int) float64 {
+	var score float64
+	for i, p := range probabilities {
+		observed := 0.0
+		if i == result {
+			observed = 1
+		}
+		score += (p - observed) * (p - observed)
+	}
+	return score
+}
+
+// RPS returns the ranked probability score, which compares the cumulative probabilities with the result so a
+// draw forecast is less wrong than an away win when the home team wins. It ranges from 0 to 1
+func RPS(probabilities []float64, result int) float64 {
+	var score, forecast, observed float64
+	for i := 0; i < len(probabilities)-1; i++ {
+		forecast += probabilities[i]
+		if i == result {
+			observed = 1
+		}
+		score += (forecast - observed) * (forecast - observed)
+	}
+	return score / float64(len(probabilities)-1)
+}
+
+// MostLikely returns the result with the highest probability, the first one on a tie
+func MostLikely(probabilities []float64) int {
+	best := 0
+	for i, p := range probabilities {
+		if p > probabilities[best] {
+			best = i
+		}
+	}
+	return best
+}
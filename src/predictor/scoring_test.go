@@ -0,0 +1,47 @@
+package predictor
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult(t *testing.T) {
+	assert.Equal(t, HomeWin, Result(2, 1))
+	assert.Equal(t, Draw, Result(1, 1))
+	assert.Equal(t, AwayWin, Result(0, 3))
+}
+
+func TestScoring(t *testing.T) {
+	probabilities := []float64{0.5, 0.3, 0.2}
+	testCases := []struct {
+		title         string
+		result        int
+		expectedLog   float64
+		expectedBrier float64
+		expectedRPS   float64
+	}{
+		{title: "home win", result: HomeWin, expectedLog: 0.693147, expectedBrier: 0.38, expectedRPS: 0.145},
+		// The cumulative probabilities miss the draw by the same amount on both sides
+		{title: "draw", result: Draw, expectedLog: 1.203973, expectedBrier: 0.78, expectedRPS: 0.145},
+		{title: "away win", result: AwayWin, expectedLog: 1.609438, expectedBrier: 0.98, expectedRPS: 0.445},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			assert.InDelta(t, testCase.expectedLog, LogLoss(probabilities, testCase.result), 1e-6)
+			assert.InDelta(t, testCase.expectedBrier, Brier(probabilities, testCase.result), 1e-9)
+			assert.InDelta(t, testCase.expectedRPS, RPS(probabilities, testCase.result), 1e-9)
+		})
+	}
+}
+
+func TestLogLoss_ZeroProbability(t *testing.T) {
+	assert.False(t, math.IsInf(LogLoss([]float64{1, 0, 0}, AwayWin), 1))
+}
+
+func TestMostLikely(t *testing.T) {
+	assert.Equal(t, HomeWin, MostLikely([]float64{0.5, 0.3, 0.2}))
+	assert.Equal(t, AwayWin, MostLikely([]float64{0.2, 0.3, 0.5}))
+	assert.Equal(t, HomeWin, MostLikely([]float64{0.4, 0.2, 0.4}))
+}
@@ -0,0 +1,251 @@
+package predictor
+
+import (
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// simulationBatch is the number of runs simulated with the same random source, the batches are seeded in order
+// so the results only depend on the seed, whatever the number of goroutines
+const simulationBatch = 500
+
+// SeasonSimulation is the outcome of the simulated seasons, one entry per team of the season
+type SeasonSimulation struct {
+	Runs  int
+	Teams []TeamSimulation
+}
+
+// TeamSimulation holds the current standing of a team and the probabilities of its final one. Positions[0] is
+// the probability of finishing first, i.e. winning the title
+type TeamSimulation struct {
+	TeamID         int64
+	Played         int64
+	Points         int64
+	GoalDifference int64
+	ExpectedPoints float64
+	Positions      []float64
+}
+
+// simulatedFixture is a remaining fixture with the cumulative probabilities of the scores of its forecast
+type simulatedFixture struct {
+	home, away  int
+	cumulative  []float64
+	homeGoals   []int64
+	awayGoals   []int64
+	probability float64
+}
+
+// standing is the table of a season, indexed like the teams
+type standing struct {
+	points, scored, conceded, played []int64
+}
+
+func newStanding(teams int) *standing {
+	return &standing{
+		points:   make([]int64, teams),
+		scored:   make([]int64, teams),
+		conceded: make([]int64, teams),
+		played:   make([]int64, teams),
+	}
+}
+
+func (s *standing) add(home int, away int, homeGoals int64, awayGoals int64) {
+	s.played[home]++
+	s.played[away]++
+	s.scored[home] += homeGoals
+	s.conceded[home] += awayGoals
+	s.scored[away] += awayGoals
+	s.conceded[away] += homeGoals
+	switch {
+	case homeGoals > awayGoals:
+		s.points[home] += 3
+	case homeGoals < awayGoals:
+		s.points[away] += 3
+	default:
+		s.points[home]++
+		s.points[away]++
+	}
+}
+
+func (s *standing) copyTo(dst *standing) {
+	copy(dst.points, s.points)
+	copy(dst.scored, s.scored)
+	copy(dst.conceded, s.conceded)
+	copy(dst.played, s.played)
+}
+
+// rank orders the teams by points, goal difference and goals scored, the teams still level are in the order of
+// tiebreak, a random one since the other tiebreakers of the leagues are unknown
+func (s *standing) rank(tiebreak []int) []int {
+	order := append([]int(nil), tiebreak...)
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if s.points[a] != s.points[b] {
+			return s.points[a] > s.points[b]
+		}
+		if s.scored[a]-s.conceded[a] != s.scored[b]-s.conceded[b] {
+			return s.scored[a]-s.conceded[a] > s.scored[b]-s.conceded[b]
+		}
+		return s.scored[a] > s.scored[b]
+	})
+	return order
+}
+
+// batchResult counts the final positions and sums the points won by the teams over a batch of runs
+type batchResult struct {
+	positions [][]int64
+	points    []int64
+}
+
+// SimulateSeason plays the remaining fixtures of a season runs times from the table of the played ones, drawing
+// every score from the forecast of the model, and returns the distribution of the final positions of the teams.
+// The goals of the remaining fixtures are ignored. The runs are spread over the CPUs and the same seed gives the
+// same result
+func SimulateSeason(model Model, played []Match, remaining []Match, runs int, seed int64) *SeasonSimulation {
+	index := make(map[int64]int)
+	var teams []int64
+	for _, matches := range [][]Match{played, remaining} {
+		for _, m := range matches {
+			for _, id := range []int64{m.HomeTeamID, m.AwayTeamID} {
+				if _, ok := index[id]; !ok {
+					index[id] = 0
+					teams = append(teams, id)
+				}
+			}
+		}
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i] < teams[j] })
+	for i, id := range teams {
+		index[id] = i
+	}
+
+	current := newStanding(len(teams))
+	for _, m := range played {
+		current.add(index[m.HomeTeamID], index[m.AwayTeamID], m.HomeGoals, m.AwayGoals)
+	}
+	fixtures := make([]simulatedFixture, 0, len(remaining))
+	for _, m := range remaining {
+		fixtures = append(fixtures, newSimulatedFixture(model.Forecast(m.HomeTeamID, m.AwayTeamID), index[m.HomeTeamID], index[m.AwayTeamID]))
+	}
+
+	results := make([]batchResult, batchCount(runs))
+	runBatches(runs, seed, func(b int, size int, seed int64) {
+		results[b] = simulateBatch(current, fixtures, size, seed)
+	})
+
+	simulation := &SeasonSimulation{Runs: runs, Teams: make([]TeamSimulation, len(teams))}
+	for i, id := range teams {
+		positions := make([]int64, len(teams))
+		var points int64
+		for _, result := range results {
+			for p, count := range result.positions[i] {
+				positions[p] += count
+			}
+			points += result.points[i]
+		}
+		team := TeamSimulation{
+			TeamID:         id,
+			Played:         current.played[i],
+			Points:         current.points[i],
+			GoalDifference: current.scored[i] - current.conceded[i],
+			ExpectedPoints: float64(current.points[i]),
+			Positions:      make([]float64, len(teams)),
+		}
+		if runs > 0 {
+			team.ExpectedPoints += float64(points) / float64(runs)
+			for p, count := range positions {
+				team.Positions[p] = float64(count) / float64(runs)
+			}
+		}
+		simulation.Teams[i] = team
+	}
+	return simulation
+}
+
+func batchCount(runs int) int {
+	return (runs + simulationBatch - 1) / simulationBatch
+}
+
+// runBatches splits the runs in batches and simulates them over the CPUs. simulate is called once per batch
+// with the index of the batch, its number of runs and its seed, seed+index
+func runBatches(runs int, seed int64, simulate func(b int, size int, seed int64)) {
+	batches := batchCount(runs)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	workers := runtime.NumCPU()
+	if workers > batches {
+		workers = batches
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range jobs {
+				size := simulationBatch
+				if b == batches-1 {
+					size = runs - b*simulationBatch
+				}
+				simulate(b, size, seed+int64(b))
+			}
+		}()
+	}
+	for b := 0; b < batches; b++ {
+		jobs <- b
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func newSimulatedFixture(forecast *Forecast, home int, away int) simulatedFixture {
+	f := simulatedFixture{home: home, away: away}
+	for h := range forecast.Matrix {
+		for a, p := range forecast.Matrix[h] {
+			if p <= 0 {
+				continue
+			}
+			f.probability += p
+			f.cumulative = append(f.cumulative, f.probability)
+			f.homeGoals = append(f.homeGoals, int64(h))
+			f.awayGoals = append(f.awayGoals, int64(a))
+		}
+	}
+	return f
+}
+
+// sample draws a score, the probabilities are normalised by the scores of the matrix
+func (f *simulatedFixture) sample(random *rand.Rand) (int64, int64) {
+	if len(f.cumulative) == 0 {
+		return 0, 0
+	}
+	i := sort.SearchFloat64s(f.cumulative, random.Float64()*f.probability)
+	if i == len(f.cumulative) {
+		i--
+	}
+	return f.homeGoals[i], f.awayGoals[i]
+}
+
+func simulateBatch(current *standing, fixtures []simulatedFixture, runs int, seed int64) batchResult {
+	teams := len(current.points)
+	random := rand.New(rand.NewSource(seed))
+	result := batchResult{positions: make([][]int64, teams), points: make([]int64, teams)}
+	for i := range result.positions {
+		result.positions[i] = make([]int64, teams)
+	}
+	table := newStanding(teams)
+	for run := 0; run < runs; run++ {
+		current.copyTo(table)
+		for i := range fixtures {
+			homeGoals, awayGoals := fixtures[i].sample(random)
+			table.add(fixtures[i].home, fixtures[i].away, homeGoals, awayGoals)
+		}
+		for position, team := range table.rank(random.Perm(teams)) {
+			result.positions[team][position]++
+		}
+		for i := range table.points {
+			result.points[i] += table.points[i] - current.points[i]
+		}
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package predictor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRatings makes team 1 much stronger than the others
+var testRatings = &DixonColes{HomeAdvantage: 0.2, Rho: -0.05, Teams: map[int64]*DixonColesRating{
+	1: {Attack: 0.8, Defence: -0.6},
+	2: {Attack: 0, Defence: 0.1},
+	3: {Attack: -0.2, Defence: 0.2},
+	4: {Attack: -0.6, Defence: 0.3},
+}}
+
+// roundRobin returns the home and away fixtures between the teams
+func roundRobin(teams ...int64) []Match {
+	var matches []Match
+	for _, home := range teams {
+		for _, away := range teams {
+			if home != away {
+				matches = append(matches, Match{HomeTeamID: home, AwayTeamID: away})
+			}
+		}
+	}
+	return matches
+}
+
+func TestSimulateSeason(t *testing.T) {
+	fixtures := roundRobin(1, 2, 3, 4)
+	// Team 4 won the first two fixtures
+	played := []Match{
+		{HomeTeamID: 4, AwayTeamID: 1, HomeGoals: 2, AwayGoals: 0},
+		{HomeTeamID: 4, AwayTeamID: 2, HomeGoals: 1, AwayGoals: 0},
+	}
+	var remaining []Match
+	for _, m := range fixtures {
+		if m.HomeTeamID != 4 || m.AwayTeamID > 2 {
+			remaining = append(remaining, m)
+		}
+	}
+
+	res := SimulateSeason(testRatings, played, remaining, 2000, 42)
+
+	assert.Equal(t, 2000, res.Runs)
+	assert.Len(t, res.Teams, 4)
+	positions := make([]float64, 4)
+	for i, team := range res.Teams {
+		assert.Equal(t, int64(i+1), team.TeamID)
+		var total float64
+		for p, probability := range team.Positions {
+			total += probability
+			positions[p] += probability
+		}
+		assert.InDelta(t, 1, total, 1e-9)
+	}
+	for _, total := range positions {
+		assert.InDelta(t, 1, total, 1e-9)
+	}
+	assert.Equal(t, int64(6), res.Teams[3].Points)
+	assert.Equal(t, int64(3), res.Teams[3].GoalDifference)
+	assert.Equal(t, int64(2), res.Teams[3].Played)
+	assert.Greater(t, res.Teams[3].ExpectedPoints, 6.0)
+	assert.Greater(t, res.Teams[0].Positions[0], 0.5)
+	assert.Greater(t, res.Teams[3].Positions[3], res.Teams[0].Positions[3])
+	assert.Greater(t, res.Teams[0].ExpectedPoints, res.Teams[1].ExpectedPoints)
+
+	// The same seed gives the same result, another seed another one
+	assert.Equal(t, res, SimulateSeason(testRatings, played, remaining, 2000, 42))
+	assert.NotEqual(t, res, SimulateSeason(testRatings, played, remaining, 2000, 43))
+}
+
+func TestSimulateSeason_Finished(t *testing.T) {
+	played := []Match{
+		{HomeTeamID: 1, AwayTeamID: 2, HomeGoals: 1, AwayGoals: 1},
+		{HomeTeamID: 2, AwayTeamID: 3, HomeGoals: 2, AwayGoals: 0},
+		{HomeTeamID: 3, AwayTeamID: 1, HomeGoals: 0, AwayGoals: 1},
+	}
+
+	res := SimulateSeason(testRatings, played, nil, 100, 1)
+
+	// Teams 1 and 2 are level on points and goal difference, team 2 scored more
+	assert.Equal(t, []float64{0, 1, 0}, res.Teams[0].Positions)
+	assert.Equal(t, []float64{1, 0, 0}, res.Teams[1].Positions)
+	assert.Equal(t, []float64{0, 0, 1}, res.Teams[2].Positions)
+	assert.Equal(t, 4.0, res.Teams[0].ExpectedPoints)
+}
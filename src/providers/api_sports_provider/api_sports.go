@@ -1,21 +1,49 @@
 package api_sports_provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/development-raul/footy-predictor/src/clients/restclient"
+	"github.com/development-raul/footy-predictor/src/config"
 	"github.com/development-raul/footy-predictor/src/domains/api_sports"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/tracing"
 	"github.com/development-raul/footy-predictor/src/zlog"
 	"io/ioutil"
 	"net/http"
-	"os"
+	"net/url"
+	"strconv"
+	"time"
 )
 
-func makeRequest(url string, action string) ([]byte, *api_sports.ErrorResponse) {
+// Client calls API Sports with the base url and credentials of its settings
+type Client struct {
+	settings config.APISportsConfig
+}
+
+// New returns the client of the API Sports settings
+func New(cfg config.APISportsConfig) *Client {
+	return &Client{settings: cfg}
+}
+
+// Headers used by API Sports to report the remaining requests of the daily and per minute quotas
+const (
+	headerQuotaDayRemaining    = "x-ratelimit-requests-remaining"
+	headerQuotaMinuteRemaining = "X-RateLimit-Remaining"
+)
+
+func (c *Client) makeRequest(ctx context.Context, url string, action string) ([]byte, *api_sports.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "APISportsProvider."+action)
+	defer span.End()
+
 	// Make API Sports request
-	res, err := restclient.Get(url, setHeaders())
+	start := time.Now()
+	res, err := restclient.Get(ctx, url, c.headers())
 	if err != nil {
-		zlog.Logger.Error(fmt.Sprintf("APISportsProvider %s requestData: ", action), err)
+		metrics.ObserveAPISports(action, "error", time.Since(start))
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("APISportsProvider requestData", "action", action, "error", err)
 		return nil, &api_sports.ErrorResponse{
 			Message:    "Error making API request",
 			StatusCode: http.StatusInternalServerError,
@@ -24,21 +52,23 @@ func makeRequest(url string, action string) ([]byte, *api_sports.ErrorResponse)
 	// Read the response
 	bytes, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		zlog.Logger.Error(fmt.Sprintf("APISportsProvider %s ReadAll: ", action), err)
+		zlog.FromContext(ctx).Errorw("APISportsProvider ReadAll", "action", action, "error", err)
 		return nil, &api_sports.ErrorResponse{
 			Message:    "Error reading API response",
 			StatusCode: http.StatusInternalServerError,
 		}
 	}
 	defer res.Body.Close()
+	metrics.ObserveAPISports(action, strconv.Itoa(res.StatusCode), time.Since(start))
+	recordQuota(res.Header)
 
 	// Handle errors from API Sports
 	if res.StatusCode != http.StatusOK {
-		zlog.Logger.Warn("API Sports non 200 response: ", string(bytes))
+		zlog.FromContext(ctx).Warnw("API Sports non 200 response", "status", res.StatusCode, "body", string(bytes))
 		// Attempt to unmarshall the response into the API Sports ErrorResponse struct
 		var errResponse api_sports.ErrorResponse
 		if err := json.Unmarshal(bytes, &errResponse); err != nil {
-			zlog.Logger.Error(fmt.Sprintf("APISportsProvider %s Unmarshal: ", action), err)
+			zlog.FromContext(ctx).Errorw("APISportsProvider Unmarshal", "action", action, "error", err)
 			return nil, &api_sports.ErrorResponse{
 				Message:    "Error decoding API response",
 				StatusCode: http.StatusInternalServerError,
@@ -49,57 +79,276 @@ func makeRequest(url string, action string) ([]byte, *api_sports.ErrorResponse)
 		return nil, &errResponse
 	}
 
-	zlog.Logger.Info("API Sports 200 response: ", string(bytes))
+	zlog.FromContext(ctx).Debugw("API Sports 200 response", "body", string(bytes))
 
 	return bytes, nil
 }
 
-func GetCountries() ([]api_sports.CountriesResponse, *api_sports.ErrorResponse) {
-	url := fmt.Sprintf("%s/countries", os.Getenv("AS_BASE_URL"))
+func (c *Client) GetCountries(ctx context.Context) ([]api_sports.CountriesResponse, *api_sports.ErrorResponse) {
+	url := fmt.Sprintf("%s/countries", c.settings.BaseURL)
 	// Make the request
-	bytes, err := makeRequest(url, "GetCountries")
+	bytes, err := c.makeRequest(ctx, url, "GetCountries")
 	if err != nil {
 		return nil, err
 	}
 
+	// Handle success response from API Sports
 	var result api_sports.GetCountriesOutput
+	if err := decode(ctx, "GetCountries", bytes, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Response, nil
+}
+
+func (c *Client) GetSeasons(ctx context.Context) ([]int64, *api_sports.ErrorResponse) {
+	url := fmt.Sprintf("%s/leagues/seasons", c.settings.BaseURL)
+	// Make the request
+	bytes, err := c.makeRequest(ctx, url, "GetSeasons")
+	if err != nil {
+		return nil, err
+	}
 	// Handle success response from API Sports
-	if err := json.Unmarshal(bytes, &result); err != nil {
-		zlog.Logger.Error("APISportsProvider GetCountries Unmarshal: ", err)
-		return nil, &api_sports.ErrorResponse{
-			Message:    "Error decoding API response",
-			StatusCode: http.StatusInternalServerError,
+	var result api_sports.GetSeasonsOutput
+	if err := decode(ctx, "GetSeasons", bytes, &result); err != nil {
+		return nil, err
+	}
+	return result.Response, nil
+}
+
+func (c *Client) GetLeagues(ctx context.Context) ([]api_sports.LeaguesResponse, *api_sports.ErrorResponse) {
+	url := fmt.Sprintf("%s/leagues", c.settings.BaseURL)
+	// Make the request
+	bytes, err := c.makeRequest(ctx, url, "GetLeagues")
+	if err != nil {
+		return nil, err
+	}
+	// Handle success response from API Sports
+	var result api_sports.GetLeaguesOutput
+	if err := decode(ctx, "GetLeagues", bytes, &result); err != nil {
+		return nil, err
+	}
+	return result.Response, nil
+}
+
+func (c *Client) GetFixtures(ctx context.Context, leagueID int64, season int64) ([]api_sports.FixturesResponse, *api_sports.ErrorResponse) {
+	url := fmt.Sprintf("%s/fixtures?league=%d&season=%d", c.settings.BaseURL, leagueID, season)
+	// Make the request
+	bytes, err := c.makeRequest(ctx, url, "GetFixtures")
+	if err != nil {
+		return nil, err
+	}
+	// Handle success response from API Sports
+	var result api_sports.GetFixturesOutput
+	if err := decode(ctx, "GetFixtures", bytes, &result); err != nil {
+		return nil, err
+	}
+	return result.Response, nil
+}
+
+// GetOdds returns the pre-match odds of a fixture or of a league season. The odds endpoint is paged, every page
+// is requested until the last one
+func (c *Client) GetOdds(ctx context.Context, req api_sports.OddsRequest) ([]api_sports.OddsResponse, *api_sports.ErrorResponse) {
+	params := url.Values{}
+	if req.Fixture != 0 {
+		params.Set("fixture", strconv.FormatInt(req.Fixture, 10))
+	}
+	if req.League != 0 {
+		params.Set("league", strconv.FormatInt(req.League, 10))
+	}
+	if req.Season != 0 {
+		params.Set("season", strconv.FormatInt(req.Season, 10))
+	}
+	if req.Bookmaker != 0 {
+		params.Set("bookmaker", strconv.FormatInt(req.Bookmaker, 10))
+	}
+
+	var odds []api_sports.OddsResponse
+	for page := int64(1); ; page++ {
+		params.Set("page", strconv.FormatInt(page, 10))
+		// Make the request
+		bytes, err := c.makeRequest(ctx, fmt.Sprintf("%s/odds?%s", c.settings.BaseURL, params.Encode()), "GetOdds")
+		if err != nil {
+			return nil, err
+		}
+		// Handle success response from API Sports
+		var result api_sports.GetOddsOutput
+		if err := decode(ctx, "GetOdds", bytes, &result); err != nil {
+			return nil, err
+		}
+		odds = append(odds, result.Response...)
+		if result.Paging.Current >= result.Paging.Total {
+			return odds, nil
+		}
+	}
+}
+
+// GetSquad returns the current squad of a team
+func (c *Client) GetSquad(ctx context.Context, teamID int64) ([]api_sports.SquadsResponse, *api_sports.ErrorResponse) {
+	url := fmt.Sprintf("%s/players/squads?team=%d", c.settings.BaseURL, teamID)
+	// Make the request
+	bytes, err := c.makeRequest(ctx, url, "GetSquad")
+	if err != nil {
+		return nil, err
+	}
+	// Handle success response from API Sports
+	var result api_sports.GetSquadsOutput
+	if err := decode(ctx, "GetSquad", bytes, &result); err != nil {
+		return nil, err
+	}
+	return result.Response, nil
+}
+
+// GetPlayers returns the players of a league season with their statistics. The players endpoint is paged, every
+// page is requested until the last one
+func (c *Client) GetPlayers(ctx context.Context, leagueID int64, season int64) ([]api_sports.PlayersResponse, *api_sports.ErrorResponse) {
+	var players []api_sports.PlayersResponse
+	for page := int64(1); ; page++ {
+		// Make the request
+		bytes, err := c.makeRequest(ctx, fmt.Sprintf("%s/players?league=%d&season=%d&page=%d", c.settings.BaseURL, leagueID, season, page), "GetPlayers")
+		if err != nil {
+			return nil, err
+		}
+		// Handle success response from API Sports
+		var result api_sports.GetPlayersOutput
+		if err := decode(ctx, "GetPlayers", bytes, &result); err != nil {
+			return nil, err
+		}
+		players = append(players, result.Response...)
+		if result.Paging.Current >= result.Paging.Total {
+			return players, nil
 		}
 	}
+}
 
+// GetFixtureEvents returns the goals, cards, substitutions and VAR decisions of a fixture in the order they happened
+func (c *Client) GetFixtureEvents(ctx context.Context, fixtureID int64) ([]api_sports.FixtureEvent, *api_sports.ErrorResponse) {
+	// Make the request
+	bytes, err := c.makeRequest(ctx, fmt.Sprintf("%s/fixtures/events?fixture=%d", c.settings.BaseURL, fixtureID), "GetFixtureEvents")
+	if err != nil {
+		return nil, err
+	}
+	// Handle success response from API Sports
+	var result api_sports.GetFixtureEventsOutput
+	if err := decode(ctx, "GetFixtureEvents", bytes, &result); err != nil {
+		return nil, err
+	}
 	return result.Response, nil
 }
 
-func GetSeasons() ([]int64, *api_sports.ErrorResponse) {
-	url := fmt.Sprintf("%s/leagues/seasons", os.Getenv("AS_BASE_URL"))
+// GetFixtureLineups returns the lineups of the two teams of a fixture
+func (c *Client) GetFixtureLineups(ctx context.Context, fixtureID int64) ([]api_sports.FixtureLineup, *api_sports.ErrorResponse) {
 	// Make the request
-	bytes, err := makeRequest(url, "GetSeasons")
+	bytes, err := c.makeRequest(ctx, fmt.Sprintf("%s/fixtures/lineups?fixture=%d", c.settings.BaseURL, fixtureID), "GetFixtureLineups")
 	if err != nil {
 		return nil, err
 	}
 	// Handle success response from API Sports
-	var result api_sports.GetSeasonsOutput
-	if err := json.Unmarshal(bytes, &result); err != nil {
-		zlog.Logger.Error("APISportsProvider GetSeasons Unmarshal: ", err)
-		return nil, &api_sports.ErrorResponse{
+	var result api_sports.GetFixtureLineupsOutput
+	if err := decode(ctx, "GetFixtureLineups", bytes, &result); err != nil {
+		return nil, err
+	}
+	return result.Response, nil
+}
+
+// GetFixtureStatistics returns the match statistics of the two teams of a fixture
+func (c *Client) GetFixtureStatistics(ctx context.Context, fixtureID int64) ([]api_sports.FixtureStatistics, *api_sports.ErrorResponse) {
+	// Make the request
+	bytes, err := c.makeRequest(ctx, fmt.Sprintf("%s/fixtures/statistics?fixture=%d", c.settings.BaseURL, fixtureID), "GetFixtureStatistics")
+	if err != nil {
+		return nil, err
+	}
+	// Handle success response from API Sports
+	var result api_sports.GetFixtureStatisticsOutput
+	if err := decode(ctx, "GetFixtureStatistics", bytes, &result); err != nil {
+		return nil, err
+	}
+	return result.Response, nil
+}
+
+// GetInjuries returns the players missing a fixture or the fixtures of a league season. The injuries endpoint is
+// paged, every page is requested until the last one
+func (c *Client) GetInjuries(ctx context.Context, req api_sports.InjuriesRequest) ([]api_sports.InjuriesResponse, *api_sports.ErrorResponse) {
+	params := url.Values{}
+	if req.Fixture != 0 {
+		params.Set("fixture", strconv.FormatInt(req.Fixture, 10))
+	}
+	if req.League != 0 {
+		params.Set("league", strconv.FormatInt(req.League, 10))
+	}
+	if req.Season != 0 {
+		params.Set("season", strconv.FormatInt(req.Season, 10))
+	}
+
+	var injuries []api_sports.InjuriesResponse
+	for page := int64(1); ; page++ {
+		params.Set("page", strconv.FormatInt(page, 10))
+		// Make the request
+		bytes, err := c.makeRequest(ctx, fmt.Sprintf("%s/injuries?%s", c.settings.BaseURL, params.Encode()), "GetInjuries")
+		if err != nil {
+			return nil, err
+		}
+		// Handle success response from API Sports
+		var result api_sports.GetInjuriesOutput
+		if err := decode(ctx, "GetInjuries", bytes, &result); err != nil {
+			return nil, err
+		}
+		injuries = append(injuries, result.Response...)
+		if result.Paging.Current >= result.Paging.Total {
+			return injuries, nil
+		}
+	}
+}
+
+// GetSidelined returns the periods a player was or is out for, injuries and suspensions alike
+func (c *Client) GetSidelined(ctx context.Context, playerID int64) ([]api_sports.Sideline, *api_sports.ErrorResponse) {
+	// Make the request
+	bytes, err := c.makeRequest(ctx, fmt.Sprintf("%s/sidelined?player=%d", c.settings.BaseURL, playerID), "GetSidelined")
+	if err != nil {
+		return nil, err
+	}
+	// Handle success response from API Sports
+	var result api_sports.GetSidelinedOutput
+	if err := decode(ctx, "GetSidelined", bytes, &result); err != nil {
+		return nil, err
+	}
+	return result.Response, nil
+}
+
+// decode unmarshals a successful response, it has its own span so the decoding time is visible in the traces
+func decode(ctx context.Context, action string, bytes []byte, result interface{}) *api_sports.ErrorResponse {
+	_, span := tracing.Start(ctx, "APISportsProvider."+action+" decode")
+	defer span.End()
+
+	if err := json.Unmarshal(bytes, result); err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("APISportsProvider Unmarshal", "action", action, "error", err)
+		return &api_sports.ErrorResponse{
 			Message:    "Error decoding API response",
 			StatusCode: http.StatusInternalServerError,
 		}
 	}
-	return result.Response, nil
+	return nil
+}
+
+func recordQuota(headers http.Header) {
+	quotas := map[string]string{
+		"day":    headerQuotaDayRemaining,
+		"minute": headerQuotaMinuteRemaining,
+	}
+	for window, header := range quotas {
+		if remaining, err := strconv.ParseFloat(headers.Get(header), 64); err == nil {
+			metrics.SetAPISportsQuota(window, remaining)
+		}
+	}
 }
 
-func setHeaders() http.Header {
+func (c *Client) headers() http.Header {
 	headers := http.Header{}
 	headers.Set("Content-type", "application/json")
 	headers.Set("Accept", "application/json")
-	headers.Set("x-rapidapi-key", os.Getenv("AS_KEY"))
-	headers.Set("x-rapidapi-host", os.Getenv("AS_HOST"))
+	headers.Set("x-rapidapi-key", c.settings.Key)
+	headers.Set("x-rapidapi-host", c.settings.Host)
 
 	return headers
 }
@@ -1,13 +1,15 @@
 package api_sports_provider
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/development-raul/footy-predictor/src/clients/restclient"
+	"github.com/development-raul/footy-predictor/src/config"
 	"github.com/development-raul/footy-predictor/src/domains/api_sports"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"net/http"
-	"os"
 	"strings"
 	"testing"
 )
@@ -23,7 +25,6 @@ func (i *FakeReaderCloser) Close() error {
 }
 
 func TestAPISportsProvider_GetCountries(t *testing.T) {
-	os.Setenv("AS_BASE_URL", "https://test.com")
 	testCases := []struct {
 		title       string
 		apiMock     restclient.Mock
@@ -142,9 +143,9 @@ func TestAPISportsProvider_GetCountries(t *testing.T) {
 				restclient.StartMockups()
 				restclient.AddMockup(testCase.apiMock)
 			}
-			os.Setenv("AS_BASE_URL", testCase.baseURL)
+			client := New(config.APISportsConfig{BaseURL: testCase.baseURL})
 
-			res, err := GetCountries()
+			res, err := client.GetCountries(context.Background())
 			assert.Equal(t, testCase.expectedRes, res)
 			assert.Equal(t, testCase.expectedErr, err)
 
@@ -154,7 +155,6 @@ func TestAPISportsProvider_GetCountries(t *testing.T) {
 }
 
 func TestAPISportsProvider_Seasons(t *testing.T) {
-	os.Setenv("AS_BASE_URL", "https://test.com")
 	testCases := []struct {
 		title       string
 		apiMock     restclient.Mock
@@ -267,9 +267,818 @@ func TestAPISportsProvider_Seasons(t *testing.T) {
 				restclient.StartMockups()
 				restclient.AddMockup(testCase.apiMock)
 			}
-			os.Setenv("AS_BASE_URL", testCase.baseURL)
+			client := New(config.APISportsConfig{BaseURL: testCase.baseURL})
 
-			res, err := GetSeasons()
+			res, err := client.GetSeasons(context.Background())
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+
+			restclient.FlushMockups()
+		})
+	}
+}
+
+func TestAPISportsProvider_GetLeagues(t *testing.T) {
+	countryCode := "GB"
+	testCases := []struct {
+		title       string
+		apiMock     restclient.Mock
+		withMock    bool
+		baseURL     string
+		expectedRes []api_sports.LeaguesResponse
+		expectedErr *api_sports.ErrorResponse
+	}{
+		{
+			title:       "error restclient.Get",
+			baseURL:     "invalid-url",
+			expectedRes: nil,
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error making API request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "error 200 json.Unmarshal",
+			apiMock: restclient.Mock{
+				Url:        "https://test.com/leagues",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader(`{"response does not match ErrorResponse struct"}`)),
+				},
+			},
+			withMock:    true,
+			baseURL:     "https://test.com",
+			expectedRes: nil,
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error decoding API response",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "success",
+			apiMock: restclient.Mock{
+				Url:        "https://test.com/leagues",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: 200,
+					Body: io.NopCloser(strings.NewReader(`{"get":"leagues","parameters":[],"errors":[],"results":1,"paging":{"current":1,"total":1},"response":[` +
+						`{"league":{"id":39,"name":"Premier League","type":"League","logo":"39.png"},` +
+						`"country":{"name":"England","code":"GB","flag":null},` +
+						`"seasons":[{"year":2021,"start":"2021-08-13","end":"2022-05-22","current":true}]}]}`)),
+				},
+			},
+			withMock: true,
+			baseURL:  "https://test.com",
+			expectedRes: []api_sports.LeaguesResponse{
+				{
+					League:  api_sports.LeagueDetails{ID: 39, Name: "Premier League", Type: "League", Logo: "39.png"},
+					Country: api_sports.LeagueCountry{Name: "England", Code: &countryCode},
+					Seasons: []api_sports.LeagueSeason{
+						{Year: 2021, Start: "2021-08-13", End: "2022-05-22", Current: true},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			if testCase.withMock {
+				restclient.StartMockups()
+				restclient.AddMockup(testCase.apiMock)
+			}
+			client := New(config.APISportsConfig{BaseURL: testCase.baseURL})
+
+			res, err := client.GetLeagues(context.Background())
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+
+			restclient.FlushMockups()
+		})
+	}
+}
+
+func TestAPISportsProvider_GetFixtures(t *testing.T) {
+	homeGoals := int64(2)
+	awayGoals := int64(1)
+	homeWinner := true
+	awayWinner := false
+	testCases := []struct {
+		title       string
+		apiMock     restclient.Mock
+		withMock    bool
+		baseURL     string
+		expectedRes []api_sports.FixturesResponse
+		expectedErr *api_sports.ErrorResponse
+	}{
+		{
+			title:       "error restclient.Get",
+			baseURL:     "invalid-url",
+			expectedRes: nil,
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error making API request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "error non 200 response",
+			apiMock: restclient.Mock{
+				Url:        "https://test.com/fixtures?league=39&season=2021",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: 499,
+					Body:       io.NopCloser(strings.NewReader(`{"message": "Something went wrong while fetching details. Try again later."}`)),
+				},
+			},
+			withMock:    true,
+			baseURL:     "https://test.com",
+			expectedRes: nil,
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Something went wrong while fetching details. Try again later.",
+				StatusCode: 499,
+			},
+		},
+		{
+			title: "error 200 json.Unmarshal",
+			apiMock: restclient.Mock{
+				Url:        "https://test.com/fixtures?league=39&season=2021",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader(`{"response does not match ErrorResponse struct"}`)),
+				},
+			},
+			withMock:    true,
+			baseURL:     "https://test.com",
+			expectedRes: nil,
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error decoding API response",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "success",
+			apiMock: restclient.Mock{
+				Url:        "https://test.com/fixtures?league=39&season=2021",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: 200,
+					Body: io.NopCloser(strings.NewReader(`{"get":"fixtures","parameters":{"league":"39","season":"2021"},"errors":[],"results":1,"paging":{"current":1,"total":1},"response":[` +
+						`{"fixture":{"id":10,"referee":"M. Oliver","timezone":"UTC","date":"2022-01-15T15:00:00+00:00","timestamp":1642258800,"status":{"long":"Match Finished","short":"FT","elapsed":90}},` +
+						`"league":{"id":39,"name":"Premier League","country":"England","season":2021,"round":"Regular Season - 21"},` +
+						`"teams":{"home":{"id":1,"name":"Home","logo":"home.png","winner":true},"away":{"id":2,"name":"Away","logo":"away.png","winner":false}},` +
+						`"goals":{"home":2,"away":1}}]}`)),
+				},
+			},
+			withMock: true,
+			baseURL:  "https://test.com",
+			expectedRes: []api_sports.FixturesResponse{
+				{
+					Fixture: api_sports.FixtureDetails{
+						ID:        10,
+						Referee:   "M. Oliver",
+						Timezone:  "UTC",
+						Date:      "2022-01-15T15:00:00+00:00",
+						Timestamp: 1642258800,
+						Status: api_sports.FixtureStatus{
+							Long:    "Match Finished",
+							Short:   "FT",
+							Elapsed: func() *int64 { e := int64(90); return &e }(),
+						},
+					},
+					League: api_sports.FixtureLeague{
+						ID:      39,
+						Name:    "Premier League",
+						Country: "England",
+						Season:  2021,
+						Round:   "Regular Season - 21",
+					},
+					Teams: api_sports.FixtureTeams{
+						Home: api_sports.FixtureTeam{ID: 1, Name: "Home", Logo: "home.png", Winner: &homeWinner},
+						Away: api_sports.FixtureTeam{ID: 2, Name: "Away", Logo: "away.png", Winner: &awayWinner},
+					},
+					Goals: api_sports.FixtureGoals{Home: &homeGoals, Away: &awayGoals},
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			if testCase.withMock {
+				restclient.StartMockups()
+				restclient.AddMockup(testCase.apiMock)
+			}
+			client := New(config.APISportsConfig{BaseURL: testCase.baseURL})
+
+			res, err := client.GetFixtures(context.Background(), 39, 2021)
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+
+			restclient.FlushMockups()
+		})
+	}
+}
+
+func TestAPISportsProvider_GetOdds(t *testing.T) {
+	page := func(current, total int64, fixtureID int64) string {
+		return fmt.Sprintf(`{"get":"odds","errors":[],"results":1,"paging":{"current":%d,"total":%d},"response":[`+
+			`{"league":{"id":39,"name":"Premier League","country":"England","season":2021},`+
+			`"fixture":{"id":%d,"timezone":"UTC","date":"2022-01-15T15:00:00+00:00","timestamp":1642258800},"update":"2022-01-14T10:00:00+00:00",`+
+			`"bookmakers":[{"id":8,"name":"Bet365","bets":[{"id":1,"name":"Match Winner","values":[{"value":"Home","odd":"1.90"}]}]}]}]}`,
+			current, total, fixtureID)
+	}
+	odds := func(fixtureID int64) api_sports.OddsResponse {
+		return api_sports.OddsResponse{
+			League:  api_sports.FixtureLeague{ID: 39, Name: "Premier League", Country: "England", Season: 2021},
+			Fixture: api_sports.OddsFixture{ID: fixtureID, Timezone: "UTC", Date: "2022-01-15T15:00:00+00:00", Timestamp: 1642258800},
+			Update:  "2022-01-14T10:00:00+00:00",
+			Bookmakers: []api_sports.OddsBookmaker{
+				{ID: 8, Name: "Bet365", Bets: []api_sports.OddsBet{
+					{ID: 1, Name: "Match Winner", Values: []api_sports.OddsValue{{Value: "Home", Odd: "1.90"}}},
+				}},
+			},
+		}
+	}
+	response := func(statusCode int, body string) *http.Response {
+		return &http.Response{StatusCode: statusCode, Body: io.NopCloser(strings.NewReader(body))}
+	}
+	testCases := []struct {
+		title       string
+		req         api_sports.OddsRequest
+		apiMocks    []restclient.Mock
+		baseURL     string
+		expectedRes []api_sports.OddsResponse
+		expectedErr *api_sports.ErrorResponse
+	}{
+		{
+			title:   "error restclient.Get",
+			req:     api_sports.OddsRequest{Fixture: 10},
+			baseURL: "invalid-url",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error making API request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "error non 200 response",
+			req:   api_sports.OddsRequest{Fixture: 10},
+			apiMocks: []restclient.Mock{
+				{Url: "https://test.com/odds?fixture=10&page=1", HttpMethod: http.MethodGet, Response: response(499, `{"message": "Something went wrong while fetching details. Try again later."}`)},
+			},
+			baseURL: "https://test.com",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Something went wrong while fetching details. Try again later.",
+				StatusCode: 499,
+			},
+		},
+		{
+			title: "error 200 json.Unmarshal",
+			req:   api_sports.OddsRequest{Fixture: 10},
+			apiMocks: []restclient.Mock{
+				{Url: "https://test.com/odds?fixture=10&page=1", HttpMethod: http.MethodGet, Response: response(200, `{"response does not match ErrorResponse struct"}`)},
+			},
+			baseURL: "https://test.com",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error decoding API response",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "error second page",
+			req:   api_sports.OddsRequest{League: 39, Season: 2021},
+			apiMocks: []restclient.Mock{
+				{Url: "https://test.com/odds?league=39&page=1&season=2021", HttpMethod: http.MethodGet, Response: response(200, page(1, 2, 10))},
+				{Url: "https://test.com/odds?league=39&page=2&season=2021", HttpMethod: http.MethodGet, Response: response(499, `{"message": "Too many requests"}`)},
+			},
+			baseURL: "https://test.com",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Too many requests",
+				StatusCode: 499,
+			},
+		},
+		{
+			title: "success fixture",
+			req:   api_sports.OddsRequest{Fixture: 10, Bookmaker: 8},
+			apiMocks: []restclient.Mock{
+				{Url: "https://test.com/odds?bookmaker=8&fixture=10&page=1", HttpMethod: http.MethodGet, Response: response(200, page(1, 1, 10))},
+			},
+			baseURL:     "https://test.com",
+			expectedRes: []api_sports.OddsResponse{odds(10)},
+		},
+		{
+			title: "success every page",
+			req:   api_sports.OddsRequest{League: 39, Season: 2021},
+			apiMocks: []restclient.Mock{
+				{Url: "https://test.com/odds?league=39&page=1&season=2021", HttpMethod: http.MethodGet, Response: response(200, page(1, 2, 10))},
+				{Url: "https://test.com/odds?league=39&page=2&season=2021", HttpMethod: http.MethodGet, Response: response(200, page(2, 2, 11))},
+			},
+			baseURL:     "https://test.com",
+			expectedRes: []api_sports.OddsResponse{odds(10), odds(11)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			if len(testCase.apiMocks) > 0 {
+				restclient.StartMockups()
+				for _, apiMock := range testCase.apiMocks {
+					restclient.AddMockup(apiMock)
+				}
+			}
+			client := New(config.APISportsConfig{BaseURL: testCase.baseURL})
+
+			res, err := client.GetOdds(context.Background(), testCase.req)
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+
+			restclient.FlushMockups()
+		})
+	}
+}
+
+func TestAPISportsProvider_GetSquad(t *testing.T) {
+	age, number := int64(34), int64(30)
+	testCases := []struct {
+		title       string
+		apiMock     *restclient.Mock
+		baseURL     string
+		expectedRes []api_sports.SquadsResponse
+		expectedErr *api_sports.ErrorResponse
+	}{
+		{
+			title:   "error restclient.Get",
+			baseURL: "invalid-url",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error making API request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "error 200 json.Unmarshal",
+			apiMock: &restclient.Mock{
+				Url:        "https://test.com/players/squads?team=85",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"response does not match ErrorResponse struct"}`)),
+				},
+			},
+			baseURL: "https://test.com",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error decoding API response",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "success",
+			apiMock: &restclient.Mock{
+				Url:        "https://test.com/players/squads?team=85",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{"get":"players/squads","errors":[],"results":1,"paging":{"current":1,"total":1},` +
+						`"response":[{"team":{"id":85,"name":"Paris Saint Germain","logo":"https://media.api-sports.io/football/teams/85.png"},` +
+						`"players":[{"id":154,"name":"L. Messi","age":34,"number":30,"position":"Attacker","photo":"https://media.api-sports.io/football/players/154.png"}]}]}`)),
+				},
+			},
+			baseURL: "https://test.com",
+			expectedRes: []api_sports.SquadsResponse{{
+				Team: api_sports.PlayerTeam{ID: 85, Name: "Paris Saint Germain", Logo: "https://media.api-sports.io/football/teams/85.png"},
+				Players: []api_sports.SquadPlayer{{ID: 154, Name: "L. Messi", Age: &age, Number: &number, Position: "Attacker",
+					Photo: "https://media.api-sports.io/football/players/154.png"}},
+			}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			if testCase.apiMock != nil {
+				restclient.StartMockups()
+				restclient.AddMockup(*testCase.apiMock)
+			}
+			client := New(config.APISportsConfig{BaseURL: testCase.baseURL})
+
+			res, err := client.GetSquad(context.Background(), 85)
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+
+			restclient.FlushMockups()
+		})
+	}
+}
+
+func TestAPISportsProvider_GetPlayers(t *testing.T) {
+	page := func(current, total int64, playerID int64) string {
+		return fmt.Sprintf(`{"get":"players","errors":[],"results":1,"paging":{"current":%d,"total":%d},"response":[`+
+			`{"player":{"id":%d,"name":"Player %d","birth":{"date":"1990-01-01"}},"statistics":[{"team":{"id":85},`+
+			`"league":{"id":61,"season":2021},"games":{"minutes":90,"rating":"7.1"},"goals":{"total":1},"cards":{}}]}]}`,
+			current, total, playerID, playerID)
+	}
+	player := func(playerID int64) api_sports.PlayersResponse {
+		date, minutes, rating, goals := "1990-01-01", int64(90), "7.1", int64(1)
+		return api_sports.PlayersResponse{
+			Player: api_sports.PlayerDetails{ID: playerID, Name: fmt.Sprintf("Player %d", playerID), Birth: api_sports.PlayerBirth{Date: &date}},
+			Statistics: []api_sports.PlayerStatistics{{
+				Team:   api_sports.PlayerTeam{ID: 85},
+				League: api_sports.FixtureLeague{ID: 61, Season: 2021},
+				Games:  api_sports.PlayerGames{Minutes: &minutes, Rating: &rating},
+				Goals:  api_sports.PlayerGoals{Total: &goals},
+			}},
+		}
+	}
+	response := func(statusCode int, body string) *http.Response {
+		return &http.Response{StatusCode: statusCode, Body: io.NopCloser(strings.NewReader(body))}
+	}
+	testCases := []struct {
+		title       string
+		apiMocks    []restclient.Mock
+		baseURL     string
+		expectedRes []api_sports.PlayersResponse
+		expectedErr *api_sports.ErrorResponse
+	}{
+		{
+			title:   "error restclient.Get",
+			baseURL: "invalid-url",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error making API request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "error 200 json.Unmarshal",
+			apiMocks: []restclient.Mock{
+				{Url: "https://test.com/players?league=61&season=2021&page=1", HttpMethod: http.MethodGet, Response: response(200, `{"response does not match ErrorResponse struct"}`)},
+			},
+			baseURL: "https://test.com",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error decoding API response",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "error second page",
+			apiMocks: []restclient.Mock{
+				{Url: "https://test.com/players?league=61&season=2021&page=1", HttpMethod: http.MethodGet, Response: response(200, page(1, 2, 154))},
+				{Url: "https://test.com/players?league=61&season=2021&page=2", HttpMethod: http.MethodGet, Response: response(499, `{"message": "Too many requests"}`)},
+			},
+			baseURL: "https://test.com",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Too many requests",
+				StatusCode: 499,
+			},
+		},
+		{
+			title: "success every page",
+			apiMocks: []restclient.Mock{
+				{Url: "https://test.com/players?league=61&season=2021&page=1", HttpMethod: http.MethodGet, Response: response(200, page(1, 2, 154))},
+				{Url: "https://test.com/players?league=61&season=2021&page=2", HttpMethod: http.MethodGet, Response: response(200, page(2, 2, 278))},
+			},
+			baseURL:     "https://test.com",
+			expectedRes: []api_sports.PlayersResponse{player(154), player(278)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			if len(testCase.apiMocks) > 0 {
+				restclient.StartMockups()
+				for _, apiMock := range testCase.apiMocks {
+					restclient.AddMockup(apiMock)
+				}
+			}
+			client := New(config.APISportsConfig{BaseURL: testCase.baseURL})
+
+			res, err := client.GetPlayers(context.Background(), 61, 2021)
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+
+			restclient.FlushMockups()
+		})
+	}
+}
+
+func TestAPISportsProvider_GetFixtureEvents(t *testing.T) {
+	playerID, playerName := int64(154), "L. Messi"
+	testCases := []struct {
+		title       string
+		apiMock     *restclient.Mock
+		baseURL     string
+		expectedRes []api_sports.FixtureEvent
+		expectedErr *api_sports.ErrorResponse
+	}{
+		{
+			title:   "error restclient.Get",
+			baseURL: "invalid-url",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error making API request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "success",
+			apiMock: &restclient.Mock{
+				Url:        "https://test.com/fixtures/events?fixture=10",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{"get":"fixtures/events","errors":[],"results":1,"paging":{"current":1,"total":1},` +
+						`"response":[{"time":{"elapsed":45,"extra":null},"team":{"id":85,"name":"Paris Saint Germain"},` +
+						`"player":{"id":154,"name":"L. Messi"},"assist":{"id":null,"name":null},"type":"Card","detail":"Yellow Card","comments":null}]}`)),
+				},
+			},
+			baseURL: "https://test.com",
+			expectedRes: []api_sports.FixtureEvent{{
+				Time:   api_sports.EventTime{Elapsed: 45},
+				Team:   api_sports.PlayerTeam{ID: 85, Name: "Paris Saint Germain"},
+				Player: api_sports.EventPlayer{ID: &playerID, Name: &playerName},
+				Type:   "Card",
+				Detail: "Yellow Card",
+			}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			if testCase.apiMock != nil {
+				restclient.StartMockups()
+				restclient.AddMockup(*testCase.apiMock)
+			}
+			client := New(config.APISportsConfig{BaseURL: testCase.baseURL})
+
+			res, err := client.GetFixtureEvents(context.Background(), 10)
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+
+			restclient.FlushMockups()
+		})
+	}
+}
+
+func TestAPISportsProvider_GetFixtureLineups(t *testing.T) {
+	playerID, number, position, grid := int64(1622), int64(50), "G", "1:1"
+	testCases := []struct {
+		title       string
+		apiMock     *restclient.Mock
+		baseURL     string
+		expectedRes []api_sports.FixtureLineup
+		expectedErr *api_sports.ErrorResponse
+	}{
+		{
+			title: "error 200 json.Unmarshal",
+			apiMock: &restclient.Mock{
+				Url:        "https://test.com/fixtures/lineups?fixture=10",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"response does not match ErrorResponse struct"}`)),
+				},
+			},
+			baseURL: "https://test.com",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error decoding API response",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "success",
+			apiMock: &restclient.Mock{
+				Url:        "https://test.com/fixtures/lineups?fixture=10",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{"get":"fixtures/lineups","errors":[],"results":1,"paging":{"current":1,"total":1},` +
+						`"response":[{"team":{"id":85,"name":"Paris Saint Germain"},"formation":"4-3-3","coach":{"id":null,"name":null},` +
+						`"startXI":[{"player":{"id":1622,"name":"G. Donnarumma","number":50,"pos":"G","grid":"1:1"}}],"substitutes":[]}]}`)),
+				},
+			},
+			baseURL: "https://test.com",
+			expectedRes: []api_sports.FixtureLineup{{
+				Team:        api_sports.PlayerTeam{ID: 85, Name: "Paris Saint Germain"},
+				Formation:   "4-3-3",
+				StartXI:     []api_sports.LineupEntry{{Player: api_sports.LineupPlayer{ID: &playerID, Name: "G. Donnarumma", Number: &number, Pos: &position, Grid: &grid}}},
+				Substitutes: []api_sports.LineupEntry{},
+			}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			if testCase.apiMock != nil {
+				restclient.StartMockups()
+				restclient.AddMockup(*testCase.apiMock)
+			}
+			client := New(config.APISportsConfig{BaseURL: testCase.baseURL})
+
+			res, err := client.GetFixtureLineups(context.Background(), 10)
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+
+			restclient.FlushMockups()
+		})
+	}
+}
+
+func TestAPISportsProvider_GetFixtureStatistics(t *testing.T) {
+	testCases := []struct {
+		title       string
+		apiMock     *restclient.Mock
+		baseURL     string
+		expectedRes []api_sports.FixtureStatistics
+		expectedErr *api_sports.ErrorResponse
+	}{
+		{
+			title:   "error restclient.Get",
+			baseURL: "invalid-url",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error making API request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "success",
+			apiMock: &restclient.Mock{
+				Url:        "https://test.com/fixtures/statistics?fixture=10",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{"get":"fixtures/statistics","errors":[],"results":1,"paging":{"current":1,"total":1},` +
+						`"response":[{"team":{"id":85,"name":"Paris Saint Germain"},"statistics":[{"type":"Total Shots","value":14},` +
+						`{"type":"Ball Possession","value":"62%"},{"type":"Red Cards","value":null}]}]}`)),
+				},
+			},
+			baseURL: "https://test.com",
+			expectedRes: []api_sports.FixtureStatistics{{
+				Team: api_sports.PlayerTeam{ID: 85, Name: "Paris Saint Germain"},
+				Statistics: []api_sports.StatisticValue{
+					{Type: "Total Shots", Value: float64(14)},
+					{Type: "Ball Possession", Value: "62%"},
+					{Type: "Red Cards"},
+				},
+			}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			if testCase.apiMock != nil {
+				restclient.StartMockups()
+				restclient.AddMockup(*testCase.apiMock)
+			}
+			client := New(config.APISportsConfig{BaseURL: testCase.baseURL})
+
+			res, err := client.GetFixtureStatistics(context.Background(), 10)
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+
+			restclient.FlushMockups()
+		})
+	}
+}
+
+func TestAPISportsProvider_GetInjuries(t *testing.T) {
+	testCases := []struct {
+		title       string
+		apiMocks    []restclient.Mock
+		baseURL     string
+		expectedRes []api_sports.InjuriesResponse
+		expectedErr *api_sports.ErrorResponse
+	}{
+		{
+			title:   "error restclient.Get",
+			baseURL: "invalid-url",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error making API request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "error 200 json.Unmarshal",
+			apiMocks: []restclient.Mock{{
+				Url:        "https://test.com/injuries?league=39&page=1&season=2021",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"response":"invalid"}`)),
+				},
+			}},
+			baseURL: "https://test.com",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error decoding API response",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "success all pages",
+			apiMocks: []restclient.Mock{
+				{
+					Url:        "https://test.com/injuries?league=39&page=1&season=2021",
+					HttpMethod: http.MethodGet,
+					Response: &http.Response{
+						StatusCode: http.StatusOK,
+						Body: io.NopCloser(strings.NewReader(`{"get":"injuries","errors":[],"results":1,"paging":{"current":1,"total":2},` +
+							`"response":[{"player":{"id":865,"name":"D. Costa","photo":"","type":"Missing Fixture","reason":"Broken ankle"},` +
+							`"team":{"id":157,"name":"Bayern Munich"},"fixture":{"id":686314,"date":"2021-04-07T19:00:00+00:00"},` +
+							`"league":{"id":39,"season":2021}}]}`)),
+					},
+				},
+				{
+					Url:        "https://test.com/injuries?league=39&page=2&season=2021",
+					HttpMethod: http.MethodGet,
+					Response: &http.Response{
+						StatusCode: http.StatusOK,
+						Body: io.NopCloser(strings.NewReader(`{"get":"injuries","errors":[],"results":1,"paging":{"current":2,"total":2},` +
+							`"response":[{"player":{"id":510,"name":"S. Gnabry","photo":"","type":"Questionable","reason":"Illness"},` +
+							`"team":{"id":157,"name":"Bayern Munich"},"fixture":{"id":686314,"date":"2021-04-07T19:00:00+00:00"},` +
+							`"league":{"id":39,"season":2021}}]}`)),
+					},
+				},
+			},
+			baseURL: "https://test.com",
+			expectedRes: []api_sports.InjuriesResponse{
+				{
+					Player:  api_sports.InjuredPlayer{ID: 865, Name: "D. Costa", Type: "Missing Fixture", Reason: "Broken ankle"},
+					Team:    api_sports.PlayerTeam{ID: 157, Name: "Bayern Munich"},
+					Fixture: api_sports.OddsFixture{ID: 686314, Date: "2021-04-07T19:00:00+00:00"},
+					League:  api_sports.FixtureLeague{ID: 39, Season: 2021},
+				},
+				{
+					Player:  api_sports.InjuredPlayer{ID: 510, Name: "S. Gnabry", Type: "Questionable", Reason: "Illness"},
+					Team:    api_sports.PlayerTeam{ID: 157, Name: "Bayern Munich"},
+					Fixture: api_sports.OddsFixture{ID: 686314, Date: "2021-04-07T19:00:00+00:00"},
+					League:  api_sports.FixtureLeague{ID: 39, Season: 2021},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			if len(testCase.apiMocks) > 0 {
+				restclient.StartMockups()
+				for _, mock := range testCase.apiMocks {
+					restclient.AddMockup(mock)
+				}
+			}
+			client := New(config.APISportsConfig{BaseURL: testCase.baseURL})
+
+			res, err := client.GetInjuries(context.Background(), api_sports.InjuriesRequest{League: 39, Season: 2021})
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+
+			restclient.FlushMockups()
+		})
+	}
+}
+
+func TestAPISportsProvider_GetSidelined(t *testing.T) {
+	end := "2021-05-01"
+	testCases := []struct {
+		title       string
+		apiMock     *restclient.Mock
+		baseURL     string
+		expectedRes []api_sports.Sideline
+		expectedErr *api_sports.ErrorResponse
+	}{
+		{
+			title:   "error restclient.Get",
+			baseURL: "invalid-url",
+			expectedErr: &api_sports.ErrorResponse{
+				Message:    "Error making API request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			title: "success",
+			apiMock: &restclient.Mock{
+				Url:        "https://test.com/sidelined?player=276",
+				HttpMethod: http.MethodGet,
+				Response: &http.Response{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{"get":"sidelined","errors":[],"results":2,"paging":{"current":1,"total":1},` +
+						`"response":[{"type":"Ankle Injury","start":"2021-03-20","end":"2021-05-01"},{"type":"Suspended","start":"2021-09-10","end":null}]}`)),
+				},
+			},
+			baseURL: "https://test.com",
+			expectedRes: []api_sports.Sideline{
+				{Type: "Ankle Injury", Start: "2021-03-20", End: &end},
+				{Type: "Suspended", Start: "2021-09-10"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			if testCase.apiMock != nil {
+				restclient.StartMockups()
+				restclient.AddMockup(*testCase.apiMock)
+			}
+			client := New(config.APISportsConfig{BaseURL: testCase.baseURL})
+
+			res, err := client.GetSidelined(context.Background(), 276)
 			assert.Equal(t, testCase.expectedRes, res)
 			assert.Equal(t, testCase.expectedErr, err)
 
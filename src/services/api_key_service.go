@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/api_keys"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type ApiKeyServiceI interface {
+	Create(ctx context.Context, req *api_keys.ApiKeyInput) (*api_keys.CreateApiKeyOutput, resterror.RestErrorI)
+	Update(ctx context.Context, req *api_keys.UpdateApiKeyInput, id int64) resterror.RestErrorI
+	Find(ctx context.Context, id int64) (*api_keys.ApiKeyOutput, resterror.RestErrorI)
+	List(ctx context.Context, req *api_keys.ListApiKeyInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	Delete(ctx context.Context, id int64) resterror.RestErrorI
+}
+
+type apiKeyService struct{}
+
+var ApiKeyService ApiKeyServiceI = &apiKeyService{}
+
+func (s *apiKeyService) Create(ctx context.Context, req *api_keys.ApiKeyInput) (*api_keys.CreateApiKeyOutput, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "ApiKeyService.Create")
+	defer span.End()
+
+	key, hash, prefix, err := auth.GenerateApiKey()
+	if err != nil {
+		zlog.FromContext(ctx).Errorw("ApiKeyService Create GenerateApiKey", "error", err)
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	record := api_keys.ApiKey{
+		Name:      req.Name,
+		KeyPrefix: prefix,
+		KeyHash:   hash,
+		Role:      req.Role,
+		Active:    req.Active,
+		CreatedAt: helpers.GetNow(),
+	}
+	if err := api_keys.ApiKeyDao.Create(ctx, &record); err != nil {
+		return nil, resterror.NewDatabaseError(err, "api_key")
+	}
+
+	return &api_keys.CreateApiKeyOutput{
+		ApiKeyOutput: api_keys.ApiKeyOutput{
+			ID:        record.ID,
+			Name:      record.Name,
+			KeyPrefix: record.KeyPrefix,
+			Role:      record.Role,
+			Active:    record.Active,
+			CreatedAt: record.CreatedAt,
+		},
+		Key: key,
+	}, nil
+}
+
+func (s *apiKeyService) Update(ctx context.Context, req *api_keys.UpdateApiKeyInput, id int64) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "ApiKeyService.Update")
+	defer span.End()
+
+	// Check if the api key exists
+	key, err := api_keys.ApiKeyDao.FindByID(ctx, id)
+	if err != nil {
+		return resterror.NewDatabaseError(err, "api_key")
+	}
+
+	// Set the ID and update the record
+	req.ID = key.ID
+	if err := api_keys.ApiKeyDao.Update(ctx, req); err != nil {
+		return resterror.NewDatabaseError(err, "api_key")
+	}
+	return nil
+}
+
+func (s *apiKeyService) Find(ctx context.Context, id int64) (*api_keys.ApiKeyOutput, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "ApiKeyService.Find")
+	defer span.End()
+
+	res, err := api_keys.ApiKeyDao.FindByID(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "api_key")
+	}
+	return res, nil
+}
+
+func (s *apiKeyService) List(ctx context.Context, req *api_keys.ListApiKeyInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "ApiKeyService.List")
+	defer span.End()
+
+	results, total, err := api_keys.ApiKeyDao.List(ctx, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	res := pagination.GeneratePaginatedResponse(req.Filter.Project(results), req.Page, req.PerPage, total)
+	res.NextCursor, res.PrevCursor = req.Filter.Cursors()
+
+	return &res, nil
+}
+
+func (s *apiKeyService) Delete(ctx context.Context, id int64) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "ApiKeyService.Delete")
+	defer span.End()
+
+	if err := api_keys.ApiKeyDao.Delete(ctx, id); err != nil {
+		return resterror.NewDatabaseError(err, "api_key")
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/domains/api_keys"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type MockApiKeyDao struct {
+	FuncCreate         func(key *api_keys.ApiKey) error
+	FuncUpdate         func(key *api_keys.UpdateApiKeyInput) error
+	FuncUpdateLastUsed func(id int64, usedAt time.Time) error
+	FuncFindByID       func(id int64) (*api_keys.ApiKeyOutput, error)
+	FuncFindByHash     func(hash string) (*api_keys.ApiKey, error)
+	FuncList           func(req *api_keys.ListApiKeyInput) ([]api_keys.ApiKeyOutput, int64, error)
+	FuncDelete         func(id int64) error
+}
+
+func (m MockApiKeyDao) Create(ctx context.Context, key *api_keys.ApiKey) error {
+	return m.FuncCreate(key)
+}
+func (m MockApiKeyDao) Update(ctx context.Context, key *api_keys.UpdateApiKeyInput) error {
+	return m.FuncUpdate(key)
+}
+func (m MockApiKeyDao) UpdateLastUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	return m.FuncUpdateLastUsed(id, usedAt)
+}
+func (m MockApiKeyDao) FindByID(ctx context.Context, id int64) (*api_keys.ApiKeyOutput, error) {
+	return m.FuncFindByID(id)
+}
+func (m MockApiKeyDao) FindByHash(ctx context.Context, hash string) (*api_keys.ApiKey, error) {
+	return m.FuncFindByHash(hash)
+}
+func (m MockApiKeyDao) List(ctx context.Context, req *api_keys.ListApiKeyInput) ([]api_keys.ApiKeyOutput, int64, error) {
+	return m.FuncList(req)
+}
+func (m MockApiKeyDao) Delete(ctx context.Context, id int64) error {
+	return m.FuncDelete(id)
+}
+
+func TestApiKeyService_Create(t *testing.T) {
+	testCases := []struct {
+		title         string
+		apiKeyDaoMock api_keys.ApiKeyDaoI
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title: "error ApiKeyDao.Create",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncCreate: func(key *api_keys.ApiKey) error {
+					return errors.New("error Create")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncCreate: func(key *api_keys.ApiKey) error {
+					key.ID = 1
+					return nil
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			api_keys.ApiKeyDao = testCase.apiKeyDaoMock
+
+			res, err := ApiKeyService.Create(context.Background(), &api_keys.ApiKeyInput{
+				Name:   "name",
+				Role:   auth.RoleEditor,
+				Active: true,
+			})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedErr != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, int64(1), res.ID)
+			assert.Equal(t, auth.RoleEditor, res.Role)
+			assert.True(t, res.Active)
+			// The returned key must match the stored prefix
+			assert.Equal(t, res.KeyPrefix, res.Key[:len(res.KeyPrefix)])
+		})
+	}
+}
+
+func TestApiKeyService_Update(t *testing.T) {
+	testCases := []struct {
+		title         string
+		apiKeyDaoMock api_keys.ApiKeyDaoI
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title: "error ApiKeyDao.FindByID",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByID: func(id int64) (*api_keys.ApiKeyOutput, error) {
+					return nil, errors.New("error FindByID")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error ApiKeyDao.FindByID not found",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByID: func(id int64) (*api_keys.ApiKeyOutput, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "api_key"),
+		},
+		{
+			title: "error ApiKeyDao.Update",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByID: func(id int64) (*api_keys.ApiKeyOutput, error) {
+					return &api_keys.ApiKeyOutput{ID: 1}, nil
+				},
+				FuncUpdate: func(key *api_keys.UpdateApiKeyInput) error {
+					return errors.New("error Update")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByID: func(id int64) (*api_keys.ApiKeyOutput, error) {
+					return &api_keys.ApiKeyOutput{ID: 1}, nil
+				},
+				FuncUpdate: func(key *api_keys.UpdateApiKeyInput) error {
+					return nil
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			api_keys.ApiKeyDao = testCase.apiKeyDaoMock
+			err := ApiKeyService.Update(context.Background(), &api_keys.UpdateApiKeyInput{
+				Name:   "name",
+				Role:   auth.RoleReader,
+				Active: true,
+			}, 1)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestApiKeyService_Find(t *testing.T) {
+	testCases := []struct {
+		title         string
+		apiKeyDaoMock api_keys.ApiKeyDaoI
+		expectedRes   *api_keys.ApiKeyOutput
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title: "error ApiKeyDao.FindByID",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByID: func(id int64) (*api_keys.ApiKeyOutput, error) {
+					return nil, errors.New("error FindByID")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error ApiKeyDao.FindByID not found",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByID: func(id int64) (*api_keys.ApiKeyOutput, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "api_key"),
+		},
+		{
+			title: "success",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByID: func(id int64) (*api_keys.ApiKeyOutput, error) {
+					return &api_keys.ApiKeyOutput{ID: 1, Name: "name"}, nil
+				},
+			},
+			expectedRes: &api_keys.ApiKeyOutput{ID: 1, Name: "name"},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			api_keys.ApiKeyDao = testCase.apiKeyDaoMock
+			res, err := ApiKeyService.Find(context.Background(), 1)
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestApiKeyService_List(t *testing.T) {
+	testCases := []struct {
+		title         string
+		apiKeyDaoMock api_keys.ApiKeyDaoI
+		expectedRes   *pagination.PaginatedResponse
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title: "error ApiKeyDao.List",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncList: func(req *api_keys.ListApiKeyInput) ([]api_keys.ApiKeyOutput, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncList: func(req *api_keys.ListApiKeyInput) ([]api_keys.ApiKeyOutput, int64, error) {
+					return []api_keys.ApiKeyOutput{{ID: 1}}, 1, nil
+				},
+			},
+			expectedRes: &pagination.PaginatedResponse{
+				From:        1,
+				Data:        []api_keys.ApiKeyOutput{{ID: 1}},
+				CurrentPage: 1,
+				LastPage:    1,
+				PerPage:     constants.DefaultPerPage,
+				To:          1,
+				Total:       1,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			api_keys.ApiKeyDao = testCase.apiKeyDaoMock
+			res, err := ApiKeyService.List(context.Background(), &api_keys.ListApiKeyInput{})
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestApiKeyService_Delete(t *testing.T) {
+	testCases := []struct {
+		title         string
+		apiKeyDaoMock api_keys.ApiKeyDaoI
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title: "error ApiKeyDao.Delete",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncDelete: func(id int64) error {
+					return errors.New("error Delete")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncDelete: func(id int64) error {
+					return nil
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			api_keys.ApiKeyDao = testCase.apiKeyDaoMock
+			err := ApiKeyService.Delete(context.Background(), 1)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/domains/api_keys"
+	"github.com/development-raul/footy-predictor/src/domains/users"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+const (
+	errorInvalidAuthentication = "INVALID_USER_AUTHENTICATION"
+	errorInvalidCredentials    = "INVALID_CREDENTIALS"
+	bootstrapSubject           = "bootstrap"
+)
+
+type AuthServiceI interface {
+	Authenticate(ctx context.Context, credential string) (*auth.Claims, resterror.RestErrorI)
+	Token(ctx context.Context, req *api_keys.TokenInput) (*api_keys.TokenOutput, resterror.RestErrorI)
+	Login(ctx context.Context, req *users.LoginInput) (*api_keys.TokenOutput, resterror.RestErrorI)
+}
+
+type authService struct {
+	tokens *auth.Tokens
+}
+
+var AuthService AuthServiceI = &authService{}
+
+// Authenticate resolves either a JWT or an API key into the claims of the caller
+func (s *authService) Authenticate(ctx context.Context, credential string) (*auth.Claims, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "AuthService.Authenticate")
+	defer span.End()
+
+	if credential == "" {
+		return nil, resterror.NewUnauthorizedError(errorInvalidAuthentication)
+	}
+
+	if auth.IsJWT(credential) {
+		claims, err := s.tokens.ParseToken(credential)
+		if err != nil {
+			return nil, resterror.NewUnauthorizedError(errorInvalidAuthentication)
+		}
+		return claims, nil
+	}
+
+	return s.authenticateApiKey(ctx, credential)
+}
+
+// Token exchanges a valid API key for a short-lived JWT carrying the same role
+func (s *authService) Token(ctx context.Context, req *api_keys.TokenInput) (*api_keys.TokenOutput, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "AuthService.Token")
+	defer span.End()
+
+	claims, apiErr := s.authenticateApiKey(ctx, req.ApiKey)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return s.issueToken(ctx, claims.Subject, claims.Role)
+}
+
+// Login exchanges the email and password of a registered user for a JWT
+func (s *authService) Login(ctx context.Context, req *users.LoginInput) (*api_keys.TokenOutput, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer span.End()
+
+	user, err := users.UserDao.FindByEmail(ctx, strings.ToLower(strings.TrimSpace(req.Email)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, resterror.NewUnauthorizedError(errorInvalidCredentials)
+		}
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return nil, resterror.NewUnauthorizedError(errorInvalidCredentials)
+	}
+
+	return s.issueToken(ctx, auth.UserSubject(user.ID), user.Role)
+}
+
+func (s *authService) issueToken(ctx context.Context, subject string, role string) (*api_keys.TokenOutput, resterror.RestErrorI) {
+	token, expiresAt, err := s.tokens.GenerateToken(subject, role)
+	if err != nil {
+		zlog.FromContext(ctx).Errorw("AuthService GenerateToken", "error", err)
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	return &api_keys.TokenOutput{
+		Token:     token,
+		TokenType: "Bearer",
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *authService) authenticateApiKey(ctx context.Context, key string) (*auth.Claims, resterror.RestErrorI) {
+	// The bootstrap key allows creating the first admin keys before any exist in the database
+	bootstrapKey := s.tokens.BootstrapKey()
+	if bootstrapKey != "" && subtle.ConstantTimeCompare([]byte(bootstrapKey), []byte(key)) == 1 {
+		claims := auth.Claims{Role: auth.RoleAdmin}
+		claims.Subject = bootstrapSubject
+		return &claims, nil
+	}
+
+	record, err := api_keys.ApiKeyDao.FindByHash(ctx, auth.HashApiKey(key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, resterror.NewUnauthorizedError(errorInvalidAuthentication)
+		}
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if !record.Active {
+		return nil, resterror.NewUnauthorizedError(errorInvalidAuthentication)
+	}
+
+	// Failing to record the usage should not block the request
+	if err := api_keys.ApiKeyDao.UpdateLastUsed(ctx, record.ID, helpers.GetNow()); err != nil {
+		zlog.FromContext(ctx).Warnw("could not update api key last used", "api_key_id", record.ID)
+	}
+
+	claims := auth.Claims{Role: record.Role}
+	claims.Subject = fmt.Sprintf("api_key:%d", record.ID)
+	return &claims, nil
+}
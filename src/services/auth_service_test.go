@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/domains/api_keys"
+	"github.com/development-raul/footy-predictor/src/domains/users"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+	"time"
+)
+
+func TestAuthService_Authenticate(t *testing.T) {
+	tokens := auth.NewTokens(config.AuthConfig{JWTSecret: "test-secret", BootstrapKey: "bootstrap-key"})
+	AuthService = &authService{tokens: tokens}
+
+	validToken, _, err := tokens.GenerateToken("api_key:7", auth.RoleEditor)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when generating a token", err)
+	}
+
+	testCases := []struct {
+		title           string
+		credential      string
+		apiKeyDaoMock   api_keys.ApiKeyDaoI
+		expectedSubject string
+		expectedRole    string
+		expectedErr     resterror.RestErrorI
+	}{
+		{
+			title:       "error empty credential",
+			credential:  "",
+			expectedErr: resterror.NewUnauthorizedError("INVALID_USER_AUTHENTICATION"),
+		},
+		{
+			title:       "error invalid token",
+			credential:  "aaa.bbb.ccc",
+			expectedErr: resterror.NewUnauthorizedError("INVALID_USER_AUTHENTICATION"),
+		},
+		{
+			title:           "success token",
+			credential:      validToken,
+			expectedSubject: "api_key:7",
+			expectedRole:    auth.RoleEditor,
+		},
+		{
+			title:           "success bootstrap key",
+			credential:      "bootstrap-key",
+			expectedSubject: "bootstrap",
+			expectedRole:    auth.RoleAdmin,
+		},
+		{
+			title:      "error ApiKeyDao.FindByHash no rows",
+			credential: "fp_unknown",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByHash: func(hash string) (*api_keys.ApiKey, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewUnauthorizedError("INVALID_USER_AUTHENTICATION"),
+		},
+		{
+			title:      "error ApiKeyDao.FindByHash",
+			credential: "fp_key",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByHash: func(hash string) (*api_keys.ApiKey, error) {
+					return nil, errors.New("error FindByHash")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:      "error inactive key",
+			credential: "fp_key",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByHash: func(hash string) (*api_keys.ApiKey, error) {
+					return &api_keys.ApiKey{ID: 1, Role: auth.RoleAdmin, Active: false}, nil
+				},
+			},
+			expectedErr: resterror.NewUnauthorizedError("INVALID_USER_AUTHENTICATION"),
+		},
+		{
+			title:      "success api key with UpdateLastUsed error",
+			credential: "fp_key",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByHash: func(hash string) (*api_keys.ApiKey, error) {
+					return &api_keys.ApiKey{ID: 3, Role: auth.RoleReader, Active: true}, nil
+				},
+				FuncUpdateLastUsed: func(id int64, usedAt time.Time) error {
+					return errors.New("error UpdateLastUsed")
+				},
+			},
+			expectedSubject: "api_key:3",
+			expectedRole:    auth.RoleReader,
+		},
+		{
+			title:      "success api key",
+			credential: "fp_key",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByHash: func(hash string) (*api_keys.ApiKey, error) {
+					if hash != auth.HashApiKey("fp_key") {
+						return nil, sql.ErrNoRows
+					}
+					return &api_keys.ApiKey{ID: 3, Role: auth.RoleAdmin, Active: true}, nil
+				},
+				FuncUpdateLastUsed: func(id int64, usedAt time.Time) error {
+					return nil
+				},
+			},
+			expectedSubject: "api_key:3",
+			expectedRole:    auth.RoleAdmin,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			api_keys.ApiKeyDao = testCase.apiKeyDaoMock
+
+			res, err := AuthService.Authenticate(context.Background(), testCase.credential)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedErr != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, testCase.expectedSubject, res.Subject)
+			assert.Equal(t, testCase.expectedRole, res.Role)
+		})
+	}
+}
+
+func TestAuthService_Token(t *testing.T) {
+	testCases := []struct {
+		title         string
+		secret        string
+		apiKeyDaoMock api_keys.ApiKeyDaoI
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title:  "error invalid api key",
+			secret: "test-secret",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByHash: func(hash string) (*api_keys.ApiKey, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewUnauthorizedError("INVALID_USER_AUTHENTICATION"),
+		},
+		{
+			title:  "error missing JWT_SECRET",
+			secret: "",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByHash: func(hash string) (*api_keys.ApiKey, error) {
+					return &api_keys.ApiKey{ID: 1, Role: auth.RoleReader, Active: true}, nil
+				},
+				FuncUpdateLastUsed: func(id int64, usedAt time.Time) error {
+					return nil
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:  "success",
+			secret: "test-secret",
+			apiKeyDaoMock: &MockApiKeyDao{
+				FuncFindByHash: func(hash string) (*api_keys.ApiKey, error) {
+					return &api_keys.ApiKey{ID: 1, Role: auth.RoleReader, Active: true}, nil
+				},
+				FuncUpdateLastUsed: func(id int64, usedAt time.Time) error {
+					return nil
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			tokens := auth.NewTokens(config.AuthConfig{JWTSecret: testCase.secret})
+			AuthService = &authService{tokens: tokens}
+			api_keys.ApiKeyDao = testCase.apiKeyDaoMock
+
+			res, err := AuthService.Token(context.Background(), &api_keys.TokenInput{ApiKey: "fp_key"})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedErr != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, "Bearer", res.TokenType)
+			claims, parseErr := tokens.ParseToken(res.Token)
+			assert.Nil(t, parseErr)
+			assert.Equal(t, "api_key:1", claims.Subject)
+			assert.Equal(t, auth.RoleReader, claims.Role)
+		})
+	}
+}
+
+func TestAuthService_Login(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	user := &users.User{ID: 7, Email: "john@test.com", PasswordHash: string(hash), Role: auth.RoleReader}
+
+	testCases := []struct {
+		title       string
+		password    string
+		userDaoMock users.UserDaoI
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title:    "error unknown email",
+			password: "secret-password",
+			userDaoMock: &MockUserDao{
+				FuncFindByEmail: func(email string) (*users.User, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewUnauthorizedError("INVALID_CREDENTIALS"),
+		},
+		{
+			title:    "error UserDao.FindByEmail",
+			password: "secret-password",
+			userDaoMock: &MockUserDao{
+				FuncFindByEmail: func(email string) (*users.User, error) {
+					return nil, errors.New("error FindByEmail")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:    "error wrong password",
+			password: "wrong-password",
+			userDaoMock: &MockUserDao{
+				FuncFindByEmail: func(email string) (*users.User, error) {
+					return user, nil
+				},
+			},
+			expectedErr: resterror.NewUnauthorizedError("INVALID_CREDENTIALS"),
+		},
+		{
+			title:    "success",
+			password: "secret-password",
+			userDaoMock: &MockUserDao{
+				FuncFindByEmail: func(email string) (*users.User, error) {
+					return user, nil
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			tokens := auth.NewTokens(config.AuthConfig{JWTSecret: "test-secret"})
+			AuthService = &authService{tokens: tokens}
+			users.UserDao = testCase.userDaoMock
+
+			res, err := AuthService.Login(context.Background(), &users.LoginInput{Email: "John@test.com", Password: testCase.password})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedErr != nil {
+				assert.Nil(t, res)
+				return
+			}
+			claims, parseErr := tokens.ParseToken(res.Token)
+			assert.Nil(t, parseErr)
+			assert.Equal(t, "user:7", claims.Subject)
+			assert.Equal(t, auth.RoleReader, claims.Role)
+		})
+	}
+}
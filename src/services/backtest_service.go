@@ -0,0 +1,339 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/domains/backtests"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/odds"
+	"github.com/development-raul/footy-predictor/src/predictor"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"sort"
+	"time"
+)
+
+// matchDuration is the time after its kickoff when the result of a fixture is known, extra time and penalties
+// included. A backtest only fits the model on the fixtures that kicked off this long before the predicted one
+const matchDuration = 150 * time.Minute
+
+// results are the 1x2 selections in the order of the predictor results
+var results = []string{odds.SelectionHome, odds.SelectionDraw, odds.SelectionAway}
+
+// runAsync runs the backtests created through the API, the tests replace it to run them in place
+var runAsync = func(fn func()) {
+	go fn()
+}
+
+type BacktestServiceI interface {
+	Create(ctx context.Context, req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI)
+	Run(ctx context.Context, req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI)
+	Find(ctx context.Context, id int64) (*backtests.Backtest, resterror.RestErrorI)
+	List(ctx context.Context, req *backtests.ListBacktestInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	Predictions(ctx context.Context, req *backtests.ListPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+}
+
+type backtestService struct {
+	valueBets config.ValueBetsConfig
+}
+
+var BacktestService BacktestServiceI = &backtestService{valueBets: config.Default().ValueBets}
+
+// Create saves a pending backtest and runs it in the background, its status tells when the results are ready
+func (s *backtestService) Create(ctx context.Context, req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "BacktestService.Create")
+	defer span.End()
+
+	backtest, apiErr := s.create(ctx, req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	// The run outlives the request, so it does not use its context
+	run := *backtest
+	runAsync(func() {
+		_ = s.execute(context.Background(), &run)
+	})
+	return backtest, nil
+}
+
+// Run saves a backtest and runs it before returning, the error is the one that failed the run
+func (s *backtestService) Run(ctx context.Context, req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "BacktestService.Run")
+	defer span.End()
+
+	backtest, apiErr := s.create(ctx, req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := s.execute(ctx, backtest); apiErr != nil {
+		return nil, apiErr
+	}
+	return backtest, nil
+}
+
+func (s *backtestService) Find(ctx context.Context, id int64) (*backtests.Backtest, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "BacktestService.Find")
+	defer span.End()
+
+	backtest, err := backtests.BacktestDao.FindByID(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "backtest")
+	}
+	return backtest, nil
+}
+
+func (s *backtestService) List(ctx context.Context, req *backtests.ListBacktestInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "BacktestService.List")
+	defer span.End()
+
+	res, total, err := backtests.BacktestDao.List(ctx, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if res == nil {
+		res = make([]backtests.Backtest, 0)
+	}
+	paginated := pagination.GeneratePaginatedResponse(res, req.Page, req.PerPage, total)
+	return &paginated, nil
+}
+
+func (s *backtestService) Predictions(ctx context.Context, req *backtests.ListPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "BacktestService.Predictions")
+	defer span.End()
+
+	if _, err := backtests.BacktestDao.FindByID(ctx, req.BacktestID); err != nil {
+		return nil, resterror.NewDatabaseError(err, "backtest")
+	}
+	res, total, err := backtests.BacktestDao.ListPredictions(ctx, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if res == nil {
+		res = make([]backtests.Prediction, 0)
+	}
+	paginated := pagination.GeneratePaginatedResponse(res, req.Page, req.PerPage, total)
+	return &paginated, nil
+}
+
+// create saves the pending backtest of the model version, the champion by default, which is saved as its
+// name@version reference. The edge of the simulated bets defaults to the one of the value bets
+func (s *backtestService) create(ctx context.Context, req *backtests.CreateBacktestInput) (*backtests.Backtest, resterror.RestErrorI) {
+	if req.SeasonTo < req.SeasonFrom {
+		return nil, resterror.NewBadRequestError("INVALID_SEASON_RANGE")
+	}
+	version, apiErr := resolveModel(ctx, req.Model)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	backtest := &backtests.Backtest{
+		LeagueID:   req.LeagueID,
+		SeasonFrom: req.SeasonFrom,
+		SeasonTo:   req.SeasonTo,
+		Model:      version.Ref(),
+		MinEdge:    s.valueBets.MinEdge,
+		Status:     backtests.StatusPending,
+		CreatedAt:  helpers.GetNow(),
+	}
+	if req.MinEdge != nil {
+		backtest.MinEdge = *req.MinEdge
+	}
+	if err := backtests.BacktestDao.Create(ctx, backtest); err != nil {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	return backtest, nil
+}
+
+// execute runs a saved backtest and saves its results, or the error when it fails
+func (s *backtestService) execute(ctx context.Context, backtest *backtests.Backtest) resterror.RestErrorI {
+	zlog.FromContext(ctx).Infow("Backtest Start", "id", backtest.ID, "league_id", backtest.LeagueID, "season_from", backtest.SeasonFrom, "season_to", backtest.SeasonTo, "model", backtest.Model)
+	now := helpers.GetNow()
+	backtest.Status = backtests.StatusRunning
+	backtest.StartedAt = &now
+	if err := backtests.BacktestDao.Start(ctx, backtest); err != nil {
+		return resterror.NewStandardInternalServerError()
+	}
+
+	predictions, apiErr := s.walk(ctx, backtest)
+	finished := helpers.GetNow()
+	backtest.FinishedAt = &finished
+	if apiErr != nil {
+		zlog.FromContext(ctx).Errorw("Backtest Failed", "id", backtest.ID, "error", apiErr.Error())
+		backtest.Status = backtests.StatusFailed
+		backtest.Error = fmt.Sprint(apiErr.Error())
+		predictions = nil
+	} else {
+		backtest.Status = backtests.StatusFinished
+		s.score(backtest, predictions)
+	}
+	if err := backtests.BacktestDao.Finish(ctx, backtest, predictions); err != nil {
+		return resterror.NewStandardInternalServerError()
+	}
+	zlog.FromContext(ctx).Infow("Backtest End", "id", backtest.ID, "status", backtest.Status, "fixtures", backtest.Fixtures, "skipped", backtest.Skipped)
+	return apiErr
+}
+
+// walk predicts the finished fixtures of the backtest seasons in kickoff order. Each one is predicted by a model
+// fitted only on the fixtures of its season and the previous one whose result was known at its kickoff, and is
+// bet on with the odds recorded before it. The fixtures that cannot be predicted yet, e.g. the first match of a
+// new league, are counted as skipped. The error is NOT_ENOUGH_DATA when no fixture could be predicted
+func (s *backtestService) walk(ctx context.Context, backtest *backtests.Backtest) ([]backtests.Prediction, resterror.RestErrorI) {
+	version, apiErr := resolveModel(ctx, backtest.Model)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	var finished []fixtures.Fixture
+	for season := backtest.SeasonFrom - 1; season <= backtest.SeasonTo; season++ {
+		res, apiErr := seasonFixtures(ctx, backtest.LeagueID, season)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		for _, f := range res {
+			if f.Finished() {
+				finished = append(finished, f)
+			}
+		}
+	}
+	sort.SliceStable(finished, func(i, j int) bool {
+		return finished[i].KickoffAt.Before(finished[j].KickoffAt)
+	})
+
+	var predictions []backtests.Prediction
+	for i := range finished {
+		fixture := &finished[i]
+		if fixture.Season < backtest.SeasonFrom {
+			continue
+		}
+		var matches []predictor.Match
+		for _, f := range finished {
+			if f.KickoffAt.Add(matchDuration).After(fixture.KickoffAt) {
+				break
+			}
+			if f.Season != fixture.Season && f.Season != fixture.Season-1 {
+				continue
+			}
+			matches = append(matches, predictor.Match{
+				HomeTeamID: f.HomeTeamID,
+				AwayTeamID: f.AwayTeamID,
+				HomeGoals:  *f.HomeGoals,
+				AwayGoals:  *f.AwayGoals,
+				PlayedAt:   f.KickoffAt,
+			})
+		}
+		model, err := predictor.Fit(version.Name, version.Hyperparameters, matches)
+		if err == predictor.ErrNotEnoughData {
+			backtest.Skipped++
+			continue
+		}
+		if err != nil {
+			zlog.FromContext(ctx).Errorw("BacktestService walk Fit", "model", backtest.Model, "error", err)
+			return nil, resterror.NewStandardInternalServerError()
+		}
+
+		forecast, _, apiErr := forecastFixture(ctx, version, model, fixture)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		homeWin, draw, awayWin := forecast.Outcome()
+		prediction := backtests.Prediction{
+			FixtureID:   fixture.ID,
+			KickoffAt:   fixture.KickoffAt,
+			MatchesUsed: int64(len(matches)),
+			HomeWin:     homeWin,
+			Draw:        draw,
+			AwayWin:     awayWin,
+			Result:      results[predictor.Result(*fixture.HomeGoals, *fixture.AwayGoals)],
+		}
+		if apiErr := s.bet(ctx, &prediction, backtest.MinEdge); apiErr != nil {
+			return nil, apiErr
+		}
+		predictions = append(predictions, prediction)
+	}
+	if len(predictions) == 0 {
+		return nil, resterror.NewUnprocessableEntityError(errorNotEnoughData)
+	}
+	return predictions, nil
+}
+
+// bet places a stake of 1 on the 1x2 selection with the highest edge over the latest odds of every bookmaker
+// recorded before the kickoff, with the same rules as the value bets. No bet is placed without such a selection
+func (s *backtestService) bet(ctx context.Context, prediction *backtests.Prediction, minEdge float64) resterror.RestErrorI {
+	rows, err := odds.OddDao.Latest(ctx, &odds.LatestOddInput{
+		FixtureID: prediction.FixtureID,
+		Market:    odds.Market1X2,
+		Before:    prediction.KickoffAt,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return resterror.NewStandardInternalServerError()
+	}
+
+	probabilities := map[string]float64{
+		odds.SelectionHome: prediction.HomeWin,
+		odds.SelectionDraw: prediction.Draw,
+		odds.SelectionAway: prediction.AwayWin,
+	}
+	best := minEdge
+	for _, market := range marketOdds(ctx, prediction.FixtureID, rows, "") {
+		for _, selection := range market.Selections {
+			// The probability is 0 when the market misses a selection
+			if selection.Probability == 0 {
+				continue
+			}
+			probability := probabilities[selection.Selection]
+			edge := probability - selection.Probability
+			if edge < best || predictor.Kelly(probability, selection.Price) == 0 {
+				continue
+			}
+			best = edge
+			prediction.Bet = selection.Selection
+			prediction.Price = selection.Price
+		}
+	}
+	switch prediction.Bet {
+	case "":
+	case prediction.Result:
+		prediction.Profit = prediction.Price - 1
+	default:
+		prediction.Profit = -1
+	}
+	return nil
+}
+
+// score averages the metrics of the predictions, the accuracy is the share of results given the highest
+// probability and the ROI the profit per unit staked
+func (s *backtestService) score(backtest *backtests.Backtest, predictions []backtests.Prediction) {
+	var logLoss, brier, rps, correct float64
+	for _, p := range predictions {
+		probabilities := []float64{p.HomeWin, p.Draw, p.AwayWin}
+		result := predictor.HomeWin
+		for i, name := range results {
+			if name == p.Result {
+				result = i
+			}
+		}
+		logLoss += predictor.LogLoss(probabilities, result)
+		brier += predictor.Brier(probabilities, result)
+		rps += predictor.RPS(probabilities, result)
+		if predictor.MostLikely(probabilities) == result {
+			correct++
+		}
+		if p.Bet != "" {
+			backtest.Bets++
+			backtest.Staked++
+			backtest.Profit += p.Profit
+		}
+	}
+
+	count := float64(len(predictions))
+	backtest.Fixtures = int64(len(predictions))
+	logLoss, brier, rps, correct = logLoss/count, brier/count, rps/count, correct/count
+	backtest.LogLoss, backtest.Brier, backtest.RPS, backtest.Accuracy = &logLoss, &brier, &rps, &correct
+	if backtest.Staked > 0 {
+		roi := backtest.Profit / backtest.Staked
+		backtest.ROI = &roi
+	}
+}
@@ -0,0 +1,543 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/domains/backtests"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/injuries"
+	"github.com/development-raul/footy-predictor/src/domains/models"
+	"github.com/development-raul/footy-predictor/src/domains/odds"
+	"github.com/development-raul/footy-predictor/src/predictor"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type MockBacktestDao struct {
+	FuncCreate          func(backtest *backtests.Backtest) error
+	FuncStart           func(backtest *backtests.Backtest) error
+	FuncFinish          func(backtest *backtests.Backtest, predictions []backtests.Prediction) error
+	FuncFindByID        func(id int64) (*backtests.Backtest, error)
+	FuncList            func(req *backtests.ListBacktestInput) ([]backtests.Backtest, int64, error)
+	FuncListPredictions func(req *backtests.ListPredictionInput) ([]backtests.Prediction, int64, error)
+}
+
+func (m MockBacktestDao) Create(ctx context.Context, backtest *backtests.Backtest) error {
+	return m.FuncCreate(backtest)
+}
+func (m MockBacktestDao) Start(ctx context.Context, backtest *backtests.Backtest) error {
+	return m.FuncStart(backtest)
+}
+func (m MockBacktestDao) Finish(ctx context.Context, backtest *backtests.Backtest, predictions []backtests.Prediction) error {
+	return m.FuncFinish(backtest, predictions)
+}
+func (m MockBacktestDao) FindByID(ctx context.Context, id int64) (*backtests.Backtest, error) {
+	return m.FuncFindByID(id)
+}
+func (m MockBacktestDao) List(ctx context.Context, req *backtests.ListBacktestInput) ([]backtests.Backtest, int64, error) {
+	return m.FuncList(req)
+}
+func (m MockBacktestDao) ListPredictions(ctx context.Context, req *backtests.ListPredictionInput) ([]backtests.Prediction, int64, error) {
+	return m.FuncListPredictions(req)
+}
+
+// testBacktestFixtures are two rounds of season 2021, the first round has nothing to fit on and the last fixture
+// kicks off an hour after the one before it, whose result is not known yet
+func testBacktestFixtures() []fixtures.Fixture {
+	goals := func(v int64) *int64 { return &v }
+	first := time.Date(2021, 8, 14, 15, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 7)
+	return []fixtures.Fixture{
+		{ID: 4, LeagueID: 39, Season: 2021, KickoffAt: second.Add(time.Hour), Status: "FT", HomeTeamID: 2, AwayTeamID: 4, HomeGoals: goals(0), AwayGoals: goals(1)},
+		{ID: 1, LeagueID: 39, Season: 2021, KickoffAt: first, Status: "FT", HomeTeamID: 1, AwayTeamID: 2, HomeGoals: goals(3), AwayGoals: goals(0)},
+		{ID: 2, LeagueID: 39, Season: 2021, KickoffAt: first, Status: "FT", HomeTeamID: 3, AwayTeamID: 4, HomeGoals: goals(1), AwayGoals: goals(1)},
+		{ID: 3, LeagueID: 39, Season: 2021, KickoffAt: second, Status: "FT", HomeTeamID: 1, AwayTeamID: 3, HomeGoals: goals(2), AwayGoals: goals(0)},
+		{ID: 5, LeagueID: 39, Season: 2021, KickoffAt: second.AddDate(0, 0, 7), Status: "NS", HomeTeamID: 1, AwayTeamID: 4},
+	}
+}
+
+func TestBacktestService_Run(t *testing.T) {
+	fixtureDao := &MockFixtureDao{
+		FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+			if req.Season == 2021 {
+				return testBacktestFixtures(), 5, nil
+			}
+			return nil, 0, nil
+		},
+	}
+	// Only the fixture 3 has odds, the home win is priced well below the model
+	oddDao := &MockOddDao{
+		FuncLatest: func(req *odds.LatestOddInput) ([]odds.Odd, error) {
+			if req.Market != odds.Market1X2 || req.Before.IsZero() {
+				return nil, errors.New("unexpected odds")
+			}
+			if req.FixtureID != 3 {
+				return nil, nil
+			}
+			return []odds.Odd{
+				{FixtureID: 3, BookmakerID: 8, Market: odds.Market1X2, Selection: odds.SelectionHome, Price: 3},
+				{FixtureID: 3, BookmakerID: 8, Market: odds.Market1X2, Selection: odds.SelectionDraw, Price: 3.5},
+				{FixtureID: 3, BookmakerID: 8, Market: odds.Market1X2, Selection: odds.SelectionAway, Price: 2.5},
+			}, nil
+		},
+	}
+	var finished *backtests.Backtest
+	var saved []backtests.Prediction
+	backtestDao := &MockBacktestDao{
+		FuncCreate: func(backtest *backtests.Backtest) error {
+			backtest.ID = 1
+			return nil
+		},
+		FuncStart: func(backtest *backtests.Backtest) error {
+			return nil
+		},
+		FuncFinish: func(backtest *backtests.Backtest, predictions []backtests.Prediction) error {
+			finished, saved = backtest, predictions
+			return nil
+		},
+	}
+	req := backtests.CreateBacktestInput{LeagueID: 39, SeasonFrom: 2021, SeasonTo: 2021}
+
+	testCases := []struct {
+		title           string
+		req             backtests.CreateBacktestInput
+		backtestDaoMock backtests.BacktestDaoI
+		fixtureDaoMock  fixtures.FixtureDaoI
+		oddDaoMock      odds.OddDaoI
+		expectedStatus  string
+		expectedErr     resterror.RestErrorI
+	}{
+		{
+			title:       "error season range",
+			req:         backtests.CreateBacktestInput{LeagueID: 39, SeasonFrom: 2021, SeasonTo: 2020},
+			expectedErr: resterror.NewBadRequestError("INVALID_SEASON_RANGE"),
+		},
+		{
+			title:       "error model not found",
+			req:         backtests.CreateBacktestInput{LeagueID: 39, SeasonFrom: 2021, SeasonTo: 2021, Model: "elo@9"},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "model"),
+		},
+		{
+			title: "error BacktestDao.Create",
+			req:   req,
+			backtestDaoMock: &MockBacktestDao{
+				FuncCreate: func(backtest *backtests.Backtest) error {
+					return errors.New("error Create")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error BacktestDao.Start",
+			req:   req,
+			backtestDaoMock: &MockBacktestDao{
+				FuncCreate: backtestDao.FuncCreate,
+				FuncStart: func(backtest *backtests.Backtest) error {
+					return errors.New("error Start")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:           "error FixtureDao.List",
+			req:             req,
+			backtestDaoMock: backtestDao,
+			fixtureDaoMock: &MockFixtureDao{
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedStatus: backtests.StatusFailed,
+			expectedErr:    resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:           "error not enough data",
+			req:             backtests.CreateBacktestInput{LeagueID: 39, SeasonFrom: 2020, SeasonTo: 2020},
+			backtestDaoMock: backtestDao,
+			fixtureDaoMock:  fixtureDao,
+			expectedStatus:  backtests.StatusFailed,
+			expectedErr:     resterror.NewUnprocessableEntityError("NOT_ENOUGH_DATA"),
+		},
+		{
+			title:           "error OddDao.Latest",
+			req:             req,
+			backtestDaoMock: backtestDao,
+			fixtureDaoMock:  fixtureDao,
+			oddDaoMock: &MockOddDao{
+				FuncLatest: func(req *odds.LatestOddInput) ([]odds.Odd, error) {
+					return nil, errors.New("error Latest")
+				},
+			},
+			expectedStatus: backtests.StatusFailed,
+			expectedErr:    resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error BacktestDao.Finish",
+			req:   req,
+			backtestDaoMock: &MockBacktestDao{
+				FuncCreate: backtestDao.FuncCreate,
+				FuncStart:  backtestDao.FuncStart,
+				FuncFinish: func(backtest *backtests.Backtest, predictions []backtests.Prediction) error {
+					return errors.New("error Finish")
+				},
+			},
+			fixtureDaoMock: fixtureDao,
+			oddDaoMock:     oddDao,
+			expectedErr:    resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:           "success",
+			req:             req,
+			backtestDaoMock: backtestDao,
+			fixtureDaoMock:  fixtureDao,
+			oddDaoMock:      oddDao,
+			expectedStatus:  backtests.StatusFinished,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			models.ModelDao = testModelDao()
+			backtests.BacktestDao = testCase.backtestDaoMock
+			fixtures.FixtureDao = testCase.fixtureDaoMock
+			odds.OddDao = testCase.oddDaoMock
+			finished, saved = nil, nil
+
+			res, err := BacktestService.Run(context.Background(), &testCase.req)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedStatus == "" {
+				return
+			}
+			assert.Equal(t, testCase.expectedStatus, finished.Status)
+			assert.NotNil(t, finished.FinishedAt)
+			if err != nil {
+				assert.Nil(t, res)
+				assert.NotEmpty(t, finished.Error)
+				assert.Nil(t, saved)
+				return
+			}
+
+			assert.Equal(t, finished, res)
+			assert.Equal(t, "poisson@1", res.Model)
+			assert.Equal(t, config.Default().ValueBets.MinEdge, res.MinEdge)
+			// The first round is skipped and the last fixture is fitted without the one that kicked off before it
+			assert.Equal(t, int64(2), res.Fixtures)
+			assert.Equal(t, int64(2), res.Skipped)
+			assert.Len(t, saved, 2)
+			assert.Equal(t, int64(3), saved[0].FixtureID)
+			assert.Equal(t, int64(2), saved[0].MatchesUsed)
+			assert.Equal(t, int64(4), saved[1].FixtureID)
+			assert.Equal(t, int64(2), saved[1].MatchesUsed)
+			assert.Equal(t, odds.SelectionHome, saved[0].Result)
+			assert.Equal(t, odds.SelectionAway, saved[1].Result)
+
+			// The home win of the fixture 3 is bet on and won, the fixture 4 has no odds
+			assert.Equal(t, odds.SelectionHome, saved[0].Bet)
+			assert.Equal(t, 3.0, saved[0].Price)
+			assert.Equal(t, 2.0, saved[0].Profit)
+			assert.Equal(t, "", saved[1].Bet)
+			assert.Equal(t, int64(1), res.Bets)
+			assert.Equal(t, 1.0, res.Staked)
+			assert.Equal(t, 2.0, res.Profit)
+			assert.Equal(t, 2.0, *res.ROI)
+
+			var logLoss, brier, rps float64
+			for i, result := range []int{predictor.HomeWin, predictor.AwayWin} {
+				probabilities := []float64{saved[i].HomeWin, saved[i].Draw, saved[i].AwayWin}
+				logLoss += predictor.LogLoss(probabilities, result) / 2
+				brier += predictor.Brier(probabilities, result) / 2
+				rps += predictor.RPS(probabilities, result) / 2
+			}
+			assert.InDelta(t, logLoss, *res.LogLoss, 1e-9)
+			assert.InDelta(t, brier, *res.Brier, 1e-9)
+			assert.InDelta(t, rps, *res.RPS, 1e-9)
+			assert.InDelta(t, 0.5, *res.Accuracy, 1e-9)
+		})
+	}
+}
+
+func TestBacktestService_RunAvailability(t *testing.T) {
+	fixtures.FixtureDao = &MockFixtureDao{
+		FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+			if req.Season == 2021 {
+				return testBacktestFixtures(), 5, nil
+			}
+			return nil, 0, nil
+		},
+	}
+	odds.OddDao = &MockOddDao{
+		FuncLatest: func(req *odds.LatestOddInput) ([]odds.Odd, error) {
+			return nil, nil
+		},
+	}
+	var saved []backtests.Prediction
+	backtests.BacktestDao = &MockBacktestDao{
+		FuncCreate: func(backtest *backtests.Backtest) error {
+			return nil
+		},
+		FuncStart: func(backtest *backtests.Backtest) error {
+			return nil
+		},
+		FuncFinish: func(backtest *backtests.Backtest, predictions []backtests.Prediction) error {
+			saved = predictions
+			return nil
+		},
+	}
+	// poisson@3 is poisson@1 giving the players missing the fixture their full weight
+	modelDao := testModelDao()
+	modelDao.FuncFindByVersion = func(name string, version int64) (*models.Model, error) {
+		if version == 3 {
+			return &models.Model{ID: 3, Name: "poisson", Version: 3, Hyperparameters: json.RawMessage(`{"availability_weight":1}`)}, nil
+		}
+		return &models.Model{ID: 1, Name: "poisson", Version: 1, Hyperparameters: json.RawMessage("{}"), Champion: true}, nil
+	}
+	models.ModelDao = modelDao
+	// A third of the home eleven of the fixture 3 is missing
+	unavailable := []injuries.Unavailable{
+		{Injury: injuries.Injury{FixtureID: 3, PlayerID: 276, TeamID: 1, Type: injuries.TypeMissing}, MinutesShare: 1},
+		{Injury: injuries.Injury{FixtureID: 3, PlayerID: 277, TeamID: 1, Type: injuries.TypeMissing}, MinutesShare: 1},
+		{Injury: injuries.Injury{FixtureID: 3, PlayerID: 278, TeamID: 1, Type: injuries.TypeMissing}, MinutesShare: 1.67},
+	}
+
+	testCases := []struct {
+		title       string
+		listErr     error
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title:       "error InjuryDao.ListByFixture",
+			listErr:     errors.New("error ListByFixture"),
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			injuries.InjuryDao = &MockInjuryDao{
+				FuncListByFixture: func(fixtureID int64) ([]injuries.Unavailable, error) {
+					if fixtureID != 3 {
+						return nil, testCase.listErr
+					}
+					return unavailable, testCase.listErr
+				},
+			}
+			saved = nil
+
+			_, err := BacktestService.Run(context.Background(), &backtests.CreateBacktestInput{LeagueID: 39, SeasonFrom: 2021, SeasonTo: 2021, Model: "poisson@3"})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if err != nil {
+				assert.Nil(t, saved)
+				return
+			}
+			adjusted := saved
+			_, err = BacktestService.Run(context.Background(), &backtests.CreateBacktestInput{LeagueID: 39, SeasonFrom: 2021, SeasonTo: 2021, Model: "poisson@1"})
+			assert.Nil(t, err)
+			assert.Len(t, adjusted, 2)
+			assert.Less(t, adjusted[0].HomeWin, saved[0].HomeWin)
+			assert.InDelta(t, 1, adjusted[0].HomeWin+adjusted[0].Draw+adjusted[0].AwayWin, 1e-9)
+			// Nobody misses the fixture 4
+			assert.InDelta(t, saved[1].HomeWin, adjusted[1].HomeWin, 1e-9)
+		})
+	}
+}
+
+func TestBacktestService_Create(t *testing.T) {
+	var finished *backtests.Backtest
+	models.ModelDao = testModelDao()
+	backtests.BacktestDao = &MockBacktestDao{
+		FuncCreate: func(backtest *backtests.Backtest) error {
+			backtest.ID = 1
+			return nil
+		},
+		FuncStart: func(backtest *backtests.Backtest) error {
+			return nil
+		},
+		FuncFinish: func(backtest *backtests.Backtest, predictions []backtests.Prediction) error {
+			finished = backtest
+			return nil
+		},
+	}
+	fixtures.FixtureDao = &MockFixtureDao{
+		FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+			return nil, 0, nil
+		},
+	}
+	var started bool
+	runAsync = func(fn func()) {
+		started = true
+		fn()
+	}
+	defer func() {
+		runAsync = func(fn func()) { go fn() }
+	}()
+	minEdge := 0.1
+
+	res, err := BacktestService.Create(context.Background(), &backtests.CreateBacktestInput{LeagueID: 39, SeasonFrom: 2021, SeasonTo: 2021, MinEdge: &minEdge})
+
+	assert.Nil(t, err)
+	assert.True(t, started)
+	// The response is the pending backtest, the run updates its own copy
+	assert.Equal(t, int64(1), res.ID)
+	assert.Equal(t, backtests.StatusPending, res.Status)
+	assert.Equal(t, 0.1, res.MinEdge)
+	assert.Equal(t, backtests.StatusFailed, finished.Status)
+	assert.Equal(t, "NOT_ENOUGH_DATA", finished.Error)
+}
+
+func TestBacktestService_Find(t *testing.T) {
+	testCases := []struct {
+		title           string
+		backtestDaoMock backtests.BacktestDaoI
+		expectedRes     *backtests.Backtest
+		expectedErr     resterror.RestErrorI
+	}{
+		{
+			title: "error BacktestDao.FindByID",
+			backtestDaoMock: &MockBacktestDao{
+				FuncFindByID: func(id int64) (*backtests.Backtest, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "backtest"),
+		},
+		{
+			title: "success",
+			backtestDaoMock: &MockBacktestDao{
+				FuncFindByID: func(id int64) (*backtests.Backtest, error) {
+					return &backtests.Backtest{ID: id}, nil
+				},
+			},
+			expectedRes: &backtests.Backtest{ID: 1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			backtests.BacktestDao = testCase.backtestDaoMock
+
+			res, err := BacktestService.Find(context.Background(), 1)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestBacktestService_List(t *testing.T) {
+	testCases := []struct {
+		title           string
+		backtestDaoMock backtests.BacktestDaoI
+		expectedRes     *pagination.PaginatedResponse
+		expectedErr     resterror.RestErrorI
+	}{
+		{
+			title: "error BacktestDao.List",
+			backtestDaoMock: &MockBacktestDao{
+				FuncList: func(req *backtests.ListBacktestInput) ([]backtests.Backtest, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			backtestDaoMock: &MockBacktestDao{
+				FuncList: func(req *backtests.ListBacktestInput) ([]backtests.Backtest, int64, error) {
+					return []backtests.Backtest{{ID: 1}}, 1, nil
+				},
+			},
+			expectedRes: &pagination.PaginatedResponse{
+				From:        1,
+				Data:        []backtests.Backtest{{ID: 1}},
+				CurrentPage: 1,
+				LastPage:    1,
+				PerPage:     20,
+				To:          1,
+				Total:       1,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			backtests.BacktestDao = testCase.backtestDaoMock
+
+			res, err := BacktestService.List(context.Background(), &backtests.ListBacktestInput{})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestBacktestService_Predictions(t *testing.T) {
+	found := func(id int64) (*backtests.Backtest, error) {
+		return &backtests.Backtest{ID: id}, nil
+	}
+	testCases := []struct {
+		title           string
+		backtestDaoMock backtests.BacktestDaoI
+		expectedRes     *pagination.PaginatedResponse
+		expectedErr     resterror.RestErrorI
+	}{
+		{
+			title: "error BacktestDao.FindByID",
+			backtestDaoMock: &MockBacktestDao{
+				FuncFindByID: func(id int64) (*backtests.Backtest, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "backtest"),
+		},
+		{
+			title: "error BacktestDao.ListPredictions",
+			backtestDaoMock: &MockBacktestDao{
+				FuncFindByID: found,
+				FuncListPredictions: func(req *backtests.ListPredictionInput) ([]backtests.Prediction, int64, error) {
+					return nil, 0, errors.New("error ListPredictions")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			backtestDaoMock: &MockBacktestDao{
+				FuncFindByID: found,
+				FuncListPredictions: func(req *backtests.ListPredictionInput) ([]backtests.Prediction, int64, error) {
+					return []backtests.Prediction{{BacktestID: req.BacktestID, FixtureID: 10}}, 1, nil
+				},
+			},
+			expectedRes: &pagination.PaginatedResponse{
+				From:        1,
+				Data:        []backtests.Prediction{{BacktestID: 1, FixtureID: 10}},
+				CurrentPage: 1,
+				LastPage:    1,
+				PerPage:     20,
+				To:          1,
+				Total:       1,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			backtests.BacktestDao = testCase.backtestDaoMock
+
+			res, err := BacktestService.Predictions(context.Background(), &backtests.ListPredictionInput{BacktestID: 1})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/brackets"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/leagues"
+	"github.com/development-raul/footy-predictor/src/domains/rounds"
+	"github.com/development-raul/footy-predictor/src/predictor"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"sort"
+)
+
+// defaultQualifiers is the number of teams of each group going through to the knockout rounds
+const defaultQualifiers = 2
+
+type BracketServiceI interface {
+	Find(ctx context.Context, leagueID int64, season int64) (*brackets.Bracket, resterror.RestErrorI)
+	Simulate(ctx context.Context, req *brackets.BracketSimulationInput) (*brackets.BracketSimulation, resterror.RestErrorI)
+}
+
+type bracketService struct{}
+
+var BracketService BracketServiceI = &bracketService{}
+
+// knockoutTie is a tie of the bracket with its played legs
+type knockoutTie struct {
+	brackets.Tie
+	played []predictor.Match
+}
+
+// Find returns the knockout rounds of a cup season with their ties, built from the fixtures of the rounds
+func (s *bracketService) Find(ctx context.Context, leagueID int64, season int64) (*brackets.Bracket, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "BracketService.Find")
+	defer span.End()
+
+	seasonRounds, res, apiErr := cupSeason(ctx, leagueID, season)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	bracket := &brackets.Bracket{LeagueID: leagueID, Season: season, Rounds: []brackets.Round{}}
+	for _, round := range seasonRounds {
+		if !round.Knockout() {
+			continue
+		}
+		r := brackets.Round{Name: round.Name, Legs: round.Legs, Ties: []brackets.Tie{}}
+		for _, tie := range knockoutTies(round, res) {
+			r.Ties = append(r.Ties, tie.Tie)
+		}
+		bracket.Rounds = append(bracket.Rounds, r)
+	}
+	return bracket, nil
+}
+
+// Simulate plays the group fixtures and the knockout ties left in a cup season many times with the model fitted
+// on the results known now, see predictor.SimulateCup. The model is a name@version reference, the champion when
+// empty
+func (s *bracketService) Simulate(ctx context.Context, req *brackets.BracketSimulationInput) (*brackets.BracketSimulation, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "BracketService.Simulate")
+	defer span.End()
+
+	seasonRounds, res, apiErr := cupSeason(ctx, req.LeagueID, req.Season)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	version, apiErr := resolveModel(ctx, req.Model)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	now := helpers.GetNow()
+	model, _, apiErr := fitModel(ctx, version, req.LeagueID, req.Season, now)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	simulation := &brackets.BracketSimulation{
+		LeagueID:   req.LeagueID,
+		Season:     req.Season,
+		Model:      version.Ref(),
+		Runs:       defaultSimulationRuns,
+		Seed:       now.UnixNano(),
+		Qualifiers: defaultQualifiers,
+	}
+	if req.Runs != 0 {
+		simulation.Runs = req.Runs
+	}
+	if req.Seed != nil {
+		simulation.Seed = *req.Seed
+	}
+	if req.Qualifiers != nil {
+		simulation.Qualifiers = *req.Qualifiers
+	}
+
+	cup := predictor.Cup{Qualifiers: simulation.Qualifiers}
+	names := make(map[int64]string)
+	groups := make(map[string]bool)
+	for _, round := range seasonRounds {
+		if round.Stage == rounds.StageGroup {
+			groups[round.Name] = true
+		}
+	}
+	for _, f := range res {
+		if !groups[f.Round] {
+			continue
+		}
+		names[f.HomeTeamID], names[f.AwayTeamID] = f.HomeTeamName, f.AwayTeamName
+		match := predictor.Match{HomeTeamID: f.HomeTeamID, AwayTeamID: f.AwayTeamID, PlayedAt: f.KickoffAt}
+		switch {
+		case f.Finished():
+			match.HomeGoals, match.AwayGoals = *f.HomeGoals, *f.AwayGoals
+			cup.GroupPlayed = append(cup.GroupPlayed, match)
+		case !f.Cancelled():
+			cup.GroupRemaining = append(cup.GroupRemaining, match)
+		}
+	}
+	for _, round := range seasonRounds {
+		if !round.Knockout() {
+			continue
+		}
+		knockout := predictor.KnockoutRound{Legs: int(round.Legs)}
+		for _, tie := range knockoutTies(round, res) {
+			names[tie.HomeTeamID], names[tie.AwayTeamID] = tie.HomeTeamName, tie.AwayTeamName
+			t := predictor.Tie{HomeTeamID: tie.HomeTeamID, AwayTeamID: tie.AwayTeamID, Played: tie.played}
+			if tie.WinnerTeamID != nil {
+				t.WinnerTeamID = *tie.WinnerTeamID
+			}
+			knockout.Ties = append(knockout.Ties, t)
+		}
+		cup.Rounds = append(cup.Rounds, knockout)
+		simulation.Rounds = append(simulation.Rounds, round.Name)
+	}
+
+	outcome := predictor.SimulateCup(model, cup, simulation.Runs, simulation.Seed)
+	for _, t := range outcome.Teams {
+		simulation.Teams = append(simulation.Teams, brackets.Team{
+			TeamID:   t.TeamID,
+			TeamName: names[t.TeamID],
+			Rounds:   t.Rounds,
+			Winner:   t.Winner,
+		})
+	}
+	// The teams most likely to win the cup first, then the ones most likely to go the furthest
+	sort.SliceStable(simulation.Teams, func(i, j int) bool {
+		a, b := simulation.Teams[i], simulation.Teams[j]
+		if a.Winner != b.Winner {
+			return a.Winner > b.Winner
+		}
+		for r := len(a.Rounds) - 1; r >= 0; r-- {
+			if a.Rounds[r] != b.Rounds[r] {
+				return a.Rounds[r] > b.Rounds[r]
+			}
+		}
+		return false
+	})
+	return simulation, nil
+}
+
+// cupSeason returns the rounds and the fixtures of a cup season. The error is NOT_A_CUP for the leagues, which
+// have no knockout rounds, and ROUNDS_NOT_FOUND until the fixtures of a knockout round are synced
+func cupSeason(ctx context.Context, leagueID int64, season int64) ([]rounds.Round, []fixtures.Fixture, resterror.RestErrorI) {
+	league, err := leagues.LeagueDao.FindByID(ctx, leagueID)
+	if err != nil {
+		return nil, nil, resterror.NewDatabaseError(err, "league")
+	}
+	if league.Type != "Cup" {
+		return nil, nil, resterror.NewUnprocessableEntityError("NOT_A_CUP")
+	}
+	seasonRounds, err := rounds.RoundDao.ListBySeason(ctx, leagueID, season)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, nil, resterror.NewStandardInternalServerError()
+	}
+	knockout := false
+	for i := range seasonRounds {
+		knockout = knockout || seasonRounds[i].Knockout()
+	}
+	if !knockout {
+		return nil, nil, resterror.NewNotFoundError("ROUNDS_NOT_FOUND")
+	}
+	res, apiErr := seasonFixtures(ctx, leagueID, season)
+	if apiErr != nil {
+		return nil, nil, apiErr
+	}
+	return seasonRounds, res, nil
+}
+
+// knockoutTies groups the fixtures of a knockout round by pair of teams, in the order of their first leg. A
+// tie is decided once its legs are played, by the aggregate or else by the winner of its last leg, which
+// API Sports sets after extra time or a penalty shootout
+func knockoutTies(round rounds.Round, res []fixtures.Fixture) []knockoutTie {
+	var legs []fixtures.Fixture
+	for _, f := range res {
+		if f.Round == round.Name && !f.Cancelled() {
+			legs = append(legs, f)
+		}
+	}
+	sort.SliceStable(legs, func(i, j int) bool { return legs[i].KickoffAt.Before(legs[j].KickoffAt) })
+
+	var ties []knockoutTie
+	index := make(map[[2]int64]int)
+	var last []*int64
+	for _, f := range legs {
+		key := [2]int64{f.HomeTeamID, f.AwayTeamID}
+		if key[0] > key[1] {
+			key[0], key[1] = key[1], key[0]
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(ties)
+			index[key] = i
+			ties = append(ties, knockoutTie{Tie: brackets.Tie{
+				HomeTeamID:   f.HomeTeamID,
+				HomeTeamName: f.HomeTeamName,
+				AwayTeamID:   f.AwayTeamID,
+				AwayTeamName: f.AwayTeamName,
+			}})
+			last = append(last, nil)
+		}
+		tie := &ties[i]
+		tie.FixtureIDs = append(tie.FixtureIDs, f.ID)
+		if !f.Finished() {
+			continue
+		}
+		homeGoals, awayGoals := *f.HomeGoals, *f.AwayGoals
+		if f.HomeTeamID != tie.HomeTeamID {
+			homeGoals, awayGoals = awayGoals, homeGoals
+		}
+		if tie.HomeGoals == nil {
+			tie.HomeGoals, tie.AwayGoals = new(int64), new(int64)
+		}
+		*tie.HomeGoals += homeGoals
+		*tie.AwayGoals += awayGoals
+		tie.played = append(tie.played, predictor.Match{
+			HomeTeamID: f.HomeTeamID,
+			AwayTeamID: f.AwayTeamID,
+			HomeGoals:  *f.HomeGoals,
+			AwayGoals:  *f.AwayGoals,
+			PlayedAt:   f.KickoffAt,
+		})
+		last[i] = f.WinnerTeamID
+	}
+
+	for i := range ties {
+		tie := &ties[i]
+		if len(tie.played) == 0 || int64(len(tie.played)) < round.Legs {
+			continue
+		}
+		switch {
+		case *tie.HomeGoals > *tie.AwayGoals:
+			tie.WinnerTeamID = &tie.HomeTeamID
+		case *tie.HomeGoals < *tie.AwayGoals:
+			tie.WinnerTeamID = &tie.AwayTeamID
+		default:
+			tie.WinnerTeamID = last[i]
+		}
+	}
+	return ties
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/domains/brackets"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/leagues"
+	"github.com/development-raul/footy-predictor/src/domains/models"
+	"github.com/development-raul/footy-predictor/src/domains/rounds"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type MockRoundDao struct {
+	FuncUpsert       func(round *rounds.Round) error
+	FuncListBySeason func(leagueID int64, season int64) ([]rounds.Round, error)
+}
+
+func (m MockRoundDao) Upsert(ctx context.Context, round *rounds.Round) error {
+	return m.FuncUpsert(round)
+}
+func (m MockRoundDao) ListBySeason(ctx context.Context, leagueID int64, season int64) ([]rounds.Round, error) {
+	return m.FuncListBySeason(leagueID, season)
+}
+
+// testCup is a cup season of league 2: a group of four teams, then two legged semi-finals and a final. Team 1 won
+// its semi-final on aggregate, team 2 and team 4 drew the first leg of theirs. The final is not drawn yet
+func testCup() ([]rounds.Round, []fixtures.Fixture) {
+	goals := func(v int64) *int64 { return &v }
+	start := time.Date(2021, 9, 14, 20, 0, 0, 0, time.UTC)
+	fixture := func(round string, home int64, away int64, homeGoals *int64, awayGoals *int64) fixtures.Fixture {
+		f := fixtures.Fixture{LeagueID: 2, Season: 2021, Round: round, Status: "FT", HomeTeamID: home, AwayTeamID: away,
+			HomeTeamName: fmt.Sprintf("Team %d", home), AwayTeamName: fmt.Sprintf("Team %d", away),
+			HomeGoals: homeGoals, AwayGoals: awayGoals}
+		if homeGoals == nil {
+			f.Status = "NS"
+		}
+		return f
+	}
+	var season []fixtures.Fixture
+	for home := int64(1); home <= 4; home++ {
+		for away := int64(1); away <= 4; away++ {
+			if home == away {
+				continue
+			}
+			// Team 1 won all its group fixtures 3-1, the others were draws
+			f := fixture("Group A - 1", home, away, goals(1), goals(1))
+			if home == 1 {
+				f.HomeGoals = goals(3)
+			} else if away == 1 {
+				f.AwayGoals = goals(3)
+			}
+			season = append(season, f)
+		}
+	}
+	season = append(season,
+		fixture("Semi-finals", 1, 3, goals(1), goals(0)),
+		fixture("Semi-finals", 2, 4, goals(2), goals(2)),
+		fixture("Semi-finals", 3, 1, goals(1), goals(1)),
+		fixture("Semi-finals", 4, 2, nil, nil),
+	)
+	for i := range season {
+		season[i].ID = int64(100 + i)
+		season[i].KickoffAt = start.AddDate(0, 0, 7*i)
+	}
+	return []rounds.Round{
+		{LeagueID: 2, Season: 2021, Name: "Group A - 1", Position: 1, Stage: rounds.StageGroup, Legs: 2},
+		{LeagueID: 2, Season: 2021, Name: "Semi-finals", Position: 2, Stage: rounds.StageKnockout, Legs: 2},
+		{LeagueID: 2, Season: 2021, Name: "Final", Position: 3, Stage: rounds.StageKnockout, Legs: 1},
+	}, season
+}
+
+func testBracketDaos() (*MockLeagueDao, *MockRoundDao, *MockFixtureDao) {
+	seasonRounds, season := testCup()
+	leagueDao := &MockLeagueDao{
+		FuncFindByID: func(id int64) (*leagues.League, error) {
+			switch id {
+			case 2:
+				return &leagues.League{ID: 2, Type: "Cup"}, nil
+			case 39:
+				return &leagues.League{ID: 39, Type: "League"}, nil
+			}
+			return nil, sql.ErrNoRows
+		},
+	}
+	roundDao := &MockRoundDao{
+		FuncListBySeason: func(leagueID int64, s int64) ([]rounds.Round, error) {
+			switch s {
+			case 2021:
+				return seasonRounds, nil
+			case 2019:
+				return nil, errors.New("error ListBySeason")
+			}
+			return nil, nil
+		},
+	}
+	fixtureDao := &MockFixtureDao{
+		FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+			if req.Season != 2021 {
+				return nil, 0, nil
+			}
+			return season, int64(len(season)), nil
+		},
+	}
+	return leagueDao, roundDao, fixtureDao
+}
+
+func TestBracketService_Find(t *testing.T) {
+	testCases := []struct {
+		title       string
+		leagueID    int64
+		season      int64
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title:       "error league not found",
+			leagueID:    3,
+			season:      2021,
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "league"),
+		},
+		{
+			title:       "error league",
+			leagueID:    39,
+			season:      2021,
+			expectedErr: resterror.NewUnprocessableEntityError("NOT_A_CUP"),
+		},
+		{
+			title:       "error RoundDao.ListBySeason",
+			leagueID:    2,
+			season:      2019,
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:       "error no knockout round",
+			leagueID:    2,
+			season:      2020,
+			expectedErr: resterror.NewNotFoundError("ROUNDS_NOT_FOUND"),
+		},
+		{
+			title:    "success",
+			leagueID: 2,
+			season:   2021,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			leagues.LeagueDao, rounds.RoundDao, fixtures.FixtureDao = testBracketDaos()
+
+			res, err := BracketService.Find(context.Background(), testCase.leagueID, testCase.season)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if err != nil {
+				assert.Nil(t, res)
+				return
+			}
+			goals := func(v int64) *int64 { return &v }
+			winner := int64(1)
+			assert.Equal(t, &brackets.Bracket{LeagueID: 2, Season: 2021, Rounds: []brackets.Round{
+				{Name: "Semi-finals", Legs: 2, Ties: []brackets.Tie{
+					{HomeTeamID: 1, HomeTeamName: "Team 1", AwayTeamID: 3, AwayTeamName: "Team 3", FixtureIDs: []int64{112, 114},
+						HomeGoals: goals(2), AwayGoals: goals(1), WinnerTeamID: &winner},
+					{HomeTeamID: 2, HomeTeamName: "Team 2", AwayTeamID: 4, AwayTeamName: "Team 4", FixtureIDs: []int64{113, 115},
+						HomeGoals: goals(2), AwayGoals: goals(2)},
+				}},
+				{Name: "Final", Legs: 1, Ties: []brackets.Tie{}},
+			}}, res)
+		})
+	}
+}
+
+func TestBracketService_Simulate(t *testing.T) {
+	seed, qualifiers := int64(7), 2
+
+	testCases := []struct {
+		title       string
+		req         brackets.BracketSimulationInput
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title:       "error league",
+			req:         brackets.BracketSimulationInput{LeagueID: 39, Season: 2021},
+			expectedErr: resterror.NewUnprocessableEntityError("NOT_A_CUP"),
+		},
+		{
+			title:       "error model not found",
+			req:         brackets.BracketSimulationInput{LeagueID: 2, Season: 2021, Model: "elo"},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "model"),
+		},
+		{
+			title: "success",
+			req:   brackets.BracketSimulationInput{LeagueID: 2, Season: 2021, Model: "dixon-coles", Runs: 1000, Seed: &seed, Qualifiers: &qualifiers},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			leagues.LeagueDao, rounds.RoundDao, fixtures.FixtureDao = testBracketDaos()
+			models.ModelDao = testModelDao()
+
+			res, err := BracketService.Simulate(context.Background(), &testCase.req)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if err != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, "dixon-coles@2", res.Model)
+			assert.Equal(t, 1000, res.Runs)
+			assert.Equal(t, int64(7), res.Seed)
+			assert.Equal(t, 2, res.Qualifiers)
+			assert.Equal(t, []string{"Semi-finals", "Final"}, res.Rounds)
+			assert.Len(t, res.Teams, 4)
+			progress := make(map[int64]brackets.Team)
+			var winners float64
+			for i, team := range res.Teams {
+				progress[team.TeamID] = team
+				assert.Equal(t, fmt.Sprintf("Team %d", team.TeamID), team.TeamName)
+				assert.Equal(t, 1.0, team.Rounds[0])
+				winners += team.Winner
+				if i > 0 {
+					assert.GreaterOrEqual(t, res.Teams[i-1].Winner, team.Winner)
+				}
+			}
+			assert.InDelta(t, 1, winners, 1e-9)
+			// Team 1 is through to the final and team 3 is out
+			assert.Equal(t, 1.0, progress[1].Rounds[1])
+			assert.Equal(t, 0.0, progress[3].Rounds[1])
+			assert.InDelta(t, 1, progress[2].Rounds[1]+progress[4].Rounds[1], 1e-9)
+
+			again, _ := BracketService.Simulate(context.Background(), &testCase.req)
+			assert.Equal(t, res, again)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/domains/competitions"
+	"github.com/development-raul/footy-predictor/src/domains/memberships"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+)
+
+const errorNotCompetitionOwner = "NOT_COMPETITION_OWNER"
+
+type CompetitionServiceI interface {
+	Create(ctx context.Context, userID int64, req *competitions.CompetitionInput) (*competitions.Competition, resterror.RestErrorI)
+	Update(ctx context.Context, userID int64, req *competitions.UpdateCompetitionInput) resterror.RestErrorI
+	Find(ctx context.Context, id int64) (*competitions.Competition, resterror.RestErrorI)
+	List(ctx context.Context, req *competitions.ListCompetitionInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	Delete(ctx context.Context, userID int64, id int64) resterror.RestErrorI
+	Join(ctx context.Context, userID int64, id int64) resterror.RestErrorI
+	Leave(ctx context.Context, userID int64, id int64) resterror.RestErrorI
+	Leaderboard(ctx context.Context, id int64, req *memberships.ListLeaderboardInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+}
+
+type competitionService struct{}
+
+var CompetitionService CompetitionServiceI = &competitionService{}
+
+func (s *competitionService) Create(ctx context.Context, userID int64, req *competitions.CompetitionInput) (*competitions.Competition, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "CompetitionService.Create")
+	defer span.End()
+
+	competition := competitions.Competition{
+		Name:                 req.Name,
+		OwnerUserID:          userID,
+		PointsExact:          req.PointsExact,
+		PointsGoalDifference: req.PointsGoalDifference,
+		PointsResult:         req.PointsResult,
+		CreatedAt:            helpers.GetNow(),
+	}
+	// The owner automatically takes part in the competition, a competition is never left without its owner
+	err := footy_db.Transaction(ctx, func(ctx context.Context) error {
+		if err := competitions.CompetitionDao.Create(ctx, &competition); err != nil {
+			return err
+		}
+		return memberships.MembershipDao.Create(ctx, &memberships.Membership{
+			CompetitionID: competition.ID,
+			UserID:        userID,
+			JoinedAt:      competition.CreatedAt,
+		})
+	})
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "competition")
+	}
+
+	return &competition, nil
+}
+
+func (s *competitionService) Update(ctx context.Context, userID int64, req *competitions.UpdateCompetitionInput) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "CompetitionService.Update")
+	defer span.End()
+
+	if _, apiErr := s.findOwned(ctx, userID, req.ID); apiErr != nil {
+		return apiErr
+	}
+
+	if err := competitions.CompetitionDao.Update(ctx, req); err != nil {
+		return resterror.NewDatabaseError(err, "competition")
+	}
+	return nil
+}
+
+func (s *competitionService) Find(ctx context.Context, id int64) (*competitions.Competition, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "CompetitionService.Find")
+	defer span.End()
+
+	res, err := competitions.CompetitionDao.FindByID(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "competition")
+	}
+	return res, nil
+}
+
+func (s *competitionService) List(ctx context.Context, req *competitions.ListCompetitionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "CompetitionService.List")
+	defer span.End()
+
+	results, total, err := competitions.CompetitionDao.List(ctx, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	res := pagination.GeneratePaginatedResponse(req.Filter.Project(results), req.Page, req.PerPage, total)
+	res.NextCursor, res.PrevCursor = req.Filter.Cursors()
+
+	return &res, nil
+}
+
+func (s *competitionService) Delete(ctx context.Context, userID int64, id int64) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "CompetitionService.Delete")
+	defer span.End()
+
+	if _, apiErr := s.findOwned(ctx, userID, id); apiErr != nil {
+		return apiErr
+	}
+
+	if err := competitions.CompetitionDao.Delete(ctx, id); err != nil {
+		return resterror.NewDatabaseError(err, "competition")
+	}
+	return nil
+}
+
+func (s *competitionService) Join(ctx context.Context, userID int64, id int64) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "CompetitionService.Join")
+	defer span.End()
+
+	if _, apiErr := s.findExisting(ctx, id); apiErr != nil {
+		return apiErr
+	}
+
+	member, err := memberships.MembershipDao.Exists(ctx, id, userID)
+	if err != nil {
+		return resterror.NewStandardInternalServerError()
+	}
+	if member {
+		return resterror.NewConflictError("ALREADY_A_MEMBER")
+	}
+
+	if err := memberships.MembershipDao.Create(ctx, &memberships.Membership{
+		CompetitionID: id,
+		UserID:        userID,
+		JoinedAt:      helpers.GetNow(),
+	}); err != nil {
+		return resterror.NewDatabaseError(err, "membership")
+	}
+	return nil
+}
+
+func (s *competitionService) Leave(ctx context.Context, userID int64, id int64) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "CompetitionService.Leave")
+	defer span.End()
+
+	competition, apiErr := s.findExisting(ctx, id)
+	if apiErr != nil {
+		return apiErr
+	}
+	// The owner has to delete the competition instead
+	if competition.OwnerUserID == userID {
+		return resterror.NewBadRequestError("OWNER_CANNOT_LEAVE")
+	}
+
+	if err := memberships.MembershipDao.Delete(ctx, id, userID); err != nil {
+		return resterror.NewDatabaseError(err, "membership")
+	}
+	return nil
+}
+
+func (s *competitionService) Leaderboard(ctx context.Context, id int64, req *memberships.ListLeaderboardInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "CompetitionService.Leaderboard")
+	defer span.End()
+
+	if _, apiErr := s.findExisting(ctx, id); apiErr != nil {
+		return nil, apiErr
+	}
+
+	results, total, err := memberships.MembershipDao.Leaderboard(ctx, id, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	// Positions continue across pages
+	perPage := req.PerPage
+	if perPage == 0 {
+		perPage = constants.DefaultPerPage
+	}
+	offset := int64(0)
+	if req.Page > 1 {
+		offset = (req.Page - 1) * perPage
+	}
+	for i := range results {
+		results[i].Position = offset + int64(i) + 1
+	}
+
+	res := pagination.GeneratePaginatedResponse(results, req.Page, req.PerPage, total)
+
+	return &res, nil
+}
+
+func (s *competitionService) findExisting(ctx context.Context, id int64) (*competitions.Competition, resterror.RestErrorI) {
+	competition, err := competitions.CompetitionDao.FindByID(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "competition")
+	}
+	return competition, nil
+}
+
+func (s *competitionService) findOwned(ctx context.Context, userID int64, id int64) (*competitions.Competition, resterror.RestErrorI) {
+	competition, apiErr := s.findExisting(ctx, id)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if competition.OwnerUserID != userID {
+		return nil, resterror.NewForbiddenError(errorNotCompetitionOwner)
+	}
+	return competition, nil
+}
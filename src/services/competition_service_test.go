@@ -0,0 +1,546 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/domains/competitions"
+	"github.com/development-raul/footy-predictor/src/domains/memberships"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type MockCompetitionDao struct {
+	FuncCreate   func(competition *competitions.Competition) error
+	FuncUpdate   func(competition *competitions.UpdateCompetitionInput) error
+	FuncFindByID func(id int64) (*competitions.Competition, error)
+	FuncList     func(req *competitions.ListCompetitionInput) ([]competitions.Competition, int64, error)
+	FuncDelete   func(id int64) error
+}
+
+func (m MockCompetitionDao) Create(ctx context.Context, competition *competitions.Competition) error {
+	return m.FuncCreate(competition)
+}
+func (m MockCompetitionDao) Update(ctx context.Context, competition *competitions.UpdateCompetitionInput) error {
+	return m.FuncUpdate(competition)
+}
+func (m MockCompetitionDao) FindByID(ctx context.Context, id int64) (*competitions.Competition, error) {
+	return m.FuncFindByID(id)
+}
+func (m MockCompetitionDao) List(ctx context.Context, req *competitions.ListCompetitionInput) ([]competitions.Competition, int64, error) {
+	return m.FuncList(req)
+}
+func (m MockCompetitionDao) Delete(ctx context.Context, id int64) error {
+	return m.FuncDelete(id)
+}
+
+type MockMembershipDao struct {
+	FuncCreate      func(membership *memberships.Membership) error
+	FuncExists      func(competitionID int64, userID int64) (bool, error)
+	FuncLeaderboard func(competitionID int64, req *memberships.ListLeaderboardInput) ([]memberships.LeaderboardEntry, int64, error)
+	FuncDelete      func(competitionID int64, userID int64) error
+}
+
+func (m MockMembershipDao) Create(ctx context.Context, membership *memberships.Membership) error {
+	return m.FuncCreate(membership)
+}
+func (m MockMembershipDao) Exists(ctx context.Context, competitionID int64, userID int64) (bool, error) {
+	return m.FuncExists(competitionID, userID)
+}
+func (m MockMembershipDao) Leaderboard(ctx context.Context, competitionID int64, req *memberships.ListLeaderboardInput) ([]memberships.LeaderboardEntry, int64, error) {
+	return m.FuncLeaderboard(competitionID, req)
+}
+func (m MockMembershipDao) Delete(ctx context.Context, competitionID int64, userID int64) error {
+	return m.FuncDelete(competitionID, userID)
+}
+
+func ownedCompetitionDao(ownerID int64) *MockCompetitionDao {
+	return &MockCompetitionDao{
+		FuncFindByID: func(id int64) (*competitions.Competition, error) {
+			return &competitions.Competition{ID: id, OwnerUserID: ownerID}, nil
+		},
+	}
+}
+
+func TestCompetitionService_Create(t *testing.T) {
+	testCases := []struct {
+		title              string
+		competitionDaoMock competitions.CompetitionDaoI
+		membershipDaoMock  memberships.MembershipDaoI
+		committed          bool
+		expectedErr        resterror.RestErrorI
+	}{
+		{
+			title: "error CompetitionDao.Create",
+			competitionDaoMock: &MockCompetitionDao{
+				FuncCreate: func(competition *competitions.Competition) error {
+					return errors.New("error Create")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error MembershipDao.Create",
+			competitionDaoMock: &MockCompetitionDao{
+				FuncCreate: func(competition *competitions.Competition) error {
+					competition.ID = 4
+					return nil
+				},
+			},
+			membershipDaoMock: &MockMembershipDao{
+				FuncCreate: func(membership *memberships.Membership) error {
+					return errors.New("error Create")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			competitionDaoMock: &MockCompetitionDao{
+				FuncCreate: func(competition *competitions.Competition) error {
+					competition.ID = 4
+					return nil
+				},
+			},
+			membershipDaoMock: &MockMembershipDao{
+				FuncCreate: func(membership *memberships.Membership) error {
+					if membership.CompetitionID != 4 || membership.UserID != 1 {
+						return errors.New("owner not joined")
+					}
+					return nil
+				},
+			},
+			committed:   true,
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, sqlErr := sqlmock.New()
+			if sqlErr != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", sqlErr)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			mock.ExpectBegin()
+			// The competition is removed again when its owner could not join it
+			if testCase.committed {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+			competitions.CompetitionDao = testCase.competitionDaoMock
+			memberships.MembershipDao = testCase.membershipDaoMock
+
+			res, err := CompetitionService.Create(context.Background(), 1, &competitions.CompetitionInput{
+				Name:         "Office",
+				PointsExact:  3,
+				PointsResult: 1,
+			})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Nil(t, mock.ExpectationsWereMet())
+			if testCase.expectedErr != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, int64(4), res.ID)
+			assert.Equal(t, int64(1), res.OwnerUserID)
+		})
+	}
+}
+
+func TestCompetitionService_Update(t *testing.T) {
+	testCases := []struct {
+		title              string
+		competitionDaoMock *MockCompetitionDao
+		expectedErr        resterror.RestErrorI
+	}{
+		{
+			title: "error CompetitionDao.FindByID not found",
+			competitionDaoMock: &MockCompetitionDao{
+				FuncFindByID: func(id int64) (*competitions.Competition, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "competition"),
+		},
+		{
+			title: "error CompetitionDao.FindByID",
+			competitionDaoMock: &MockCompetitionDao{
+				FuncFindByID: func(id int64) (*competitions.Competition, error) {
+					return nil, errors.New("error FindByID")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:              "error not the owner",
+			competitionDaoMock: ownedCompetitionDao(2),
+			expectedErr:        resterror.NewForbiddenError("NOT_COMPETITION_OWNER"),
+		},
+		{
+			title: "error CompetitionDao.Update",
+			competitionDaoMock: func() *MockCompetitionDao {
+				m := ownedCompetitionDao(1)
+				m.FuncUpdate = func(competition *competitions.UpdateCompetitionInput) error {
+					return errors.New("error Update")
+				}
+				return m
+			}(),
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			competitionDaoMock: func() *MockCompetitionDao {
+				m := ownedCompetitionDao(1)
+				m.FuncUpdate = func(competition *competitions.UpdateCompetitionInput) error {
+					return nil
+				}
+				return m
+			}(),
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			competitions.CompetitionDao = testCase.competitionDaoMock
+
+			err := CompetitionService.Update(context.Background(), 1, &competitions.UpdateCompetitionInput{ID: 4, Name: "Office"})
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestCompetitionService_Find(t *testing.T) {
+	testCases := []struct {
+		title              string
+		competitionDaoMock competitions.CompetitionDaoI
+		expectedRes        *competitions.Competition
+		expectedErr        resterror.RestErrorI
+	}{
+		{
+			title: "error CompetitionDao.FindByID",
+			competitionDaoMock: &MockCompetitionDao{
+				FuncFindByID: func(id int64) (*competitions.Competition, error) {
+					return nil, errors.New("error FindByID")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error competition not found",
+			competitionDaoMock: &MockCompetitionDao{
+				FuncFindByID: func(id int64) (*competitions.Competition, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "competition"),
+		},
+		{
+			title:              "success",
+			competitionDaoMock: ownedCompetitionDao(1),
+			expectedRes:        &competitions.Competition{ID: 4, OwnerUserID: 1},
+			expectedErr:        nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			competitions.CompetitionDao = testCase.competitionDaoMock
+
+			res, err := CompetitionService.Find(context.Background(), 4)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestCompetitionService_List(t *testing.T) {
+	testCases := []struct {
+		title              string
+		competitionDaoMock competitions.CompetitionDaoI
+		expectedRes        *pagination.PaginatedResponse
+		expectedErr        resterror.RestErrorI
+	}{
+		{
+			title: "error CompetitionDao.List",
+			competitionDaoMock: &MockCompetitionDao{
+				FuncList: func(req *competitions.ListCompetitionInput) ([]competitions.Competition, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			competitionDaoMock: &MockCompetitionDao{
+				FuncList: func(req *competitions.ListCompetitionInput) ([]competitions.Competition, int64, error) {
+					return []competitions.Competition{{ID: 4}}, 1, nil
+				},
+			},
+			expectedRes: &pagination.PaginatedResponse{
+				From:        1,
+				Data:        []competitions.Competition{{ID: 4}},
+				CurrentPage: 1,
+				LastPage:    1,
+				PerPage:     20,
+				To:          1,
+				Total:       1,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			competitions.CompetitionDao = testCase.competitionDaoMock
+
+			res, err := CompetitionService.List(context.Background(), &competitions.ListCompetitionInput{})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestCompetitionService_Delete(t *testing.T) {
+	testCases := []struct {
+		title              string
+		competitionDaoMock *MockCompetitionDao
+		expectedErr        resterror.RestErrorI
+	}{
+		{
+			title:              "error not the owner",
+			competitionDaoMock: ownedCompetitionDao(2),
+			expectedErr:        resterror.NewForbiddenError("NOT_COMPETITION_OWNER"),
+		},
+		{
+			title: "error CompetitionDao.Delete",
+			competitionDaoMock: func() *MockCompetitionDao {
+				m := ownedCompetitionDao(1)
+				m.FuncDelete = func(id int64) error {
+					return errors.New("error Delete")
+				}
+				return m
+			}(),
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			competitionDaoMock: func() *MockCompetitionDao {
+				m := ownedCompetitionDao(1)
+				m.FuncDelete = func(id int64) error {
+					return nil
+				}
+				return m
+			}(),
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			competitions.CompetitionDao = testCase.competitionDaoMock
+
+			err := CompetitionService.Delete(context.Background(), 1, 4)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestCompetitionService_Join(t *testing.T) {
+	testCases := []struct {
+		title              string
+		competitionDaoMock competitions.CompetitionDaoI
+		membershipDaoMock  memberships.MembershipDaoI
+		expectedErr        resterror.RestErrorI
+	}{
+		{
+			title: "error invalid competition",
+			competitionDaoMock: &MockCompetitionDao{
+				FuncFindByID: func(id int64) (*competitions.Competition, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "competition"),
+		},
+		{
+			title:              "error MembershipDao.Exists",
+			competitionDaoMock: ownedCompetitionDao(2),
+			membershipDaoMock: &MockMembershipDao{
+				FuncExists: func(competitionID int64, userID int64) (bool, error) {
+					return false, errors.New("error Exists")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:              "error already a member",
+			competitionDaoMock: ownedCompetitionDao(2),
+			membershipDaoMock: &MockMembershipDao{
+				FuncExists: func(competitionID int64, userID int64) (bool, error) {
+					return true, nil
+				},
+			},
+			expectedErr: resterror.NewConflictError("ALREADY_A_MEMBER"),
+		},
+		{
+			title:              "error MembershipDao.Create",
+			competitionDaoMock: ownedCompetitionDao(2),
+			membershipDaoMock: &MockMembershipDao{
+				FuncExists: func(competitionID int64, userID int64) (bool, error) {
+					return false, nil
+				},
+				FuncCreate: func(membership *memberships.Membership) error {
+					return errors.New("error Create")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:              "success",
+			competitionDaoMock: ownedCompetitionDao(2),
+			membershipDaoMock: &MockMembershipDao{
+				FuncExists: func(competitionID int64, userID int64) (bool, error) {
+					return false, nil
+				},
+				FuncCreate: func(membership *memberships.Membership) error {
+					return nil
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			competitions.CompetitionDao = testCase.competitionDaoMock
+			memberships.MembershipDao = testCase.membershipDaoMock
+
+			err := CompetitionService.Join(context.Background(), 1, 4)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestCompetitionService_Leave(t *testing.T) {
+	testCases := []struct {
+		title              string
+		competitionDaoMock competitions.CompetitionDaoI
+		membershipDaoMock  memberships.MembershipDaoI
+		expectedErr        resterror.RestErrorI
+	}{
+		{
+			title:              "error owner cannot leave",
+			competitionDaoMock: ownedCompetitionDao(1),
+			expectedErr:        resterror.NewBadRequestError("OWNER_CANNOT_LEAVE"),
+		},
+		{
+			title:              "error MembershipDao.Delete",
+			competitionDaoMock: ownedCompetitionDao(2),
+			membershipDaoMock: &MockMembershipDao{
+				FuncDelete: func(competitionID int64, userID int64) error {
+					return errors.New("error Delete")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:              "success",
+			competitionDaoMock: ownedCompetitionDao(2),
+			membershipDaoMock: &MockMembershipDao{
+				FuncDelete: func(competitionID int64, userID int64) error {
+					return nil
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			competitions.CompetitionDao = testCase.competitionDaoMock
+			memberships.MembershipDao = testCase.membershipDaoMock
+
+			err := CompetitionService.Leave(context.Background(), 1, 4)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestCompetitionService_Leaderboard(t *testing.T) {
+	testCases := []struct {
+		title              string
+		competitionDaoMock competitions.CompetitionDaoI
+		membershipDaoMock  memberships.MembershipDaoI
+		expectedRes        *pagination.PaginatedResponse
+		expectedErr        resterror.RestErrorI
+	}{
+		{
+			title: "error invalid competition",
+			competitionDaoMock: &MockCompetitionDao{
+				FuncFindByID: func(id int64) (*competitions.Competition, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "competition"),
+		},
+		{
+			title:              "error MembershipDao.Leaderboard",
+			competitionDaoMock: ownedCompetitionDao(1),
+			membershipDaoMock: &MockMembershipDao{
+				FuncLeaderboard: func(competitionID int64, req *memberships.ListLeaderboardInput) ([]memberships.LeaderboardEntry, int64, error) {
+					return nil, 0, errors.New("error Leaderboard")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:              "success",
+			competitionDaoMock: ownedCompetitionDao(1),
+			membershipDaoMock: &MockMembershipDao{
+				FuncLeaderboard: func(competitionID int64, req *memberships.ListLeaderboardInput) ([]memberships.LeaderboardEntry, int64, error) {
+					return []memberships.LeaderboardEntry{{UserID: 3, Points: 9}, {UserID: 1, Points: 4}}, 4, nil
+				},
+			},
+			expectedRes: &pagination.PaginatedResponse{
+				From: 3,
+				Data: []memberships.LeaderboardEntry{
+					{Position: 3, UserID: 3, Points: 9},
+					{Position: 4, UserID: 1, Points: 4},
+				},
+				CurrentPage: 2,
+				LastPage:    2,
+				PerPage:     2,
+				To:          4,
+				Total:       4,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			competitions.CompetitionDao = testCase.competitionDaoMock
+			memberships.MembershipDao = testCase.membershipDaoMock
+
+			res, err := CompetitionService.Leaderboard(context.Background(), 4, &memberships.ListLeaderboardInput{Page: 2, PerPage: 2})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
+)
+
+// Configure builds the services that depend on the configuration: the authentication signs and parses the tokens
+// with the auth settings, the sync services call API Sports with its settings and the value bets and backtests
+// default to the value bets settings
+func Configure(cfg *config.Config) {
+	apiSports := api_sports_provider.New(cfg.APISports)
+
+	AuthService = &authService{tokens: auth.NewTokens(cfg.Auth)}
+	CountryService = &countryService{apiSports: apiSports}
+	SeasonService = &seasonService{apiSports: apiSports}
+	LeagueService = &leagueService{apiSports: apiSports}
+	FixtureService = &fixtureService{apiSports: apiSports}
+	OddService = &oddService{apiSports: apiSports}
+	PlayerService = &playerService{apiSports: apiSports}
+	FixtureDetailService = &fixtureDetailService{apiSports: apiSports}
+	InjuryService = &injuryService{apiSports: apiSports}
+	ValueBetService = &valueBetService{settings: cfg.ValueBets}
+	BacktestService = &backtestService{valueBets: cfg.ValueBets}
+}
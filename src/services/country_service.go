@@ -1,86 +1,274 @@
 package services
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"github.com/development-raul/footy-predictor/src/domains/countries"
+	"github.com/development-raul/footy-predictor/src/metrics"
 	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/bulk"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/etag"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
+	"github.com/development-raul/footy-predictor/src/utils/mergepatch"
 	"github.com/development-raul/footy-predictor/src/utils/pagination"
 	"github.com/development-raul/footy-predictor/src/utils/resterror"
 	"github.com/development-raul/footy-predictor/src/zlog"
+	"io"
 )
 
 type CountryServiceI interface {
-	Create(req *countries.CountryInput) resterror.RestErrorI
-	Update(req *countries.UpdateCountryInput, id int64) resterror.RestErrorI
-	Find(id int64) (*countries.CountryOutput, resterror.RestErrorI)
-	List(req *countries.ListCountryInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
-	Delete(id int64) resterror.RestErrorI
-	Sync() resterror.RestErrorI
+	Create(ctx context.Context, req *countries.CountryInput) resterror.RestErrorI
+	Update(ctx context.Context, req *countries.UpdateCountryInput, id int64) resterror.RestErrorI
+	Patch(ctx context.Context, id int64, version int64, patch []byte) (int64, resterror.RestErrorI)
+	Find(ctx context.Context, id int64) (*countries.CountryOutput, resterror.RestErrorI)
+	List(ctx context.Context, req *countries.ListCountryInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	Export(ctx context.Context, req *countries.ListCountryInput, w export.Writer) resterror.RestErrorI
+	Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI)
+	Delete(ctx context.Context, id int64, version int64) resterror.RestErrorI
+	BulkCreate(ctx context.Context, req *countries.BulkCreateCountryInput) (*bulk.Response, resterror.RestErrorI)
+	BulkUpdate(ctx context.Context, req *countries.BulkUpdateCountryInput) (*bulk.Response, resterror.RestErrorI)
+	BulkDelete(ctx context.Context, req *bulk.DeleteInput) (*bulk.Response, resterror.RestErrorI)
+	Sync(ctx context.Context) resterror.RestErrorI
 }
 
-type countryService struct{}
+type countryService struct {
+	apiSports *api_sports_provider.Client
+}
 
 var CountryService CountryServiceI = &countryService{}
 
-func (s *countryService) Create(req *countries.CountryInput) resterror.RestErrorI {
-	if err := countries.CountryDao.Create(&countries.Country{
+func (s *countryService) Create(ctx context.Context, req *countries.CountryInput) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "CountryService.Create")
+	defer span.End()
+
+	if err := countries.CountryDao.Create(ctx, &countries.Country{
 		Code:   req.Code,
 		Name:   req.Name,
 		Flag:   req.Flag,
 		Active: req.Active,
 	}); err != nil {
-		return resterror.NewStandardInternalServerError()
+		return resterror.NewDatabaseError(err, "country")
 	}
 	return nil
 }
 
-func (s *countryService) Update(req *countries.UpdateCountryInput, id int64) resterror.RestErrorI {
-	// Check if the country already exists
-	country, err := countries.CountryDao.FindByID(id)
+func (s *countryService) Update(ctx context.Context, req *countries.UpdateCountryInput, id int64) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "CountryService.Update")
+	defer span.End()
+
+	// Check if the country already exists and is still at the version the client read
+	country, err := countries.CountryDao.FindByID(ctx, id)
 	if err != nil {
-		return resterror.NewBadRequestError("INVALID_COUNTRY_ID")
+		return resterror.NewDatabaseError(err, "country")
+	}
+	if req.Version != etag.Any && req.Version != country.Version {
+		return resterror.NewDatabaseError(dberror.ErrVersionMismatch, "country")
 	}
 
-	// Set the ID and update the records
-	req.ID = country.ID
-	if err := countries.CountryDao.Update(req); err != nil {
-		return resterror.NewStandardInternalServerError()
+	// Set the ID and update the records, the version check is repeated by the update in case of a concurrent change
+	req.ID, req.Version = country.ID, country.Version
+	if err := countries.CountryDao.Update(ctx, req); err != nil {
+		return resterror.NewDatabaseError(err, "country")
 	}
 	return nil
 }
 
-func (s *countryService) Find(id int64) (*countries.CountryOutput, resterror.RestErrorI) {
-	res, err := countries.CountryDao.FindByID(id)
-	if err != nil && err != sql.ErrNoRows {
-		return nil, resterror.NewStandardInternalServerError()
+// Patch applies a JSON Merge Patch to the fields of UpdateCountryInput, a null member resets the field. It returns
+// the new version of the country
+func (s *countryService) Patch(ctx context.Context, id int64, version int64, patch []byte) (int64, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "CountryService.Patch")
+	defer span.End()
+
+	country, err := countries.CountryDao.FindByID(ctx, id)
+	if err != nil {
+		return 0, resterror.NewDatabaseError(err, "country")
+	}
+	if version != etag.Any && version != country.Version {
+		return 0, resterror.NewDatabaseError(dberror.ErrVersionMismatch, "country")
+	}
+
+	// Merge the patch into the current fields and validate the result as a full update
+	doc, _ := json.Marshal(countries.UpdateCountryInput{
+		Code:   country.Code,
+		Name:   country.Name,
+		Flag:   country.Flag,
+		Active: country.Active,
+	})
+	var req countries.UpdateCountryInput
+	merged, err := mergepatch.Apply(doc, patch)
+	if err == nil {
+		err = json.Unmarshal(merged, &req)
+	}
+	if err != nil {
+		return 0, resterror.NewBadRequestError(constants.ErrorInvalidRequestBody)
+	}
+	if errs := utils.ValidateStruct(&req); errs != nil {
+		return 0, resterror.NewValidationError(errs)
+	}
+
+	req.ID, req.Version = country.ID, country.Version
+	if err := countries.CountryDao.Update(ctx, &req); err != nil {
+		return 0, resterror.NewDatabaseError(err, "country")
+	}
+	return req.Version, nil
+}
+
+func (s *countryService) Find(ctx context.Context, id int64) (*countries.CountryOutput, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "CountryService.Find")
+	defer span.End()
+
+	res, err := countries.CountryDao.FindByID(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "country")
 	}
 	return res, nil
 }
 
-func (s *countryService) List(req *countries.ListCountryInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
-	results, total, err := countries.CountryDao.List(req)
+func (s *countryService) List(ctx context.Context, req *countries.ListCountryInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "CountryService.List")
+	defer span.End()
+
+	results, total, err := countries.CountryDao.List(ctx, req)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, resterror.NewStandardInternalServerError()
 	}
 
-	res := pagination.GeneratePaginatedResponse(results, req.Page, req.PerPage, total)
+	res := pagination.GeneratePaginatedResponse(req.Filter.Project(results), req.Page, req.PerPage, total)
+	res.NextCursor, res.PrevCursor = req.Filter.Cursors()
 
 	return &res, nil
 }
 
-func (s *countryService) Delete(id int64) resterror.RestErrorI {
-	if err := countries.CountryDao.Delete(id); err != nil {
+// Export streams the records matching the filters of the list request, page by page
+func (s *countryService) Export(ctx context.Context, req *countries.ListCountryInput, w export.Writer) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "CountryService.Export")
+	defer span.End()
+
+	req.Page, req.PerPage = 1, export.PerPage
+	err := export.Stream(w, &req.Filter, func() (interface{}, error) {
+		results, _, err := countries.CountryDao.List(ctx, req)
+		return results, err
+	})
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("CountryService Export Stream", "error", err)
 		return resterror.NewStandardInternalServerError()
 	}
 	return nil
 }
 
-func (s *countryService) Sync() resterror.RestErrorI {
-	zlog.Logger.Info("Sync Countries Start")
+// Import upserts the rows of a CSV or JSON upload, the rows that are invalid or fail are reported
+func (s *countryService) Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "CountryService.Import")
+	defer span.End()
+
+	var items []countries.CountryInput
+	rowErrs, apiErr := importer.Decode(r, format, &items)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return importer.Run(ctx, items, rowErrs, func(ctx context.Context, i int) (int64, error) {
+		item := items[i]
+		return 0, countries.CountryDao.Upsert(ctx, &countries.Country{
+			Code:   item.Code,
+			Name:   item.Name,
+			Flag:   item.Flag,
+			Active: item.Active,
+		})
+	})
+}
+
+// Delete removes the country when it is at version, or at any version for etag.Any
+func (s *countryService) Delete(ctx context.Context, id int64, version int64) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "CountryService.Delete")
+	defer span.End()
+
+	country, err := countries.CountryDao.FindByID(ctx, id)
+	if err != nil {
+		return resterror.NewDatabaseError(err, "country")
+	}
+	if version != etag.Any && version != country.Version {
+		return resterror.NewDatabaseError(dberror.ErrVersionMismatch, "country")
+	}
+	if err := countries.CountryDao.Delete(ctx, id, country.Version); err != nil {
+		return resterror.NewDatabaseError(err, "country")
+	}
+	return nil
+}
+
+func (s *countryService) BulkCreate(ctx context.Context, req *countries.BulkCreateCountryInput) (*bulk.Response, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "CountryService.BulkCreate")
+	defer span.End()
+
+	return bulk.Run(ctx, req.Mode, bulk.StatusCreated, req.Items, func(ctx context.Context, i int) (int64, error) {
+		item := req.Items[i]
+		country := countries.Country{
+			Code:   item.Code,
+			Name:   item.Name,
+			Flag:   item.Flag,
+			Active: item.Active,
+		}
+		err := countries.CountryDao.Create(ctx, &country)
+		return country.ID, err
+	})
+}
+
+func (s *countryService) BulkUpdate(ctx context.Context, req *countries.BulkUpdateCountryInput) (*bulk.Response, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "CountryService.BulkUpdate")
+	defer span.End()
+
+	return bulk.Run(ctx, req.Mode, bulk.StatusUpdated, req.Items, func(ctx context.Context, i int) (int64, error) {
+		item := req.Items[i]
+		country, err := countries.CountryDao.FindByID(ctx, item.ID)
+		if err != nil {
+			return 0, err
+		}
+		if item.Version != etag.Any && item.Version != country.Version {
+			return 0, dberror.ErrVersionMismatch
+		}
+		return item.ID, countries.CountryDao.Update(ctx, &countries.UpdateCountryInput{
+			ID:      item.ID,
+			Version: country.Version,
+			Code:    item.Code,
+			Name:    item.Name,
+			Flag:    item.Flag,
+			Active:  item.Active,
+		})
+	})
+}
+
+func (s *countryService) BulkDelete(ctx context.Context, req *bulk.DeleteInput) (*bulk.Response, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "CountryService.BulkDelete")
+	defer span.End()
+
+	return bulk.Run(ctx, req.Mode, bulk.StatusDeleted, req.Items, func(ctx context.Context, i int) (int64, error) {
+		id := req.Items[i].ID
+		country, err := countries.CountryDao.FindByID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return id, countries.CountryDao.Delete(ctx, id, country.Version)
+	})
+}
+
+func (s *countryService) Sync(ctx context.Context) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "CountryService.Sync")
+	defer span.End()
+
+	zlog.FromContext(ctx).Infow("Sync Countries Start")
+	run := metrics.StartSync("countries")
+	defer run.Done()
 	// Get existing countries - set a high pagination, so we can be sure we are getting all in one go
 	filters := countries.ListCountryInput{PerPage: 999}
-	results, total, err := countries.CountryDao.List(&filters)
+	results, total, err := countries.CountryDao.List(ctx, &filters)
 	if err != nil && err != sql.ErrNoRows {
+		run.Fail()
 		return resterror.NewStandardInternalServerError()
 	}
 	// Create a map with existing counties, so we can easily identify the already existing countries
@@ -89,29 +277,33 @@ func (s *countryService) Sync() resterror.RestErrorI {
 		existingCountries[v.Name] = v.Code
 	}
 	// Get the list of countries from API Sports
-	res, apiErr := api_sports_provider.GetCountries()
+	res, apiErr := s.apiSports.GetCountries(ctx)
 	if apiErr != nil {
+		run.Fail()
 		return resterror.NewStandardInternalServerError()
 	}
 
 	for _, country := range res {
 		// Check if the country already exists
 		if _, exists := existingCountries[country.Name]; exists {
+			run.Row(metrics.RowSkipped)
 			continue
 		}
 		// Create the country if it does not exist
-		err := countries.CountryDao.Create(&countries.Country{
+		err := countries.CountryDao.Create(ctx, &countries.Country{
 			Code:   country.Code,
 			Name:   country.Name,
 			Flag:   country.Flag,
 			Active: true,
 		})
 		if err != nil {
-			zlog.Logger.Warn("could not create country: ", country.Name)
+			zlog.FromContext(ctx).Warnw("could not create country", "country", country.Name)
+			run.Row(metrics.RowFailed)
 			continue
 		}
-		zlog.Logger.Info("created new country: ", country.Name)
+		run.Row(metrics.RowCreated)
+		zlog.FromContext(ctx).Infow("created new country", "country", country.Name)
 	}
-	zlog.Logger.Info("Sync Countries End")
+	zlog.FromContext(ctx).Infow("Sync Countries End")
 	return nil
 }
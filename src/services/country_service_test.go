@@ -1,43 +1,61 @@
 package services
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
 	"errors"
-	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
 	"github.com/development-raul/footy-predictor/src/clients/restclient"
+	"github.com/development-raul/footy-predictor/src/config"
 	"github.com/development-raul/footy-predictor/src/domains/countries"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/utils/bulk"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/etag"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
 	"github.com/development-raul/footy-predictor/src/utils/pagination"
 	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"net/http"
-	"os"
+	"net/url"
 	"strings"
 	"testing"
 )
 
 type MockCountryDao struct {
 	FuncCreate   func(country *countries.Country) error
+	FuncUpsert   func(country *countries.Country) error
 	FuncUpdate   func(country *countries.UpdateCountryInput) error
 	FuncFindByID func(id int64) (*countries.CountryOutput, error)
 	FuncList     func(req *countries.ListCountryInput) ([]countries.CountryOutput, int64, error)
-	FuncDelete   func(id int64) error
+	FuncDelete   func(id int64, version int64) error
 }
 
-func (m MockCountryDao) Create(country *countries.Country) error {
+func (m MockCountryDao) Create(ctx context.Context, country *countries.Country) error {
 	return m.FuncCreate(country)
 }
-func (m MockCountryDao) Update(country *countries.UpdateCountryInput) error {
+func (m MockCountryDao) Upsert(ctx context.Context, country *countries.Country) error {
+	return m.FuncUpsert(country)
+}
+func (m MockCountryDao) Update(ctx context.Context, country *countries.UpdateCountryInput) error {
 	return m.FuncUpdate(country)
 }
-func (m MockCountryDao) FindByID(id int64) (*countries.CountryOutput, error) {
+func (m MockCountryDao) FindByID(ctx context.Context, id int64) (*countries.CountryOutput, error) {
 	return m.FuncFindByID(id)
 }
-func (m MockCountryDao) List(req *countries.ListCountryInput) ([]countries.CountryOutput, int64, error) {
+func (m MockCountryDao) List(ctx context.Context, req *countries.ListCountryInput) ([]countries.CountryOutput, int64, error) {
 	return m.FuncList(req)
 }
-func (m MockCountryDao) Delete(id int64) error {
-	return m.FuncDelete(id)
+func (m MockCountryDao) Delete(ctx context.Context, id int64, version int64) error {
+	return m.FuncDelete(id, version)
 }
 
 func TestCountryService_Create(t *testing.T) {
@@ -69,7 +87,7 @@ func TestCountryService_Create(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.title, func(t *testing.T) {
 			countries.CountryDao = testCase.countryDaoMock
-			err := CountryService.Create(&countries.CountryInput{
+			err := CountryService.Create(context.Background(), &countries.CountryInput{
 				Code:   "code",
 				Name:   "name",
 				Flag:   "flag",
@@ -82,9 +100,11 @@ func TestCountryService_Create(t *testing.T) {
 
 func TestCountryService_Update(t *testing.T) {
 	testCases := []struct {
-		title          string
-		countryDaoMock countries.CountryDaoI
-		expectedErr    resterror.RestErrorI
+		title           string
+		version         int64
+		countryDaoMock  countries.CountryDaoI
+		expectedVersion int64
+		expectedErr     resterror.RestErrorI
 	}{
 		{
 			title: "error CountryDao.FindByID",
@@ -93,7 +113,51 @@ func TestCountryService_Update(t *testing.T) {
 					return nil, errors.New("error FindByID")
 				},
 			},
-			expectedErr: resterror.NewBadRequestError("INVALID_COUNTRY_ID"),
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error CountryDao.FindByID not found",
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "country"),
+		},
+		{
+			title:   "error version mismatch",
+			version: 2,
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
+					return &countries.CountryOutput{ID: id, Version: 3}, nil
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(dberror.ErrVersionMismatch, "country"),
+		},
+		{
+			title:   "error CountryDao.Update version mismatch",
+			version: 3,
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
+					return &countries.CountryOutput{ID: id, Version: 3}, nil
+				},
+				FuncUpdate: func(country *countries.UpdateCountryInput) error {
+					return dberror.ErrVersionMismatch
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(dberror.ErrVersionMismatch, "country"),
+		},
+		{
+			title: "error CountryDao.Update duplicate name",
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
+					return &countries.CountryOutput{ID: id}, nil
+				},
+				FuncUpdate: func(country *countries.UpdateCountryInput) error {
+					return dberror.Wrap(&mysql.MySQLError{Number: 1062})
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(dberror.ErrDuplicate, "country"),
 		},
 		{
 			title: "error CountryDao.Update",
@@ -114,35 +178,162 @@ func TestCountryService_Update(t *testing.T) {
 			expectedErr: resterror.NewStandardInternalServerError(),
 		},
 		{
-			title: "success",
+			title:   "success",
+			version: 3,
 			countryDaoMock: &MockCountryDao{
 				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
 					return &countries.CountryOutput{
-						ID:     1,
-						Code:   "code",
-						Name:   "name",
-						Flag:   "flag",
-						Active: true,
+						ID:      1,
+						Code:    "code",
+						Name:    "name",
+						Flag:    "flag",
+						Active:  true,
+						Version: 3,
 					}, nil
 				},
 				FuncUpdate: func(country *countries.UpdateCountryInput) error {
+					country.Version++
 					return nil
 				},
 			},
-			expectedErr: nil,
+			expectedVersion: 4,
+			expectedErr:     nil,
+		},
+		{
+			title:   "success any version",
+			version: etag.Any,
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
+					return &countries.CountryOutput{ID: id, Version: 3}, nil
+				},
+				FuncUpdate: func(country *countries.UpdateCountryInput) error {
+					country.Version++
+					return nil
+				},
+			},
+			expectedVersion: 4,
+			expectedErr:     nil,
 		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.title, func(t *testing.T) {
 			countries.CountryDao = testCase.countryDaoMock
-			err := CountryService.Update(&countries.UpdateCountryInput{
-				Code:   "code",
-				Name:   "name",
-				Flag:   "flag",
-				Active: true,
-			}, 1)
+			req := countries.UpdateCountryInput{
+				Version: testCase.version,
+				Code:    "code",
+				Name:    "name",
+				Flag:    "flag",
+				Active:  true,
+			}
+			err := CountryService.Update(context.Background(), &req, 1)
 			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedErr == nil {
+				assert.Equal(t, testCase.expectedVersion, req.Version)
+			}
+		})
+	}
+}
+
+func TestCountryService_Patch(t *testing.T) {
+	spain := func(id int64) (*countries.CountryOutput, error) {
+		return &countries.CountryOutput{ID: id, Code: "ES", Name: "Spain", Flag: "flag", Active: true, Version: 3}, nil
+	}
+	testCases := []struct {
+		title           string
+		version         int64
+		patch           string
+		countryDaoMock  countries.CountryDaoI
+		expectedUpdate  *countries.UpdateCountryInput
+		expectedVersion int64
+		expectedErr     resterror.RestErrorI
+	}{
+		{
+			title:   "error CountryDao.FindByID not found",
+			version: 3,
+			patch:   `{"active":false}`,
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "country"),
+		},
+		{
+			title:          "error version mismatch",
+			version:        2,
+			patch:          `{"active":false}`,
+			countryDaoMock: &MockCountryDao{FuncFindByID: spain},
+			expectedErr:    resterror.NewDatabaseError(dberror.ErrVersionMismatch, "country"),
+		},
+		{
+			title:          "error invalid patch",
+			version:        3,
+			patch:          `{"active":`,
+			countryDaoMock: &MockCountryDao{FuncFindByID: spain},
+			expectedErr:    resterror.NewBadRequestError(constants.ErrorInvalidRequestBody),
+		},
+		{
+			title:          "error invalid field type",
+			version:        3,
+			patch:          `{"active":"no"}`,
+			countryDaoMock: &MockCountryDao{FuncFindByID: spain},
+			expectedErr:    resterror.NewBadRequestError(constants.ErrorInvalidRequestBody),
+		},
+		{
+			title:          "error required field removed",
+			version:        3,
+			patch:          `{"name":null}`,
+			countryDaoMock: &MockCountryDao{FuncFindByID: spain},
+			expectedErr:    resterror.NewValidationError(map[string][]string{"name": {"The name field is required."}}),
+		},
+		{
+			title:   "error CountryDao.Update",
+			version: 3,
+			patch:   `{"active":false}`,
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: spain,
+				FuncUpdate: func(country *countries.UpdateCountryInput) error {
+					return errors.New("error Update")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:   "success",
+			version: 3,
+			patch:   `{"active":false,"flag":null}`,
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: spain,
+				FuncUpdate: func(country *countries.UpdateCountryInput) error {
+					country.Version++
+					return nil
+				},
+			},
+			expectedUpdate:  &countries.UpdateCountryInput{ID: 1, Version: 4, Code: "ES", Name: "Spain"},
+			expectedVersion: 4,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			var updated *countries.UpdateCountryInput
+			mock := testCase.countryDaoMock.(*MockCountryDao)
+			if update := mock.FuncUpdate; update != nil {
+				mock.FuncUpdate = func(country *countries.UpdateCountryInput) error {
+					updated = country
+					return update(country)
+				}
+			}
+			countries.CountryDao = mock
+
+			version, err := CountryService.Patch(context.Background(), 1, testCase.version, []byte(testCase.patch))
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedVersion, version)
+			if testCase.expectedUpdate != nil {
+				assert.Equal(t, testCase.expectedUpdate, updated)
+			}
 		})
 	}
 }
@@ -167,7 +358,7 @@ func TestCountryService_Find(t *testing.T) {
 			expectedErr: resterror.NewStandardInternalServerError(),
 		},
 		{
-			title: "error CountryDao.FindByID no rows",
+			title: "error CountryDao.FindByID not found",
 			id:    1,
 			countryDaoMock: &MockCountryDao{
 				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
@@ -175,7 +366,7 @@ func TestCountryService_Find(t *testing.T) {
 				},
 			},
 			expectedRes: nil,
-			expectedErr: nil,
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "country"),
 		},
 		{
 			title: "success",
@@ -206,7 +397,7 @@ func TestCountryService_Find(t *testing.T) {
 		t.Run(testCase.title, func(t *testing.T) {
 			countries.CountryDao = testCase.countryDaoMock
 
-			res, err := CountryService.Find(testCase.id)
+			res, err := CountryService.Find(context.Background(), testCase.id)
 
 			assert.Equal(t, testCase.expectedRes, res)
 			assert.Equal(t, testCase.expectedErr, err)
@@ -293,12 +484,7 @@ func TestCountryService_List(t *testing.T) {
 		t.Run(testCase.title, func(t *testing.T) {
 			countries.CountryDao = testCase.countryDaoMock
 
-			res, err := CountryService.List(&countries.ListCountryInput{
-				Code:    "code",
-				Name:    "name",
-				Active:  true,
-				Order:   "name",
-				OrderBy: "asc",
+			res, err := CountryService.List(context.Background(), &countries.ListCountryInput{
 				Page:    1,
 				PerPage: 10,
 			})
@@ -310,24 +496,73 @@ func TestCountryService_List(t *testing.T) {
 }
 
 func TestCountryService_Delete(t *testing.T) {
+	found := func(id int64) (*countries.CountryOutput, error) {
+		return &countries.CountryOutput{ID: id, Version: 3}, nil
+	}
 	testCases := []struct {
 		title          string
+		version        int64
 		countryDaoMock countries.CountryDaoI
 		expectedErr    resterror.RestErrorI
 	}{
 		{
-			title: "error CountryDao.Delete",
+			title:   "error CountryDao.FindByID not found",
+			version: 3,
 			countryDaoMock: &MockCountryDao{
-				FuncDelete: func(id int64) error {
+				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "country"),
+		},
+		{
+			title:          "error version mismatch",
+			version:        2,
+			countryDaoMock: &MockCountryDao{FuncFindByID: found},
+			expectedErr:    resterror.NewDatabaseError(dberror.ErrVersionMismatch, "country"),
+		},
+		{
+			title:   "error CountryDao.Delete",
+			version: 3,
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: found,
+				FuncDelete: func(id int64, version int64) error {
 					return errors.New("error Delete")
 				},
 			},
 			expectedErr: resterror.NewStandardInternalServerError(),
 		},
 		{
-			title: "success",
+			title:   "error CountryDao.Delete version mismatch",
+			version: 3,
 			countryDaoMock: &MockCountryDao{
-				FuncDelete: func(id int64) error {
+				FuncFindByID: found,
+				FuncDelete: func(id int64, version int64) error {
+					return dberror.ErrVersionMismatch
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(dberror.ErrVersionMismatch, "country"),
+		},
+		{
+			title:   "success",
+			version: 3,
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: found,
+				FuncDelete: func(id int64, version int64) error {
+					return nil
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			title:   "success any version",
+			version: etag.Any,
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: found,
+				FuncDelete: func(id int64, version int64) error {
+					if version != 3 {
+						return dberror.ErrVersionMismatch
+					}
 					return nil
 				},
 			},
@@ -338,14 +573,14 @@ func TestCountryService_Delete(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.title, func(t *testing.T) {
 			countries.CountryDao = testCase.countryDaoMock
-			err := CountryService.Delete(1)
+			err := CountryService.Delete(context.Background(), 1, testCase.version)
 			assert.Equal(t, testCase.expectedErr, err)
 		})
 	}
 }
 
 func TestCountryService_Sync(t *testing.T) {
-	os.Setenv("AS_BASE_URL", "http://localhost")
+	CountryService = &countryService{apiSports: api_sports_provider.New(config.APISportsConfig{BaseURL: "http://localhost"})}
 	testCases := []struct {
 		title          string
 		countryDaoMock countries.CountryDaoI
@@ -475,17 +710,280 @@ func TestCountryService_Sync(t *testing.T) {
 			restclient.StartMockups()
 			restclient.FlushMockups()
 			restclient.AddMockup(restclient.Mock{
-				Url:        fmt.Sprintf("%s/countries", os.Getenv("AS_BASE_URL")),
+				Url:        "http://localhost/countries",
 				HttpMethod: http.MethodGet,
 				Response:   testCase.restClientResp,
 			})
 			countries.CountryDao = testCase.countryDaoMock
 
 			// Execution
-			err := CountryService.Sync()
+			err := CountryService.Sync(context.Background())
 
 			// Assertions
 			assert.Equal(t, testCase.expectedErr, err)
 		})
 	}
 }
+
+func TestCountryService_BulkUpdate(t *testing.T) {
+	testCases := []struct {
+		title          string
+		mode           string
+		countryDaoMock countries.CountryDaoI
+		funcMock       func(sqlmock.Sqlmock)
+		expectedRes    *bulk.Response
+		expectedErr    resterror.RestErrorI
+	}{
+		{
+			title: "error atomic CountryDao.FindByID not found",
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectRollback()
+			},
+			expectedErr: resterror.NewCustomError(&bulk.Response{
+				Mode:   bulk.ModeAtomic,
+				Failed: 1,
+				Results: []bulk.Result{
+					{Index: 0, Status: bulk.StatusFailed, Errors: map[string][]string{"id": {bulk.ErrorNotFound}}},
+				},
+			}, http.StatusUnprocessableEntity),
+		},
+		{
+			title: "error atomic version mismatch",
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
+					return &countries.CountryOutput{ID: id, Version: 2}, nil
+				},
+			},
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectRollback()
+			},
+			expectedErr: resterror.NewCustomError(&bulk.Response{
+				Mode:   bulk.ModeAtomic,
+				Failed: 1,
+				Results: []bulk.Result{
+					{Index: 0, Status: bulk.StatusFailed, Errors: map[string][]string{"version": {bulk.ErrorVersionMismatch}}},
+				},
+			}, http.StatusUnprocessableEntity),
+		},
+		{
+			title: "success best effort CountryDao.Update error",
+			mode:  bulk.ModeBestEffort,
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
+					return &countries.CountryOutput{ID: id, Version: 1}, nil
+				},
+				FuncUpdate: func(country *countries.UpdateCountryInput) error {
+					return errors.New("error Update")
+				},
+			},
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("ROLLBACK TO SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+			},
+			expectedRes: &bulk.Response{
+				Mode:   bulk.ModeBestEffort,
+				Failed: 1,
+				Results: []bulk.Result{
+					{Index: 0, Status: bulk.StatusFailed, Errors: map[string][]string{"item": {bulk.ErrorFailed}}},
+				},
+			},
+		},
+		{
+			title: "success",
+			countryDaoMock: &MockCountryDao{
+				FuncFindByID: func(id int64) (*countries.CountryOutput, error) {
+					return &countries.CountryOutput{ID: id, Version: 1}, nil
+				},
+				FuncUpdate: func(country *countries.UpdateCountryInput) error {
+					return nil
+				},
+			},
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectCommit()
+			},
+			expectedRes: &bulk.Response{
+				Mode:    bulk.ModeAtomic,
+				Applied: 1,
+				Results: []bulk.Result{{Index: 0, Status: bulk.StatusUpdated, ID: 1}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+			countries.CountryDao = testCase.countryDaoMock
+
+			res, apiErr := CountryService.BulkUpdate(context.Background(), &countries.BulkUpdateCountryInput{
+				Mode:  testCase.mode,
+				Items: []countries.BulkUpdateCountryItem{{ID: 1, Version: 1, Name: "Spain"}},
+			})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, apiErr)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCountryService_Export(t *testing.T) {
+	// The first page is full so the export selects the next page after its last record
+	firstPage := make([]countries.CountryOutput, export.PerPage+1)
+	for i := range firstPage {
+		firstPage[i] = countries.CountryOutput{ID: 1, Name: "Spain"}
+	}
+	pages := [][]countries.CountryOutput{firstPage, {{ID: 2, Name: "Wales"}}}
+
+	testCases := []struct {
+		title          string
+		countryDaoMock func() countries.CountryDaoI
+		expectedRes    string
+		expectedErr    resterror.RestErrorI
+	}{
+		{
+			title: "error CountryDao.List",
+			countryDaoMock: func() countries.CountryDaoI {
+				return &MockCountryDao{
+					FuncList: func(req *countries.ListCountryInput) ([]countries.CountryOutput, int64, error) {
+						return nil, 0, errors.New("error List")
+					},
+				}
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success every page",
+			countryDaoMock: func() countries.CountryDaoI {
+				calls := 0
+				return &MockCountryDao{
+					FuncList: func(req *countries.ListCountryInput) ([]countries.CountryOutput, int64, error) {
+						if req.Filter.Total() {
+							return nil, 0, errors.New("the export must skip the count")
+						}
+						results := pages[calls]
+						calls++
+						return req.Filter.Page(req.Page, req.PerPage, results).Rows(results).([]countries.CountryOutput), pagination.TotalNotCounted, nil
+					},
+				}
+			},
+			expectedRes: strings.Repeat(`{"id":1}`+"\n", export.PerPage) + `{"id":2}` + "\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			countries.CountryDao = testCase.countryDaoMock()
+			query, _ := filter.Parse(url.Values{"fields": {"id"}}, countries.CountryOutput{})
+			var buf bytes.Buffer
+
+			apiErr := CountryService.Export(context.Background(), &countries.ListCountryInput{Filter: *query},
+				export.NewWriter(&buf, export.FormatNDJSON, query.Columns(countries.CountryOutput{})))
+
+			assert.Equal(t, testCase.expectedErr, apiErr)
+			assert.Equal(t, testCase.expectedRes, buf.String())
+		})
+	}
+}
+
+func TestCountryService_Import(t *testing.T) {
+	testCases := []struct {
+		title          string
+		format         string
+		body           string
+		countryDaoMock countries.CountryDaoI
+		funcMock       func(sqlmock.Sqlmock)
+		expectedRes    *importer.Report
+		expectedErr    resterror.RestErrorI
+	}{
+		{
+			title:       "error invalid json",
+			format:      importer.FormatJSON,
+			body:        `{"name":"Spain"}`,
+			funcMock:    func(m sqlmock.Sqlmock) {},
+			expectedErr: resterror.NewValidationError(map[string][]string{"file": {"The file must be a json array of objects"}}),
+		},
+		{
+			title:  "success csv with row errors",
+			format: importer.FormatCSV,
+			body:   "name,code,active\nSpain,ES,true\n,GB,true\nWales,GB-WLS,maybe\nItaly,IT,false\n",
+			countryDaoMock: &MockCountryDao{
+				FuncUpsert: func(country *countries.Country) error {
+					if country.Name == "Italy" {
+						return errors.New("error Upsert")
+					}
+					return nil
+				},
+			},
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("ROLLBACK TO SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("ROLLBACK TO SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+			},
+			expectedRes: &importer.Report{
+				Rows:     4,
+				Imported: 1,
+				Failed:   3,
+				Errors: []importer.RowError{
+					{Row: 2, Errors: map[string][]string{"name": {"The name field is required."}}},
+					{Row: 3, Errors: map[string][]string{"active": {"The active must be true or false"}}},
+					{Row: 4, Errors: map[string][]string{"item": {bulk.ErrorFailed}}},
+				},
+			},
+		},
+		{
+			title:  "success json",
+			format: importer.FormatJSON,
+			body:   `[{"name":"Spain","code":"ES","active":true}]`,
+			countryDaoMock: &MockCountryDao{
+				FuncUpsert: func(country *countries.Country) error {
+					return nil
+				},
+			},
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+			},
+			expectedRes: &importer.Report{Rows: 1, Imported: 1, Errors: []importer.RowError{}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+			countries.CountryDao = testCase.countryDaoMock
+
+			res, apiErr := CountryService.Import(context.Background(), strings.NewReader(testCase.body), testCase.format)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, apiErr)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
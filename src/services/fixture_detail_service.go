@@ -0,0 +1,327 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/api_sports"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_events"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_lineups"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_statistics"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"strconv"
+	"strings"
+)
+
+type FixtureDetailServiceI interface {
+	Events(ctx context.Context, fixtureID int64) ([]fixture_events.Event, resterror.RestErrorI)
+	Lineups(ctx context.Context, fixtureID int64) ([]fixture_lineups.Lineup, resterror.RestErrorI)
+	Statistics(ctx context.Context, fixtureID int64) ([]fixture_statistics.TeamStatistic, resterror.RestErrorI)
+	Sync(ctx context.Context, fixtureID int64) resterror.RestErrorI
+	SyncMissing(ctx context.Context, req *fixtures.SyncDetailsInput) resterror.RestErrorI
+}
+
+type fixtureDetailService struct {
+	apiSports *api_sports_provider.Client
+}
+
+var FixtureDetailService FixtureDetailServiceI = &fixtureDetailService{}
+
+// Events returns the events of a fixture in the order they happened
+func (s *fixtureDetailService) Events(ctx context.Context, fixtureID int64) ([]fixture_events.Event, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "FixtureDetailService.Events")
+	defer span.End()
+
+	if _, err := fixtures.FixtureDao.FindByID(ctx, fixtureID); err != nil {
+		return nil, resterror.NewDatabaseError(err, "fixture")
+	}
+	res, err := fixture_events.FixtureEventDao.ListByFixture(ctx, fixtureID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if res == nil {
+		res = []fixture_events.Event{}
+	}
+	return res, nil
+}
+
+// Lineups returns the lineups of the teams of a fixture with their starters and substitutes
+func (s *fixtureDetailService) Lineups(ctx context.Context, fixtureID int64) ([]fixture_lineups.Lineup, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "FixtureDetailService.Lineups")
+	defer span.End()
+
+	if _, err := fixtures.FixtureDao.FindByID(ctx, fixtureID); err != nil {
+		return nil, resterror.NewDatabaseError(err, "fixture")
+	}
+	res, err := fixture_lineups.FixtureLineupDao.ListByFixture(ctx, fixtureID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if len(res) == 0 {
+		return []fixture_lineups.Lineup{}, nil
+	}
+	lineupPlayers, err := fixture_lineups.FixtureLineupDao.ListPlayers(ctx, fixtureID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	teams := make(map[int64]int, len(res))
+	for i := range res {
+		res[i].StartXI, res[i].Substitutes = []fixture_lineups.Player{}, []fixture_lineups.Player{}
+		teams[res[i].TeamID] = i
+	}
+	for _, p := range lineupPlayers {
+		i, ok := teams[p.TeamID]
+		if !ok {
+			continue
+		}
+		if p.Starter {
+			res[i].StartXI = append(res[i].StartXI, p)
+		} else {
+			res[i].Substitutes = append(res[i].Substitutes, p)
+		}
+	}
+	return res, nil
+}
+
+// Statistics returns the match statistics of the teams of a fixture
+func (s *fixtureDetailService) Statistics(ctx context.Context, fixtureID int64) ([]fixture_statistics.TeamStatistic, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "FixtureDetailService.Statistics")
+	defer span.End()
+
+	if _, err := fixtures.FixtureDao.FindByID(ctx, fixtureID); err != nil {
+		return nil, resterror.NewDatabaseError(err, "fixture")
+	}
+	res, err := fixture_statistics.FixtureStatisticDao.ListByFixture(ctx, fixtureID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if res == nil {
+		res = []fixture_statistics.TeamStatistic{}
+	}
+	return res, nil
+}
+
+// Sync imports the events, the lineups and the match statistics of a fixture from API Sports, each replacing the
+// stored ones. What API Sports has no data for yet is left as it is. A failed part does not stop the others, the
+// error is returned once they are all done. The fixture is marked as having its details once all three parts were
+// replaced
+func (s *fixtureDetailService) Sync(ctx context.Context, fixtureID int64) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "FixtureDetailService.Sync")
+	defer span.End()
+
+	zlog.FromContext(ctx).Infow("Sync Fixture Details Start", "fixture_id", fixtureID)
+	run := metrics.StartSync("fixture_details")
+	defer run.Done()
+	failed := false
+	complete := true
+
+	if res, apiErr := s.apiSports.GetFixtureEvents(ctx, fixtureID); apiErr != nil {
+		run.Fail()
+		failed = true
+	} else if len(res) == 0 {
+		complete = false
+	} else {
+		events := make([]fixture_events.Event, len(res))
+		for i, v := range res {
+			events[i] = fixture_events.Event{
+				Sequence:   int64(i + 1),
+				Elapsed:    v.Time.Elapsed,
+				Extra:      v.Time.Extra,
+				TeamID:     v.Team.ID,
+				TeamName:   v.Team.Name,
+				PlayerID:   v.Player.ID,
+				PlayerName: v.Player.Name,
+				AssistID:   v.Assist.ID,
+				AssistName: v.Assist.Name,
+				Type:       fixture_events.TypeOf(v.Type),
+				Detail:     v.Detail,
+				Comments:   v.Comments,
+			}
+		}
+		complete = s.replaced(ctx, run, fixture_events.FixtureEventDao.Replace(ctx, fixtureID, events), "events", fixtureID) && complete
+	}
+
+	if res, apiErr := s.apiSports.GetFixtureLineups(ctx, fixtureID); apiErr != nil {
+		run.Fail()
+		failed = true
+	} else if len(res) == 0 {
+		complete = false
+	} else {
+		lineups := make([]fixture_lineups.Lineup, len(res))
+		for i, v := range res {
+			lineups[i] = fixture_lineups.Lineup{
+				TeamID:      v.Team.ID,
+				TeamName:    v.Team.Name,
+				Formation:   v.Formation,
+				CoachID:     v.Coach.ID,
+				CoachName:   v.Coach.Name,
+				StartXI:     lineupPlayers(v.StartXI),
+				Substitutes: lineupPlayers(v.Substitutes),
+			}
+		}
+		complete = s.replaced(ctx, run, fixture_lineups.FixtureLineupDao.Replace(ctx, fixtureID, lineups), "lineups", fixtureID) && complete
+	}
+
+	if res, apiErr := s.apiSports.GetFixtureStatistics(ctx, fixtureID); apiErr != nil {
+		run.Fail()
+		failed = true
+	} else if len(res) == 0 {
+		complete = false
+	} else {
+		statistics := make([]fixture_statistics.TeamStatistic, len(res))
+		for i, v := range res {
+			statistics[i] = teamStatistic(v)
+		}
+		complete = s.replaced(ctx, run, fixture_statistics.FixtureStatisticDao.Replace(ctx, fixtureID, statistics), "statistics", fixtureID) && complete
+	}
+
+	if failed {
+		return resterror.NewStandardInternalServerError()
+	}
+	if complete {
+		if err := fixtures.FixtureDao.MarkDetailsSynced(ctx, fixtureID, helpers.GetNow()); err != nil {
+			zlog.FromContext(ctx).Warnw("could not mark fixture details synced", "fixture_id", fixtureID)
+		}
+	}
+	zlog.FromContext(ctx).Infow("Sync Fixture Details End", "fixture_id", fixtureID)
+	return nil
+}
+
+// SyncMissing imports the details of a fixture, or of every finished fixture of a league season whose details are
+// missing. A failed fixture does not stop the others, the error is returned once they are all done
+func (s *fixtureDetailService) SyncMissing(ctx context.Context, req *fixtures.SyncDetailsInput) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "FixtureDetailService.SyncMissing")
+	defer span.End()
+
+	if req.FixtureID != 0 {
+		if _, err := fixtures.FixtureDao.FindByID(ctx, req.FixtureID); err != nil {
+			return resterror.NewDatabaseError(err, "fixture")
+		}
+		return FixtureDetailService.Sync(ctx, req.FixtureID)
+	}
+
+	list := fixtures.ListFixtureInput{
+		LeagueID: req.LeagueID,
+		Season:   req.Season,
+		Page:     1,
+		PerPage:  500,
+	}
+	var missing []int64
+	for {
+		results, total, err := fixtures.FixtureDao.List(ctx, &list)
+		if err != nil && err != sql.ErrNoRows {
+			return resterror.NewStandardInternalServerError()
+		}
+		for i := range results {
+			if results[i].Finished() && results[i].DetailsSyncedAt == nil {
+				missing = append(missing, results[i].ID)
+			}
+		}
+		if list.Page*list.PerPage >= total {
+			break
+		}
+		list.Page++
+	}
+
+	failed := false
+	for _, fixtureID := range missing {
+		if apiErr := FixtureDetailService.Sync(ctx, fixtureID); apiErr != nil {
+			zlog.FromContext(ctx).Warnw("could not sync fixture details", "fixture_id", fixtureID)
+			failed = true
+		}
+	}
+	if failed {
+		return resterror.NewStandardInternalServerError()
+	}
+	return nil
+}
+
+// replaced records the outcome of replacing a part of the details of a fixture and reports if it was replaced
+func (s *fixtureDetailService) replaced(ctx context.Context, run *metrics.SyncRun, err error, part string, fixtureID int64) bool {
+	if err != nil {
+		zlog.FromContext(ctx).Warnw("could not replace fixture "+part, "fixture_id", fixtureID)
+		run.Row(metrics.RowFailed)
+		return false
+	}
+	run.Row(metrics.RowUpserted)
+	return true
+}
+
+func lineupPlayers(entries []api_sports.LineupEntry) []fixture_lineups.Player {
+	res := make([]fixture_lineups.Player, len(entries))
+	for i, e := range entries {
+		res[i] = fixture_lineups.Player{
+			PlayerID:   e.Player.ID,
+			PlayerName: e.Player.Name,
+			Number:     e.Player.Number,
+			Position:   e.Player.Pos,
+			Grid:       e.Player.Grid,
+		}
+	}
+	return res
+}
+
+// teamStatistic reads the match statistics of a team. API Sports gives the values as numbers, as texts like "55%"
+// for the percentages and "1.23" for the expected goals, or null when there is none
+func teamStatistic(v api_sports.FixtureStatistics) fixture_statistics.TeamStatistic {
+	statistic := fixture_statistics.TeamStatistic{TeamID: v.Team.ID, TeamName: v.Team.Name}
+	for _, stat := range v.Statistics {
+		value, ok := statisticValue(stat.Value)
+		if !ok {
+			continue
+		}
+		count := int64(value)
+		switch stat.Type {
+		case "Shots on Goal":
+			statistic.ShotsOnTarget = count
+		case "Shots off Goal":
+			statistic.ShotsOffTarget = count
+		case "Total Shots":
+			statistic.Shots = count
+		case "Blocked Shots":
+			statistic.BlockedShots = count
+		case "Shots insidebox":
+			statistic.ShotsInsideBox = count
+		case "Shots outsidebox":
+			statistic.ShotsOutsideBox = count
+		case "Fouls":
+			statistic.Fouls = count
+		case "Corner Kicks":
+			statistic.Corners = count
+		case "Offsides":
+			statistic.Offsides = count
+		case "Ball Possession":
+			statistic.Possession = &count
+		case "Yellow Cards":
+			statistic.YellowCards = count
+		case "Red Cards":
+			statistic.RedCards = count
+		case "Goalkeeper Saves":
+			statistic.Saves = count
+		case "Total passes":
+			statistic.Passes = count
+		case "Passes accurate":
+			statistic.PassesAccurate = count
+		case "expected_goals":
+			statistic.ExpectedGoals = &value
+		}
+	}
+	return statistic
+}
+
+func statisticValue(v interface{}) (float64, bool) {
+	switch value := v.(type) {
+	case float64:
+		return value, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "%"), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
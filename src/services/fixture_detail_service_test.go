@@ -0,0 +1,485 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/clients/restclient"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/domains/api_sports"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_events"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_lineups"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_statistics"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockFixtureEventDao struct {
+	FuncReplace       func(fixtureID int64, events []fixture_events.Event) error
+	FuncListByFixture func(fixtureID int64) ([]fixture_events.Event, error)
+}
+
+func (m MockFixtureEventDao) Replace(ctx context.Context, fixtureID int64, events []fixture_events.Event) error {
+	return m.FuncReplace(fixtureID, events)
+}
+func (m MockFixtureEventDao) ListByFixture(ctx context.Context, fixtureID int64) ([]fixture_events.Event, error) {
+	return m.FuncListByFixture(fixtureID)
+}
+
+type MockFixtureLineupDao struct {
+	FuncReplace       func(fixtureID int64, lineups []fixture_lineups.Lineup) error
+	FuncListByFixture func(fixtureID int64) ([]fixture_lineups.Lineup, error)
+	FuncListPlayers   func(fixtureID int64) ([]fixture_lineups.Player, error)
+}
+
+func (m MockFixtureLineupDao) Replace(ctx context.Context, fixtureID int64, lineups []fixture_lineups.Lineup) error {
+	return m.FuncReplace(fixtureID, lineups)
+}
+func (m MockFixtureLineupDao) ListByFixture(ctx context.Context, fixtureID int64) ([]fixture_lineups.Lineup, error) {
+	return m.FuncListByFixture(fixtureID)
+}
+func (m MockFixtureLineupDao) ListPlayers(ctx context.Context, fixtureID int64) ([]fixture_lineups.Player, error) {
+	return m.FuncListPlayers(fixtureID)
+}
+
+type MockFixtureStatisticDao struct {
+	FuncReplace       func(fixtureID int64, statistics []fixture_statistics.TeamStatistic) error
+	FuncListByFixture func(fixtureID int64) ([]fixture_statistics.TeamStatistic, error)
+}
+
+func (m MockFixtureStatisticDao) Replace(ctx context.Context, fixtureID int64, statistics []fixture_statistics.TeamStatistic) error {
+	return m.FuncReplace(fixtureID, statistics)
+}
+func (m MockFixtureStatisticDao) ListByFixture(ctx context.Context, fixtureID int64) ([]fixture_statistics.TeamStatistic, error) {
+	return m.FuncListByFixture(fixtureID)
+}
+
+// testDetailFixtureDao knows fixture 10 only
+func testDetailFixtureDao() *MockFixtureDao {
+	return &MockFixtureDao{
+		FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+			if id != 10 {
+				return nil, sql.ErrNoRows
+			}
+			return &fixtures.Fixture{ID: id}, nil
+		},
+	}
+}
+
+type MockFixtureDetailService struct {
+	FuncSync func(fixtureID int64) resterror.RestErrorI
+}
+
+func (m MockFixtureDetailService) Events(ctx context.Context, fixtureID int64) ([]fixture_events.Event, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockFixtureDetailService) Lineups(ctx context.Context, fixtureID int64) ([]fixture_lineups.Lineup, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockFixtureDetailService) Statistics(ctx context.Context, fixtureID int64) ([]fixture_statistics.TeamStatistic, resterror.RestErrorI) {
+	return nil, nil
+}
+func (m MockFixtureDetailService) Sync(ctx context.Context, fixtureID int64) resterror.RestErrorI {
+	return m.FuncSync(fixtureID)
+}
+func (m MockFixtureDetailService) SyncMissing(ctx context.Context, req *fixtures.SyncDetailsInput) resterror.RestErrorI {
+	return nil
+}
+
+func TestFixtureDetailService_Events(t *testing.T) {
+	testCases := []struct {
+		title       string
+		fixtureID   int64
+		listErr     error
+		list        []fixture_events.Event
+		expectedRes []fixture_events.Event
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title:       "error fixture not found",
+			fixtureID:   11,
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "fixture"),
+		},
+		{
+			title:       "error FixtureEventDao.ListByFixture",
+			fixtureID:   10,
+			listErr:     errors.New("error ListByFixture"),
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:       "success no events",
+			fixtureID:   10,
+			expectedRes: []fixture_events.Event{},
+		},
+		{
+			title:       "success",
+			fixtureID:   10,
+			list:        []fixture_events.Event{{FixtureID: 10, Sequence: 1, Type: fixture_events.TypeGoal}},
+			expectedRes: []fixture_events.Event{{FixtureID: 10, Sequence: 1, Type: fixture_events.TypeGoal}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			fixtures.FixtureDao = testDetailFixtureDao()
+			fixture_events.FixtureEventDao = &MockFixtureEventDao{
+				FuncListByFixture: func(fixtureID int64) ([]fixture_events.Event, error) {
+					return testCase.list, testCase.listErr
+				},
+			}
+
+			res, err := FixtureDetailService.Events(context.Background(), testCase.fixtureID)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestFixtureDetailService_Lineups(t *testing.T) {
+	testCases := []struct {
+		title       string
+		listErr     error
+		playersErr  error
+		list        []fixture_lineups.Lineup
+		expectedRes []fixture_lineups.Lineup
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title:       "error FixtureLineupDao.ListByFixture",
+			listErr:     errors.New("error ListByFixture"),
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:       "success no lineups",
+			expectedRes: []fixture_lineups.Lineup{},
+		},
+		{
+			title:       "error FixtureLineupDao.ListPlayers",
+			list:        []fixture_lineups.Lineup{{FixtureID: 10, TeamID: 1}},
+			playersErr:  errors.New("error ListPlayers"),
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			list:  []fixture_lineups.Lineup{{FixtureID: 10, TeamID: 1, Formation: "4-4-2"}, {FixtureID: 10, TeamID: 2, Formation: "3-5-2"}},
+			expectedRes: []fixture_lineups.Lineup{
+				{FixtureID: 10, TeamID: 1, Formation: "4-4-2",
+					StartXI: []fixture_lineups.Player{
+						{FixtureID: 10, TeamID: 1, Sequence: 1, PlayerName: "Keeper", Starter: true},
+						{FixtureID: 10, TeamID: 1, Sequence: 2, PlayerName: "Striker", Starter: true},
+					},
+					Substitutes: []fixture_lineups.Player{{FixtureID: 10, TeamID: 1, Sequence: 3, PlayerName: "Bench"}},
+				},
+				{FixtureID: 10, TeamID: 2, Formation: "3-5-2", StartXI: []fixture_lineups.Player{}, Substitutes: []fixture_lineups.Player{}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			fixtures.FixtureDao = testDetailFixtureDao()
+			fixture_lineups.FixtureLineupDao = &MockFixtureLineupDao{
+				FuncListByFixture: func(fixtureID int64) ([]fixture_lineups.Lineup, error) {
+					return testCase.list, testCase.listErr
+				},
+				FuncListPlayers: func(fixtureID int64) ([]fixture_lineups.Player, error) {
+					return []fixture_lineups.Player{
+						{FixtureID: 10, TeamID: 1, Sequence: 1, PlayerName: "Keeper", Starter: true},
+						{FixtureID: 10, TeamID: 1, Sequence: 2, PlayerName: "Striker", Starter: true},
+						{FixtureID: 10, TeamID: 1, Sequence: 3, PlayerName: "Bench"},
+					}, testCase.playersErr
+				},
+			}
+
+			res, err := FixtureDetailService.Lineups(context.Background(), 10)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if err != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, testCase.expectedRes, res)
+		})
+	}
+}
+
+func TestFixtureDetailService_Statistics(t *testing.T) {
+	testCases := []struct {
+		title       string
+		fixtureID   int64
+		listErr     error
+		expectedRes []fixture_statistics.TeamStatistic
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title:       "error fixture not found",
+			fixtureID:   11,
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "fixture"),
+		},
+		{
+			title:       "error FixtureStatisticDao.ListByFixture",
+			fixtureID:   10,
+			listErr:     errors.New("error ListByFixture"),
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:       "success no statistics",
+			fixtureID:   10,
+			expectedRes: []fixture_statistics.TeamStatistic{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			fixtures.FixtureDao = testDetailFixtureDao()
+			fixture_statistics.FixtureStatisticDao = &MockFixtureStatisticDao{
+				FuncListByFixture: func(fixtureID int64) ([]fixture_statistics.TeamStatistic, error) {
+					return nil, testCase.listErr
+				},
+			}
+
+			res, err := FixtureDetailService.Statistics(context.Background(), testCase.fixtureID)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestFixtureDetailService_Sync(t *testing.T) {
+	FixtureDetailService = &fixtureDetailService{apiSports: api_sports_provider.New(config.APISportsConfig{BaseURL: "http://localhost"})}
+	eventsBody := `{"response": [
+		{"time": {"elapsed": 23, "extra": null}, "team": {"id": 1, "name": "Home"}, "player": {"id": 7, "name": "Striker"},
+			"assist": {"id": 8, "name": "Winger"}, "type": "Goal", "detail": "Normal Goal", "comments": null},
+		{"time": {"elapsed": 90, "extra": 3}, "team": {"id": 2, "name": "Away"}, "player": {"id": 20, "name": "Out"},
+			"assist": {"id": 21, "name": "In"}, "type": "subst", "detail": "Substitution 1", "comments": null}
+	]}`
+	lineupsBody := `{"response": [
+		{"team": {"id": 1, "name": "Home"}, "formation": "4-3-3", "coach": {"id": 4, "name": "Coach"},
+			"startXI": [{"player": {"id": 1, "name": "Keeper", "number": 1, "pos": "G", "grid": "1:1"}}],
+			"substitutes": [{"player": {"id": 12, "name": "Bench", "number": 12, "pos": "G", "grid": null}}]}
+	]}`
+	statisticsBody := `{"response": [
+		{"team": {"id": 1, "name": "Home"}, "statistics": [
+			{"type": "Shots on Goal", "value": 6}, {"type": "Total Shots", "value": 14}, {"type": "Corner Kicks", "value": 7},
+			{"type": "Ball Possession", "value": "62%"}, {"type": "Red Cards", "value": null}, {"type": "expected_goals", "value": "1.87"}
+		]}
+	]}`
+
+	testCases := []struct {
+		title              string
+		statisticsStatus   int
+		statisticsBody     string
+		expectedEvents     []fixture_events.Event
+		expectedLineups    []fixture_lineups.Lineup
+		expectedStatistics []fixture_statistics.TeamStatistic
+		expectedMarked     []int64
+		expectedErr        resterror.RestErrorI
+	}{
+		{
+			title:            "error api_sports_provider.GetFixtureStatistics",
+			statisticsStatus: http.StatusInternalServerError,
+			statisticsBody:   statisticsBody,
+			expectedErr:      resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:            "success without statistics yet",
+			statisticsStatus: http.StatusOK,
+			statisticsBody:   `{"response": []}`,
+		},
+		{
+			title:            "success",
+			statisticsStatus: http.StatusOK,
+			statisticsBody:   statisticsBody,
+			expectedMarked:   []int64{10},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			restclient.StartMockups()
+			restclient.FlushMockups()
+			for url, resp := range map[string]*http.Response{
+				"http://localhost/fixtures/events?fixture=10":     {StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(eventsBody))},
+				"http://localhost/fixtures/lineups?fixture=10":    {StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(lineupsBody))},
+				"http://localhost/fixtures/statistics?fixture=10": {StatusCode: testCase.statisticsStatus, Body: ioutil.NopCloser(strings.NewReader(testCase.statisticsBody))},
+			} {
+				restclient.AddMockup(restclient.Mock{Url: url, HttpMethod: http.MethodGet, Response: resp})
+			}
+			var events []fixture_events.Event
+			fixture_events.FixtureEventDao = &MockFixtureEventDao{
+				FuncReplace: func(fixtureID int64, res []fixture_events.Event) error {
+					events = res
+					return nil
+				},
+			}
+			var lineups []fixture_lineups.Lineup
+			fixture_lineups.FixtureLineupDao = &MockFixtureLineupDao{
+				FuncReplace: func(fixtureID int64, res []fixture_lineups.Lineup) error {
+					lineups = res
+					return nil
+				},
+			}
+			var statistics []fixture_statistics.TeamStatistic
+			fixture_statistics.FixtureStatisticDao = &MockFixtureStatisticDao{
+				FuncReplace: func(fixtureID int64, res []fixture_statistics.TeamStatistic) error {
+					statistics = res
+					return nil
+				},
+			}
+
+			var marked []int64
+			fixtures.FixtureDao = &MockFixtureDao{
+				FuncMarkDetailsSynced: func(id int64, syncedAt time.Time) error {
+					marked = append(marked, id)
+					return nil
+				},
+			}
+
+			err := FixtureDetailService.Sync(context.Background(), 10)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			// The fixture is marked once API Sports had all the parts
+			assert.Equal(t, testCase.expectedMarked, marked)
+			// The parts API Sports answered are synced either way
+			id := func(v int64) *int64 { return &v }
+			text := func(v string) *string { return &v }
+			assert.Equal(t, []fixture_events.Event{
+				{Sequence: 1, Elapsed: 23, TeamID: 1, TeamName: "Home", PlayerID: id(7), PlayerName: text("Striker"),
+					AssistID: id(8), AssistName: text("Winger"), Type: fixture_events.TypeGoal, Detail: "Normal Goal"},
+				{Sequence: 2, Elapsed: 90, Extra: id(3), TeamID: 2, TeamName: "Away", PlayerID: id(20), PlayerName: text("Out"),
+					AssistID: id(21), AssistName: text("In"), Type: fixture_events.TypeSubstitution, Detail: "Substitution 1"},
+			}, events)
+			assert.Equal(t, []fixture_lineups.Lineup{
+				{TeamID: 1, TeamName: "Home", Formation: "4-3-3", CoachID: id(4), CoachName: text("Coach"),
+					StartXI:     []fixture_lineups.Player{{PlayerID: id(1), PlayerName: "Keeper", Number: id(1), Position: text("G"), Grid: text("1:1")}},
+					Substitutes: []fixture_lineups.Player{{PlayerID: id(12), PlayerName: "Bench", Number: id(12), Position: text("G")}},
+				},
+			}, lineups)
+			if testCase.expectedMarked == nil {
+				assert.Nil(t, statistics)
+				return
+			}
+			expectedGoals := 1.87
+			assert.Equal(t, []fixture_statistics.TeamStatistic{
+				{TeamID: 1, TeamName: "Home", Shots: 14, ShotsOnTarget: 6, Corners: 7, Possession: id(62), ExpectedGoals: &expectedGoals},
+			}, statistics)
+		})
+	}
+}
+
+func TestFixtureDetailService_SyncMissing(t *testing.T) {
+	finished := func(id int64, detailsSyncedAt *time.Time) fixtures.Fixture {
+		goals := int64(1)
+		return fixtures.Fixture{ID: id, Status: "FT", HomeGoals: &goals, AwayGoals: &goals, DetailsSyncedAt: detailsSyncedAt}
+	}
+	syncedAt := time.Date(2022, 1, 15, 17, 0, 0, 0, time.UTC)
+	defer func(service FixtureDetailServiceI) { FixtureDetailService = service }(FixtureDetailService)
+
+	testCases := []struct {
+		title          string
+		req            *fixtures.SyncDetailsInput
+		fixtureDaoMock fixtures.FixtureDaoI
+		syncErr        resterror.RestErrorI
+		expectedSynced []int64
+		expectedErr    resterror.RestErrorI
+	}{
+		{
+			title: "error fixture not found",
+			req:   &fixtures.SyncDetailsInput{FixtureID: 10},
+			fixtureDaoMock: &MockFixtureDao{
+				FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "fixture"),
+		},
+		{
+			title: "success fixture",
+			req:   &fixtures.SyncDetailsInput{FixtureID: 10},
+			fixtureDaoMock: &MockFixtureDao{
+				FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+					f := finished(id, &syncedAt)
+					return &f, nil
+				},
+			},
+			expectedSynced: []int64{10},
+		},
+		{
+			title: "error FixtureDao.List",
+			req:   &fixtures.SyncDetailsInput{LeagueID: 39, Season: 2021},
+			fixtureDaoMock: &MockFixtureDao{
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error FixtureDetailService.Sync",
+			req:   &fixtures.SyncDetailsInput{LeagueID: 39, Season: 2021},
+			fixtureDaoMock: &MockFixtureDao{
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					return []fixtures.Fixture{finished(10, nil), finished(11, nil)}, 2, nil
+				},
+			},
+			syncErr:        resterror.NewStandardInternalServerError(),
+			expectedSynced: []int64{10, 11},
+			expectedErr:    resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success league season syncs the finished fixtures missing details",
+			req:   &fixtures.SyncDetailsInput{LeagueID: 39, Season: 2021},
+			fixtureDaoMock: &MockFixtureDao{
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					if req.Page == 1 {
+						return []fixtures.Fixture{finished(10, nil), finished(11, &syncedAt)}, 501, nil
+					}
+					return []fixtures.Fixture{{ID: 12, Status: "NS"}, finished(13, nil)}, 501, nil
+				},
+			},
+			expectedSynced: []int64{10, 13},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			fixtures.FixtureDao = testCase.fixtureDaoMock
+			var synced []int64
+			FixtureDetailService = &MockFixtureDetailService{
+				FuncSync: func(fixtureID int64) resterror.RestErrorI {
+					synced = append(synced, fixtureID)
+					return testCase.syncErr
+				},
+			}
+
+			err := (&fixtureDetailService{}).SyncMissing(context.Background(), testCase.req)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedSynced, synced)
+		})
+	}
+}
+
+func TestTeamStatistic(t *testing.T) {
+	res := teamStatistic(api_sports.FixtureStatistics{
+		Team: api_sports.PlayerTeam{ID: 2, Name: "Away"},
+		Statistics: []api_sports.StatisticValue{
+			{Type: "Ball Possession", Value: " 38% "},
+			{Type: "Passes %", Value: "81%"},
+			{Type: "expected_goals", Value: nil},
+			{Type: "Fouls", Value: "n/a"},
+		},
+	})
+
+	possession := int64(38)
+	assert.Equal(t, fixture_statistics.TeamStatistic{TeamID: 2, TeamName: "Away", Possession: &possession}, res)
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/rounds"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"io"
+	"sort"
+	"time"
+)
+
+// detailsRetryWindow is how long after the kickoff a sync keeps importing the details of a finished fixture that
+// does not have them all yet
+const detailsRetryWindow = 72 * time.Hour
+
+type FixtureServiceI interface {
+	Find(ctx context.Context, id int64) (*fixtures.Fixture, resterror.RestErrorI)
+	List(ctx context.Context, req *fixtures.ListFixtureInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	Export(ctx context.Context, req *fixtures.ListFixtureInput, w export.Writer) resterror.RestErrorI
+	Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI)
+	Sync(ctx context.Context, req *fixtures.SyncFixtureInput) resterror.RestErrorI
+}
+
+type fixtureService struct {
+	apiSports *api_sports_provider.Client
+}
+
+var FixtureService FixtureServiceI = &fixtureService{}
+
+func (s *fixtureService) Find(ctx context.Context, id int64) (*fixtures.Fixture, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "FixtureService.Find")
+	defer span.End()
+
+	res, err := fixtures.FixtureDao.FindByID(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "fixture")
+	}
+	return res, nil
+}
+
+func (s *fixtureService) List(ctx context.Context, req *fixtures.ListFixtureInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "FixtureService.List")
+	defer span.End()
+
+	results, total, err := fixtures.FixtureDao.List(ctx, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	res := pagination.GeneratePaginatedResponse(req.Filter.Project(results), req.Page, req.PerPage, total)
+	res.NextCursor, res.PrevCursor = req.Filter.Cursors()
+
+	return &res, nil
+}
+
+// Export streams the records matching the filters of the list request, page by page
+func (s *fixtureService) Export(ctx context.Context, req *fixtures.ListFixtureInput, w export.Writer) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "FixtureService.Export")
+	defer span.End()
+
+	req.Page, req.PerPage = 1, export.PerPage
+	err := export.Stream(w, &req.Filter, func() (interface{}, error) {
+		results, _, err := fixtures.FixtureDao.List(ctx, req)
+		return results, err
+	})
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("FixtureService Export Stream", "error", err)
+		return resterror.NewStandardInternalServerError()
+	}
+	return nil
+}
+
+// Import upserts the rows of a CSV or JSON upload, the rows that are invalid or fail are reported
+func (s *fixtureService) Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "FixtureService.Import")
+	defer span.End()
+
+	var items []fixtures.Fixture
+	rowErrs, apiErr := importer.Decode(r, format, &items)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return importer.Run(ctx, items, rowErrs, func(ctx context.Context, i int) (int64, error) {
+		return items[i].ID, fixtures.FixtureDao.Upsert(ctx, &items[i])
+	})
+}
+
+// Sync imports the fixtures of a league season from API Sports, then scores the user predictions of every finished
+// fixture not scored yet, or whose score was corrected, and imports the events, lineups and statistics of every
+// fixture that finished since the previous sync, or that finished recently and does not have them all yet. The
+// rounds of the season are derived from the league.round of the fixtures
+func (s *fixtureService) Sync(ctx context.Context, req *fixtures.SyncFixtureInput) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "FixtureService.Sync")
+	defer span.End()
+
+	zlog.FromContext(ctx).Infow("Sync Fixtures Start", "league_id", req.LeagueID, "season", req.Season)
+	run := metrics.StartSync("fixtures")
+	defer run.Done()
+	// Get the fixtures we already know about, so we can identify the ones that just finished or are not scored
+	stored, apiErr := s.storedFixtures(ctx, req.LeagueID, req.Season)
+	if apiErr != nil {
+		run.Fail()
+		return apiErr
+	}
+
+	// Get the list of fixtures from API Sports
+	res, err := s.apiSports.GetFixtures(ctx, req.LeagueID, req.Season)
+	if err != nil {
+		run.Fail()
+		return resterror.NewStandardInternalServerError()
+	}
+
+	synced := make([]fixtures.Fixture, 0, len(res))
+	for _, v := range res {
+		kickoff, parseErr := time.Parse(time.RFC3339, v.Fixture.Date)
+		if parseErr != nil {
+			zlog.FromContext(ctx).Warnw("could not parse fixture date", "fixture_id", v.Fixture.ID)
+			run.Row(metrics.RowFailed)
+			continue
+		}
+
+		fixture := fixtures.Fixture{
+			ID:           v.Fixture.ID,
+			LeagueID:     v.League.ID,
+			Season:       v.League.Season,
+			Round:        v.League.Round,
+			KickoffAt:    kickoff.UTC(),
+			Status:       v.Fixture.Status.Short,
+			HomeTeamID:   v.Teams.Home.ID,
+			HomeTeamName: v.Teams.Home.Name,
+			AwayTeamID:   v.Teams.Away.ID,
+			AwayTeamName: v.Teams.Away.Name,
+			HomeGoals:    v.Goals.Home,
+			AwayGoals:    v.Goals.Away,
+		}
+		switch {
+		case v.Teams.Home.Winner != nil && *v.Teams.Home.Winner:
+			fixture.WinnerTeamID = &fixture.HomeTeamID
+		case v.Teams.Away.Winner != nil && *v.Teams.Away.Winner:
+			fixture.WinnerTeamID = &fixture.AwayTeamID
+		}
+		synced = append(synced, fixture)
+		if err := fixtures.FixtureDao.Upsert(ctx, &fixture); err != nil {
+			zlog.FromContext(ctx).Warnw("could not upsert fixture", "fixture_id", fixture.ID)
+			run.Row(metrics.RowFailed)
+			continue
+		}
+		run.Row(metrics.RowUpserted)
+
+		if !fixture.Finished() {
+			continue
+		}
+		previous, known := stored[fixture.ID]
+		// Score the fixtures a previous sync did not, a failed scoring is tried again by the next sync
+		if !known || !scored(&previous, &fixture) {
+			if apiErr := UserPredictionService.ScoreFixture(ctx, &fixture); apiErr != nil {
+				zlog.FromContext(ctx).Warnw("could not score fixture", "fixture_id", fixture.ID)
+			}
+		}
+		// Trigger the sync of the details for fixtures that finished since the last sync, the ones API Sports did not
+		// have them all for yet are tried again by the next syncs
+		if !known || !previous.Finished() || detailsMissing(&previous, helpers.GetNow()) {
+			if apiErr := FixtureDetailService.Sync(ctx, fixture.ID); apiErr != nil {
+				zlog.FromContext(ctx).Warnw("could not sync fixture details", "fixture_id", fixture.ID)
+			}
+		}
+	}
+	for _, round := range seasonRounds(synced) {
+		if err := rounds.RoundDao.Upsert(ctx, &round); err != nil {
+			zlog.FromContext(ctx).Warnw("could not upsert round", "league_id", round.LeagueID, "round", round.Name)
+		}
+	}
+	zlog.FromContext(ctx).Infow("Sync Fixtures End", "league_id", req.LeagueID, "season", req.Season)
+	return nil
+}
+
+// seasonRounds derives the rounds of a league season from its fixtures, ordered by their first kickoff. A round
+// is played over two legs when a pair of teams meets twice in it, and the knockout rounds played before the group
+// stage are qualifying rounds
+func seasonRounds(season []fixtures.Fixture) []rounds.Round {
+	type pair struct{ a, b int64 }
+	var results []rounds.Round
+	index := make(map[string]int)
+	starts := make(map[string]time.Time)
+	pairs := make(map[string]map[pair]int)
+	for _, f := range season {
+		if f.Round == "" {
+			continue
+		}
+		i, ok := index[f.Round]
+		if !ok {
+			i = len(results)
+			index[f.Round] = i
+			results = append(results, rounds.Round{
+				LeagueID: f.LeagueID,
+				Season:   f.Season,
+				Name:     f.Round,
+				Stage:    rounds.StageOf(f.Round),
+				Legs:     1,
+			})
+			starts[f.Round] = f.KickoffAt
+			pairs[f.Round] = make(map[pair]int)
+		}
+		if f.KickoffAt.Before(starts[f.Round]) {
+			starts[f.Round] = f.KickoffAt
+		}
+		p := pair{f.HomeTeamID, f.AwayTeamID}
+		if p.a > p.b {
+			p.a, p.b = p.b, p.a
+		}
+		pairs[f.Round][p]++
+		if pairs[f.Round][p] > 1 {
+			results[i].Legs = 2
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return starts[results[i].Name].Before(starts[results[j].Name])
+	})
+	groups := false
+	for i := range results {
+		results[i].Position = int64(i + 1)
+		if results[i].Stage == rounds.StageGroup {
+			groups = true
+		}
+	}
+	for i := 0; groups && results[i].Stage != rounds.StageGroup; i++ {
+		if results[i].Stage == rounds.StageKnockout {
+			results[i].Stage = rounds.StageQualifying
+		}
+	}
+	return results
+}
+
+// storedFixtures returns the stored fixtures of a league season by id
+func (s *fixtureService) storedFixtures(ctx context.Context, leagueID int64, season int64) (map[int64]fixtures.Fixture, resterror.RestErrorI) {
+	stored := make(map[int64]fixtures.Fixture)
+	req := fixtures.ListFixtureInput{
+		LeagueID: leagueID,
+		Season:   season,
+		Page:     1,
+		PerPage:  500,
+	}
+	for {
+		results, total, err := fixtures.FixtureDao.List(ctx, &req)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, resterror.NewStandardInternalServerError()
+		}
+		for i := range results {
+			stored[results[i].ID] = results[i]
+		}
+		if req.Page*req.PerPage >= total {
+			return stored, nil
+		}
+		req.Page++
+	}
+}
+
+// detailsMissing checks if the stored fixture kicked off recently and does not have all its details yet
+func detailsMissing(stored *fixtures.Fixture, now time.Time) bool {
+	return stored.DetailsSyncedAt == nil && now.Sub(stored.KickoffAt) < detailsRetryWindow
+}
+
+// scored checks if the stored fixture was scored with the final score synced, the upsert resets scored_at when the
+// score changed
+func scored(stored *fixtures.Fixture, synced *fixtures.Fixture) bool {
+	return stored.ScoredAt != nil && stored.Finished() &&
+		*stored.HomeGoals == *synced.HomeGoals && *stored.AwayGoals == *synced.AwayGoals
+}
@@ -0,0 +1,366 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/clients/restclient"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/domains/fixture_events"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/rounds"
+	"github.com/development-raul/footy-predictor/src/domains/user_predictions"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockFixtureDao struct {
+	FuncUpsert            func(fixture *fixtures.Fixture) error
+	FuncFindByID          func(id int64) (*fixtures.Fixture, error)
+	FuncList              func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error)
+	FuncMarkScored        func(id int64, scoredAt time.Time) error
+	FuncMarkDetailsSynced func(id int64, syncedAt time.Time) error
+}
+
+func (m MockFixtureDao) Upsert(ctx context.Context, fixture *fixtures.Fixture) error {
+	return m.FuncUpsert(fixture)
+}
+func (m MockFixtureDao) FindByID(ctx context.Context, id int64) (*fixtures.Fixture, error) {
+	return m.FuncFindByID(id)
+}
+func (m MockFixtureDao) List(ctx context.Context, req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+	return m.FuncList(req)
+}
+func (m MockFixtureDao) MarkScored(ctx context.Context, id int64, scoredAt time.Time) error {
+	return m.FuncMarkScored(id, scoredAt)
+}
+func (m MockFixtureDao) MarkDetailsSynced(ctx context.Context, id int64, syncedAt time.Time) error {
+	return m.FuncMarkDetailsSynced(id, syncedAt)
+}
+
+func TestFixtureService_Find(t *testing.T) {
+	testCases := []struct {
+		title          string
+		fixtureDaoMock fixtures.FixtureDaoI
+		expectedRes    *fixtures.Fixture
+		expectedErr    resterror.RestErrorI
+	}{
+		{
+			title: "error FixtureDao.FindByID",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+					return nil, errors.New("error FindByID")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+					return &fixtures.Fixture{ID: id}, nil
+				},
+			},
+			expectedRes: &fixtures.Fixture{ID: 10},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			fixtures.FixtureDao = testCase.fixtureDaoMock
+
+			res, err := FixtureService.Find(context.Background(), 10)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestFixtureService_List(t *testing.T) {
+	testCases := []struct {
+		title          string
+		fixtureDaoMock fixtures.FixtureDaoI
+		expectedRes    *pagination.PaginatedResponse
+		expectedErr    resterror.RestErrorI
+	}{
+		{
+			title: "error FixtureDao.List",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					return []fixtures.Fixture{{ID: 10}}, 1, nil
+				},
+			},
+			expectedRes: &pagination.PaginatedResponse{
+				From:        1,
+				Data:        []fixtures.Fixture{{ID: 10}},
+				CurrentPage: 1,
+				LastPage:    1,
+				PerPage:     20,
+				To:          1,
+				Total:       1,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			fixtures.FixtureDao = testCase.fixtureDaoMock
+
+			res, err := FixtureService.List(context.Background(), &fixtures.ListFixtureInput{})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestFixtureService_Sync(t *testing.T) {
+	FixtureService = &fixtureService{apiSports: api_sports_provider.New(config.APISportsConfig{BaseURL: "http://localhost"})}
+	FixtureDetailService = &fixtureDetailService{apiSports: api_sports_provider.New(config.APISportsConfig{BaseURL: "http://localhost"})}
+	homeGoals, awayGoals, noGoals := int64(2), int64(1), int64(0)
+	scoredAt := time.Date(2022, 1, 15, 17, 0, 0, 0, time.UTC)
+	fixturesBody := `{
+		"get": "fixtures",
+		"errors": [],
+		"results": 2,
+		"paging": {"current": 1, "total": 1},
+		"response": [
+			{
+				"fixture": {"id": 10, "date": "2022-01-15T15:00:00+00:00", "status": {"long": "Match Finished", "short": "FT"}},
+				"league": {"id": 39, "season": 2021, "round": "Regular Season - 21"},
+				"teams": {"home": {"id": 1, "name": "Home"}, "away": {"id": 2, "name": "Away"}},
+				"goals": {"home": 2, "away": 1}
+			},
+			{
+				"fixture": {"id": 11, "date": "2022-01-15T17:30:00+00:00", "status": {"long": "Match Finished", "short": "FT"}},
+				"league": {"id": 39, "season": 2021, "round": "Regular Season - 21"},
+				"teams": {"home": {"id": 3, "name": "Other"}, "away": {"id": 4, "name": "Another"}},
+				"goals": {"home": 0, "away": 0}
+			}
+		]
+	}`
+
+	testCases := []struct {
+		title          string
+		fixtureDaoMock fixtures.FixtureDaoI
+		restClientResp *http.Response
+		expectedScored []int64
+		expectedSynced []int64
+		expectedRounds []rounds.Round
+		expectedErr    resterror.RestErrorI
+	}{
+		{
+			title: "error FixtureDao.List",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error api_sports_provider.GetFixtures",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					return nil, 0, nil
+				},
+			},
+			restClientResp: &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       ioutil.NopCloser(strings.NewReader(``)),
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success scores only newly finished fixtures",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					// Fixture 11 was already finished and scored during the previous sync
+					return []fixtures.Fixture{
+						{ID: 10, Status: "1H", HomeGoals: &homeGoals, AwayGoals: &awayGoals},
+						{ID: 11, Status: "FT", HomeGoals: &noGoals, AwayGoals: &noGoals, ScoredAt: &scoredAt},
+					}, 2, nil
+				},
+				FuncUpsert: func(fixture *fixtures.Fixture) error {
+					return nil
+				},
+				FuncMarkScored: func(id int64, scoredAt time.Time) error {
+					return nil
+				},
+			},
+			restClientResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(fixturesBody)),
+			},
+			expectedScored: []int64{10},
+			expectedSynced: []int64{10},
+			expectedRounds: []rounds.Round{
+				{LeagueID: 39, Season: 2021, Name: "Regular Season - 21", Position: 1, Stage: rounds.StageLeague, Legs: 1},
+			},
+			expectedErr: nil,
+		},
+		{
+			title: "success scores again the fixtures not scored or with a corrected score",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					// The scoring of fixture 11 failed during the previous sync and fixture 10 was scored 1-1
+					return []fixtures.Fixture{
+						{ID: 10, Status: "FT", HomeGoals: &awayGoals, AwayGoals: &awayGoals, ScoredAt: &scoredAt},
+						{ID: 11, Status: "FT", HomeGoals: &noGoals, AwayGoals: &noGoals},
+					}, 2, nil
+				},
+				FuncUpsert: func(fixture *fixtures.Fixture) error {
+					return nil
+				},
+				FuncMarkScored: func(id int64, scoredAt time.Time) error {
+					return nil
+				},
+			},
+			restClientResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(fixturesBody)),
+			},
+			expectedScored: []int64{10, 11},
+			expectedRounds: []rounds.Round{
+				{LeagueID: 39, Season: 2021, Name: "Regular Season - 21", Position: 1, Stage: rounds.StageLeague, Legs: 1},
+			},
+			expectedErr: nil,
+		},
+		{
+			title: "success syncs again the details missing for the recently finished fixtures",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					// Both fixtures were finished and scored during the previous sync, only fixture 11 has its details
+					recent := time.Now().UTC().Add(-time.Hour)
+					return []fixtures.Fixture{
+						{ID: 10, KickoffAt: recent, Status: "FT", HomeGoals: &homeGoals, AwayGoals: &awayGoals, ScoredAt: &scoredAt},
+						{ID: 11, KickoffAt: recent, Status: "FT", HomeGoals: &noGoals, AwayGoals: &noGoals, ScoredAt: &scoredAt, DetailsSyncedAt: &scoredAt},
+					}, 2, nil
+				},
+				FuncUpsert: func(fixture *fixtures.Fixture) error {
+					return nil
+				},
+			},
+			restClientResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(fixturesBody)),
+			},
+			expectedSynced: []int64{10},
+			expectedRounds: []rounds.Round{
+				{LeagueID: 39, Season: 2021, Name: "Regular Season - 21", Position: 1, Stage: rounds.StageLeague, Legs: 1},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			// Initialization
+			restclient.StartMockups()
+			restclient.FlushMockups()
+			restclient.AddMockup(restclient.Mock{
+				Url:        "http://localhost/fixtures?league=39&season=2021",
+				HttpMethod: http.MethodGet,
+				Response:   testCase.restClientResp,
+			})
+			fixtures.FixtureDao = testCase.fixtureDaoMock
+			var synced []rounds.Round
+			rounds.RoundDao = &MockRoundDao{
+				FuncUpsert: func(round *rounds.Round) error {
+					synced = append(synced, *round)
+					return nil
+				},
+			}
+			var scored []int64
+			user_predictions.UserPredictionDao = &MockUserPredictionDao{
+				FuncListForScoring: func(fixtureID int64) ([]user_predictions.ScoringRow, error) {
+					scored = append(scored, fixtureID)
+					return nil, nil
+				},
+				FuncUpdatePoints: func(id int64, points int64, scoredAt time.Time) error {
+					return nil
+				},
+			}
+
+			// The details of the newly finished fixtures are synced, API Sports has their events only
+			for url, body := range map[string]string{
+				"http://localhost/fixtures/events?fixture=10":     `{"response": [{"time": {"elapsed": 23}, "team": {"id": 1, "name": "Home"}, "player": {}, "assist": {}, "type": "Goal", "detail": "Normal Goal"}]}`,
+				"http://localhost/fixtures/lineups?fixture=10":    `{"response": []}`,
+				"http://localhost/fixtures/statistics?fixture=10": `{"response": []}`,
+			} {
+				restclient.AddMockup(restclient.Mock{
+					Url:        url,
+					HttpMethod: http.MethodGet,
+					Response:   &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body))},
+				})
+			}
+			var details []int64
+			fixture_events.FixtureEventDao = &MockFixtureEventDao{
+				FuncReplace: func(fixtureID int64, events []fixture_events.Event) error {
+					details = append(details, fixtureID)
+					return nil
+				},
+			}
+
+			// Execution
+			err := FixtureService.Sync(context.Background(), &fixtures.SyncFixtureInput{LeagueID: 39, Season: 2021})
+
+			// Assertions
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedScored, scored)
+			assert.Equal(t, testCase.expectedSynced, details)
+			assert.Equal(t, testCase.expectedRounds, synced)
+		})
+	}
+}
+
+func TestDetailsMissing(t *testing.T) {
+	now := time.Date(2022, 1, 18, 12, 0, 0, 0, time.UTC)
+	syncedAt := time.Date(2022, 1, 15, 17, 0, 0, 0, time.UTC)
+
+	assert.True(t, detailsMissing(&fixtures.Fixture{KickoffAt: now.Add(-71 * time.Hour)}, now))
+	assert.False(t, detailsMissing(&fixtures.Fixture{KickoffAt: now.Add(-73 * time.Hour)}, now))
+	assert.False(t, detailsMissing(&fixtures.Fixture{KickoffAt: now.Add(-time.Hour), DetailsSyncedAt: &syncedAt}, now))
+}
+
+func TestSeasonRounds(t *testing.T) {
+	start := time.Date(2021, 6, 22, 19, 0, 0, 0, time.UTC)
+	fixture := func(day int, round string, home int64, away int64) fixtures.Fixture {
+		return fixtures.Fixture{LeagueID: 2, Season: 2021, Round: round, KickoffAt: start.AddDate(0, 0, day), HomeTeamID: home, AwayTeamID: away}
+	}
+	season := []fixtures.Fixture{
+		fixture(70, "Group A - 1", 1, 2),
+		fixture(71, "Group A - 1", 3, 4),
+		fixture(0, "1st Qualifying Round", 5, 6),
+		fixture(7, "1st Qualifying Round", 6, 5),
+		fixture(56, "Play-offs", 7, 8),
+		fixture(63, "Play-offs", 8, 7),
+		fixture(240, "Final", 1, 3),
+		{LeagueID: 2, Season: 2021, KickoffAt: start},
+	}
+
+	assert.Equal(t, []rounds.Round{
+		{LeagueID: 2, Season: 2021, Name: "1st Qualifying Round", Position: 1, Stage: rounds.StageQualifying, Legs: 2},
+		{LeagueID: 2, Season: 2021, Name: "Play-offs", Position: 2, Stage: rounds.StageQualifying, Legs: 2},
+		{LeagueID: 2, Season: 2021, Name: "Group A - 1", Position: 3, Stage: rounds.StageGroup, Legs: 1},
+		{LeagueID: 2, Season: 2021, Name: "Final", Position: 4, Stage: rounds.StageKnockout, Legs: 1},
+	}, seasonRounds(season))
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/api_sports"
+	"github.com/development-raul/footy-predictor/src/domains/injuries"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"time"
+)
+
+type InjuryServiceI interface {
+	Unavailable(ctx context.Context, teamID int64) ([]injuries.Unavailable, resterror.RestErrorI)
+	Sync(ctx context.Context, req *injuries.SyncInjuryInput) resterror.RestErrorI
+}
+
+type injuryService struct {
+	apiSports *api_sports_provider.Client
+}
+
+var InjuryService InjuryServiceI = &injuryService{}
+
+// Unavailable returns the players of a team missing its next fixture, the ones who played the most first
+func (s *injuryService) Unavailable(ctx context.Context, teamID int64) ([]injuries.Unavailable, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "InjuryService.Unavailable")
+	defer span.End()
+
+	res, err := injuries.InjuryDao.ListByTeam(ctx, teamID, helpers.GetNow())
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if res == nil {
+		res = []injuries.Unavailable{}
+	}
+	return res, nil
+}
+
+// Sync imports the players missing a fixture, or the fixtures of a league season, each fixture replacing the
+// players stored for it. The expected return of a missing player is the end of their sideline period covering the
+// fixture, looked up once per player
+func (s *injuryService) Sync(ctx context.Context, req *injuries.SyncInjuryInput) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "InjuryService.Sync")
+	defer span.End()
+
+	zlog.FromContext(ctx).Infow("Sync Injuries Start", "fixture_id", req.FixtureID, "league_id", req.LeagueID, "season", req.Season)
+	run := metrics.StartSync("injuries")
+	defer run.Done()
+	// Get the injuries from API Sports
+	res, apiErr := s.apiSports.GetInjuries(ctx, api_sports.InjuriesRequest{
+		Fixture: req.FixtureID,
+		League:  req.LeagueID,
+		Season:  req.Season,
+	})
+	if apiErr != nil {
+		run.Fail()
+		return resterror.NewStandardInternalServerError()
+	}
+
+	// Group the players by fixture in the order API Sports lists the fixtures
+	var fixtureIDs []int64
+	byFixture := make(map[int64][]injuries.Injury)
+	sidelines := make(map[int64][]api_sports.Sideline)
+	for _, v := range res {
+		injury := injuries.Injury{
+			FixtureID:  v.Fixture.ID,
+			PlayerID:   v.Player.ID,
+			PlayerName: v.Player.Name,
+			TeamID:     v.Team.ID,
+			TeamName:   v.Team.Name,
+			Type:       injuries.TypeOf(v.Player.Type),
+			Reason:     v.Player.Reason,
+		}
+		if injury.Type == injuries.TypeMissing {
+			periods, ok := sidelines[injury.PlayerID]
+			if !ok {
+				var apiErr *api_sports.ErrorResponse
+				if periods, apiErr = s.apiSports.GetSidelined(ctx, injury.PlayerID); apiErr != nil {
+					zlog.FromContext(ctx).Warnw("could not get the sideline periods of the player", "player_id", injury.PlayerID)
+				}
+				sidelines[injury.PlayerID] = periods
+			}
+			injury.ExpectedReturn = expectedReturn(periods, v.Fixture.Date)
+		}
+		if _, ok := byFixture[injury.FixtureID]; !ok {
+			fixtureIDs = append(fixtureIDs, injury.FixtureID)
+		}
+		byFixture[injury.FixtureID] = append(byFixture[injury.FixtureID], injury)
+	}
+
+	for _, fixtureID := range fixtureIDs {
+		if err := injuries.InjuryDao.Replace(ctx, fixtureID, byFixture[fixtureID]); err != nil {
+			zlog.FromContext(ctx).Warnw("could not replace fixture injuries", "fixture_id", fixtureID)
+			run.Row(metrics.RowFailed)
+			continue
+		}
+		run.Row(metrics.RowUpserted)
+	}
+	zlog.FromContext(ctx).Infow("Sync Injuries End", "fixture_id", req.FixtureID, "league_id", req.LeagueID, "season", req.Season)
+	return nil
+}
+
+// expectedReturn returns the end of the latest sideline period started by the day of the fixture and not over
+// before it, nil when there is none or its end is unknown
+func expectedReturn(periods []api_sports.Sideline, fixtureDate string) *time.Time {
+	kickoff, err := time.Parse(time.RFC3339, fixtureDate)
+	if err != nil {
+		return nil
+	}
+	day := kickoff.UTC().Truncate(24 * time.Hour)
+	var res, latest *time.Time
+	for _, p := range periods {
+		start, err := time.Parse("2006-01-02", p.Start)
+		if err != nil || start.After(day) || (latest != nil && start.Before(*latest)) {
+			continue
+		}
+		if p.End == nil {
+			latest, res = &start, nil
+			continue
+		}
+		end, err := time.Parse("2006-01-02", *p.End)
+		if err != nil || end.Before(day) {
+			continue
+		}
+		latest, res = &start, &end
+	}
+	return res
+}
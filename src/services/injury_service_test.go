@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/clients/restclient"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/domains/api_sports"
+	"github.com/development-raul/footy-predictor/src/domains/injuries"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockInjuryDao struct {
+	FuncReplace       func(fixtureID int64, injuries []injuries.Injury) error
+	FuncListByFixture func(fixtureID int64) ([]injuries.Unavailable, error)
+	FuncListByTeam    func(teamID int64, from time.Time) ([]injuries.Unavailable, error)
+}
+
+func (m MockInjuryDao) Replace(ctx context.Context, fixtureID int64, injuries []injuries.Injury) error {
+	return m.FuncReplace(fixtureID, injuries)
+}
+func (m MockInjuryDao) ListByFixture(ctx context.Context, fixtureID int64) ([]injuries.Unavailable, error) {
+	return m.FuncListByFixture(fixtureID)
+}
+func (m MockInjuryDao) ListByTeam(ctx context.Context, teamID int64, from time.Time) ([]injuries.Unavailable, error) {
+	return m.FuncListByTeam(teamID, from)
+}
+
+func TestInjuryService_Unavailable(t *testing.T) {
+	unavailable := []injuries.Unavailable{{
+		Injury:       injuries.Injury{FixtureID: 10, PlayerID: 276, PlayerName: "Striker", TeamID: 1, Type: injuries.TypeMissing, Reason: "Knee Injury"},
+		MinutesShare: 0.8,
+	}}
+
+	testCases := []struct {
+		title       string
+		listErr     error
+		list        []injuries.Unavailable
+		expectedRes []injuries.Unavailable
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title:       "error InjuryDao.ListByTeam",
+			listErr:     errors.New("error ListByTeam"),
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:       "success no players",
+			expectedRes: []injuries.Unavailable{},
+		},
+		{
+			title:       "success",
+			list:        unavailable,
+			expectedRes: unavailable,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			injuries.InjuryDao = &MockInjuryDao{
+				FuncListByTeam: func(teamID int64, from time.Time) ([]injuries.Unavailable, error) {
+					assert.Equal(t, int64(1), teamID)
+					return testCase.list, testCase.listErr
+				},
+			}
+
+			res, err := InjuryService.Unavailable(context.Background(), 1)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestInjuryService_Sync(t *testing.T) {
+	InjuryService = &injuryService{apiSports: api_sports_provider.New(config.APISportsConfig{BaseURL: "http://localhost"})}
+	injuriesBody := `{"paging": {"current": 1, "total": 1}, "response": [
+		{"player": {"id": 276, "name": "Striker", "type": "Missing Fixture", "reason": "Knee Injury"},
+			"team": {"id": 1, "name": "Home"}, "fixture": {"id": 10, "date": "2022-01-15T15:00:00+00:00"}},
+		{"player": {"id": 300, "name": "Keeper", "type": "Questionable", "reason": "Illness"},
+			"team": {"id": 2, "name": "Away"}, "fixture": {"id": 10, "date": "2022-01-15T15:00:00+00:00"}}
+	]}`
+	sidelinedBody := `{"response": [
+		{"type": "Ankle Injury", "start": "2021-09-01", "end": "2021-10-01"},
+		{"type": "Knee Injury", "start": "2022-01-02", "end": "2022-02-20"}
+	]}`
+
+	testCases := []struct {
+		title            string
+		injuriesStatus   int
+		sidelinedStatus  int
+		expectedInjuries []injuries.Injury
+		expectedErr      resterror.RestErrorI
+	}{
+		{
+			title:          "error api_sports_provider.GetInjuries",
+			injuriesStatus: http.StatusInternalServerError,
+			expectedErr:    resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:           "success without the sideline periods",
+			injuriesStatus:  http.StatusOK,
+			sidelinedStatus: http.StatusInternalServerError,
+			expectedInjuries: []injuries.Injury{
+				{FixtureID: 10, PlayerID: 276, PlayerName: "Striker", TeamID: 1, TeamName: "Home", Type: injuries.TypeMissing, Reason: "Knee Injury"},
+				{FixtureID: 10, PlayerID: 300, PlayerName: "Keeper", TeamID: 2, TeamName: "Away", Type: injuries.TypeQuestionable, Reason: "Illness"},
+			},
+		},
+		{
+			title:           "success",
+			injuriesStatus:  http.StatusOK,
+			sidelinedStatus: http.StatusOK,
+			expectedInjuries: []injuries.Injury{
+				{FixtureID: 10, PlayerID: 276, PlayerName: "Striker", TeamID: 1, TeamName: "Home", Type: injuries.TypeMissing, Reason: "Knee Injury",
+					ExpectedReturn: func() *time.Time { d := time.Date(2022, 2, 20, 0, 0, 0, 0, time.UTC); return &d }()},
+				{FixtureID: 10, PlayerID: 300, PlayerName: "Keeper", TeamID: 2, TeamName: "Away", Type: injuries.TypeQuestionable, Reason: "Illness"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			restclient.StartMockups()
+			restclient.FlushMockups()
+			for url, resp := range map[string]*http.Response{
+				"http://localhost/injuries?fixture=10&page=1": {StatusCode: testCase.injuriesStatus, Body: ioutil.NopCloser(strings.NewReader(injuriesBody))},
+				"http://localhost/sidelined?player=276":       {StatusCode: testCase.sidelinedStatus, Body: ioutil.NopCloser(strings.NewReader(sidelinedBody))},
+			} {
+				restclient.AddMockup(restclient.Mock{Url: url, HttpMethod: http.MethodGet, Response: resp})
+			}
+			var replaced []injuries.Injury
+			injuries.InjuryDao = &MockInjuryDao{
+				FuncReplace: func(fixtureID int64, res []injuries.Injury) error {
+					assert.Equal(t, int64(10), fixtureID)
+					replaced = res
+					return nil
+				},
+			}
+
+			err := InjuryService.Sync(context.Background(), &injuries.SyncInjuryInput{FixtureID: 10})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedInjuries, replaced)
+		})
+	}
+}
+
+func TestExpectedReturn(t *testing.T) {
+	date := func(v string) *string { return &v }
+	day := func(y int, m time.Month, d int) *time.Time {
+		res := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return &res
+	}
+
+	testCases := []struct {
+		title       string
+		periods     []api_sports.Sideline
+		fixtureDate string
+		expectedRes *time.Time
+	}{
+		{
+			title:       "invalid fixture date",
+			periods:     []api_sports.Sideline{{Start: "2022-01-02", End: date("2022-02-20")}},
+			fixtureDate: "15/01/2022",
+		},
+		{
+			title:       "no period",
+			fixtureDate: "2022-01-15T15:00:00+00:00",
+		},
+		{
+			title:       "period over before the fixture",
+			periods:     []api_sports.Sideline{{Start: "2021-09-01", End: date("2021-10-01")}},
+			fixtureDate: "2022-01-15T15:00:00+00:00",
+		},
+		{
+			title:       "period started after the fixture",
+			periods:     []api_sports.Sideline{{Start: "2022-01-16", End: date("2022-02-20")}},
+			fixtureDate: "2022-01-15T15:00:00+00:00",
+		},
+		{
+			title:       "period ending on the day of the fixture",
+			periods:     []api_sports.Sideline{{Start: "2022-01-02", End: date("2022-01-15")}},
+			fixtureDate: "2022-01-15T15:00:00+00:00",
+			expectedRes: day(2022, 1, 15),
+		},
+		{
+			title: "latest period covering the fixture",
+			periods: []api_sports.Sideline{
+				{Start: "2022-01-10", End: date("2022-03-01")},
+				{Start: "2021-12-20", End: date("2022-01-31")},
+			},
+			fixtureDate: "2022-01-15T15:00:00+00:00",
+			expectedRes: day(2022, 3, 1),
+		},
+		{
+			title: "latest period with an unknown end",
+			periods: []api_sports.Sideline{
+				{Start: "2021-12-20", End: date("2022-01-31")},
+				{Start: "2022-01-10"},
+			},
+			fixtureDate: "2022-01-15T15:00:00+00:00",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedRes, expectedReturn(testCase.periods, testCase.fixtureDate))
+		})
+	}
+}
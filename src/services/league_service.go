@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/api_sports"
+	"github.com/development-raul/footy-predictor/src/domains/leagues"
+	"github.com/development-raul/footy-predictor/src/domains/seasons"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"io"
+	"time"
+)
+
+type LeagueServiceI interface {
+	Find(ctx context.Context, id int64) (*leagues.League, resterror.RestErrorI)
+	List(ctx context.Context, req *leagues.ListLeagueInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	Export(ctx context.Context, req *leagues.ListLeagueInput, w export.Writer) resterror.RestErrorI
+	Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI)
+	Sync(ctx context.Context) resterror.RestErrorI
+}
+
+type leagueService struct {
+	apiSports *api_sports_provider.Client
+}
+
+var LeagueService LeagueServiceI = &leagueService{}
+
+func (s *leagueService) Find(ctx context.Context, id int64) (*leagues.League, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "LeagueService.Find")
+	defer span.End()
+
+	res, err := leagues.LeagueDao.FindByID(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "league")
+	}
+	return res, nil
+}
+
+func (s *leagueService) List(ctx context.Context, req *leagues.ListLeagueInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "LeagueService.List")
+	defer span.End()
+
+	results, total, err := leagues.LeagueDao.List(ctx, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	res := pagination.GeneratePaginatedResponse(req.Filter.Project(results), req.Page, req.PerPage, total)
+	res.NextCursor, res.PrevCursor = req.Filter.Cursors()
+
+	return &res, nil
+}
+
+// Export streams the records matching the filters of the list request, page by page
+func (s *leagueService) Export(ctx context.Context, req *leagues.ListLeagueInput, w export.Writer) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "LeagueService.Export")
+	defer span.End()
+
+	req.Page, req.PerPage = 1, export.PerPage
+	err := export.Stream(w, &req.Filter, func() (interface{}, error) {
+		results, _, err := leagues.LeagueDao.List(ctx, req)
+		return results, err
+	})
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("LeagueService Export Stream", "error", err)
+		return resterror.NewStandardInternalServerError()
+	}
+	return nil
+}
+
+// Import upserts the rows of a CSV or JSON upload, the rows that are invalid or fail are reported
+func (s *leagueService) Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "LeagueService.Import")
+	defer span.End()
+
+	var items []leagues.League
+	rowErrs, apiErr := importer.Decode(r, format, &items)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return importer.Run(ctx, items, rowErrs, func(ctx context.Context, i int) (int64, error) {
+		return items[i].ID, leagues.LeagueDao.Upsert(ctx, &items[i])
+	})
+}
+
+// Sync imports every league from API Sports, existing leagues get their details refreshed
+func (s *leagueService) Sync(ctx context.Context) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "LeagueService.Sync")
+	defer span.End()
+
+	zlog.FromContext(ctx).Infow("Sync Leagues Start")
+	run := metrics.StartSync("leagues")
+	defer run.Done()
+	// Get the list of leagues from API Sports
+	res, apiErr := s.apiSports.GetLeagues(ctx)
+	if apiErr != nil {
+		run.Fail()
+		return resterror.NewStandardInternalServerError()
+	}
+
+	for _, v := range res {
+		league := leagues.League{
+			ID:          v.League.ID,
+			Name:        v.League.Name,
+			Type:        v.League.Type,
+			Logo:        v.League.Logo,
+			CountryName: v.Country.Name,
+			Active:      true,
+		}
+		// World competitions have no country code
+		if v.Country.Code != nil {
+			league.CountryCode = *v.Country.Code
+		}
+		if err := leagues.LeagueDao.Upsert(ctx, &league); err != nil {
+			zlog.FromContext(ctx).Warnw("could not upsert league", "league_id", league.ID)
+			run.Row(metrics.RowFailed)
+			continue
+		}
+		run.Row(metrics.RowUpserted)
+		s.syncSeasons(ctx, league.ID, v.Seasons)
+	}
+	zlog.FromContext(ctx).Infow("Sync Leagues End")
+	return nil
+}
+
+// syncSeasons stores the dates and current marker of the seasons of a league
+func (s *leagueService) syncSeasons(ctx context.Context, leagueID int64, res []api_sports.LeagueSeason) {
+	for _, v := range res {
+		start, startErr := time.Parse(seasons.DateLayout, v.Start)
+		end, endErr := time.Parse(seasons.DateLayout, v.End)
+		if startErr != nil || endErr != nil {
+			zlog.FromContext(ctx).Warnw("invalid league season dates", "league_id", leagueID, "season", v.Year)
+			continue
+		}
+		leagueSeason := seasons.LeagueSeason{
+			LeagueID:  leagueID,
+			Season:    v.Year,
+			Label:     seasons.Label(start, end),
+			StartDate: start,
+			EndDate:   end,
+			Current:   v.Current,
+		}
+		if err := seasons.SeasonDao.UpsertLeagueSeason(ctx, &leagueSeason); err != nil {
+			zlog.FromContext(ctx).Warnw("could not upsert league season", "league_id", leagueID, "season", v.Year)
+		}
+	}
+}
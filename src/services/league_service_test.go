@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/clients/restclient"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/domains/leagues"
+	"github.com/development-raul/footy-predictor/src/domains/seasons"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockLeagueDao struct {
+	FuncUpsert   func(league *leagues.League) error
+	FuncFindByID func(id int64) (*leagues.League, error)
+	FuncList     func(req *leagues.ListLeagueInput) ([]leagues.League, int64, error)
+}
+
+func (m MockLeagueDao) Upsert(ctx context.Context, league *leagues.League) error {
+	return m.FuncUpsert(league)
+}
+func (m MockLeagueDao) FindByID(ctx context.Context, id int64) (*leagues.League, error) {
+	return m.FuncFindByID(id)
+}
+func (m MockLeagueDao) List(ctx context.Context, req *leagues.ListLeagueInput) ([]leagues.League, int64, error) {
+	return m.FuncList(req)
+}
+
+func TestLeagueService_Find(t *testing.T) {
+	testCases := []struct {
+		title         string
+		leagueDaoMock leagues.LeagueDaoI
+		expectedRes   *leagues.League
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title: "error LeagueDao.FindByID",
+			leagueDaoMock: &MockLeagueDao{
+				FuncFindByID: func(id int64) (*leagues.League, error) {
+					return nil, errors.New("error FindByID")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			leagueDaoMock: &MockLeagueDao{
+				FuncFindByID: func(id int64) (*leagues.League, error) {
+					return &leagues.League{ID: id}, nil
+				},
+			},
+			expectedRes: &leagues.League{ID: 39},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			leagues.LeagueDao = testCase.leagueDaoMock
+
+			res, err := LeagueService.Find(context.Background(), 39)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestLeagueService_List(t *testing.T) {
+	testCases := []struct {
+		title         string
+		leagueDaoMock leagues.LeagueDaoI
+		expectedRes   *pagination.PaginatedResponse
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title: "error LeagueDao.List",
+			leagueDaoMock: &MockLeagueDao{
+				FuncList: func(req *leagues.ListLeagueInput) ([]leagues.League, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			leagueDaoMock: &MockLeagueDao{
+				FuncList: func(req *leagues.ListLeagueInput) ([]leagues.League, int64, error) {
+					return []leagues.League{{ID: 39}}, 1, nil
+				},
+			},
+			expectedRes: func() *pagination.PaginatedResponse {
+				res := pagination.GeneratePaginatedResponse([]leagues.League{{ID: 39}}, 1, 20, 1)
+				return &res
+			}(),
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			leagues.LeagueDao = testCase.leagueDaoMock
+
+			res, err := LeagueService.List(context.Background(), &leagues.ListLeagueInput{Page: 1, PerPage: 20})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestLeagueService_Sync(t *testing.T) {
+	LeagueService = &leagueService{apiSports: api_sports_provider.New(config.APISportsConfig{BaseURL: "http://localhost"})}
+	leaguesBody := `{
+		"get": "leagues",
+		"errors": [],
+		"results": 2,
+		"paging": {"current": 1, "total": 1},
+		"response": [
+			{"league": {"id": 39, "name": "Premier League", "type": "League", "logo": "39.png"}, "country": {"name": "England", "code": "GB"}, "seasons": [
+				{"year": 2021, "start": "2021-08-13", "end": "2022-05-22", "current": true},
+				{"year": 2020, "start": "", "end": "", "current": false}
+			]},
+			{"league": {"id": 1, "name": "World Cup", "type": "Cup", "logo": "1.png"}, "country": {"name": "World", "code": null}, "seasons": [
+				{"year": 2022, "start": "2022-11-20", "end": "2022-12-18", "current": true}
+			]}
+		]
+	}`
+
+	testCases := []struct {
+		title           string
+		restClientResp  *http.Response
+		upsertErr       error
+		expectedRes     []leagues.League
+		expectedSeasons []seasons.LeagueSeason
+		expectedErr     resterror.RestErrorI
+	}{
+		{
+			title: "error api_sports_provider.GetLeagues",
+			restClientResp: &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       ioutil.NopCloser(strings.NewReader(``)),
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success upsert errors are skipped",
+			restClientResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(leaguesBody)),
+			},
+			upsertErr: errors.New("error Upsert"),
+			expectedRes: []leagues.League{
+				{ID: 39, Name: "Premier League", Type: "League", Logo: "39.png", CountryName: "England", CountryCode: "GB", Active: true},
+				{ID: 1, Name: "World Cup", Type: "Cup", Logo: "1.png", CountryName: "World", Active: true},
+			},
+			expectedErr: nil,
+		},
+		{
+			title: "success",
+			restClientResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(leaguesBody)),
+			},
+			expectedRes: []leagues.League{
+				{ID: 39, Name: "Premier League", Type: "League", Logo: "39.png", CountryName: "England", CountryCode: "GB", Active: true},
+				{ID: 1, Name: "World Cup", Type: "Cup", Logo: "1.png", CountryName: "World", Active: true},
+			},
+			expectedSeasons: []seasons.LeagueSeason{
+				{
+					LeagueID:  39,
+					Season:    2021,
+					Label:     "2021/22",
+					StartDate: time.Date(2021, 8, 13, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2022, 5, 22, 0, 0, 0, 0, time.UTC),
+					Current:   true,
+				},
+				{
+					LeagueID:  1,
+					Season:    2022,
+					Label:     "2022",
+					StartDate: time.Date(2022, 11, 20, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2022, 12, 18, 0, 0, 0, 0, time.UTC),
+					Current:   true,
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			// Initialization
+			restclient.StartMockups()
+			restclient.FlushMockups()
+			restclient.AddMockup(restclient.Mock{
+				Url:        "http://localhost/leagues",
+				HttpMethod: http.MethodGet,
+				Response:   testCase.restClientResp,
+			})
+			var upserted []leagues.League
+			leagues.LeagueDao = &MockLeagueDao{
+				FuncUpsert: func(league *leagues.League) error {
+					upserted = append(upserted, *league)
+					return testCase.upsertErr
+				},
+			}
+			var upsertedSeasons []seasons.LeagueSeason
+			seasons.SeasonDao = &MockSeasonDao{
+				FuncUpsertLeagueSeason: func(leagueSeason *seasons.LeagueSeason) error {
+					upsertedSeasons = append(upsertedSeasons, *leagueSeason)
+					return nil
+				},
+			}
+
+			// Execution
+			err := LeagueService.Sync(context.Background())
+
+			// Assertions
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedRes, upserted)
+			assert.Equal(t, testCase.expectedSeasons, upsertedSeasons)
+		})
+	}
+}
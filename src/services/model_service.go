@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/development-raul/footy-predictor/src/domains/backtests"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/models"
+	"github.com/development-raul/footy-predictor/src/predictor"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxCompared is the highest number of model versions compared at once, each one may fit a model
+const maxCompared = 10
+
+type ModelServiceI interface {
+	Create(ctx context.Context, req *models.CreateModelInput) (*models.Model, resterror.RestErrorI)
+	Find(ctx context.Context, id int64) (*models.Model, resterror.RestErrorI)
+	List(ctx context.Context, req *models.ListModelInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	Champion(ctx context.Context, id int64) (*models.Model, resterror.RestErrorI)
+	Compare(ctx context.Context, req *models.CompareModelInput) ([]models.Comparison, resterror.RestErrorI)
+	Fit(ctx context.Context, id int64, req *models.FitInput) (*models.Fit, resterror.RestErrorI)
+}
+
+type modelService struct{}
+
+var ModelService ModelServiceI = &modelService{}
+
+// Create registers the next version of a model once its hyperparameters are valid, the versions are challengers
+// until made champion
+func (s *modelService) Create(ctx context.Context, req *models.CreateModelInput) (*models.Model, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "ModelService.Create")
+	defer span.End()
+
+	if err := predictor.Validate(req.Name, req.Hyperparameters); err != nil {
+		return nil, resterror.NewBadRequestError("INVALID_HYPERPARAMETERS")
+	}
+	hyperparameters := req.Hyperparameters
+	if len(hyperparameters) == 0 || string(hyperparameters) == "null" {
+		hyperparameters = json.RawMessage("{}")
+	}
+	model := &models.Model{
+		Name:            req.Name,
+		Hyperparameters: hyperparameters,
+		Description:     req.Description,
+		CreatedAt:       helpers.GetNow(),
+	}
+	if err := models.ModelDao.Create(ctx, model); err != nil {
+		return nil, resterror.NewDatabaseError(err, "model")
+	}
+	// The version is numbered by the database
+	res, err := models.ModelDao.FindByID(ctx, model.ID)
+	if err != nil {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	return res, nil
+}
+
+func (s *modelService) Find(ctx context.Context, id int64) (*models.Model, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "ModelService.Find")
+	defer span.End()
+
+	model, err := models.ModelDao.FindByID(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "model")
+	}
+	return model, nil
+}
+
+func (s *modelService) List(ctx context.Context, req *models.ListModelInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "ModelService.List")
+	defer span.End()
+
+	res, total, err := models.ModelDao.List(ctx, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if res == nil {
+		res = make([]models.Model, 0)
+	}
+	paginated := pagination.GeneratePaginatedResponse(res, req.Page, req.PerPage, total)
+	return &paginated, nil
+}
+
+// Champion makes the model version the one used when no model is requested
+func (s *modelService) Champion(ctx context.Context, id int64) (*models.Model, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "ModelService.Champion")
+	defer span.End()
+
+	model, err := models.ModelDao.FindByID(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "model")
+	}
+	if err := models.ModelDao.SetChampion(ctx, id); err != nil {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	model.Champion = true
+	return model, nil
+}
+
+// Compare returns the model versions side by side, in the order requested, with their prediction of the fixture
+// and their latest finished backtest of its league
+func (s *modelService) Compare(ctx context.Context, req *models.CompareModelInput) ([]models.Comparison, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "ModelService.Compare")
+	defer span.End()
+
+	refs := strings.Split(req.Models, ",")
+	if len(refs) > maxCompared {
+		return nil, resterror.NewBadRequestError("TOO_MANY_MODELS")
+	}
+	var versions []*models.Model
+	for _, ref := range refs {
+		version, apiErr := resolveModel(ctx, strings.TrimSpace(ref))
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		versions = append(versions, version)
+	}
+
+	var fixture *fixtures.Fixture
+	leagueID := req.LeagueID
+	if req.FixtureID != 0 {
+		var err error
+		if fixture, err = fixtures.FixtureDao.FindByID(ctx, req.FixtureID); err != nil {
+			return nil, resterror.NewDatabaseError(err, "fixture")
+		}
+		if leagueID == 0 {
+			leagueID = fixture.LeagueID
+		}
+	}
+
+	results := make([]models.Comparison, 0, len(versions))
+	for _, version := range versions {
+		comparison := models.Comparison{Model: *version}
+		if fixture != nil {
+			prediction, apiErr := predict(ctx, fixture, version)
+			if apiErr != nil && apiErr.Code() != http.StatusUnprocessableEntity {
+				return nil, apiErr
+			}
+			comparison.Prediction = prediction
+		}
+		backtest, apiErr := s.latestBacktest(ctx, version, leagueID)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		comparison.Backtest = backtest
+		results = append(results, comparison)
+	}
+	return results, nil
+}
+
+// Fit fits the model version on the fixtures of the league that finished until now in the season and the previous
+// one and returns its parameters, e.g. the rho, home advantage, attacks and defences of dixon-coles. The fit is not
+// stored since the predictions are fitted at the kickoff of their fixture
+func (s *modelService) Fit(ctx context.Context, id int64, req *models.FitInput) (*models.Fit, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "ModelService.Fit")
+	defer span.End()
+
+	version, err := models.ModelDao.FindByID(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "model")
+	}
+	now := helpers.GetNow()
+	model, matches, apiErr := fitModel(ctx, version, req.LeagueID, req.Season, now)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	parameters, err := json.Marshal(model)
+	if err != nil {
+		zlog.FromContext(ctx).Errorw("ModelService Fit Marshal", "model", version.Ref(), "error", err)
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	return &models.Fit{
+		ModelID:      version.ID,
+		LeagueID:     req.LeagueID,
+		Season:       req.Season,
+		FittedBefore: now,
+		Matches:      int64(matches),
+		Parameters:   parameters,
+		CreatedAt:    now,
+	}, nil
+}
+
+// latestBacktest returns the latest finished backtest of the model version, of the league unless leagueID is 0
+func (s *modelService) latestBacktest(ctx context.Context, version *models.Model, leagueID int64) (*backtests.Backtest, resterror.RestErrorI) {
+	values := url.Values{
+		"model":  {version.Ref()},
+		"status": {backtests.StatusFinished},
+	}
+	if leagueID != 0 {
+		values.Set("league_id", strconv.FormatInt(leagueID, 10))
+	}
+	query, _ := filter.Parse(values, backtests.Backtest{})
+	query.SkipTotal()
+	res, _, err := backtests.BacktestDao.List(ctx, &backtests.ListBacktestInput{Page: 1, PerPage: 1, Filter: *query})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return &res[0], nil
+}
+
+// resolveModel finds the model version of a reference: name@version, the name alone for its latest version, or
+// empty for the champion. The error is INVALID_MODEL when the version is not a positive number
+func resolveModel(ctx context.Context, ref string) (*models.Model, resterror.RestErrorI) {
+	if ref == "" {
+		model, err := models.ModelDao.FindChampion(ctx)
+		if err != nil {
+			return nil, resterror.NewDatabaseError(err, "champion_model")
+		}
+		return model, nil
+	}
+
+	name, version := ref, int64(0)
+	if i := strings.LastIndex(ref, "@"); i >= 0 {
+		var err error
+		name = ref[:i]
+		if version, err = strconv.ParseInt(ref[i+1:], 10, 64); err != nil || version < 1 {
+			return nil, resterror.NewBadRequestError("INVALID_MODEL")
+		}
+	}
+	model, err := models.ModelDao.FindByVersion(ctx, name, version)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "model")
+	}
+	return model, nil
+}
@@ -0,0 +1,459 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/domains/backtests"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/models"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type MockModelDao struct {
+	FuncCreate        func(model *models.Model) error
+	FuncFindByID      func(id int64) (*models.Model, error)
+	FuncFindByVersion func(name string, version int64) (*models.Model, error)
+	FuncFindChampion  func() (*models.Model, error)
+	FuncList          func(req *models.ListModelInput) ([]models.Model, int64, error)
+	FuncSetChampion   func(id int64) error
+	FuncFindFit       func(modelID int64, leagueID int64, season int64, before time.Time) (*models.Fit, error)
+	FuncSaveFit       func(fit *models.Fit) error
+}
+
+func (m MockModelDao) Create(ctx context.Context, model *models.Model) error {
+	return m.FuncCreate(model)
+}
+func (m MockModelDao) FindByID(ctx context.Context, id int64) (*models.Model, error) {
+	return m.FuncFindByID(id)
+}
+func (m MockModelDao) FindByVersion(ctx context.Context, name string, version int64) (*models.Model, error) {
+	return m.FuncFindByVersion(name, version)
+}
+func (m MockModelDao) FindChampion(ctx context.Context) (*models.Model, error) {
+	return m.FuncFindChampion()
+}
+func (m MockModelDao) List(ctx context.Context, req *models.ListModelInput) ([]models.Model, int64, error) {
+	return m.FuncList(req)
+}
+func (m MockModelDao) SetChampion(ctx context.Context, id int64) error {
+	return m.FuncSetChampion(id)
+}
+func (m MockModelDao) FindFit(ctx context.Context, modelID int64, leagueID int64, season int64, before time.Time) (*models.Fit, error) {
+	return m.FuncFindFit(modelID, leagueID, season, before)
+}
+func (m MockModelDao) SaveFit(ctx context.Context, fit *models.Fit) error {
+	return m.FuncSaveFit(fit)
+}
+
+// testModelDao has the seeded poisson@1 champion and a dixon-coles@2 challenger, it never has a saved fit
+func testModelDao() *MockModelDao {
+	versions := []models.Model{
+		{ID: 1, Name: "poisson", Version: 1, Hyperparameters: json.RawMessage("{}"), Champion: true},
+		{ID: 2, Name: "dixon-coles", Version: 2, Hyperparameters: json.RawMessage(`{"xi":0.005}`)},
+	}
+	return &MockModelDao{
+		FuncFindByID: func(id int64) (*models.Model, error) {
+			for _, v := range versions {
+				if v.ID == id {
+					return &v, nil
+				}
+			}
+			return nil, sql.ErrNoRows
+		},
+		FuncFindByVersion: func(name string, version int64) (*models.Model, error) {
+			for _, v := range versions {
+				if v.Name == name && (version == 0 || v.Version == version) {
+					return &v, nil
+				}
+			}
+			return nil, sql.ErrNoRows
+		},
+		FuncFindChampion: func() (*models.Model, error) {
+			return &versions[0], nil
+		},
+		FuncFindFit: func(modelID int64, leagueID int64, season int64, before time.Time) (*models.Fit, error) {
+			return nil, sql.ErrNoRows
+		},
+		FuncSaveFit: func(fit *models.Fit) error {
+			return nil
+		},
+	}
+}
+
+func TestResolveModel(t *testing.T) {
+	models.ModelDao = testModelDao()
+	testCases := []struct {
+		title       string
+		ref         string
+		expectedRef string
+		expectedErr resterror.RestErrorI
+	}{
+		{title: "champion", ref: "", expectedRef: "poisson@1"},
+		{title: "version", ref: "dixon-coles@2", expectedRef: "dixon-coles@2"},
+		{title: "latest version", ref: "dixon-coles", expectedRef: "dixon-coles@2"},
+		{title: "error invalid version", ref: "dixon-coles@latest", expectedErr: resterror.NewBadRequestError("INVALID_MODEL")},
+		{title: "error version 0", ref: "dixon-coles@0", expectedErr: resterror.NewBadRequestError("INVALID_MODEL")},
+		{title: "error not found", ref: "elo@1", expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "model")},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			res, err := resolveModel(context.Background(), testCase.ref)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, testCase.expectedRef, res.Ref())
+			}
+		})
+	}
+}
+
+func TestModelService_Create(t *testing.T) {
+	testCases := []struct {
+		title        string
+		req          models.CreateModelInput
+		modelDaoMock models.ModelDaoI
+		expectedRes  *models.Model
+		expectedErr  resterror.RestErrorI
+	}{
+		{
+			title:       "error invalid hyperparameters",
+			req:         models.CreateModelInput{Name: "elo", Hyperparameters: json.RawMessage(`{"k":-1}`)},
+			expectedErr: resterror.NewBadRequestError("INVALID_HYPERPARAMETERS"),
+		},
+		{
+			title:       "error unknown hyperparameter",
+			req:         models.CreateModelInput{Name: "poisson", Hyperparameters: json.RawMessage(`{"rho":0.1}`)},
+			expectedErr: resterror.NewBadRequestError("INVALID_HYPERPARAMETERS"),
+		},
+		{
+			title: "error ModelDao.Create",
+			req:   models.CreateModelInput{Name: "elo"},
+			modelDaoMock: &MockModelDao{
+				FuncCreate: func(model *models.Model) error {
+					return errors.New("error Create")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			req:   models.CreateModelInput{Name: "elo", Description: "Default Elo"},
+			modelDaoMock: &MockModelDao{
+				FuncCreate: func(model *models.Model) error {
+					if string(model.Hyperparameters) != "{}" || model.Description != "Default Elo" {
+						return errors.New("unexpected model")
+					}
+					model.ID = 3
+					return nil
+				},
+				FuncFindByID: func(id int64) (*models.Model, error) {
+					return &models.Model{ID: id, Name: "elo", Version: 1}, nil
+				},
+			},
+			expectedRes: &models.Model{ID: 3, Name: "elo", Version: 1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			models.ModelDao = testCase.modelDaoMock
+
+			res, err := ModelService.Create(context.Background(), &testCase.req)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestModelService_Find(t *testing.T) {
+	models.ModelDao = testModelDao()
+
+	res, err := ModelService.Find(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "dixon-coles@2", res.Ref())
+
+	res, err = ModelService.Find(context.Background(), 9)
+	assert.Nil(t, res)
+	assert.Equal(t, resterror.NewDatabaseError(sql.ErrNoRows, "model"), err)
+}
+
+func TestModelService_List(t *testing.T) {
+	testCases := []struct {
+		title        string
+		modelDaoMock models.ModelDaoI
+		expectedRes  *pagination.PaginatedResponse
+		expectedErr  resterror.RestErrorI
+	}{
+		{
+			title: "error ModelDao.List",
+			modelDaoMock: &MockModelDao{
+				FuncList: func(req *models.ListModelInput) ([]models.Model, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success empty",
+			modelDaoMock: &MockModelDao{
+				FuncList: func(req *models.ListModelInput) ([]models.Model, int64, error) {
+					return nil, 0, nil
+				},
+			},
+			expectedRes: &pagination.PaginatedResponse{
+				Data:        []models.Model{},
+				CurrentPage: 1,
+				LastPage:    1,
+				PerPage:     20,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			models.ModelDao = testCase.modelDaoMock
+
+			res, err := ModelService.List(context.Background(), &models.ListModelInput{})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestModelService_Champion(t *testing.T) {
+	var champion int64
+	modelDao := testModelDao()
+	modelDao.FuncSetChampion = func(id int64) error {
+		champion = id
+		return nil
+	}
+	testCases := []struct {
+		title            string
+		id               int64
+		modelDaoMock     models.ModelDaoI
+		expectedChampion int64
+		expectedErr      resterror.RestErrorI
+	}{
+		{
+			title:        "error not found",
+			id:           9,
+			modelDaoMock: modelDao,
+			expectedErr:  resterror.NewDatabaseError(sql.ErrNoRows, "model"),
+		},
+		{
+			title: "error ModelDao.SetChampion",
+			id:    2,
+			modelDaoMock: &MockModelDao{
+				FuncFindByID: modelDao.FuncFindByID,
+				FuncSetChampion: func(id int64) error {
+					return errors.New("error SetChampion")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:            "success",
+			id:               2,
+			modelDaoMock:     modelDao,
+			expectedChampion: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			models.ModelDao = testCase.modelDaoMock
+			champion = 0
+
+			res, err := ModelService.Champion(context.Background(), testCase.id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedChampion, champion)
+			if err == nil {
+				assert.True(t, res.Champion)
+			}
+		})
+	}
+}
+
+func TestModelService_Compare(t *testing.T) {
+	goals := func(v int64) *int64 { return &v }
+	kickoff := time.Date(2022, 1, 15, 15, 0, 0, 0, time.UTC)
+	fixture := fixtures.Fixture{ID: 10, LeagueID: 39, Season: 2021, KickoffAt: kickoff, Status: "NS", HomeTeamID: 2, AwayTeamID: 1}
+	history := []fixtures.Fixture{
+		{ID: 1, KickoffAt: kickoff.AddDate(0, 0, -14), Status: "FT", HomeTeamID: 1, AwayTeamID: 3, HomeGoals: goals(3), AwayGoals: goals(0)},
+		{ID: 2, KickoffAt: kickoff.AddDate(0, 0, -7), Status: "FT", HomeTeamID: 2, AwayTeamID: 1, HomeGoals: goals(1), AwayGoals: goals(2)},
+	}
+	fixtureDao := &MockFixtureDao{
+		FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+			if id != 10 {
+				return nil, sql.ErrNoRows
+			}
+			return &fixture, nil
+		},
+		FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+			if req.Season != 2021 {
+				return nil, 0, nil
+			}
+			return history, 2, nil
+		},
+	}
+	// Only the champion was backtested on the league
+	backtestDao := &MockBacktestDao{
+		FuncList: func(req *backtests.ListBacktestInput) ([]backtests.Backtest, int64, error) {
+			if len(req.Filter.Conditions()) != 3 || req.PerPage != 1 || req.Filter.Total() {
+				return nil, 0, errors.New("unexpected filter")
+			}
+			if req.Filter.Conditions()[1].Args[0] != "poisson@1" {
+				return nil, 0, nil
+			}
+			return []backtests.Backtest{{ID: 7, LeagueID: 39, Model: "poisson@1", Status: backtests.StatusFinished}}, 0, nil
+		},
+	}
+
+	testCases := []struct {
+		title           string
+		req             models.CompareModelInput
+		backtestDaoMock backtests.BacktestDaoI
+		expectedRefs    []string
+		expectedErr     resterror.RestErrorI
+	}{
+		{
+			title:       "error too many models",
+			req:         models.CompareModelInput{Models: "poisson,poisson,poisson,poisson,poisson,poisson,poisson,poisson,poisson,poisson,poisson"},
+			expectedErr: resterror.NewBadRequestError("TOO_MANY_MODELS"),
+		},
+		{
+			title:       "error invalid model",
+			req:         models.CompareModelInput{Models: "poisson@x"},
+			expectedErr: resterror.NewBadRequestError("INVALID_MODEL"),
+		},
+		{
+			title:       "error fixture not found",
+			req:         models.CompareModelInput{Models: "poisson", FixtureID: 11},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "fixture"),
+		},
+		{
+			title: "error BacktestDao.List",
+			req:   models.CompareModelInput{Models: "poisson", LeagueID: 39},
+			backtestDaoMock: &MockBacktestDao{
+				FuncList: func(req *backtests.ListBacktestInput) ([]backtests.Backtest, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:           "success",
+			req:             models.CompareModelInput{Models: "dixon-coles@2, poisson", FixtureID: 10},
+			backtestDaoMock: backtestDao,
+			expectedRefs:    []string{"dixon-coles@2", "poisson@1"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			models.ModelDao = testModelDao()
+			fixtures.FixtureDao = fixtureDao
+			backtests.BacktestDao = testCase.backtestDaoMock
+
+			res, err := ModelService.Compare(context.Background(), &testCase.req)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if err != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Len(t, res, len(testCase.expectedRefs))
+			for i, ref := range testCase.expectedRefs {
+				assert.Equal(t, ref, res[i].Model.Ref())
+				assert.Equal(t, ref, res[i].Prediction.Model)
+				assert.Equal(t, 2, res[i].Prediction.MatchesUsed)
+			}
+			// Dixon-Coles fits its ratings by maximum likelihood where Poisson averages the goals
+			assert.NotEqual(t, res[0].Prediction.Draw, res[1].Prediction.Draw)
+			assert.Nil(t, res[0].Backtest)
+			assert.Equal(t, int64(7), res[1].Backtest.ID)
+		})
+	}
+}
+
+func TestModelService_Fit(t *testing.T) {
+	goals := func(v int64) *int64 { return &v }
+	kickoff := time.Date(2022, 1, 15, 15, 0, 0, 0, time.UTC)
+	history := []fixtures.Fixture{
+		{ID: 1, KickoffAt: kickoff.AddDate(0, 0, -14), Status: "FT", HomeTeamID: 1, AwayTeamID: 3, HomeGoals: goals(3), AwayGoals: goals(1)},
+		{ID: 2, KickoffAt: kickoff.AddDate(0, 0, -7), Status: "FT", HomeTeamID: 2, AwayTeamID: 1, HomeGoals: goals(1), AwayGoals: goals(2)},
+		{ID: 3, KickoffAt: kickoff.AddDate(0, 0, 7), Status: "NS", HomeTeamID: 3, AwayTeamID: 2},
+	}
+	fixtureDao := &MockFixtureDao{
+		FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+			if req.Season != 2021 {
+				return nil, 0, nil
+			}
+			return history, 3, nil
+		},
+	}
+
+	testCases := []struct {
+		title         string
+		id            int64
+		req           models.FitInput
+		expectedTeams int
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title:       "error not found",
+			id:          9,
+			req:         models.FitInput{LeagueID: 39, Season: 2021},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "model"),
+		},
+		{
+			title:       "error not enough data",
+			id:          2,
+			req:         models.FitInput{LeagueID: 39, Season: 2020},
+			expectedErr: resterror.NewUnprocessableEntityError(errorNotEnoughData),
+		},
+		{
+			title:         "success",
+			id:            2,
+			req:           models.FitInput{LeagueID: 39, Season: 2021},
+			expectedTeams: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			models.ModelDao = testModelDao()
+			fixtures.FixtureDao = fixtureDao
+
+			res, err := ModelService.Fit(context.Background(), testCase.id, &testCase.req)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if err != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, int64(2), res.ModelID)
+			assert.Equal(t, int64(39), res.LeagueID)
+			assert.Equal(t, int64(2), res.Matches)
+			var parameters struct {
+				HomeAdvantage *float64                   `json:"home_advantage"`
+				Rho           *float64                   `json:"rho"`
+				Teams         map[string]json.RawMessage `json:"teams"`
+			}
+			assert.Nil(t, json.Unmarshal(res.Parameters, &parameters))
+			assert.NotNil(t, parameters.HomeAdvantage)
+			assert.NotNil(t, parameters.Rho)
+			assert.Len(t, parameters.Teams, testCase.expectedTeams)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/api_sports"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/odds"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/predictor"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// API Sports ids of the bets stored as markets, the other bets are ignored
+var oddMarkets = map[int64]string{
+	1: odds.Market1X2,
+	4: odds.MarketAsianHandicap,
+	5: odds.MarketOverUnder,
+	8: odds.MarketBTTS,
+}
+
+// Number of selections of a complete market, the implied probabilities of a line missing one are not computed
+var oddSelections = map[string]int{
+	odds.Market1X2:           3,
+	odds.MarketAsianHandicap: 2,
+	odds.MarketOverUnder:     2,
+	odds.MarketBTTS:          2,
+}
+
+type OddServiceI interface {
+	List(ctx context.Context, req *odds.ListOddInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	Fixture(ctx context.Context, req *odds.LatestOddInput) ([]odds.MarketOdds, resterror.RestErrorI)
+	Sync(ctx context.Context, req *odds.SyncOddInput) resterror.RestErrorI
+}
+
+type oddService struct {
+	apiSports *api_sports_provider.Client
+}
+
+var OddService OddServiceI = &oddService{}
+
+func (s *oddService) List(ctx context.Context, req *odds.ListOddInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "OddService.List")
+	defer span.End()
+
+	results, total, err := odds.OddDao.List(ctx, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	res := pagination.GeneratePaginatedResponse(req.Filter.Project(results), req.Page, req.PerPage, total)
+	res.NextCursor, res.PrevCursor = req.Filter.Cursors()
+
+	return &res, nil
+}
+
+// Fixture returns the current odds of a fixture per bookmaker, market and line, with the implied probabilities
+// of the selections once the overround is removed
+func (s *oddService) Fixture(ctx context.Context, req *odds.LatestOddInput) ([]odds.MarketOdds, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "OddService.Fixture")
+	defer span.End()
+
+	if _, err := fixtures.FixtureDao.FindByID(ctx, req.FixtureID); err != nil {
+		return nil, resterror.NewDatabaseError(err, "fixture")
+	}
+	rows, err := odds.OddDao.Latest(ctx, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	return marketOdds(ctx, req.FixtureID, rows, req.Method), nil
+}
+
+// marketOdds groups the latest odds of a fixture by bookmaker, market and line and computes the implied
+// probabilities of the complete markets with the overround removal method, basic by default
+func marketOdds(ctx context.Context, fixtureID int64, rows []odds.Odd, method string) []odds.MarketOdds {
+	if method == "" {
+		method = predictor.MethodBasic
+	}
+
+	// The rows are sorted by bookmaker, market and line, every change of them starts a market
+	results := make([]odds.MarketOdds, 0)
+	for _, row := range rows {
+		last := len(results) - 1
+		if last < 0 || results[last].BookmakerID != row.BookmakerID || results[last].Market != row.Market || results[last].Line != row.Line {
+			results = append(results, odds.MarketOdds{
+				BookmakerID: row.BookmakerID,
+				Bookmaker:   row.Bookmaker,
+				Market:      row.Market,
+				Line:        row.Line,
+			})
+			last++
+		}
+		if row.RecordedAt.After(results[last].RecordedAt) {
+			results[last].RecordedAt = row.RecordedAt
+		}
+		results[last].Selections = append(results[last].Selections, odds.SelectionOdds{Selection: row.Selection, Price: row.Price})
+	}
+	for i := range results {
+		if len(results[i].Selections) != oddSelections[results[i].Market] {
+			continue
+		}
+		prices := make([]float64, len(results[i].Selections))
+		for j, selection := range results[i].Selections {
+			prices[j] = selection.Price
+		}
+		probabilities, err := predictor.Implied(prices, method)
+		if err != nil {
+			zlog.FromContext(ctx).Warnw("could not compute implied probabilities", "fixture_id", fixtureID, "bookmaker_id", results[i].BookmakerID, "market", results[i].Market)
+			continue
+		}
+		results[i].Overround = predictor.Overround(prices)
+		for j := range results[i].Selections {
+			results[i].Selections[j].Probability = probabilities[j]
+		}
+	}
+	return results
+}
+
+// Sync imports the pre-match odds of a fixture or of a league season from API Sports. A price is stored at the
+// time the bookmaker last updated it and the DAO skips the ones equal to the previous price of their selection and
+// line, so every sync adds the prices that moved since the previous one
+func (s *oddService) Sync(ctx context.Context, req *odds.SyncOddInput) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "OddService.Sync")
+	defer span.End()
+
+	zlog.FromContext(ctx).Infow("Sync Odds Start", "fixture_id", req.FixtureID, "league_id", req.LeagueID, "season", req.Season, "bookmaker_id", req.BookmakerID)
+	run := metrics.StartSync("odds")
+	defer run.Done()
+	// Get every page of odds from API Sports
+	res, err := s.apiSports.GetOdds(ctx, api_sports.OddsRequest{
+		Fixture:   req.FixtureID,
+		League:    req.LeagueID,
+		Season:    req.Season,
+		Bookmaker: req.BookmakerID,
+	})
+	if err != nil {
+		run.Fail()
+		return resterror.NewStandardInternalServerError()
+	}
+
+	for _, v := range res {
+		recordedAt, parseErr := time.Parse(time.RFC3339, v.Update)
+		if parseErr != nil {
+			zlog.FromContext(ctx).Warnw("could not parse odds update", "fixture_id", v.Fixture.ID)
+			run.Row(metrics.RowFailed)
+			continue
+		}
+		for _, bookmaker := range v.Bookmakers {
+			for _, bet := range bookmaker.Bets {
+				market, ok := oddMarkets[bet.ID]
+				if !ok {
+					continue
+				}
+				for _, value := range bet.Values {
+					selection, line, ok := parseOddSelection(market, value.Value)
+					price, priceErr := strconv.ParseFloat(value.Odd, 64)
+					if !ok || priceErr != nil {
+						zlog.FromContext(ctx).Warnw("could not parse odd", "fixture_id", v.Fixture.ID, "bookmaker_id", bookmaker.ID, "bet", bet.Name, "value", value.Value, "odd", value.Odd)
+						run.Row(metrics.RowSkipped)
+						continue
+					}
+					odd := odds.Odd{
+						FixtureID:   v.Fixture.ID,
+						BookmakerID: bookmaker.ID,
+						Bookmaker:   bookmaker.Name,
+						Market:      market,
+						Selection:   selection,
+						Line:        line,
+						Price:       price,
+						RecordedAt:  recordedAt.UTC(),
+					}
+					if err := odds.OddDao.Upsert(ctx, &odd); err != nil {
+						zlog.FromContext(ctx).Warnw("could not upsert odd", "fixture_id", odd.FixtureID, "bookmaker_id", odd.BookmakerID, "market", odd.Market)
+						run.Row(metrics.RowFailed)
+						continue
+					}
+					run.Row(metrics.RowUpserted)
+				}
+			}
+		}
+	}
+	zlog.FromContext(ctx).Infow("Sync Odds End", "fixture_id", req.FixtureID, "league_id", req.LeagueID, "season", req.Season, "bookmaker_id", req.BookmakerID)
+	return nil
+}
+
+// parseOddSelection reads the selection and the line of an API Sports bet value, e.g. "Home", "Over 2.5" or
+// "Away +0.5". The asian handicap line is returned from the home team side, so "Away +0.5" is the line -0.5
+func parseOddSelection(market string, value string) (string, float64, bool) {
+	fields := strings.Fields(strings.ToLower(value))
+	if len(fields) == 0 {
+		return "", 0, false
+	}
+	selection := fields[0]
+	var line float64
+	if len(fields) > 1 {
+		var err error
+		if line, err = strconv.ParseFloat(fields[1], 64); err != nil || len(fields) > 2 {
+			return "", 0, false
+		}
+	}
+
+	switch market {
+	case odds.Market1X2:
+		return selection, 0, len(fields) == 1 && (selection == odds.SelectionHome || selection == odds.SelectionDraw || selection == odds.SelectionAway)
+	case odds.MarketBTTS:
+		return selection, 0, len(fields) == 1 && (selection == odds.SelectionYes || selection == odds.SelectionNo)
+	case odds.MarketOverUnder:
+		return selection, line, len(fields) == 2 && (selection == odds.SelectionOver || selection == odds.SelectionUnder)
+	case odds.MarketAsianHandicap:
+		if selection == odds.SelectionAway && line != 0 {
+			line = -line
+		}
+		return selection, line, len(fields) == 2 && (selection == odds.SelectionHome || selection == odds.SelectionAway)
+	}
+	return "", 0, false
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/clients/restclient"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/odds"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockOddDao struct {
+	FuncUpsert func(odd *odds.Odd) error
+	FuncList   func(req *odds.ListOddInput) ([]odds.Odd, int64, error)
+	FuncLatest func(req *odds.LatestOddInput) ([]odds.Odd, error)
+}
+
+func (m MockOddDao) Upsert(ctx context.Context, odd *odds.Odd) error {
+	return m.FuncUpsert(odd)
+}
+func (m MockOddDao) List(ctx context.Context, req *odds.ListOddInput) ([]odds.Odd, int64, error) {
+	return m.FuncList(req)
+}
+func (m MockOddDao) Latest(ctx context.Context, req *odds.LatestOddInput) ([]odds.Odd, error) {
+	return m.FuncLatest(req)
+}
+
+func TestOddService_List(t *testing.T) {
+	testCases := []struct {
+		title       string
+		oddDaoMock  odds.OddDaoI
+		expectedRes *pagination.PaginatedResponse
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title: "error OddDao.List",
+			oddDaoMock: &MockOddDao{
+				FuncList: func(req *odds.ListOddInput) ([]odds.Odd, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			oddDaoMock: &MockOddDao{
+				FuncList: func(req *odds.ListOddInput) ([]odds.Odd, int64, error) {
+					return []odds.Odd{{ID: 1}}, 1, nil
+				},
+			},
+			expectedRes: &pagination.PaginatedResponse{
+				From:        1,
+				Data:        []odds.Odd{{ID: 1}},
+				CurrentPage: 1,
+				LastPage:    1,
+				PerPage:     20,
+				To:          1,
+				Total:       1,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			odds.OddDao = testCase.oddDaoMock
+
+			res, err := OddService.List(context.Background(), &odds.ListOddInput{})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestOddService_Fixture(t *testing.T) {
+	recordedAt := time.Date(2022, 1, 14, 10, 0, 0, 0, time.UTC)
+	movedAt := recordedAt.Add(time.Hour)
+	found := &MockFixtureDao{
+		FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+			return &fixtures.Fixture{ID: id}, nil
+		},
+	}
+	testCases := []struct {
+		title          string
+		method         string
+		fixtureDaoMock fixtures.FixtureDaoI
+		oddDaoMock     odds.OddDaoI
+		expectedRes    []odds.MarketOdds
+		expectedErr    resterror.RestErrorI
+	}{
+		{
+			title: "error FixtureDao.FindByID",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "fixture"),
+		},
+		{
+			title:          "error OddDao.Latest",
+			fixtureDaoMock: found,
+			oddDaoMock: &MockOddDao{
+				FuncLatest: func(req *odds.LatestOddInput) ([]odds.Odd, error) {
+					return nil, errors.New("error Latest")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:          "success no odds",
+			fixtureDaoMock: found,
+			oddDaoMock: &MockOddDao{
+				FuncLatest: func(req *odds.LatestOddInput) ([]odds.Odd, error) {
+					return nil, nil
+				},
+			},
+			expectedRes: []odds.MarketOdds{},
+		},
+		{
+			title:          "success groups markets",
+			method:         "power",
+			fixtureDaoMock: found,
+			oddDaoMock: &MockOddDao{
+				FuncLatest: func(req *odds.LatestOddInput) ([]odds.Odd, error) {
+					return []odds.Odd{
+						{BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketOverUnder, Selection: odds.SelectionOver, Line: 2.5, Price: 1.9, RecordedAt: recordedAt},
+						{BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketOverUnder, Selection: odds.SelectionUnder, Line: 2.5, Price: 1.9, RecordedAt: movedAt},
+						// The market misses the under price
+						{BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketOverUnder, Selection: odds.SelectionOver, Line: 3.5, Price: 3, RecordedAt: recordedAt},
+						{BookmakerID: 11, Bookmaker: "1xBet", Market: odds.MarketBTTS, Selection: odds.SelectionYes, Price: 2, RecordedAt: recordedAt},
+						{BookmakerID: 11, Bookmaker: "1xBet", Market: odds.MarketBTTS, Selection: odds.SelectionNo, Price: 2, RecordedAt: recordedAt},
+					}, nil
+				},
+			},
+			expectedRes: []odds.MarketOdds{
+				{
+					BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketOverUnder, Line: 2.5, RecordedAt: movedAt,
+					Overround: 2/1.9 - 1,
+					Selections: []odds.SelectionOdds{
+						{Selection: odds.SelectionOver, Price: 1.9, Probability: 0.5},
+						{Selection: odds.SelectionUnder, Price: 1.9, Probability: 0.5},
+					},
+				},
+				{
+					BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketOverUnder, Line: 3.5, RecordedAt: recordedAt,
+					Selections: []odds.SelectionOdds{{Selection: odds.SelectionOver, Price: 3}},
+				},
+				{
+					BookmakerID: 11, Bookmaker: "1xBet", Market: odds.MarketBTTS, RecordedAt: recordedAt,
+					Selections: []odds.SelectionOdds{
+						{Selection: odds.SelectionYes, Price: 2, Probability: 0.5},
+						{Selection: odds.SelectionNo, Price: 2, Probability: 0.5},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			fixtures.FixtureDao = testCase.fixtureDaoMock
+			odds.OddDao = testCase.oddDaoMock
+
+			res, err := OddService.Fixture(context.Background(), &odds.LatestOddInput{FixtureID: 10, Method: testCase.method})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, len(testCase.expectedRes), len(res))
+			for i := range testCase.expectedRes {
+				assert.InDelta(t, testCase.expectedRes[i].Overround, res[i].Overround, 1e-9)
+				for j := range testCase.expectedRes[i].Selections {
+					assert.InDelta(t, testCase.expectedRes[i].Selections[j].Probability, res[i].Selections[j].Probability, 1e-9)
+					res[i].Selections[j].Probability = testCase.expectedRes[i].Selections[j].Probability
+				}
+				res[i].Overround = testCase.expectedRes[i].Overround
+			}
+			if testCase.expectedRes != nil {
+				assert.Equal(t, testCase.expectedRes, res)
+			}
+		})
+	}
+}
+
+func TestOddService_Sync(t *testing.T) {
+	OddService = &oddService{apiSports: api_sports_provider.New(config.APISportsConfig{BaseURL: "http://localhost"})}
+	oddsBody := `{
+		"get": "odds",
+		"errors": [],
+		"results": 2,
+		"paging": {"current": 1, "total": 1},
+		"response": [
+			{
+				"league": {"id": 39, "season": 2021},
+				"fixture": {"id": 10},
+				"update": "2022-01-14T10:00:00+00:00",
+				"bookmakers": [{"id": 8, "name": "Bet365", "bets": [
+					{"id": 1, "name": "Match Winner", "values": [{"value": "Home", "odd": "2.10"}, {"value": "Draw", "odd": "3.40"}, {"value": "Away", "odd": "3.60"}]},
+					{"id": 4, "name": "Asian Handicap", "values": [{"value": "Home -0.5", "odd": "2.05"}, {"value": "Away +0.5", "odd": "1.80"}]},
+					{"id": 5, "name": "Goals Over/Under", "values": [{"value": "Over 2.5", "odd": "1.85"}, {"value": "Under 2.5", "odd": "abc"}]},
+					{"id": 8, "name": "Both Teams Score", "values": [{"value": "Yes", "odd": "1.70"}, {"value": "Maybe", "odd": "2.10"}]},
+					{"id": 12, "name": "Double Chance", "values": [{"value": "Home/Draw", "odd": "1.25"}]}
+				]}]
+			},
+			{
+				"league": {"id": 39, "season": 2021},
+				"fixture": {"id": 11},
+				"update": "not a date",
+				"bookmakers": []
+			}
+		]
+	}`
+	recordedAt := time.Date(2022, 1, 14, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		title          string
+		restClientResp *http.Response
+		upsertErr      error
+		expectedOdds   []odds.Odd
+		expectedErr    resterror.RestErrorI
+	}{
+		{
+			title: "error api_sports_provider.GetOdds",
+			restClientResp: &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       ioutil.NopCloser(strings.NewReader(``)),
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success with failed upserts",
+			restClientResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(oddsBody)),
+			},
+			upsertErr: errors.New("error Upsert"),
+			expectedOdds: []odds.Odd{
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.Market1X2, Selection: odds.SelectionHome, Price: 2.1, RecordedAt: recordedAt},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.Market1X2, Selection: odds.SelectionDraw, Price: 3.4, RecordedAt: recordedAt},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.Market1X2, Selection: odds.SelectionAway, Price: 3.6, RecordedAt: recordedAt},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketAsianHandicap, Selection: odds.SelectionHome, Line: -0.5, Price: 2.05, RecordedAt: recordedAt},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketAsianHandicap, Selection: odds.SelectionAway, Line: -0.5, Price: 1.8, RecordedAt: recordedAt},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketOverUnder, Selection: odds.SelectionOver, Line: 2.5, Price: 1.85, RecordedAt: recordedAt},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketBTTS, Selection: odds.SelectionYes, Price: 1.7, RecordedAt: recordedAt},
+			},
+			expectedErr: nil,
+		},
+		{
+			title: "success",
+			restClientResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(oddsBody)),
+			},
+			expectedOdds: []odds.Odd{
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.Market1X2, Selection: odds.SelectionHome, Price: 2.1, RecordedAt: recordedAt},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.Market1X2, Selection: odds.SelectionDraw, Price: 3.4, RecordedAt: recordedAt},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.Market1X2, Selection: odds.SelectionAway, Price: 3.6, RecordedAt: recordedAt},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketAsianHandicap, Selection: odds.SelectionHome, Line: -0.5, Price: 2.05, RecordedAt: recordedAt},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketAsianHandicap, Selection: odds.SelectionAway, Line: -0.5, Price: 1.8, RecordedAt: recordedAt},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketOverUnder, Selection: odds.SelectionOver, Line: 2.5, Price: 1.85, RecordedAt: recordedAt},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketBTTS, Selection: odds.SelectionYes, Price: 1.7, RecordedAt: recordedAt},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			// Initialization
+			restclient.StartMockups()
+			restclient.FlushMockups()
+			restclient.AddMockup(restclient.Mock{
+				Url:        "http://localhost/odds?fixture=10&page=1",
+				HttpMethod: http.MethodGet,
+				Response:   testCase.restClientResp,
+			})
+			var upserted []odds.Odd
+			odds.OddDao = &MockOddDao{
+				FuncUpsert: func(odd *odds.Odd) error {
+					upserted = append(upserted, *odd)
+					return testCase.upsertErr
+				},
+			}
+
+			// Execution
+			err := OddService.Sync(context.Background(), &odds.SyncOddInput{FixtureID: 10})
+
+			// Assertions
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedOdds, upserted)
+		})
+	}
+}
+
+func TestParseOddSelection(t *testing.T) {
+	testCases := []struct {
+		market            string
+		value             string
+		expectedSelection string
+		expectedLine      float64
+		expectedOk        bool
+	}{
+		{market: odds.Market1X2, value: "Draw", expectedSelection: odds.SelectionDraw, expectedOk: true},
+		{market: odds.Market1X2, value: "Home 1"},
+		{market: odds.MarketBTTS, value: "No", expectedSelection: odds.SelectionNo, expectedOk: true},
+		{market: odds.MarketOverUnder, value: "Under 3.25", expectedSelection: odds.SelectionUnder, expectedLine: 3.25, expectedOk: true},
+		{market: odds.MarketOverUnder, value: "Over"},
+		{market: odds.MarketAsianHandicap, value: "Away -1", expectedSelection: odds.SelectionAway, expectedLine: 1, expectedOk: true},
+		{market: odds.MarketAsianHandicap, value: "Away +0", expectedSelection: odds.SelectionAway, expectedLine: 0, expectedOk: true},
+		{market: odds.MarketAsianHandicap, value: "Draw +0"},
+		{market: odds.MarketAsianHandicap, value: ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.market+" "+testCase.value, func(t *testing.T) {
+			selection, line, ok := parseOddSelection(testCase.market, testCase.value)
+
+			assert.Equal(t, testCase.expectedOk, ok)
+			if testCase.expectedOk {
+				assert.Equal(t, testCase.expectedSelection, selection)
+				assert.Equal(t, testCase.expectedLine, line)
+			}
+		})
+	}
+}
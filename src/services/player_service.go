@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/api_sports"
+	"github.com/development-raul/footy-predictor/src/domains/players"
+	"github.com/development-raul/footy-predictor/src/domains/squads"
+	"github.com/development-raul/footy-predictor/src/metrics"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"strconv"
+	"time"
+)
+
+type PlayerServiceI interface {
+	Find(ctx context.Context, id int64) (*players.PlayerOutput, resterror.RestErrorI)
+	List(ctx context.Context, req *players.ListPlayerInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	Leaders(ctx context.Context, req *players.ListLeaderInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	Squad(ctx context.Context, teamID int64) ([]squads.SquadPlayer, resterror.RestErrorI)
+	Sync(ctx context.Context, req *players.SyncPlayerInput) resterror.RestErrorI
+}
+
+type playerService struct {
+	apiSports *api_sports_provider.Client
+}
+
+var PlayerService PlayerServiceI = &playerService{}
+
+// Find returns a player with its statistics of every synced season
+func (s *playerService) Find(ctx context.Context, id int64) (*players.PlayerOutput, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "PlayerService.Find")
+	defer span.End()
+
+	player, err := players.PlayerDao.FindByID(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "player")
+	}
+	statistics, err := players.PlayerDao.ListStatistics(ctx, id)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if statistics == nil {
+		statistics = []players.Statistic{}
+	}
+	return &players.PlayerOutput{Player: *player, Statistics: statistics}, nil
+}
+
+func (s *playerService) List(ctx context.Context, req *players.ListPlayerInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "PlayerService.List")
+	defer span.End()
+
+	results, total, err := players.PlayerDao.List(ctx, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	res := pagination.GeneratePaginatedResponse(req.Filter.Project(results), req.Page, req.PerPage, total)
+	res.NextCursor, res.PrevCursor = req.Filter.Cursors()
+
+	return &res, nil
+}
+
+// Leaders returns the top scorers or the top assist providers of a league season
+func (s *playerService) Leaders(ctx context.Context, req *players.ListLeaderInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "PlayerService.Leaders")
+	defer span.End()
+
+	results, total, err := players.PlayerDao.Leaders(ctx, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	// Positions continue across pages
+	perPage := req.PerPage
+	if perPage == 0 {
+		perPage = constants.DefaultPerPage
+	}
+	offset := int64(0)
+	if req.Page > 1 {
+		offset = (req.Page - 1) * perPage
+	}
+	for i := range results {
+		results[i].Position = offset + int64(i) + 1
+	}
+
+	res := pagination.GeneratePaginatedResponse(results, req.Page, req.PerPage, total)
+
+	return &res, nil
+}
+
+// Squad returns the players of the current squad of a team by position
+func (s *playerService) Squad(ctx context.Context, teamID int64) ([]squads.SquadPlayer, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "PlayerService.Squad")
+	defer span.End()
+
+	res, err := squads.SquadDao.ListByTeam(ctx, teamID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if len(res) == 0 {
+		return nil, resterror.NewNotFoundError("SQUAD_NOT_FOUND")
+	}
+	return res, nil
+}
+
+// Sync imports the current squad of a team, or the players of a league season with their statistics
+func (s *playerService) Sync(ctx context.Context, req *players.SyncPlayerInput) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "PlayerService.Sync")
+	defer span.End()
+
+	if req.TeamID != 0 {
+		return s.syncSquad(ctx, req.TeamID)
+	}
+	return s.syncPlayers(ctx, req.LeagueID, req.Season)
+}
+
+// syncSquad upserts the players of the squad of a team, then removes the players who left it. The players who
+// left are kept when a row failed, so a failed upsert does not take a player out of the squad
+func (s *playerService) syncSquad(ctx context.Context, teamID int64) resterror.RestErrorI {
+	zlog.FromContext(ctx).Infow("Sync Squad Start", "team_id", teamID)
+	run := metrics.StartSync("squads")
+	defer run.Done()
+	// Get the squad from API Sports
+	res, apiErr := s.apiSports.GetSquad(ctx, teamID)
+	if apiErr != nil {
+		run.Fail()
+		return resterror.NewStandardInternalServerError()
+	}
+
+	syncedAt := helpers.GetNow().Truncate(time.Second)
+	failed := false
+	for _, v := range res {
+		for _, p := range v.Players {
+			player := players.Player{ID: p.ID, Name: p.Name, Age: p.Age, Photo: p.Photo}
+			if err := players.PlayerDao.Upsert(ctx, &player); err != nil {
+				zlog.FromContext(ctx).Warnw("could not upsert player", "player_id", p.ID, "team_id", v.Team.ID)
+				run.Row(metrics.RowFailed)
+				failed = true
+				continue
+			}
+			squad := squads.Squad{
+				PlayerID: p.ID,
+				TeamID:   v.Team.ID,
+				TeamName: v.Team.Name,
+				Number:   p.Number,
+				Position: p.Position,
+				SyncedAt: syncedAt,
+			}
+			if err := squads.SquadDao.Upsert(ctx, &squad); err != nil {
+				zlog.FromContext(ctx).Warnw("could not upsert squad player", "player_id", p.ID, "team_id", v.Team.ID)
+				run.Row(metrics.RowFailed)
+				failed = true
+				continue
+			}
+			run.Row(metrics.RowUpserted)
+		}
+	}
+	if !failed {
+		if err := squads.SquadDao.DeleteStale(ctx, teamID, syncedAt); err != nil {
+			zlog.FromContext(ctx).Warnw("could not delete the players who left the squad", "team_id", teamID)
+		}
+	}
+	zlog.FromContext(ctx).Infow("Sync Squad End", "team_id", teamID)
+	return nil
+}
+
+// syncPlayers upserts the players of a league season and their statistics for every team they played for
+func (s *playerService) syncPlayers(ctx context.Context, leagueID int64, season int64) resterror.RestErrorI {
+	zlog.FromContext(ctx).Infow("Sync Players Start", "league_id", leagueID, "season", season)
+	run := metrics.StartSync("players")
+	defer run.Done()
+	// Get every page of players from API Sports
+	res, apiErr := s.apiSports.GetPlayers(ctx, leagueID, season)
+	if apiErr != nil {
+		run.Fail()
+		return resterror.NewStandardInternalServerError()
+	}
+
+	for _, v := range res {
+		player := players.Player{
+			ID:          v.Player.ID,
+			Name:        v.Player.Name,
+			Firstname:   v.Player.Firstname,
+			Lastname:    v.Player.Lastname,
+			Age:         v.Player.Age,
+			Nationality: v.Player.Nationality,
+			Height:      v.Player.Height,
+			Weight:      v.Player.Weight,
+			Photo:       v.Player.Photo,
+		}
+		if v.Player.Birth.Date != nil {
+			if birthDate, err := time.Parse("2006-01-02", *v.Player.Birth.Date); err == nil {
+				player.BirthDate = &birthDate
+			}
+		}
+		if err := players.PlayerDao.Upsert(ctx, &player); err != nil {
+			zlog.FromContext(ctx).Warnw("could not upsert player", "player_id", player.ID)
+			run.Row(metrics.RowFailed)
+			continue
+		}
+		run.Row(metrics.RowUpserted)
+		for _, stats := range v.Statistics {
+			statistic := playerStatistic(player.ID, stats)
+			if err := players.PlayerDao.UpsertStatistic(ctx, &statistic); err != nil {
+				zlog.FromContext(ctx).Warnw("could not upsert player statistic", "player_id", player.ID, "team_id", statistic.TeamID, "league_id", statistic.LeagueID)
+				run.Row(metrics.RowFailed)
+				continue
+			}
+			run.Row(metrics.RowUpserted)
+		}
+	}
+	zlog.FromContext(ctx).Infow("Sync Players End", "league_id", leagueID, "season", season)
+	return nil
+}
+
+// playerStatistic reads the statistics of a player for a team, API Sports gives null for what the player did not do
+func playerStatistic(playerID int64, stats api_sports.PlayerStatistics) players.Statistic {
+	count := func(v *int64) int64 {
+		if v == nil {
+			return 0
+		}
+		return *v
+	}
+	statistic := players.Statistic{
+		PlayerID:    playerID,
+		TeamID:      stats.Team.ID,
+		TeamName:    stats.Team.Name,
+		LeagueID:    stats.League.ID,
+		Season:      stats.League.Season,
+		Position:    stats.Games.Position,
+		Appearances: count(stats.Games.Appearences),
+		Lineups:     count(stats.Games.Lineups),
+		Minutes:     count(stats.Games.Minutes),
+		Goals:       count(stats.Goals.Total),
+		Assists:     count(stats.Goals.Assists),
+		YellowCards: count(stats.Cards.Yellow),
+		RedCards:    count(stats.Cards.Red) + count(stats.Cards.Yellowred),
+	}
+	if stats.Games.Rating != nil {
+		if rating, err := strconv.ParseFloat(*stats.Games.Rating, 64); err == nil {
+			statistic.Rating = &rating
+		}
+	}
+	return statistic
+}
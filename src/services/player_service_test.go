@@ -0,0 +1,489 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/clients/restclient"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/domains/players"
+	"github.com/development-raul/footy-predictor/src/domains/squads"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockPlayerDao struct {
+	FuncUpsert          func(player *players.Player) error
+	FuncFindByID        func(id int64) (*players.Player, error)
+	FuncList            func(req *players.ListPlayerInput) ([]players.Player, int64, error)
+	FuncUpsertStatistic func(statistic *players.Statistic) error
+	FuncListStatistics  func(playerID int64) ([]players.Statistic, error)
+	FuncLeaders         func(req *players.ListLeaderInput) ([]players.Leader, int64, error)
+}
+
+func (m MockPlayerDao) Upsert(ctx context.Context, player *players.Player) error {
+	return m.FuncUpsert(player)
+}
+func (m MockPlayerDao) FindByID(ctx context.Context, id int64) (*players.Player, error) {
+	return m.FuncFindByID(id)
+}
+func (m MockPlayerDao) List(ctx context.Context, req *players.ListPlayerInput) ([]players.Player, int64, error) {
+	return m.FuncList(req)
+}
+func (m MockPlayerDao) UpsertStatistic(ctx context.Context, statistic *players.Statistic) error {
+	return m.FuncUpsertStatistic(statistic)
+}
+func (m MockPlayerDao) ListStatistics(ctx context.Context, playerID int64) ([]players.Statistic, error) {
+	return m.FuncListStatistics(playerID)
+}
+func (m MockPlayerDao) Leaders(ctx context.Context, req *players.ListLeaderInput) ([]players.Leader, int64, error) {
+	return m.FuncLeaders(req)
+}
+
+type MockSquadDao struct {
+	FuncUpsert      func(squad *squads.Squad) error
+	FuncDeleteStale func(teamID int64, before time.Time) error
+	FuncListByTeam  func(teamID int64) ([]squads.SquadPlayer, error)
+}
+
+func (m MockSquadDao) Upsert(ctx context.Context, squad *squads.Squad) error {
+	return m.FuncUpsert(squad)
+}
+func (m MockSquadDao) DeleteStale(ctx context.Context, teamID int64, before time.Time) error {
+	return m.FuncDeleteStale(teamID, before)
+}
+func (m MockSquadDao) ListByTeam(ctx context.Context, teamID int64) ([]squads.SquadPlayer, error) {
+	return m.FuncListByTeam(teamID)
+}
+
+func TestPlayerService_Find(t *testing.T) {
+	testCases := []struct {
+		title         string
+		playerDaoMock players.PlayerDaoI
+		expectedRes   *players.PlayerOutput
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title: "error PlayerDao.FindByID",
+			playerDaoMock: &MockPlayerDao{
+				FuncFindByID: func(id int64) (*players.Player, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "player"),
+		},
+		{
+			title: "error PlayerDao.ListStatistics",
+			playerDaoMock: &MockPlayerDao{
+				FuncFindByID: func(id int64) (*players.Player, error) {
+					return &players.Player{ID: id}, nil
+				},
+				FuncListStatistics: func(playerID int64) ([]players.Statistic, error) {
+					return nil, errors.New("error ListStatistics")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success without statistics",
+			playerDaoMock: &MockPlayerDao{
+				FuncFindByID: func(id int64) (*players.Player, error) {
+					return &players.Player{ID: id}, nil
+				},
+				FuncListStatistics: func(playerID int64) ([]players.Statistic, error) {
+					return nil, nil
+				},
+			},
+			expectedRes: &players.PlayerOutput{Player: players.Player{ID: 154}, Statistics: []players.Statistic{}},
+		},
+		{
+			title: "success",
+			playerDaoMock: &MockPlayerDao{
+				FuncFindByID: func(id int64) (*players.Player, error) {
+					return &players.Player{ID: id}, nil
+				},
+				FuncListStatistics: func(playerID int64) ([]players.Statistic, error) {
+					return []players.Statistic{{PlayerID: playerID, LeagueID: 61, Season: 2021}}, nil
+				},
+			},
+			expectedRes: &players.PlayerOutput{Player: players.Player{ID: 154},
+				Statistics: []players.Statistic{{PlayerID: 154, LeagueID: 61, Season: 2021}}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			players.PlayerDao = testCase.playerDaoMock
+
+			res, err := PlayerService.Find(context.Background(), 154)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestPlayerService_List(t *testing.T) {
+	testCases := []struct {
+		title         string
+		playerDaoMock players.PlayerDaoI
+		expectedRes   *pagination.PaginatedResponse
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title: "error PlayerDao.List",
+			playerDaoMock: &MockPlayerDao{
+				FuncList: func(req *players.ListPlayerInput) ([]players.Player, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			playerDaoMock: &MockPlayerDao{
+				FuncList: func(req *players.ListPlayerInput) ([]players.Player, int64, error) {
+					return []players.Player{{ID: 154}}, 1, nil
+				},
+			},
+			expectedRes: &pagination.PaginatedResponse{
+				From:        1,
+				Data:        []players.Player{{ID: 154}},
+				CurrentPage: 1,
+				LastPage:    1,
+				PerPage:     20,
+				To:          1,
+				Total:       1,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			players.PlayerDao = testCase.playerDaoMock
+
+			res, err := PlayerService.List(context.Background(), &players.ListPlayerInput{})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestPlayerService_Leaders(t *testing.T) {
+	testCases := []struct {
+		title         string
+		playerDaoMock players.PlayerDaoI
+		expectedRes   *pagination.PaginatedResponse
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title: "error PlayerDao.Leaders",
+			playerDaoMock: &MockPlayerDao{
+				FuncLeaders: func(req *players.ListLeaderInput) ([]players.Leader, int64, error) {
+					return nil, 0, errors.New("error Leaders")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			playerDaoMock: &MockPlayerDao{
+				FuncLeaders: func(req *players.ListLeaderInput) ([]players.Leader, int64, error) {
+					return []players.Leader{{PlayerID: 154, Goals: 6}, {PlayerID: 278, Goals: 5}}, 12, nil
+				},
+			},
+			expectedRes: &pagination.PaginatedResponse{
+				From:        3,
+				Data:        []players.Leader{{Position: 3, PlayerID: 154, Goals: 6}, {Position: 4, PlayerID: 278, Goals: 5}},
+				CurrentPage: 2,
+				LastPage:    6,
+				PerPage:     2,
+				To:          4,
+				Total:       12,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			players.PlayerDao = testCase.playerDaoMock
+
+			res, err := PlayerService.Leaders(context.Background(), &players.ListLeaderInput{LeagueID: 61, Season: 2021, Page: 2, PerPage: 2})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestPlayerService_Squad(t *testing.T) {
+	testCases := []struct {
+		title        string
+		squadDaoMock squads.SquadDaoI
+		expectedRes  []squads.SquadPlayer
+		expectedErr  resterror.RestErrorI
+	}{
+		{
+			title: "error SquadDao.ListByTeam",
+			squadDaoMock: &MockSquadDao{
+				FuncListByTeam: func(teamID int64) ([]squads.SquadPlayer, error) {
+					return nil, errors.New("error ListByTeam")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error squad not synced",
+			squadDaoMock: &MockSquadDao{
+				FuncListByTeam: func(teamID int64) ([]squads.SquadPlayer, error) {
+					return nil, nil
+				},
+			},
+			expectedErr: resterror.NewNotFoundError("SQUAD_NOT_FOUND"),
+		},
+		{
+			title: "success",
+			squadDaoMock: &MockSquadDao{
+				FuncListByTeam: func(teamID int64) ([]squads.SquadPlayer, error) {
+					return []squads.SquadPlayer{{PlayerID: 154, Position: "Attacker"}}, nil
+				},
+			},
+			expectedRes: []squads.SquadPlayer{{PlayerID: 154, Position: "Attacker"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			squads.SquadDao = testCase.squadDaoMock
+
+			res, err := PlayerService.Squad(context.Background(), 85)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestPlayerService_SyncSquad(t *testing.T) {
+	PlayerService = &playerService{apiSports: api_sports_provider.New(config.APISportsConfig{BaseURL: "http://localhost"})}
+	squadBody := `{
+		"get": "players/squads",
+		"errors": [],
+		"results": 1,
+		"paging": {"current": 1, "total": 1},
+		"response": [{
+			"team": {"id": 85, "name": "Paris Saint Germain", "logo": "https://media.api-sports.io/football/teams/85.png"},
+			"players": [
+				{"id": 1622, "name": "G. Donnarumma", "age": 22, "number": 50, "position": "Goalkeeper", "photo": "https://media.api-sports.io/football/players/1622.png"},
+				{"id": 154, "name": "L. Messi", "age": 34, "number": null, "position": "Attacker", "photo": "https://media.api-sports.io/football/players/154.png"}
+			]
+		}]
+	}`
+	age1622, number1622, age154 := int64(22), int64(50), int64(34)
+
+	testCases := []struct {
+		title           string
+		restClientResp  *http.Response
+		upsertErr       error
+		expectedPlayers []players.Player
+		expectedSquads  []squads.Squad
+		expectedStale   bool
+		expectedErr     resterror.RestErrorI
+	}{
+		{
+			title: "error api_sports_provider.GetSquad",
+			restClientResp: &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       ioutil.NopCloser(strings.NewReader(``)),
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success with failed upserts",
+			restClientResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(squadBody)),
+			},
+			upsertErr: errors.New("error Upsert"),
+			expectedPlayers: []players.Player{
+				{ID: 1622, Name: "G. Donnarumma", Age: &age1622, Photo: "https://media.api-sports.io/football/players/1622.png"},
+				{ID: 154, Name: "L. Messi", Age: &age154, Photo: "https://media.api-sports.io/football/players/154.png"},
+			},
+			expectedErr: nil,
+		},
+		{
+			title: "success",
+			restClientResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(squadBody)),
+			},
+			expectedPlayers: []players.Player{
+				{ID: 1622, Name: "G. Donnarumma", Age: &age1622, Photo: "https://media.api-sports.io/football/players/1622.png"},
+				{ID: 154, Name: "L. Messi", Age: &age154, Photo: "https://media.api-sports.io/football/players/154.png"},
+			},
+			expectedSquads: []squads.Squad{
+				{PlayerID: 1622, TeamID: 85, TeamName: "Paris Saint Germain", Number: &number1622, Position: "Goalkeeper"},
+				{PlayerID: 154, TeamID: 85, TeamName: "Paris Saint Germain", Position: "Attacker"},
+			},
+			expectedStale: true,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			// Initialization
+			restclient.StartMockups()
+			restclient.FlushMockups()
+			restclient.AddMockup(restclient.Mock{
+				Url:        "http://localhost/players/squads?team=85",
+				HttpMethod: http.MethodGet,
+				Response:   testCase.restClientResp,
+			})
+			var upserted []players.Player
+			players.PlayerDao = &MockPlayerDao{
+				FuncUpsert: func(player *players.Player) error {
+					upserted = append(upserted, *player)
+					return testCase.upsertErr
+				},
+			}
+			var squad []squads.Squad
+			var syncedAt, staleBefore time.Time
+			squads.SquadDao = &MockSquadDao{
+				FuncUpsert: func(s *squads.Squad) error {
+					syncedAt = s.SyncedAt
+					s.SyncedAt = time.Time{}
+					squad = append(squad, *s)
+					return nil
+				},
+				FuncDeleteStale: func(teamID int64, before time.Time) error {
+					assert.Equal(t, int64(85), teamID)
+					staleBefore = before
+					return nil
+				},
+			}
+
+			// Execution
+			err := PlayerService.Sync(context.Background(), &players.SyncPlayerInput{TeamID: 85})
+
+			// Assertions
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedPlayers, upserted)
+			assert.Equal(t, testCase.expectedSquads, squad)
+			// The players not listed by this sync are removed from the squad
+			assert.Equal(t, testCase.expectedStale, !staleBefore.IsZero())
+			if testCase.expectedStale {
+				assert.Equal(t, syncedAt, staleBefore)
+			}
+		})
+	}
+}
+
+func TestPlayerService_SyncPlayers(t *testing.T) {
+	PlayerService = &playerService{apiSports: api_sports_provider.New(config.APISportsConfig{BaseURL: "http://localhost"})}
+	playersBody := `{
+		"get": "players",
+		"errors": [],
+		"results": 1,
+		"paging": {"current": 1, "total": 1},
+		"response": [{
+			"player": {"id": 154, "name": "L. Messi", "firstname": "Lionel Andrés", "lastname": "Messi Cuccittini", "age": 34,
+				"birth": {"date": "1987-06-24", "place": "Rosario", "country": "Argentina"}, "nationality": "Argentina",
+				"height": "170 cm", "weight": "72 kg", "injured": false, "photo": "https://media.api-sports.io/football/players/154.png"},
+			"statistics": [{
+				"team": {"id": 85, "name": "Paris Saint Germain"},
+				"league": {"id": 61, "name": "Ligue 1", "country": "France", "season": 2021},
+				"games": {"appearences": 26, "lineups": 24, "minutes": 2153, "number": null, "position": "Attacker", "rating": "7.235", "captain": false},
+				"goals": {"total": 6, "conceded": 0, "assists": 14, "saves": null},
+				"cards": {"yellow": 2, "yellowred": 1, "red": null}
+			}]
+		}]
+	}`
+	age, height, weight := int64(34), "170 cm", "72 kg"
+	birthDate := time.Date(1987, 6, 24, 0, 0, 0, 0, time.UTC)
+	rating := 7.235
+	player := players.Player{ID: 154, Name: "L. Messi", Firstname: "Lionel Andrés", Lastname: "Messi Cuccittini", Age: &age,
+		BirthDate: &birthDate, Nationality: "Argentina", Height: &height, Weight: &weight,
+		Photo: "https://media.api-sports.io/football/players/154.png"}
+
+	testCases := []struct {
+		title              string
+		restClientResp     *http.Response
+		upsertErr          error
+		expectedPlayers    []players.Player
+		expectedStatistics []players.Statistic
+		expectedErr        resterror.RestErrorI
+	}{
+		{
+			title: "error api_sports_provider.GetPlayers",
+			restClientResp: &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       ioutil.NopCloser(strings.NewReader(``)),
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success with failed upserts",
+			restClientResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(playersBody)),
+			},
+			upsertErr:       errors.New("error Upsert"),
+			expectedPlayers: []players.Player{player},
+			expectedErr:     nil,
+		},
+		{
+			title: "success",
+			restClientResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(playersBody)),
+			},
+			expectedPlayers: []players.Player{player},
+			expectedStatistics: []players.Statistic{{PlayerID: 154, TeamID: 85, TeamName: "Paris Saint Germain", LeagueID: 61,
+				Season: 2021, Position: "Attacker", Appearances: 26, Lineups: 24, Minutes: 2153, Goals: 6, Assists: 14,
+				YellowCards: 2, RedCards: 1, Rating: &rating}},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			// Initialization
+			restclient.StartMockups()
+			restclient.FlushMockups()
+			restclient.AddMockup(restclient.Mock{
+				Url:        "http://localhost/players?league=61&season=2021&page=1",
+				HttpMethod: http.MethodGet,
+				Response:   testCase.restClientResp,
+			})
+			var upserted []players.Player
+			var statistics []players.Statistic
+			players.PlayerDao = &MockPlayerDao{
+				FuncUpsert: func(player *players.Player) error {
+					upserted = append(upserted, *player)
+					return testCase.upsertErr
+				},
+				FuncUpsertStatistic: func(statistic *players.Statistic) error {
+					statistics = append(statistics, *statistic)
+					return nil
+				},
+			}
+
+			// Execution
+			err := PlayerService.Sync(context.Background(), &players.SyncPlayerInput{LeagueID: 61, Season: 2021})
+
+			// Assertions
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedPlayers, upserted)
+			assert.Equal(t, testCase.expectedStatistics, statistics)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/injuries"
+	"github.com/development-raul/footy-predictor/src/domains/models"
+	"github.com/development-raul/footy-predictor/src/domains/predictions"
+	"github.com/development-raul/footy-predictor/src/predictor"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"sort"
+	"time"
+)
+
+const errorNotEnoughData = "NOT_ENOUGH_DATA"
+
+// The goal lines and home handicaps of the markets of a prediction
+var (
+	goalLines     = []float64{1.5, 2.5, 3.5}
+	handicapLines = []float64{-1.5, -0.5, 0.5, 1.5}
+)
+
+type PredictionServiceI interface {
+	Predict(ctx context.Context, fixtureID int64, model string) (*predictions.Prediction, resterror.RestErrorI)
+}
+
+type predictionService struct{}
+
+var PredictionService PredictionServiceI = &predictionService{}
+
+// Predict forecasts the result of a fixture from the finished fixtures of the same league played before its kickoff
+// in the current and the previous season, so the model is usable from the first round of a season. The model is a
+// name@version reference, see resolveModel, the champion when empty
+func (s *predictionService) Predict(ctx context.Context, fixtureID int64, model string) (*predictions.Prediction, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "PredictionService.Predict")
+	defer span.End()
+
+	version, apiErr := resolveModel(ctx, model)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	fixture, err := fixtures.FixtureDao.FindByID(ctx, fixtureID)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "fixture")
+	}
+	return predict(ctx, fixture, version)
+}
+
+// predict forecasts the fixture with the model version. The fit made once every fixture that kicked off before it
+// has finished is saved and reused as is, so the prediction of a past fixture by a model version never changes.
+// The fits made earlier are saved too but fitted again on the next request, when more results may be known
+func predict(ctx context.Context, fixture *fixtures.Fixture, version *models.Model) (*predictions.Prediction, resterror.RestErrorI) {
+	settled := fixture.KickoffAt.Add(matchDuration)
+	fit, err := models.ModelDao.FindFit(ctx, version.ID, fixture.LeagueID, fixture.Season, fixture.KickoffAt)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	var model predictor.Model
+	if fit != nil && !fit.CreatedAt.Before(settled) {
+		if model, err = predictor.Load(version.Name, fit.Parameters); err != nil {
+			zlog.FromContext(ctx).Errorw("PredictionService predict Load", "model", version.Ref(), "error", err)
+			return nil, resterror.NewStandardInternalServerError()
+		}
+	} else {
+		var matches int
+		var apiErr resterror.RestErrorI
+		model, matches, apiErr = fitModel(ctx, version, fixture.LeagueID, fixture.Season, fixture.KickoffAt)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		parameters, err := json.Marshal(model)
+		if err != nil {
+			zlog.FromContext(ctx).Errorw("PredictionService predict Marshal", "model", version.Ref(), "error", err)
+			return nil, resterror.NewStandardInternalServerError()
+		}
+		fit = &models.Fit{
+			ModelID:      version.ID,
+			LeagueID:     fixture.LeagueID,
+			Season:       fixture.Season,
+			FittedBefore: fixture.KickoffAt,
+			Matches:      int64(matches),
+			Parameters:   parameters,
+			CreatedAt:    helpers.GetNow(),
+		}
+		if err := models.ModelDao.SaveFit(ctx, fit); err != nil {
+			return nil, resterror.NewStandardInternalServerError()
+		}
+	}
+	forecast, availability, apiErr := forecastFixture(ctx, version, model, fixture)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	homeWin, draw, awayWin := forecast.Outcome()
+	homeGoals, awayGoals, probability := forecast.MostLikelyScore()
+
+	return &predictions.Prediction{
+		FixtureID:         fixture.ID,
+		Model:             version.Ref(),
+		HomeTeamID:        fixture.HomeTeamID,
+		AwayTeamID:        fixture.AwayTeamID,
+		HomeExpectedGoals: forecast.HomeExpectedGoals,
+		AwayExpectedGoals: forecast.AwayExpectedGoals,
+		HomeWin:           homeWin,
+		Draw:              draw,
+		AwayWin:           awayWin,
+		MostLikelyScore: predictions.Score{
+			HomeGoals:   homeGoals,
+			AwayGoals:   awayGoals,
+			Probability: probability,
+		},
+		Markets:      markets(forecast),
+		Availability: availability,
+		MatchesUsed:  int(fit.Matches),
+	}, nil
+}
+
+// forecastFixture forecasts the fixture with the fitted model of the version, adjusted for the missing players when
+// the version has an availability_weight. Predictions, value bets and backtests all forecast through it so they agree
+func forecastFixture(ctx context.Context, version *models.Model, model predictor.Model, fixture *fixtures.Fixture) (*predictor.Forecast, *predictions.Availability, resterror.RestErrorI) {
+	forecast := model.Forecast(fixture.HomeTeamID, fixture.AwayTeamID)
+	weight := predictor.AvailabilityWeight(version.Hyperparameters)
+	if weight <= 0 {
+		return forecast, nil, nil
+	}
+	return adjustAvailability(ctx, fixture, forecast, weight)
+}
+
+// adjustAvailability scales the forecast for the players listed missing the fixture, weighted by their minutes
+// share, see predictor.Availability. The questionable players are expected to play
+func adjustAvailability(ctx context.Context, fixture *fixtures.Fixture, forecast *predictor.Forecast, weight float64) (*predictor.Forecast, *predictions.Availability, resterror.RestErrorI) {
+	unavailable, err := injuries.InjuryDao.ListByFixture(ctx, fixture.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, nil, resterror.NewStandardInternalServerError()
+	}
+
+	availability := &predictions.Availability{
+		Weight:      weight,
+		HomePlayers: []predictions.MissingPlayer{},
+		AwayPlayers: []predictions.MissingPlayer{},
+	}
+	var missing predictor.Availability
+	for _, u := range unavailable {
+		if u.Type != injuries.TypeMissing {
+			continue
+		}
+		player := predictions.MissingPlayer{
+			PlayerID:     u.PlayerID,
+			PlayerName:   u.PlayerName,
+			Reason:       u.Reason,
+			MinutesShare: u.MinutesShare,
+		}
+		switch u.TeamID {
+		case fixture.HomeTeamID:
+			missing.HomeMissing += u.MinutesShare / 11
+			availability.HomePlayers = append(availability.HomePlayers, player)
+		case fixture.AwayTeamID:
+			missing.AwayMissing += u.MinutesShare / 11
+			availability.AwayPlayers = append(availability.AwayPlayers, player)
+		}
+	}
+	availability.HomeMissing, availability.AwayMissing = missing.HomeMissing, missing.AwayMissing
+	availability.HomeFactor, availability.AwayFactor = missing.Factors(weight)
+	return forecast.Scale(availability.HomeFactor, availability.AwayFactor), availability, nil
+}
+
+// markets derives the over/under, both teams to score and Asian handicap probabilities from the forecast, on half
+// lines only so no stake is refunded
+func markets(forecast *predictor.Forecast) predictions.Markets {
+	res := predictions.Markets{
+		OverUnder:     make([]predictions.GoalLine, 0, len(goalLines)),
+		AsianHandicap: make([]predictions.Handicap, 0, len(handicapLines)),
+	}
+	for _, line := range goalLines {
+		over := forecast.Over(line)
+		res.OverUnder = append(res.OverUnder, predictions.GoalLine{Line: line, Over: over, Under: 1 - over})
+	}
+	both := forecast.BothScore()
+	res.BothTeamsScore = predictions.BothTeamsScore{Yes: both, No: 1 - both}
+	for _, line := range handicapLines {
+		home := forecast.HomeCovers(line)
+		res.AsianHandicap = append(res.AsianHandicap, predictions.Handicap{Line: line, Home: home, Away: 1 - home})
+	}
+	return res
+}
+
+// fitModel fits the model version on the fixtures of a league that finished before the given time in the season and
+// the previous one, it also returns the number of fixtures used. The error is NOT_ENOUGH_DATA when nothing finished yet
+func fitModel(ctx context.Context, version *models.Model, leagueID int64, season int64, before time.Time) (predictor.Model, int, resterror.RestErrorI) {
+	var matches []predictor.Match
+	for _, s := range []int64{season - 1, season} {
+		res, apiErr := finishedBefore(ctx, leagueID, s, before)
+		if apiErr != nil {
+			return nil, 0, apiErr
+		}
+		matches = append(matches, res...)
+	}
+
+	model, err := predictor.Fit(version.Name, version.Hyperparameters, matches)
+	if err == predictor.ErrNotEnoughData {
+		return nil, 0, resterror.NewUnprocessableEntityError(errorNotEnoughData)
+	}
+	if err != nil {
+		zlog.FromContext(ctx).Errorw("PredictionService fitModel Fit", "model", version.Ref(), "error", err)
+		return nil, 0, resterror.NewStandardInternalServerError()
+	}
+	return model, len(matches), nil
+}
+
+// finishedBefore returns the fixtures of a league season that finished before the given time in kickoff order. A
+// fixture counts from matchDuration after its kickoff, as in the backtests, so the prediction does not use results
+// that were unknown at kickoff
+func finishedBefore(ctx context.Context, leagueID int64, season int64, before time.Time) ([]predictor.Match, resterror.RestErrorI) {
+	res, apiErr := seasonFixtures(ctx, leagueID, season)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].KickoffAt.Before(res[j].KickoffAt)
+	})
+	var matches []predictor.Match
+	for _, f := range res {
+		if !f.Finished() || f.KickoffAt.Add(matchDuration).After(before) {
+			continue
+		}
+		matches = append(matches, predictor.Match{
+			HomeTeamID: f.HomeTeamID,
+			AwayTeamID: f.AwayTeamID,
+			HomeGoals:  *f.HomeGoals,
+			AwayGoals:  *f.AwayGoals,
+			PlayedAt:   f.KickoffAt,
+		})
+	}
+	return matches, nil
+}
+
+// seasonFixtures returns every fixture of a league season
+func seasonFixtures(ctx context.Context, leagueID int64, season int64) ([]fixtures.Fixture, resterror.RestErrorI) {
+	var res []fixtures.Fixture
+	req := fixtures.ListFixtureInput{
+		LeagueID: leagueID,
+		Season:   season,
+		Page:     1,
+		PerPage:  500,
+	}
+	for {
+		results, total, err := fixtures.FixtureDao.List(ctx, &req)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, resterror.NewStandardInternalServerError()
+		}
+		res = append(res, results...)
+		if req.Page*req.PerPage >= total {
+			return res, nil
+		}
+		req.Page++
+	}
+}
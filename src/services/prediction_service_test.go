@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/injuries"
+	"github.com/development-raul/footy-predictor/src/domains/models"
+	"github.com/development-raul/footy-predictor/src/domains/predictions"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPredictionService_Predict(t *testing.T) {
+	kickoff := time.Date(2022, 1, 15, 15, 0, 0, 0, time.UTC)
+	goals := func(g int64) *int64 { return &g }
+	fixture := fixtures.Fixture{ID: 10, LeagueID: 39, Season: 2021, KickoffAt: kickoff, Status: "NS", HomeTeamID: 1, AwayTeamID: 2}
+	history := []fixtures.Fixture{
+		{ID: 1, KickoffAt: kickoff.AddDate(0, 0, -14), Status: "FT", HomeTeamID: 1, AwayTeamID: 3, HomeGoals: goals(3), AwayGoals: goals(0)},
+		{ID: 2, KickoffAt: kickoff.AddDate(0, 0, -7), Status: "FT", HomeTeamID: 2, AwayTeamID: 1, HomeGoals: goals(1), AwayGoals: goals(2)},
+		{ID: 3, KickoffAt: kickoff.AddDate(0, 0, -7), Status: "PST", HomeTeamID: 3, AwayTeamID: 2},
+		// Played after the fixture kicked off, must not be used
+		{ID: 4, KickoffAt: kickoff.AddDate(0, 0, 7), Status: "FT", HomeTeamID: 2, AwayTeamID: 3, HomeGoals: goals(5), AwayGoals: goals(0)},
+		// Kicked off less than matchDuration before the fixture, the result was not known yet at kickoff
+		{ID: 5, KickoffAt: kickoff.Add(-2 * time.Hour), Status: "FT", HomeTeamID: 3, AwayTeamID: 1, HomeGoals: goals(4), AwayGoals: goals(0)},
+	}
+
+	modelDao := testModelDao()
+	var saved *models.Fit
+	modelDao.FuncSaveFit = func(fit *models.Fit) error {
+		saved = fit
+		return nil
+	}
+	fixtureDao := &MockFixtureDao{
+		FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+			return &fixture, nil
+		},
+		FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+			if req.Season != 2021 {
+				return nil, 0, nil
+			}
+			return history, int64(len(history)), nil
+		},
+	}
+	// A fit saved once the results before the kickoff were known, from more matches than the history has
+	savedFit := func(createdAt time.Time) func(modelID int64, leagueID int64, season int64, before time.Time) (*models.Fit, error) {
+		return func(modelID int64, leagueID int64, season int64, before time.Time) (*models.Fit, error) {
+			if modelID != 1 || leagueID != 39 || season != 2021 || !before.Equal(kickoff) {
+				return nil, errors.New("unexpected fit")
+			}
+			return &models.Fit{
+				ModelID:    1,
+				Matches:    30,
+				Parameters: json.RawMessage(`{"home_avg":1.5,"away_avg":1.1,"home":{},"away":{}}`),
+				CreatedAt:  createdAt,
+			}, nil
+		}
+	}
+
+	testCases := []struct {
+		title          string
+		model          string
+		findFit        func(modelID int64, leagueID int64, season int64, before time.Time) (*models.Fit, error)
+		saveFit        func(fit *models.Fit) error
+		fixtureDaoMock fixtures.FixtureDaoI
+		expectedModel  string
+		expectedUsed   int
+		expectedSaved  bool
+		expectedErr    resterror.RestErrorI
+	}{
+		{
+			title:       "error model not found",
+			model:       "elo@9",
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "model"),
+		},
+		{
+			title: "error fixture not found",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "fixture"),
+		},
+		{
+			title: "error FixtureDao.FindByID",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+					return nil, errors.New("error FindByID")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error ModelDao.FindFit",
+			findFit: func(modelID int64, leagueID int64, season int64, before time.Time) (*models.Fit, error) {
+				return nil, errors.New("error FindFit")
+			},
+			fixtureDaoMock: fixtureDao,
+			expectedErr:    resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error FixtureDao.List",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncFindByID: fixtureDao.FuncFindByID,
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error not enough data",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncFindByID: fixtureDao.FuncFindByID,
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					return nil, 0, nil
+				},
+			},
+			expectedErr: resterror.NewUnprocessableEntityError("NOT_ENOUGH_DATA"),
+		},
+		{
+			title: "error ModelDao.SaveFit",
+			saveFit: func(fit *models.Fit) error {
+				return errors.New("error SaveFit")
+			},
+			fixtureDaoMock: fixtureDao,
+			expectedErr:    resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:          "success champion",
+			fixtureDaoMock: fixtureDao,
+			expectedModel:  "poisson@1",
+			expectedUsed:   2,
+			expectedSaved:  true,
+		},
+		{
+			title:          "success model version",
+			model:          "dixon-coles@2",
+			fixtureDaoMock: fixtureDao,
+			expectedModel:  "dixon-coles@2",
+			expectedUsed:   2,
+			expectedSaved:  true,
+		},
+		{
+			title:   "success saved fit",
+			findFit: savedFit(kickoff.Add(3 * time.Hour)),
+			fixtureDaoMock: &MockFixtureDao{
+				FuncFindByID: fixtureDao.FuncFindByID,
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					return nil, 0, errors.New("the saved fit must be used")
+				},
+			},
+			expectedModel: "poisson@1",
+			expectedUsed:  30,
+		},
+		{
+			title:          "success saved fit fitted again before the results were known",
+			findFit:        savedFit(kickoff.Add(-time.Hour)),
+			fixtureDaoMock: fixtureDao,
+			expectedModel:  "poisson@1",
+			expectedUsed:   2,
+			expectedSaved:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			mock := *modelDao
+			if testCase.findFit != nil {
+				mock.FuncFindFit = testCase.findFit
+			}
+			if testCase.saveFit != nil {
+				mock.FuncSaveFit = testCase.saveFit
+			}
+			models.ModelDao = &mock
+			fixtures.FixtureDao = testCase.fixtureDaoMock
+			saved = nil
+
+			res, err := PredictionService.Predict(context.Background(), 10, testCase.model)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedErr != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, int64(10), res.FixtureID)
+			assert.Equal(t, testCase.expectedModel, res.Model)
+			assert.Equal(t, testCase.expectedUsed, res.MatchesUsed)
+			assert.InDelta(t, 1, res.HomeWin+res.Draw+res.AwayWin, 1e-9)
+			assert.Len(t, res.Markets.OverUnder, 3)
+			assert.Equal(t, 2.5, res.Markets.OverUnder[1].Line)
+			assert.InDelta(t, 1, res.Markets.OverUnder[1].Over+res.Markets.OverUnder[1].Under, 1e-9)
+			assert.InDelta(t, 1, res.Markets.BothTeamsScore.Yes+res.Markets.BothTeamsScore.No, 1e-9)
+			// Minus half a goal is a home win and plus half a goal a home win or a draw
+			assert.InDelta(t, res.HomeWin, res.Markets.AsianHandicap[1].Home, 1e-9)
+			assert.InDelta(t, res.HomeWin+res.Draw, res.Markets.AsianHandicap[2].Home, 1e-9)
+			assert.Equal(t, testCase.expectedSaved, saved != nil)
+			if saved != nil {
+				assert.Equal(t, int64(39), saved.LeagueID)
+				assert.Equal(t, kickoff, saved.FittedBefore)
+				assert.Equal(t, int64(2), saved.Matches)
+				assert.NotEmpty(t, saved.Parameters)
+			}
+		})
+	}
+}
+
+func TestPredictionService_PredictAvailability(t *testing.T) {
+	kickoff := time.Date(2022, 1, 15, 15, 0, 0, 0, time.UTC)
+	goals := func(g int64) *int64 { return &g }
+	fixture := fixtures.Fixture{ID: 10, LeagueID: 39, Season: 2021, KickoffAt: kickoff, Status: "NS", HomeTeamID: 1, AwayTeamID: 2}
+	history := []fixtures.Fixture{
+		{ID: 1, KickoffAt: kickoff.AddDate(0, 0, -14), Status: "FT", HomeTeamID: 1, AwayTeamID: 3, HomeGoals: goals(3), AwayGoals: goals(0)},
+		{ID: 2, KickoffAt: kickoff.AddDate(0, 0, -7), Status: "FT", HomeTeamID: 2, AwayTeamID: 1, HomeGoals: goals(1), AwayGoals: goals(2)},
+	}
+	fixtures.FixtureDao = &MockFixtureDao{
+		FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+			return &fixture, nil
+		},
+		FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+			if req.Season != 2021 {
+				return nil, 0, nil
+			}
+			return history, int64(len(history)), nil
+		},
+	}
+	// poisson@3 is poisson@1 giving the players missing the fixture half their weight
+	modelDao := testModelDao()
+	modelDao.FuncFindByVersion = func(name string, version int64) (*models.Model, error) {
+		if version == 3 {
+			return &models.Model{ID: 3, Name: "poisson", Version: 3, Hyperparameters: json.RawMessage(`{"availability_weight":0.5}`)}, nil
+		}
+		return &models.Model{ID: 1, Name: "poisson", Version: 1, Hyperparameters: json.RawMessage("{}"), Champion: true}, nil
+	}
+	models.ModelDao = modelDao
+	unavailable := []injuries.Unavailable{
+		{Injury: injuries.Injury{FixtureID: 10, PlayerID: 276, PlayerName: "Striker", TeamID: 1, Type: injuries.TypeMissing, Reason: "Knee Injury"}, MinutesShare: 0.88},
+		{Injury: injuries.Injury{FixtureID: 10, PlayerID: 277, PlayerName: "Winger", TeamID: 1, Type: injuries.TypeMissing, Reason: "Red Card"}, MinutesShare: 0.22},
+		{Injury: injuries.Injury{FixtureID: 10, PlayerID: 300, PlayerName: "Keeper", TeamID: 2, Type: injuries.TypeQuestionable, Reason: "Illness"}, MinutesShare: 1},
+	}
+
+	testCases := []struct {
+		title       string
+		listErr     error
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title:       "error InjuryDao.ListByFixture",
+			listErr:     errors.New("error ListByFixture"),
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			injuries.InjuryDao = &MockInjuryDao{
+				FuncListByFixture: func(fixtureID int64) ([]injuries.Unavailable, error) {
+					assert.Equal(t, int64(10), fixtureID)
+					return unavailable, testCase.listErr
+				},
+			}
+
+			res, err := PredictionService.Predict(context.Background(), 10, "poisson@3")
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if err != nil {
+				assert.Nil(t, res)
+				return
+			}
+			// A tenth of the home eleven is missing, the questionable keeper is expected to play
+			assert.Equal(t, "poisson@3", res.Model)
+			assert.NotNil(t, res.Availability)
+			assert.Equal(t, 0.5, res.Availability.Weight)
+			assert.InDelta(t, 0.1, res.Availability.HomeMissing, 1e-9)
+			assert.Equal(t, 0.0, res.Availability.AwayMissing)
+			assert.InDelta(t, 0.95, res.Availability.HomeFactor, 1e-9)
+			assert.InDelta(t, 1.05, res.Availability.AwayFactor, 1e-9)
+			assert.Equal(t, []predictions.MissingPlayer{
+				{PlayerID: 276, PlayerName: "Striker", Reason: "Knee Injury", MinutesShare: 0.88},
+				{PlayerID: 277, PlayerName: "Winger", Reason: "Red Card", MinutesShare: 0.22},
+			}, res.Availability.HomePlayers)
+			assert.Equal(t, []predictions.MissingPlayer{}, res.Availability.AwayPlayers)
+
+			unadjusted, err := PredictionService.Predict(context.Background(), 10, "poisson@1")
+			assert.Nil(t, err)
+			assert.Nil(t, unadjusted.Availability)
+			assert.InDelta(t, unadjusted.HomeExpectedGoals*0.95, res.HomeExpectedGoals, 1e-9)
+			assert.InDelta(t, unadjusted.AwayExpectedGoals*1.05, res.AwayExpectedGoals, 1e-9)
+			assert.Less(t, res.HomeWin, unadjusted.HomeWin)
+			assert.InDelta(t, 1, res.HomeWin+res.Draw+res.AwayWin, 1e-9)
+		})
+	}
+}
@@ -1,87 +1,279 @@
 package services
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"github.com/development-raul/footy-predictor/src/domains/seasons"
+	"github.com/development-raul/footy-predictor/src/metrics"
 	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/bulk"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/etag"
+	"github.com/development-raul/footy-predictor/src/utils/export"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/importer"
+	"github.com/development-raul/footy-predictor/src/utils/mergepatch"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
 	"github.com/development-raul/footy-predictor/src/utils/resterror"
 	"github.com/development-raul/footy-predictor/src/zlog"
+	"io"
+	"time"
 )
 
 type SeasonServiceI interface {
-	Create(id int64) resterror.RestErrorI
-	Find(id int64) (*seasons.Season, resterror.RestErrorI)
-	List(req *seasons.ListSeasonInput) ([]seasons.Season, resterror.RestErrorI)
-	Delete(id int64) resterror.RestErrorI
-	Sync() resterror.RestErrorI
+	Create(ctx context.Context, season *seasons.Season) resterror.RestErrorI
+	Patch(ctx context.Context, id int64, version int64, patch []byte) (int64, resterror.RestErrorI)
+	Find(ctx context.Context, id int64) (*seasons.Season, resterror.RestErrorI)
+	List(ctx context.Context, req *seasons.ListSeasonInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	Export(ctx context.Context, req *seasons.ListSeasonInput, w export.Writer) resterror.RestErrorI
+	Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI)
+	Current(ctx context.Context, req *seasons.CurrentSeasonInput) (*seasons.LeagueSeason, resterror.RestErrorI)
+	Delete(ctx context.Context, id int64, version int64) resterror.RestErrorI
+	BulkCreate(ctx context.Context, req *seasons.BulkCreateSeasonInput) (*bulk.Response, resterror.RestErrorI)
+	BulkDelete(ctx context.Context, req *bulk.DeleteInput) (*bulk.Response, resterror.RestErrorI)
+	Sync(ctx context.Context) resterror.RestErrorI
 }
 
-type seasonService struct{}
+// seasonsSyncPerPage is the page size used to load the existing seasons before a sync
+const seasonsSyncPerPage = 500
+
+type seasonService struct {
+	apiSports *api_sports_provider.Client
+}
 
 var SeasonService SeasonServiceI = &seasonService{}
 
-func (s *seasonService) Create(id int64) resterror.RestErrorI {
-	if err := seasons.SeasonDao.Create(id); err != nil {
-		return resterror.NewStandardInternalServerError()
+func (s *seasonService) Create(ctx context.Context, season *seasons.Season) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "SeasonService.Create")
+	defer span.End()
+
+	if season.Label == "" {
+		season.Label = seasons.DefaultLabel(season.ID)
+	}
+	if err := seasons.SeasonDao.Create(ctx, season); err != nil {
+		return resterror.NewDatabaseError(err, "season")
 	}
 	return nil
 }
 
-func (s *seasonService) Find(id int64) (*seasons.Season, resterror.RestErrorI) {
-	res, err := seasons.SeasonDao.Find(id)
-	if err != nil && err != sql.ErrNoRows {
-		return nil, resterror.NewStandardInternalServerError()
+// Patch applies a JSON Merge Patch to the fields of UpdateSeasonInput, a null member resets the field. It returns
+// the new version of the season
+func (s *seasonService) Patch(ctx context.Context, id int64, version int64, patch []byte) (int64, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "SeasonService.Patch")
+	defer span.End()
+
+	season, err := seasons.SeasonDao.Find(ctx, id)
+	if err != nil {
+		return 0, resterror.NewDatabaseError(err, "season")
+	}
+	if version != etag.Any && version != season.Version {
+		return 0, resterror.NewDatabaseError(dberror.ErrVersionMismatch, "season")
+	}
+
+	// Merge the patch into the current fields and validate the result as a full update
+	doc, _ := json.Marshal(seasons.UpdateSeasonInput{Label: season.Label})
+	var req seasons.UpdateSeasonInput
+	merged, err := mergepatch.Apply(doc, patch)
+	if err == nil {
+		err = json.Unmarshal(merged, &req)
+	}
+	if err != nil {
+		return 0, resterror.NewBadRequestError(constants.ErrorInvalidRequestBody)
+	}
+	if errs := utils.ValidateStruct(&req); errs != nil {
+		return 0, resterror.NewValidationError(errs)
+	}
+
+	req.ID, req.Version = season.ID, season.Version
+	if err := seasons.SeasonDao.Update(ctx, &req); err != nil {
+		return 0, resterror.NewDatabaseError(err, "season")
+	}
+	return req.Version, nil
+}
+
+func (s *seasonService) Find(ctx context.Context, id int64) (*seasons.Season, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "SeasonService.Find")
+	defer span.End()
+
+	res, err := seasons.SeasonDao.Find(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "season")
 	}
 	return res, nil
 }
 
-func (s *seasonService) List(req *seasons.ListSeasonInput) ([]seasons.Season, resterror.RestErrorI) {
-	results, err := seasons.SeasonDao.List(req)
+func (s *seasonService) List(ctx context.Context, req *seasons.ListSeasonInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "SeasonService.List")
+	defer span.End()
+
+	results, total, err := seasons.SeasonDao.List(ctx, req)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, resterror.NewStandardInternalServerError()
 	}
 
-	return results, nil
+	res := pagination.GeneratePaginatedResponse(req.Filter.Project(results), req.Page, req.PerPage, total)
+	res.NextCursor, res.PrevCursor = req.Filter.Cursors()
+
+	return &res, nil
 }
 
-func (s *seasonService) Delete(id int64) resterror.RestErrorI {
-	if err := seasons.SeasonDao.Delete(id); err != nil {
+// Export streams the records matching the filters of the list request, page by page
+func (s *seasonService) Export(ctx context.Context, req *seasons.ListSeasonInput, w export.Writer) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "SeasonService.Export")
+	defer span.End()
+
+	req.Page, req.PerPage = 1, export.PerPage
+	err := export.Stream(w, &req.Filter, func() (interface{}, error) {
+		results, _, err := seasons.SeasonDao.List(ctx, req)
+		return results, err
+	})
+	if err != nil {
+		tracing.Fail(span, err)
+		zlog.FromContext(ctx).Errorw("SeasonService Export Stream", "error", err)
 		return resterror.NewStandardInternalServerError()
 	}
 	return nil
 }
 
-func (s *seasonService) Sync() resterror.RestErrorI {
-	zlog.Logger.Info("Sync Seasons Start")
-	// Get existing seasons
-	results, err := seasons.SeasonDao.List(&seasons.ListSeasonInput{Order: "asc"})
-	if err != nil && err != sql.ErrNoRows {
-		return resterror.NewStandardInternalServerError()
+// Import upserts the rows of a CSV or JSON upload, the rows that are invalid or fail are reported
+func (s *seasonService) Import(ctx context.Context, r io.Reader, format string) (*importer.Report, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "SeasonService.Import")
+	defer span.End()
+
+	var items []seasons.Season
+	rowErrs, apiErr := importer.Decode(r, format, &items)
+	if apiErr != nil {
+		return nil, apiErr
 	}
+	return importer.Run(ctx, items, rowErrs, func(ctx context.Context, i int) (int64, error) {
+		season := items[i]
+		if season.Label == "" {
+			season.Label = seasons.DefaultLabel(season.ID)
+		}
+		return season.ID, seasons.SeasonDao.Upsert(ctx, &season)
+	})
+}
+
+// Current resolves the season of the league active on the requested date. Without a date the season marked as
+// current by API Sports is used, falling back to the season active today
+func (s *seasonService) Current(ctx context.Context, req *seasons.CurrentSeasonInput) (*seasons.LeagueSeason, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "SeasonService.Current")
+	defer span.End()
+
+	date := helpers.GetNow()
+	if req.Date != "" {
+		date, _ = time.Parse(seasons.DateLayout, req.Date)
+	} else {
+		res, err := seasons.SeasonDao.FindCurrent(ctx, req.LeagueID)
+		if err == nil {
+			return res, nil
+		}
+		if err != sql.ErrNoRows {
+			return nil, resterror.NewStandardInternalServerError()
+		}
+	}
+
+	res, err := seasons.SeasonDao.FindByDate(ctx, req.LeagueID, date)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "season")
+	}
+	return res, nil
+}
+
+// Delete removes the season when it is at version, or at any version for etag.Any
+func (s *seasonService) Delete(ctx context.Context, id int64, version int64) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "SeasonService.Delete")
+	defer span.End()
+
+	season, err := seasons.SeasonDao.Find(ctx, id)
+	if err != nil {
+		return resterror.NewDatabaseError(err, "season")
+	}
+	if version != etag.Any && version != season.Version {
+		return resterror.NewDatabaseError(dberror.ErrVersionMismatch, "season")
+	}
+	if err := seasons.SeasonDao.Delete(ctx, id, season.Version); err != nil {
+		return resterror.NewDatabaseError(err, "season")
+	}
+	return nil
+}
+
+func (s *seasonService) BulkCreate(ctx context.Context, req *seasons.BulkCreateSeasonInput) (*bulk.Response, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "SeasonService.BulkCreate")
+	defer span.End()
+
+	return bulk.Run(ctx, req.Mode, bulk.StatusCreated, req.Items, func(ctx context.Context, i int) (int64, error) {
+		season := req.Items[i]
+		if season.Label == "" {
+			season.Label = seasons.DefaultLabel(season.ID)
+		}
+		return season.ID, seasons.SeasonDao.Create(ctx, &season)
+	})
+}
+
+func (s *seasonService) BulkDelete(ctx context.Context, req *bulk.DeleteInput) (*bulk.Response, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "SeasonService.BulkDelete")
+	defer span.End()
+
+	return bulk.Run(ctx, req.Mode, bulk.StatusDeleted, req.Items, func(ctx context.Context, i int) (int64, error) {
+		id := req.Items[i].ID
+		season, err := seasons.SeasonDao.Find(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return id, seasons.SeasonDao.Delete(ctx, id, season.Version)
+	})
+}
+
+func (s *seasonService) Sync(ctx context.Context) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "SeasonService.Sync")
+	defer span.End()
+
+	zlog.FromContext(ctx).Infow("Sync Seasons Start")
+	run := metrics.StartSync("seasons")
+	defer run.Done()
 	// Create a map with existing seasons, so we can easily identify the already existing seasons
-	existingSeasons := make(map[int64]int64, len(results))
-	for _, v := range results {
-		existingSeasons[v.ID] = v.ID
+	existingSeasons := make(map[int64]int64)
+	for page := int64(1); ; page++ {
+		results, _, err := seasons.SeasonDao.List(ctx, &seasons.ListSeasonInput{Page: page, PerPage: seasonsSyncPerPage})
+		if err != nil && err != sql.ErrNoRows {
+			run.Fail()
+			return resterror.NewStandardInternalServerError()
+		}
+		for _, v := range results {
+			existingSeasons[v.ID] = v.ID
+		}
+		if len(results) < seasonsSyncPerPage {
+			break
+		}
 	}
 
 	// Get the list of seasons from API Sports
-	res, apiErr := api_sports_provider.GetSeasons()
+	res, apiErr := s.apiSports.GetSeasons(ctx)
 	if apiErr != nil {
+		run.Fail()
 		return resterror.NewStandardInternalServerError()
 	}
 
 	for _, id := range res {
 		// Check if the season already exists
 		if _, exists := existingSeasons[id]; exists {
+			run.Row(metrics.RowSkipped)
 			continue
 		}
 		// Create the season if it does not exist
-		if err := seasons.SeasonDao.Create(id); err != nil {
-			zlog.Logger.Warn("could not create season: ", id)
+		if err := seasons.SeasonDao.Create(ctx, &seasons.Season{ID: id, Label: seasons.DefaultLabel(id)}); err != nil {
+			zlog.FromContext(ctx).Warnw("could not create season", "season", id)
+			run.Row(metrics.RowFailed)
 			continue
 		}
-		zlog.Logger.Info("created new season: ", id)
+		run.Row(metrics.RowCreated)
+		zlog.FromContext(ctx).Infow("created new season", "season", id)
 	}
-	zlog.Logger.Info("Sync Seasons End")
+	zlog.FromContext(ctx).Infow("Sync Seasons End")
 	return nil
 }
@@ -1,38 +1,67 @@
 package services
 
 import (
+	"context"
 	"database/sql"
 	"errors"
-	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
 	"github.com/development-raul/footy-predictor/src/clients/restclient"
+	"github.com/development-raul/footy-predictor/src/config"
 	"github.com/development-raul/footy-predictor/src/domains/seasons"
+	"github.com/development-raul/footy-predictor/src/providers/api_sports_provider"
+	"github.com/development-raul/footy-predictor/src/utils/bulk"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
 	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"net/http"
-	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 type MockSeasonDao struct {
-	FuncCreate func(id int64) error
-	FuncFind   func(id int64) (*seasons.Season, error)
-	FuncList   func(req *seasons.ListSeasonInput) ([]seasons.Season, error)
-	FuncDelete func(id int64) error
+	FuncCreate             func(season *seasons.Season) error
+	FuncUpsert             func(season *seasons.Season) error
+	FuncUpdate             func(season *seasons.UpdateSeasonInput) error
+	FuncFind               func(id int64) (*seasons.Season, error)
+	FuncList               func(req *seasons.ListSeasonInput) ([]seasons.Season, int64, error)
+	FuncDelete             func(id int64, version int64) error
+	FuncUpsertLeagueSeason func(leagueSeason *seasons.LeagueSeason) error
+	FuncFindCurrent        func(leagueID int64) (*seasons.LeagueSeason, error)
+	FuncFindByDate         func(leagueID int64, date time.Time) (*seasons.LeagueSeason, error)
 }
 
-func (m MockSeasonDao) Create(id int64) error {
-	return m.FuncCreate(id)
+func (m MockSeasonDao) Create(ctx context.Context, season *seasons.Season) error {
+	return m.FuncCreate(season)
 }
-func (m MockSeasonDao) Find(id int64) (*seasons.Season, error) {
+func (m MockSeasonDao) Upsert(ctx context.Context, season *seasons.Season) error {
+	return m.FuncUpsert(season)
+}
+func (m MockSeasonDao) Update(ctx context.Context, season *seasons.UpdateSeasonInput) error {
+	return m.FuncUpdate(season)
+}
+func (m MockSeasonDao) Find(ctx context.Context, id int64) (*seasons.Season, error) {
 	return m.FuncFind(id)
 }
-func (m MockSeasonDao) List(req *seasons.ListSeasonInput) ([]seasons.Season, error) {
+func (m MockSeasonDao) List(ctx context.Context, req *seasons.ListSeasonInput) ([]seasons.Season, int64, error) {
 	return m.FuncList(req)
 }
-func (m MockSeasonDao) Delete(id int64) error {
-	return m.FuncDelete(id)
+func (m MockSeasonDao) Delete(ctx context.Context, id int64, version int64) error {
+	return m.FuncDelete(id, version)
+}
+func (m MockSeasonDao) UpsertLeagueSeason(ctx context.Context, leagueSeason *seasons.LeagueSeason) error {
+	return m.FuncUpsertLeagueSeason(leagueSeason)
+}
+func (m MockSeasonDao) FindCurrent(ctx context.Context, leagueID int64) (*seasons.LeagueSeason, error) {
+	return m.FuncFindCurrent(leagueID)
+}
+func (m MockSeasonDao) FindByDate(ctx context.Context, leagueID int64, date time.Time) (*seasons.LeagueSeason, error) {
+	return m.FuncFindByDate(leagueID, date)
 }
 
 func TestSeasonService_Create(t *testing.T) {
@@ -44,16 +73,19 @@ func TestSeasonService_Create(t *testing.T) {
 		{
 			title: "error SeasonDao.Create",
 			seasonDaoMock: &MockSeasonDao{
-				FuncCreate: func(id int64) error {
+				FuncCreate: func(season *seasons.Season) error {
 					return errors.New("error Create")
 				},
 			},
 			expectedErr: resterror.NewStandardInternalServerError(),
 		},
 		{
-			title: "success",
+			title: "success default label",
 			seasonDaoMock: &MockSeasonDao{
-				FuncCreate: func(id int64) error {
+				FuncCreate: func(season *seasons.Season) error {
+					if season.Label != "2021/22" {
+						return errors.New("unexpected label " + season.Label)
+					}
 					return nil
 				},
 			},
@@ -65,7 +97,7 @@ func TestSeasonService_Create(t *testing.T) {
 		t.Run(testCase.title, func(t *testing.T) {
 			seasons.SeasonDao = testCase.seasonDaoMock
 
-			err := SeasonService.Create(1)
+			err := SeasonService.Create(context.Background(), &seasons.Season{ID: 2021})
 
 			assert.Equal(t, testCase.expectedErr, err)
 		})
@@ -92,7 +124,7 @@ func TestSeasonService_Find(t *testing.T) {
 			expectedErr: resterror.NewStandardInternalServerError(),
 		},
 		{
-			title: "error SeasonDao.Find no rows",
+			title: "error SeasonDao.Find not found",
 			id:    1,
 			seasonDaoMock: &MockSeasonDao{
 				FuncFind: func(id int64) (*seasons.Season, error) {
@@ -100,7 +132,7 @@ func TestSeasonService_Find(t *testing.T) {
 				},
 			},
 			expectedRes: nil,
-			expectedErr: nil,
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "season"),
 		},
 		{
 			title: "success",
@@ -119,7 +151,7 @@ func TestSeasonService_Find(t *testing.T) {
 		t.Run(testCase.title, func(t *testing.T) {
 			seasons.SeasonDao = testCase.seasonDaoMock
 
-			res, err := SeasonService.Find(testCase.id)
+			res, err := SeasonService.Find(context.Background(), testCase.id)
 
 			assert.Equal(t, testCase.expectedRes, res)
 			assert.Equal(t, testCase.expectedErr, err)
@@ -131,14 +163,14 @@ func TestSeasonService_List(t *testing.T) {
 	testCases := []struct {
 		title         string
 		seasonDaoMock seasons.SeasonDaoI
-		expectedRes   []seasons.Season
+		expectedRes   *pagination.PaginatedResponse
 		expectedErr   resterror.RestErrorI
 	}{
 		{
 			title: "error SeasonDao.List",
 			seasonDaoMock: &MockSeasonDao{
-				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, error) {
-					return nil, errors.New("error List")
+				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, int64, error) {
+					return nil, 0, errors.New("error List")
 				},
 			},
 			expectedRes: nil,
@@ -147,21 +179,37 @@ func TestSeasonService_List(t *testing.T) {
 		{
 			title: "error SeasonDao.List not found",
 			seasonDaoMock: &MockSeasonDao{
-				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, error) {
-					return nil, sql.ErrNoRows
+				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, int64, error) {
+					return nil, 0, sql.ErrNoRows
 				},
 			},
-			expectedRes: nil,
+			expectedRes: &pagination.PaginatedResponse{
+				From:        0,
+				Data:        []seasons.Season(nil),
+				CurrentPage: 1,
+				LastPage:    1,
+				PerPage:     10,
+				To:          0,
+				Total:       0,
+			},
 			expectedErr: nil,
 		},
 		{
 			title: "success",
 			seasonDaoMock: &MockSeasonDao{
-				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, error) {
-					return []seasons.Season{{ID: 1}}, nil
+				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, int64, error) {
+					return []seasons.Season{{ID: 2021, Label: "2021/22"}}, 1, nil
 				},
 			},
-			expectedRes: []seasons.Season{{ID: 1}},
+			expectedRes: &pagination.PaginatedResponse{
+				From:        1,
+				Data:        []seasons.Season{{ID: 2021, Label: "2021/22"}},
+				CurrentPage: 1,
+				LastPage:    1,
+				PerPage:     10,
+				To:          1,
+				Total:       1,
+			},
 			expectedErr: nil,
 		},
 	}
@@ -170,7 +218,103 @@ func TestSeasonService_List(t *testing.T) {
 		t.Run(testCase.title, func(t *testing.T) {
 			seasons.SeasonDao = testCase.seasonDaoMock
 
-			res, err := SeasonService.List(&seasons.ListSeasonInput{ID: 1, Order: "asc"})
+			res, err := SeasonService.List(context.Background(), &seasons.ListSeasonInput{
+				Page:    1,
+				PerPage: 10,
+			})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestSeasonService_Current(t *testing.T) {
+	leagueSeason := &seasons.LeagueSeason{
+		LeagueID:  39,
+		Season:    2021,
+		Label:     "2021/22",
+		StartDate: time.Date(2021, 8, 13, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2022, 5, 22, 0, 0, 0, 0, time.UTC),
+		Current:   true,
+	}
+	testCases := []struct {
+		title         string
+		date          string
+		seasonDaoMock seasons.SeasonDaoI
+		expectedRes   *seasons.LeagueSeason
+		expectedErr   resterror.RestErrorI
+	}{
+		{
+			title: "error SeasonDao.FindCurrent",
+			seasonDaoMock: &MockSeasonDao{
+				FuncFindCurrent: func(leagueID int64) (*seasons.LeagueSeason, error) {
+					return nil, errors.New("error FindCurrent")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success current marker",
+			seasonDaoMock: &MockSeasonDao{
+				FuncFindCurrent: func(leagueID int64) (*seasons.LeagueSeason, error) {
+					return leagueSeason, nil
+				},
+			},
+			expectedRes: leagueSeason,
+		},
+		{
+			title: "success no current marker falls back to today",
+			seasonDaoMock: &MockSeasonDao{
+				FuncFindCurrent: func(leagueID int64) (*seasons.LeagueSeason, error) {
+					return nil, sql.ErrNoRows
+				},
+				FuncFindByDate: func(leagueID int64, date time.Time) (*seasons.LeagueSeason, error) {
+					return leagueSeason, nil
+				},
+			},
+			expectedRes: leagueSeason,
+		},
+		{
+			title: "error SeasonDao.FindByDate not found",
+			date:  "2000-01-01",
+			seasonDaoMock: &MockSeasonDao{
+				FuncFindByDate: func(leagueID int64, date time.Time) (*seasons.LeagueSeason, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "season"),
+		},
+		{
+			title: "error SeasonDao.FindByDate",
+			date:  "2021-10-01",
+			seasonDaoMock: &MockSeasonDao{
+				FuncFindByDate: func(leagueID int64, date time.Time) (*seasons.LeagueSeason, error) {
+					return nil, errors.New("error FindByDate")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success date",
+			date:  "2021-10-01",
+			seasonDaoMock: &MockSeasonDao{
+				FuncFindByDate: func(leagueID int64, date time.Time) (*seasons.LeagueSeason, error) {
+					if !date.Equal(time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)) {
+						return nil, sql.ErrNoRows
+					}
+					return leagueSeason, nil
+				},
+			},
+			expectedRes: leagueSeason,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			seasons.SeasonDao = testCase.seasonDaoMock
+
+			res, err := SeasonService.Current(context.Background(), &seasons.CurrentSeasonInput{LeagueID: 39, Date: testCase.date})
 
 			assert.Equal(t, testCase.expectedRes, res)
 			assert.Equal(t, testCase.expectedErr, err)
@@ -178,25 +322,152 @@ func TestSeasonService_List(t *testing.T) {
 	}
 }
 
+func TestSeasonService_Patch(t *testing.T) {
+	found := func(id int64) (*seasons.Season, error) {
+		return &seasons.Season{ID: id, Label: "2021", Version: 3}, nil
+	}
+	testCases := []struct {
+		title           string
+		version         int64
+		patch           string
+		seasonDaoMock   seasons.SeasonDaoI
+		expectedUpdate  *seasons.UpdateSeasonInput
+		expectedVersion int64
+		expectedErr     resterror.RestErrorI
+	}{
+		{
+			title:   "error SeasonDao.Find not found",
+			version: 3,
+			patch:   `{"label":"2021/22"}`,
+			seasonDaoMock: &MockSeasonDao{
+				FuncFind: func(id int64) (*seasons.Season, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "season"),
+		},
+		{
+			title:         "error version mismatch",
+			version:       2,
+			patch:         `{"label":"2021/22"}`,
+			seasonDaoMock: &MockSeasonDao{FuncFind: found},
+			expectedErr:   resterror.NewDatabaseError(dberror.ErrVersionMismatch, "season"),
+		},
+		{
+			title:         "error invalid patch",
+			version:       3,
+			patch:         `{"label":`,
+			seasonDaoMock: &MockSeasonDao{FuncFind: found},
+			expectedErr:   resterror.NewBadRequestError(constants.ErrorInvalidRequestBody),
+		},
+		{
+			title:         "error invalid field type",
+			version:       3,
+			patch:         `{"label":2021}`,
+			seasonDaoMock: &MockSeasonDao{FuncFind: found},
+			expectedErr:   resterror.NewBadRequestError(constants.ErrorInvalidRequestBody),
+		},
+		{
+			title:         "error label too long",
+			version:       3,
+			patch:         `{"label":"2021/2022/23"}`,
+			seasonDaoMock: &MockSeasonDao{FuncFind: found},
+			expectedErr:   resterror.NewValidationError(map[string][]string{"label": {"the label must have a length less than 10"}}),
+		},
+		{
+			title:   "error SeasonDao.Update",
+			version: 3,
+			patch:   `{"label":"2021/22"}`,
+			seasonDaoMock: &MockSeasonDao{
+				FuncFind: found,
+				FuncUpdate: func(season *seasons.UpdateSeasonInput) error {
+					return errors.New("error Update")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:   "success",
+			version: 3,
+			patch:   `{"label":"2021/22"}`,
+			seasonDaoMock: &MockSeasonDao{
+				FuncFind: found,
+				FuncUpdate: func(season *seasons.UpdateSeasonInput) error {
+					season.Version++
+					return nil
+				},
+			},
+			expectedUpdate:  &seasons.UpdateSeasonInput{ID: 1, Version: 4, Label: "2021/22"},
+			expectedVersion: 4,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			var updated *seasons.UpdateSeasonInput
+			mock := testCase.seasonDaoMock.(*MockSeasonDao)
+			if update := mock.FuncUpdate; update != nil {
+				mock.FuncUpdate = func(season *seasons.UpdateSeasonInput) error {
+					updated = season
+					return update(season)
+				}
+			}
+			seasons.SeasonDao = mock
+
+			version, err := SeasonService.Patch(context.Background(), 1, testCase.version, []byte(testCase.patch))
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedVersion, version)
+			if testCase.expectedUpdate != nil {
+				assert.Equal(t, testCase.expectedUpdate, updated)
+			}
+		})
+	}
+}
+
 func TestSeasonService_Delete(t *testing.T) {
+	found := func(id int64) (*seasons.Season, error) {
+		return &seasons.Season{ID: id, Version: 3}, nil
+	}
 	testCases := []struct {
 		title         string
+		version       int64
 		seasonDaoMock seasons.SeasonDaoI
 		expectedErr   resterror.RestErrorI
 	}{
 		{
-			title: "error CountryDao.Delete",
+			title:   "error SeasonDao.Find not found",
+			version: 3,
 			seasonDaoMock: &MockSeasonDao{
-				FuncDelete: func(id int64) error {
+				FuncFind: func(id int64) (*seasons.Season, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "season"),
+		},
+		{
+			title:         "error version mismatch",
+			version:       2,
+			seasonDaoMock: &MockSeasonDao{FuncFind: found},
+			expectedErr:   resterror.NewDatabaseError(dberror.ErrVersionMismatch, "season"),
+		},
+		{
+			title:   "error SeasonDao.Delete",
+			version: 3,
+			seasonDaoMock: &MockSeasonDao{
+				FuncFind: found,
+				FuncDelete: func(id int64, version int64) error {
 					return errors.New("error Delete")
 				},
 			},
 			expectedErr: resterror.NewStandardInternalServerError(),
 		},
 		{
-			title: "success",
+			title:   "success",
+			version: 3,
 			seasonDaoMock: &MockSeasonDao{
-				FuncDelete: func(id int64) error {
+				FuncFind: found,
+				FuncDelete: func(id int64, version int64) error {
 					return nil
 				},
 			},
@@ -207,14 +478,14 @@ func TestSeasonService_Delete(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.title, func(t *testing.T) {
 			seasons.SeasonDao = testCase.seasonDaoMock
-			err := SeasonService.Delete(1)
+			err := SeasonService.Delete(context.Background(), 1, testCase.version)
 			assert.Equal(t, testCase.expectedErr, err)
 		})
 	}
 }
 
 func TestSeasonService_Sync(t *testing.T) {
-	os.Setenv("AS_BASE_URL", "http://localhost")
+	SeasonService = &seasonService{apiSports: api_sports_provider.New(config.APISportsConfig{BaseURL: "http://localhost"})}
 	testCases := []struct {
 		title          string
 		seasonDaoMock  seasons.SeasonDaoI
@@ -224,8 +495,8 @@ func TestSeasonService_Sync(t *testing.T) {
 		{
 			title: "error SeasonDao.List",
 			seasonDaoMock: &MockSeasonDao{
-				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, error) {
-					return nil, errors.New("error List")
+				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, int64, error) {
+					return nil, 0, errors.New("error List")
 				},
 			},
 			expectedErr: resterror.NewStandardInternalServerError(),
@@ -233,8 +504,8 @@ func TestSeasonService_Sync(t *testing.T) {
 		{
 			title: "error api_sports_provider.GetSeasons",
 			seasonDaoMock: &MockSeasonDao{
-				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, error) {
-					return []seasons.Season{{ID: 1}}, nil
+				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, int64, error) {
+					return []seasons.Season{{ID: 1}}, 1, nil
 				},
 			},
 			restClientResp: &http.Response{
@@ -246,10 +517,10 @@ func TestSeasonService_Sync(t *testing.T) {
 		{
 			title: "error SeasonDao.Create",
 			seasonDaoMock: &MockSeasonDao{
-				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, error) {
-					return []seasons.Season{{ID: 1}}, nil
+				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, int64, error) {
+					return []seasons.Season{{ID: 1}}, 1, nil
 				},
-				FuncCreate: func(id int64) error {
+				FuncCreate: func(season *seasons.Season) error {
 					return errors.New("error Create")
 				},
 			},
@@ -274,10 +545,10 @@ func TestSeasonService_Sync(t *testing.T) {
 		{
 			title: "success",
 			seasonDaoMock: &MockSeasonDao{
-				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, error) {
-					return []seasons.Season{{ID: 1}}, nil
+				FuncList: func(req *seasons.ListSeasonInput) ([]seasons.Season, int64, error) {
+					return []seasons.Season{{ID: 1}}, 1, nil
 				},
-				FuncCreate: func(id int64) error {
+				FuncCreate: func(season *seasons.Season) error {
 					return nil
 				},
 			},
@@ -308,17 +579,51 @@ func TestSeasonService_Sync(t *testing.T) {
 			restclient.StartMockups()
 			restclient.FlushMockups()
 			restclient.AddMockup(restclient.Mock{
-				Url:        fmt.Sprintf("%s/leagues/seasons", os.Getenv("AS_BASE_URL")),
+				Url:        "http://localhost/leagues/seasons",
 				HttpMethod: http.MethodGet,
 				Response:   testCase.restClientResp,
 			})
 			seasons.SeasonDao = testCase.seasonDaoMock
 
 			// Execution
-			err := SeasonService.Sync()
+			err := SeasonService.Sync(context.Background())
 
 			// Assertions
 			assert.Equal(t, testCase.expectedErr, err)
 		})
 	}
 }
+
+func TestSeasonService_BulkCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	footy_db.Client = sqlx.NewDb(db, "sqlmock")
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	var created []seasons.Season
+	seasons.SeasonDao = &MockSeasonDao{
+		FuncCreate: func(season *seasons.Season) error {
+			created = append(created, *season)
+			return nil
+		},
+	}
+
+	res, apiErr := SeasonService.BulkCreate(context.Background(), &seasons.BulkCreateSeasonInput{
+		Items: []seasons.Season{{ID: 2021}, {ID: 2022, Label: "2022"}},
+	})
+
+	assert.Nil(t, apiErr)
+	assert.Equal(t, &bulk.Response{
+		Mode:    bulk.ModeAtomic,
+		Applied: 2,
+		Results: []bulk.Result{
+			{Index: 0, Status: bulk.StatusCreated, ID: 2021},
+			{Index: 1, Status: bulk.StatusCreated, ID: 2022},
+		},
+	}, res)
+	assert.Equal(t, []seasons.Season{{ID: 2021, Label: "2021/22"}, {ID: 2022, Label: "2022"}}, created)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
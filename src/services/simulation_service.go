@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"github.com/development-raul/footy-predictor/src/domains/leagues"
+	"github.com/development-raul/footy-predictor/src/domains/simulations"
+	"github.com/development-raul/footy-predictor/src/predictor"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"sort"
+)
+
+// Defaults of the season simulations
+const (
+	defaultSimulationRuns   = 10000
+	defaultEuropePlaces     = 4
+	defaultRelegationPlaces = 3
+)
+
+type SimulationServiceI interface {
+	Simulate(ctx context.Context, req *simulations.SimulationInput) (*simulations.Simulation, resterror.RestErrorI)
+}
+
+type simulationService struct{}
+
+var SimulationService SimulationServiceI = &simulationService{}
+
+// Simulate plays the fixtures left in a league season many times from the current table with the model fitted on
+// the results known now, see predictor.SimulateSeason. The model is a name@version reference, the champion when
+// empty. The error is NOT_A_LEAGUE for the cups, which have no table
+func (s *simulationService) Simulate(ctx context.Context, req *simulations.SimulationInput) (*simulations.Simulation, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "SimulationService.Simulate")
+	defer span.End()
+
+	league, err := leagues.LeagueDao.FindByID(ctx, req.LeagueID)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "league")
+	}
+	if league.Type != "League" {
+		return nil, resterror.NewUnprocessableEntityError("NOT_A_LEAGUE")
+	}
+	version, apiErr := resolveModel(ctx, req.Model)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	res, apiErr := seasonFixtures(ctx, req.LeagueID, req.Season)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if len(res) == 0 {
+		return nil, resterror.NewNotFoundError("FIXTURES_NOT_FOUND")
+	}
+
+	now := helpers.GetNow()
+	model, _, apiErr := fitModel(ctx, version, req.LeagueID, req.Season, now)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	names := make(map[int64]string)
+	var played, remaining []predictor.Match
+	for _, f := range res {
+		names[f.HomeTeamID], names[f.AwayTeamID] = f.HomeTeamName, f.AwayTeamName
+		match := predictor.Match{HomeTeamID: f.HomeTeamID, AwayTeamID: f.AwayTeamID, PlayedAt: f.KickoffAt}
+		switch {
+		case f.Finished():
+			match.HomeGoals, match.AwayGoals = *f.HomeGoals, *f.AwayGoals
+			played = append(played, match)
+		case !f.Cancelled():
+			remaining = append(remaining, match)
+		}
+	}
+
+	simulation := &simulations.Simulation{
+		LeagueID:         req.LeagueID,
+		Season:           req.Season,
+		Model:            version.Ref(),
+		Runs:             defaultSimulationRuns,
+		Seed:             now.UnixNano(),
+		FixturesLeft:     len(remaining),
+		EuropePlaces:     defaultEuropePlaces,
+		RelegationPlaces: defaultRelegationPlaces,
+	}
+	if req.Runs != 0 {
+		simulation.Runs = req.Runs
+	}
+	if req.Seed != nil {
+		simulation.Seed = *req.Seed
+	}
+	if req.EuropePlaces != nil {
+		simulation.EuropePlaces = *req.EuropePlaces
+	}
+	if req.RelegationPlaces != nil {
+		simulation.RelegationPlaces = *req.RelegationPlaces
+	}
+
+	outcome := predictor.SimulateSeason(model, played, remaining, simulation.Runs, simulation.Seed)
+	teams := len(outcome.Teams)
+	for _, t := range outcome.Teams {
+		team := simulations.Team{
+			TeamID:         t.TeamID,
+			TeamName:       names[t.TeamID],
+			Played:         t.Played,
+			Points:         t.Points,
+			GoalDifference: t.GoalDifference,
+			ExpectedPoints: t.ExpectedPoints,
+			Positions:      t.Positions,
+		}
+		for position, probability := range t.Positions {
+			if position == 0 {
+				team.Title = probability
+			}
+			if position < simulation.EuropePlaces {
+				team.Europe += probability
+			}
+			if position >= teams-simulation.RelegationPlaces {
+				team.Relegation += probability
+			}
+		}
+		simulation.Teams = append(simulation.Teams, team)
+	}
+	sort.SliceStable(simulation.Teams, func(i, j int) bool {
+		return simulation.Teams[i].ExpectedPoints > simulation.Teams[j].ExpectedPoints
+	})
+	return simulation, nil
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/leagues"
+	"github.com/development-raul/footy-predictor/src/domains/models"
+	"github.com/development-raul/footy-predictor/src/domains/simulations"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSimulationService_Simulate(t *testing.T) {
+	goals := func(v int64) *int64 { return &v }
+	start := time.Date(2021, 8, 14, 15, 0, 0, 0, time.UTC)
+	// A double round robin of 4 teams, the first half is played and team 1 won every match
+	var season []fixtures.Fixture
+	for home := int64(1); home <= 4; home++ {
+		for away := int64(1); away <= 4; away++ {
+			if home == away {
+				continue
+			}
+			fixture := fixtures.Fixture{ID: int64(len(season) + 1), LeagueID: 39, Season: 2021, Status: "NS",
+				KickoffAt: start.AddDate(0, 0, 7*len(season)), HomeTeamID: home, AwayTeamID: away,
+				HomeTeamName: fmt.Sprintf("Team %d", home), AwayTeamName: fmt.Sprintf("Team %d", away)}
+			if home < away {
+				fixture.Status, fixture.HomeGoals, fixture.AwayGoals = "FT", goals(1), goals(1)
+				if home == 1 {
+					fixture.HomeGoals = goals(3)
+				}
+			}
+			season = append(season, fixture)
+		}
+	}
+	season[len(season)-1].Status = "CANC"
+	leagueDao := &MockLeagueDao{
+		FuncFindByID: func(id int64) (*leagues.League, error) {
+			switch id {
+			case 39:
+				return &leagues.League{ID: 39, Type: "League"}, nil
+			case 45:
+				return &leagues.League{ID: 45, Type: "Cup"}, nil
+			}
+			return nil, sql.ErrNoRows
+		},
+	}
+	fixtureDao := &MockFixtureDao{
+		FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+			if req.Season != 2021 {
+				return nil, 0, nil
+			}
+			return season, int64(len(season)), nil
+		},
+	}
+	seed, europe, relegation := int64(7), 2, 1
+
+	testCases := []struct {
+		title       string
+		req         simulations.SimulationInput
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title:       "error league not found",
+			req:         simulations.SimulationInput{LeagueID: 40, Season: 2021},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "league"),
+		},
+		{
+			title:       "error cup",
+			req:         simulations.SimulationInput{LeagueID: 45, Season: 2021},
+			expectedErr: resterror.NewUnprocessableEntityError("NOT_A_LEAGUE"),
+		},
+		{
+			title:       "error model not found",
+			req:         simulations.SimulationInput{LeagueID: 39, Season: 2021, Model: "elo"},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "model"),
+		},
+		{
+			title:       "error no fixtures",
+			req:         simulations.SimulationInput{LeagueID: 39, Season: 2020},
+			expectedErr: resterror.NewNotFoundError("FIXTURES_NOT_FOUND"),
+		},
+		{
+			title: "success",
+			req: simulations.SimulationInput{LeagueID: 39, Season: 2021, Model: "dixon-coles", Runs: 1000, Seed: &seed,
+				EuropePlaces: &europe, RelegationPlaces: &relegation},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			leagues.LeagueDao = leagueDao
+			models.ModelDao = testModelDao()
+			fixtures.FixtureDao = fixtureDao
+
+			res, err := SimulationService.Simulate(context.Background(), &testCase.req)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if err != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, "dixon-coles@2", res.Model)
+			assert.Equal(t, 1000, res.Runs)
+			assert.Equal(t, int64(7), res.Seed)
+			assert.Equal(t, 5, res.FixturesLeft)
+			assert.Len(t, res.Teams, 4)
+			// Team 1 leads by 4 points with 3 fixtures left
+			leader := res.Teams[0]
+			assert.Equal(t, int64(1), leader.TeamID)
+			assert.Equal(t, "Team 1", leader.TeamName)
+			assert.Equal(t, int64(9), leader.Points)
+			assert.Equal(t, int64(6), leader.GoalDifference)
+			assert.Greater(t, leader.Title, 0.5)
+			assert.Equal(t, leader.Positions[0], leader.Title)
+			assert.InDelta(t, leader.Positions[0]+leader.Positions[1], leader.Europe, 1e-9)
+			assert.Equal(t, leader.Positions[3], leader.Relegation)
+			var relegated float64
+			for i, team := range res.Teams {
+				relegated += team.Relegation
+				if i > 0 {
+					assert.GreaterOrEqual(t, res.Teams[i-1].ExpectedPoints, team.ExpectedPoints)
+				}
+			}
+			assert.InDelta(t, 1, relegated, 1e-9)
+
+			again, _ := SimulationService.Simulate(context.Background(), &testCase.req)
+			assert.Equal(t, res, again)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/memberships"
+	"github.com/development-raul/footy-predictor/src/domains/user_predictions"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+)
+
+type UserPredictionServiceI interface {
+	Submit(ctx context.Context, competitionID int64, userID int64, req *user_predictions.UserPredictionInput) (*user_predictions.UserPrediction, resterror.RestErrorI)
+	List(ctx context.Context, req *user_predictions.ListUserPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+	ScoreFixture(ctx context.Context, fixture *fixtures.Fixture) resterror.RestErrorI
+}
+
+type userPredictionService struct{}
+
+var UserPredictionService UserPredictionServiceI = &userPredictionService{}
+
+// Submit creates or replaces the prediction of a competition member for a fixture that has not kicked off yet
+func (s *userPredictionService) Submit(ctx context.Context, competitionID int64, userID int64, req *user_predictions.UserPredictionInput) (*user_predictions.UserPrediction, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "UserPredictionService.Submit")
+	defer span.End()
+
+	member, err := memberships.MembershipDao.Exists(ctx, competitionID, userID)
+	if err != nil {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	if !member {
+		return nil, resterror.NewForbiddenError("NOT_A_MEMBER")
+	}
+
+	fixture, err := fixtures.FixtureDao.FindByID(ctx, req.FixtureID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, resterror.NewBadRequestError("INVALID_FIXTURE_ID")
+		}
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	// Predictions are locked once the fixture kicks off
+	deadline := user_predictions.KickoffDeadline{KickoffAt: fixture.KickoffAt.UTC().Format("2006-01-02 15:04:05")}
+	if errs := utils.ValidateStruct(&deadline); errs != nil {
+		return nil, resterror.NewValidationError(errs)
+	}
+
+	now := helpers.GetNow()
+	prediction := user_predictions.UserPrediction{
+		CompetitionID: competitionID,
+		UserID:        userID,
+		FixtureID:     fixture.ID,
+		HomeGoals:     *req.HomeGoals,
+		AwayGoals:     *req.AwayGoals,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := user_predictions.UserPredictionDao.Upsert(ctx, &prediction); err != nil {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	return &prediction, nil
+}
+
+func (s *userPredictionService) List(ctx context.Context, req *user_predictions.ListUserPredictionInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "UserPredictionService.List")
+	defer span.End()
+
+	results, total, err := user_predictions.UserPredictionDao.List(ctx, req)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	res := pagination.GeneratePaginatedResponse(req.Filter.Project(results), req.Page, req.PerPage, total)
+	res.NextCursor, res.PrevCursor = req.Filter.Cursors()
+
+	return &res, nil
+}
+
+// ScoreFixture awards points to every prediction made for a finished fixture using the rules of its competition,
+// then records when the fixture was scored. Scoring again gives the same points
+func (s *userPredictionService) ScoreFixture(ctx context.Context, fixture *fixtures.Fixture) resterror.RestErrorI {
+	ctx, span := tracing.Start(ctx, "UserPredictionService.ScoreFixture")
+	defer span.End()
+
+	if !fixture.Finished() {
+		return resterror.NewBadRequestError("FIXTURE_NOT_FINISHED")
+	}
+
+	rows, err := user_predictions.UserPredictionDao.ListForScoring(ctx, fixture.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return resterror.NewStandardInternalServerError()
+	}
+
+	scoredAt := helpers.GetNow()
+	failed := false
+	for _, row := range rows {
+		points := ScorePrediction(row, *fixture.HomeGoals, *fixture.AwayGoals)
+		if err := user_predictions.UserPredictionDao.UpdatePoints(ctx, row.ID, points, scoredAt); err != nil {
+			zlog.FromContext(ctx).Warnw("could not score prediction", "user_prediction_id", row.ID)
+			failed = true
+		}
+	}
+	// The fixture stays unscored until every prediction is, so the next sync scores it again
+	if failed {
+		return resterror.NewStandardInternalServerError()
+	}
+	if err := fixtures.FixtureDao.MarkScored(ctx, fixture.ID, scoredAt); err != nil {
+		return resterror.NewStandardInternalServerError()
+	}
+	fixture.ScoredAt = &scoredAt
+	zlog.FromContext(ctx).Infow("scored predictions", "fixture_id", fixture.ID)
+	return nil
+}
+
+// ScorePrediction returns the points earned by a prediction given the final score.
+// An exact score earns the most points, followed by the correct result with the correct goal difference
+// and finally the correct result alone. Competitions without goal difference points only award the result points
+func ScorePrediction(row user_predictions.ScoringRow, homeGoals int64, awayGoals int64) int64 {
+	switch {
+	case row.HomeGoals == homeGoals && row.AwayGoals == awayGoals:
+		return row.PointsExact
+	case sign(row.HomeGoals-row.AwayGoals) != sign(homeGoals-awayGoals):
+		return 0
+	case row.HomeGoals-row.AwayGoals == homeGoals-awayGoals && row.PointsGoalDifference > 0:
+		return row.PointsGoalDifference
+	default:
+		return row.PointsResult
+	}
+}
+
+func sign(n int64) int64 {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/memberships"
+	"github.com/development-raul/footy-predictor/src/domains/user_predictions"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type MockUserPredictionDao struct {
+	FuncUpsert         func(prediction *user_predictions.UserPrediction) error
+	FuncList           func(req *user_predictions.ListUserPredictionInput) ([]user_predictions.UserPrediction, int64, error)
+	FuncListForScoring func(fixtureID int64) ([]user_predictions.ScoringRow, error)
+	FuncUpdatePoints   func(id int64, points int64, scoredAt time.Time) error
+}
+
+func (m MockUserPredictionDao) Upsert(ctx context.Context, prediction *user_predictions.UserPrediction) error {
+	return m.FuncUpsert(prediction)
+}
+func (m MockUserPredictionDao) List(ctx context.Context, req *user_predictions.ListUserPredictionInput) ([]user_predictions.UserPrediction, int64, error) {
+	return m.FuncList(req)
+}
+func (m MockUserPredictionDao) ListForScoring(ctx context.Context, fixtureID int64) ([]user_predictions.ScoringRow, error) {
+	return m.FuncListForScoring(fixtureID)
+}
+func (m MockUserPredictionDao) UpdatePoints(ctx context.Context, id int64, points int64, scoredAt time.Time) error {
+	return m.FuncUpdatePoints(id, points, scoredAt)
+}
+
+func memberDao(member bool) *MockMembershipDao {
+	return &MockMembershipDao{
+		FuncExists: func(competitionID int64, userID int64) (bool, error) {
+			return member, nil
+		},
+	}
+}
+
+func fixtureDaoKickingOffAt(kickoff time.Time) *MockFixtureDao {
+	return &MockFixtureDao{
+		FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+			return &fixtures.Fixture{ID: id, KickoffAt: kickoff}, nil
+		},
+	}
+}
+
+func TestUserPredictionService_Submit(t *testing.T) {
+	future := time.Now().UTC().Add(24 * time.Hour)
+	past := time.Now().UTC().Add(-time.Minute)
+
+	testCases := []struct {
+		title                 string
+		membershipDaoMock     memberships.MembershipDaoI
+		fixtureDaoMock        fixtures.FixtureDaoI
+		userPredictionDaoMock user_predictions.UserPredictionDaoI
+		expectedErr           resterror.RestErrorI
+	}{
+		{
+			title: "error MembershipDao.Exists",
+			membershipDaoMock: &MockMembershipDao{
+				FuncExists: func(competitionID int64, userID int64) (bool, error) {
+					return false, errors.New("error Exists")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:             "error not a member",
+			membershipDaoMock: memberDao(false),
+			expectedErr:       resterror.NewForbiddenError("NOT_A_MEMBER"),
+		},
+		{
+			title:             "error invalid fixture",
+			membershipDaoMock: memberDao(true),
+			fixtureDaoMock: &MockFixtureDao{
+				FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewBadRequestError("INVALID_FIXTURE_ID"),
+		},
+		{
+			title:             "error FixtureDao.FindByID",
+			membershipDaoMock: memberDao(true),
+			fixtureDaoMock: &MockFixtureDao{
+				FuncFindByID: func(id int64) (*fixtures.Fixture, error) {
+					return nil, errors.New("error FindByID")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:             "error fixture already kicked off",
+			membershipDaoMock: memberDao(true),
+			fixtureDaoMock:    fixtureDaoKickingOffAt(past),
+			expectedErr: resterror.NewValidationError(map[string][]string{
+				"kickoff_at": {"The kickoff at must be a date in the future"},
+			}),
+		},
+		{
+			title:             "error UserPredictionDao.Upsert",
+			membershipDaoMock: memberDao(true),
+			fixtureDaoMock:    fixtureDaoKickingOffAt(future),
+			userPredictionDaoMock: &MockUserPredictionDao{
+				FuncUpsert: func(prediction *user_predictions.UserPrediction) error {
+					return errors.New("error Upsert")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:             "success",
+			membershipDaoMock: memberDao(true),
+			fixtureDaoMock:    fixtureDaoKickingOffAt(future),
+			userPredictionDaoMock: &MockUserPredictionDao{
+				FuncUpsert: func(prediction *user_predictions.UserPrediction) error {
+					return nil
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			memberships.MembershipDao = testCase.membershipDaoMock
+			fixtures.FixtureDao = testCase.fixtureDaoMock
+			user_predictions.UserPredictionDao = testCase.userPredictionDaoMock
+			homeGoals, awayGoals := int64(2), int64(1)
+
+			res, err := UserPredictionService.Submit(context.Background(), 4, 1, &user_predictions.UserPredictionInput{
+				FixtureID: 10,
+				HomeGoals: &homeGoals,
+				AwayGoals: &awayGoals,
+			})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedErr != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, int64(4), res.CompetitionID)
+			assert.Equal(t, int64(1), res.UserID)
+			assert.Equal(t, int64(10), res.FixtureID)
+			assert.Equal(t, int64(2), res.HomeGoals)
+			assert.Equal(t, int64(1), res.AwayGoals)
+		})
+	}
+}
+
+func TestUserPredictionService_List(t *testing.T) {
+	testCases := []struct {
+		title                 string
+		userPredictionDaoMock user_predictions.UserPredictionDaoI
+		expectedRes           *pagination.PaginatedResponse
+		expectedErr           resterror.RestErrorI
+	}{
+		{
+			title: "error UserPredictionDao.List",
+			userPredictionDaoMock: &MockUserPredictionDao{
+				FuncList: func(req *user_predictions.ListUserPredictionInput) ([]user_predictions.UserPrediction, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success",
+			userPredictionDaoMock: &MockUserPredictionDao{
+				FuncList: func(req *user_predictions.ListUserPredictionInput) ([]user_predictions.UserPrediction, int64, error) {
+					return []user_predictions.UserPrediction{{ID: 5}}, 1, nil
+				},
+			},
+			expectedRes: &pagination.PaginatedResponse{
+				From:        1,
+				Data:        []user_predictions.UserPrediction{{ID: 5}},
+				CurrentPage: 1,
+				LastPage:    1,
+				PerPage:     20,
+				To:          1,
+				Total:       1,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			user_predictions.UserPredictionDao = testCase.userPredictionDaoMock
+
+			res, err := UserPredictionService.List(context.Background(), &user_predictions.ListUserPredictionInput{CompetitionID: 4, UserID: 1})
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestUserPredictionService_ScoreFixture(t *testing.T) {
+	homeGoals, awayGoals := int64(2), int64(1)
+	finished := &fixtures.Fixture{ID: 10, Status: "FT", HomeGoals: &homeGoals, AwayGoals: &awayGoals}
+
+	testCases := []struct {
+		title                 string
+		fixture               *fixtures.Fixture
+		userPredictionDaoMock user_predictions.UserPredictionDaoI
+		markScoredErr         error
+		expectedPoints        map[int64]int64
+		expectedScored        bool
+		expectedErr           resterror.RestErrorI
+	}{
+		{
+			title:       "error fixture not finished",
+			fixture:     &fixtures.Fixture{ID: 10, Status: "NS"},
+			expectedErr: resterror.NewBadRequestError("FIXTURE_NOT_FINISHED"),
+		},
+		{
+			title:   "error UserPredictionDao.ListForScoring",
+			fixture: finished,
+			userPredictionDaoMock: &MockUserPredictionDao{
+				FuncListForScoring: func(fixtureID int64) ([]user_predictions.ScoringRow, error) {
+					return nil, errors.New("error ListForScoring")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:   "error UserPredictionDao.UpdatePoints",
+			fixture: finished,
+			userPredictionDaoMock: &MockUserPredictionDao{
+				FuncListForScoring: func(fixtureID int64) ([]user_predictions.ScoringRow, error) {
+					return []user_predictions.ScoringRow{{ID: 1}, {ID: 2}}, nil
+				},
+				FuncUpdatePoints: func(id int64, p int64, scoredAt time.Time) error {
+					if id == 1 {
+						return errors.New("error UpdatePoints")
+					}
+					return nil
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:         "error FixtureDao.MarkScored",
+			fixture:       finished,
+			markScoredErr: errors.New("error MarkScored"),
+			expectedErr:   resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:          "success",
+			fixture:        finished,
+			expectedPoints: map[int64]int64{1: 5, 2: 3, 3: 1, 4: 0},
+			expectedScored: true,
+			expectedErr:    nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			points := make(map[int64]int64)
+			marked := false
+			fixtures.FixtureDao = &MockFixtureDao{
+				FuncMarkScored: func(id int64, scoredAt time.Time) error {
+					assert.Equal(t, int64(10), id)
+					marked = testCase.markScoredErr == nil
+					return testCase.markScoredErr
+				},
+			}
+			user_predictions.UserPredictionDao = testCase.userPredictionDaoMock
+			if user_predictions.UserPredictionDao == nil {
+				user_predictions.UserPredictionDao = &MockUserPredictionDao{
+					FuncListForScoring: func(fixtureID int64) ([]user_predictions.ScoringRow, error) {
+						return []user_predictions.ScoringRow{
+							{ID: 1, HomeGoals: 2, AwayGoals: 1, PointsExact: 5, PointsGoalDifference: 3, PointsResult: 1},
+							{ID: 2, HomeGoals: 3, AwayGoals: 2, PointsExact: 5, PointsGoalDifference: 3, PointsResult: 1},
+							{ID: 3, HomeGoals: 1, AwayGoals: 0, PointsExact: 5, PointsGoalDifference: 0, PointsResult: 1},
+							{ID: 4, HomeGoals: 1, AwayGoals: 1, PointsExact: 5, PointsGoalDifference: 3, PointsResult: 1},
+						}, nil
+					},
+					FuncUpdatePoints: func(id int64, p int64, scoredAt time.Time) error {
+						points[id] = p
+						return nil
+					},
+				}
+			}
+
+			fixture := *testCase.fixture
+			err := UserPredictionService.ScoreFixture(context.Background(), &fixture)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedPoints != nil {
+				assert.Equal(t, testCase.expectedPoints, points)
+			}
+			// A fixture is only marked scored once every prediction is
+			assert.Equal(t, testCase.expectedScored, marked)
+			assert.Equal(t, testCase.expectedScored, fixture.ScoredAt != nil)
+		})
+	}
+}
+
+func TestScorePrediction(t *testing.T) {
+	rules := user_predictions.ScoringRow{PointsExact: 5, PointsGoalDifference: 3, PointsResult: 1}
+	testCases := []struct {
+		title          string
+		home, away     int64
+		expectedPoints int64
+	}{
+		{title: "exact score", home: 2, away: 1, expectedPoints: 5},
+		{title: "goal difference", home: 3, away: 2, expectedPoints: 3},
+		{title: "result", home: 4, away: 0, expectedPoints: 1},
+		{title: "wrong result", home: 0, away: 1, expectedPoints: 0},
+		{title: "wrong draw", home: 1, away: 1, expectedPoints: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			row := rules
+			row.HomeGoals, row.AwayGoals = testCase.home, testCase.away
+			assert.Equal(t, testCase.expectedPoints, ScorePrediction(row, 2, 1))
+		})
+	}
+}
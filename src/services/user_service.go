@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/domains/users"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+type UserServiceI interface {
+	Register(ctx context.Context, req *users.RegisterUserInput) (*users.UserOutput, resterror.RestErrorI)
+	Find(ctx context.Context, id int64) (*users.UserOutput, resterror.RestErrorI)
+}
+
+type userService struct{}
+
+var UserService UserServiceI = &userService{}
+
+func (s *userService) Register(ctx context.Context, req *users.RegisterUserInput) (*users.UserOutput, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "UserService.Register")
+	defer span.End()
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
+	// Make sure the email is not already in use
+	_, err := users.UserDao.FindByEmail(ctx, email)
+	if err == nil {
+		return nil, resterror.NewConflictError("EMAIL_ALREADY_REGISTERED")
+	}
+	if err != sql.ErrNoRows {
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		zlog.FromContext(ctx).Errorw("UserService Register GenerateFromPassword", "error", err)
+		return nil, resterror.NewStandardInternalServerError()
+	}
+
+	// Registered users can only read data, elevated access is granted through API keys
+	user := users.User{
+		Email:        email,
+		Name:         strings.TrimSpace(req.Name),
+		PasswordHash: string(hash),
+		Role:         auth.RoleReader,
+		CreatedAt:    helpers.GetNow(),
+	}
+	// A concurrent registration of the same email is only caught by the unique key
+	if err := users.UserDao.Create(ctx, &user); err != nil {
+		return nil, resterror.NewDatabaseError(err, "user")
+	}
+
+	return &users.UserOutput{
+		ID:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+	}, nil
+}
+
+func (s *userService) Find(ctx context.Context, id int64) (*users.UserOutput, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "UserService.Find")
+	defer span.End()
+
+	res, err := users.UserDao.FindByID(ctx, id)
+	if err != nil {
+		return nil, resterror.NewDatabaseError(err, "user")
+	}
+	return res, nil
+}
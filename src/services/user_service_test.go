@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/domains/users"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+)
+
+type MockUserDao struct {
+	FuncCreate      func(user *users.User) error
+	FuncFindByID    func(id int64) (*users.UserOutput, error)
+	FuncFindByEmail func(email string) (*users.User, error)
+}
+
+func (m MockUserDao) Create(ctx context.Context, user *users.User) error {
+	return m.FuncCreate(user)
+}
+func (m MockUserDao) FindByID(ctx context.Context, id int64) (*users.UserOutput, error) {
+	return m.FuncFindByID(id)
+}
+func (m MockUserDao) FindByEmail(ctx context.Context, email string) (*users.User, error) {
+	return m.FuncFindByEmail(email)
+}
+
+func TestUserService_Register(t *testing.T) {
+	testCases := []struct {
+		title       string
+		userDaoMock users.UserDaoI
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title: "error email already registered",
+			userDaoMock: &MockUserDao{
+				FuncFindByEmail: func(email string) (*users.User, error) {
+					return &users.User{ID: 1}, nil
+				},
+			},
+			expectedErr: resterror.NewConflictError("EMAIL_ALREADY_REGISTERED"),
+		},
+		{
+			title: "error UserDao.FindByEmail",
+			userDaoMock: &MockUserDao{
+				FuncFindByEmail: func(email string) (*users.User, error) {
+					return nil, errors.New("error FindByEmail")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error UserDao.Create",
+			userDaoMock: &MockUserDao{
+				FuncFindByEmail: func(email string) (*users.User, error) {
+					return nil, sql.ErrNoRows
+				},
+				FuncCreate: func(user *users.User) error {
+					return errors.New("error Create")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error email registered concurrently",
+			userDaoMock: &MockUserDao{
+				FuncFindByEmail: func(email string) (*users.User, error) {
+					return nil, sql.ErrNoRows
+				},
+				FuncCreate: func(user *users.User) error {
+					return dberror.ErrDuplicate
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(dberror.ErrDuplicate, "user"),
+		},
+		{
+			title: "success",
+			userDaoMock: &MockUserDao{
+				FuncFindByEmail: func(email string) (*users.User, error) {
+					return nil, sql.ErrNoRows
+				},
+				FuncCreate: func(user *users.User) error {
+					// The password must never be stored in plain text
+					if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("secret-password")) != nil {
+						return errors.New("invalid hash")
+					}
+					user.ID = 1
+					return nil
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			users.UserDao = testCase.userDaoMock
+
+			res, err := UserService.Register(context.Background(), &users.RegisterUserInput{
+				Email:    " John@Test.com ",
+				Name:     "John",
+				Password: "secret-password",
+			})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedErr != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, int64(1), res.ID)
+			assert.Equal(t, "john@test.com", res.Email)
+			assert.Equal(t, auth.RoleReader, res.Role)
+		})
+	}
+}
+
+func TestUserService_Find(t *testing.T) {
+	testCases := []struct {
+		title       string
+		userDaoMock users.UserDaoI
+		expectedRes *users.UserOutput
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title: "error UserDao.FindByID",
+			userDaoMock: &MockUserDao{
+				FuncFindByID: func(id int64) (*users.UserOutput, error) {
+					return nil, errors.New("error FindByID")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "error user not found",
+			userDaoMock: &MockUserDao{
+				FuncFindByID: func(id int64) (*users.UserOutput, error) {
+					return nil, sql.ErrNoRows
+				},
+			},
+			expectedErr: resterror.NewDatabaseError(sql.ErrNoRows, "user"),
+		},
+		{
+			title: "success",
+			userDaoMock: &MockUserDao{
+				FuncFindByID: func(id int64) (*users.UserOutput, error) {
+					return &users.UserOutput{ID: 1}, nil
+				},
+			},
+			expectedRes: &users.UserOutput{ID: 1},
+			expectedErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			users.UserDao = testCase.userDaoMock
+
+			res, err := UserService.Find(context.Background(), 1)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/odds"
+	"github.com/development-raul/footy-predictor/src/domains/value_bets"
+	"github.com/development-raul/footy-predictor/src/predictor"
+	"github.com/development-raul/footy-predictor/src/tracing"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/development-raul/footy-predictor/src/utils/pagination"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+)
+
+const (
+	// statusNotStarted is the API Sports short status of the fixtures that have not kicked off
+	statusNotStarted = "NS"
+	// valueBetDays is the length of the default kickoff window
+	valueBetDays = 7
+	dateLayout   = "2006-01-02"
+)
+
+type ValueBetServiceI interface {
+	List(ctx context.Context, req *value_bets.ListValueBetInput) (*pagination.PaginatedResponse, resterror.RestErrorI)
+}
+
+type valueBetService struct {
+	settings config.ValueBetsConfig
+}
+
+var ValueBetService ValueBetServiceI = &valueBetService{settings: config.Default().ValueBets}
+
+// List compares the model probabilities of the upcoming fixtures with the latest odds of every bookmaker and
+// returns the selections with the highest edge first. The champion model of a league is fitted once on the
+// fixtures that finished so far, the leagues without enough data are left out
+func (s *valueBetService) List(ctx context.Context, req *value_bets.ListValueBetInput) (*pagination.PaginatedResponse, resterror.RestErrorI) {
+	ctx, span := tracing.Start(ctx, "ValueBetService.List")
+	defer span.End()
+
+	now := helpers.GetNow()
+	minEdge, bankroll, fraction := s.settings.MinEdge, s.settings.Bankroll, s.settings.KellyFraction
+	if req.MinEdge != nil {
+		minEdge = *req.MinEdge
+	}
+	if req.Bankroll != 0 {
+		bankroll = req.Bankroll
+	}
+	if req.KellyFraction != 0 {
+		fraction = req.KellyFraction
+	}
+
+	champion, apiErr := resolveModel(ctx, "")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	upcoming, apiErr := s.upcomingFixtures(ctx, req, now)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	type leagueSeason struct{ leagueID, season int64 }
+	fitted := make(map[leagueSeason]predictor.Model)
+	results := make([]value_bets.ValueBet, 0)
+	for i := range upcoming {
+		fixture := &upcoming[i]
+		key := leagueSeason{fixture.LeagueID, fixture.Season}
+		model, ok := fitted[key]
+		if !ok {
+			var apiErr resterror.RestErrorI
+			model, _, apiErr = fitModel(ctx, champion, fixture.LeagueID, fixture.Season, now)
+			if apiErr != nil && apiErr.Code() != http.StatusUnprocessableEntity {
+				return nil, apiErr
+			}
+			fitted[key] = model
+		}
+		if model == nil {
+			continue
+		}
+
+		rows, err := odds.OddDao.Latest(ctx, &odds.LatestOddInput{FixtureID: fixture.ID, Market: req.Market, BookmakerID: req.BookmakerID})
+		if err != nil && err != sql.ErrNoRows {
+			return nil, resterror.NewStandardInternalServerError()
+		}
+		forecast, _, apiErr := forecastFixture(ctx, champion, model, fixture)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		for _, market := range marketOdds(ctx, fixture.ID, rows, req.Method) {
+			for _, selection := range market.Selections {
+				probability, ok := modelProbability(forecast, market.Market, selection.Selection, market.Line)
+				// The probability is 0 when the market misses a selection
+				if !ok || selection.Probability == 0 {
+					continue
+				}
+				edge := probability - selection.Probability
+				kelly := predictor.Kelly(probability, selection.Price)
+				if edge < minEdge || kelly == 0 {
+					continue
+				}
+				results = append(results, value_bets.ValueBet{
+					FixtureID:         fixture.ID,
+					LeagueID:          fixture.LeagueID,
+					KickoffAt:         fixture.KickoffAt,
+					HomeTeamName:      fixture.HomeTeamName,
+					AwayTeamName:      fixture.AwayTeamName,
+					BookmakerID:       market.BookmakerID,
+					Bookmaker:         market.Bookmaker,
+					Market:            market.Market,
+					Selection:         selection.Selection,
+					Line:              market.Line,
+					Price:             selection.Price,
+					RecordedAt:        market.RecordedAt,
+					Model:             champion.Ref(),
+					ModelProbability:  probability,
+					MarketProbability: selection.Probability,
+					Edge:              edge,
+					ExpectedValue:     probability*selection.Price - 1,
+					Kelly:             kelly,
+					Stake:             math.Round(bankroll*fraction*kelly*100) / 100,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Edge > results[j].Edge
+	})
+	return s.page(results, req.Page, req.PerPage), nil
+}
+
+// upcomingFixtures returns the fixtures of the kickoff window that have not started yet
+func (s *valueBetService) upcomingFixtures(ctx context.Context, req *value_bets.ListValueBetInput, now time.Time) ([]fixtures.Fixture, resterror.RestErrorI) {
+	from, to := req.From, req.To
+	if from == "" {
+		from = now.Format(dateLayout)
+	}
+	if to == "" {
+		start, _ := time.Parse(dateLayout, from)
+		to = start.AddDate(0, 0, valueBetDays).Format(dateLayout)
+	}
+
+	var upcoming []fixtures.Fixture
+	list := fixtures.ListFixtureInput{
+		LeagueID: req.LeagueID,
+		From:     from,
+		To:       to,
+		Page:     1,
+		PerPage:  500,
+	}
+	for {
+		results, total, err := fixtures.FixtureDao.List(ctx, &list)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, resterror.NewStandardInternalServerError()
+		}
+		for _, f := range results {
+			if f.Status == statusNotStarted && f.KickoffAt.After(now) {
+				upcoming = append(upcoming, f)
+			}
+		}
+		if list.Page*list.PerPage >= total {
+			return upcoming, nil
+		}
+		list.Page++
+	}
+}
+
+// page returns the requested page of the value bets, they are computed for every fixture so the whole list
+// is paginated in memory. The input is validated already, the bounds are checked again since they slice the list
+func (s *valueBetService) page(results []value_bets.ValueBet, page int64, perPage int64) *pagination.PaginatedResponse {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = constants.DefaultPerPage
+	}
+	total := int64(len(results))
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+	res := pagination.GeneratePaginatedResponse(results[start:end], page, perPage, total)
+	return &res
+}
+
+// modelProbability returns the probability of a selection from the score forecast. The over_under and
+// asian_handicap lines that can be void are not supported
+func modelProbability(forecast *predictor.Forecast, market string, selection string, line float64) (float64, bool) {
+	switch market {
+	case odds.Market1X2:
+		home, draw, away := forecast.Outcome()
+		switch selection {
+		case odds.SelectionHome:
+			return home, true
+		case odds.SelectionDraw:
+			return draw, true
+		case odds.SelectionAway:
+			return away, true
+		}
+	case odds.MarketBTTS:
+		switch selection {
+		case odds.SelectionYes:
+			return forecast.BothScore(), true
+		case odds.SelectionNo:
+			return 1 - forecast.BothScore(), true
+		}
+	case odds.MarketOverUnder:
+		if !predictor.HalfLine(line) {
+			return 0, false
+		}
+		switch selection {
+		case odds.SelectionOver:
+			return forecast.Over(line), true
+		case odds.SelectionUnder:
+			return 1 - forecast.Over(line), true
+		}
+	case odds.MarketAsianHandicap:
+		if !predictor.HalfLine(line) {
+			return 0, false
+		}
+		switch selection {
+		case odds.SelectionHome:
+			return forecast.HomeCovers(line), true
+		case odds.SelectionAway:
+			return 1 - forecast.HomeCovers(line), true
+		}
+	}
+	return 0, false
+}
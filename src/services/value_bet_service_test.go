@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/domains/fixtures"
+	"github.com/development-raul/footy-predictor/src/domains/injuries"
+	"github.com/development-raul/footy-predictor/src/domains/models"
+	"github.com/development-raul/footy-predictor/src/domains/odds"
+	"github.com/development-raul/footy-predictor/src/domains/value_bets"
+	"github.com/development-raul/footy-predictor/src/predictor"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestValueBetService_List(t *testing.T) {
+	goals := func(v int64) *int64 { return &v }
+	kickoff := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Second)
+	played := time.Now().UTC().AddDate(0, -1, 0)
+	// Team 1 wins every home match, the league 40 has no finished fixture
+	finished := []fixtures.Fixture{
+		{ID: 1, LeagueID: 39, Season: 2021, KickoffAt: played, Status: "FT", HomeTeamID: 1, AwayTeamID: 2, HomeGoals: goals(3), AwayGoals: goals(0)},
+		{ID: 2, LeagueID: 39, Season: 2021, KickoffAt: played, Status: "FT", HomeTeamID: 2, AwayTeamID: 3, HomeGoals: goals(1), AwayGoals: goals(1)},
+		{ID: 3, LeagueID: 39, Season: 2021, KickoffAt: played, Status: "FT", HomeTeamID: 3, AwayTeamID: 1, HomeGoals: goals(0), AwayGoals: goals(1)},
+		{ID: 4, LeagueID: 39, Season: 2021, KickoffAt: played, Status: "FT", HomeTeamID: 1, AwayTeamID: 3, HomeGoals: goals(2), AwayGoals: goals(0)},
+	}
+	upcoming := []fixtures.Fixture{
+		{ID: 10, LeagueID: 39, Season: 2021, KickoffAt: kickoff, Status: "NS", HomeTeamID: 1, HomeTeamName: "Home", AwayTeamID: 3, AwayTeamName: "Away"},
+		{ID: 11, LeagueID: 40, Season: 2021, KickoffAt: kickoff, Status: "NS", HomeTeamID: 5, AwayTeamID: 6},
+		{ID: 12, LeagueID: 39, Season: 2021, KickoffAt: kickoff, Status: "PST", HomeTeamID: 2, AwayTeamID: 3},
+	}
+	fixtureDao := &MockFixtureDao{
+		FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+			if req.From != "" {
+				return upcoming, 3, nil
+			}
+			if req.LeagueID == 39 && req.Season == 2021 {
+				return finished, 4, nil
+			}
+			return nil, 0, nil
+		},
+	}
+	// The home win is priced as an outsider and the under 2.5 line misses its over price
+	oddDao := &MockOddDao{
+		FuncLatest: func(req *odds.LatestOddInput) ([]odds.Odd, error) {
+			if req.FixtureID != 10 {
+				return nil, errors.New("unexpected fixture")
+			}
+			return []odds.Odd{
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.Market1X2, Selection: odds.SelectionHome, Price: 3.2, RecordedAt: played},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.Market1X2, Selection: odds.SelectionDraw, Price: 3.3, RecordedAt: played},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.Market1X2, Selection: odds.SelectionAway, Price: 2.2, RecordedAt: played},
+				{FixtureID: 10, BookmakerID: 8, Bookmaker: "Bet365", Market: odds.MarketOverUnder, Selection: odds.SelectionUnder, Line: 2.5, Price: 5, RecordedAt: played},
+			}, nil
+		},
+	}
+	minEdge := 0.99
+	// With its full weight, the home team missing more than half its eleven is less likely to win
+	weighted := testModelDao()
+	weighted.FuncFindChampion = func() (*models.Model, error) {
+		return &models.Model{ID: 3, Name: "poisson", Version: 3, Hyperparameters: json.RawMessage(`{"availability_weight":1}`), Champion: true}, nil
+	}
+	injuryDao := &MockInjuryDao{
+		FuncListByFixture: func(fixtureID int64) ([]injuries.Unavailable, error) {
+			var res []injuries.Unavailable
+			for player := int64(1); player <= 6; player++ {
+				res = append(res, injuries.Unavailable{Injury: injuries.Injury{FixtureID: fixtureID, PlayerID: player, TeamID: 1, Type: injuries.TypeMissing}, MinutesShare: 1})
+			}
+			return res, nil
+		},
+	}
+
+	testCases := []struct {
+		title          string
+		req            value_bets.ListValueBetInput
+		modelDaoMock   models.ModelDaoI
+		fixtureDaoMock fixtures.FixtureDaoI
+		oddDaoMock     odds.OddDaoI
+		injuryDaoMock  injuries.InjuryDaoI
+		expectedCount  int
+		expectedTotal  int64
+		expectedModel  string
+		// expectedMaxModel bounds the model probability of the bet when set, it is above 0.9 without missing players
+		expectedMaxModel float64
+		expectedErr      resterror.RestErrorI
+	}{
+		{
+			title: "error FixtureDao.List",
+			fixtureDaoMock: &MockFixtureDao{
+				FuncList: func(req *fixtures.ListFixtureInput) ([]fixtures.Fixture, int64, error) {
+					return nil, 0, errors.New("error List")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:          "error OddDao.Latest",
+			fixtureDaoMock: fixtureDao,
+			oddDaoMock: &MockOddDao{
+				FuncLatest: func(req *odds.LatestOddInput) ([]odds.Odd, error) {
+					return nil, errors.New("error Latest")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:          "error InjuryDao.ListByFixture",
+			modelDaoMock:   weighted,
+			fixtureDaoMock: fixtureDao,
+			oddDaoMock:     oddDao,
+			injuryDaoMock: &MockInjuryDao{
+				FuncListByFixture: func(fixtureID int64) ([]injuries.Unavailable, error) {
+					return nil, errors.New("error ListByFixture")
+				},
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title:          "success no bet above the edge",
+			req:            value_bets.ListValueBetInput{MinEdge: &minEdge},
+			fixtureDaoMock: fixtureDao,
+			oddDaoMock:     oddDao,
+			expectedCount:  0,
+			expectedTotal:  0,
+		},
+		{
+			title:          "success",
+			req:            value_bets.ListValueBetInput{Bankroll: 200, KellyFraction: 0.5},
+			fixtureDaoMock: fixtureDao,
+			oddDaoMock:     oddDao,
+			expectedCount:  1,
+			expectedTotal:  1,
+			expectedModel:  "poisson@1",
+		},
+		{
+			title:            "success with the missing players",
+			req:              value_bets.ListValueBetInput{Bankroll: 200, KellyFraction: 0.5},
+			modelDaoMock:     weighted,
+			fixtureDaoMock:   fixtureDao,
+			oddDaoMock:       oddDao,
+			injuryDaoMock:    injuryDao,
+			expectedCount:    1,
+			expectedTotal:    1,
+			expectedModel:    "poisson@3",
+			expectedMaxModel: 0.7,
+		},
+		{
+			title:          "success page after the last",
+			req:            value_bets.ListValueBetInput{Page: 2},
+			fixtureDaoMock: fixtureDao,
+			oddDaoMock:     oddDao,
+			expectedCount:  0,
+			expectedTotal:  1,
+		},
+		{
+			title:          "success negative page and per page",
+			req:            value_bets.ListValueBetInput{Bankroll: 200, KellyFraction: 0.5, Page: -1, PerPage: -5},
+			fixtureDaoMock: fixtureDao,
+			oddDaoMock:     oddDao,
+			expectedCount:  1,
+			expectedTotal:  1,
+			expectedModel:  "poisson@1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			models.ModelDao = testModelDao()
+			if testCase.modelDaoMock != nil {
+				models.ModelDao = testCase.modelDaoMock
+			}
+			fixtures.FixtureDao = testCase.fixtureDaoMock
+			odds.OddDao = testCase.oddDaoMock
+			injuries.InjuryDao = testCase.injuryDaoMock
+
+			res, err := ValueBetService.List(context.Background(), &testCase.req)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedErr != nil {
+				return
+			}
+			assert.Equal(t, testCase.expectedTotal, res.Total)
+			bets := res.Data.([]value_bets.ValueBet)
+			assert.Equal(t, testCase.expectedCount, len(bets))
+			if len(bets) == 0 {
+				return
+			}
+
+			bet := bets[0]
+			assert.Equal(t, int64(10), bet.FixtureID)
+			assert.Equal(t, "Home", bet.HomeTeamName)
+			assert.Equal(t, kickoff, bet.KickoffAt)
+			assert.Equal(t, odds.Market1X2, bet.Market)
+			assert.Equal(t, odds.SelectionHome, bet.Selection)
+			assert.Equal(t, 3.2, bet.Price)
+			assert.Equal(t, testCase.expectedModel, bet.Model)
+			market, _ := predictor.Implied([]float64{3.2, 3.3, 2.2}, predictor.MethodBasic)
+			assert.InDelta(t, market[0], bet.MarketProbability, 1e-9)
+			assert.InDelta(t, bet.ModelProbability-bet.MarketProbability, bet.Edge, 1e-9)
+			assert.GreaterOrEqual(t, bet.Edge, 0.05)
+			assert.InDelta(t, bet.ModelProbability*3.2-1, bet.ExpectedValue, 1e-9)
+			assert.InDelta(t, predictor.Kelly(bet.ModelProbability, 3.2), bet.Kelly, 1e-9)
+			assert.Equal(t, math.Round(200*0.5*bet.Kelly*100)/100, bet.Stake)
+			if testCase.expectedMaxModel > 0 {
+				assert.Less(t, bet.ModelProbability, testCase.expectedMaxModel)
+			} else {
+				assert.Greater(t, bet.ModelProbability, 0.9)
+			}
+		})
+	}
+}
+
+func TestModelProbability(t *testing.T) {
+	forecast := predictor.NewForecast(1.5, 1.1)
+	home, draw, away := forecast.Outcome()
+	testCases := []struct {
+		market      string
+		selection   string
+		line        float64
+		expectedRes float64
+		expectedOk  bool
+	}{
+		{market: odds.Market1X2, selection: odds.SelectionHome, expectedRes: home, expectedOk: true},
+		{market: odds.Market1X2, selection: odds.SelectionDraw, expectedRes: draw, expectedOk: true},
+		{market: odds.Market1X2, selection: odds.SelectionAway, expectedRes: away, expectedOk: true},
+		{market: odds.MarketBTTS, selection: odds.SelectionNo, expectedRes: 1 - forecast.BothScore(), expectedOk: true},
+		{market: odds.MarketOverUnder, selection: odds.SelectionUnder, line: 2.5, expectedRes: 1 - forecast.Over(2.5), expectedOk: true},
+		{market: odds.MarketOverUnder, selection: odds.SelectionOver, line: 2.25},
+		{market: odds.MarketAsianHandicap, selection: odds.SelectionAway, line: -0.5, expectedRes: draw + away, expectedOk: true},
+		{market: odds.MarketAsianHandicap, selection: odds.SelectionHome, line: -1},
+		{market: odds.Market1X2, selection: odds.SelectionYes},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.market+" "+testCase.selection, func(t *testing.T) {
+			res, ok := modelProbability(forecast, testCase.market, testCase.selection, testCase.line)
+
+			assert.Equal(t, testCase.expectedOk, ok)
+			assert.InDelta(t, testCase.expectedRes, res, 1e-9)
+		})
+	}
+}
@@ -1,22 +1,28 @@
 package swaggertypes
 
+import "github.com/development-raul/footy-predictor/src/utils/bulk"
+
 type StandardValidationError struct {
 	Error struct {
 		FieldName []string `json:"field_name" example:"example error"`
 	} `json:"error"`
-	Code int `json:"code" example:"400"`
+	ErrorCode string `json:"error_code" example:"VALIDATION_FAILED"`
+	Code      int    `json:"code" example:"400"`
 }
 type StandardInternalServerError struct {
-	Error string `json:"error" example:"Server Error"`
-	Code  int    `json:"code" example:"500"`
+	Error     string `json:"error" example:"Server Error"`
+	ErrorCode string `json:"error_code" example:"INTERNAL_SERVER_ERROR"`
+	Code      int    `json:"code" example:"500"`
 }
 type StandardBadRequestError struct {
-	Error string `json:"error" example:"Bad Request"`
-	Code  int    `json:"code" example:"400"`
+	Error     string `json:"error" example:"Bad Request"`
+	ErrorCode string `json:"error_code" example:"BAD_REQUEST"`
+	Code      int    `json:"code" example:"400"`
 }
 type StandardNotFoundError struct {
-	Error string `json:"error" example:"Not found"`
-	Code  int    `json:"code" example:"404"`
+	Error     string `json:"error" example:"Not found"`
+	ErrorCode string `json:"error_code" example:"NOT_FOUND"`
+	Code      int    `json:"code" example:"404"`
 }
 
 type NoErrorString struct {
@@ -33,8 +39,36 @@ type NoErrorI struct {
 	Code int `json:"code" example:"200"`
 }
 type StandardUnauthorisedError struct {
-	Error string `json:"error" example:"INVALID_USER_AUTHENTICATION"`
-	Code  int    `json:"code" example:"401"`
+	Error     string `json:"error" example:"INVALID_USER_AUTHENTICATION"`
+	ErrorCode string `json:"error_code" example:"INVALID_USER_AUTHENTICATION"`
+	Code      int    `json:"code" example:"401"`
+}
+type StandardForbiddenError struct {
+	Error     string `json:"error" example:"INSUFFICIENT_PERMISSIONS"`
+	ErrorCode string `json:"error_code" example:"INSUFFICIENT_PERMISSIONS"`
+	Code      int    `json:"code" example:"403"`
+}
+
+// DatabaseError is returned when the record does not exist (404), conflicts with another record (409), was
+// changed since the client read it (412) or has a value or reference the database rejected (422), error_code is
+// e.g. COUNTRY_NOT_FOUND, COUNTRY_ALREADY_EXISTS or COUNTRY_VERSION_MISMATCH
+type DatabaseError struct {
+	Error     string `json:"error" example:"The country does not exist"`
+	ErrorCode string `json:"error_code" example:"COUNTRY_NOT_FOUND"`
+	Code      int    `json:"code" example:"404"`
+}
+
+// StandardPreconditionRequiredError is returned when a conditional update or delete is sent without If-Match
+type StandardPreconditionRequiredError struct {
+	Error     string `json:"error" example:"IF_MATCH_REQUIRED"`
+	ErrorCode string `json:"error_code" example:"IF_MATCH_REQUIRED"`
+	Code      int    `json:"code" example:"428"`
+}
+
+type StandardConflictError struct {
+	Error     string `json:"error" example:"Conflict"`
+	ErrorCode string `json:"error_code" example:"CONFLICT"`
+	Code      int    `json:"code" example:"409"`
 }
 
 type PaginatedData struct {
@@ -46,6 +80,16 @@ type PaginatedData struct {
 		PerPage     int64       `json:"per_page,string"`
 		To          int64       `json:"to"`
 		Total       int64       `json:"total"`
+		NextCursor  string      `json:"next_cursor,omitempty"`
+		PrevCursor  string      `json:"prev_cursor,omitempty"`
 	} `json:"data"`
 	Code int `json:"code"`
 }
+
+// BulkError is returned when an atomic bulk request is rejected, 400 for invalid items and 422 when an item
+// failed and the transaction was rolled back
+type BulkError struct {
+	Error     bulk.Response `json:"error"`
+	ErrorCode string        `json:"error_code" example:"UNPROCESSABLE_ENTITY"`
+	Code      int           `json:"code" example:"422"`
+}
@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/development-raul/footy-predictor/src/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/development-raul/footy-predictor"
+
+// Exporters supported by Configure
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+var provider *sdktrace.TracerProvider
+
+func init() {
+	// Trace context is propagated even when no exporter is configured, so upstream traces are not broken
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// Configure installs the tracer provider exporting the spans to stdout or to an OTLP HTTP collector. The stdout
+// exporter writes to stderr so the spans do not end up in the output of the commands. With the none exporter spans
+// are not recorded
+func Configure(cfg config.TracingConfig) error {
+	if cfg.Exporter == ExporterNone || cfg.Exporter == "" {
+		return nil
+	}
+	exporter, err := newExporter(cfg, os.Stderr)
+	if err != nil {
+		return err
+	}
+	Use(sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(cfg.ServiceName))),
+	))
+	return nil
+}
+
+func newExporter(cfg config.TracingConfig, w io.Writer) (sdktrace.SpanExporter, error) {
+	if cfg.Exporter == ExporterStdout {
+		return stdouttrace.New(stdouttrace.WithWriter(w), stdouttrace.WithPrettyPrint())
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	// The client connects lazily, an unreachable collector does not stop the application
+	return otlptracehttp.New(context.Background(), opts...)
+}
+
+// Use sets the tracer provider used by Start, tests use it with an in memory exporter
+func Use(p *sdktrace.TracerProvider) {
+	provider = p
+	otel.SetTracerProvider(p)
+}
+
+// Shutdown flushes the pending spans, it must be called before the process exits
+func Shutdown(ctx context.Context) error {
+	if provider == nil {
+		return nil
+	}
+	return provider.Shutdown(ctx)
+}
+
+// Start starts a span that is a child of the span in ctx, if any
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// Attributes is a shortcut for the start option setting the span attributes
+func Attributes(attrs ...attribute.KeyValue) trace.SpanStartOption {
+	return trace.WithAttributes(attrs...)
+}
+
+// Fail records the error on the span and marks it as failed
+func Fail(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+)
+
+func TestConfigure_None(t *testing.T) {
+	provider = nil
+
+	assert.Nil(t, Configure(config.TracingConfig{Exporter: ExporterNone}))
+	assert.Nil(t, provider)
+	assert.Nil(t, Shutdown(context.Background()))
+}
+
+func TestNewExporter_Stdout(t *testing.T) {
+	var out bytes.Buffer
+	exporter, err := newExporter(config.TracingConfig{Exporter: ExporterStdout}, &out)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	Use(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	_, span := Start(context.Background(), "FixtureDao.Find")
+	span.End()
+	assert.Nil(t, Shutdown(context.Background()))
+
+	assert.Contains(t, out.String(), `"Name": "FixtureDao.Find"`)
+}
+
+func TestStart_ChildAndFail(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	Use(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx, parent := Start(context.Background(), "FixtureService.Find")
+	_, child := Start(ctx, "FixtureDao.Find")
+	Fail(child, errors.New("error Find"))
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+	assert.Equal(t, "FixtureDao.Find", spans[0].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "error Find", spans[0].Status().Description)
+	assert.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/config"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Define the roles a client can have, from the least to the most privileged
+const (
+	RoleReader = "reader"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+
+	apiKeyPrefix    = "fp_"
+	userSubject     = "user:"
+	apiKeyBytes     = 32
+	tokenIssuer     = "footy-predictor"
+	defaultTokenTTL = time.Hour
+)
+
+var roleRanks = map[string]int{
+	RoleReader: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Tokens signs and validates the JWTs with the secret and lifetime of the authentication settings
+type Tokens struct {
+	settings config.AuthConfig
+}
+
+// NewTokens returns the tokens of the authentication settings
+func NewTokens(cfg config.AuthConfig) *Tokens {
+	return &Tokens{settings: cfg}
+}
+
+// BootstrapKey returns the optional static admin key, empty when it is disabled
+func (t *Tokens) BootstrapKey() string {
+	return t.settings.BootstrapKey
+}
+
+type Claims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// ValidRole checks if the provided role is one of the supported roles
+func ValidRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// HasRole checks if role is at least as privileged as the required role
+func HasRole(role string, required string) bool {
+	rank, ok := roleRanks[role]
+	if !ok {
+		return false
+	}
+	return rank >= roleRanks[required]
+}
+
+// GenerateApiKey creates a new random API key and returns it together with its hash and display prefix
+func GenerateApiKey() (key string, hash string, prefix string, err error) {
+	b := make([]byte, apiKeyBytes)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	key = apiKeyPrefix + hex.EncodeToString(b)
+	return key, HashApiKey(key), key[:len(apiKeyPrefix)+8], nil
+}
+
+// HashApiKey returns the SHA-256 hex digest of the key; API keys are high entropy so no salt is required
+func HashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateToken creates a signed JWT for the given subject and role
+func (t *Tokens) GenerateToken(subject string, role string) (string, time.Time, error) {
+	secret, err := t.secret()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(t.ttl())
+	claims := Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// ParseToken validates the signature and expiry of a JWT and returns its claims
+func (t *Tokens) ParseToken(token string) (*Claims, error) {
+	secret, err := t.secret()
+	if err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	parsed, err := jwt.ParseWithClaims(token, &claims, func(j *jwt.Token) (interface{}, error) {
+		if _, ok := j.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", j.Header["alg"])
+		}
+		return secret, nil
+	})
+	if err != nil || !parsed.Valid || !claims.VerifyIssuer(tokenIssuer, true) || !ValidRole(claims.Role) {
+		return nil, ErrInvalidToken
+	}
+	return &claims, nil
+}
+
+// IsJWT checks if the provided credential looks like a JWT rather than an API key
+func IsJWT(credential string) bool {
+	return strings.Count(credential, ".") == 2 && !strings.HasPrefix(credential, apiKeyPrefix)
+}
+
+// UserSubject returns the token subject used for registered users
+func UserSubject(id int64) string {
+	return userSubject + strconv.FormatInt(id, 10)
+}
+
+// UserIDFromSubject extracts the user id from a token subject; it returns false for non user subjects
+func UserIDFromSubject(subject string) (int64, bool) {
+	if !strings.HasPrefix(subject, userSubject) {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(subject, userSubject), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+func (t *Tokens) secret() ([]byte, error) {
+	if t.settings.JWTSecret == "" {
+		return nil, errors.New("JWT secret is not configured")
+	}
+	return []byte(t.settings.JWTSecret), nil
+}
+
+func (t *Tokens) ttl() time.Duration {
+	if t.settings.JWTTTL <= 0 {
+		return defaultTokenTTL
+	}
+	return t.settings.JWTTTL
+}
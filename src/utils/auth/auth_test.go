@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestHasRole(t *testing.T) {
+	assert.True(t, HasRole(RoleAdmin, RoleEditor))
+	assert.True(t, HasRole(RoleEditor, RoleEditor))
+	assert.False(t, HasRole(RoleReader, RoleEditor))
+	assert.False(t, HasRole("owner", RoleReader))
+}
+
+func TestGenerateApiKey(t *testing.T) {
+	key, hash, prefix, err := GenerateApiKey()
+
+	assert.Nil(t, err)
+	assert.Equal(t, apiKeyPrefix, key[:len(apiKeyPrefix)])
+	assert.Equal(t, len(apiKeyPrefix)+apiKeyBytes*2, len(key))
+	assert.Equal(t, key[:len(prefix)], prefix)
+	assert.Equal(t, HashApiKey(key), hash)
+	assert.False(t, IsJWT(key))
+}
+
+func TestGenerateToken(t *testing.T) {
+	_, _, err := NewTokens(config.AuthConfig{JWTSecret: ""}).GenerateToken("api_key:1", RoleReader)
+	assert.NotNil(t, err)
+
+	tokens := NewTokens(config.AuthConfig{JWTSecret: "test-secret"})
+	token, expiresAt, err := tokens.GenerateToken("api_key:1", RoleReader)
+	assert.Nil(t, err)
+	assert.True(t, IsJWT(token))
+	assert.WithinDuration(t, time.Now().Add(defaultTokenTTL), expiresAt, time.Minute)
+
+	claims, err := tokens.ParseToken(token)
+	assert.Nil(t, err)
+	assert.Equal(t, "api_key:1", claims.Subject)
+	assert.Equal(t, RoleReader, claims.Role)
+}
+
+func TestParseToken(t *testing.T) {
+	tokens := NewTokens(config.AuthConfig{JWTSecret: "test-secret"})
+
+	sign := func(claims Claims, secret string) string {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		return token
+	}
+	valid := jwt.RegisteredClaims{
+		Issuer:    tokenIssuer,
+		Subject:   "api_key:1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	otherIssuer := valid
+	otherIssuer.Issuer = "someone-else"
+
+	testCases := []struct {
+		title       string
+		token       string
+		expectedErr error
+	}{
+		{title: "error malformed", token: "a.b.c", expectedErr: ErrInvalidToken},
+		{title: "error wrong secret", token: sign(Claims{Role: RoleAdmin, RegisteredClaims: valid}, "other"), expectedErr: ErrInvalidToken},
+		{title: "error expired", token: sign(Claims{Role: RoleAdmin, RegisteredClaims: expired}, "test-secret"), expectedErr: ErrInvalidToken},
+		{title: "error issuer", token: sign(Claims{Role: RoleAdmin, RegisteredClaims: otherIssuer}, "test-secret"), expectedErr: ErrInvalidToken},
+		{title: "error role", token: sign(Claims{Role: "owner", RegisteredClaims: valid}, "test-secret"), expectedErr: ErrInvalidToken},
+		{title: "success", token: sign(Claims{Role: RoleAdmin, RegisteredClaims: valid}, "test-secret"), expectedErr: nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			_, err := tokens.ParseToken(testCase.token)
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestUserIDFromSubject(t *testing.T) {
+	assert.Equal(t, "user:7", UserSubject(7))
+
+	testCases := []struct {
+		title      string
+		subject    string
+		expectedID int64
+		expectedOk bool
+	}{
+		{title: "api key subject", subject: "api_key:7", expectedOk: false},
+		{title: "bootstrap subject", subject: "bootstrap", expectedOk: false},
+		{title: "invalid id", subject: "user:abc", expectedOk: false},
+		{title: "success", subject: UserSubject(7), expectedID: 7, expectedOk: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			id, ok := UserIDFromSubject(testCase.subject)
+			assert.Equal(t, testCase.expectedID, id)
+			assert.Equal(t, testCase.expectedOk, ok)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Package bulk applies a list of items in a single transaction and reports the outcome of every item.
+//
+// Every item is validated with utils.ValidateStruct before the transaction starts. In atomic mode, the default,
+// one invalid or failing item rolls back the whole list. In best_effort mode each item runs behind a savepoint, a
+// failing item is rolled back to it and the remaining items are still applied.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/development-raul/footy-predictor/src/zlog"
+	"net/http"
+	"reflect"
+)
+
+// Modes
+const (
+	ModeAtomic     = "atomic"
+	ModeBestEffort = "best_effort"
+)
+
+// MaxItems is the maximum number of items of a bulk request
+const MaxItems = 500
+
+// Statuses of an item
+const (
+	StatusCreated = "created"
+	StatusUpdated = "updated"
+	StatusDeleted = "deleted"
+	// StatusUpserted items were created or updated by an import
+	StatusUpserted = "upserted"
+	// StatusInvalid items failed validation and were not applied
+	StatusInvalid = "invalid"
+	// StatusFailed items were rejected by the database
+	StatusFailed = "failed"
+	// StatusSkipped items were valid but not applied, or rolled back, because another item failed in atomic mode
+	StatusSkipped = "skipped"
+)
+
+// Errors reported for the items rejected by the database, Apply returns sql.ErrNoRows when the record of the
+// item does not exist and the typed errors of the dberror package for the other known failures
+const (
+	ErrorFailed           = "The item could not be saved"
+	ErrorNotFound         = "The id does not exist"
+	ErrorDuplicate        = "The item already exists"
+	ErrorReferenced       = "The item is used by other records"
+	ErrorInvalidReference = "The item references a record that does not exist"
+	ErrorInvalid          = "The item has a value that cannot be saved"
+	ErrorVersionMismatch  = "The record is not at this version anymore"
+)
+
+const (
+	querySavepoint         = `SAVEPOINT bulk_item`
+	queryRollbackSavepoint = `ROLLBACK TO SAVEPOINT bulk_item`
+)
+
+// errAborted rolls back the transaction of an atomic run
+var errAborted = errors.New("bulk run aborted")
+
+// DeleteItem identifies a record to delete
+type DeleteItem struct {
+	ID int64 `json:"id" validate:"required"`
+}
+
+// DeleteInput is the request body of the bulk delete endpoints
+type DeleteInput struct {
+	Mode  string       `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+	Items []DeleteItem `json:"items" validate:"required,min=1,max=500"`
+}
+
+// Result is the outcome of the item at Index in the request, ID is the id of the created, updated or deleted record
+type Result struct {
+	Index  int                 `json:"index"`
+	Status string              `json:"status"`
+	ID     int64               `json:"id,omitempty"`
+	Errors map[string][]string `json:"errors,omitempty"`
+}
+
+// Response reports the outcome of every item, Applied counts the items saved once the transaction committed
+type Response struct {
+	Mode    string   `json:"mode"`
+	Applied int      `json:"applied"`
+	Failed  int      `json:"failed"`
+	Results []Result `json:"results"`
+}
+
+// StatusCode is 200 when every item was applied and 207 when only some of them were
+func (r *Response) StatusCode() int {
+	if r.Failed > 0 {
+		return http.StatusMultiStatus
+	}
+	return http.StatusOK
+}
+
+// Apply saves the item at index i and returns the id of its record
+type Apply func(ctx context.Context, i int) (int64, error)
+
+// Run validates the items, a slice of structs, and applies them in a single transaction. status is reported for
+// the applied items. The error is a 400 when an atomic run has invalid items, a 422 when an item failed and the
+// atomic run was rolled back, the response is then its error
+func Run(ctx context.Context, mode string, status string, items interface{}, apply Apply) (*Response, resterror.RestErrorI) {
+	if mode == "" {
+		mode = ModeAtomic
+	}
+	v := reflect.ValueOf(items)
+	res := &Response{Mode: mode, Results: make([]Result, v.Len())}
+	for i := range res.Results {
+		res.Results[i] = Result{Index: i}
+		if errs := utils.ValidateStruct(v.Index(i).Interface()); errs != nil {
+			res.Results[i].Status = StatusInvalid
+			res.Results[i].Errors = errs
+			res.Failed++
+		}
+	}
+	if mode == ModeAtomic && res.Failed > 0 {
+		res.skipValid()
+		return nil, resterror.NewValidationError(res)
+	}
+
+	err := footy_db.Transaction(ctx, func(ctx context.Context) error {
+		for i := range res.Results {
+			result := &res.Results[i]
+			if result.Status == StatusInvalid {
+				continue
+			}
+			if mode == ModeBestEffort {
+				if _, err := footy_db.DB(ctx).ExecContext(ctx, querySavepoint); err != nil {
+					return err
+				}
+			}
+			id, err := apply(ctx, i)
+			if err != nil {
+				result.Status = StatusFailed
+				result.Errors = itemErrors(err)
+				res.Failed++
+				if mode == ModeAtomic {
+					return errAborted
+				}
+				if _, err := footy_db.DB(ctx).ExecContext(ctx, queryRollbackSavepoint); err != nil {
+					return err
+				}
+				continue
+			}
+			result.Status = status
+			result.ID = id
+		}
+		return nil
+	})
+	if err == errAborted {
+		res.skipValid()
+		return nil, resterror.NewCustomError(res, http.StatusUnprocessableEntity)
+	}
+	if err != nil {
+		zlog.FromContext(ctx).Errorw("bulk Run Transaction", "error", err)
+		return nil, resterror.NewStandardInternalServerError()
+	}
+	res.Applied = len(res.Results) - res.Failed
+	return res, nil
+}
+
+// skipValid marks the items that did not fail as skipped, they were not applied or were rolled back
+func (r *Response) skipValid() {
+	for i := range r.Results {
+		if r.Results[i].Status != StatusInvalid && r.Results[i].Status != StatusFailed {
+			r.Results[i].Status = StatusSkipped
+			r.Results[i].ID = 0
+		}
+	}
+}
+
+// itemErrors describes the error returned by Apply
+func itemErrors(err error) map[string][]string {
+	switch {
+	case errors.Is(err, dberror.ErrNotFound):
+		return map[string][]string{"id": {ErrorNotFound}}
+	case errors.Is(err, dberror.ErrDuplicate):
+		return map[string][]string{"item": {ErrorDuplicate}}
+	case errors.Is(err, dberror.ErrReferenced):
+		return map[string][]string{"item": {ErrorReferenced}}
+	case errors.Is(err, dberror.ErrInvalidReference):
+		return map[string][]string{"item": {ErrorInvalidReference}}
+	case errors.Is(err, dberror.ErrInvalid):
+		return map[string][]string{"item": {ErrorInvalid}}
+	case errors.Is(err, dberror.ErrVersionMismatch):
+		return map[string][]string{"version": {ErrorVersionMismatch}}
+	default:
+		return map[string][]string{"item": {ErrorFailed}}
+	}
+}
@@ -0,0 +1,163 @@
+package bulk
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+type testItem struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func TestRun(t *testing.T) {
+	items := []testItem{{Name: "a"}, {}, {Name: "fail"}, {Name: "missing"}, {Name: "b"}}
+	apply := func(ctx context.Context, i int) (int64, error) {
+		switch items[i].Name {
+		case "fail":
+			return 0, errors.New("error Apply")
+		case "missing":
+			return 0, sql.ErrNoRows
+		}
+		return int64(i + 10), nil
+	}
+	invalid := Result{Index: 1, Status: StatusInvalid, Errors: map[string][]string{"name": {"The name field is required."}}}
+	failed := Result{Index: 2, Status: StatusFailed, Errors: map[string][]string{"item": {ErrorFailed}}}
+	missing := Result{Index: 3, Status: StatusFailed, Errors: map[string][]string{"id": {ErrorNotFound}}}
+
+	testCases := []struct {
+		title       string
+		mode        string
+		items       []testItem
+		funcMock    func(sqlmock.Sqlmock)
+		expectedRes *Response
+		expectedErr resterror.RestErrorI
+	}{
+		{
+			title:    "error atomic invalid items",
+			items:    items,
+			funcMock: func(m sqlmock.Sqlmock) {},
+			expectedErr: resterror.NewValidationError(&Response{
+				Mode:   ModeAtomic,
+				Failed: 1,
+				Results: []Result{
+					{Index: 0, Status: StatusSkipped},
+					invalid,
+					{Index: 2, Status: StatusSkipped},
+					{Index: 3, Status: StatusSkipped},
+					{Index: 4, Status: StatusSkipped},
+				},
+			}),
+		},
+		{
+			title: "error atomic item failed rolls back",
+			mode:  ModeAtomic,
+			items: []testItem{{Name: "a"}, {Name: "fail"}, {Name: "b"}},
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectRollback()
+			},
+			expectedErr: resterror.NewCustomError(&Response{
+				Mode:   ModeAtomic,
+				Failed: 1,
+				Results: []Result{
+					{Index: 0, Status: StatusSkipped},
+					{Index: 1, Status: StatusFailed, Errors: map[string][]string{"item": {ErrorFailed}}},
+					{Index: 2, Status: StatusSkipped},
+				},
+			}, http.StatusUnprocessableEntity),
+		},
+		{
+			title: "error Begin",
+			items: []testItem{{Name: "a"}},
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin().WillReturnError(errors.New("error Begin"))
+			},
+			expectedErr: resterror.NewStandardInternalServerError(),
+		},
+		{
+			title: "success best effort",
+			mode:  ModeBestEffort,
+			items: items,
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("ROLLBACK TO SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("ROLLBACK TO SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+			},
+			expectedRes: &Response{
+				Mode:    ModeBestEffort,
+				Applied: 2,
+				Failed:  3,
+				Results: []Result{
+					{Index: 0, Status: StatusCreated, ID: 10},
+					invalid,
+					failed,
+					missing,
+					{Index: 4, Status: StatusCreated, ID: 14},
+				},
+			},
+		},
+		{
+			title: "success atomic",
+			items: []testItem{{Name: "a"}, {Name: "b"}},
+			funcMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectCommit()
+			},
+			expectedRes: &Response{
+				Mode:    ModeAtomic,
+				Applied: 2,
+				Results: []Result{
+					{Index: 0, Status: StatusCreated, ID: 10},
+					{Index: 1, Status: StatusCreated, ID: 11},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			footy_db.Client = sqlx.NewDb(db, "sqlmock")
+			testCase.funcMock(mock)
+			items = testCase.items
+
+			res, apiErr := Run(context.Background(), testCase.mode, StatusCreated, testCase.items, apply)
+
+			assert.Equal(t, testCase.expectedRes, res)
+			assert.Equal(t, testCase.expectedErr, apiErr)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestResponse_StatusCode(t *testing.T) {
+	assert.Equal(t, http.StatusOK, (&Response{Applied: 2}).StatusCode())
+	assert.Equal(t, http.StatusMultiStatus, (&Response{Applied: 1, Failed: 1}).StatusCode())
+}
+
+func TestItemErrors(t *testing.T) {
+	assert.Equal(t, map[string][]string{"id": {ErrorNotFound}}, itemErrors(sql.ErrNoRows))
+	assert.Equal(t, map[string][]string{"item": {ErrorDuplicate}}, itemErrors(dberror.Wrap(&mysql.MySQLError{Number: 1062})))
+	assert.Equal(t, map[string][]string{"item": {ErrorInvalidReference}}, itemErrors(dberror.Wrap(&mysql.MySQLError{Number: 1452})))
+	assert.Equal(t, map[string][]string{"version": {ErrorVersionMismatch}}, itemErrors(dberror.ErrVersionMismatch))
+	assert.Equal(t, map[string][]string{"item": {ErrorFailed}}, itemErrors(errors.New("error Apply")))
+}
@@ -5,4 +5,11 @@ const (
 	ErrorInvalidRequestBody = "Invalid request body."
 	ServerError             = "Something went wrong. Please try again later."
 	DefaultPerPage          = 20
+
+	// Gin context keys set by the authentication middleware
+	ContextKeyAuthSubject = "auth_subject"
+	ContextKeyAuthRole    = "auth_role"
+	// Gin context key and header of the request correlation ID
+	ContextKeyRequestID = "request_id"
+	HeaderRequestID     = "X-Request-ID"
 )
@@ -0,0 +1,85 @@
+// Package dberror classifies the errors returned by the database so the services can tell a missing record, a
+// duplicate or a foreign key violation apart without knowing the MySQL error codes.
+//
+// The DAOs wrap their errors with Wrap, the services compare them with errors.Is and resterror.NewDatabaseError
+// maps them to a response.
+package dberror
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+)
+
+// Kinds of the database errors. ErrNotFound is sql.ErrNoRows so the existing comparisons keep working
+var (
+	ErrNotFound = sql.ErrNoRows
+	// ErrDuplicate is a unique key violation, e.g. a country name that already exists
+	ErrDuplicate = errors.New("duplicate record")
+	// ErrReferenced is returned when deleting or updating a record other records still reference
+	ErrReferenced = errors.New("record is referenced")
+	// ErrInvalidReference is returned when a record references a record that does not exist
+	ErrInvalidReference = errors.New("referenced record does not exist")
+	// ErrInvalid is a value rejected by the column, e.g. too long or out of range
+	ErrInvalid = errors.New("invalid value")
+	// ErrVersionMismatch is returned by the DAOs when a conditional update or delete finds the record at another
+	// version than the one the client read, i.e. somebody else changed it in between
+	ErrVersionMismatch = errors.New("record version mismatch")
+)
+
+// MySQL error numbers, see https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+const (
+	mysqlDuplicateEntry     = 1062
+	mysqlRowIsReferenced    = 1451
+	mysqlRowIsReferencedOld = 1217
+	mysqlNoReferencedRow    = 1452
+	mysqlNoReferencedRowOld = 1216
+	mysqlBadNull            = 1048
+	mysqlOutOfRange         = 1264
+	mysqlDataTruncated      = 1265
+	mysqlIncorrectValue     = 1366
+	mysqlDataTooLong        = 1406
+)
+
+var kinds = map[uint16]error{
+	mysqlDuplicateEntry:     ErrDuplicate,
+	mysqlRowIsReferenced:    ErrReferenced,
+	mysqlRowIsReferencedOld: ErrReferenced,
+	mysqlNoReferencedRow:    ErrInvalidReference,
+	mysqlNoReferencedRowOld: ErrInvalidReference,
+	mysqlBadNull:            ErrInvalid,
+	mysqlOutOfRange:         ErrInvalid,
+	mysqlDataTruncated:      ErrInvalid,
+	mysqlIncorrectValue:     ErrInvalid,
+	mysqlDataTooLong:        ErrInvalid,
+}
+
+// Error is a database error of a known kind, errors.Is matches it with its kind and with the original error
+type Error struct {
+	Kind error
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Wrap returns the typed error of err, sql.ErrNoRows and the errors of unknown kinds are returned unchanged
+func Wrap(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return err
+	}
+	if kind, ok := kinds[mysqlErr.Number]; ok {
+		return &Error{Kind: kind, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package dberror
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	testCases := []struct {
+		title        string
+		err          error
+		expectedKind error
+	}{
+		{title: "nil", err: nil, expectedKind: nil},
+		{title: "not found", err: sql.ErrNoRows, expectedKind: ErrNotFound},
+		{title: "duplicate", err: &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'Spain' for key 'countries_name_unique'"}, expectedKind: ErrDuplicate},
+		{title: "referenced", err: &mysql.MySQLError{Number: 1451}, expectedKind: ErrReferenced},
+		{title: "invalid reference", err: &mysql.MySQLError{Number: 1452}, expectedKind: ErrInvalidReference},
+		{title: "invalid", err: &mysql.MySQLError{Number: 1406, Message: "Data too long for column 'code' at row 1"}, expectedKind: ErrInvalid},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			err := Wrap(testCase.err)
+
+			if testCase.expectedKind == nil {
+				assert.Nil(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, testCase.expectedKind))
+			assert.True(t, errors.Is(err, testCase.err))
+			assert.Equal(t, testCase.err.Error(), err.Error())
+		})
+	}
+
+	unknown := &mysql.MySQLError{Number: 1205}
+	assert.Equal(t, error(unknown), Wrap(unknown))
+	assert.False(t, errors.Is(Wrap(unknown), ErrDuplicate))
+}
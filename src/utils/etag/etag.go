@@ -0,0 +1,54 @@
+// Package etag formats and compares the entity tags of versioned records.
+//
+// The ETag of a record is its version in quotes, e.g. "3". The version is bumped by every update, so a client
+// sends the ETag it read back in If-Match to make sure nobody changed the record in between, and in If-None-Match
+// to skip downloading a record it already has.
+package etag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Any is the version of the If-Match wildcard *, it matches whatever version the record has
+const Any int64 = 0
+
+const weakPrefix = "W/"
+
+// Format returns the ETag of a record version
+func Format(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// Parse returns the version of a strong ETag, false when the tag is weak or was not generated by Format.
+// The wildcard * parses as Any
+func Parse(tag string) (int64, bool) {
+	tag = strings.TrimSpace(tag)
+	if tag == "*" {
+		return Any, true
+	}
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// NoneMatch tells if an If-None-Match header lists the ETag of version. Weak tags are compared as strong ones
+// as RFC 7232 requires for If-None-Match
+func NoneMatch(header string, version int64) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), weakPrefix)
+		if tag == "*" {
+			return true
+		}
+		if v, ok := Parse(tag); ok && v == version {
+			return true
+		}
+	}
+	return false
+}
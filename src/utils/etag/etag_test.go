@@ -0,0 +1,56 @@
+package etag
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, `"3"`, Format(3))
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		title           string
+		tag             string
+		expectedVersion int64
+		expectedOk      bool
+	}{
+		{title: "strong", tag: `"3"`, expectedVersion: 3, expectedOk: true},
+		{title: "spaces", tag: ` "12" `, expectedVersion: 12, expectedOk: true},
+		{title: "wildcard", tag: "*", expectedVersion: Any, expectedOk: true},
+		{title: "weak", tag: `W/"3"`},
+		{title: "not quoted", tag: "3"},
+		{title: "not a version", tag: `"abc"`},
+		{title: "zero", tag: `"0"`},
+		{title: "empty", tag: ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			version, ok := Parse(testCase.tag)
+
+			assert.Equal(t, testCase.expectedOk, ok)
+			assert.Equal(t, testCase.expectedVersion, version)
+		})
+	}
+}
+
+func TestNoneMatch(t *testing.T) {
+	testCases := []struct {
+		title       string
+		header      string
+		expectedRes bool
+	}{
+		{title: "same version", header: `"3"`, expectedRes: true},
+		{title: "weak", header: `W/"3"`, expectedRes: true},
+		{title: "list", header: `"1", "3"`, expectedRes: true},
+		{title: "wildcard", header: "*", expectedRes: true},
+		{title: "other version", header: `"2"`},
+		{title: "empty", header: ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedRes, NoneMatch(testCase.header, 3))
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Package export streams the records of a list as CSV, JSON or NDJSON.
+//
+// The records are read one page at a time with the same filter.Query as the list endpoint and every page is
+// written and flushed before the next one is selected, so an export never holds more than a page in memory.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Formats
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// PerPage is the number of records selected per query
+const PerPage = 500
+
+var contentTypes = map[string]string{
+	FormatCSV:    "text/csv; charset=utf-8",
+	FormatJSON:   "application/json; charset=utf-8",
+	FormatNDJSON: "application/x-ndjson; charset=utf-8",
+}
+
+// Input holds the format parameter of the export endpoints, json is the default
+type Input struct {
+	Format string `json:"format" form:"format" validate:"omitempty,oneof=csv json ndjson"`
+}
+
+// Writer encodes the records of an export
+type Writer interface {
+	// Write encodes a page of records and flushes them
+	Write(rows []filter.Row) error
+	// Close ends the document, e.g. writes the header of an empty CSV or the brackets of an empty JSON array
+	Close() error
+}
+
+// NewWriter returns the writer of format, columns are the keys of the rows and the header of the CSV format
+func NewWriter(w io.Writer, format string, columns []string) Writer {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: w, csv: csv.NewWriter(w), columns: columns}
+	case FormatNDJSON:
+		return &jsonWriter{w: w, lines: true}
+	default:
+		return &jsonWriter{w: w}
+	}
+}
+
+// Response returns a writer setting the content type and attachment headers of the export before the first
+// write, errors returned before any record was written can then still be sent as JSON
+func Response(w http.ResponseWriter, format string, name string) io.Writer {
+	if format == "" {
+		format = FormatJSON
+	}
+	return &response{w: w, format: format, name: name}
+}
+
+// Stream writes every page of a list, list selects the page of query and is called until the last page
+func Stream(w Writer, query *filter.Query, list func() (interface{}, error)) error {
+	query.SkipTotal()
+	for {
+		results, err := list()
+		if err != nil {
+			return err
+		}
+		if err := w.Write(query.Records(results)); err != nil {
+			return err
+		}
+		if !query.Next() {
+			return w.Close()
+		}
+	}
+}
+
+// Value formats a CSV cell, times use RFC 3339 and nil pointers are empty
+func Value(value interface{}) string {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+type csvWriter struct {
+	w       io.Writer
+	csv     *csv.Writer
+	columns []string
+	started bool
+}
+
+func (c *csvWriter) Write(rows []filter.Row) error {
+	if !c.started {
+		c.started = true
+		if err := c.csv.Write(c.columns); err != nil {
+			return err
+		}
+	}
+	record := make([]string, len(c.columns))
+	for _, row := range rows {
+		for i, value := range row {
+			record[i] = Value(value.Value)
+		}
+		if err := c.csv.Write(record); err != nil {
+			return err
+		}
+	}
+	c.csv.Flush()
+	if err := c.csv.Error(); err != nil {
+		return err
+	}
+	flush(c.w)
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	if c.started {
+		return nil
+	}
+	return c.Write(nil)
+}
+
+type jsonWriter struct {
+	w       io.Writer
+	lines   bool
+	started bool
+}
+
+func (j *jsonWriter) Write(rows []filter.Row) error {
+	for _, row := range rows {
+		b, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		switch {
+		case j.lines:
+			b = append(b, '\n')
+		case !j.started:
+			b = append([]byte("["), b...)
+		default:
+			b = append([]byte(","), b...)
+		}
+		j.started = true
+		if _, err := j.w.Write(b); err != nil {
+			return err
+		}
+	}
+	flush(j.w)
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	end := "]\n"
+	switch {
+	case j.lines && !j.started:
+		// An empty NDJSON export still sends the headers
+		end = ""
+	case j.lines:
+		return nil
+	case !j.started:
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	flush(j.w)
+	return err
+}
+
+func flush(w io.Writer) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+type response struct {
+	w       http.ResponseWriter
+	format  string
+	name    string
+	started bool
+}
+
+func (r *response) Write(b []byte) (int, error) {
+	if !r.started {
+		r.started = true
+		r.w.Header().Set("Content-Type", contentTypes[r.format])
+		r.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, r.name, r.format))
+		r.w.WriteHeader(http.StatusOK)
+	}
+	return r.w.Write(b)
+}
+
+func (r *response) Flush() {
+	flush(r.w)
+}
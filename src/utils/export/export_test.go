@@ -0,0 +1,84 @@
+package export
+
+import (
+	"bytes"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWriter(t *testing.T) {
+	goals := int64(2)
+	kickoff := time.Date(2022, 1, 15, 15, 0, 0, 0, time.UTC)
+	rows := []filter.Row{
+		{{Key: "id", Value: int64(1)}, {Key: "kickoff_at", Value: kickoff}, {Key: "home_goals", Value: &goals}},
+		{{Key: "id", Value: int64(2)}, {Key: "kickoff_at", Value: kickoff}, {Key: "home_goals", Value: (*int64)(nil)}},
+	}
+	columns := []string{"id", "kickoff_at", "home_goals"}
+
+	testCases := []struct {
+		title       string
+		format      string
+		pages       [][]filter.Row
+		expectedRes string
+	}{
+		{
+			title:       "csv",
+			format:      FormatCSV,
+			pages:       [][]filter.Row{rows[:1], rows[1:]},
+			expectedRes: "id,kickoff_at,home_goals\n1,2022-01-15T15:00:00Z,2\n2,2022-01-15T15:00:00Z,\n",
+		},
+		{
+			title:       "csv empty",
+			format:      FormatCSV,
+			expectedRes: "id,kickoff_at,home_goals\n",
+		},
+		{
+			title:  "json",
+			format: FormatJSON,
+			pages:  [][]filter.Row{rows[:1], rows[1:]},
+			expectedRes: `[{"id":1,"kickoff_at":"2022-01-15T15:00:00Z","home_goals":2},` +
+				`{"id":2,"kickoff_at":"2022-01-15T15:00:00Z","home_goals":null}]` + "\n",
+		},
+		{
+			title:       "json empty",
+			format:      FormatJSON,
+			expectedRes: "[]\n",
+		},
+		{
+			title:  "ndjson",
+			format: FormatNDJSON,
+			pages:  [][]filter.Row{rows},
+			expectedRes: `{"id":1,"kickoff_at":"2022-01-15T15:00:00Z","home_goals":2}` + "\n" +
+				`{"id":2,"kickoff_at":"2022-01-15T15:00:00Z","home_goals":null}` + "\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, testCase.format, columns)
+			for _, page := range testCase.pages {
+				assert.Nil(t, w.Write(page))
+			}
+			assert.Nil(t, w.Close())
+
+			assert.Equal(t, testCase.expectedRes, buf.String())
+		})
+	}
+}
+
+func TestResponse(t *testing.T) {
+	res := httptest.NewRecorder()
+	w := NewWriter(Response(res, FormatNDJSON, "fixtures"), FormatNDJSON, []string{"id"})
+
+	assert.Nil(t, w.Write([]filter.Row{{{Key: "id", Value: 1}}}))
+	assert.Nil(t, w.Close())
+
+	assert.Equal(t, "application/x-ndjson; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="fixtures.ndjson"`, res.Header().Get("Content-Disposition"))
+	assert.True(t, res.Flushed)
+	assert.Equal(t, "{\"id\":1}\n", res.Body.String())
+}
@@ -0,0 +1,533 @@
+// Package filter parses the filter, sort and field selection query parameters of the list endpoints.
+//
+// The filterable fields are declared on the DTO returned by the endpoint with a filter tag listing the supported
+// operators, the column comes from the db tag and the parameter name from the json tag:
+//
+//	Name string `json:"name" db:"name" filter:"like,eq,ne,in,sort"`
+//
+// The first operator is used when the parameter has no operator, e.g. ?name=eng, the others are selected with
+// ?name[ne]=England. The sort option allows ?sort=-name,id, default or default=desc makes the field the default
+// sort, oneof=a b restricts the values and every field with a db tag can be selected with ?fields=id,name.
+// The records are paginated with the page or the cursor parameters, see Page.
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Operators supported in the filter tag
+const (
+	Eq   = "eq"
+	Ne   = "ne"
+	In   = "in"
+	Gt   = "gt"
+	Gte  = "gte"
+	Lt   = "lt"
+	Lte  = "lte"
+	Like = "like"
+	Null = "null"
+
+	sortOption        = "sort"
+	defaultOption     = "default"
+	defaultDescOption = "default=desc"
+	oneOfOption       = "oneof="
+	// MaxInValues limits the number of values of an in filter
+	MaxInValues = 100
+
+	paramSort    = "sort"
+	paramFields  = "fields"
+	paramOrder   = "order"
+	paramOrderBy = "order_by"
+	paramCursor  = "cursor"
+	paramTotal   = "total"
+
+	// uniqueColumn is added to every sort so the order, and the cursors, are deterministic
+	uniqueColumn = "id"
+)
+
+var orders = []string{"desc", "asc"}
+
+var operatorSQL = map[string]string{
+	Eq:  "=",
+	Ne:  "<>",
+	Gt:  ">",
+	Gte: ">=",
+	Lt:  "<",
+	Lte: "<=",
+}
+
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// Query is the parsed filter of a list request, the zero value does not filter or select anything and sorts
+// the records by the default sort of the model
+type Query struct {
+	spec       *spec
+	conditions []helpers.Condition
+	sorts      []sortKey
+	fields     []*field
+	cursor     *cursor
+	noTotal    bool
+	next       string
+	prev       string
+	following  *cursor
+}
+
+type sortKey struct {
+	field *field
+	desc  bool
+}
+
+// Conditions returns the where conditions, they are added with helpers.NewWhere().Conditions
+func (q *Query) Conditions() []helpers.Condition {
+	return q.conditions
+}
+
+// Total tells if the records have to be counted, ?total=false skips the count
+func (q *Query) Total() bool {
+	return !q.noTotal
+}
+
+// Cursors returns the cursors of the next and previous pages, they are set by Page.Rows
+func (q *Query) Cursors() (next string, prev string) {
+	return q.next, q.prev
+}
+
+// Next moves the query to the page after the one returned by the last Page.Rows call, it returns false when
+// that page was the last one. It is used to read every page of a list, e.g. by the exports
+func (q *Query) Next() bool {
+	if q.following == nil {
+		return false
+	}
+	q.cursor, q.following = q.following, nil
+	return true
+}
+
+// SkipTotal disables the count of the records, like ?total=false
+func (q *Query) SkipTotal() {
+	q.noTotal = true
+}
+
+// Columns returns the names of the selected fields, or of every field of model when the fields parameter was
+// not sent. They are the keys of the rows returned by Records
+func (q *Query) Columns(model interface{}) []string {
+	var columns []string
+	for _, f := range q.recordFields(model) {
+		columns = append(columns, f.param)
+	}
+	return columns
+}
+
+// Records converts a slice of DTOs to rows of the Columns fields, unlike Project the rows are returned even
+// when the fields parameter was not sent
+func (q *Query) Records(data interface{}) []Row {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice {
+		return nil
+	}
+	fields := q.recordFields(data)
+	rows := make([]Row, v.Len())
+	for i := range rows {
+		item := reflect.Indirect(v.Index(i))
+		row := make(Row, len(fields))
+		for j, f := range fields {
+			row[j] = Value{Key: f.param, Value: item.FieldByIndex(f.index).Interface()}
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+func (q *Query) recordFields(model interface{}) []*field {
+	if len(q.fields) > 0 {
+		return q.fields
+	}
+	return specOf(reflect.TypeOf(model)).all
+}
+
+// Project keeps only the selected fields of a slice of DTOs, data is returned unchanged when the fields
+// parameter was not sent. The fields keep the order they are declared in
+func (q *Query) Project(data interface{}) interface{} {
+	if len(q.fields) == 0 {
+		return data
+	}
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice {
+		return data
+	}
+	rows := make([]Row, v.Len())
+	for i := range rows {
+		item := reflect.Indirect(v.Index(i))
+		row := make(Row, len(q.fields))
+		for j, f := range q.fields {
+			row[j] = Value{Key: f.param, Value: item.FieldByIndex(f.index).Interface()}
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// Parse reads the filter, sort and fields parameters declared by the filter tags of model. Parameters that are not
+// declared are ignored so the list DTOs can still bind their own parameters, the invalid ones are returned
+// with the validation errors format
+func Parse(values url.Values, model interface{}) (*Query, map[string][]string) {
+	s := specOf(reflect.TypeOf(model))
+	q := &Query{spec: s}
+	errs := make(map[string][]string)
+
+	// The keys are sorted so the conditions, and the query arguments, always have the same order
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		vals := values[key]
+		name, operator, explicit := splitKey(key)
+		f, ok := s.fields[name]
+		if !ok || f.ops == nil {
+			if explicit {
+				errs[key] = append(errs[key], fmt.Sprintf("The field: '%v' can not be filtered", name))
+			}
+			continue
+		}
+		if !explicit {
+			operator = f.defaultOp
+		}
+		if !f.ops[operator] {
+			errs[key] = append(errs[key], fmt.Sprintf("The field: '%v' must use one of the operators [%v]", name, strings.Join(f.opList, " ")))
+			continue
+		}
+		for _, raw := range vals {
+			value, msg := f.parse(operator, raw)
+			if msg != "" {
+				errs[key] = append(errs[key], msg)
+				continue
+			}
+			q.conditions = append(q.conditions, condition(f.column, operator, value))
+		}
+	}
+
+	if raw := values.Get(paramSort); raw != "" {
+		for _, name := range strings.Split(raw, ",") {
+			name = strings.TrimSpace(name)
+			desc := strings.HasPrefix(name, "-")
+			f, ok := s.fields[strings.TrimPrefix(name, "-")]
+			if !ok || !f.sortable {
+				errs[paramSort] = append(errs[paramSort], oneOf(paramSort, s.sortable))
+				break
+			}
+			q.sorts = append(q.sorts, sortKey{field: f, desc: desc})
+		}
+	}
+
+	// order and order_by are the single field sort supported before the sort parameter
+	order := values.Get(paramOrder)
+	if order != "" && !helpers.ContainsString(orders, order) {
+		errs[paramOrder] = append(errs[paramOrder], oneOf(paramOrder, orders))
+	}
+	if raw := values.Get(paramOrderBy); raw != "" {
+		if f, ok := s.fields[raw]; !ok || !f.sortable {
+			errs[paramOrderBy] = append(errs[paramOrderBy], oneOf(paramOrderBy, s.sortable))
+		} else if len(q.sorts) == 0 {
+			q.sorts = []sortKey{{field: f, desc: order == "desc"}}
+		}
+	}
+	if len(q.sorts) == 0 && order != "" {
+		for _, key := range s.defaultSorts {
+			q.sorts = append(q.sorts, sortKey{field: key.field, desc: order == "desc"})
+		}
+	}
+
+	if raw := values.Get(paramTotal); raw != "" {
+		total, err := strconv.ParseBool(raw)
+		if err != nil {
+			errs[paramTotal] = append(errs[paramTotal], "The total must be true or false")
+		}
+		q.noTotal = !total
+	}
+
+	if raw := values.Get(paramCursor); raw != "" && len(errs) == 0 {
+		c, ok := decodeCursor(raw, s.keyset(q.sorts))
+		if !ok {
+			errs[paramCursor] = append(errs[paramCursor], "The cursor is invalid or was created with another sort")
+		}
+		q.cursor = c
+	}
+
+	if raw := values.Get(paramFields); raw != "" {
+		selected := make(map[string]bool)
+		for _, name := range strings.Split(raw, ",") {
+			name = strings.TrimSpace(name)
+			if _, ok := s.fields[name]; !ok {
+				errs[paramFields] = append(errs[paramFields], oneOf(paramFields, s.selectable))
+				break
+			}
+			selected[name] = true
+		}
+		for _, name := range s.selectable {
+			if selected[name] {
+				q.fields = append(q.fields, s.fields[name])
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return q, nil
+}
+
+// splitKey splits name[operator] parameters, explicit is false for plain name parameters
+func splitKey(key string) (name string, operator string, explicit bool) {
+	open := strings.Index(key, "[")
+	if open <= 0 || !strings.HasSuffix(key, "]") {
+		return key, "", false
+	}
+	return key[:open], key[open+1 : len(key)-1], true
+}
+
+func condition(column string, operator string, value interface{}) helpers.Condition {
+	switch operator {
+	case In:
+		values := value.([]interface{})
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+		return helpers.Condition{Query: fmt.Sprintf("%s IN (%s)", column, placeholders), Args: values}
+	case Like:
+		return helpers.Condition{Query: column + " LIKE ?", Args: []interface{}{"%" + escapeLike(fmt.Sprint(value)) + "%"}}
+	case Null:
+		if value.(bool) {
+			return helpers.Condition{Query: column + " IS NULL"}
+		}
+		return helpers.Condition{Query: column + " IS NOT NULL"}
+	default:
+		return helpers.Condition{Query: fmt.Sprintf("%s %s ?", column, operatorSQL[operator]), Args: []interface{}{value}}
+	}
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+func oneOf(param string, names []string) string {
+	return fmt.Sprintf("The field: '%v' must be one of [%v]", param, strings.Join(names, " "))
+}
+
+// Row is a record reduced to the selected fields, it is encoded as a JSON object keeping the field order
+type Row []Value
+
+// Value is a field of a Row
+type Value struct {
+	Key   string
+	Value interface{}
+}
+
+func (r Row) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, v := range r {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(v.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+type spec struct {
+	fields       map[string]*field
+	sortable     []string
+	selectable   []string
+	defaultSorts []sortKey
+	unique       *field
+	all          []*field
+}
+
+// keyset returns the sorts, or the default ones, followed by the unique column
+func (s *spec) keyset(sorts []sortKey) []sortKey {
+	if len(sorts) == 0 {
+		sorts = s.defaultSorts
+	}
+	keys := append([]sortKey{}, sorts...)
+	if s.unique == nil {
+		return keys
+	}
+	for _, key := range keys {
+		if key.field == s.unique {
+			return keys
+		}
+	}
+	return append(keys, sortKey{field: s.unique})
+}
+
+type field struct {
+	param     string
+	column    string
+	index     []int
+	kind      reflect.Type
+	ops       map[string]bool
+	opList    []string
+	defaultOp string
+	sortable  bool
+	values    []string
+}
+
+var specs sync.Map
+
+func specOf(t reflect.Type) *spec {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if s, ok := specs.Load(t); ok {
+		return s.(*spec)
+	}
+	s := &spec{fields: make(map[string]*field)}
+	collect(s, t, nil)
+	specs.Store(t, s)
+	return s
+}
+
+// collect reads the tags of t, the fields of embedded structs are collected as if they were declared on t
+func collect(s *spec, t reflect.Type, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		idx := append(append([]int{}, index...), i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			collect(s, sf.Type, idx)
+			continue
+		}
+		param := strings.SplitN(sf.Tag.Get("json"), ",", 2)[0]
+		column := sf.Tag.Get("db")
+		if param == "" || param == "-" {
+			continue
+		}
+		if column == "" || column == "-" {
+			s.all = append(s.all, &field{param: param, index: idx, kind: sf.Type})
+			continue
+		}
+		f := &field{param: param, column: column, index: idx, kind: sf.Type}
+		s.all = append(s.all, f)
+		for _, option := range strings.Split(sf.Tag.Get("filter"), ",") {
+			switch option = strings.TrimSpace(option); option {
+			case "":
+			case sortOption:
+				f.sortable = true
+			case defaultOption, defaultDescOption:
+				f.sortable = true
+				s.defaultSorts = append(s.defaultSorts, sortKey{field: f, desc: option == defaultDescOption})
+			default:
+				if strings.HasPrefix(option, oneOfOption) {
+					f.values = strings.Fields(strings.TrimPrefix(option, oneOfOption))
+					continue
+				}
+				if f.ops == nil {
+					f.ops = make(map[string]bool)
+					f.defaultOp = option
+				}
+				f.ops[option] = true
+				f.opList = append(f.opList, option)
+			}
+		}
+		s.fields[param] = f
+		if column == uniqueColumn {
+			s.unique = f
+		}
+		s.selectable = append(s.selectable, param)
+		if f.sortable {
+			s.sortable = append(s.sortable, param)
+		}
+	}
+}
+
+// parse converts the raw value to the type of the field, msg describes why it is invalid
+func (f *field) parse(operator string, raw string) (value interface{}, msg string) {
+	switch operator {
+	case Null:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Sprintf("The %v must be true or false", f.label())
+		}
+		return b, ""
+	case In:
+		parts := strings.Split(raw, ",")
+		if len(parts) > MaxInValues {
+			return nil, fmt.Sprintf("The %v can have at most %d values", f.label(), MaxInValues)
+		}
+		values := make([]interface{}, len(parts))
+		for i, part := range parts {
+			if values[i], msg = f.parseValue(strings.TrimSpace(part)); msg != "" {
+				return nil, msg
+			}
+		}
+		return values, ""
+	case Like:
+		return raw, ""
+	default:
+		return f.parseValue(raw)
+	}
+}
+
+func (f *field) parseValue(raw string) (interface{}, string) {
+	if len(f.values) > 0 && !helpers.ContainsString(f.values, raw) {
+		return nil, oneOf(f.param, f.values)
+	}
+	t := f.kind
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		for _, layout := range timeLayouts {
+			if v, err := time.Parse(layout, raw); err == nil {
+				return v.UTC(), ""
+			}
+		}
+		return nil, fmt.Sprintf("The %v must have the format YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ", f.label())
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Sprintf("The %v must be true or false", f.label())
+		}
+		return v, ""
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Sprintf("The %v must be a valid integer", f.label())
+		}
+		return v, ""
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Sprintf("The %v must be a valid number", f.label())
+		}
+		return v, ""
+	default:
+		return raw, ""
+	}
+}
+
+func (f *field) label() string {
+	return strings.ReplaceAll(f.param, "_", " ")
+}
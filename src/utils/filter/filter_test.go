@@ -0,0 +1,145 @@
+package filter
+
+import (
+	"encoding/json"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testEmbedded struct {
+	CreatedAt time.Time `json:"created_at" db:"created_at" filter:"gt,lte,sort"`
+}
+
+type testOutput struct {
+	ID     int64  `json:"id" db:"id" filter:"eq,ne,in,gt,sort"`
+	Name   string `json:"name" db:"name" filter:"like,eq,sort"`
+	Role   string `json:"role" db:"role" filter:"eq,in,oneof=reader admin"`
+	Active bool   `json:"active" db:"active" filter:"eq"`
+	Goals  *int64 `json:"goals" db:"goals" filter:"null"`
+	Secret string `json:"-" db:"secret"`
+	testEmbedded
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		title              string
+		query              string
+		expectedConditions []helpers.Condition
+		expectedOrder      string
+		expectedSelect     string
+		expectedErr        map[string][]string
+	}{
+		{
+			title:          "success no parameters",
+			query:          "page=2",
+			expectedOrder:  "id ASC",
+			expectedSelect: "*",
+		},
+		{
+			title: "success default operators",
+			query: "id=3&name=a_b&active=false",
+			expectedConditions: []helpers.Condition{
+				{Query: "active = ?", Args: []interface{}{false}},
+				{Query: "id = ?", Args: []interface{}{int64(3)}},
+				{Query: "name LIKE ?", Args: []interface{}{`%a\_b%`}},
+			},
+			expectedOrder:  "id ASC",
+			expectedSelect: "*",
+		},
+		{
+			title: "success explicit operators",
+			query: "id[ne]=3&id[in]=1,2&name[eq]=x&goals[null]=true&created_at[gt]=2022-01-02&role[in]=admin",
+			expectedConditions: []helpers.Condition{
+				{Query: "created_at > ?", Args: []interface{}{time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)}},
+				{Query: "goals IS NULL"},
+				{Query: "id IN (?, ?)", Args: []interface{}{int64(1), int64(2)}},
+				{Query: "id <> ?", Args: []interface{}{int64(3)}},
+				{Query: "name = ?", Args: []interface{}{"x"}},
+				{Query: "role IN (?)", Args: []interface{}{"admin"}},
+			},
+			expectedOrder:  "id ASC",
+			expectedSelect: "*",
+		},
+		{
+			title:          "success legacy order",
+			query:          "order_by=name&order=desc",
+			expectedOrder:  "name DESC, id ASC",
+			expectedSelect: "*",
+		},
+		{
+			title:          "success sort and fields",
+			query:          "sort=-created_at,id&fields=name,id,created_at",
+			expectedOrder:  "created_at DESC, id ASC",
+			expectedSelect: "id, name, created_at",
+		},
+		{
+			title: "error invalid values",
+			query: "id=x&active=maybe&goals[null]=1x&role=owner&created_at[lte]=yesterday",
+			expectedErr: map[string][]string{
+				"id":              {"The id must be a valid integer"},
+				"active":          {"The active must be true or false"},
+				"goals[null]":     {"The goals must be true or false"},
+				"role":            {"The field: 'role' must be one of [reader admin]"},
+				"created_at[lte]": {"The created at must have the format YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ"},
+			},
+		},
+		{
+			title: "error invalid operators and fields",
+			query: "name[gt]=a&secret[eq]=x&sort=role&order=up&order_by=active&fields=id,secret&total=no",
+			expectedErr: map[string][]string{
+				"name[gt]":   {"The field: 'name' must use one of the operators [like eq]"},
+				"secret[eq]": {"The field: 'secret' can not be filtered"},
+				"sort":       {"The field: 'sort' must be one of [id name created_at]"},
+				"order":      {"The field: 'order' must be one of [desc asc]"},
+				"order_by":   {"The field: 'order_by' must be one of [id name created_at]"},
+				"total":      {"The total must be true or false"},
+				"fields":     {"The field: 'fields' must be one of [id name role active goals created_at]"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			values, _ := url.ParseQuery(testCase.query)
+
+			query, err := Parse(values, testOutput{})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedErr != nil {
+				assert.Nil(t, query)
+				return
+			}
+			assert.Equal(t, testCase.expectedConditions, query.Conditions())
+			page := query.Page(1, 20, nil)
+			assert.Equal(t, testCase.expectedOrder, page.OrderBy())
+			assert.Equal(t, testCase.expectedSelect, page.Select("*"))
+		})
+	}
+}
+
+func TestParse_InLimit(t *testing.T) {
+	values := url.Values{"id[in]": {"1" + strings.Repeat(",1", MaxInValues)}}
+
+	query, err := Parse(values, testOutput{})
+
+	assert.Nil(t, query)
+	assert.Equal(t, map[string][]string{"id[in]": {"The id can have at most 100 values"}}, err)
+}
+
+func TestQuery_Project(t *testing.T) {
+	goals := int64(2)
+	data := []testOutput{{ID: 1, Name: "a", Goals: &goals}, {ID: 2, Name: "b"}}
+
+	var empty Query
+	assert.Equal(t, data, empty.Project(data))
+
+	query, _ := Parse(url.Values{"fields": {"goals,id"}}, testOutput{})
+	res, err := json.Marshal(query.Project(data))
+
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"id":1,"goals":2},{"id":2,"goals":null}]`, string(res))
+}
@@ -0,0 +1,240 @@
+package filter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/helpers"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// cursor is the position of a record in the keyset, the records after it are listed, or the ones before it
+// when backward is set. It is sent to the clients as opaque base64 JSON
+type cursor struct {
+	Sort     string   `json:"s"`
+	Values   []string `json:"v"`
+	Backward bool     `json:"b,omitempty"`
+
+	values []interface{}
+}
+
+func (c *cursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor decodes the cursor and converts its values to the types of the keys, ok is false when the cursor
+// is invalid or was created for other keys
+func decodeCursor(raw string, keys []sortKey) (*cursor, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, false
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Sort != signature(keys) || len(c.Values) != len(keys) {
+		return nil, false
+	}
+	for i, key := range keys {
+		value, msg := key.field.parseValue(c.Values[i])
+		if msg != "" {
+			return nil, false
+		}
+		c.values = append(c.values, value)
+	}
+	return &c, true
+}
+
+// signature identifies the keys a cursor was created for, e.g. -kickoff_at,id
+func signature(keys []sortKey) string {
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key.field.param
+		if key.desc {
+			names[i] = "-" + names[i]
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// Page builds the clauses of a page of records. The records are sorted by the keyset, the requested or
+// default sort followed by the unique id column, and one more record than requested is selected to know if
+// there is a next page.
+//
+// Without a cursor the page is selected with LIMIT/OFFSET, with a cursor the records after (or before) the
+// cursor are selected with a where condition on the keyset, which does not need to scan the skipped records
+type Page struct {
+	query   *Query
+	keys    []sortKey
+	perPage int64
+	offset  int64
+}
+
+// Page returns the page of the list request, model is the DTO the records are scanned in and is only used when
+// the query was not parsed, e.g. the services listing records themselves
+func (q *Query) Page(page, perPage int64, model interface{}) *Page {
+	s := q.spec
+	if s == nil {
+		s = specOf(reflect.TypeOf(model))
+	}
+	if page < 1 {
+		page = 1
+	}
+	// The requests are validated but the services listing records themselves are not, any size below one falls
+	// back to the default
+	if perPage < 1 {
+		perPage = constants.DefaultPerPage
+	}
+	p := &Page{query: q, keys: s.keyset(q.sorts), perPage: perPage}
+	if q.cursor == nil {
+		p.offset = (page - 1) * perPage
+	}
+	return p
+}
+
+// Select returns the columns of the fields parameter, or fallback when it was not sent. The keyset columns are
+// always selected since the cursors are built from them
+func (p *Page) Select(fallback string) string {
+	if len(p.query.fields) == 0 {
+		return fallback
+	}
+	var columns []string
+	for _, f := range p.query.fields {
+		columns = append(columns, f.column)
+	}
+	for _, key := range p.keys {
+		if !helpers.ContainsString(columns, key.field.column) {
+			columns = append(columns, key.field.column)
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
+// Where adds the cursor condition to the where clause, the count of the records must use the clause without it
+func (p *Page) Where(where string) string {
+	c := p.condition()
+	if c.Query == "" {
+		return where
+	}
+	if strings.TrimSpace(where) == "" {
+		return "WHERE " + c.Query
+	}
+	return where + " AND " + c.Query
+}
+
+// Args adds the arguments of the cursor condition to the arguments of the where clause
+func (p *Page) Args(args []interface{}) []interface{} {
+	return append(append([]interface{}{}, args...), p.condition().Args...)
+}
+
+// OrderBy returns the ORDER BY expression, backward cursors select the previous records in reverse order
+func (p *Page) OrderBy() string {
+	backward := p.backward()
+	sorts := make([]string, len(p.keys))
+	for i, key := range p.keys {
+		if key.desc != backward {
+			sorts[i] = key.field.column + " DESC"
+		} else {
+			sorts[i] = key.field.column + " ASC"
+		}
+	}
+	return strings.Join(sorts, ", ")
+}
+
+// Limit returns the LIMIT clause, it selects one more record than requested
+func (p *Page) Limit() string {
+	if p.offset > 0 {
+		return fmt.Sprintf("LIMIT %d OFFSET %d", p.perPage+1, p.offset)
+	}
+	return fmt.Sprintf("LIMIT %d", p.perPage+1)
+}
+
+// Rows drops the extra record, restores the order of the records selected backward and sets the cursors of
+// the next and previous pages. results is the slice of DTOs returned by the select, the slice is returned
+func (p *Page) Rows(results interface{}) interface{} {
+	v := reflect.ValueOf(results)
+	more := int64(v.Len()) > p.perPage
+	if more {
+		v = v.Slice(0, int(p.perPage))
+	}
+	backward := p.backward()
+	if backward {
+		reversed := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			reversed.Index(v.Len() - 1 - i).Set(v.Index(i))
+		}
+		v = reversed
+	}
+
+	p.query.next, p.query.prev, p.query.following = "", "", nil
+	if v.Len() == 0 {
+		return v.Interface()
+	}
+	// Forward the previous page exists when records were skipped, backward the next page always exists
+	hasNext := more || backward
+	hasPrev := (backward && more) || (!backward && (p.query.cursor != nil || p.offset > 0))
+	if hasNext {
+		p.query.following = p.cursorAt(v.Index(v.Len()-1), false)
+		p.query.next = p.query.following.encode()
+	}
+	if hasPrev {
+		p.query.prev = p.cursorAt(v.Index(0), true).encode()
+	}
+	return v.Interface()
+}
+
+func (p *Page) backward() bool {
+	return p.query.cursor != nil && p.query.cursor.Backward
+}
+
+func (p *Page) cursorAt(row reflect.Value, backward bool) *cursor {
+	row = reflect.Indirect(row)
+	c := &cursor{Sort: signature(p.keys), Backward: backward}
+	for _, key := range p.keys {
+		value := row.FieldByIndex(key.field.index)
+		c.Values = append(c.Values, formatValue(value))
+		c.values = append(c.values, reflect.Indirect(value).Interface())
+	}
+	return c
+}
+
+// condition selects the records after the cursor: (a > ?) OR (a = ? AND b > ?) OR ..., with < for the
+// descending keys and the comparisons reversed for backward cursors
+func (p *Page) condition() helpers.Condition {
+	c := p.query.cursor
+	if c == nil {
+		return helpers.Condition{}
+	}
+	var ors []string
+	var args []interface{}
+	for i, key := range p.keys {
+		var ands []string
+		for j := 0; j < i; j++ {
+			ands = append(ands, p.keys[j].field.column+" = ?")
+			args = append(args, c.values[j])
+		}
+		operator := ">"
+		if key.desc != c.Backward {
+			operator = "<"
+		}
+		ands = append(ands, fmt.Sprintf("%s %s ?", key.field.column, operator))
+		args = append(args, c.values[i])
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+	return helpers.Condition{Query: "(" + strings.Join(ors, " OR ") + ")", Args: args}
+}
+
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v.Interface())
+}
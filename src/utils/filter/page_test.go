@@ -0,0 +1,146 @@
+package filter
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type testPageOutput struct {
+	ID        int64     `json:"id" db:"id" filter:"eq,sort"`
+	Name      string    `json:"name" db:"name" filter:"like,sort"`
+	KickoffAt time.Time `json:"kickoff_at" db:"kickoff_at" filter:"gt,sort,default=desc"`
+}
+
+func testPageRows(ids ...int64) []testPageOutput {
+	kickoff := time.Date(2022, 1, 10, 15, 0, 0, 0, time.UTC)
+	var rows []testPageOutput
+	for _, id := range ids {
+		rows = append(rows, testPageOutput{ID: id, KickoffAt: kickoff.Add(-time.Duration(id) * time.Hour)})
+	}
+	return rows
+}
+
+func TestPage_Offset(t *testing.T) {
+	query, _ := Parse(url.Values{}, testPageOutput{})
+
+	first := query.Page(1, 2, nil)
+	assert.Equal(t, "kickoff_at DESC, id ASC", first.OrderBy())
+	assert.Equal(t, "LIMIT 3", first.Limit())
+	assert.Equal(t, "WHERE true", first.Where("WHERE true"))
+	assert.Equal(t, []interface{}{1}, first.Args([]interface{}{1}))
+
+	rows := first.Rows(testPageRows(1, 2, 3)).([]testPageOutput)
+	next, prev := query.Cursors()
+	assert.Equal(t, testPageRows(1, 2), rows)
+	assert.NotEmpty(t, next)
+	assert.Empty(t, prev)
+
+	second := query.Page(2, 2, nil)
+	assert.Equal(t, "LIMIT 3 OFFSET 2", second.Limit())
+	rows = second.Rows(testPageRows(3)).([]testPageOutput)
+	next, prev = query.Cursors()
+	assert.Equal(t, testPageRows(3), rows)
+	assert.Empty(t, next)
+	assert.NotEmpty(t, prev)
+}
+
+func TestPage_InvalidSize(t *testing.T) {
+	query, _ := Parse(url.Values{}, testPageOutput{})
+
+	page := query.Page(-1, -1, nil)
+	assert.Equal(t, "LIMIT 21", page.Limit())
+	rows := page.Rows(testPageRows(1, 2)).([]testPageOutput)
+	assert.Equal(t, testPageRows(1, 2), rows)
+}
+
+func TestPage_Cursor(t *testing.T) {
+	query, _ := Parse(url.Values{}, testPageOutput{})
+	query.Page(1, 2, nil).Rows(testPageRows(1, 2, 3))
+	next, _ := query.Cursors()
+
+	// The next page selects the records after the last record of the first page
+	query, errs := Parse(url.Values{"cursor": {next}}, testPageOutput{})
+	assert.Nil(t, errs)
+	page := query.Page(5, 2, nil)
+	assert.Equal(t, "LIMIT 3", page.Limit())
+	assert.Equal(t, "kickoff_at DESC, id ASC", page.OrderBy())
+	assert.Equal(t, "WHERE true AND ((kickoff_at < ?) OR (kickoff_at = ? AND id > ?))", page.Where("WHERE true"))
+	kickoff := testPageRows(2)[0].KickoffAt
+	assert.Equal(t, []interface{}{kickoff, kickoff, int64(2)}, page.Args(nil))
+
+	rows := page.Rows(testPageRows(3, 4)).([]testPageOutput)
+	next, prev := query.Cursors()
+	assert.Equal(t, testPageRows(3, 4), rows)
+	assert.Empty(t, next)
+	assert.NotEmpty(t, prev)
+
+	// The previous page selects the records before the first record in reverse order
+	query, errs = Parse(url.Values{"cursor": {prev}}, testPageOutput{})
+	assert.Nil(t, errs)
+	page = query.Page(1, 2, nil)
+	assert.Equal(t, "kickoff_at ASC, id DESC", page.OrderBy())
+	assert.Equal(t, "WHERE ((kickoff_at > ?) OR (kickoff_at = ? AND id < ?))", page.Where(""))
+
+	rows = page.Rows(testPageRows(2, 1)).([]testPageOutput)
+	next, prev = query.Cursors()
+	assert.Equal(t, testPageRows(1, 2), rows)
+	assert.NotEmpty(t, next)
+	assert.Empty(t, prev)
+}
+
+func TestPage_SelectKeyset(t *testing.T) {
+	query, _ := Parse(url.Values{"fields": {"name"}}, testPageOutput{})
+
+	assert.Equal(t, "name, kickoff_at, id", query.Page(1, 20, nil).Select("*"))
+}
+
+func TestParse_InvalidCursor(t *testing.T) {
+	query, _ := Parse(url.Values{}, testPageOutput{})
+	query.Page(1, 1, nil).Rows(testPageRows(1, 2))
+	next, _ := query.Cursors()
+
+	testCases := []struct {
+		title  string
+		values url.Values
+	}{
+		{title: "error not base64", values: url.Values{"cursor": {"not a cursor!"}}},
+		{title: "error not json", values: url.Values{"cursor": {"bm90IGpzb24"}}},
+		{title: "error other sort", values: url.Values{"cursor": {next}, "sort": {"name"}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			query, errs := Parse(testCase.values, testPageOutput{})
+
+			assert.Nil(t, query)
+			assert.Equal(t, map[string][]string{"cursor": {"The cursor is invalid or was created with another sort"}}, errs)
+		})
+	}
+}
+
+func TestQuery_Next(t *testing.T) {
+	query, _ := Parse(url.Values{}, testPageOutput{})
+	query.Page(1, 2, nil).Rows(testPageRows(1, 2, 3))
+
+	assert.True(t, query.Next())
+	page := query.Page(1, 2, nil)
+	assert.Equal(t, "LIMIT 3", page.Limit())
+	kickoff := testPageRows(2)[0].KickoffAt
+	assert.Equal(t, []interface{}{kickoff, kickoff, int64(2)}, page.Args(nil))
+
+	page.Rows(testPageRows(3))
+	assert.False(t, query.Next())
+}
+
+func TestQuery_Records(t *testing.T) {
+	var query Query
+	assert.Equal(t, []string{"id", "name", "kickoff_at"}, query.Columns(testPageOutput{}))
+	records := query.Records(testPageRows(1))
+	assert.Equal(t, []Row{{{Key: "id", Value: int64(1)}, {Key: "name", Value: ""}, {Key: "kickoff_at", Value: testPageRows(1)[0].KickoffAt}}}, records)
+
+	selected, _ := Parse(url.Values{"fields": {"name"}}, testPageOutput{})
+	assert.Equal(t, []string{"name"}, selected.Columns(testPageOutput{}))
+	assert.Equal(t, []Row{{{Key: "name", Value: ""}}}, selected.Records(testPageRows(1)))
+}
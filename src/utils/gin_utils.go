@@ -2,7 +2,10 @@ package utils
 
 import (
 	"fmt"
+	"github.com/development-raul/footy-predictor/src/utils/auth"
 	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/etag"
+	"github.com/development-raul/footy-predictor/src/utils/filter"
 	"github.com/development-raul/footy-predictor/src/utils/resterror"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -47,8 +50,9 @@ func GinShouldValidate(data interface{}) func(*gin.Context) bool {
 	return func(c *gin.Context) bool {
 		if err := ValidateStruct(data); err != nil {
 			c.JSON(http.StatusBadRequest, resterror.ValidationError{
-				Error: err,
-				Code:  http.StatusBadRequest,
+				Error:     err,
+				ErrorCode: resterror.ValidationFailed,
+				Code:      http.StatusBadRequest,
 			})
 			return false
 		}
@@ -61,6 +65,73 @@ func GinShouldValidate(data interface{}) func(*gin.Context) bool {
 	}
 }
 
+// GinShouldFilter parses the filter, sort and fields query parameters declared by the filter tags of model,
+// see the filter package
+func GinShouldFilter(query *filter.Query, model interface{}) func(*gin.Context) bool {
+	return func(c *gin.Context) bool {
+		parsed, err := filter.Parse(c.Request.URL.Query(), model)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, resterror.ValidationError{
+				Error:     err,
+				ErrorCode: resterror.ValidationFailed,
+				Code:      http.StatusBadRequest,
+			})
+			return false
+		}
+		*query = *parsed
+		return true
+	}
+}
+
+// GinShouldBeUser makes sure the request was authenticated as a registered user and stores its id in userID.
+// Callers authenticated with an API key are rejected since they cannot own competitions or predictions
+func GinShouldBeUser(userID *int64) func(*gin.Context) bool {
+	return func(c *gin.Context) bool {
+		id, ok := auth.UserIDFromSubject(c.GetString(constants.ContextKeyAuthSubject))
+		if !ok {
+			restErr := resterror.NewForbiddenError("USER_ACCOUNT_REQUIRED")
+			c.JSON(restErr.Code(), restErr)
+			return false
+		}
+		*userID = id
+		return true
+	}
+}
+
+// GinShouldMatch requires the If-Match header of a conditional update or delete and stores the version of its
+// ETag in version, etag.Any for the wildcard. The services compare it with the version of the record
+func GinShouldMatch(version *int64) func(*gin.Context) bool {
+	return func(c *gin.Context) bool {
+		header := c.GetHeader("If-Match")
+		if header == "" {
+			restErr := resterror.NewPreconditionRequiredError("IF_MATCH_REQUIRED")
+			c.JSON(restErr.Code(), restErr)
+			return false
+		}
+		v, ok := etag.Parse(header)
+		if !ok {
+			restErr := resterror.NewPreconditionFailedError("INVALID_IF_MATCH")
+			c.JSON(restErr.Code(), restErr)
+			return false
+		}
+		*version = v
+		return true
+	}
+}
+
+// GinShouldBeModified sets the ETag header of a record at version and answers 304 Not Modified, without a body,
+// when the If-None-Match header shows the client already has that version
+func GinShouldBeModified(version int64) func(*gin.Context) bool {
+	return func(c *gin.Context) bool {
+		c.Header("ETag", etag.Format(version))
+		if etag.NoneMatch(c.GetHeader("If-None-Match"), version) {
+			c.AbortWithStatus(http.StatusNotModified)
+			return false
+		}
+		return true
+	}
+}
+
 // ValidateStruct validates provided struct using validator v10
 func ValidateStruct(obj interface{}) map[string][]string {
 	v := validator.New()
@@ -141,6 +212,14 @@ func ValidateStruct(obj interface{}) map[string][]string {
 			errMap[field] = append(errMap[field], fmt.Sprintf("the %v must have a length less than %v", fieldNoUnderscore, e.Param()))
 		case "min":
 			errMap[field] = append(errMap[field], fmt.Sprintf("The %v must have a length of at least %v", fieldNoUnderscore, e.Param()))
+		case "gt":
+			errMap[field] = append(errMap[field], fmt.Sprintf("The %v must be greater than %v", fieldNoUnderscore, e.Param()))
+		case "gte":
+			errMap[field] = append(errMap[field], fmt.Sprintf("The %v must be at least %v", fieldNoUnderscore, e.Param()))
+		case "lt":
+			errMap[field] = append(errMap[field], fmt.Sprintf("The %v must be less than %v", fieldNoUnderscore, e.Param()))
+		case "lte":
+			errMap[field] = append(errMap[field], fmt.Sprintf("The %v must be at most %v", fieldNoUnderscore, e.Param()))
 		case "email":
 			errMap[field] = append(errMap[field], fmt.Sprintf("The %v must be a valid email address.", fieldNoUnderscore))
 		case "oneof":
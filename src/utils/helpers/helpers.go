@@ -47,6 +47,20 @@ func (i *newWhereT) Where(query string, args ...interface{}) *newWhereT {
 	return i
 }
 
+// Condition is a where condition built outside of the where builder, e.g. by the list filters
+type Condition struct {
+	Query string
+	Args  []interface{}
+}
+
+// Conditions adds every condition joined with AND, the same way Where does
+func (i *newWhereT) Conditions(conditions []Condition) *newWhereT {
+	for _, c := range conditions {
+		i.Where(c.Query, c.Args...)
+	}
+	return i
+}
+
 func (i *newWhereT) CustomWhere(query string, args ...interface{}) *newWhereT {
 	i.customFields = append(i.customFields, whereField{
 		query: query,
@@ -105,3 +119,12 @@ func ContainsInt64(haystack []int64, needle int64) bool {
 	}
 	return false
 }
+
+func ContainsString(haystack []string, needle string) bool {
+	for _, v := range haystack {
+		if v == needle {
+			return true
+		}
+	}
+	return false
+}
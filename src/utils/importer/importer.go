@@ -0,0 +1,289 @@
+// Package importer reads CSV or JSON uploads into item structs and upserts them with the bulk package.
+//
+// The CSV header, or the keys of the JSON objects, are matched with the json tags of the item struct, unknown
+// columns are ignored. A value that cannot be converted to its field is reported with the validation errors of
+// its row, the row is then not applied. Every row runs in best_effort mode so the valid rows are saved even when
+// some of them fail.
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/development-raul/footy-predictor/src/utils/bulk"
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// Limits of an upload
+const (
+	MaxRows = 5000
+	MaxSize = 10 << 20
+)
+
+// FileField is the multipart form field of the uploaded file
+const FileField = "file"
+
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// errInvalidRow stops a row that could not be decoded from being applied
+var errInvalidRow = errors.New("invalid row")
+
+// RowError lists the errors of a row, Row is the 1-based position of the row in the upload, not counting the
+// CSV header
+type RowError struct {
+	Row    int                 `json:"row"`
+	Errors map[string][]string `json:"errors"`
+}
+
+// Report is the outcome of an import
+type Report struct {
+	Rows     int        `json:"rows"`
+	Imported int        `json:"imported"`
+	Failed   int        `json:"failed"`
+	Errors   []RowError `json:"errors"`
+}
+
+// StatusCode is 200 when every row was imported and 207 when only some of them were
+func (r *Report) StatusCode() int {
+	if r.Failed > 0 {
+		return http.StatusMultiStatus
+	}
+	return http.StatusOK
+}
+
+// Upload returns the uploaded file, the file field of a multipart form or else the request body, and its format.
+// The format is read from the format query parameter, the file extension or the content type, in that order
+func Upload(req *http.Request) (io.ReadCloser, string, resterror.RestErrorI) {
+	format := req.URL.Query().Get("format")
+	body := req.Body
+	contentType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if contentType == "multipart/form-data" {
+		file, header, err := req.FormFile(FileField)
+		if err != nil {
+			return nil, "", fileError("The file field is required")
+		}
+		body = file
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+		}
+	}
+	if format == "" {
+		switch contentType {
+		case "text/csv":
+			format = FormatCSV
+		case "application/json":
+			format = FormatJSON
+		}
+	}
+	if format != FormatCSV && format != FormatJSON {
+		if body != nil {
+			body.Close()
+		}
+		return nil, "", fileError("The file must be a csv or json file")
+	}
+	if body == nil {
+		return nil, "", fileError("The file field is required")
+	}
+	return body, format, nil
+}
+
+// Decode reads the rows of r into items, a pointer to a slice of structs. The values that could not be converted
+// are returned by row index, the error is a 400 when the file itself is invalid
+func Decode(r io.Reader, format string, items interface{}) (map[int]map[string][]string, resterror.RestErrorI) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, MaxSize+1))
+	if err != nil {
+		return nil, fileError("The file could not be read")
+	}
+	if len(data) > MaxSize {
+		return nil, fileError(fmt.Sprintf("The file must be smaller than %d MB", MaxSize>>20))
+	}
+	if format == FormatCSV {
+		return decodeCSV(data, reflect.ValueOf(items).Elem())
+	}
+	return decodeJSON(data, reflect.ValueOf(items).Elem())
+}
+
+// Run upserts the decoded items with apply, the rows in rowErrs are reported as failed without being applied
+func Run(ctx context.Context, items interface{}, rowErrs map[int]map[string][]string, apply bulk.Apply) (*Report, resterror.RestErrorI) {
+	res, apiErr := bulk.Run(ctx, bulk.ModeBestEffort, bulk.StatusUpserted, items, func(ctx context.Context, i int) (int64, error) {
+		if rowErrs[i] != nil {
+			return 0, errInvalidRow
+		}
+		return apply(ctx, i)
+	})
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	report := &Report{Rows: len(res.Results), Imported: res.Applied, Failed: res.Failed, Errors: []RowError{}}
+	for _, result := range res.Results {
+		errs := result.Errors
+		if rowErrs[result.Index] != nil {
+			errs = rowErrs[result.Index]
+			for key, msgs := range result.Errors {
+				if _, ok := errs[key]; !ok && key != "item" {
+					errs[key] = msgs
+				}
+			}
+		}
+		if len(errs) > 0 {
+			report.Errors = append(report.Errors, RowError{Row: result.Index + 1, Errors: errs})
+		}
+	}
+	return report, nil
+}
+
+func decodeCSV(data []byte, items reflect.Value) (map[int]map[string][]string, resterror.RestErrorI) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fileError("The file must have a header row")
+	}
+	if err != nil {
+		return nil, fileError("The file is not a valid csv file")
+	}
+
+	fields := columns(items.Type().Elem())
+	rowErrs := make(map[int]map[string][]string)
+	for i := 0; ; i++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rowErrs, nil
+		}
+		if err != nil {
+			return nil, fileError(fmt.Sprintf("The row %d is not valid csv", i+1))
+		}
+		if i == MaxRows {
+			return nil, fileError(fmt.Sprintf("The file can have at most %d rows", MaxRows))
+		}
+		item := reflect.New(items.Type().Elem()).Elem()
+		for j, name := range header {
+			index, ok := fields[strings.TrimSpace(name)]
+			if !ok || j >= len(record) {
+				continue
+			}
+			if msg := setValue(item.FieldByIndex(index), strings.TrimSpace(record[j]), name); msg != "" {
+				addError(rowErrs, i, name, msg)
+			}
+		}
+		items.Set(reflect.Append(items, item))
+	}
+}
+
+func decodeJSON(data []byte, items reflect.Value) (map[int]map[string][]string, resterror.RestErrorI) {
+	var rows []json.RawMessage
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fileError("The file must be a json array of objects")
+	}
+	if len(rows) > MaxRows {
+		return nil, fileError(fmt.Sprintf("The file can have at most %d rows", MaxRows))
+	}
+
+	rowErrs := make(map[int]map[string][]string)
+	for i, row := range rows {
+		item := reflect.New(items.Type().Elem())
+		if err := json.Unmarshal(row, item.Interface()); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) && typeErr.Field != "" {
+				addError(rowErrs, i, typeErr.Field, fmt.Sprintf("The %v has an invalid type", label(typeErr.Field)))
+			} else {
+				addError(rowErrs, i, "row", "The row must be a json object")
+			}
+		}
+		items.Set(reflect.Append(items, item.Elem()))
+	}
+	return rowErrs, nil
+}
+
+// columns maps the json names of the fields of t to their index
+func columns(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := strings.SplitN(sf.Tag.Get("json"), ",", 2)[0]
+		if sf.PkgPath != "" || name == "" || name == "-" {
+			continue
+		}
+		fields[name] = sf.Index
+	}
+	return fields
+}
+
+// setValue converts the cell to the type of v, an empty cell keeps the zero value. The returned message
+// describes why the cell is invalid
+func setValue(v reflect.Value, raw string, name string) string {
+	if raw == "" {
+		return ""
+	}
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	if _, ok := v.Interface().(time.Time); ok {
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, raw); err == nil {
+				v.Set(reflect.ValueOf(t.UTC()))
+				return ""
+			}
+		}
+		return fmt.Sprintf("The %v must have the format YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ", label(name))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Sprintf("The %v must be true or false", label(name))
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Sprintf("The %v must be a valid integer", label(name))
+		}
+		v.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Sprintf("The %v must be a valid number", label(name))
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Sprintf("The %v cannot be imported", label(name))
+	}
+	return ""
+}
+
+func addError(rowErrs map[int]map[string][]string, row int, key string, msg string) {
+	if rowErrs[row] == nil {
+		rowErrs[row] = make(map[string][]string)
+	}
+	rowErrs[row][key] = append(rowErrs[row][key], msg)
+}
+
+func label(name string) string {
+	return strings.ReplaceAll(name, "_", " ")
+}
+
+func fileError(msg string) resterror.RestErrorI {
+	return resterror.NewValidationError(map[string][]string{FileField: {msg}})
+}
@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"github.com/development-raul/footy-predictor/src/utils/resterror"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testItem struct {
+	ID        int64     `json:"id" validate:"required"`
+	Name      string    `json:"name"`
+	KickoffAt time.Time `json:"kickoff_at"`
+	Goals     *int64    `json:"goals"`
+}
+
+func TestDecode(t *testing.T) {
+	goals := int64(2)
+	kickoff := time.Date(2022, 1, 15, 15, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		title           string
+		format          string
+		body            string
+		expectedItems   []testItem
+		expectedRowErrs map[int]map[string][]string
+		expectedErr     resterror.RestErrorI
+	}{
+		{
+			title:       "error csv without header",
+			format:      FormatCSV,
+			body:        "",
+			expectedErr: resterror.NewValidationError(map[string][]string{"file": {"The file must have a header row"}}),
+		},
+		{
+			title:       "error json not an array",
+			format:      FormatJSON,
+			body:        `{"id":1}`,
+			expectedErr: resterror.NewValidationError(map[string][]string{"file": {"The file must be a json array of objects"}}),
+		},
+		{
+			title:  "success csv",
+			format: FormatCSV,
+			body:   "id,name,kickoff_at,goals,unknown\n1,Spain,2022-01-15T15:00:00Z,2,x\nx,,2022-01-15,,\n",
+			expectedItems: []testItem{
+				{ID: 1, Name: "Spain", KickoffAt: kickoff, Goals: &goals},
+				{KickoffAt: time.Date(2022, 1, 15, 0, 0, 0, 0, time.UTC)},
+			},
+			expectedRowErrs: map[int]map[string][]string{1: {"id": {"The id must be a valid integer"}}},
+		},
+		{
+			title:  "success json",
+			format: FormatJSON,
+			body:   `[{"id":1,"name":"Spain","kickoff_at":"2022-01-15T15:00:00Z","goals":2},{"id":"x"}]`,
+			expectedItems: []testItem{
+				{ID: 1, Name: "Spain", KickoffAt: kickoff, Goals: &goals},
+				{},
+			},
+			expectedRowErrs: map[int]map[string][]string{1: {"id": {"The id has an invalid type"}}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			var items []testItem
+			rowErrs, apiErr := Decode(strings.NewReader(testCase.body), testCase.format, &items)
+
+			assert.Equal(t, testCase.expectedErr, apiErr)
+			assert.Equal(t, testCase.expectedItems, items)
+			assert.Equal(t, testCase.expectedRowErrs, rowErrs)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Package mergepatch applies JSON Merge Patch documents, see RFC 7396.
+//
+// The members of the patch replace the members of the document, null removes a member and nested objects are
+// merged recursively. Anything else than an object replaces the whole value, arrays included.
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ContentType is the media type of a merge patch request body
+const ContentType = "application/merge-patch+json"
+
+// ErrInvalid is returned when the document or the patch is not valid JSON
+var ErrInvalid = errors.New("invalid merge patch")
+
+// Apply returns doc with patch merged into it
+func Apply(doc, patch []byte) ([]byte, error) {
+	var target, changes interface{}
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, ErrInvalid
+	}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, ErrInvalid
+	}
+	return json.Marshal(merge(target, changes))
+}
+
+func merge(target, patch interface{}) interface{} {
+	changes, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	members, ok := target.(map[string]interface{})
+	if !ok {
+		members = map[string]interface{}{}
+	}
+	for name, value := range changes {
+		if value == nil {
+			delete(members, name)
+			continue
+		}
+		members[name] = merge(members[name], value)
+	}
+	return members
+}
@@ -0,0 +1,39 @@
+package mergepatch
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	// Cases from the appendix of RFC 7396
+	testCases := []struct {
+		title       string
+		doc         string
+		patch       string
+		expectedRes string
+		expectedErr error
+	}{
+		{title: "replace member", doc: `{"a":"b"}`, patch: `{"a":"c"}`, expectedRes: `{"a":"c"}`},
+		{title: "add member", doc: `{"a":"b"}`, patch: `{"b":"c"}`, expectedRes: `{"a":"b","b":"c"}`},
+		{title: "remove member", doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expectedRes: `{"b":"c"}`},
+		{title: "replace array", doc: `{"a":["b"]}`, patch: `{"a":"c"}`, expectedRes: `{"a":"c"}`},
+		{title: "replace with array", doc: `{"a":"c"}`, patch: `{"a":["b"]}`, expectedRes: `{"a":["b"]}`},
+		{title: "nested object", doc: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, expectedRes: `{"a":{"b":"d"}}`},
+		{title: "object into scalar", doc: `{"e":null}`, patch: `{"a":1}`, expectedRes: `{"a":1,"e":null}`},
+		{title: "not an object", doc: `{"a":"foo"}`, patch: `"bar"`, expectedRes: `"bar"`},
+		{title: "empty patch", doc: `{"a":"b"}`, patch: `{}`, expectedRes: `{"a":"b"}`},
+		{title: "invalid patch", doc: `{"a":"b"}`, patch: `{"a":`, expectedErr: ErrInvalid},
+		{title: "invalid doc", doc: ``, patch: `{}`, expectedErr: ErrInvalid},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			res, err := Apply([]byte(testCase.doc), []byte(testCase.patch))
+
+			assert.Equal(t, testCase.expectedErr, err)
+			if testCase.expectedErr == nil {
+				assert.Equal(t, testCase.expectedRes, string(res))
+			}
+		})
+	}
+}
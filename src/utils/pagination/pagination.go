@@ -1,6 +1,7 @@
 package pagination
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"github.com/development-raul/footy-predictor/src/clients/mysql/footy_db"
@@ -9,6 +10,9 @@ import (
 	"reflect"
 )
 
+// TotalNotCounted is the total of the lists whose records were not counted, last_page is -1 as well
+const TotalNotCounted = -1
+
 type PaginatedResponse struct {
 	From        int64       `json:"from"`
 	Data        interface{} `json:"data"`
@@ -17,6 +21,9 @@ type PaginatedResponse struct {
 	PerPage     int64       `json:"per_page"`
 	To          int64       `json:"to"`
 	Total       int64       `json:"total"`
+	// NextCursor and PrevCursor are only set by the lists supporting cursors, when there is such a page
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 func GeneratePaginationQuery(page, perPage int64) string {
@@ -41,7 +48,13 @@ func GeneratePaginatedResponse(data interface{}, page, perPage, total int64) Pag
 	var lastPage int64
 	var from int64
 	var to int64
-	if float64(total) > 0 && reflect.Slice == reflect.TypeOf(data).Kind() {
+	if total == TotalNotCounted && reflect.Slice == reflect.TypeOf(data).Kind() {
+		lastPage = TotalNotCounted
+		if n := int64(reflect.ValueOf(data).Len()); n > 0 {
+			from = (perPage * (page - 1)) + 1
+			to = from + n - 1
+		}
+	} else if float64(total) > 0 && reflect.Slice == reflect.TypeOf(data).Kind() {
 		from = (perPage * (page - 1)) + 1
 		to = from + int64(reflect.ValueOf(data).Len()) - 1
 		lastPage = int64(math.Ceil(float64(total) / float64(perPage)))
@@ -69,9 +82,9 @@ func GeneratePaginationSort(defaultSort string, sortBy string, sortOrder string)
 	return fmt.Sprintf("%s %s", sortBy, sortOrder)
 }
 
-func GetTableTotalRowsArgs(query string, params ...interface{}) (int64, error) {
+func GetTableTotalRowsArgs(ctx context.Context, query string, params ...interface{}) (int64, error) {
 	var total int64
-	err := footy_db.Client.Get(&total, query, params...)
+	err := footy_db.Client.GetContext(ctx, &total, query, params...)
 	if err != nil && err != sql.ErrNoRows {
 		return 0, err
 	}
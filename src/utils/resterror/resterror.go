@@ -3,8 +3,11 @@ package resterror
 import (
 "encoding/json"
 "errors"
+	"fmt"
 	"github.com/development-raul/footy-predictor/src/utils/constants"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
 	"net/http"
+	"strings"
 )
 
 type RestErrorI interface {
@@ -14,14 +17,19 @@ type RestErrorI interface {
 
 type restError struct {
 	Err        interface{} `json:"error"`
+	ErrorCode  string      `json:"error_code,omitempty"`
 	StatusCode int         `json:"code"`
 }
 
 type ValidationError struct {
-	Error interface{} `json:"error"`
-	Code  int         `json:"code"`
+	Error     interface{} `json:"error"`
+	ErrorCode string      `json:"error_code"`
+	Code      int         `json:"code"`
 }
 
+// ValidationFailed is the error_code of the requests whose fields did not pass the validation
+const ValidationFailed = "VALIDATION_FAILED"
+
 func (i *restError) Error() interface{} {
 	return i.Err
 }
@@ -33,6 +41,7 @@ func (i *restError) Code() int {
 func NewConflictError(msg string) RestErrorI {
 	return &restError{
 		Err:        msg,
+		ErrorCode:  errorCode(msg, http.StatusConflict),
 		StatusCode: http.StatusConflict,
 	}
 }
@@ -40,6 +49,7 @@ func NewConflictError(msg string) RestErrorI {
 func NewUnauthorizedError(msg string) RestErrorI {
 	return &restError{
 		Err:        msg,
+		ErrorCode:  errorCode(msg, http.StatusUnauthorized),
 		StatusCode: http.StatusUnauthorized,
 	}
 }
@@ -47,6 +57,7 @@ func NewUnauthorizedError(msg string) RestErrorI {
 func NewBadRequestError(msg string) RestErrorI {
 	return &restError{
 		Err:        msg,
+		ErrorCode:  errorCode(msg, http.StatusBadRequest),
 		StatusCode: http.StatusBadRequest,
 	}
 }
@@ -54,6 +65,7 @@ func NewBadRequestError(msg string) RestErrorI {
 func NewUnprocessableEntityError(msg string) RestErrorI {
 	return &restError{
 		Err:        msg,
+		ErrorCode:  errorCode(msg, http.StatusUnprocessableEntity),
 		StatusCode: http.StatusUnprocessableEntity,
 	}
 }
@@ -61,6 +73,7 @@ func NewUnprocessableEntityError(msg string) RestErrorI {
 func NewNotFoundError(msg string) RestErrorI {
 	return &restError{
 		Err:        msg,
+		ErrorCode:  errorCode(msg, http.StatusNotFound),
 		StatusCode: http.StatusNotFound,
 	}
 }
@@ -68,6 +81,7 @@ func NewNotFoundError(msg string) RestErrorI {
 func NewInternalServerError(msg string) RestErrorI {
 	return &restError{
 		Err:        msg,
+		ErrorCode:  errorCode(msg, http.StatusInternalServerError),
 		StatusCode: http.StatusInternalServerError,
 	}
 }
@@ -75,6 +89,7 @@ func NewInternalServerError(msg string) RestErrorI {
 func NewStandardInternalServerError() RestErrorI {
 	return &restError{
 		Err:        constants.ServerError,
+		ErrorCode:  statusCode(http.StatusInternalServerError),
 		StatusCode: http.StatusInternalServerError,
 	}
 }
@@ -82,17 +97,59 @@ func NewStandardInternalServerError() RestErrorI {
 func NewCustomError(e interface{}, code int) RestErrorI {
 	return &restError{
 		Err:        e,
+		ErrorCode:  statusCode(code),
 		StatusCode: code,
 	}
 }
 
+// NewValidationError reports the fields of a request that did not pass the validation, errs maps each field to its
+// messages
+func NewValidationError(errs interface{}) RestErrorI {
+	return &restError{
+		Err:        errs,
+		ErrorCode:  ValidationFailed,
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
 func NewForbiddenError(msg string) RestErrorI {
 	return &restError{
 		Err:        msg,
+		ErrorCode:  errorCode(msg, http.StatusForbidden),
 		StatusCode: http.StatusForbidden,
 	}
 }
 
+func NewPreconditionFailedError(msg string) RestErrorI {
+	return &restError{
+		Err:        msg,
+		ErrorCode:  errorCode(msg, http.StatusPreconditionFailed),
+		StatusCode: http.StatusPreconditionFailed,
+	}
+}
+
+func NewPreconditionRequiredError(msg string) RestErrorI {
+	return &restError{
+		Err:        msg,
+		ErrorCode:  errorCode(msg, http.StatusPreconditionRequired),
+		StatusCode: http.StatusPreconditionRequired,
+	}
+}
+
+// errorCode is the error_code of a message, the messages passed as constants such as INVALID_COUNTRY_ID are their
+// own code and the other ones get the code of the status
+func errorCode(msg string, status int) string {
+	if msg == "" || strings.TrimLeft(msg, "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_") != "" {
+		return statusCode(status)
+	}
+	return msg
+}
+
+// statusCode is the status text as an error_code, e.g. BAD_REQUEST
+func statusCode(status int) string {
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
+
 func NewRestErrorFromBytes(bytes []byte) (RestErrorI, error) {
 	var apiErr restError
 	if err := json.Unmarshal(bytes, &apiErr); err != nil {
@@ -100,3 +157,32 @@ func NewRestErrorFromBytes(bytes []byte) (RestErrorI, error) {
 	}
 	return &apiErr, nil
 }
+
+// NewDatabaseError maps the typed errors of the DAOs, see the dberror package. resource names the record in the
+// message and prefixes the error_code, e.g. country gives COUNTRY_NOT_FOUND. Other errors are a standard 500
+func NewDatabaseError(err error, resource string) RestErrorI {
+	var status int
+	var suffix, msg string
+	name := strings.ReplaceAll(resource, "_", " ")
+	switch {
+	case errors.Is(err, dberror.ErrNotFound):
+		status, suffix, msg = http.StatusNotFound, "NOT_FOUND", "The %v does not exist"
+	case errors.Is(err, dberror.ErrDuplicate):
+		status, suffix, msg = http.StatusConflict, "ALREADY_EXISTS", "The %v already exists"
+	case errors.Is(err, dberror.ErrReferenced):
+		status, suffix, msg = http.StatusConflict, "IN_USE", "The %v is used by other records"
+	case errors.Is(err, dberror.ErrInvalidReference):
+		status, suffix, msg = http.StatusUnprocessableEntity, "INVALID_REFERENCE", "The %v references a record that does not exist"
+	case errors.Is(err, dberror.ErrInvalid):
+		status, suffix, msg = http.StatusUnprocessableEntity, "INVALID", "The %v has a value that cannot be saved"
+	case errors.Is(err, dberror.ErrVersionMismatch):
+		status, suffix, msg = http.StatusPreconditionFailed, "VERSION_MISMATCH", "The %v was changed by another request, read it again to get its current ETag"
+	default:
+		return NewStandardInternalServerError()
+	}
+	return &restError{
+		Err:        fmt.Sprintf(msg, name),
+		ErrorCode:  strings.ToUpper(resource) + "_" + suffix,
+		StatusCode: status,
+	}
+}
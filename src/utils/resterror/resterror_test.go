@@ -1,37 +1,56 @@
 package resterror
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/development-raul/footy-predictor/src/utils/dberror"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
 func TestRestError_Error(t *testing.T) {
-	jsonTest(t, NewConflictError("test msg"), "test msg", 409)
+	jsonTest(t, NewConflictError("test msg"), "test msg", "CONFLICT", 409)
 
-	jsonTest(t, NewUnauthorizedError("test msg"), "test msg", 401)
+	jsonTest(t, NewUnauthorizedError("test msg"), "test msg", "UNAUTHORIZED", 401)
 
-	jsonTest(t, NewBadRequestError("test msg"), "test msg", 400)
+	jsonTest(t, NewBadRequestError("test msg"), "test msg", "BAD_REQUEST", 400)
 
-	jsonTest(t, NewUnprocessableEntityError("test msg"), "test msg", 422)
+	jsonTest(t, NewBadRequestError("INVALID_COUNTRY_ID"), "INVALID_COUNTRY_ID", "INVALID_COUNTRY_ID", 400)
 
-	jsonTest(t, NewNotFoundError("test msg"), "test msg", 404)
+	jsonTest(t, NewUnprocessableEntityError("test msg"), "test msg", "UNPROCESSABLE_ENTITY", 422)
 
-	jsonTest(t, NewInternalServerError("test msg"), "test msg", 500)
+	jsonTest(t, NewNotFoundError("test msg"), "test msg", "NOT_FOUND", 404)
 
-	jsonTest(t, NewStandardInternalServerError(), "Something went wrong. Please try again later.", 500)
+	jsonTest(t, NewInternalServerError("test msg"), "test msg", "INTERNAL_SERVER_ERROR", 500)
 
-	jsonTest(t, NewCustomError("test msg", 202), "test msg", 202)
+	jsonTest(t, NewStandardInternalServerError(), "Something went wrong. Please try again later.", "INTERNAL_SERVER_ERROR", 500)
 
-	jsonTest(t, NewForbiddenError("test msg"), "test msg", 403)
+	jsonTest(t, NewCustomError("test msg", 202), "test msg", "ACCEPTED", 202)
+
+	jsonTest(t, NewForbiddenError("test msg"), "test msg", "FORBIDDEN", 403)
+
+	jsonTest(t, NewPreconditionFailedError("INVALID_IF_MATCH"), "INVALID_IF_MATCH", "INVALID_IF_MATCH", 412)
+
+	jsonTest(t, NewPreconditionRequiredError("IF_MATCH_REQUIRED"), "IF_MATCH_REQUIRED", "IF_MATCH_REQUIRED", 428)
+}
+
+func TestNewValidationError(t *testing.T) {
+	e := NewValidationError(map[string][]string{"name": {"The name field is required."}})
+	b, err := json.Marshal(e)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"error":{"name":["The name field is required."]},"error_code":"VALIDATION_FAILED","code":400}`, string(b))
+	assert.Equal(t, 400, e.Code())
 }
 
-func jsonTest(t *testing.T, e RestErrorI, msg string, code int) {
+func jsonTest(t *testing.T, e RestErrorI, msg string, errorCode string, code int) {
 	b, err := json.Marshal(e)
 	assert.Nil(t, err)
 
-	assert.Equal(t, fmt.Sprintf(`{"error":"%v","code":%v}`, msg, code), string(b))
+	assert.Equal(t, fmt.Sprintf(`{"error":"%v","error_code":"%v","code":%v}`, msg, errorCode, code), string(b))
 	assert.Equal(t, e.Error(), msg)
 	assert.Equal(t, e.Code(), code)
 }
@@ -48,3 +67,56 @@ func TestNewRestErrorFromBytes(t *testing.T) {
 	}
 	assert.Nil(t, r2)
 }
+
+func TestNewDatabaseError(t *testing.T) {
+	testCases := []struct {
+		title       string
+		err         error
+		expectedRes string
+	}{
+		{
+			title:       "not found",
+			err:         sql.ErrNoRows,
+			expectedRes: `{"error":"The api key does not exist","error_code":"API_KEY_NOT_FOUND","code":404}`,
+		},
+		{
+			title:       "duplicate",
+			err:         dberror.Wrap(&mysql.MySQLError{Number: 1062}),
+			expectedRes: `{"error":"The api key already exists","error_code":"API_KEY_ALREADY_EXISTS","code":409}`,
+		},
+		{
+			title:       "referenced",
+			err:         dberror.Wrap(&mysql.MySQLError{Number: 1451}),
+			expectedRes: `{"error":"The api key is used by other records","error_code":"API_KEY_IN_USE","code":409}`,
+		},
+		{
+			title:       "invalid reference",
+			err:         dberror.Wrap(&mysql.MySQLError{Number: 1452}),
+			expectedRes: `{"error":"The api key references a record that does not exist","error_code":"API_KEY_INVALID_REFERENCE","code":422}`,
+		},
+		{
+			title:       "invalid",
+			err:         dberror.Wrap(&mysql.MySQLError{Number: 1406}),
+			expectedRes: `{"error":"The api key has a value that cannot be saved","error_code":"API_KEY_INVALID","code":422}`,
+		},
+		{
+			title:       "version mismatch",
+			err:         dberror.ErrVersionMismatch,
+			expectedRes: `{"error":"The api key was changed by another request, read it again to get its current ETag","error_code":"API_KEY_VERSION_MISMATCH","code":412}`,
+		},
+		{
+			title:       "other",
+			err:         errors.New("connection refused"),
+			expectedRes: `{"error":"Something went wrong. Please try again later.","error_code":"INTERNAL_SERVER_ERROR","code":500}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			b, err := json.Marshal(NewDatabaseError(testCase.err, "api_key"))
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedRes, string(b))
+		})
+	}
+}
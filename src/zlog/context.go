@@ -0,0 +1,41 @@
+package zlog
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type loggerKey struct{}
+
+// NewContext returns a copy of ctx carrying the logger, used to scope the log fields to a request
+func NewContext(ctx context.Context, logger *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger stored in ctx or the global Logger. A *gin.Context is resolved
+// through its request, that is where the middlewares store the request logger
+func FromContext(ctx context.Context) *zap.SugaredLogger {
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		if ginCtx.Request == nil {
+			return Logger
+		}
+		ctx = ginCtx.Request.Context()
+	}
+	if ctx == nil {
+		return Logger
+	}
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.SugaredLogger); ok {
+		return logger
+	}
+	return Logger
+}
+
+// With adds the fields to the request logger of ctx so every later log line of the request includes them
+func With(ctx *gin.Context, args ...interface{}) {
+	if ctx.Request == nil {
+		return
+	}
+	ctx.Request = ctx.Request.WithContext(NewContext(ctx.Request.Context(), FromContext(ctx).With(args...)))
+}
@@ -1,32 +1,21 @@
 package zlog
 
 import (
-	"go.uber.org/zap"
 	"os"
 	"strings"
+
+	"github.com/development-raul/footy-predictor/src/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var Logger *zap.SugaredLogger
 
 func init() {
-	appEnv := strings.ToLower(os.Getenv("APP_ENV"))
-	logPath := os.Getenv("ERROR_LOG_PATH")
-	if logPath == "" {
-		logPath = "application.log"
-	}
-
-	config := zap.Config{}
-
-	if appEnv == "prod" || appEnv == "production" {
-		config = zap.NewProductionConfig()
-		config.OutputPaths = []string{"stderr", logPath}
-	} else if appEnv == "test" {
-		config = zap.NewDevelopmentConfig()
-		config.OutputPaths = []string{"stderr"}
-	} else {
-		config = zap.NewDevelopmentConfig()
-		config.OutputPaths = []string{"stderr", logPath}
-	}
+	// Log to stderr until the configuration is loaded, this is also the logger used by the tests
+	config := zap.NewDevelopmentConfig()
+	config.OutputPaths = []string{"stderr"}
 
 	standardLogger, err := config.Build()
 	if err != nil {
@@ -35,3 +24,54 @@ func init() {
 
 	Logger = standardLogger.Sugar() // Use sugar logger
 }
+
+// Configure replaces the default logger with one matching the application configuration
+func Configure(cfg *config.Config) error {
+	logger, err := New(cfg)
+	if err != nil {
+		return err
+	}
+
+	Logger = logger
+	return nil
+}
+
+// New builds a logger writing to stderr and, when a log file is configured, to a file rotated by size
+func New(cfg *config.Config) (*zap.SugaredLogger, error) {
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(strings.ToLower(cfg.Log.Level))); err != nil {
+		return nil, err
+	}
+
+	encoderConfig := zap.NewDevelopmentEncoderConfig()
+	if cfg.IsProduction() {
+		encoderConfig = zap.NewProductionEncoderConfig()
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	}
+
+	format := strings.ToLower(cfg.Log.Format)
+	if format == "" {
+		format = "console"
+		if cfg.IsProduction() {
+			format = "json"
+		}
+	}
+	encoder := zapcore.NewConsoleEncoder(encoderConfig)
+	if format == "json" {
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	}
+
+	writers := []zapcore.WriteSyncer{zapcore.Lock(os.Stderr)}
+	if cfg.Log.File != "" {
+		writers = append(writers, zapcore.AddSync(&lumberjack.Logger{
+			Filename:   cfg.Log.File,
+			MaxSize:    cfg.Log.MaxSizeMB,
+			MaxBackups: cfg.Log.MaxBackups,
+			MaxAge:     cfg.Log.MaxAgeDays,
+			Compress:   cfg.Log.Compress,
+		}))
+	}
+
+	core := zapcore.NewCore(encoder, zapcore.NewMultiWriteSyncer(writers...), level)
+	return zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)).Sugar(), nil
+}
@@ -0,0 +1,104 @@
+package zlog
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/development-raul/footy-predictor/src/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNew(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zlog")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating a temp dir", err)
+	}
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		title       string
+		env         string
+		log         config.LogConfig
+		expected    string
+		expectedErr bool
+	}{
+		{
+			title:       "error invalid level",
+			log:         config.LogConfig{Level: "verbose"},
+			expectedErr: true,
+		},
+		{
+			title:    "production defaults to json",
+			env:      "production",
+			log:      config.LogConfig{Level: "info"},
+			expected: `"msg":"message"`,
+		},
+		{
+			title:    "console format",
+			env:      "production",
+			log:      config.LogConfig{Level: "info", Format: "console"},
+			expected: "\tmessage\t",
+		},
+		{
+			title:    "level filters lower entries",
+			env:      "development",
+			log:      config.LogConfig{Level: "error"},
+			expected: "",
+		},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.App.Env = testCase.env
+			cfg.Log = testCase.log
+			cfg.Log.File = filepath.Join(dir, string(rune('a'+i))+".log")
+
+			logger, err := New(cfg)
+			if testCase.expectedErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+
+			logger.Infow("message", "key", "value")
+			_ = logger.Sync()
+
+			content, _ := ioutil.ReadFile(cfg.Log.File)
+			if testCase.expected == "" {
+				assert.Empty(t, string(content))
+				return
+			}
+			assert.True(t, strings.Contains(string(content), testCase.expected), string(content))
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	defaultLogger := Logger
+	Logger = zap.New(core).Sugar()
+	defer func() { Logger = defaultLogger }()
+
+	requestLogger := Logger.With("request_id", "req-1")
+	assert.Equal(t, Logger, FromContext(context.Background()))
+	assert.Equal(t, Logger, FromContext(&gin.Context{}))
+	assert.Equal(t, requestLogger, FromContext(NewContext(context.Background(), requestLogger)))
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	ginCtx := &gin.Context{Request: req}
+	With(ginCtx, "request_id", "req-1")
+	With(ginCtx, "user", "user:7")
+	FromContext(ginCtx).Infow("message")
+
+	assert.Equal(t, map[string]interface{}{"request_id": "req-1", "user": "user:7"}, logs.All()[0].ContextMap())
+}